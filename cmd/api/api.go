@@ -16,7 +16,6 @@ import (
 	"os"
 	"path"
 	"path/filepath"
-	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -24,11 +23,14 @@ import (
 	"github.com/k0sproject/k0s/cmd/internal"
 	mw "github.com/k0sproject/k0s/internal/pkg/middleware"
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/cloudidentity"
 	"github.com/k0sproject/k0s/pkg/config"
 	"github.com/k0sproject/k0s/pkg/constant"
 	"github.com/k0sproject/k0s/pkg/etcd"
 	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/token"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -38,6 +40,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/time/rate"
 )
 
 func NewAPICmd() *cobra.Command {
@@ -103,16 +106,55 @@ func buildServer(k0sVars *config.CfgVars, nodeConfig *v1beta1.ClusterConfig) (fu
 	mux := http.NewServeMux()
 	storage := nodeConfig.Spec.Storage
 
-	if storage.Type == v1beta1.EtcdStorageType && !storage.Etcd.IsExternalClusterUsed() {
+	// One-time join tokens are consumed by the last step of a controller join.
+	joinsEtcd := storage.Type == v1beta1.EtcdStorageType && !storage.Etcd.IsExternalClusterUsed()
+
+	if joinsEtcd {
 		// Only mount the etcd handler if we're running on internal etcd storage
 		// by default the mux will return 404 back which the caller should handle
 		mux.Handle(prefix+"/etcd/members", mw.AllowMethods(http.MethodPost)(
-			authMiddleware(etcdHandler(k0sVars.CertRootDir, k0sVars.EtcdCertDir), secrets, "controller-join")))
+			authMiddleware(trackTokenUsage(etcdHandler(k0sVars.CertRootDir, k0sVars.EtcdCertDir), secrets, true), secrets, token.RoleController)))
 	}
 
 	if storage.IsJoinable() {
 		mux.Handle(prefix+"/ca", mw.AllowMethods(http.MethodGet)(
-			authMiddleware(caHandler(k0sVars.CertRootDir), secrets, "controller-join")))
+			authMiddleware(trackTokenUsage(caHandler(k0sVars.CertRootDir), secrets, !joinsEtcd), secrets, token.RoleController)))
+	}
+
+	// Workers that join with a join code fetch the CA certificate first, so
+	// that they can verify the join API before sending their code to it.
+	mux.Handle(prefix+"/join/ca-certificate", mw.AllowMethods(http.MethodGet)(
+		caCertificateHandler(k0sVars.CertRootDir)))
+	mux.Handle(prefix+"/join/code", mw.AllowMethods(http.MethodGet)(
+		authMiddleware(trackTokenUsage(joinCodeHandler(nodeConfig.Spec.API.APIAddressURL(), k0sVars.CertRootDir, secrets), secrets, false), secrets, token.RoleWorker)))
+
+	if cloudIdentityJoin := nodeConfig.Spec.API.CloudIdentityJoin; cloudIdentityJoin != nil {
+		caCert, err := os.ReadFile(filepath.Join(k0sVars.CertRootDir, "ca.crt"))
+		if err != nil {
+			return nil, err
+		}
+		verifier, err := cloudidentity.NewVerifier(cloudIdentityJoin, caCert)
+		if err != nil {
+			return nil, err
+		}
+		tokens, err := token.NewManagerForClient(client)
+		if err != nil {
+			return nil, err
+		}
+		mux.Handle(prefix+"/join/cloud-identity", mw.AllowMethods(http.MethodPost)(
+			cloudIdentityJoinHandler(verifier, cloudidentity.NewRecords(client), tokens, nodeConfig.Spec.API.APIAddressURL(), k0sVars.CertRootDir)))
+	}
+
+	if joinRequests := nodeConfig.Spec.API.JoinRequests; joinRequests != nil {
+		tokens, err := token.NewManagerForClient(client)
+		if err != nil {
+			return nil, err
+		}
+		mux.Handle(prefix+"/join/requests", mw.AllowMethods(http.MethodPost)(
+			mw.RateLimitBySource(joinRequestRate, joinRequestBurst)(
+				submitJoinRequestHandler(tokens, joinRequests.GetMaxPending(), joinRequests.GetMaxPendingPerSource()))))
+		mux.Handle(prefix+"/join/requests/{id}", mw.AllowMethods(http.MethodGet)(
+			joinRequestStatusHandler(tokens, nodeConfig.Spec.API.APIAddressURL(), k0sVars.CertRootDir)))
 	}
 
 	ipAddr, bindAddressSpecified := nodeConfig.Spec.API.ExtraArgs["bind-address"]
@@ -146,7 +188,7 @@ func etcdHandler(certRootDir, etcdCertDir string) http.Handler {
 			sendError(err, resp)
 			return
 		}
-		logrus.Infof("etcd API, adding new member: %s", etcdReq.PeerAddress)
+		logrus.Infof("etcd API, adding new learner: %s", etcdReq.PeerAddress)
 		err = etcdReq.Validate()
 		if err != nil {
 			sendError(err, resp)
@@ -159,7 +201,10 @@ func etcdHandler(certRootDir, etcdCertDir string) http.Handler {
 			return
 		}
 
-		memberList, err := etcdClient.AddMember(ctx, etcdReq.Node, etcdReq.PeerAddress)
+		// Join as a learner, so that the new member doesn't count towards the
+		// quorum before it has caught up. It's promoted by the existing
+		// controllers afterwards.
+		memberList, err := etcdClient.AddLearner(ctx, etcdReq.Node, etcdReq.PeerAddress)
 		if err != nil {
 			sendError(err, resp)
 			return
@@ -231,6 +276,252 @@ func caHandler(certRootDir string) http.Handler {
 	})
 }
 
+// The time in which instances that joined via their cloud identity need to
+// use their join token.
+const cloudIdentityJoinTokenTTL = 15 * time.Minute
+
+// cloudIdentityJoinHandler issues one-time worker join tokens to cloud
+// instances that present a valid signed identity. Each identity is accepted
+// only once.
+func cloudIdentityJoinHandler(verifier *cloudidentity.Verifier, records *cloudidentity.Records, tokens *token.Manager, joinURL, certRootDir string) http.Handler {
+	unauthorizedErr := errors.New("go away")
+
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var joinReq v1beta1.CloudIdentityJoinRequest
+		if err := json.NewDecoder(io.LimitReader(req.Body, 1<<20)).Decode(&joinReq); err != nil {
+			sendError(err, resp, http.StatusBadRequest)
+			return
+		}
+
+		instance, err := verifier.Verify(req.Context(), &joinReq)
+		if err != nil {
+			logrus.WithError(err).Warn("Rejected cloud identity join from ", req.RemoteAddr)
+			sendError(unauthorizedErr, resp, http.StatusUnauthorized)
+			return
+		}
+		if err := records.Consume(req.Context(), instance); errors.Is(err, cloudidentity.ErrIdentityUsed) {
+			logrus.Warnf("Rejected cloud identity join of %s instance %s from %s: %v", instance.Provider, instance.ID, req.RemoteAddr, err)
+			sendError(unauthorizedErr, resp, http.StatusUnauthorized)
+			return
+		} else if err != nil {
+			sendError(fmt.Errorf("failed to record cloud identity: %w", err), resp)
+			return
+		}
+
+		bootstrapToken, err := tokens.CreateFor(req.Context(), cloudIdentityJoinTokenTTL, token.RoleWorker, true, "cloud-identity:"+instance.String())
+		if err != nil {
+			sendError(err, resp)
+			return
+		}
+		joinToken, err := workerJoinToken(joinURL, certRootDir, bootstrapToken)
+		if err != nil {
+			sendError(err, resp)
+			return
+		}
+		logrus.Infof("Issued join token with ID %s to %s instance %s", bootstrapToken.ID, instance.Provider, instance.ID)
+
+		resp.Header().Set("content-type", "application/json")
+		if err := json.NewEncoder(resp).Encode(v1beta1.CloudIdentityJoinResponse{Token: joinToken}); err != nil {
+			sendError(err, resp)
+			return
+		}
+	})
+}
+
+// The rate at which each source may submit join requests.
+var (
+	joinRequestRate  = rate.Every(30 * time.Second)
+	joinRequestBurst = 3
+)
+
+// submitJoinRequestHandler stores the join requests of nodes, which need to
+// be approved before the nodes get their client certificates.
+func submitJoinRequestHandler(tokens *token.Manager, maxPending, maxPendingPerSource int) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		var joinReq v1beta1.JoinRequest
+		if err := json.NewDecoder(io.LimitReader(req.Body, 1<<16)).Decode(&joinReq); err != nil {
+			sendError(err, resp, http.StatusBadRequest)
+			return
+		}
+		if _, err := token.ParseNodeCSR(joinReq.CSR, joinReq.NodeName); err != nil {
+			sendError(fmt.Errorf("invalid certificate signing request: %w", err), resp, http.StatusBadRequest)
+			return
+		}
+
+		requestedBy, _, err := net.SplitHostPort(req.RemoteAddr)
+		if err != nil {
+			requestedBy = req.RemoteAddr
+		}
+		id, err := tokens.SubmitJoinRequest(req.Context(), joinReq.NodeName, joinReq.CSR, requestedBy, maxPending, maxPendingPerSource)
+		if errors.Is(err, token.ErrTooManyPendingJoinRequests) {
+			sendError(err, resp, http.StatusTooManyRequests)
+			return
+		} else if err != nil {
+			sendError(err, resp)
+			return
+		}
+		logrus.Infof("Received join request %s for node %s from %s", id, joinReq.NodeName, requestedBy)
+
+		resp.Header().Set("content-type", "application/json")
+		if err := json.NewEncoder(resp).Encode(v1beta1.JoinRequestResponse{ID: id}); err != nil {
+			sendError(err, resp)
+			return
+		}
+	})
+}
+
+// joinRequestStatusHandler returns the state of a join request and, once it's
+// been approved, the node's client certificate. The certificate is useless
+// without the node's private key, which never leaves the node.
+func joinRequestStatusHandler(tokens *token.Manager, apiServerURL, certRootDir string) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		secret, err := tokens.GetJoinRequestSecret(req.Context(), req.PathValue("id"))
+		if apierrors.IsNotFound(err) {
+			sendError(errors.New("join request not found"), resp, http.StatusNotFound)
+			return
+		} else if err != nil {
+			sendError(err, resp)
+			return
+		}
+
+		status := v1beta1.JoinRequestStatus{State: secret.Labels[token.JoinRequestStateLabel]}
+		if status.State == token.JoinRequestApproved {
+			caCert, err := os.ReadFile(filepath.Join(certRootDir, "ca.crt"))
+			if err != nil {
+				sendError(err, resp)
+				return
+			}
+			caKey, err := os.ReadFile(filepath.Join(certRootDir, "ca.key"))
+			if err != nil {
+				sendError(err, resp)
+				return
+			}
+			if status.Certificate, err = tokens.IssueJoinRequestCertificate(req.Context(), secret, caCert, caKey); err != nil {
+				sendError(err, resp)
+				return
+			}
+			status.CACertificate, status.APIServerURL = caCert, apiServerURL
+		}
+
+		resp.Header().Set("content-type", "application/json")
+		if err := json.NewEncoder(resp).Encode(status); err != nil {
+			sendError(err, resp)
+			return
+		}
+	})
+}
+
+// caCertificateHandler serves the cluster's CA certificate. It's public, so
+// that it can be fetched without any credentials.
+func caCertificateHandler(certRootDir string) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		caCert, err := os.ReadFile(filepath.Join(certRootDir, "ca.crt"))
+		if err != nil {
+			sendError(err, resp)
+			return
+		}
+
+		resp.Header().Set("content-type", "application/x-pem-file")
+		_, _ = resp.Write(caCert)
+	})
+}
+
+// joinCodeHandler exchanges the join code of an authenticated request, i.e.
+// its worker bootstrap token, for the full worker join token. One-time join
+// codes are exchanged for a new one-time token, so that they can be exchanged
+// only once.
+func joinCodeHandler(joinURL, certRootDir string, secrets clientcorev1.SecretInterface) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		rawToken, _ := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		bootstrapToken, err := bootstraptokenv1.NewBootstrapTokenString(rawToken)
+		if err != nil {
+			sendError(err, resp, http.StatusBadRequest)
+			return
+		}
+
+		secret, err := secrets.Get(req.Context(), tokenutil.BootstrapTokenSecretName(bootstrapToken.ID), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			sendError(errUnauthorized, resp, http.StatusUnauthorized)
+			return
+		} else if err != nil {
+			sendError(err, resp)
+			return
+		}
+		if token.IsOneTime(secret) {
+			bootstrapToken, err = exchangeOneTimeToken(req.Context(), secrets, secret)
+			if errors.Is(err, errOneTimeTokenUsed) {
+				sendError(errUnauthorized, resp, http.StatusUnauthorized)
+				return
+			} else if err != nil {
+				sendError(err, resp)
+				return
+			}
+		}
+
+		joinToken, err := workerJoinToken(joinURL, certRootDir, bootstrapToken)
+		if err != nil {
+			sendError(err, resp)
+			return
+		}
+
+		resp.Header().Set("content-type", "application/json")
+		if err := json.NewEncoder(resp).Encode(v1beta1.JoinCodeResponse{Token: joinToken}); err != nil {
+			sendError(err, resp)
+			return
+		}
+	})
+}
+
+// exchangeOneTimeToken consumes the one-time worker token of the given
+// bootstrap token secret and creates a new one-time token in its place, which
+// expires at the same time, but is only known to the requester.
+func exchangeOneTimeToken(ctx context.Context, secrets clientcorev1.SecretInterface, secret *corev1.Secret) (*bootstraptokenv1.BootstrapTokenString, error) {
+	consumed, err := bootstraptokenv1.BootstrapTokenFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	var ttl time.Duration
+	if consumed.Expires != nil {
+		ttl = time.Until(consumed.Expires.Time)
+	}
+	exchanged, exchangedToken, err := token.RandomBootstrapSecret(token.RoleWorker, ttl)
+	if err != nil {
+		return nil, err
+	}
+	exchanged.Labels = map[string]string{token.OneTimeLabel: "true"}
+	exchanged.Annotations = map[string]string{token.CreatedByAnnotation: secret.Annotations[token.CreatedByAnnotation]}
+
+	if ok, err := token.ConsumeOneTime(ctx, secrets, secret); err != nil {
+		return nil, fmt.Errorf("failed to consume one-time join code: %w", err)
+	} else if !ok {
+		return nil, errOneTimeTokenUsed
+	}
+
+	if _, err := secrets.Create(ctx, exchanged, metav1.CreateOptions{}); err != nil {
+		if err := token.RestoreOneTime(context.WithoutCancel(ctx), secrets, secret); err != nil {
+			logrus.WithError(err).Error("Failed to restore one-time join code with ID ", consumed.Token.ID)
+		}
+		return nil, fmt.Errorf("failed to create one-time join token: %w", err)
+	}
+
+	logrus.Infof("Exchanged one-time join code with ID %s for one-time join token with ID %s", consumed.Token.ID, exchangedToken.ID)
+	return exchangedToken, nil
+}
+
+// workerJoinToken generates the worker join token for the given bootstrap
+// token.
+func workerJoinToken(joinURL, certRootDir string, bootstrapToken *bootstraptokenv1.BootstrapTokenString) (string, error) {
+	caCert, err := os.ReadFile(filepath.Join(certRootDir, "ca.crt"))
+	if err != nil {
+		return "", err
+	}
+	kubeconfig, err := token.GenerateKubeconfig(joinURL, caCert, token.WorkerTokenAuthName, bootstrapToken)
+	if err != nil {
+		return "", err
+	}
+	return token.JoinEncode(bytes.NewReader(kubeconfig))
+}
+
 // The token is in form of xyz.foobar where:
 //   - xyz: the token "ID" in kube api
 //   - foobar: the token itself
@@ -238,7 +529,8 @@ func caHandler(certRootDir string) http.Handler {
 // We need to validate:
 //   - that we find a secret with the ID
 //   - that the token matches whats inside the secret
-func isValidToken(ctx context.Context, secrets clientcorev1.SecretInterface, rawTokenString, usage string) bool {
+//   - that the token has the required role, and no other
+func isValidToken(ctx context.Context, secrets clientcorev1.SecretInterface, rawTokenString, role string) bool {
 	tokenString, err := bootstraptokenv1.NewBootstrapTokenString(rawTokenString)
 	if err != nil {
 		return false
@@ -253,39 +545,120 @@ func isValidToken(ctx context.Context, secrets clientcorev1.SecretInterface, raw
 		return false
 	}
 
-	token, err := bootstraptokenv1.BootstrapTokenFromSecret(secret)
+	bootstrapToken, err := bootstraptokenv1.BootstrapTokenFromSecret(secret)
 	if err != nil {
 		logrus.WithError(err).Errorf("Bootstrap token with ID %s is malformed", tokenString.ID)
 		return false
 	}
 
-	if token.Expires != nil && !time.Now().Before(token.Expires.Time) {
+	if bootstrapToken.Expires != nil && !time.Now().Before(bootstrapToken.Expires.Time) {
 		return false
 	}
 
-	if *token.Token != *tokenString {
+	if *bootstrapToken.Token != *tokenString {
 		return false
 	}
 
-	switch {
-	case slices.Contains(token.Usages, usage):
-		return true // usage found
-	case bytes.Equal(secret.Data["usage-"+usage], []byte("true")):
-		return true // usage found in its legacy form
+	switch tokenRole := token.RoleOf(secret); tokenRole {
+	case role:
+		return true
+	case "":
+		logrus.Warnf("Rejected join token with ID %s, it has no unambiguous role", tokenString.ID)
+		return false
 	default:
-		return false // usage not found
+		logrus.Warnf("Rejected %s join token with ID %s, a %s token is required", tokenRole, tokenString.ID, role)
+		return false
 	}
 }
 
-func authMiddleware(next http.Handler, secrets clientcorev1.SecretInterface, usage string) http.Handler {
-	unauthorizedErr := errors.New("go away")
+var (
+	errUnauthorized     = errors.New("go away")
+	errOneTimeTokenUsed = errors.New("one-time join token has been used already")
+)
 
+func authMiddleware(next http.Handler, secrets clientcorev1.SecretInterface, role string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
-		if ok && isValidToken(r.Context(), secrets, token, usage) {
+		if ok && isValidToken(r.Context(), secrets, token, role) {
 			next.ServeHTTP(w, r)
 		} else {
-			sendError(unauthorizedErr, w, http.StatusUnauthorized)
+			sendError(errUnauthorized, w, http.StatusUnauthorized)
+		}
+	})
+}
+
+// trackTokenUsage records the usage of the request's join token if the request
+// succeeded. If the request is the last step of a join, one-time tokens are
+// consumed atomically before the request is served instead, so that they can't
+// be used concurrently, and restored if the request fails.
+func trackTokenUsage(next http.Handler, secrets clientcorev1.SecretInterface, lastJoinStep bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawToken, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		tokenString, err := bootstraptokenv1.NewBootstrapTokenString(rawToken)
+		if err != nil {
+			sendError(errUnauthorized, w, http.StatusUnauthorized)
+			return
+		}
+		secretName := tokenutil.BootstrapTokenSecretName(tokenString.ID)
+
+		var consumed *corev1.Secret
+		if lastJoinStep {
+			secret, err := secrets.Get(r.Context(), secretName, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				sendError(errUnauthorized, w, http.StatusUnauthorized)
+				return
+			} else if err != nil {
+				sendError(err, w)
+				return
+			}
+			if token.IsOneTime(secret) {
+				if ok, err := token.ConsumeOneTime(r.Context(), secrets, secret); err != nil {
+					sendError(fmt.Errorf("failed to consume one-time join token: %w", err), w)
+					return
+				} else if !ok {
+					logrus.Warnf("Rejected one-time join token with ID %s, it has been used already", tokenString.ID)
+					sendError(errUnauthorized, w, http.StatusUnauthorized)
+					return
+				}
+				consumed = secret
+			}
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		if consumed != nil && recorder.status != http.StatusOK {
+			if err := token.RestoreOneTime(context.WithoutCancel(r.Context()), secrets, consumed); err != nil {
+				logrus.WithError(err).Error("Failed to restore one-time join token with ID ", tokenString.ID)
+			}
+			return
+		}
+		if recorder.status != http.StatusOK {
+			return
+		}
+
+		usedBy, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			usedBy = r.RemoteAddr
+		}
+		logrus.Infof("Join token with ID %s used by %s", tokenString.ID, usedBy)
+
+		if consumed != nil {
+			logrus.Info("Invalidated one-time join token with ID ", tokenString.ID)
+			return
+		}
+		if err := token.RecordUsage(r.Context(), secrets, secretName, usedBy, time.Now()); err != nil && !apierrors.IsNotFound(err) {
+			logrus.WithError(err).Error("Failed to record usage of join token with ID ", tokenString.ID)
 		}
 	})
 }
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package apiserver
+
+import (
+	"github.com/k0sproject/k0s/cmd/internal"
+	"github.com/k0sproject/k0s/pkg/config"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func NewAPIServerCmd() *cobra.Command {
+	var debugFlags internal.DebugFlags
+
+	cmd := &cobra.Command{
+		Use:              "apiserver",
+		Short:            "Manage the Kubernetes API server",
+		Args:             cobra.NoArgs,
+		PersistentPreRun: debugFlags.Run,
+		RunE:             func(*cobra.Command, []string) error { return pflag.ErrHelp }, // Enforce arg validation
+	}
+
+	pflags := cmd.PersistentFlags()
+	debugFlags.AddToFlagSet(pflags)
+	pflags.AddFlagSet(config.GetPersistentFlagSet())
+
+	cmd.AddCommand(rotateEncryptionKeyCmd())
+
+	return cmd
+}
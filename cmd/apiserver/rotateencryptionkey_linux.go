@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/pkg/file"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/encryptionconfig"
+	"github.com/k0sproject/k0s/pkg/kubernetes"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	apiserverv1 "k8s.io/apiserver/pkg/apis/apiserver/v1"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var rotationPhases = []string{"add", "promote", "rewrite", "prune"}
+
+func rotateEncryptionKeyCmd() *cobra.Command {
+	var (
+		phase     string
+		resources []string
+		timeout   time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rotate-encryption-key",
+		Short: "Rotate the key used to encrypt resources at rest",
+		Long: `Rotate the key used to encrypt resources at rest.
+
+Rotates the keys of the encryption configuration that's referenced by the
+encryption-provider-config argument in spec.api.extraArgs. The API server needs
+to be configured with encryption-provider-config-automatic-reload set to "true"
+so that it picks up the changes to the configuration without a restart.
+
+The rotation consists of four phases:
+
+  add      Add a new key that's used for decryption only.
+  promote  Make the new key the one that's used for encryption.
+  rewrite  Rewrite all encrypted resources, so that they're encrypted with the
+           new key.
+  prune    Remove all keys but the new one.
+
+By default, all phases are run one after another. In clusters with multiple
+controllers, run the add phase on a single controller and copy the encryption
+configuration to all other controllers. Then run the promote and prune phases on
+every controller before proceeding with the next phase, and the rewrite phase
+on a single controller.`,
+		Example: `  k0s apiserver rotate-encryption-key
+  k0s apiserver rotate-encryption-key --phase add`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var phases []string
+			switch {
+			case phase == "all":
+				phases = rotationPhases
+			case slices.Contains(rotationPhases, phase):
+				phases = []string{phase}
+			default:
+				return fmt.Errorf("unsupported phase %q, must be one of all, %s", phase, strings.Join(rotationPhases, ", "))
+			}
+
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+			nodeConfig, err := opts.K0sVars.NodeConfig()
+			if err != nil {
+				return err
+			}
+
+			configPath := nodeConfig.Spec.API.ExtraArgs["encryption-provider-config"]
+			if configPath == "" {
+				return errors.New("encryption at rest isn't configured: spec.api.extraArgs has no encryption-provider-config")
+			}
+			if phases[0] != "rewrite" && nodeConfig.Spec.API.ExtraArgs["encryption-provider-config-automatic-reload"] != "true" {
+				return errors.New(`the API server doesn't reload the encryption configuration: set encryption-provider-config-automatic-reload to "true" in spec.api.extraArgs`)
+			}
+
+			restConfig, err := kubernetes.ClientConfig(kubernetes.KubeconfigFromFile(opts.K0sVars.AdminKubeConfigPath))
+			if err != nil {
+				return err
+			}
+
+			r := rotation{
+				configPath: configPath,
+				restConfig: restConfig,
+				timeout:    timeout,
+				resources:  resources,
+			}
+			for _, phase := range phases {
+				if err := r.run(cmd.Context(), phase); err != nil {
+					return fmt.Errorf("%s phase failed: %w", phase, err)
+				}
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&phase, "phase", "all", "the phase to run (all, "+strings.Join(rotationPhases, ", ")+")")
+	flags.StringSliceVar(&resources, "resources", nil, "the resources to rewrite (default: the encrypted resources of the encryption configuration)")
+	flags.DurationVar(&timeout, "timeout", 5*time.Minute, "the time to wait for the API server to reload the encryption configuration")
+
+	return cmd
+}
+
+type rotation struct {
+	configPath string
+	restConfig *rest.Config
+	timeout    time.Duration
+	resources  []string
+}
+
+func (r *rotation) run(ctx context.Context, phase string) error {
+	if phase == "rewrite" {
+		return r.rewrite(ctx)
+	}
+
+	data, err := os.ReadFile(r.configPath)
+	if err != nil {
+		return err
+	}
+	encryptionConfig, err := encryptionconfig.Unmarshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", r.configPath, err)
+	}
+
+	switch phase {
+	case "add":
+		name := "key-" + time.Now().UTC().Format("20060102150405")
+		if err := encryptionconfig.AddKey(encryptionConfig, name); err != nil {
+			return err
+		}
+		logrus.Infof("Adding encryption key %s", name)
+	case "promote":
+		promoted := encryptionconfig.PromoteLatestKeys(encryptionConfig)
+		if len(promoted) == 0 {
+			return errors.New("no key to promote, run the add phase first")
+		}
+		logrus.Infof("Promoting encryption keys %s", strings.Join(promoted, ", "))
+	case "prune":
+		removed := encryptionconfig.PruneKeys(encryptionConfig)
+		if len(removed) == 0 {
+			logrus.Info("No encryption keys to prune")
+			return nil
+		}
+		logrus.Infof("Pruning encryption keys %s", strings.Join(removed, ", "))
+	}
+
+	return r.writeAndWaitForReload(ctx, encryptionConfig)
+}
+
+// writeAndWaitForReload writes the encryption configuration and waits until
+// the API server has reloaded it.
+func (r *rotation) writeAndWaitForReload(ctx context.Context, encryptionConfig *apiserverv1.EncryptionConfiguration) error {
+	client, err := kubeclientset.NewForConfig(r.restConfig)
+	if err != nil {
+		return err
+	}
+	reloadTimestamps := func(ctx context.Context) (float64, float64, error) {
+		metrics, err := client.CoreV1().RESTClient().Get().AbsPath("/metrics").DoRaw(ctx)
+		if err != nil {
+			return 0, 0, err
+		}
+		return encryptionconfig.ReloadTimestamps(metrics)
+	}
+
+	lastSuccess, lastFailure, err := reloadTimestamps(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get API server metrics: %w", err)
+	}
+
+	data, err := encryptionconfig.Marshal(encryptionConfig)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(r.configPath)
+	if err != nil {
+		return err
+	}
+	writer := file.AtomicWithTarget(r.configPath).WithPermissions(info.Mode())
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		writer = writer.WithOwner(int(stat.Uid)).WithGroup(int(stat.Gid))
+	}
+	if err := writer.Write(data); err != nil {
+		return err
+	}
+
+	logrus.Info("Waiting for the API server to reload the encryption configuration")
+	return wait.PollUntilContextTimeout(ctx, 2*time.Second, r.timeout, false, func(ctx context.Context) (bool, error) {
+		success, failure, err := reloadTimestamps(ctx)
+		switch {
+		case err != nil:
+			logrus.WithError(err).Debug("Failed to get API server metrics")
+			return false, nil
+		case failure > lastFailure:
+			return false, errors.New("the API server failed to reload the encryption configuration, check its logs")
+		default:
+			return success > lastSuccess, nil
+		}
+	})
+}
+
+// rewrite updates all the encrypted resources without changing them, so that
+// the API server stores them encrypted with the current key.
+func (r *rotation) rewrite(ctx context.Context) error {
+	resources := r.resources
+	if len(resources) == 0 {
+		data, err := os.ReadFile(r.configPath)
+		if err != nil {
+			return err
+		}
+		encryptionConfig, err := encryptionconfig.Unmarshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", r.configPath, err)
+		}
+		resources = encryptionconfig.EncryptedResources(encryptionConfig)
+	}
+
+	client, err := kubeclientset.NewForConfig(r.restConfig)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := dynamic.NewForConfig(r.restConfig)
+	if err != nil {
+		return err
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(client.Discovery()))
+
+	for _, resource := range resources {
+		if strings.Contains(resource, "*") {
+			return fmt.Errorf("can't rewrite wildcard resource %q, use --resources to list the resources to rewrite", resource)
+		}
+		gvr, err := mapper.ResourceFor(schema.ParseGroupResource(resource).WithVersion(""))
+		if err != nil {
+			return err
+		}
+		if err := rewriteResource(ctx, dynamicClient.Resource(gvr), resource); err != nil {
+			return fmt.Errorf("failed to rewrite %s: %w", resource, err)
+		}
+	}
+
+	return nil
+}
+
+func rewriteResource(ctx context.Context, client dynamic.NamespaceableResourceInterface, resource string) error {
+	var rewritten int
+	opts := metav1.ListOptions{Limit: 250}
+	for {
+		list, err := client.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		for i := range list.Items {
+			item := &list.Items[i]
+			_, err := client.Namespace(item.GetNamespace()).Update(ctx, item, metav1.UpdateOptions{})
+			// Objects that have been changed or deleted in the meantime don't
+			// need to be rewritten anymore.
+			if err != nil && !apierrors.IsConflict(err) && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("%s/%s: %w", item.GetNamespace(), item.GetName(), err)
+			}
+			rewritten++
+		}
+		logrus.Infof("Rewrote %d %s", rewritten, resource)
+
+		if opts.Continue = list.GetContinue(); opts.Continue == "" {
+			return nil
+		}
+	}
+}
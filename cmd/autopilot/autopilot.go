@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package autopilot
+
+import (
+	"github.com/k0sproject/k0s/cmd/internal"
+	"github.com/k0sproject/k0s/pkg/config"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func NewAutopilotCmd() *cobra.Command {
+	var debugFlags internal.DebugFlags
+
+	cmd := &cobra.Command{
+		Use:              "autopilot",
+		Short:            "Inspect autopilot updates",
+		Args:             cobra.NoArgs,
+		PersistentPreRun: debugFlags.Run,
+		RunE:             func(*cobra.Command, []string) error { return pflag.ErrHelp }, // Enforce arg validation
+	}
+
+	pflags := cmd.PersistentFlags()
+	debugFlags.AddToFlagSet(pflags)
+	pflags.AddFlagSet(config.GetPersistentFlagSet())
+
+	cmd.AddCommand(newStatusCmd())
+
+	return cmd
+}
@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package autopilot
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
+	appku "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/cmdprovider/k0supdate/utils"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+	apclient "github.com/k0sproject/k0s/pkg/client/clientset"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/kubernetes"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/spf13/cobra"
+)
+
+func newStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Display the status of the autopilot plan",
+		Long: `Display the status of the autopilot plan, including the state of every
+targeted node, an estimated completion time and the update configuration.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+
+			restConfig, err := kubernetes.ClientConfig(kubernetes.KubeconfigFromFile(opts.K0sVars.AdminKubeConfigPath))
+			if err != nil {
+				return err
+			}
+			client, err := apclient.NewForConfig(restConfig)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			plan, err := client.AutopilotV1beta2().Plans().Get(ctx, apconst.AutopilotName, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				plan = nil
+			} else if err != nil {
+				return fmt.Errorf("failed to get plan: %w", err)
+			}
+
+			updateConfigs, err := client.AutopilotV1beta2().UpdateConfigs().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to list update configs: %w", err)
+			}
+
+			return printStatus(cmd.OutOrStdout(), plan, updateConfigs.Items, time.Now())
+		},
+	}
+}
+
+func printStatus(out io.Writer, plan *apv1beta2.Plan, updateConfigs []apv1beta2.UpdateConfig, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	for _, uc := range updateConfigs {
+		fmt.Fprintf(w, "Update config:\t%s (channel %s, server %s, strategy %s)\n",
+			uc.Name, uc.Spec.Channel, uc.Spec.UpdateServer, valueOr(uc.Spec.UpgradeStrategy.Type, apv1beta2.UpdateStrategyTypePeriodic))
+	}
+
+	if plan == nil {
+		fmt.Fprintln(w, "Plan:\tnone")
+		return w.Flush()
+	}
+
+	fmt.Fprintf(w, "Plan:\t%s\n", plan.Spec.ID)
+	state := valueOr(plan.Status.State.String(), "Pending")
+	if appc.IsPlanPaused(plan) {
+		state += " (paused)"
+	}
+	fmt.Fprintf(w, "State:\t%s\n", state)
+	fmt.Fprintf(w, "Created:\t%s (%s ago)\n", plan.CreationTimestamp.UTC().Format(time.RFC3339), duration.HumanDuration(now.Sub(plan.CreationTimestamp.Time)))
+	if eta, ok := estimateCompletion(plan); ok {
+		fmt.Fprintf(w, "ETA:\t%s (in %s)\n", eta.UTC().Format(time.RFC3339), duration.HumanDuration(max(eta.Sub(now), 0)))
+	}
+
+	for _, command := range plan.Status.Commands {
+		if command.Description != "" {
+			fmt.Fprintf(w, "Last error:\tcommand %d: %s\n", command.ID, command.Description)
+		}
+	}
+	for _, gate := range plan.Status.HealthGates {
+		fmt.Fprintf(w, "Failing health gate:\t%s since %s: %s\n", gate.Name, gate.FailingSince.UTC().Format(time.RFC3339), gate.Message)
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "COMMAND\tTARGET\tNODE\tSTATE\tFAILURES\tLAST UPDATED")
+	for _, command := range plan.Status.Commands {
+		for _, group := range commandTargets(command) {
+			for _, target := range group.targets {
+				lastUpdated := "-"
+				if !target.LastUpdatedTimestamp.IsZero() {
+					lastUpdated = target.LastUpdatedTimestamp.UTC().Format(time.RFC3339)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n", group.command, group.label, target.Name, target.State, target.Failures, lastUpdated)
+			}
+		}
+	}
+
+	return w.Flush()
+}
+
+type targetGroup struct {
+	command, label string
+	targets        []apv1beta2.PlanCommandTargetStatus
+}
+
+// commandTargets returns the target statuses of a plan command, grouped by
+// their kind.
+func commandTargets(command apv1beta2.PlanCommandStatus) []targetGroup {
+	var groups []targetGroup
+	if command.K0sUpdate != nil {
+		groups = append(groups,
+			targetGroup{"k0supdate", "controller", command.K0sUpdate.Controllers},
+			targetGroup{"k0supdate", "worker", command.K0sUpdate.Workers},
+		)
+	}
+	if command.AirgapUpdate != nil {
+		groups = append(groups, targetGroup{"airgapupdate", "worker", command.AirgapUpdate.Workers})
+	}
+	if command.RuntimeUpdate != nil {
+		groups = append(groups, targetGroup{"runtimeupdate", "worker", command.RuntimeUpdate.Workers})
+	}
+	if command.HelmUpdate != nil {
+		groups = append(groups, targetGroup{"helmupdate", "chart", command.HelmUpdate.Targets()})
+	}
+	return groups
+}
+
+// estimateCompletion extrapolates the time at which all targets of the plan
+// will be done, based on the rate at which targets have been completed since
+// the plan was created. No estimate can be given before the first target is
+// done, or after all of them are.
+func estimateCompletion(plan *apv1beta2.Plan) (time.Time, bool) {
+	var total, done int
+	var lastDone time.Time
+	for _, command := range plan.Status.Commands {
+		for _, group := range commandTargets(command) {
+			for _, target := range group.targets {
+				total++
+				if appku.IsDone(target) {
+					done++
+					if target.LastUpdatedTimestamp.After(lastDone) {
+						lastDone = target.LastUpdatedTimestamp.Time
+					}
+				}
+			}
+		}
+	}
+
+	if done == 0 || done == total || lastDone.Before(plan.CreationTimestamp.Time) {
+		return time.Time{}, false
+	}
+
+	perTarget := lastDone.Sub(plan.CreationTimestamp.Time) / time.Duration(done)
+	return lastDone.Add(perTarget * time.Duration(total-done)), true
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
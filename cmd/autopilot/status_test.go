@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package autopilot
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPrintStatus(t *testing.T) {
+	created := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	now := created.Add(30 * time.Minute)
+
+	target := func(name string, state apv1beta2.PlanCommandTargetStateType, updated time.Duration) apv1beta2.PlanCommandTargetStatus {
+		return apv1beta2.PlanCommandTargetStatus{
+			Name:                 name,
+			State:                state,
+			LastUpdatedTimestamp: metav1.NewTime(created.Add(updated)),
+		}
+	}
+
+	updateConfigs := []apv1beta2.UpdateConfig{{
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Spec: apv1beta2.UpdateSpec{
+			Channel:      "stable",
+			UpdateServer: "https://updates.k0sproject.io",
+		},
+	}}
+
+	t.Run("NoPlan", func(t *testing.T) {
+		var out strings.Builder
+		require.NoError(t, printStatus(&out, nil, updateConfigs, now))
+		assert.Equal(t, ""+
+			"Update config:  example (channel stable, server https://updates.k0sproject.io, strategy periodic)\n"+
+			"Plan:           none\n",
+			out.String())
+	})
+
+	t.Run("InProgress", func(t *testing.T) {
+		plan := &apv1beta2.Plan{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              apconst.AutopilotName,
+				CreationTimestamp: metav1.NewTime(created),
+				Annotations:       map[string]string{apconst.PlanPausedAnnotation: "true"},
+			},
+			Spec: apv1beta2.PlanSpec{ID: "id123"},
+			Status: apv1beta2.PlanStatus{
+				State: appc.PlanSchedulableWait,
+				Commands: []apv1beta2.PlanCommandStatus{{
+					ID:    0,
+					State: appc.PlanSchedulableWait,
+					K0sUpdate: &apv1beta2.PlanCommandK0sUpdateStatus{
+						Controllers: []apv1beta2.PlanCommandTargetStatus{
+							target("controller0", appc.SignalCompleted, 10*time.Minute),
+						},
+						Workers: []apv1beta2.PlanCommandTargetStatus{
+							target("worker0", appc.SignalCompleted, 20*time.Minute),
+							target("worker1", appc.SignalSent, 25*time.Minute),
+							{Name: "worker2", State: appc.SignalPending},
+						},
+					},
+				}},
+			},
+		}
+
+		var out strings.Builder
+		require.NoError(t, printStatus(&out, plan, nil, now))
+		assert.Equal(t, ""+
+			"Plan:     id123\n"+
+			"State:    SchedulableWait (paused)\n"+
+			"Created:  2026-01-01T10:00:00Z (30m ago)\n"+
+			"ETA:      2026-01-01T10:40:00Z (in 10m)\n"+
+			"\n"+
+			"COMMAND     TARGET       NODE          STATE             FAILURES   LAST UPDATED\n"+
+			"k0supdate   controller   controller0   SignalCompleted   0          2026-01-01T10:10:00Z\n"+
+			"k0supdate   worker       worker0       SignalCompleted   0          2026-01-01T10:20:00Z\n"+
+			"k0supdate   worker       worker1       SignalSent        0          2026-01-01T10:25:00Z\n"+
+			"k0supdate   worker       worker2       SignalPending     0          -\n",
+			out.String())
+	})
+}
+
+func TestEstimateCompletion(t *testing.T) {
+	created := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	plan := func(states ...apv1beta2.PlanCommandTargetStateType) *apv1beta2.Plan {
+		var workers []apv1beta2.PlanCommandTargetStatus
+		for i, state := range states {
+			workers = append(workers, apv1beta2.PlanCommandTargetStatus{
+				State:                state,
+				LastUpdatedTimestamp: metav1.NewTime(created.Add(time.Duration(i+1) * time.Minute)),
+			})
+		}
+		return &apv1beta2.Plan{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+			Status: apv1beta2.PlanStatus{
+				Commands: []apv1beta2.PlanCommandStatus{{
+					AirgapUpdate: &apv1beta2.PlanCommandAirgapUpdateStatus{Workers: workers},
+				}},
+			},
+		}
+	}
+
+	_, ok := estimateCompletion(plan(appc.SignalPending, appc.SignalPending))
+	assert.False(t, ok, "nothing done yet")
+
+	_, ok = estimateCompletion(plan(appc.SignalCompleted, appc.SignalSkipped))
+	assert.False(t, ok, "everything done")
+
+	eta, ok := estimateCompletion(plan(appc.SignalCompleted, appc.SignalSkipped, appc.SignalPending, appc.SignalPending))
+	if assert.True(t, ok) {
+		assert.Equal(t, created.Add(4*time.Minute), eta)
+	}
+}
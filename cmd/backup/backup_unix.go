@@ -6,6 +6,7 @@
 package backup
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -19,6 +20,8 @@ import (
 	"github.com/k0sproject/k0s/pkg/component/status"
 	"github.com/k0sproject/k0s/pkg/config"
 
+	"k8s.io/kubectl/pkg/util/term"
+
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -29,6 +32,9 @@ func NewBackupCmd() *cobra.Command {
 	var (
 		debugFlags internal.DebugFlags
 		savePath   string
+		s3Options  backup.S3Options
+		incOptions incrementalOptions
+		encOptions encryptionOptions
 	)
 
 	cmd := &cobra.Command{
@@ -49,25 +55,95 @@ func NewBackupCmd() *cobra.Command {
 			if nodeConfig.Spec.Storage.Etcd.IsExternalClusterUsed() {
 				return errors.New("command 'k0s backup' does not support external etcd cluster")
 			}
-			return c.backup(nodeConfig, savePath, cmd.OutOrStdout())
+			encryption, err := encOptions.load()
+			if err != nil {
+				return err
+			}
+			return c.backup(cmd.Context(), nodeConfig, savePath, &s3Options, &incOptions, encryption, cmd.OutOrStdout())
 		},
 	}
 
 	debugFlags.AddToFlagSet(cmd.PersistentFlags())
 
+	cmd.AddCommand(newVerifyCmd())
+
 	flags := cmd.Flags()
 	flags.AddFlagSet(config.GetPersistentFlagSet())
-	flags.StringVar(&savePath, "save-path", "", "destination directory path for backup assets, use '-' for stdout")
+	flags.StringVar(&savePath, "save-path", "", "destination directory path for backup assets, use '-' for stdout or s3://bucket/prefix for S3-compatible object storage")
+	flags.StringVar(&s3Options.Endpoint, "s3-endpoint", "", "URL of an S3-compatible object storage (default: AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL environment variable, or AWS)")
+	flags.StringVar(&s3Options.Region, "s3-region", "", "region of the S3 bucket (default: AWS_REGION or AWS_DEFAULT_REGION environment variable, AWS profile, or us-east-1)")
+	flags.StringVar(&s3Options.SSE, "s3-sse", "", "server-side encryption for the uploaded backup archive (valid values: AES256, aws:kms)")
+	flags.StringVar(&s3Options.SSEKMSKeyID, "s3-sse-kms-key-id", "", "ID of the KMS key used for aws:kms server-side encryption")
+	flags.BoolVar(&incOptions.enabled, "incremental", false, "back up only the etcd changes since the newest backup archive in the save path")
+	flags.StringArrayVar(&encOptions.encrypt, "encrypt", nil, "encrypt the backup archive for the given age recipient (age1...), AWS KMS key (awskms:<key ID, ARN or alias>), or with a passphrase (passphrase), can be given multiple times")
+	flags.StringVar(&encOptions.passphraseFile, "passphrase-file", "", "file containing the passphrase for --encrypt=passphrase (default: "+backup.PassphraseEnvVar+" environment variable)")
+	flags.IntVar(&incOptions.maxDeltas, "max-deltas", backup.DefaultMaxDeltas, "maximum number of consecutive incremental backups before a full etcd snapshot is taken again")
 
 	return cmd
 }
 
-func (c *command) backup(nodeConfig *k0sv1beta1.ClusterConfig, savePath string, out io.Writer) error {
+type incrementalOptions struct {
+	enabled   bool
+	maxDeltas int
+}
+
+type encryptionOptions struct {
+	encrypt        []string
+	passphraseFile string
+}
+
+func (o *encryptionOptions) load() (*backup.EncryptionOptions, error) {
+	var encryption backup.EncryptionOptions
+	for _, recipient := range o.encrypt {
+		if recipient != "passphrase" {
+			encryption.Recipients = append(encryption.Recipients, recipient)
+			continue
+		}
+		passphrase, err := backup.LoadPassphrase(o.passphraseFile)
+		if err != nil {
+			return nil, err
+		}
+		if passphrase == "" {
+			return nil, fmt.Errorf("--encrypt=passphrase requires a passphrase via --passphrase-file or the %s environment variable", backup.PassphraseEnvVar)
+		}
+		encryption.Passphrase = passphrase
+	}
+	if err := encryption.ValidateRecipients(); err != nil {
+		return nil, fmt.Errorf("invalid --encrypt value: %w", err)
+	}
+	return &encryption, nil
+}
+
+func (c *command) backup(ctx context.Context, nodeConfig *k0sv1beta1.ClusterConfig, savePath string, s3Options *backup.S3Options, incOptions *incrementalOptions, encryption *backup.EncryptionOptions, out io.Writer) error {
 	if os.Geteuid() != 0 {
 		return errors.New("this command must be run as root")
 	}
 
-	if savePath != "-" && !dir.IsDirectory(savePath) {
+	switch s3Options.SSE {
+	case "", "AES256", "aws:kms":
+	default:
+		return fmt.Errorf("unsupported server-side encryption: %q", s3Options.SSE)
+	}
+	if s3Options.SSEKMSKeyID != "" && s3Options.SSE != "aws:kms" {
+		return errors.New("--s3-sse-kms-key-id can only be used together with --s3-sse=aws:kms")
+	}
+
+	if incOptions.enabled {
+		if encryption.IsEnabled() {
+			return errors.New("--incremental can't be combined with --encrypt")
+		}
+		if savePath == "-" || backup.IsS3URL(savePath) {
+			return errors.New("--incremental requires the save-path to be a local directory")
+		}
+		if incOptions.maxDeltas < 1 {
+			return errors.New("--max-deltas must be at least 1")
+		}
+	}
+
+	if savePath == "-" && term.IsTerminal(out) {
+		return errors.New("cowardly refusing to write binary data to a terminal")
+	}
+	if savePath != "-" && !backup.IsS3URL(savePath) && !dir.IsDirectory(savePath) {
 		return fmt.Errorf("the save-path directory (%s) does not exist", savePath)
 	}
 
@@ -86,7 +162,10 @@ func (c *command) backup(nodeConfig *k0sv1beta1.ClusterConfig, savePath string,
 		if err != nil {
 			return err
 		}
-		return mgr.RunBackup(nodeConfig.Spec, c.K0sVars, savePath, out)
+		mgr.S3 = *s3Options
+		mgr.Incremental, mgr.MaxDeltas = incOptions.enabled, incOptions.maxDeltas
+		mgr.Encryption = *encryption
+		return mgr.RunBackup(ctx, nodeConfig.Spec, c.K0sVars, savePath, out)
 	}
 	return fmt.Errorf("backup command must be run on the controller node, have `%s`", status.Role)
 }
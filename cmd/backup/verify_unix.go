@@ -0,0 +1,89 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/k0sproject/k0s/internal/pkg/file"
+	"github.com/k0sproject/k0s/pkg/backup"
+
+	"k8s.io/kubectl/pkg/util/term"
+
+	"github.com/spf13/cobra"
+)
+
+func newVerifyCmd() *cobra.Command {
+	var (
+		s3Options      backup.S3Options
+		passphraseFile string
+		identityFile   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify filename",
+		Short: "Verify the integrity of a backup archive without restoring it",
+		Long: `Verify the integrity of a backup archive without restoring it. Use '-' as
+filename to read the archive from stdin, or an s3://bucket/key URL to read it
+from S3-compatible object storage.
+
+The archive is unpacked into a temporary directory and validated: the archive
+can be decrypted and unpacked, its files match the checksums embedded in the
+archive, the etcd snapshot or kine database is intact, the k0s configuration
+is valid, and all certificates match their keys. The command exits with a
+non-zero exit code if any errors have been found.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			switch {
+			case path == "-":
+				if term.IsTerminal(cmd.InOrStdin()) {
+					return errors.New("cowardly refusing to read binary data from a terminal")
+				}
+			case !backup.IsS3URL(path) && !file.Exists(path):
+				return fmt.Errorf("given file %s does not exist", path)
+			}
+
+			mgr, err := backup.NewBackupManager()
+			if err != nil {
+				return err
+			}
+			mgr.S3 = s3Options
+			mgr.Stdin = cmd.InOrStdin()
+			if mgr.Encryption.Passphrase, err = backup.LoadPassphrase(passphraseFile); err != nil {
+				return err
+			}
+			if identityFile != "" {
+				f, err := os.Open(identityFile)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				if mgr.Encryption.Identities, err = backup.ParseAgeIdentities(f); err != nil {
+					return fmt.Errorf("failed to parse identity file: %w", err)
+				}
+			}
+
+			report := mgr.Verify(cmd.Context(), path)
+			report.Print(cmd.OutOrStdout())
+			if !report.OK() {
+				return errors.New("backup archive verification failed")
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "Backup archive verified successfully")
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&s3Options.Endpoint, "s3-endpoint", "", "URL of an S3-compatible object storage (default: AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL environment variable, or AWS)")
+	flags.StringVar(&s3Options.Region, "s3-region", "", "region of the S3 bucket (default: AWS_REGION or AWS_DEFAULT_REGION environment variable, AWS profile, or us-east-1)")
+	flags.StringVar(&passphraseFile, "passphrase-file", "", "file containing the passphrase to decrypt the backup archive (default: "+backup.PassphraseEnvVar+" environment variable)")
+	flags.StringVar(&identityFile, "identity-file", "", "age identity file containing the keys to decrypt the backup archive")
+
+	return cmd
+}
@@ -17,7 +17,9 @@ func NewConfigCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(NewCreateCmd())
+	cmd.AddCommand(NewDiffCmd())
 	cmd.AddCommand(NewEditCmd())
+	cmd.AddCommand(NewMigrateCmd())
 	cmd.AddCommand(NewStatusCmd())
 	cmd.AddCommand(NewValidateCmd())
 
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/k0sproject/k0s/cmd/internal"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	k0sclientset "github.com/k0sproject/k0s/pkg/client/clientset"
+	"github.com/k0sproject/k0s/pkg/component/controller/clusterconfig"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/constant"
+	"github.com/k0sproject/k0s/pkg/kubernetes"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/spf13/cobra"
+)
+
+func NewDiffCmd() *cobra.Command {
+	var debugFlags internal.DebugFlags
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare the configuration file with the defaults and the cluster",
+		Long: `Compare the configuration file with the defaults and the cluster.
+
+Lists all fields whose values differ between the built-in defaults, the given
+configuration file and the dynamic configuration of the cluster. Fields whose
+file value is overridden by the dynamic configuration, or whose cluster value
+is ignored because it's node specific, are annotated accordingly. Needs to be
+run on a controller node, unless dynamic configuration isn't enabled.`,
+		Example:          `  k0s config diff --config /etc/k0s/k0s.yaml`,
+		Args:             cobra.NoArgs,
+		PersistentPreRun: debugFlags.Run,
+		RunE: func(cmd *cobra.Command, _ []string) (err error) {
+			var bytes []byte
+
+			// config.CfgFile is the global value holder for --config flag, set by cobra/pflag
+			switch config.CfgFile {
+			case "-":
+				if bytes, err = io.ReadAll(cmd.InOrStdin()); err != nil {
+					return fmt.Errorf("failed to read configuration from standard input: %w", err)
+				}
+			case "":
+				return errors.New("--config can't be empty")
+			default:
+				if bytes, err = config.ReadConfig(config.CfgFile); err != nil {
+					return fmt.Errorf("failed to read configuration file: %w", err)
+				}
+			}
+
+			file, err := v1beta1.ConfigFromBytes(bytes)
+			if err != nil {
+				return fmt.Errorf("failed to parse configuration: %w", err)
+			}
+
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+			cluster, err := getClusterConfig(cmd, opts.K0sVars.AdminKubeConfigPath)
+			if err != nil {
+				return err
+			}
+			if cluster == nil {
+				if _, err := fmt.Fprintln(cmd.ErrOrStderr(), "No dynamic configuration found in the cluster, comparing with the defaults only"); err != nil {
+					return err
+				}
+			}
+
+			diffs, err := clusterconfig.Diff(v1beta1.DefaultClusterConfig(), file, cluster)
+			if err != nil {
+				return err
+			}
+
+			return printDiff(cmd.OutOrStdout(), diffs, cluster != nil)
+		},
+	}
+
+	debugFlags.AddToFlagSet(cmd.PersistentFlags())
+	flags := cmd.Flags()
+	flags.AddFlagSet(config.FileInputFlag())
+	flags.AddFlagSet(config.GetKubeCtlFlagSet())
+	_ = cmd.MarkFlagRequired("config")
+
+	return cmd
+}
+
+// getClusterConfig returns the dynamic configuration of the cluster, or nil if
+// there's none.
+func getClusterConfig(cmd *cobra.Command, kubeconfigPath string) (*v1beta1.ClusterConfig, error) {
+	restConfig, err := kubernetes.ClientConfig(kubernetes.KubeconfigFromFile(kubeconfigPath))
+	if err != nil {
+		return nil, err
+	}
+	client, err := k0sclientset.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := client.K0sV1beta1().ClusterConfigs(constant.ClusterConfigNamespace).Get(cmd.Context(), constant.ClusterConfigObjectName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get dynamic configuration: %w", err)
+	}
+	return cluster, nil
+}
+
+func printDiff(out io.Writer, diffs []clusterconfig.FieldDiff, withCluster bool) error {
+	if len(diffs) == 0 {
+		_, err := fmt.Fprintln(out, "No differences found")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if withCluster {
+		fmt.Fprintln(w, "FIELD\tDEFAULT\tFILE\tCLUSTER\tNOTE")
+	} else {
+		fmt.Fprintln(w, "FIELD\tDEFAULT\tFILE")
+	}
+	for _, diff := range diffs {
+		if withCluster {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", diff.Path, formatValue(diff.Default), formatValue(diff.File), formatValue(diff.Cluster), diff.Note)
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", diff.Path, formatValue(diff.Default), formatValue(diff.File))
+		}
+	}
+	return w.Flush()
+}
+
+// formatValue renders scalar values as is and all others as compact JSON.
+func formatValue(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "-"
+	case string:
+		return value
+	case bool, int64, float64:
+		return fmt.Sprint(value)
+	default:
+		if data, err := json.Marshal(value); err == nil {
+			return string(data)
+		}
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/component/controller/clusterconfig"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintDiff(t *testing.T) {
+	diffs := []clusterconfig.FieldDiff{
+		{Path: "spec.api.sans", Default: nil, File: []any{"a", "b"}, Cluster: nil},
+		{Path: "spec.network.kuberouter.mtu", Default: nil, File: int64(1400), Cluster: int64(1350), Note: "overridden"},
+	}
+
+	t.Run("cluster", func(t *testing.T) {
+		var out strings.Builder
+		require.NoError(t, printDiff(&out, diffs, true))
+		assert.Equal(t, `FIELD                        DEFAULT  FILE       CLUSTER  NOTE
+spec.api.sans                -        ["a","b"]  -        
+spec.network.kuberouter.mtu  -        1400       1350     overridden
+`, out.String())
+	})
+
+	t.Run("no_cluster", func(t *testing.T) {
+		var out strings.Builder
+		require.NoError(t, printDiff(&out, diffs, false))
+		assert.Equal(t, `FIELD                        DEFAULT  FILE
+spec.api.sans                -        ["a","b"]
+spec.network.kuberouter.mtu  -        1400
+`, out.String())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var out strings.Builder
+		require.NoError(t, printDiff(&out, nil, true))
+		assert.Equal(t, "No differences found\n", out.String())
+	})
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/k0sproject/k0s/cmd/internal"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+func NewMigrateCmd() *cobra.Command {
+	var debugFlags internal.DebugFlags
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Replace deprecated fields in a k0s configuration",
+		Long: `Replace deprecated fields in a k0s configuration.
+
+Reads a configuration written for an older version of k0s, replaces all
+deprecated fields with their current counterparts, and writes the migrated
+configuration to the standard output. A report of all changes and their effect
+on the cluster is written to the standard error. Comments and the order of
+fields aren't preserved.`,
+		Example:          `  k0s config migrate --config /etc/k0s/k0s.yaml > k0s.migrated.yaml`,
+		Args:             cobra.NoArgs,
+		PersistentPreRun: debugFlags.Run,
+		RunE: func(cmd *cobra.Command, _ []string) (err error) {
+			var bytes []byte
+
+			// config.CfgFile is the global value holder for --config flag, set by cobra/pflag
+			switch config.CfgFile {
+			case "-":
+				if bytes, err = io.ReadAll(cmd.InOrStdin()); err != nil {
+					return fmt.Errorf("failed to read configuration from standard input: %w", err)
+				}
+			case "":
+				return errors.New("--config can't be empty")
+			default:
+				if bytes, err = config.ReadConfig(config.CfgFile); err != nil {
+					return fmt.Errorf("failed to read configuration file: %w", err)
+				}
+			}
+
+			migrated, changes, err := config.MigrateConfig(bytes)
+			if err != nil {
+				return fmt.Errorf("failed to migrate configuration: %w", err)
+			}
+			if _, err := v1beta1.ConfigFromBytes(migrated); err != nil {
+				return fmt.Errorf("failed to parse migrated configuration: %w", err)
+			}
+
+			if len(changes) == 0 {
+				if _, err := fmt.Fprintln(cmd.ErrOrStderr(), "No deprecated fields found"); err != nil {
+					return err
+				}
+			}
+			for _, change := range changes {
+				if _, err := fmt.Fprintln(cmd.ErrOrStderr(), change); err != nil {
+					return err
+				}
+			}
+
+			_, err = cmd.OutOrStdout().Write(migrated)
+			return err
+		},
+	}
+
+	debugFlags.AddToFlagSet(cmd.PersistentFlags())
+	cmd.Flags().AddFlagSet(config.FileInputFlag())
+	_ = cmd.MarkFlagRequired("config")
+
+	return cmd
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateCmd(t *testing.T) {
+	t.Run("deprecated", func(t *testing.T) {
+		cmd := NewMigrateCmd()
+		cmd.SetArgs([]string{"--config", "-"})
+		cmd.SetIn(bytes.NewReader([]byte("spec:\n  network:\n    calico:\n      mode: ipip\n")))
+		out, errOut := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		cmd.SetOut(out)
+		cmd.SetErr(errOut)
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, "spec:\n  network:\n    calico:\n      mode: bird\n", out.String())
+		assert.Equal(t, "spec.network.calico.mode: Replaced ipip by its new name bird, the behavior is unchanged\n", errOut.String())
+	})
+
+	t.Run("current", func(t *testing.T) {
+		cmd := NewMigrateCmd()
+		cmd.SetArgs([]string{"--config", "-"})
+		cmd.SetIn(bytes.NewReader(validConfig()))
+		out, errOut := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		cmd.SetOut(out)
+		cmd.SetErr(errOut)
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, string(validConfig()), out.String())
+		assert.Equal(t, "No deprecated fields found\n", errOut.String())
+	})
+}
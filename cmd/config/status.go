@@ -4,19 +4,37 @@
 package config
 
 import (
+	"errors"
+
 	"github.com/k0sproject/k0s/pkg/config"
 
 	"github.com/spf13/cobra"
 )
 
+// featureGatesJSONPath renders the k0s feature gates reported in the status of
+// the cluster configuration as a table.
+const featureGatesJSONPath = `jsonpath={"NAME\tSTAGE\tENABLED\n"}{range .status.k0sFeatureGates[*]}{.name}{"\t"}{.stage}{"\t"}{.enabled}{"\n"}{end}`
+
 func NewStatusCmd() *cobra.Command {
-	var outputFormat string
+	var (
+		outputFormat string
+		featureGates bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Display dynamic configuration reconciliation status",
-		Args:  cobra.NoArgs,
+		Example: `  k0s config status
+  k0s config status --feature-gates`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			if featureGates {
+				if outputFormat != "" {
+					return errors.New("--output can't be combined with --feature-gates")
+				}
+				return reExecKubectl(cmd, "-n", "kube-system", "get", "clusterconfig", "k0s", "-o", featureGatesJSONPath)
+			}
+
 			args := []string{"-n", "kube-system", "get", "event", "--field-selector", "involvedObject.name=k0s"}
 			if outputFormat != "" {
 				args = append(args, "-o", outputFormat)
@@ -29,6 +47,7 @@ func NewStatusCmd() *cobra.Command {
 	flags := cmd.Flags()
 	flags.AddFlagSet(config.GetKubeCtlFlagSet())
 	flags.StringVarP(&outputFormat, "output", "o", "", "Output format. Must be one of yaml|json")
+	flags.BoolVar(&featureGates, "feature-gates", false, "Display which k0s features are enabled")
 
 	return cmd
 }
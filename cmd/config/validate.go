@@ -7,7 +7,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/k0sproject/k0s/cmd/internal"
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
@@ -18,13 +17,24 @@ import (
 )
 
 func NewValidateCmd() *cobra.Command {
-	var debugFlags internal.DebugFlags
+	var (
+		debugFlags internal.DebugFlags
+		strict     bool
+		expand     bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validate k0s configuration",
-		Long: `Example:
-   k0s config validate --config path_to_config.yaml`,
+		Long: `Validate k0s configuration.
+
+In strict mode, fields that aren't part of the configuration schema, e.g.
+misspelled ones, are rejected as well. With --expand, ${ENV_VAR} and
+${file:/path} references are substituted before validating, the same way as
+"k0s controller --expand-config" does.`,
+		Example: `  k0s config validate --config path_to_config.yaml
+  k0s config validate --strict --config path_to_config.yaml
+  k0s config validate --config path_to_config_dir/`,
 		Args:             cobra.NoArgs,
 		PersistentPreRun: debugFlags.Run,
 		RunE: func(cmd *cobra.Command, _ []string) (err error) {
@@ -39,17 +49,29 @@ func NewValidateCmd() *cobra.Command {
 			case "":
 				return errors.New("--config can't be empty")
 			default:
-				if bytes, err = os.ReadFile(config.CfgFile); err != nil {
+				if bytes, err = config.ReadConfig(config.CfgFile); err != nil {
 					return fmt.Errorf("failed to read configuration file: %w", err)
 				}
 			}
 
+			if expand {
+				if bytes, err = config.ExpandReferences(bytes); err != nil {
+					return err
+				}
+			}
+
+			if strict {
+				if err := config.CheckUnknownFields(bytes); err != nil {
+					return err
+				}
+			}
+
 			cfg, err := v1beta1.ConfigFromBytes(bytes)
 			if err != nil {
 				return fmt.Errorf("failed to parse configuration: %w", err)
 			}
 
-			return errors.Join(cfg.Validate()...)
+			return errors.Join(append(cfg.Validate(), config.ValidateK0sFeatureGates(cfg.Spec.K0sFeatureGates)...)...)
 		},
 	}
 
@@ -62,6 +84,8 @@ func NewValidateCmd() *cobra.Command {
 	})
 
 	cmd.Flags().AddFlagSet(config.FileInputFlag())
+	cmd.Flags().BoolVar(&strict, "strict", false, "reject unknown fields")
+	cmd.Flags().BoolVar(&expand, "expand", false, "substitute ${ENV_VAR} and ${file:/path} references")
 	_ = cmd.MarkFlagRequired("config")
 
 	return cmd
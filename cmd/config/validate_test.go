@@ -73,6 +73,35 @@ func TestValidateCmd(t *testing.T) {
 		assert.Contains(t, errOut.String(), "cannot unmarshal")
 	})
 
+	t.Run("strict", func(t *testing.T) {
+		config := []byte("spec:\n  api:\n    extraArgss: {}\n  network:\n    calico:\n      mtuu: 1450\n")
+
+		cmd := NewValidateCmd()
+		cmd.SetArgs([]string{"--config", "-"})
+		cmd.SetIn(bytes.NewReader(config))
+		cmd.SetErr(io.Discard)
+		assert.NoError(t, cmd.Execute(), "unknown fields should be ignored by default")
+
+		cmd = NewValidateCmd()
+		cmd.SetArgs([]string{"--strict", "--config", "-"})
+		cmd.SetIn(bytes.NewReader(config))
+		cmd.SetErr(io.Discard)
+		assert.ErrorContains(t, cmd.Execute(), "unknown fields: spec.api.extraArgss, spec.network.calico.mtuu")
+
+		cmd = NewValidateCmd()
+		cmd.SetArgs([]string{"--strict", "--config", "-"})
+		cmd.SetIn(bytes.NewReader(validConfig()))
+		assert.NoError(t, cmd.Execute())
+	})
+
+	t.Run("unknown k0s feature gate", func(t *testing.T) {
+		cmd := NewValidateCmd()
+		cmd.SetArgs([]string{"--config", "-"})
+		cmd.SetIn(bytes.NewReader([]byte("spec:\n  k0sFeatureGates:\n    - name: NoSuchFeature\n      enabled: true\n")))
+		cmd.SetErr(io.Discard)
+		assert.ErrorContains(t, cmd.Execute(), `k0sFeatureGates: unknown feature "NoSuchFeature"`)
+	})
+
 	t.Run("valid config from file", func(t *testing.T) {
 		cmd := NewValidateCmd()
 		tmpfile := filepath.Join(t.TempDir(), "testconfig")
@@ -15,7 +15,9 @@ import (
 	"github.com/k0sproject/k0s/internal/pkg/file"
 	"github.com/k0sproject/k0s/internal/pkg/users"
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
 	"github.com/k0sproject/k0s/pkg/certificate"
+	"github.com/k0sproject/k0s/pkg/component/controller/clusterconfig"
 	"github.com/k0sproject/k0s/pkg/config"
 	"github.com/k0sproject/k0s/pkg/constant"
 
@@ -179,6 +181,34 @@ func (c *Certificates) Init(ctx context.Context) error {
 		return err
 	})
 
+	eg.Go(func() error {
+		// autopilot admission webhooks, called by the API server on the same host
+		webhookReq := certificate.Request{
+			Name:      apconst.WebhookCertName,
+			CN:        "k0s-autopilot-webhook",
+			O:         "kubernetes",
+			CACert:    caCertPath,
+			CAKey:     caCertKey,
+			Hostnames: []string{"localhost", "127.0.0.1"},
+		}
+		_, err := c.CertManager.EnsureCertificate(webhookReq, users.RootUID, c.ClusterSpec.API.CA.CertificatesExpireAfter.Duration)
+		return err
+	})
+
+	eg.Go(func() error {
+		// cluster configuration admission webhooks, called by the API server on the same host
+		webhookReq := certificate.Request{
+			Name:      clusterconfig.WebhookCertName,
+			CN:        "k0s-clusterconfig-webhook",
+			O:         "kubernetes",
+			CACert:    caCertPath,
+			CAKey:     caCertKey,
+			Hostnames: []string{"localhost", "127.0.0.1"},
+		}
+		_, err := c.CertManager.EnsureCertificate(webhookReq, users.RootUID, c.ClusterSpec.API.CA.CertificatesExpireAfter.Duration)
+		return err
+	})
+
 	hostnames := []string{
 		"kubernetes",
 		"kubernetes.default",
@@ -23,13 +23,16 @@ import (
 	"github.com/k0sproject/k0s/internal/pkg/dir"
 	"github.com/k0sproject/k0s/internal/pkg/file"
 	"github.com/k0sproject/k0s/internal/pkg/stringmap"
+	"github.com/k0sproject/k0s/internal/pkg/stringslice"
 	"github.com/k0sproject/k0s/internal/pkg/sysinfo"
 	"github.com/k0sproject/k0s/internal/sync/value"
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/applier"
 	apclient "github.com/k0sproject/k0s/pkg/autopilot/client"
+	"github.com/k0sproject/k0s/pkg/backup"
 	"github.com/k0sproject/k0s/pkg/build"
 	"github.com/k0sproject/k0s/pkg/certificate"
+	"github.com/k0sproject/k0s/pkg/cloudmetadata"
 	"github.com/k0sproject/k0s/pkg/component/controller"
 	"github.com/k0sproject/k0s/pkg/component/controller/clusterconfig"
 	"github.com/k0sproject/k0s/pkg/component/controller/cplb"
@@ -93,9 +96,21 @@ func NewControllerCmd() *cobra.Command {
 			if c.TokenArg != "" && c.TokenFile != "" {
 				return errors.New("you can only pass one token argument either as a CLI argument 'k0s controller [join-token]' or as a flag 'k0s controller --token-file [path]'")
 			}
+			if c.CloudIdentityJoin != "" {
+				return errors.New("--cloud-identity-join is only supported for workers")
+			}
+			if c.JoinCode != "" {
+				return errors.New("--join-code is only supported for workers")
+			}
+			if c.JoinRequest {
+				return errors.New("--join-request is only supported for workers")
+			}
 			if err := controllerFlags.Normalize(); err != nil {
 				return err
 			}
+			if err := internal.ConfigureLogging(&c.WorkerOptions); err != nil {
+				return err
+			}
 
 			if err := (&sysinfo.K0sSysinfoSpec{
 				ControllerRoleEnabled: true,
@@ -107,6 +122,7 @@ func NewControllerCmd() *cobra.Command {
 
 			ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 			defer cancel()
+			internal.StartSystemdWatchdog(ctx)
 			return c.start(ctx, &controllerFlags, debugFlags.IsDebug())
 		},
 	}
@@ -131,7 +147,7 @@ func (c *command) start(ctx context.Context, flags *config.ControllerOptions, de
 		return fmt.Errorf("failed to load node config: %w", err)
 	}
 
-	if errs := nodeConfig.Validate(); len(errs) > 0 {
+	if errs := append(nodeConfig.Validate(), config.ValidateK0sFeatureGates(nodeConfig.Spec.K0sFeatureGates)...); len(errs) > 0 {
 		return fmt.Errorf("invalid node config: %w", errors.Join(errs...))
 	}
 
@@ -202,6 +218,17 @@ func (c *command) start(ctx context.Context, flags *config.ControllerOptions, de
 		}
 	}
 
+	var cloudMetadataSANs []string
+	if provider := nodeConfig.Spec.API.SANsFromCloudMetadata; provider != "" {
+		addresses, detected, err := cloudmetadata.NewClient().Addresses(ctx, cloudmetadata.Provider(provider))
+		if err != nil {
+			return fmt.Errorf("failed to discover SANs from cloud metadata: %w", err)
+		}
+		logrus.Infof("Discovered SANs from %s instance metadata: %s", detected, addresses)
+		cloudMetadataSANs = addresses
+		nodeConfig.Spec.API.SANs = stringslice.Unique(append(nodeConfig.Spec.API.SANs, addresses...))
+	}
+
 	logrus.Infof("using api address: %s", nodeConfig.Spec.API.Address)
 	logrus.Infof("using listen port: %d", nodeConfig.Spec.API.Port)
 	logrus.Infof("using sans: %s", nodeConfig.Spec.API.SANs)
@@ -213,6 +240,7 @@ func (c *command) start(ctx context.Context, flags *config.ControllerOptions, de
 	logrus.Infof("DNS address: %s", dnsAddress)
 
 	var storageBackend manager.Component
+	var etcdComponent *controller.Etcd
 	storageType := nodeConfig.Spec.Storage.Type
 
 	switch storageType {
@@ -222,13 +250,21 @@ func (c *command) start(ctx context.Context, flags *config.ControllerOptions, de
 			K0sVars: c.K0sVars,
 		}
 	case v1beta1.EtcdStorageType:
-		storageBackend = &controller.Etcd{
+		if nodeConfig.Spec.Storage.Etcd.IsExternalClusterUsed() {
+			storageBackend = &controller.ExternalEtcd{
+				Config:  nodeConfig.Spec.Storage.Etcd,
+				K0sVars: c.K0sVars,
+			}
+			break
+		}
+		etcdComponent = &controller.Etcd{
 			CertManager: certificateManager,
 			Config:      nodeConfig.Spec.Storage.Etcd,
 			JoinClient:  joinClient,
 			K0sVars:     c.K0sVars,
 			LogLevel:    c.LogLevels.Etcd,
 		}
+		storageBackend = etcdComponent
 	default:
 		return fmt.Errorf("invalid storage type: %s", nodeConfig.Spec.Storage.Type)
 	}
@@ -292,7 +328,7 @@ func (c *command) start(ctx context.Context, flags *config.ControllerOptions, de
 		DisableEndpointReconciler: enableK0sEndpointReconciler,
 	})
 
-	nodeName, kubeletExtraArgs, err := workercmd.GetNodeName(&c.WorkerOptions)
+	nodeName, kubeletExtraArgs, err := workercmd.GetNodeName(ctx, c.K0sVars, &c.WorkerOptions)
 	if err != nil {
 		return fmt.Errorf("failed to determine node name: %w", err)
 	}
@@ -338,10 +374,28 @@ func (c *command) start(ctx context.Context, flags *config.ControllerOptions, de
 		nodeComponents.Add(ctx, &controller.K0SControlAPI{RuntimeConfig: rtc})
 	}
 
+	if controllerMode != config.SingleNodeMode {
+		nodeComponents.Add(ctx, &controller.JoinTokenTracker{
+			KubeClientFactory: adminClientFactory,
+			LeaderElector:     leaderElector,
+		})
+	}
+
+	if controllerMode != config.SingleNodeMode && flags.WorkerJoinTokenRotation > 0 {
+		nodeComponents.Add(ctx, &controller.JoinTokenRotator{
+			KubeClientFactory: adminClientFactory,
+			LeaderElector:     leaderElector,
+			APISpec:           nodeConfig.Spec.API,
+			CertRootDir:       c.K0sVars.CertRootDir,
+			Validity:          flags.WorkerJoinTokenRotation,
+		})
+	}
+
 	if !slices.Contains(flags.DisableComponents, constant.CsrApproverComponentName) {
 		nodeComponents.Add(ctx, controller.NewCSRApprover(nodeConfig,
 			leaderElector,
-			adminClientFactory))
+			adminClientFactory,
+			flags.VerifyKubeletServingCSRs))
 	}
 
 	if flags.EnableK0sCloudProvider {
@@ -354,7 +408,7 @@ func (c *command) start(ctx context.Context, flags *config.ControllerOptions, de
 			),
 		)
 	}
-	nodeComponents.Add(ctx, &status.Status{
+	statusComponent := &status.Status{
 		Prober: prober.DefaultProber,
 		StatusInformation: status.K0sStatus{
 			Pid:           os.Getpid(),
@@ -365,9 +419,32 @@ func (c *command) start(ctx context.Context, flags *config.ControllerOptions, de
 			SingleNode:    controllerMode == config.SingleNodeMode,
 			K0sVars:       c.K0sVars,
 			ClusterConfig: nodeConfig,
+
+			CloudMetadataSANs: cloudMetadataSANs,
 		},
 		Socket:      c.K0sVars.StatusSocketPath,
 		CertManager: worker.NewCertificateManager(c.K0sVars.KubeletAuthConfigPath),
+	}
+	if controllerMode != config.SingleNodeMode {
+		statusComponent.JoinTokens = &status.JoinTokens{
+			KubeClientFactory: adminClientFactory,
+			ClusterConfig:     nodeConfig,
+			K0sVars:           c.K0sVars,
+			Group:             flags.JoinTokenSocketGroup,
+		}
+	}
+	if nodeConfig.Spec.Backup.IsEnabled() {
+		backupScheduler := &backup.Scheduler{
+			K0sVars:       c.K0sVars,
+			NodeSpec:      nodeConfig.Spec,
+			LeaderElector: leaderElector,
+		}
+		nodeComponents.Add(ctx, backupScheduler)
+		statusComponent.Backups = backupScheduler
+	}
+	nodeComponents.Add(ctx, statusComponent)
+	nodeComponents.Add(ctx, &controller.UnhealthyEventRecorder{
+		KubeClientFactory: adminClientFactory,
 	})
 
 	if nodeConfig.Spec.Storage.Type == v1beta1.EtcdStorageType && !nodeConfig.Spec.Storage.Etcd.IsExternalClusterUsed() {
@@ -377,6 +454,40 @@ func (c *command) start(ctx context.Context, flags *config.ControllerOptions, de
 		}
 		clusterComponents.Add(ctx, controller.NewCRD(c.K0sVars.ManifestsDir, "etcd", controller.WithStackName("etcd-member")))
 		nodeComponents.Add(ctx, etcdReconciler)
+		nodeComponents.Add(ctx, &controller.EtcdCertificateRotator{Etcd: etcdComponent})
+		nodeComponents.Add(ctx, &controller.EtcdLearnerPromoter{
+			K0sVars:    c.K0sVars,
+			EtcdConfig: nodeConfig.Spec.Storage.Etcd,
+		})
+		nodeComponents.Add(ctx, &controller.EtcdAlarmMonitor{
+			K0sVars:           c.K0sVars,
+			EtcdConfig:        nodeConfig.Spec.Storage.Etcd,
+			KubeClientFactory: adminClientFactory,
+		})
+
+		if nodeConfig.Spec.Storage.Etcd.Defragmentation.IsEnabled() {
+			nodeComponents.Add(ctx, &controller.EtcdDefragmenter{
+				K0sVars:    c.K0sVars,
+				EtcdConfig: nodeConfig.Spec.Storage.Etcd,
+			})
+		}
+
+		if metrics := nodeConfig.Spec.Storage.Etcd.Metrics; metrics != nil && metrics.Proxy != nil {
+			nodeComponents.Add(ctx, &controller.EtcdMetricsProxy{
+				K0sVars:           c.K0sVars,
+				Metrics:           metrics,
+				KubeClientFactory: adminClientFactory,
+			})
+		}
+
+		if nodeConfig.Spec.Storage.Etcd.MemberEviction.IsEnabled() {
+			nodeComponents.Add(ctx, &controller.EtcdMemberEvictor{
+				K0sVars:           c.K0sVars,
+				EtcdConfig:        nodeConfig.Spec.Storage.Etcd,
+				KubeClientFactory: adminClientFactory,
+				LeaderElector:     leaderElector,
+			})
+		}
 	}
 
 	perfTimer.Checkpoint("starting-certificates-init")
@@ -425,6 +536,10 @@ func (c *command) start(ctx context.Context, flags *config.ControllerOptions, de
 			leaderElector,
 			nodeConfig,
 		))
+		clusterComponents.Add(ctx, &clusterconfig.Webhook{
+			CertDir:           c.K0sVars.CertRootDir,
+			KubeClientFactory: adminClientFactory,
+		})
 
 		configSource, err = clusterconfig.NewAPIConfigSource(adminClientFactory)
 		if err != nil {
@@ -435,7 +550,7 @@ func (c *command) start(ctx context.Context, flags *config.ControllerOptions, de
 	}
 
 	clusterComponents.Add(ctx, controller.NewClusterConfigReconciler(
-		clusterComponents,
+		manager.Reconcilers{nodeComponents, clusterComponents},
 		adminClientFactory,
 		configSource,
 	))
@@ -559,6 +674,9 @@ func (c *command) start(ctx context.Context, flags *config.ControllerOptions, de
 		KubeletExtraArgs:   c.KubeletExtraArgs,
 		AdminClientFactory: adminClientFactory,
 		Workloads:          controllerMode.WorkloadsEnabled(),
+
+		ControlNodeStaleTimeout: flags.AutopilotControlNodeStaleTimeout,
+		ControlNodeRemovalAge:   flags.AutopilotControlNodeRemovalAge,
 	})
 
 	clusterComponents.Add(ctx, controller.NewUpdateProber(
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"fmt"
+
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/etcd"
+
+	"github.com/dustin/go-humanize"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func etcdDefragCmd() *cobra.Command {
+	var (
+		dbSizeThreshold resource.QuantityValue
+		force           bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "defrag",
+		Short: "Defragment the etcd member running on this controller",
+		Long: `Defragment the etcd member running on this controller.
+
+The member doesn't respond to any requests while it's being defragmented.
+Defragmentation waits until no other member of the cluster is being
+defragmented, and is refused if the cluster would lose its quorum.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+			nodeConfig, err := opts.K0sVars.NodeConfig()
+			if err != nil {
+				return err
+			}
+			if dbSizeThreshold.Sign() < 0 {
+				return fmt.Errorf("invalid database size threshold: %s", dbSizeThreshold.String())
+			}
+
+			etcdClient, err := etcd.NewClient(opts.K0sVars.CertRootDir, opts.K0sVars.EtcdCertDir, nodeConfig.Spec.Storage.Etcd)
+			if err != nil {
+				return fmt.Errorf("can't connect to the etcd: %w", err)
+			}
+			defer etcdClient.Close()
+			peerTLS, err := etcd.PeerTLSConfig(opts.K0sVars.EtcdCertDir)
+			if err != nil {
+				return fmt.Errorf("failed to load etcd peer certificates: %w", err)
+			}
+
+			defragmenter := etcd.Defragmenter{
+				Client:    etcdClient,
+				PeerTLS:   peerTLS,
+				MinDBSize: dbSizeThreshold.Value(),
+				Force:     force,
+				Log:       logrus.StandardLogger(),
+			}
+			result, err := defragmenter.Defragment(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if result.Skipped {
+				_, err = fmt.Fprintf(out, "Skipped etcd member %x, database size of %s doesn't exceed %s\n",
+					result.MemberID, humanize.IBytes(uint64(result.DBSizeBefore)), humanize.IBytes(uint64(dbSizeThreshold.Value())))
+			} else {
+				_, err = fmt.Fprintf(out, "Defragmented etcd member %x, database size %s -> %s\n",
+					result.MemberID, humanize.IBytes(uint64(result.DBSizeBefore)), humanize.IBytes(uint64(result.DBSizeAfter)))
+			}
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.AddFlagSet(config.GetPersistentFlagSet())
+	flags.Var(&dbSizeThreshold, "db-size-threshold", "only defragment if the database size exceeds this threshold, e.g. 1Gi")
+	flags.BoolVar(&force, "force", false, "defragment even if the etcd cluster would lose its quorum")
+
+	return cmd
+}
@@ -48,8 +48,12 @@ func NewEtcdCmd() *cobra.Command {
 	debugFlags.AddToFlagSet(pflags)
 	pflags.AddFlagSet(config.GetPersistentFlagSet())
 
+	cmd.AddCommand(etcdDefragCmd())
+	cmd.AddCommand(etcdHealthCmd())
 	cmd.AddCommand(etcdLeaveCmd())
 	cmd.AddCommand(etcdListCmd())
+	cmd.AddCommand(etcdRotateCertsCmd())
+	addPlatformSpecificCommands(cmd)
 
 	return cmd
 }
@@ -0,0 +1,10 @@
+//go:build !unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import "github.com/spf13/cobra"
+
+func addPlatformSpecificCommands(etcd *cobra.Command) { /* no-op */ }
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/etcd"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func etcdHealthCmd() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "health",
+		Short: "Report the health of the etcd cluster members",
+		Long: `Report the health of the etcd cluster members.
+
+Members only serve their client API on the loopback interface, hence the
+database size and raft index are only reported for the etcd member running on
+this controller. All other members are probed via their peer endpoint.`,
+		Args: cobra.NoArgs,
+		PreRunE: func(*cobra.Command, []string) error {
+			switch outputFormat {
+			case "text", "json":
+				return nil
+			default:
+				return fmt.Errorf("unknown output format: %q", outputFormat)
+			}
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+			nodeConfig, err := opts.K0sVars.NodeConfig()
+			if err != nil {
+				return err
+			}
+
+			etcdClient, err := etcd.NewClient(opts.K0sVars.CertRootDir, opts.K0sVars.EtcdCertDir, nodeConfig.Spec.Storage.Etcd)
+			if err != nil {
+				return fmt.Errorf("can't connect to the etcd: %w", err)
+			}
+			defer etcdClient.Close()
+			peerTLS, err := etcd.PeerTLSConfig(opts.K0sVars.EtcdCertDir)
+			if err != nil {
+				return fmt.Errorf("failed to load etcd peer certificates: %w", err)
+			}
+
+			status, err := etcdClient.Status(cmd.Context(), peerTLS)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if outputFormat == "json" {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				err = enc.Encode(status)
+			} else {
+				err = printStatus(out, status)
+			}
+			if err != nil {
+				return err
+			}
+
+			if !status.IsHealthy() {
+				return errors.New("etcd cluster is unhealthy")
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.AddFlagSet(config.GetPersistentFlagSet())
+	flags.StringVarP(&outputFormat, "output", "o", "text", "Output format (valid values: text, json)")
+
+	return cmd
+}
+
+func printStatus(out io.Writer, status *etcd.ClusterStatus) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Cluster ID: %s, raft term: %d\n\n", status.ClusterID, status.RaftTerm)
+	fmt.Fprintln(w, "NAME\tID\tPEER URL\tHEALTHY\tLEADER\tVERSION\tDB SIZE\tDB IN USE\tRAFT INDEX\tALARMS\tERROR")
+	for _, member := range status.Members {
+		name := member.Name
+		if member.Local {
+			name += " (local)"
+		}
+		if member.Learner {
+			name += " (learner)"
+		}
+		dbSize, dbInUse, raftIndex := "-", "-", "-"
+		if member.Local {
+			dbSize = humanize.IBytes(uint64(member.DBSize))
+			dbInUse = humanize.IBytes(uint64(member.DBInUse))
+			raftIndex = strconv.FormatUint(member.RaftIndex, 10)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%t\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			name, member.ID, orDash(member.PeerURL), member.Healthy, member.Leader, orDash(member.Version),
+			dbSize, dbInUse, raftIndex, orDash(strings.Join(member.Alarms, ",")), orDash(member.Error),
+		)
+	}
+	return w.Flush()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/etcd"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintStatus(t *testing.T) {
+	status := &etcd.ClusterStatus{
+		ClusterID: "cafe",
+		LeaderID:  "1",
+		RaftTerm:  3,
+		Members: []etcd.MemberStatus{
+			{ID: "1", Name: "controller-1", PeerURL: "https://10.0.0.1:2380", Local: true, Leader: true, Healthy: true, Version: "3.6.4", DBSize: 2 << 20, DBInUse: 1 << 20, RaftIndex: 42},
+			{ID: "2", Name: "controller-2", PeerURL: "https://10.0.0.2:2380", Error: "connection refused", Alarms: []string{"NOSPACE"}},
+		},
+	}
+
+	var out strings.Builder
+	require.NoError(t, printStatus(&out, status))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 5)
+	assert.Equal(t, "Cluster ID: cafe, raft term: 3", lines[0])
+	assert.Equal(t, []string{"NAME", "ID", "PEER", "URL", "HEALTHY", "LEADER", "VERSION", "DB", "SIZE", "DB", "IN", "USE", "RAFT", "INDEX", "ALARMS", "ERROR"}, strings.Fields(lines[2]))
+	assert.Equal(t, []string{"controller-1", "(local)", "1", "https://10.0.0.1:2380", "true", "true", "3.6.4", "2.0", "MiB", "1.0", "MiB", "42", "-", "-"}, strings.Fields(lines[3]))
+	assert.Equal(t, []string{"controller-2", "2", "https://10.0.0.2:2380", "false", "false", "-", "-", "-", "-", "NOSPACE", "connection", "refused"}, strings.Fields(lines[4]))
+	assert.False(t, status.IsHealthy())
+}
+
+func TestEtcdHealthCmd_RejectsUnknownOutputFormat(t *testing.T) {
+	healthCmd := etcdHealthCmd()
+	healthCmd.SetArgs([]string{"--output=yaml"})
+	assert.ErrorContains(t, healthCmd.Execute(), `unknown output format: "yaml"`)
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/etcd"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func etcdRotateCertsCmd() *cobra.Command {
+	var (
+		noWait  bool
+		timeout time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rotate-certs",
+		Short: "Rotate the certificates of all etcd cluster members",
+		Long: `Rotate the certificates of all etcd cluster members.
+
+Requests all controllers to reissue the server, peer and client certificates of
+their etcd member and to restart it afterwards. The members are restarted one at
+a time, and only if the etcd cluster keeps its quorum. Waits until all members
+have rotated their certificates, unless --no-wait is given.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+			nodeConfig, err := opts.K0sVars.NodeConfig()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			etcdClient, err := etcd.NewClient(opts.K0sVars.CertRootDir, opts.K0sVars.EtcdCertDir, nodeConfig.Spec.Storage.Etcd)
+			if err != nil {
+				return fmt.Errorf("can't connect to the etcd: %w", err)
+			}
+			defer etcdClient.Close()
+
+			requestedAt := time.Now()
+			if err := etcdClient.RequestCertificateRotation(ctx, requestedAt); err != nil {
+				return err
+			}
+			logrus.Info("Requested the rotation of the etcd certificates")
+			if noWait {
+				return nil
+			}
+
+			var pending []string
+			err = wait.PollUntilContextTimeout(ctx, 5*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+				// The etcd member on this controller is restarted at some point,
+				// hence errors are expected and retried.
+				var err error
+				if pending, err = etcdClient.PendingCertificateRotations(ctx, requestedAt); err != nil {
+					logrus.WithError(err).Debug("Failed to get pending etcd certificate rotations")
+					return false, nil
+				}
+				return len(pending) == 0, nil
+			})
+			if err != nil {
+				if len(pending) > 0 && wait.Interrupted(err) {
+					return fmt.Errorf("timed out waiting for etcd members to rotate their certificates: %s", strings.Join(pending, ", "))
+				}
+				return err
+			}
+
+			logrus.Info("All etcd members rotated their certificates")
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.AddFlagSet(config.GetPersistentFlagSet())
+	flags.BoolVar(&noWait, "no-wait", false, "don't wait for the etcd members to rotate their certificates")
+	flags.DurationVar(&timeout, "timeout", 30*time.Minute, "the time to wait for the etcd members to rotate their certificates")
+
+	return cmd
+}
@@ -0,0 +1,144 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/pkg/dir"
+	"github.com/k0sproject/k0s/internal/pkg/file"
+	"github.com/k0sproject/k0s/pkg/backup"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/etcd"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func etcdSnapshotCmd() *cobra.Command {
+	var (
+		targetPath string
+		compress   bool
+		s3Options  backup.S3Options
+	)
+
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Take a snapshot of the etcd database",
+		Long: `Take a snapshot of the etcd database.
+
+Unlike "k0s backup", this saves only the etcd snapshot, without certificates,
+manifests or the k0s configuration. This makes it cheap enough to be taken
+frequently between full backups. The snapshot can be restored with etcdutl.
+Prints the path or the S3 URL of the snapshot.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+			nodeConfig, err := opts.K0sVars.NodeConfig()
+			if err != nil {
+				return err
+			}
+
+			switch s3Options.SSE {
+			case "", "AES256", "aws:kms":
+			default:
+				return fmt.Errorf("unsupported server-side encryption: %q", s3Options.SSE)
+			}
+			if s3Options.SSEKMSKeyID != "" && s3Options.SSE != "aws:kms" {
+				return errors.New("--s3-sse-kms-key-id can only be used together with --s3-sse=aws:kms")
+			}
+
+			toS3 := backup.IsS3URL(targetPath)
+			snapshotDir := targetPath
+			if toS3 {
+				if snapshotDir, err = os.MkdirTemp("", "k0s-etcd-snapshot-*"); err != nil {
+					return err
+				}
+				defer os.RemoveAll(snapshotDir)
+			} else if !dir.IsDirectory(targetPath) {
+				return fmt.Errorf("the target-path directory (%s) does not exist", targetPath)
+			}
+
+			etcdClient, err := etcd.NewClient(opts.K0sVars.CertRootDir, opts.K0sVars.EtcdCertDir, nodeConfig.Spec.Storage.Etcd)
+			if err != nil {
+				return fmt.Errorf("can't connect to the etcd: %w", err)
+			}
+			defer etcdClient.Close()
+
+			snapshotPath := filepath.Join(snapshotDir, snapshotFileName(time.Now()))
+			version, err := etcdClient.SaveSnapshot(cmd.Context(), snapshotPath)
+			if err != nil {
+				return err
+			}
+			logrus.Debugf("Saved snapshot of etcd %s to %s", version, snapshotPath)
+
+			if compress {
+				if snapshotPath, err = compressFile(snapshotPath); err != nil {
+					return err
+				}
+			}
+
+			if toS3 {
+				if snapshotPath, err = backup.UploadToS3(cmd.Context(), s3Options, targetPath, snapshotPath); err != nil {
+					return err
+				}
+			}
+
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), snapshotPath)
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.AddFlagSet(config.GetPersistentFlagSet())
+	flags.StringVar(&targetPath, "target-path", ".", "destination directory for the snapshot, or s3://bucket/prefix for S3-compatible object storage")
+	flags.BoolVar(&compress, "compress", false, "compress the snapshot with gzip")
+	flags.StringVar(&s3Options.Endpoint, "s3-endpoint", "", "URL of an S3-compatible object storage (default: AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL environment variable, or AWS)")
+	flags.StringVar(&s3Options.Region, "s3-region", "", "region of the S3 bucket (default: AWS_REGION or AWS_DEFAULT_REGION environment variable, AWS profile, or us-east-1)")
+	flags.StringVar(&s3Options.SSE, "s3-sse", "", "server-side encryption for the uploaded snapshot (valid values: AES256, aws:kms)")
+	flags.StringVar(&s3Options.SSEKMSKeyID, "s3-sse-kms-key-id", "", "ID of the KMS key used for aws:kms server-side encryption")
+
+	return cmd
+}
+
+// snapshotFileName returns the file name of a snapshot taken at the given
+// time, in the same format as the names of backup archives.
+func snapshotFileName(now time.Time) string {
+	return "k0s_etcd_snapshot_" + now.UTC().Format("2006-01-02T15_04_05_000Z") + ".db"
+}
+
+// compressFile compresses the given file with gzip and removes it afterwards.
+// Returns the path of the compressed file.
+func compressFile(path string) (string, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	compressedPath := path + ".gz"
+	err = file.WriteAtomically(compressedPath, 0600, func(w io.Writer) error {
+		gz := gzip.NewWriter(w)
+		if _, err := io.Copy(gz, src); err != nil {
+			return err
+		}
+		return gz.Close()
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to compress %s: %w", path, err)
+	}
+
+	return compressedPath, os.Remove(path)
+}
@@ -0,0 +1,42 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotFileName(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 6000000, time.FixedZone("CET", 3600))
+	assert.Equal(t, "k0s_etcd_snapshot_2026-01-02T02_04_05_000Z.db", snapshotFileName(now))
+}
+
+func TestCompressFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.db")
+	require.NoError(t, os.WriteFile(path, []byte("snapshot"), 0600))
+
+	compressedPath, err := compressFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, path+".gz", compressedPath)
+	assert.NoFileExists(t, path)
+
+	f, err := os.Open(compressedPath)
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	data, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot", string(data))
+}
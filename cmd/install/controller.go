@@ -11,6 +11,7 @@ import (
 	"os"
 	"testing/iotest"
 
+	"github.com/k0sproject/k0s/internal/pkg/sysinfo"
 	"github.com/k0sproject/k0s/pkg/config"
 	"github.com/k0sproject/k0s/pkg/install"
 
@@ -54,6 +55,16 @@ With the controller subcommand you can setup a single node cluster by running:
 				return err
 			}
 
+			single, _ := cmd.Flags().GetBool("single")
+			enableWorker, _ := cmd.Flags().GetBool("enable-worker")
+			if err := runPreFlightChecks(installFlags, &sysinfo.K0sSysinfoSpec{
+				ControllerRoleEnabled: true,
+				WorkerRoleEnabled:     single || enableWorker,
+				DataDir:               k0sVars.DataDir,
+			}); err != nil {
+				return err
+			}
+
 			systemUsers := nodeConfig.Spec.Install.SystemUsers
 			homeDir := k0sVars.DataDir
 			if err := install.EnsureControllerUsers(systemUsers, homeDir); err != nil {
@@ -61,10 +72,12 @@ With the controller subcommand you can setup a single node cluster by running:
 			}
 
 			args := append([]string{"controller"}, flagsAndVals...)
-			if err := install.InstallService(args, installFlags.envVars, installFlags.force); err != nil {
+			if err := install.InstallService(args, installFlags.envVars, installFlags.force, &installFlags.unitOpts); err != nil {
 				return fmt.Errorf("failed to install controller service: %w", err)
 			}
 
+			installSELinuxModule(cmd.Context(), k0sVars.DataDir)
+
 			return nil
 		},
 	}
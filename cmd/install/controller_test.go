@@ -43,7 +43,13 @@ With the controller subcommand you can setup a single node cluster by running:
 	
 
 Flags:
-  -c, --config string                                  config file, use '-' to read the config from stdin (default `+defaultConfigPath+`)
+      --autopilot-controlnode-removal-age duration     the time after which autopilot removes the ControlNodes of stale controllers (0 keeps them)
+      --autopilot-controlnode-stale-timeout duration   the time after which autopilot marks controllers as stale that didn't renew their lease (0 disables the staleness detection) (default 5m0s)
+      --cloud-identity-join string                     cloud provider whose signed instance identity is used to join the cluster instead of a join token (valid values: aws, gcp)
+      --cloud-metadata-spot-taint string               effect of the taint that's added to spot instances detected via --labels-from-cloud-metadata (valid values: NoSchedule, PreferNoSchedule, NoExecute)
+      --component-log-files                            write the output of each component to rotated files in <rundir>/logs
+      --component-log-forward string                   forward the output of the components to syslog, syslog+udp://host:port, syslog+tcp://host:port, or a ring of rotated files given as file:<path>
+  -c, --config string                                  config file or directory of config files to be merged, use '-' to read the config from stdin (default `+defaultConfigPath+`)
       --cri-socket string                              container runtime socket to use, default to internal containerd. Format: [remote|docker]:[path-to-socket]
       --data-dir string                                Data Directory for k0s. DO NOT CHANGE for an existing setup, things will break! (default `+defaultDataDir+`)
       --disable-components strings                     disable components (valid items: applier-manager,autopilot,control-api,coredns,csr-approver,endpoint-reconciler,helm,konnectivity-server,kube-controller-manager,kube-proxy,kube-scheduler,metrics-server,network-provider,node-role,system-rbac,windows-node,worker-config)
@@ -52,28 +58,52 @@ Flags:
       --enable-k0s-cloud-provider                      enables the k0s-cloud-provider (default false)
       --enable-metrics-scraper                         enable scraping metrics from the controller components (kube-scheduler, kube-controller-manager)
       --enable-worker                                  enable worker (default false)
+      --expand-config                                  substitute ${ENV_VAR} and ${file:/path} references in the config file
   -h, --help                                           help for controller
       --init-only                                      only initialize controller and exit
       --iptables-mode string                           iptables mode (valid values: nft, legacy, auto). default: auto
+      --join-api-ca-file string                        path to the cluster's CA certificate that's used to verify the k0s join API with --cloud-identity-join, --join-code or --join-request
+      --join-api-fingerprint string                    fingerprint of the cluster's CA certificate that's used to verify the k0s join API with --join-code or --join-request, instead of --join-api-ca-file
+      --join-api-url string                            URL of the k0s join API that's used with --cloud-identity-join, --join-code or --join-request, e.g. https://controller:9443
+      --join-code string                               join code created via 'k0s token create --format=short' that's exchanged for a join token via the k0s join API
+      --join-request                                   join by submitting a certificate signing request via the k0s join API and waiting for its approval, instead of using a join token
+      --join-token-socket-group string                 group whose members may create, list and invalidate join tokens via the status socket, in addition to root
       --k0s-cloud-provider-port int                    the port that k0s-cloud-provider binds on (default 10258)
       --k0s-cloud-provider-update-frequency duration   the frequency of k0s-cloud-provider node updates (default 2m0s)
       --kube-controller-manager-extra-args string      extra args for kube-controller-manager
       --kubelet-extra-args string                      extra args for kubelet
       --kubelet-root-dir string                        Kubelet root directory for k0s
       --labels mapStringString                         Node labels, list of key=value pairs
+      --labels-from-cloud-metadata string              cloud provider whose instance metadata is used to label the node with its region, zone, instance type and whether it's a spot instance (valid values: auto, aws, gcp, azure, openstack)
+      --log-format string                              format of the k0s log (valid values: text, json) (default "text")
   -l, --logging stringToString                         Logging Levels for the different components (default [containerd=info,etcd=info,konnectivity-server=1,kube-apiserver=1,kube-controller-manager=1,kube-scheduler=1,kubelet=1])
       --no-taints                                      disable default taints for controller node
+      --node-name-template string                      template for the node name that's bound at the node's first start, e.g. pool-{{.RandomID}} (fields: Hostname, MachineID, RandomID)
       --profile string                                 worker profile to use on the node (default "default")
+      --reconcile-labels-and-taints                    reconcile the node's labels and taints with --labels and --taints on each start
+      --reconcile-prefix string                        key prefix of the labels and taints that are removed from the node if they're not given in --labels and --taints
       --single                                         enable single node (implies --enable-worker, default false)
       --status-socket string                           Full file path to the socket file. (default: <rundir>/status.sock)
+      --strict-config                                  reject unknown fields in the config file
       --taints strings                                 Node taints, list of key=value:effect strings
       --token-file string                              Path to the file containing join-token.
+      --verify-kubelet-serving-csrs                    verify the node identity and the SANs of kubelet serving CSRs against the Node objects before approving them
+      --worker-join-token-rotation duration            the validity of the worker join tokens that are rotated automatically and published in the kube-system/k0s-worker-join-token secret (0 disables the rotation)
 
 Global Flags:
-  -d, --debug                  Debug logging (implies verbose logging)
-      --debugListenOn string   Http listenOn for Debug pprof handler (default ":6060")
-  -e, --env stringArray        set environment variable
-      --force                  force init script creation
-  -v, --verbose                Verbose logging
+      --after stringArray           start k0s after the given systemd unit
+      --cpu-quota string            set the CPU quota of the k0s systemd unit, e.g. 200%
+  -d, --debug                       Debug logging (implies verbose logging)
+      --debugListenOn string        Http listenOn for Debug pprof handler (default ":6060")
+  -e, --env stringArray             set environment variable
+      --env-file stringArray        set systemd environment file (prefix with - to ignore missing files)
+      --fix                         fix the host issues that are found at install time, e.g. load kernel modules and set sysctls persistently
+      --force                       force init script creation
+      --ignore-pre-flight-checks    install even if pre-flight checks fail
+      --init-system string          init system that manages the k0s service (valid values: auto, openrc, runit, s6, systemd, sysv, upstart) (default "auto")
+      --memory-max string           set the memory limit of the k0s systemd unit, e.g. 4G
+  -v, --verbose                     Verbose logging
+      --wants stringArray           start the given systemd unit along with k0s
+      --watchdog-timeout duration   let systemd restart k0s if it becomes unresponsive for the given time
 `, out.String())
 }
@@ -6,13 +6,18 @@ package install
 import (
 	"github.com/k0sproject/k0s/cmd/internal"
 	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/install"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
 type installFlags struct {
-	force   bool
-	envVars []string
+	force                 bool
+	envVars               []string
+	initSystem            string
+	unitOpts              install.UnitOptions
+	fix                   bool
+	ignorePreFlightChecks bool
 }
 
 func NewInstallCmd() *cobra.Command {
@@ -22,11 +27,14 @@ func NewInstallCmd() *cobra.Command {
 	)
 
 	cmd := &cobra.Command{
-		Use:              "install",
-		Short:            "Install k0s on a brand-new system. Must be run as root (or with sudo)",
-		Args:             cobra.NoArgs,
-		PersistentPreRun: debugFlags.Run,
-		RunE:             func(*cobra.Command, []string) error { return pflag.ErrHelp }, // Enforce arg validation
+		Use:   "install",
+		Short: "Install k0s on a brand-new system. Must be run as root (or with sudo)",
+		Args:  cobra.NoArgs,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			debugFlags.Run(cmd, args)
+			return install.SelectInitSystem(installFlags.initSystem)
+		},
+		RunE: func(*cobra.Command, []string) error { return pflag.ErrHelp }, // Enforce arg validation
 	}
 
 	pflags := cmd.PersistentFlags()
@@ -38,6 +46,15 @@ func NewInstallCmd() *cobra.Command {
 	})
 	pflags.BoolVar(&installFlags.force, "force", false, "force init script creation")
 	pflags.StringArrayVarP(&installFlags.envVars, "env", "e", nil, "set environment variable")
+	pflags.BoolVar(&installFlags.fix, "fix", false, "fix the host issues that are found at install time, e.g. load kernel modules and set sysctls persistently")
+	pflags.BoolVar(&installFlags.ignorePreFlightChecks, "ignore-pre-flight-checks", false, "install even if pre-flight checks fail")
+	pflags.StringVar(&installFlags.initSystem, "init-system", install.AutoInitSystem, internal.InitSystemFlagUsage())
+	pflags.StringArrayVar(&installFlags.unitOpts.EnvironmentFiles, "env-file", nil, "set systemd environment file (prefix with - to ignore missing files)")
+	pflags.StringArrayVar(&installFlags.unitOpts.After, "after", nil, "start k0s after the given systemd unit")
+	pflags.StringArrayVar(&installFlags.unitOpts.Wants, "wants", nil, "start the given systemd unit along with k0s")
+	pflags.StringVar(&installFlags.unitOpts.CPUQuota, "cpu-quota", "", "set the CPU quota of the k0s systemd unit, e.g. 200%")
+	pflags.StringVar(&installFlags.unitOpts.MemoryMax, "memory-max", "", "set the memory limit of the k0s systemd unit, e.g. 4G")
+	pflags.DurationVar(&installFlags.unitOpts.WatchdogTimeout, "watchdog-timeout", 0, "let systemd restart k0s if it becomes unresponsive for the given time")
 
 	cmd.AddCommand(installWorkerCmd(&installFlags))
 	addPlatformSpecificCommands(cmd, &installFlags)
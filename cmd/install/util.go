@@ -4,11 +4,17 @@
 package install
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
 
+	"github.com/k0sproject/k0s/internal/pkg/sysinfo"
+	"github.com/k0sproject/k0s/pkg/install"
+	"github.com/k0sproject/k0s/pkg/selinux"
+
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -24,7 +30,7 @@ func cmdFlagsToArgs(cmd *cobra.Command) ([]string, error) {
 			flagsAndVals = append(flagsAndVals, fmt.Sprintf(`--%s=%s`, f.Name, strings.Trim(val, "[]")))
 		default:
 			switch f.Name {
-			case "env", "force":
+			case "env", "force", "fix", "ignore-pre-flight-checks", "init-system", "env-file", "after", "wants", "cpu-quota", "memory-max", "watchdog-timeout":
 				return
 			case "data-dir", "kubelet-root-dir", "token-file", "config":
 				if absVal, err := filepath.Abs(val); err != nil {
@@ -44,3 +50,40 @@ func cmdFlagsToArgs(cmd *cobra.Command) ([]string, error) {
 
 	return flagsAndVals, nil
 }
+
+// installSELinuxModule installs the SELinux policy module of k0s if SELinux is
+// enabled. Failures are only logged, since k0s may still be usable, e.g. when
+// SELinux is permissive.
+func installSELinuxModule(ctx context.Context, dataDir string) {
+	if !selinux.Enabled() {
+		return
+	}
+
+	logrus.Info("Installing SELinux policy module")
+	if err := selinux.InstallModule(ctx, dataDir); err != nil {
+		logrus.WithError(err).Warn(`Failed to install the SELinux policy module, run "k0s selinux install" once the problem has been resolved`)
+	}
+}
+
+// runPreFlightChecks runs the pre-flight checks for the given roles, and looks
+// for host issues that would only surface once k0s is running. The issues are
+// fixed if requested, otherwise they're only reported.
+func runPreFlightChecks(installFlags *installFlags, spec *sysinfo.K0sSysinfoSpec) error {
+	if err := spec.RunPreFlightChecks(installFlags.ignorePreFlightChecks); !installFlags.ignorePreFlightChecks && err != nil {
+		return fmt.Errorf("%w (use --ignore-pre-flight-checks to install anyways)", err)
+	}
+
+	for _, issue := range install.CheckHost(spec.WorkerRoleEnabled) {
+		if !installFlags.fix {
+			logrus.Warnf("%s, use --fix to remediate: %s", issue.Description, issue.Remediation)
+			continue
+		}
+
+		logrus.Infof("%s, remediating: %s", issue.Description, issue.Remediation)
+		if err := issue.Fix(); err != nil {
+			return fmt.Errorf("failed to remediate: %s: %w", issue.Description, err)
+		}
+	}
+
+	return nil
+}
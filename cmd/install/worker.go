@@ -11,6 +11,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/k0sproject/k0s/internal/pkg/sysinfo"
 	"github.com/k0sproject/k0s/pkg/config"
 	"github.com/k0sproject/k0s/pkg/install"
 )
@@ -32,11 +33,25 @@ All default values of worker command will be passed to the service stub unless o
 				return err
 			}
 
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+
+			if err := runPreFlightChecks(installFlags, &sysinfo.K0sSysinfoSpec{
+				WorkerRoleEnabled: true,
+				DataDir:           opts.K0sVars.DataDir,
+			}); err != nil {
+				return err
+			}
+
 			args := append([]string{"worker"}, flagsAndVals...)
-			if err := install.InstallService(args, installFlags.envVars, installFlags.force); err != nil {
+			if err := install.InstallService(args, installFlags.envVars, installFlags.force, &installFlags.unitOpts); err != nil {
 				return fmt.Errorf("failed to install worker service: %w", err)
 			}
 
+			installSELinuxModule(cmd.Context(), opts.K0sVars.DataDir)
+
 			return nil
 		},
 	}
@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"strings"
+
+	"github.com/k0sproject/k0s/pkg/install"
+)
+
+// InitSystemFlagUsage returns the usage of the --init-system flag.
+func InitSystemFlagUsage() string {
+	supported := append([]string{install.AutoInitSystem}, install.InitSystems()...)
+	return "init system that manages the k0s service (valid values: " + strings.Join(supported, ", ") + ")"
+}
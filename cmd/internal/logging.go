@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	internallog "github.com/k0sproject/k0s/internal/pkg/log"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/supervisor"
+)
+
+// ConfigureLogging applies the log format to the k0s log, and sets up the
+// capture and forwarding of the output of the supervised processes.
+func ConfigureLogging(opts *config.WorkerOptions) error {
+	if err := internallog.SetFormat(opts.LogFormat); err != nil {
+		return err
+	}
+	return supervisor.ConfigureLogs(supervisor.LogOptions{
+		Files:   opts.ComponentLogFiles,
+		Forward: opts.ComponentLogForward,
+	})
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/daemon"
+	"github.com/sirupsen/logrus"
+)
+
+// StartSystemdWatchdog notifies systemd periodically that k0s is alive, if the
+// k0s unit has been installed with a watchdog timeout. The notifications stop
+// when the given context is done.
+func StartSystemdWatchdog(ctx context.Context) {
+	// Unset the watchdog environment variables, so that they aren't inherited
+	// by the components that k0s is running.
+	timeout, err := daemon.SdWatchdogEnabled(true)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to determine systemd watchdog timeout")
+		return
+	}
+	if timeout == 0 {
+		return
+	}
+
+	logrus.Debugf("Notifying systemd watchdog every %s", timeout/2)
+	go func() {
+		ticker := time.NewTicker(timeout / 2)
+		defer ticker.Stop()
+		for {
+			if _, err := daemon.SdNotify(false, daemon.SdNotifyWatchdog); err != nil {
+				logrus.WithError(err).Warn("Failed to notify systemd watchdog")
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
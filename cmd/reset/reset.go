@@ -1,4 +1,4 @@
-//go:build linux
+//go:build linux || windows
 
 // SPDX-FileCopyrightText: 2021 k0s authors
 // SPDX-License-Identifier: Apache-2.0
@@ -6,23 +6,47 @@
 package reset
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/k0sproject/k0s/cmd/internal"
+	"github.com/k0sproject/k0s/internal/pkg/file"
 	"github.com/k0sproject/k0s/pkg/cleanup"
-	"github.com/k0sproject/k0s/pkg/component/status"
 	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/etcd"
 
+	"github.com/dustin/go-humanize"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
 type command config.CLIOptions
 
+// resetFlags are the flags that control what's being reset.
+type resetFlags struct {
+	debug      bool
+	preserve   []string
+	backupEtcd string
+	purge      bool
+	podLogs    bool
+	report     string
+}
+
 func NewResetCmd() *cobra.Command {
-	var debugFlags internal.DebugFlags
+	var (
+		debugFlags   internal.DebugFlags
+		dryRun       bool
+		outputFormat string
+		flags        resetFlags
+	)
 
 	cmd := &cobra.Command{
 		Use:              "reset",
@@ -35,48 +59,246 @@ func NewResetCmd() *cobra.Command {
 				return err
 			}
 			c := (*command)(opts)
-			return c.reset(debugFlags.IsDebug())
+			flags.debug = debugFlags.IsDebug()
+			if dryRun {
+				if flags.report != "" {
+					return errors.New("--report can't be used together with --dry-run, use --output instead")
+				}
+				return c.dryRun(&flags, cmd.OutOrStdout(), outputFormat)
+			}
+			if cmd.Flags().Changed("output") {
+				return errors.New("--output can only be used together with --dry-run")
+			}
+			return c.reset(&flags, cmd.OutOrStdout())
 		},
 	}
 
 	debugFlags.AddToFlagSet(cmd.PersistentFlags())
 
-	flags := cmd.Flags()
-	flags.AddFlagSet(config.GetPersistentFlagSet())
-	flags.AddFlagSet(config.GetCriSocketFlag())
-	flags.AddFlagSet(config.FileInputFlag())
-	flags.String("kubelet-root-dir", "", "Kubelet root directory for k0s")
+	pflags := cmd.Flags()
+	pflags.AddFlagSet(config.GetPersistentFlagSet())
+	pflags.AddFlagSet(config.GetCriSocketFlag())
+	pflags.AddFlagSet(config.FileInputFlag())
+	pflags.String("kubelet-root-dir", "", "Kubelet root directory for k0s")
+	pflags.BoolVar(&dryRun, "dry-run", false, "Only report what would be removed, without changing anything")
+	pflags.StringVarP(&outputFormat, "output", "o", "text", "Output format of the dry-run report (valid values: text, json)")
+	pflags.StringSliceVar(&flags.preserve, "preserve", nil, "State to keep on the node (valid values: "+strings.Join(cleanup.PreservableStates, ", ")+")")
+	pflags.StringVar(&flags.backupEtcd, "backup-etcd", "", "Save a snapshot of the etcd data to the given file or directory before the reset (defaults to the current directory)")
+	pflags.Lookup("backup-etcd").NoOptDefVal = "."
+	pflags.BoolVar(&flags.purge, "purge", false, "Also remove the log files of the k0s service and the groups of the k0s system users")
+	pflags.BoolVar(&flags.podLogs, "purge-pod-logs", false, "Also remove the logs of all pods and containers")
+	pflags.StringVar(&flags.report, "report", "", "Write a JSON report of the reset to the given file (use - for stdout)")
 
 	return cmd
 }
 
-func (c *command) reset(debug bool) error {
-	if os.Geteuid() != 0 {
+// Reset cleans up the node in the same way as k0s reset, using the given
+// options and the default reset flags, except for purging.
+func Reset(opts *config.CLIOptions, debug, purge bool, out io.Writer) error {
+	return (*command)(opts).reset(&resetFlags{debug: debug, purge: purge}, out)
+}
+
+func (c *command) reset(flags *resetFlags, out io.Writer) (err error) {
+	var result *cleanup.Result
+	if flags.report != "" {
+		defer func() {
+			if result == nil {
+				result = &cleanup.Result{Steps: []cleanup.StepResult{}}
+			}
+			result.Success = err == nil
+			if err != nil {
+				result.Error = err.Error()
+			}
+			if reportErr := writeResult(flags.report, out, result); reportErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to write reset report: %w", reportErr))
+			}
+		}()
+	}
+
+	if runtime.GOOS != "windows" && os.Geteuid() != 0 {
 		return errors.New("this command must be run as root")
 	}
 
-	k0sStatus, _ := status.GetStatusInfo(c.K0sVars.StatusSocketPath)
-	if k0sStatus != nil && k0sStatus.Pid != 0 {
+	if c.isK0sRunning() {
 		return errors.New("k0s seems to be running, please stop k0s before reset")
 	}
 
-	nodeCfg, err := c.K0sVars.NodeConfig()
+	cfg, err := c.cleanupConfig(flags)
+	if err != nil {
+		return err
+	}
+
+	var snapshotPath string
+	if flags.backupEtcd != "" {
+		if snapshotPath, err = c.etcdSnapshotPath(flags.backupEtcd); err != nil {
+			return err
+		}
+		if snapshotPath != "" {
+			logrus.Info("Saving etcd snapshot to ", snapshotPath)
+			if err := etcd.SaveDataDirSnapshot(c.K0sVars.EtcdDataDir, snapshotPath); err != nil {
+				return fmt.Errorf("failed to save etcd snapshot, not resetting: %w", err)
+			}
+		}
+	}
+
+	result, err = cfg.Cleanup(flags.report != "")
+	result.EtcdSnapshot = snapshotPath
+	logrus.Info("k0s cleanup operations done.")
+	logrus.Warn("To ensure a full reset, a node reboot is recommended.")
+
+	return err
+}
+
+// writeResult writes the reset result as JSON to the given file, or to out if
+// the path is "-".
+func writeResult(path string, out io.Writer, result *cleanup.Result) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if path == "-" {
+		_, err := out.Write(data)
+		return err
+	}
+	return file.WriteContentAtomically(path, data, 0644)
+}
+
+func (c *command) dryRun(flags *resetFlags, out io.Writer, outputFormat string) error {
+	if outputFormat != "text" && outputFormat != "json" {
+		return fmt.Errorf("unknown output format: %q", outputFormat)
+	}
+
+	if runtime.GOOS != "windows" && os.Geteuid() != 0 {
+		return errors.New("this command must be run as root")
+	}
+
+	if c.isK0sRunning() {
+		logrus.Warn("k0s seems to be running, it needs to be stopped before reset")
+	}
+
+	cfg, err := c.cleanupConfig(flags)
 	if err != nil {
 		return err
 	}
+
+	var snapshotPath string
+	if flags.backupEtcd != "" {
+		if snapshotPath, err = c.etcdSnapshotPath(flags.backupEtcd); err != nil {
+			return err
+		}
+	}
+
+	report, err := cfg.DryRun()
+	report.EtcdSnapshot = snapshotPath
+	if outputFormat == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(report); encodeErr != nil {
+			return errors.Join(err, encodeErr)
+		}
+	} else if printErr := printReport(out, report); printErr != nil {
+		return errors.Join(err, printErr)
+	}
+
+	return err
+}
+
+func (c *command) cleanupConfig(flags *resetFlags) (*cleanup.Config, error) {
+	nodeCfg, err := c.K0sVars.NodeConfig()
+	if err != nil {
+		return nil, err
+	}
 	if nodeCfg.Spec.Storage.Kine != nil && nodeCfg.Spec.Storage.Kine.DataSource != "" {
 		logrus.Warn("Kine dataSource is configured. k0s will not reset the data source if it points to an external database. If you plan to continue using the data source, you should reset it to avoid conflicts.")
 	}
 
 	// Get Cleanup Config
-	cfg, err := cleanup.NewConfig(debug, c.K0sVars, nodeCfg.Spec.Install.SystemUsers, c.CriSocket)
+	cfg, err := cleanup.NewConfig(flags.debug, c.K0sVars, nodeCfg.Spec.Install.SystemUsers, c.CriSocket, flags.preserve, flags.purge, flags.podLogs)
 	if err != nil {
-		return fmt.Errorf("failed to configure cleanup: %w", err)
+		return nil, fmt.Errorf("failed to configure cleanup: %w", err)
 	}
 
-	err = cfg.Cleanup()
-	logrus.Info("k0s cleanup operations done.")
-	logrus.Warn("To ensure a full reset, a node reboot is recommended.")
+	return cfg, nil
+}
+
+// etcdSnapshotPath returns the file to which the etcd snapshot is to be saved.
+// If the given path is a directory, the snapshot is saved to a timestamped
+// file in that directory. An empty path is returned if there's no etcd data
+// on this node.
+func (c *command) etcdSnapshotPath(path string) (string, error) {
+	if _, err := os.Stat(etcd.DataDirDB(c.K0sVars.EtcdDataDir)); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			logrus.Warnf("No etcd data found in %s, not saving an etcd snapshot", c.K0sVars.EtcdDataDir)
+			return "", nil
+		}
+		return "", err
+	}
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	if stat, err := os.Stat(path); err == nil && stat.IsDir() {
+		path = filepath.Join(path, "k0s-etcd-snapshot-"+time.Now().UTC().Format("20060102T150405Z")+".db")
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	for _, dir := range []string{c.K0sVars.DataDir, c.K0sVars.RunDir, c.K0sVars.KubeletRootDir} {
+		if rel, err := filepath.Rel(dir, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("the etcd snapshot can't be saved to %s, since it's removed during reset", path)
+		}
+	}
+
+	return path, nil
+}
+
+// printReport prints the dry-run report in a human readable form.
+func printReport(out io.Writer, report *cleanup.Report) error {
+	var b strings.Builder
+	for _, section := range []struct {
+		title string
+		items []string
+		goos  string // all operating systems if empty
+	}{
+		{"Mount points to be unmounted", report.Unmounts, "linux"},
+		{"Processes to be terminated", report.Processes, "windows"},
+		{"Directories to be removed", report.Directories, ""},
+		{"Files to be removed", report.Files, ""},
+		{"Services to be uninstalled", report.Services, ""},
+		{"Users to be deleted", report.Users, "linux"},
+		{"Groups to be deleted", report.Groups, "linux"},
+		{"Containers to be stopped and removed", report.Containers, "linux"},
+		{"Network namespaces to be removed", report.NetworkNamespaces, "linux"},
+		{"Network interfaces to be deleted", report.Interfaces, "linux"},
+		{"iptables chains to be deleted", report.IPTablesChains, "linux"},
+		{"IPVS virtual servers to be deleted", report.IPVSServices, "linux"},
+		{"HNS load balancers to be deleted", report.HNSLoadBalancers, "windows"},
+		{"HNS networks to be deleted", report.HNSNetworks, "windows"},
+	} {
+		if section.goos != "" && section.goos != runtime.GOOS {
+			continue
+		}
+		fmt.Fprintf(&b, "%s:\n", section.title)
+		if len(section.items) == 0 {
+			b.WriteString("  (none)\n")
+		}
+		for _, item := range section.items {
+			fmt.Fprintf(&b, "  %s\n", item)
+		}
+	}
+
+	if report.PodLogsSize > 0 {
+		fmt.Fprintf(&b, "Size of the pod logs to be removed:\n  %s\n", humanize.IBytes(uint64(report.PodLogsSize)))
+	}
+
+	if report.EtcdSnapshot != "" {
+		fmt.Fprintf(&b, "etcd snapshot to be saved:\n  %s\n", report.EtcdSnapshot)
+	}
 
+	_, err := io.WriteString(out, b.String())
 	return err
 }
@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reset
+
+import "github.com/k0sproject/k0s/pkg/component/status"
+
+func (c *command) isK0sRunning() bool {
+	k0sStatus, _ := status.GetStatusInfo(c.K0sVars.StatusSocketPath)
+	return k0sStatus != nil && k0sStatus.Pid != 0
+}
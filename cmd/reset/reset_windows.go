@@ -0,0 +1,10 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reset
+
+// isK0sRunning always returns false on Windows, as there's no status socket.
+// The cleanup stops the k0s service instead.
+func (c *command) isK0sRunning() bool {
+	return false
+}
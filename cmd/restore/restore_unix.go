@@ -6,6 +6,7 @@
 package restore
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -21,6 +22,8 @@ import (
 	"github.com/k0sproject/k0s/pkg/config"
 	"github.com/k0sproject/k0s/pkg/constant"
 
+	"k8s.io/kubectl/pkg/util/term"
+
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -28,17 +31,27 @@ import (
 type command struct {
 	*config.CLIOptions
 	restoredConfigPath string
+	s3Options          backup.S3Options
+	passphraseFile     string
+	identityFile       string
+	dryRun             bool
+	remap              backup.NodeRemapOptions
 }
 
 func NewRestoreCmd() *cobra.Command {
 	var (
 		debugFlags         internal.DebugFlags
 		restoredConfigPath string
+		s3Options          backup.S3Options
+		passphraseFile     string
+		identityFile       string
+		dryRun             bool
+		remap              backup.NodeRemapOptions
 	)
 
 	cmd := &cobra.Command{
 		Use:              "restore filename",
-		Short:            "restore k0s state from given backup archive. Use '-' as filename to read from stdin. Must be run as root (or with sudo)",
+		Short:            "restore k0s state from given backup archive. Use '-' as filename to read from stdin, or an s3://bucket/key URL to read from S3-compatible object storage. Must be run as root (or with sudo)",
 		Args:             cobra.ExactArgs(1),
 		PersistentPreRun: debugFlags.Run,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -47,9 +60,9 @@ func NewRestoreCmd() *cobra.Command {
 				return err
 			}
 
-			c := command{opts, restoredConfigPath}
+			c := command{opts, restoredConfigPath, s3Options, passphraseFile, identityFile, dryRun, remap}
 
-			return c.restore(args[0], cmd.OutOrStdout())
+			return c.restore(cmd.Context(), args[0], cmd.InOrStdin(), cmd.OutOrStdout())
 		},
 	}
 
@@ -58,25 +71,36 @@ func NewRestoreCmd() *cobra.Command {
 	flags := cmd.Flags()
 	flags.AddFlagSet(config.GetPersistentFlagSet())
 	flags.StringVar(&restoredConfigPath, "config-out", "", "Specify desired name and full path for the restored k0s.yaml file (default: k0s_<archive timestamp>.yaml")
+	flags.StringVar(&s3Options.Endpoint, "s3-endpoint", "", "URL of an S3-compatible object storage (default: AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL environment variable, or AWS)")
+	flags.StringVar(&s3Options.Region, "s3-region", "", "region of the S3 bucket (default: AWS_REGION or AWS_DEFAULT_REGION environment variable, AWS profile, or us-east-1)")
+	flags.StringVar(&passphraseFile, "passphrase-file", "", "file containing the passphrase to decrypt the backup archive (default: "+backup.PassphraseEnvVar+" environment variable)")
+	flags.StringVar(&identityFile, "identity-file", "", "age identity file containing the keys to decrypt the backup archive")
+	flags.BoolVar(&dryRun, "dry-run", false, "check whether the backup archive can be restored onto this node, without changing anything")
+	flags.StringVar(&remap.Address, "address", "", "IP address of this controller, replacing the backed-up API and etcd peer addresses and SANs in the restored k0s.yaml, when restoring onto a different host")
+	flags.BoolVar(&remap.RegenerateCertificates, "regenerate-certs", false, "remove all certificates issued by the backed-up CAs, so that k0s issues them anew for this controller when it starts")
 
 	return cmd
 }
 
-func (c *command) restore(path string, out io.Writer) error {
+func (c *command) restore(ctx context.Context, path string, in io.Reader, out io.Writer) error {
 	if os.Geteuid() != 0 {
 		return errors.New("this command must be run as root")
 	}
 
 	k0sStatus, _ := status.GetStatusInfo(c.K0sVars.StatusSocketPath)
-	if k0sStatus != nil && k0sStatus.Pid != 0 {
+	k0sRunning := k0sStatus != nil && k0sStatus.Pid != 0
+	if k0sRunning && !c.dryRun {
 		logrus.Fatal("k0s seems to be running! k0s must be down during the restore operation.")
 	}
 
-	if path != "-" && !file.Exists(path) {
+	if path == "-" && term.IsTerminal(in) {
+		return errors.New("cowardly refusing to read binary data from a terminal")
+	}
+	if path != "-" && !backup.IsS3URL(path) && !file.Exists(path) {
 		return fmt.Errorf("given file %s does not exist", path)
 	}
 
-	if !dir.IsDirectory(c.K0sVars.DataDir) {
+	if !c.dryRun && !dir.IsDirectory(c.K0sVars.DataDir) {
 		if err := dir.Init(c.K0sVars.DataDir, constant.DataDirMode); err != nil {
 			return err
 		}
@@ -86,10 +110,40 @@ func (c *command) restore(path string, out io.Writer) error {
 	if err != nil {
 		return err
 	}
+	mgr.S3 = c.s3Options
+	mgr.Stdin = in
+	mgr.Remap = c.remap
+	if mgr.Encryption.Passphrase, err = backup.LoadPassphrase(c.passphraseFile); err != nil {
+		return err
+	}
+	if c.identityFile != "" {
+		f, err := os.Open(c.identityFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if mgr.Encryption.Identities, err = backup.ParseAgeIdentities(f); err != nil {
+			return fmt.Errorf("failed to parse identity file: %w", err)
+		}
+	}
 	if c.restoredConfigPath == "" {
 		c.restoredConfigPath = defaultConfigFileOutputPath(path)
 	}
-	return mgr.RunRestore(path, c.K0sVars, c.restoredConfigPath, out)
+
+	if c.dryRun {
+		report := mgr.DryRunRestore(ctx, path, c.K0sVars, c.restoredConfigPath)
+		if k0sRunning {
+			report.Errors = append(report.Errors, "k0s is running, it must be down during the restore operation")
+		}
+		report.Print(out)
+		if !report.OK() {
+			return errors.New("pre-flight checks failed")
+		}
+		fmt.Fprintln(out, "Pre-flight checks passed")
+		return nil
+	}
+
+	return mgr.RunRestore(ctx, path, c.K0sVars, c.restoredConfigPath, out)
 }
 
 // set output config file name and path according to input archive Timestamps
@@ -4,21 +4,29 @@
 package cmd
 
 import (
+	"github.com/k0sproject/k0s/cmd/apiserver"
 	"github.com/k0sproject/k0s/cmd/backup"
 	"github.com/k0sproject/k0s/cmd/controller"
 	"github.com/k0sproject/k0s/cmd/keepalived"
 	"github.com/k0sproject/k0s/cmd/reset"
 	"github.com/k0sproject/k0s/cmd/restore"
+	"github.com/k0sproject/k0s/cmd/selinux"
 	"github.com/k0sproject/k0s/cmd/status"
+	"github.com/k0sproject/k0s/cmd/uninstall"
+	"github.com/k0sproject/k0s/cmd/upgrade"
 
 	"github.com/spf13/cobra"
 )
 
 func addPlatformSpecificCommands(root *cobra.Command) {
+	root.AddCommand(apiserver.NewAPIServerCmd())
 	root.AddCommand(backup.NewBackupCmd())
 	root.AddCommand(controller.NewControllerCmd())
 	root.AddCommand(keepalived.NewKeepalivedSetStateCmd()) // hidden
 	root.AddCommand(reset.NewResetCmd())
 	root.AddCommand(restore.NewRestoreCmd())
+	root.AddCommand(selinux.NewSELinuxCmd())
 	root.AddCommand(status.NewStatusCmd())
+	root.AddCommand(uninstall.NewUninstallCmd())
+	root.AddCommand(upgrade.NewUpgradeCmd())
 }
@@ -65,11 +65,14 @@ func TestUnknownSubCommandsAreRejected(t *testing.T) {
 	commandsWithArguments := []string{
 		"airgap bundle-artifacts",
 		"kubeconfig create",
+		"token approve",
+		"token deny",
 		"token invalidate",
 		"worker",
 	}
 	if runtime.GOOS == "linux" {
 		commandsWithArguments = append(commandsWithArguments,
+			"backup verify",
 			"controller",
 			"restore",
 		)
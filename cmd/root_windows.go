@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/k0sproject/k0s/cmd/reset"
+	"github.com/k0sproject/k0s/cmd/uninstall"
+
+	"github.com/spf13/cobra"
+)
+
+func addPlatformSpecificCommands(root *cobra.Command) {
+	root.AddCommand(reset.NewResetCmd())
+	root.AddCommand(uninstall.NewUninstallCmd())
+}
@@ -0,0 +1,109 @@
+//go:build linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package selinux
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/k0sproject/k0s/cmd/internal"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/selinux"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func NewSELinuxCmd() *cobra.Command {
+	var debugFlags internal.DebugFlags
+
+	cmd := &cobra.Command{
+		Use:   "selinux",
+		Short: "Manage the SELinux policy module of k0s",
+		Long: `Manage the SELinux policy module of k0s.
+
+The policy module labels the container runtime binaries and state in the k0s
+data directory, so that they're confined by the container-selinux policy.`,
+		Args:             cobra.NoArgs,
+		PersistentPreRun: debugFlags.Run,
+		RunE:             func(*cobra.Command, []string) error { return pflag.ErrHelp }, // Enforce arg validation
+	}
+
+	debugFlags.AddToFlagSet(cmd.PersistentFlags())
+
+	cmd.AddCommand(newSELinuxInstallCmd())
+	cmd.AddCommand(newSELinuxVerifyCmd())
+
+	return cmd
+}
+
+func newSELinuxInstallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install or upgrade the SELinux policy module of k0s and relabel the data directory. Must be run as root (or with sudo)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if os.Geteuid() != 0 {
+				return errors.New("this command must be run as root")
+			}
+			if !selinux.Enabled() {
+				return errors.New("SELinux is not enabled on this host")
+			}
+
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+
+			if err := selinux.InstallModule(cmd.Context(), opts.K0sVars.DataDir); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "SELinux policy module %s installed for %s\n", selinux.ModuleName, opts.K0sVars.DataDir)
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(config.GetPersistentFlagSet())
+
+	return cmd
+}
+
+func newSELinuxVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the SELinux labels of the k0s data directory",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !selinux.Enabled() {
+				return errors.New("SELinux is not enabled on this host")
+			}
+
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+
+			mismatches, err := selinux.VerifyLabels(opts.K0sVars.DataDir)
+			if err != nil {
+				return err
+			}
+			if len(mismatches) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "SELinux labels are correct")
+				return nil
+			}
+
+			for _, mismatch := range mismatches {
+				fmt.Fprintln(cmd.OutOrStdout(), mismatch.String())
+			}
+			return errors.New(`SELinux labels are incorrect, run "k0s selinux install" to fix them`)
+		},
+	}
+
+	cmd.Flags().AddFlagSet(config.GetPersistentFlagSet())
+
+	return cmd
+}
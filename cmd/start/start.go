@@ -16,7 +16,10 @@ import (
 )
 
 func NewStartCmd() *cobra.Command {
-	var debugFlags internal.DebugFlags
+	var (
+		debugFlags internal.DebugFlags
+		initSystem string
+	)
 
 	cmd := &cobra.Command{
 		Use:              "start",
@@ -27,6 +30,9 @@ func NewStartCmd() *cobra.Command {
 			if runtime.GOOS != "windows" && os.Geteuid() != 0 {
 				return errors.New("this command must be run as root")
 			}
+			if err := install.SelectInitSystem(initSystem); err != nil {
+				return err
+			}
 			svc, err := install.InstalledService()
 			if err != nil {
 				return err
@@ -40,6 +46,7 @@ func NewStartCmd() *cobra.Command {
 	}
 
 	debugFlags.AddToFlagSet(cmd.PersistentFlags())
+	cmd.Flags().StringVar(&initSystem, "init-system", install.AutoInitSystem, internal.InitSystemFlagUsage())
 
 	return cmd
 }
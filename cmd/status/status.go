@@ -9,6 +9,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/k0sproject/k0s/cmd/internal"
 	"github.com/k0sproject/k0s/pkg/component/status"
@@ -121,6 +125,28 @@ func printStatus(w io.Writer, status *status.K0sStatus, output string) {
 		if status.StubFile != "" {
 			fmt.Fprintln(w, "Service file:", status.StubFile)
 		}
+		if len(status.CloudMetadataSANs) > 0 {
+			fmt.Fprintln(w, "Cloud metadata SANs:", strings.Join(status.CloudMetadataSANs, ", "))
+		}
+		for _, cert := range status.KubeletCertificates {
+			var overdue string
+			if cert.RotationOverdue {
+				overdue = " (rotation overdue)"
+			}
+			fmt.Fprintf(w, "Kubelet %s certificate expires: %s%s\n", cert.Usage, cert.NotAfter.Format(time.RFC3339), overdue)
+		}
+		for _, name := range slices.Sorted(maps.Keys(status.Degraded)) {
+			fmt.Fprintf(w, "Degraded: gave up restarting %s, it has been %s\n", name, status.Degraded[name])
+		}
+		if status.Backup != nil {
+			fmt.Fprintln(w, "Backup schedule:", status.Backup.Schedule)
+			if status.Backup.LastSuccess != nil {
+				fmt.Fprintln(w, "Last successful backup:", status.Backup.LastSuccess.Format(time.RFC3339), status.Backup.LastArchive)
+			}
+			if status.Backup.LastFailure != nil {
+				fmt.Fprintln(w, "Last failed backup:", status.Backup.LastFailure.Format(time.RFC3339), status.Backup.LastError)
+			}
+		}
 
 	}
 }
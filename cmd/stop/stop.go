@@ -16,7 +16,10 @@ import (
 )
 
 func NewStopCmd() *cobra.Command {
-	var debugFlags internal.DebugFlags
+	var (
+		debugFlags internal.DebugFlags
+		initSystem string
+	)
 
 	cmd := &cobra.Command{
 		Use:              "stop",
@@ -27,6 +30,9 @@ func NewStopCmd() *cobra.Command {
 			if runtime.GOOS != "windows" && os.Geteuid() != 0 {
 				return errors.New("this command must be run as root")
 			}
+			if err := install.SelectInitSystem(initSystem); err != nil {
+				return err
+			}
 			svc, err := install.InstalledService()
 			if err != nil {
 				return err
@@ -43,6 +49,7 @@ func NewStopCmd() *cobra.Command {
 	}
 
 	debugFlags.AddToFlagSet(cmd.PersistentFlags())
+	cmd.Flags().StringVar(&initSystem, "init-system", install.AutoInitSystem, internal.InitSystemFlagUsage())
 
 	return cmd
 }
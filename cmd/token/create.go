@@ -24,7 +24,9 @@ func tokenCreateCmd() *cobra.Command {
 	var (
 		createTokenRole string
 		tokenExpiry     string
+		oneTime         bool
 		waitCreate      bool
+		format          string
 	)
 
 	cmd := &cobra.Command{
@@ -32,10 +34,25 @@ func tokenCreateCmd() *cobra.Command {
 		Short: "Create join token",
 		Example: `k0s token create --role worker --expiry 100h //sets expiration time to 100 hours
 k0s token create --role worker --expiry 10m  //sets expiration time to 10 minutes
+k0s token create --role worker --one-time    //invalidates the token after the first join
+k0s token create --role worker --format short //prints a join code for k0s worker --join-code
 `,
 		Args: cobra.NoArgs,
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
-			return checkTokenRole(createTokenRole)
+			if err := checkTokenRole(createTokenRole); err != nil {
+				return err
+			}
+			switch format {
+			case "token":
+				return nil
+			case "short":
+				if createTokenRole != token.RoleWorker {
+					return errors.New("the short format is only supported for worker tokens")
+				}
+				return nil
+			default:
+				return fmt.Errorf("unsupported format %q; supported formats are %q and %q", format, "token", "short")
+			}
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			opts, err := config.GetCmdOpts(cmd)
@@ -47,6 +64,9 @@ k0s token create --role worker --expiry 10m  //sets expiration time to 10 minute
 			if err != nil {
 				return err
 			}
+			if expiry < 0 {
+				return fmt.Errorf("invalid expiry %s: must not be negative", tokenExpiry)
+			}
 
 			nodeConfig, err := opts.K0sVars.NodeConfig()
 			if err != nil {
@@ -54,6 +74,7 @@ k0s token create --role worker --expiry 10m  //sets expiration time to 10 minute
 			}
 
 			var bootstrapToken string
+			var joinCode *token.JoinCode
 			// we will retry every second for two minutes and then error
 			err = retry.OnError(wait.Backoff{
 				Steps:    120,
@@ -75,12 +96,22 @@ k0s token create --role worker --expiry 10m  //sets expiration time to 10 minute
 					return err
 				}
 
-				bootstrapToken, err = token.CreateKubeletBootstrapToken(cmd.Context(), nodeConfig.Spec.API, opts.K0sVars, createTokenRole, expiry)
+				if format == "short" {
+					joinCode, err = token.CreateJoinCode(cmd.Context(), nodeConfig.Spec.API, opts.K0sVars, expiry, oneTime)
+					return err
+				}
+				bootstrapToken, err = token.CreateKubeletBootstrapToken(cmd.Context(), nodeConfig.Spec.API, opts.K0sVars, createTokenRole, expiry, oneTime)
 				return err
 			})
 			if err != nil {
 				return err
 			}
+			if joinCode != nil {
+				fmt.Fprintln(cmd.OutOrStdout(), "Join code:  ", joinCode.Code)
+				fmt.Fprintln(cmd.OutOrStdout(), "Fingerprint:", joinCode.Fingerprint)
+				fmt.Fprintln(cmd.OutOrStdout(), "Join API:   ", joinCode.JoinAPIURL)
+				return nil
+			}
 			fmt.Fprintln(cmd.OutOrStdout(), bootstrapToken)
 			return nil
 		},
@@ -90,7 +121,9 @@ k0s token create --role worker --expiry 10m  //sets expiration time to 10 minute
 	flags.AddFlagSet(config.GetPersistentFlagSet())
 	flags.StringVar(&tokenExpiry, "expiry", "0s", "Expiration time of the token. Format 1.5h, 2h45m or 300ms.")
 	flags.StringVar(&createTokenRole, "role", "worker", "Either worker or controller")
+	flags.BoolVar(&oneTime, "one-time", false, "invalidate the token after the first successful join")
 	flags.BoolVar(&waitCreate, "wait", false, "wait forever (default false)")
+	flags.StringVar(&format, "format", "token", "Either token for a full join token, or short for a join code that can be typed in via k0s worker --join-code")
 
 	return cmd
 }
@@ -21,8 +21,14 @@ func tokenListCmd() *cobra.Command {
 	var listTokenRole string
 
 	cmd := &cobra.Command{
-		Use:     "list",
-		Short:   "List join tokens",
+		Use:   "list",
+		Short: "List join tokens",
+		Long: `List join tokens.
+
+Shows when and by whom the tokens have been created, and when and by which node
+they have been used last. Nodes that used worker tokens are shown by their name,
+controllers that used controller tokens by their address. Tokens that can't be
+used for exactly one role are shown with the role unknown.`,
 		Example: `k0s token list --role worker // list worker tokens`,
 		Args:    cobra.NoArgs,
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
@@ -66,7 +72,11 @@ func printTokens(writer io.Writer, tokens []token.Token, listTokenRole string) {
 		ColumnDefinitions: []metav1.TableColumnDefinition{
 			{Name: "ID", Type: "string", Description: "Token ID"},
 			{Name: "Role", Type: "string", Description: "Token Role"},
+			{Name: "Created at", Type: "string", Description: "Creation Time"},
+			{Name: "Created by", Type: "string", Description: "Creator"},
 			{Name: "Expires at", Type: "string", Description: "Expiration Time"},
+			{Name: "Last used at", Type: "string", Description: "Time of Last Usage"},
+			{Name: "Last used by", Type: "string", Description: "Node of Last Usage"},
 		},
 	}
 
@@ -74,7 +84,7 @@ func printTokens(writer io.Writer, tokens []token.Token, listTokenRole string) {
 	for _, t := range tokens {
 		if listTokenRole == "" || listTokenRole == t.Role {
 			table.Rows = append(table.Rows, metav1.TableRow{
-				Cells: []any{t.ID, t.Role, t.Expiry},
+				Cells: []any{t.ID, t.Role, t.Created, t.CreatedBy, t.Expiry, t.LastUsed, t.LastUsedBy},
 			})
 		}
 	}
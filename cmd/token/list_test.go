@@ -14,22 +14,22 @@ import (
 func TestPrintTokens(t *testing.T) {
 	// Mock tokens
 	tokens := []token.Token{
-		{ID: "token1", Role: "controller", Expiry: "2025-05-12T12:00:00Z"},
-		{ID: "token2", Role: "worker", Expiry: "2025-05-13T12:00:00Z"},
-		{ID: "token3", Role: "worker", Expiry: "2025-05-14T12:00:00Z"},
+		{ID: "token1", Role: "controller", Created: "2025-05-11T12:00:00Z", CreatedBy: "root@controller-0", Expiry: "2025-05-12T12:00:00Z", LastUsed: "2025-05-11T13:00:00Z", LastUsedBy: "10.0.0.2"},
+		{ID: "token2", Role: "worker", Created: "2025-05-11T12:00:00Z", CreatedBy: "root@controller-0", Expiry: "2025-05-13T12:00:00Z", LastUsed: "2025-05-11T14:00:00Z", LastUsedBy: "worker-0"},
+		{ID: "token3", Role: "worker", Created: "2025-05-11T12:00:00Z", Expiry: "2025-05-14T12:00:00Z"},
 	}
 
 	t.Run("controller Tokens", func(t *testing.T) {
-		expectedOutput := "ID       ROLE         EXPIRES AT\n" +
-			"token1   controller   2025-05-12T12:00:00Z\n"
+		expectedOutput := "ID       ROLE         CREATED AT             CREATED BY          EXPIRES AT             LAST USED AT           LAST USED BY\n" +
+			"token1   controller   2025-05-11T12:00:00Z   root@controller-0   2025-05-12T12:00:00Z   2025-05-11T13:00:00Z   10.0.0.2\n"
 		var output bytes.Buffer
 		printTokens(&output, tokens, "controller")
 		assert.Equal(t, expectedOutput, output.String())
 	})
 	t.Run("worker Tokens", func(t *testing.T) {
-		expectedOutput := "ID       ROLE     EXPIRES AT\n" +
-			"token2   worker   2025-05-13T12:00:00Z\n" +
-			"token3   worker   2025-05-14T12:00:00Z\n"
+		expectedOutput := "ID       ROLE     CREATED AT             CREATED BY          EXPIRES AT             LAST USED AT           LAST USED BY\n" +
+			"token2   worker   2025-05-11T12:00:00Z   root@controller-0   2025-05-13T12:00:00Z   2025-05-11T14:00:00Z   worker-0\n" +
+			"token3   worker   2025-05-11T12:00:00Z                       2025-05-14T12:00:00Z                          \n"
 		var output bytes.Buffer
 		printTokens(&output, tokens, "worker")
 		assert.Equal(t, expectedOutput, output.String())
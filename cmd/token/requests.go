@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/token"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/spf13/cobra"
+)
+
+func tokenRequestsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "requests",
+		Short: "List join requests",
+		Long: `List join requests.
+
+Nodes that join via --join-request submit a join request, which needs to be
+approved via "k0s token approve" before the node gets its credentials. Join
+requests that aren't approved within an hour expire.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+			manager, err := token.NewManager(opts.K0sVars.AdminKubeConfigPath)
+			if err != nil {
+				return err
+			}
+
+			requests, err := manager.ListJoinRequests(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if len(requests) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No join requests found")
+				return nil
+			}
+
+			printJoinRequests(cmd.OutOrStdout(), requests)
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(config.GetPersistentFlagSet())
+
+	return cmd
+}
+
+func tokenApproveCmd() *cobra.Command {
+	return decideJoinRequestsCmd("approve", "Approve join requests", "approved", (*token.Manager).ApproveJoinRequest)
+}
+
+func tokenDenyCmd() *cobra.Command {
+	return decideJoinRequestsCmd("deny", "Deny join requests", "denied", (*token.Manager).DenyJoinRequest)
+}
+
+func decideJoinRequestsCmd(use, short, decided string, decide func(*token.Manager, context.Context, string) error) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     use + " join-request...",
+		Short:   short,
+		Example: "k0s token " + use + " xyz123",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+			manager, err := token.NewManager(opts.K0sVars.AdminKubeConfigPath)
+			if err != nil {
+				return err
+			}
+
+			for _, id := range args {
+				if err := decide(manager, cmd.Context(), id); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "join request %s %s successfully\n", id, decided)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(config.GetPersistentFlagSet())
+
+	return cmd
+}
+
+func printJoinRequests(writer io.Writer, requests []token.JoinRequest) {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "ID", Type: "string", Description: "Join Request ID"},
+			{Name: "Node", Type: "string", Description: "Node Name"},
+			{Name: "Requested by", Type: "string", Description: "Requester Address"},
+			{Name: "Created at", Type: "string", Description: "Creation Time"},
+			{Name: "State", Type: "string", Description: "State"},
+			{Name: "Decided by", Type: "string", Description: "Approver"},
+		},
+	}
+
+	for _, r := range requests {
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []any{r.ID, r.NodeName, r.RequestedBy, r.Created, r.State, r.DecidedBy},
+		})
+	}
+
+	tabWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	defer tabWriter.Flush()
+
+	printer := printers.NewTablePrinter(printers.PrintOptions{})
+	if err := printer.PrintObj(table, tabWriter); err != nil {
+		fmt.Fprintf(writer, "Error printing table: %v\n", err)
+	}
+}
@@ -23,6 +23,9 @@ func NewTokenCmd() *cobra.Command {
 	cmd.AddCommand(tokenListCmd())
 	cmd.AddCommand(tokenInvalidateCmd())
 	cmd.AddCommand(preSharedCmd())
+	cmd.AddCommand(tokenRequestsCmd())
+	cmd.AddCommand(tokenApproveCmd())
+	cmd.AddCommand(tokenDenyCmd())
 	addPlatformSpecificCommands(cmd)
 
 	return cmd
@@ -0,0 +1,173 @@
+//go:build linux || windows
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package uninstall
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/k0sproject/k0s/cmd/internal"
+	"github.com/k0sproject/k0s/cmd/reset"
+	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/install"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type uninstallFlags struct {
+	initSystem string
+	reset      bool
+	purge      bool
+}
+
+func NewUninstallCmd() *cobra.Command {
+	var (
+		debugFlags internal.DebugFlags
+		flags      uninstallFlags
+	)
+
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Uninstall the k0s service. Must be run as root (or with sudo)",
+		Long: `Uninstall the k0s service.
+
+Stops the k0s service and removes it, including the service files of all the
+supported init systems. With --reset, the node is reset afterwards, as with
+"k0s reset". If the k0s binary has been installed by autopilot, it is removed
+as well.`,
+		Args:             cobra.NoArgs,
+		PersistentPreRun: debugFlags.Run,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if runtime.GOOS != "windows" && os.Geteuid() != 0 {
+				return errors.New("this command must be run as root")
+			}
+			if flags.purge && !flags.reset {
+				return errors.New("--purge can only be used together with --reset")
+			}
+
+			if err := install.SelectInitSystem(flags.initSystem); err != nil {
+				return err
+			}
+
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+
+			// Look up the binary before resetting, as the reset removes the
+			// data directory in which it's recorded.
+			binaryPath, err := autopilotInstalledBinary(opts.K0sVars.DataDir)
+			if err != nil {
+				return err
+			}
+
+			if err := uninstallServices(); err != nil {
+				return err
+			}
+
+			if flags.reset {
+				if err := reset.Reset(opts, debugFlags.IsDebug(), flags.purge, cmd.OutOrStdout()); err != nil {
+					return fmt.Errorf("failed to reset: %w", err)
+				}
+			}
+
+			if binaryPath != "" {
+				return removeBinary(binaryPath, opts.K0sVars.DataDir)
+			}
+
+			return nil
+		},
+	}
+
+	debugFlags.AddToFlagSet(cmd.PersistentFlags())
+
+	pflags := cmd.Flags()
+	pflags.AddFlagSet(config.GetPersistentFlagSet())
+	pflags.AddFlagSet(config.GetCriSocketFlag())
+	pflags.AddFlagSet(config.FileInputFlag())
+	pflags.String("kubelet-root-dir", "", "Kubelet root directory for k0s")
+	pflags.StringVar(&flags.initSystem, "init-system", install.AutoInitSystem, internal.InitSystemFlagUsage())
+	pflags.BoolVar(&flags.reset, "reset", false, "Reset the node after uninstalling the service, as k0s reset does")
+	pflags.BoolVar(&flags.purge, "purge", false, "Also remove the log files of the k0s service and the groups of the k0s system users (requires --reset)")
+
+	return cmd
+}
+
+// uninstallServices stops and uninstalls the k0s services and removes any
+// service files that are left behind.
+func uninstallServices() error {
+	var errs []error
+
+	for _, role := range []string{"controller", "worker"} {
+		name := install.GetServiceConfig(role).Name
+
+		installed, err := install.IsServiceInstalled(role)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to check %s service: %w", name, err))
+		} else if installed {
+			logrus.Infof("Stopping %s service", name)
+			if err := install.StopService(role); err != nil {
+				errs = append(errs, fmt.Errorf("failed to stop %s service: %w", name, err))
+				continue
+			}
+			logrus.Infof("Uninstalling %s service", name)
+			if err := install.UninstallService(role); err != nil {
+				errs = append(errs, fmt.Errorf("failed to uninstall %s service: %w", name, err))
+				continue
+			}
+		}
+
+		files, err := install.ServiceFiles(role)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, file := range files {
+			logrus.Infof("Removing %s", file)
+			if err := os.RemoveAll(file); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// autopilotInstalledBinary returns the path of the k0s binary that autopilot
+// has installed, or an empty string if there's none.
+func autopilotInstalledBinary(dataDir string) (string, error) {
+	path, err := os.ReadFile(filepath.Join(dataDir, apconst.InstalledK0sBinaryFilename))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(path)), nil
+}
+
+func removeBinary(path, dataDir string) error {
+	logrus.Infof("Removing k0s binary %s, which has been installed by autopilot", path)
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		if runtime.GOOS == "windows" {
+			logrus.WithError(err).Warn("Failed to remove the k0s binary, please remove it manually")
+			return nil
+		}
+		return fmt.Errorf("failed to remove k0s binary: %w", err)
+	}
+
+	err := os.Remove(filepath.Join(dataDir, apconst.InstalledK0sBinaryFilename))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
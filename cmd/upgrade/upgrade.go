@@ -0,0 +1,293 @@
+//go:build linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/k0sproject/k0s/cmd/internal"
+	internalhttp "github.com/k0sproject/k0s/internal/http"
+	"github.com/k0sproject/k0s/internal/pkg/file"
+	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
+	apdl "github.com/k0sproject/k0s/pkg/autopilot/download"
+	"github.com/k0sproject/k0s/pkg/build"
+	"github.com/k0sproject/k0s/pkg/component/status"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/install"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// The location of the k0s release assets on GitHub.
+const releaseBaseURL = "https://github.com/k0sproject/k0s/releases/download"
+
+type upgradeFlags struct {
+	to         string
+	sha256     string
+	initSystem string
+	force      bool
+}
+
+func NewUpgradeCmd() *cobra.Command {
+	var (
+		debugFlags internal.DebugFlags
+		flags      upgradeFlags
+	)
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade k0s in place on a single node. Must be run as root (or with sudo)",
+		Long: `Upgrade k0s in place on a single node.
+
+Replaces the running k0s binary with the one given via --to and restarts the
+k0s service. The new binary is either a release version that's downloaded from
+GitHub, a URL, or a local file. It's verified against its SHA-256 checksum, if
+one is known, and by running it before it replaces the current binary.
+
+This is meant for single-node and development clusters. Use autopilot to
+upgrade multi-node clusters.`,
+		Example: `  k0s upgrade --to v1.34.1+k0s.0
+  k0s upgrade --to https://example.com/k0s --sha256 <checksum>
+  k0s upgrade --to ./k0s`,
+		Args:             cobra.NoArgs,
+		PersistentPreRun: debugFlags.Run,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if os.Geteuid() != 0 {
+				return errors.New("this command must be run as root")
+			}
+			if flags.to == "" {
+				return errors.New("--to is required")
+			}
+			if err := install.SelectInitSystem(flags.initSystem); err != nil {
+				return err
+			}
+
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+
+			if !flags.force {
+				if info, err := status.GetStatusInfo(opts.K0sVars.StatusSocketPath); err == nil && !info.SingleNode {
+					return errors.New("k0s isn't running as a single node, use autopilot to upgrade multi-node clusters (or --force to upgrade anyways)")
+				}
+			}
+
+			return upgrade(cmd.Context(), &flags, opts.K0sVars.DataDir)
+		},
+	}
+
+	debugFlags.AddToFlagSet(cmd.PersistentFlags())
+
+	pflags := cmd.Flags()
+	pflags.AddFlagSet(config.GetPersistentFlagSet())
+	pflags.StringVar(&flags.to, "to", "", "the k0s version, URL or file to upgrade to")
+	pflags.StringVar(&flags.sha256, "sha256", "", "the expected SHA-256 checksum of the new k0s binary (looked up in the release's checksums for versions)")
+	pflags.StringVar(&flags.initSystem, "init-system", install.AutoInitSystem, internal.InitSystemFlagUsage())
+	pflags.BoolVar(&flags.force, "force", false, "upgrade even if k0s isn't running as a single node, or is already at the given version")
+
+	return cmd
+}
+
+func upgrade(ctx context.Context, flags *upgradeFlags, dataDir string) error {
+	k0sBinaryPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if k0sBinaryPath, err = filepath.EvalSymlinks(k0sBinaryPath); err != nil {
+		return err
+	}
+
+	// Stage the new binary next to the current one, so that it can be swapped
+	// atomically, in the same way as autopilot does it.
+	binDir := filepath.Dir(k0sBinaryPath)
+	updatePath := filepath.Join(binDir, apconst.K0sTempFilename)
+	defer func() {
+		if err := os.Remove(updatePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logrus.WithError(err).Warn("Failed to remove ", updatePath)
+		}
+	}()
+
+	expectedVersion, err := stageBinary(ctx, flags.to, flags.sha256, binDir)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(updatePath, 0755); err != nil {
+		return err
+	}
+
+	version, err := binaryVersion(ctx, updatePath)
+	if err != nil {
+		return fmt.Errorf("failed to verify the new k0s binary: %w", err)
+	}
+	if expectedVersion != "" && version != expectedVersion {
+		return fmt.Errorf("the new k0s binary is version %s, expected %s", version, expectedVersion)
+	}
+	if version == build.Version && !flags.force {
+		logrus.Infof("k0s is already at version %s", version)
+		return nil
+	}
+
+	logrus.Infof("Upgrading k0s from %s to %s", build.Version, version)
+	if err := os.Rename(updatePath, k0sBinaryPath); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", k0sBinaryPath, err)
+	}
+
+	// Record the installed binary, so that k0s uninstall can remove it
+	markerPath := filepath.Join(dataDir, apconst.InstalledK0sBinaryFilename)
+	if err := file.WriteContentAtomically(markerPath, []byte(k0sBinaryPath), 0644); err != nil {
+		logrus.WithError(err).Warn("Failed to record the installed k0s binary")
+	}
+
+	svc, err := install.InstalledService()
+	if err != nil {
+		logrus.WithError(err).Warn("Not restarting the k0s service, restart k0s manually to complete the upgrade")
+		return nil
+	}
+	logrus.Info("Restarting the k0s service")
+	if err := svc.Restart(); err != nil {
+		return fmt.Errorf("failed to restart the k0s service: %w", err)
+	}
+
+	return nil
+}
+
+// stageBinary places the new k0s binary into the given directory, using the
+// autopilot's temporary file name. Returns the version that the binary is
+// expected to have, if it's been given as a version.
+func stageBinary(ctx context.Context, to, sha256sum, binDir string) (expectedVersion string, _ error) {
+	var url string
+	switch {
+	case strings.HasPrefix(to, "https://") || strings.HasPrefix(to, "http://"):
+		url = to
+
+	case strings.ContainsRune(to, filepath.Separator) || isFile(to):
+		logrus.Infof("Copying %s", to)
+		return "", copyBinary(to, sha256sum, filepath.Join(binDir, apconst.K0sTempFilename))
+
+	default:
+		expectedVersion = to
+		if !strings.HasPrefix(expectedVersion, "v") {
+			expectedVersion = "v" + expectedVersion
+		}
+		asset := releaseAsset(expectedVersion, runtime.GOARCH)
+		url = releaseBaseURL + "/" + expectedVersion + "/" + asset
+		if sha256sum == "" {
+			var err error
+			if sha256sum, err = releaseChecksum(ctx, expectedVersion, asset); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	logrus.Infof("Downloading %s", url)
+	if err := apdl.NewDownloader(apdl.Config{
+		URL:          url,
+		ExpectedHash: sha256sum,
+		Hasher:       sha256.New(),
+		DownloadDir:  binDir,
+		Filename:     apconst.K0sTempFilename,
+	}).Download(ctx); err != nil {
+		return "", err
+	}
+
+	return expectedVersion, nil
+}
+
+// releaseAsset returns the name of the k0s binary of the given release for the
+// given architecture.
+func releaseAsset(version, arch string) string {
+	return "k0s-" + version + "-" + arch
+}
+
+// releaseChecksum looks up the SHA-256 checksum of the given asset in the
+// checksums file of the given release.
+func releaseChecksum(ctx context.Context, version, asset string) (string, error) {
+	var sums bytes.Buffer
+	if err := internalhttp.Download(ctx, releaseBaseURL+"/"+version+"/sha256sums.txt", &sums); err != nil {
+		return "", fmt.Errorf("failed to download the checksums of k0s %s: %w", version, err)
+	}
+	return findChecksum(&sums, asset)
+}
+
+// findChecksum finds the checksum of the given file in the output of sha256sum.
+func findChecksum(sums io.Reader, fileName string) (string, error) {
+	lines := bufio.NewScanner(sums)
+	for lines.Scan() {
+		sum, name, ok := strings.Cut(lines.Text(), " ")
+		if ok && strings.TrimLeft(strings.TrimSpace(name), "*") == fileName {
+			return sum, nil
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no checksum found for %s", fileName)
+}
+
+func isFile(path string) bool {
+	stat, err := os.Stat(path)
+	return err == nil && stat.Mode().IsRegular()
+}
+
+// copyBinary copies the given file to the given path, verifying its checksum
+// if one is given.
+func copyBinary(src, sha256sum, dst string) error {
+	var expectedHash []byte
+	if sha256sum != "" {
+		var err error
+		if expectedHash, err = hex.DecodeString(sha256sum); err != nil {
+			return fmt.Errorf("invalid SHA-256 checksum: %w", err)
+		}
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	return file.AtomicWithTarget(dst).WithPermissions(0755).Do(func(f file.AtomicWriter) error {
+		hasher := sha256.New()
+		if _, err := io.Copy(io.MultiWriter(f, hasher), in); err != nil {
+			return err
+		}
+		if hash := hasher.Sum(nil); expectedHash != nil && !bytes.Equal(expectedHash, hash) {
+			return fmt.Errorf("hash mismatch: expected %x, got %x", expectedHash, hash)
+		}
+		return nil
+	})
+}
+
+// binaryVersion runs the given k0s binary and returns its version.
+func binaryVersion(ctx context.Context, path string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, path, "version").Output()
+	if err != nil {
+		return "", err
+	}
+	version := strings.TrimSpace(string(out))
+	if version == "" || strings.ContainsAny(version, " \n") {
+		return "", fmt.Errorf("unexpected version output: %q", version)
+	}
+	return version, nil
+}
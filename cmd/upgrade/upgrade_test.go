@@ -0,0 +1,60 @@
+//go:build linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindChecksum(t *testing.T) {
+	sums := `aaaa  k0s-v1.34.1+k0s.0-amd64
+bbbb *k0s-v1.34.1+k0s.0-arm64
+cccc  k0s-v1.34.1+k0s.0-amd64.exe
+`
+	sum, err := findChecksum(strings.NewReader(sums), releaseAsset("v1.34.1+k0s.0", "amd64"))
+	require.NoError(t, err)
+	assert.Equal(t, "aaaa", sum)
+
+	sum, err = findChecksum(strings.NewReader(sums), releaseAsset("v1.34.1+k0s.0", "arm64"))
+	require.NoError(t, err)
+	assert.Equal(t, "bbbb", sum, "binary mode checksums should be found")
+
+	_, err = findChecksum(strings.NewReader(sums), releaseAsset("v1.34.1+k0s.0", "arm"))
+	assert.ErrorContains(t, err, "no checksum found for k0s-v1.34.1+k0s.0-arm")
+}
+
+func TestCopyBinary(t *testing.T) {
+	dir := t.TempDir()
+	src, dst := filepath.Join(dir, "k0s"), filepath.Join(dir, "k0s.tmp")
+	require.NoError(t, os.WriteFile(src, []byte("k0s"), 0644))
+	const sum = "6ec93ec61f9b7a7f3a5e5a2ee0a2d9b4c4b6f1a2ee11d3c70f2bd3b4e0c4a4c4"
+
+	t.Run("hash_mismatch", func(t *testing.T) {
+		assert.ErrorContains(t, copyBinary(src, sum, dst), "hash mismatch")
+		assert.NoFileExists(t, dst)
+	})
+
+	t.Run("valid_hash", func(t *testing.T) {
+		hash := sha256.Sum256([]byte("k0s"))
+		require.NoError(t, copyBinary(src, hex.EncodeToString(hash[:]), dst))
+		assert.FileExists(t, dst)
+	})
+
+	t.Run("no_hash", func(t *testing.T) {
+		require.NoError(t, copyBinary(src, "", dst))
+		content, err := os.ReadFile(dst)
+		require.NoError(t, err)
+		assert.Equal(t, "k0s", string(content))
+	})
+}
@@ -0,0 +1,21 @@
+//go:build !windows
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// shutdownContext returns a context that is canceled when k0s is asked to
+// shut down. The returned function needs to be called after the worker has
+// terminated.
+func shutdownContext(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+	return ctx, cancel, nil
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/install"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// shutdownContext returns a context that is canceled when k0s is asked to
+// shut down. The returned function needs to be called after the worker has
+// terminated.
+//
+// When k0s has been started by the Windows service manager, it reports its
+// status to it and stops when the service is stopped. Its logs are then
+// written to the Windows event log, as there's no console attached.
+func shutdownContext(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	isService, err := svc.IsWindowsService()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isService {
+		ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+		return ctx, cancel, nil
+	}
+
+	name := install.GetServiceConfig("worker").Name
+	if log, err := eventlog.Open(name); err != nil {
+		logrus.WithError(err).Warn("Failed to open the event log, logs won't be recorded")
+	} else {
+		logrus.AddHook(&eventLogHook{log})
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	handler := &serviceHandler{
+		stop:       func() { cancel(errors.New("service stop requested")) },
+		terminated: make(chan struct{}),
+		stopping:   make(chan struct{}),
+	}
+	dispatcherDone := make(chan struct{})
+	go func() {
+		defer close(dispatcherDone)
+		if err := svc.Run(name, handler); err != nil {
+			logrus.WithError(err).Error("Service control dispatcher failed")
+			cancel(err)
+		}
+	}()
+
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			close(handler.terminated)
+			select {
+			case <-handler.stopping:
+				// Wait until the service manager has been told that the
+				// service has stopped. Otherwise, it considers this a
+				// failure and applies the recovery actions.
+				<-dispatcherDone
+			default:
+				// The worker terminated on its own. Exiting without
+				// reporting this lets the service manager restart it.
+			}
+			cancel(nil)
+		})
+	}, nil
+}
+
+type serviceHandler struct {
+	stop       func()
+	terminated chan struct{}
+	stopping   chan struct{}
+}
+
+// Execute implements [svc.Handler].
+func (h *serviceHandler) Execute(_ []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	changes <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+
+	for {
+		select {
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				changes <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				changes <- svc.Status{State: svc.StopPending, WaitHint: uint32((2 * time.Minute).Milliseconds())}
+				close(h.stopping)
+				h.stop()
+				<-h.terminated
+				return false, 0
+			}
+		case <-h.terminated:
+			// Block until the process exits.
+			select {}
+		}
+	}
+}
+
+// eventLogHook writes log entries to the Windows event log.
+type eventLogHook struct{ log *eventlog.Log }
+
+func (h *eventLogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *eventLogHook) Fire(entry *logrus.Entry) error {
+	msg, err := entry.String()
+	if err != nil {
+		return err
+	}
+
+	switch entry.Level {
+	case logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel:
+		return h.log.Error(1, msg)
+	case logrus.WarnLevel:
+		return h.log.Warning(1, msg)
+	default:
+		return h.log.Info(1, msg)
+	}
+}
@@ -5,13 +5,19 @@ package worker
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"os"
-	"os/signal"
 	"path/filepath"
 	"runtime"
-	"syscall"
+	"strings"
+	"time"
 
 	"github.com/k0sproject/k0s/cmd/internal"
 	"github.com/k0sproject/k0s/internal/pkg/dir"
@@ -19,6 +25,9 @@ import (
 	"github.com/k0sproject/k0s/internal/pkg/flags"
 	"github.com/k0sproject/k0s/internal/pkg/stringmap"
 	"github.com/k0sproject/k0s/internal/pkg/sysinfo"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/cloudidentity"
+	"github.com/k0sproject/k0s/pkg/cloudmetadata"
 	"github.com/k0sproject/k0s/pkg/component/iptables"
 	"github.com/k0sproject/k0s/pkg/component/manager"
 	"github.com/k0sproject/k0s/pkg/component/prober"
@@ -30,11 +39,16 @@ import (
 	"github.com/k0sproject/k0s/pkg/constant"
 	"github.com/k0sproject/k0s/pkg/kubernetes"
 	"github.com/k0sproject/k0s/pkg/node"
+	"github.com/k0sproject/k0s/pkg/rootless"
 	"github.com/k0sproject/k0s/pkg/token"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -51,6 +65,8 @@ func NewWorkerCmd() *cobra.Command {
 	var (
 		debugFlags            internal.DebugFlags
 		ignorePreFlightChecks bool
+		rootlessMode          bool
+		rootlessNetwork       string
 	)
 
 	cmd := &cobra.Command{
@@ -66,6 +82,10 @@ func NewWorkerCmd() *cobra.Command {
 		Args:             cobra.MaximumNArgs(1),
 		PersistentPreRun: debugFlags.Run,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if rootlessMode && !rootless.InNamespace() {
+				return runRootless(cmd, rootlessNetwork)
+			}
+
 			opts, err := config.GetCmdOpts(cmd)
 			if err != nil {
 				return err
@@ -76,15 +96,36 @@ func NewWorkerCmd() *cobra.Command {
 				c.TokenArg = args[0]
 			}
 
-			getBootstrapKubeconfig, err := kubeconfigGetterFromJoinToken(c.TokenFile, c.TokenArg)
+			if err := internal.ConfigureLogging(&c.WorkerOptions); err != nil {
+				return err
+			}
+
+			if rootless.InNamespace() {
+				if c.Rootless, err = rootless.Setup(c.K0sVars.RunDir); err != nil {
+					return fmt.Errorf("failed to set up rootless worker: %w", err)
+				}
+			}
+
+			// Set up signal handling
+			ctx, cancel, err := shutdownContext(cmd.Context())
 			if err != nil {
 				return err
 			}
+			defer cancel()
+			internal.StartSystemdWatchdog(ctx)
 
-			nodeName, kubeletExtraArgs, err := GetNodeName(&c.WorkerOptions)
+			nodeName, kubeletExtraArgs, err := GetNodeName(ctx, c.K0sVars, &c.WorkerOptions)
 			if err != nil {
 				return fmt.Errorf("failed to determine node name: %w", err)
 			}
+			if c.Rootless != nil && c.Rootless.NodeIP != "" && kubeletExtraArgs["--node-ip"] == "" {
+				kubeletExtraArgs["--node-ip"] = c.Rootless.NodeIP
+			}
+
+			getBootstrapKubeconfig, err := bootstrapKubeconfigGetter(ctx, &c.WorkerOptions, nodeName)
+			if err != nil {
+				return err
+			}
 
 			if err := (&sysinfo.K0sSysinfoSpec{
 				ControllerRoleEnabled: false,
@@ -94,10 +135,6 @@ func NewWorkerCmd() *cobra.Command {
 				return err
 			}
 
-			// Set up signal handling
-			ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
-			defer cancel()
-
 			// Check for legacy CA file (unused on worker-only nodes since 1.33)
 			if legacyCAFile := filepath.Join(c.K0sVars.CertRootDir, "ca.crt"); file.Exists(legacyCAFile) {
 				// Keep the file to allow interop between 1.32 and 1.33.
@@ -126,11 +163,35 @@ func NewWorkerCmd() *cobra.Command {
 	flags.AddFlagSet(config.GetPersistentFlagSet())
 	flags.AddFlagSet(config.GetWorkerFlags())
 	flags.BoolVar(&ignorePreFlightChecks, "ignore-pre-flight-checks", false, "continue even if pre-flight checks fail")
+	flags.BoolVar(&rootlessMode, "rootless", false, "run the worker as an unprivileged user in a user namespace (experimental)")
+	flags.StringVar(&rootlessNetwork, "rootless-network", rootless.NetworkAuto, "network driver of rootless workers (valid values: auto, pasta, slirp4netns)")
 
 	return cmd
 }
 
-func GetNodeName(opts *config.WorkerOptions) (apitypes.NodeName, stringmap.StringMap, error) {
+// runRootless re-executes the worker command in the namespaces of a rootless
+// worker. Rootless workers use a data directory below the user's home
+// directory, unless it's given explicitly.
+func runRootless(cmd *cobra.Command, network string) error {
+	args := os.Args[1:]
+	if !cmd.Flags().Changed("data-dir") {
+		dataDir, err := rootless.DefaultDataDir()
+		if err != nil {
+			return err
+		}
+		args = append(args, "--data-dir="+dataDir)
+	}
+
+	ctx, cancel, err := shutdownContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	return rootless.Run(ctx, network, args)
+}
+
+func GetNodeName(ctx context.Context, k0sVars *config.CfgVars, opts *config.WorkerOptions) (apitypes.NodeName, stringmap.StringMap, error) {
 	// The node name used during bootstrapping needs to match the node name
 	// selected by kubelet. Otherwise, kubelet will have problems interacting
 	// with a Node object that doesn't match the name in the certificates.
@@ -143,6 +204,24 @@ func GetNodeName(opts *config.WorkerOptions) (apitypes.NodeName, stringmap.Strin
 	// exactly matches the behavior of kubelet.
 
 	kubeletExtraArgs := flags.Split(opts.KubeletExtraArgs)
+	if opts.NodeNameTemplate != "" {
+		if _, found := kubeletExtraArgs["--hostname-override"]; found {
+			return "", nil, errors.New("--node-name-template and --hostname-override in --kubelet-extra-args are mutually exclusive")
+		}
+
+		// Bind the node name only once, so that it stays stable across
+		// restarts. The kubelet needs to use the very same name.
+		if err := dir.Init(k0sVars.DataDir, constant.DataDirMode); err != nil {
+			return "", nil, err
+		}
+		nodeName, err := node.BindNodeName(ctx, k0sVars.NodeNamePath, opts.NodeNameTemplate)
+		if err != nil {
+			return "", nil, err
+		}
+		kubeletExtraArgs["--hostname-override"] = string(nodeName)
+		return nodeName, kubeletExtraArgs, nil
+	}
+
 	nodeName, err := node.GetNodeName(kubeletExtraArgs["--hostname-override"])
 	if err != nil {
 		return "", nil, err
@@ -175,6 +254,226 @@ func kubeconfigGetterFromJoinToken(tokenFile, tokenArg string) (clientcmd.Kubeco
 	}, nil
 }
 
+// kubeconfigGetterFromCloudIdentity returns a getter that obtains a join
+// token from the k0s join API in exchange for the signed identity of the
+// cloud instance.
+func kubeconfigGetterFromCloudIdentity(ctx context.Context, opts *config.WorkerOptions) (clientcmd.KubeconfigGetter, error) {
+	provider := cloudmetadata.Provider(opts.CloudIdentityJoin)
+	switch provider {
+	case cloudmetadata.ProviderAWS, cloudmetadata.ProviderGCP:
+	default:
+		return nil, fmt.Errorf("unsupported cloud provider for --cloud-identity-join: %q", provider)
+	}
+	if opts.JoinAPIURL == "" || opts.JoinAPICAFile == "" {
+		return nil, errors.New("--cloud-identity-join requires --join-api-url and --join-api-ca-file")
+	}
+
+	return func() (*clientcmdapi.Config, error) {
+		caCert, err := os.ReadFile(opts.JoinAPICAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read join API CA certificate: %w", err)
+		}
+
+		identity, signature, err := cloudmetadata.NewClient().Identity(ctx, provider, cloudidentity.Audience)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get instance identity: %w", err)
+		}
+
+		joinRequest := &v1beta1.CloudIdentityJoinRequest{
+			Provider:  string(provider),
+			Identity:  identity,
+			Signature: signature,
+		}
+		// On AWS, the instance profile may serve as an alternative proof of
+		// identity, if the join API is configured to verify it.
+		if provider == cloudmetadata.ProviderAWS {
+			if joinRequest.CallerIdentityRequest, err = cloudidentity.PresignCallerIdentity(ctx, caCert); err != nil {
+				logrus.WithError(err).Debug("Not presigning a GetCallerIdentity request")
+			}
+		}
+
+		joinToken, err := token.JoinWithCloudIdentity(ctx, opts.JoinAPIURL, caCert, joinRequest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to join with cloud identity: %w", err)
+		}
+
+		return loadKubeconfigFromJoinToken(joinToken)
+	}, nil
+}
+
+// bootstrapKubeconfigGetter returns a getter for the bootstrap kubeconfig,
+// depending on how the worker joins the cluster.
+func bootstrapKubeconfigGetter(ctx context.Context, opts *config.WorkerOptions, nodeName apitypes.NodeName) (clientcmd.KubeconfigGetter, error) {
+	var methods []string
+	if opts.TokenFile != "" || opts.TokenArg != "" {
+		methods = append(methods, "a join token")
+	}
+	if opts.CloudIdentityJoin != "" {
+		methods = append(methods, "--cloud-identity-join")
+	}
+	if opts.JoinCode != "" {
+		methods = append(methods, "--join-code")
+	}
+	if opts.JoinRequest {
+		methods = append(methods, "--join-request")
+	}
+	if len(methods) > 1 {
+		return nil, fmt.Errorf("%s are mutually exclusive", strings.Join(methods, ", "))
+	}
+
+	switch {
+	case opts.CloudIdentityJoin != "":
+		return kubeconfigGetterFromCloudIdentity(ctx, opts)
+	case opts.JoinCode != "":
+		return kubeconfigGetterFromJoinCode(ctx, opts)
+	case opts.JoinRequest:
+		return kubeconfigGetterFromJoinRequest(ctx, opts, nodeName)
+	default:
+		return kubeconfigGetterFromJoinToken(opts.TokenFile, opts.TokenArg)
+	}
+}
+
+// kubeconfigGetterFromJoinCode returns a getter that exchanges the join code
+// for a join token via the k0s join API.
+func kubeconfigGetterFromJoinCode(ctx context.Context, opts *config.WorkerOptions) (clientcmd.KubeconfigGetter, error) {
+	if err := checkJoinAPIOptions(opts, "--join-code"); err != nil {
+		return nil, err
+	}
+
+	return func() (*clientcmdapi.Config, error) {
+		caCert, err := loadJoinAPICACert(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		joinToken, err := token.JoinWithCode(ctx, opts.JoinAPIURL, caCert, opts.JoinCode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to exchange join code: %w", err)
+		}
+
+		return loadKubeconfigFromJoinToken(joinToken)
+	}, nil
+}
+
+// kubeconfigGetterFromJoinRequest returns a getter that submits a join
+// request via the k0s join API and waits for its approval. The bootstrap
+// kubeconfig authenticates with the short-lived client certificate that is
+// issued for the approved request.
+func kubeconfigGetterFromJoinRequest(ctx context.Context, opts *config.WorkerOptions, nodeName apitypes.NodeName) (clientcmd.KubeconfigGetter, error) {
+	if err := checkJoinAPIOptions(opts, "--join-request"); err != nil {
+		return nil, err
+	}
+
+	return func() (*clientcmdapi.Config, error) {
+		caCert, err := loadJoinAPICACert(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+			Subject: pkix.Name{CommonName: "system:node:" + string(nodeName), Organization: []string{"system:nodes"}},
+		}, key)
+		if err != nil {
+			return nil, err
+		}
+		keyPEM, err := keyutil.MarshalPrivateKeyToPEM(key)
+		if err != nil {
+			return nil, err
+		}
+
+		id, err := token.SubmitJoinRequest(ctx, opts.JoinAPIURL, caCert, &v1beta1.JoinRequest{
+			NodeName: string(nodeName),
+			CSR:      pem.EncodeToMemory(&pem.Block{Type: certutil.CertificateRequestBlockType, Bytes: csr}),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to submit join request: %w", err)
+		}
+		logrus.Infof("Submitted join request %s, waiting for its approval via k0s token approve %s", id, id)
+
+		var status *v1beta1.JoinRequestStatus
+		if err := wait.PollUntilContextCancel(ctx, 5*time.Second, true, func(ctx context.Context) (bool, error) {
+			status, err = token.GetJoinRequestStatus(ctx, opts.JoinAPIURL, caCert, id)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					return false, fmt.Errorf("join request %s has expired", id)
+				}
+				logrus.WithError(err).Debug("Failed to get join request status, retrying")
+				return false, nil
+			}
+			return status.State != token.JoinRequestPending, nil
+		}); err != nil {
+			return nil, err
+		}
+		if status.State != token.JoinRequestApproved {
+			return nil, fmt.Errorf("join request %s has been %s", id, strings.ToLower(status.State))
+		}
+		logrus.Infof("Join request %s has been approved", id)
+
+		const contextName = "k0s"
+		return &clientcmdapi.Config{
+			Clusters: map[string]*clientcmdapi.Cluster{contextName: {
+				Server:                   status.APIServerURL,
+				CertificateAuthorityData: status.CACertificate,
+			}},
+			Contexts: map[string]*clientcmdapi.Context{contextName: {
+				Cluster:  contextName,
+				AuthInfo: contextName,
+			}},
+			CurrentContext: contextName,
+			AuthInfos: map[string]*clientcmdapi.AuthInfo{contextName: {
+				ClientCertificateData: status.Certificate,
+				ClientKeyData:         keyPEM,
+			}},
+		}, nil
+	}, nil
+}
+
+func checkJoinAPIOptions(opts *config.WorkerOptions, flag string) error {
+	if opts.JoinAPIURL == "" {
+		return fmt.Errorf("%s requires --join-api-url", flag)
+	}
+	if opts.JoinAPICAFile != "" && opts.JoinAPIFingerprint != "" {
+		return errors.New("--join-api-ca-file and --join-api-fingerprint are mutually exclusive")
+	}
+	return nil
+}
+
+// loadJoinAPICACert returns the CA certificate that's used to verify the k0s
+// join API. It's either the given CA certificate, or it's fetched from the join
+// API itself and verified against the given fingerprint. If neither is given,
+// the fetched CA certificate is trusted on first use.
+func loadJoinAPICACert(ctx context.Context, opts *config.WorkerOptions) ([]byte, error) {
+	if opts.JoinAPICAFile != "" {
+		caCert, err := os.ReadFile(opts.JoinAPICAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read join API CA certificate: %w", err)
+		}
+		return caCert, nil
+	}
+
+	caCert, err := token.FetchCACertificate(ctx, opts.JoinAPIURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch join API CA certificate: %w", err)
+	}
+	if opts.JoinAPIFingerprint != "" {
+		if err := token.VerifyCAFingerprint(caCert, opts.JoinAPIFingerprint); err != nil {
+			return nil, fmt.Errorf("failed to verify join API CA certificate: %w", err)
+		}
+		return caCert, nil
+	}
+
+	fingerprint, err := token.CAFingerprint(caCert)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Warnf("Trusting the join API's CA certificate on first use, its fingerprint is %s; use --join-api-fingerprint to verify it", fingerprint)
+	return caCert, nil
+}
+
 func loadKubeconfigFromJoinToken(tokenData string) (*clientcmdapi.Config, error) {
 	decoded, err := token.DecodeJoinToken(tokenData)
 	if err != nil {
@@ -229,6 +528,19 @@ func (c *Command) Start(ctx context.Context, nodeName apitypes.NodeName, kubelet
 	if err != nil {
 		return err
 	}
+	if c.Rootless != nil && !config.FeatureEnabled(workerConfig.K0sFeatureGates, config.RootlessWorkerFeature.Name) {
+		return fmt.Errorf("rootless workers are experimental and need to be enabled via the %s k0s feature gate", config.RootlessWorkerFeature.Name)
+	}
+
+	labels, taints := c.Labels, c.Taints
+	if c.LabelsFromCloudMetadata != "" {
+		labels, taints, err = worker.AddCloudMetadataLabelsAndTaints(ctx, c.LabelsFromCloudMetadata, c.CloudMetadataSpotTaint, labels, taints)
+		if err != nil {
+			return err
+		}
+	} else if c.CloudMetadataSpotTaint != "" {
+		return errors.New("--cloud-metadata-spot-taint requires --labels-from-cloud-metadata")
+	}
 
 	componentManager := manager.New(prober.DefaultProber)
 
@@ -266,6 +578,21 @@ func (c *Command) Start(ctx context.Context, nodeName apitypes.NodeName, kubelet
 			BinDir:       c.K0sVars.BinDir,
 		})
 	}
+
+	var staticPodPath string
+	if workerConfig.StaticPods != nil && workerConfig.StaticPods.Enabled {
+		staticPodPath = c.K0sVars.StaticPodsDir
+	}
+
+	var seccompProfiles []v1beta1.SeccompProfile
+	if workerConfig.Seccomp != nil {
+		seccompProfiles = workerConfig.Seccomp.Profiles
+	}
+	var kubeletRestartPolicy *v1beta1.RestartPolicy
+	if workerConfig.RestartPolicies != nil {
+		kubeletRestartPolicy = workerConfig.RestartPolicies.Kubelet
+	}
+
 	componentManager.Add(ctx,
 		&worker.Kubelet{
 			NodeName:            nodeName,
@@ -276,11 +603,34 @@ func (c *Command) Start(ctx context.Context, nodeName apitypes.NodeName, kubelet
 			Kubeconfig:          kubeletKubeconfigPath,
 			Configuration:       *workerConfig.KubeletConfiguration.DeepCopy(),
 			LogLevel:            c.LogLevels.Kubelet,
-			Labels:              c.Labels,
-			Taints:              c.Taints,
+			Labels:              labels,
+			Taints:              taints,
 			ExtraArgs:           kubeletExtraArgs,
 			DualStackEnabled:    workerConfig.DualStackEnabled,
+			ConfigDropIns:       workerConfig.KubeletConfigDropIns,
+			StaticPodPath:       staticPodPath,
+			CredentialProviders: workerConfig.ImageCredentialProviders,
+			SeccompProfiles:     seccompProfiles,
+			Rootless:            c.Rootless,
+			RestartPolicy:       kubeletRestartPolicy,
+		})
+
+	componentManager.Add(ctx, &worker.NodeMetadata{
+		NodeName:      nodeName,
+		Kubeconfig:    kubeletKubeconfigPath,
+		Reconcile:     c.ReconcileLabelsAndTaints,
+		Labels:        labels,
+		Taints:        taints,
+		ManagedPrefix: c.ReconcilePrefix,
+	})
+
+	if runtime.GOOS != "windows" {
+		componentManager.Add(ctx, &worker.KubeletCertificateMonitor{
+			NodeName:   nodeName,
+			CertDir:    filepath.Join(c.K0sVars.KubeletRootDir, "pki"),
+			Kubeconfig: kubeletKubeconfigPath,
 		})
+	}
 
 	certManager := worker.NewCertificateManager(kubeletKubeconfigPath)
 
@@ -292,6 +642,27 @@ func (c *Command) Start(ctx context.Context, nodeName apitypes.NodeName, kubelet
 	}
 
 	worker.KernelSetup()
+	if err := worker.ApplyKernelParameters(workerConfig.Sysctls, workerConfig.KernelModules); err != nil {
+		return fmt.Errorf("host doesn't satisfy the kernel parameters of worker profile %q: %w", c.WorkerProfile, err)
+	}
+	if gracefulShutdown := workerConfig.GracefulShutdown; gracefulShutdown != nil {
+		if err := worker.VerifyGracefulShutdown(gracefulShutdown.GracePeriod.Duration); err != nil {
+			return fmt.Errorf("host doesn't support the graceful shutdown of worker profile %q: %w", c.WorkerProfile, err)
+		}
+	}
+	if swap := workerConfig.Swap; swap != nil {
+		if err := worker.VerifySwap(swap.Behavior); err != nil {
+			return fmt.Errorf("host doesn't support the swap behavior of worker profile %q: %w", c.WorkerProfile, err)
+		}
+	}
+	if err := worker.VerifyResourceManagers(&workerConfig.KubeletConfiguration); err != nil {
+		return fmt.Errorf("host doesn't support the resource managers of worker profile %q: %w", c.WorkerProfile, err)
+	}
+	if workerConfig.ResourceReservations != nil {
+		if err := worker.EnsureReservedCgroups(ctx, &workerConfig.KubeletConfiguration); err != nil {
+			return fmt.Errorf("host doesn't support the resource reservations of worker profile %q: %w", c.WorkerProfile, err)
+		}
+	}
 
 	err = componentManager.Start(ctx)
 	if err != nil {
@@ -6,10 +6,12 @@ package http
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	internalio "github.com/k0sproject/k0s/internal/io"
@@ -25,6 +27,7 @@ func Download(ctx context.Context, url string, target io.Writer, options ...Down
 	opts := downloadOptions{
 		stalenessTimeout: time.Minute,
 		header:           http.Header{},
+		proxy:            http.ProxyFromEnvironment,
 	}
 	for _, opt := range options {
 		opt(&opts)
@@ -36,12 +39,14 @@ func Download(ctx context.Context, url string, target io.Writer, options ...Down
 	transport := &http.Transport{
 		// This is a one-shot HTTP client which should release resources immediately.
 		DisableKeepAlives: true,
-		Proxy:             http.ProxyFromEnvironment,
+		Proxy:             opts.proxy,
 	}
 
 	// if tls check is disabled, we need to disable the transport's TLS checks as well.
 	if opts.insecureSkipTLSVerify {
 		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	} else if opts.rootCAs != nil {
+		transport.TLSClientConfig = &tls.Config{RootCAs: opts.rootCAs}
 	}
 
 	// Prepare the client and the request.
@@ -131,9 +136,27 @@ func WithHeader(header, value string) DownloadOption {
 	}
 }
 
+// WithProxy sets the function that determines the proxy to use for a request.
+// Defaults to the proxy settings from the environment if omitted.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) DownloadOption {
+	return func(opts *downloadOptions) {
+		opts.proxy = proxy
+	}
+}
+
+// WithRootCAs sets the certificate authorities that are trusted for TLS
+// connections. Defaults to the system's certificate authorities if omitted.
+func WithRootCAs(rootCAs *x509.CertPool) DownloadOption {
+	return func(opts *downloadOptions) {
+		opts.rootCAs = rootCAs
+	}
+}
+
 type downloadOptions struct {
 	stalenessTimeout      time.Duration
 	insecureSkipTLSVerify bool
+	proxy                 func(*http.Request) (*url.URL, error)
+	rootCAs               *x509.CertPool
 	username              string
 	password              string
 	header                http.Header
@@ -173,6 +173,28 @@ func TestDownload_CustomHeaders(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDownload_Proxy(t *testing.T) {
+	var proxiedHost string
+	proxyURL := startFakeDownloadServer(t, false, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		_, _ = w.Write([]byte("proxied"))
+	}))
+
+	parsedProxyURL, err := url.Parse(proxyURL)
+	require.NoError(t, err)
+
+	var downloaded strings.Builder
+	err = internalhttp.Download(
+		t.Context(),
+		"http://example.invalid/file",
+		&downloaded,
+		internalhttp.WithProxy(http.ProxyURL(parsedProxyURL)),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.invalid", proxiedHost)
+	assert.Equal(t, "proxied", downloaded.String())
+}
+
 func startFakeDownloadServer(t *testing.T, usetls bool, handler http.Handler) string {
 	server := &http.Server{Addr: "localhost:0", Handler: handler}
 	listener, err := net.Listen("tcp", server.Addr)
@@ -4,17 +4,24 @@
 package log
 
 import (
+	"fmt"
+
 	"github.com/bombsimon/logrusr/v4"
 	cfssllog "github.com/cloudflare/cfssl/log"
 	"github.com/sirupsen/logrus"
 	crlog "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// The formats of the k0s log.
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+const timestampFormat = "2006-01-02 15:04:05"
+
 func InitLogging() {
-	customFormatter := new(logrus.TextFormatter)
-	customFormatter.TimestampFormat = "2006-01-02 15:04:05"
-	customFormatter.FullTimestamp = true
-	logrus.SetFormatter(customFormatter)
+	logrus.SetFormatter(newTextFormatter())
 
 	cfssllog.SetLogger((*cfsslAdapter)(logrus.WithField("component", "cfssl")))
 	crlog.SetLogger(logrusr.New(logrus.WithField("component", "controller-runtime")))
@@ -36,3 +43,38 @@ func SetWarnLevel() {
 	logrus.SetLevel(logrus.WarnLevel)
 	cfssllog.Level = cfssllog.LevelWarning
 }
+
+// SetFormat sets the format of the k0s log.
+func SetFormat(format string) error {
+	switch format {
+	case TextFormat:
+		logrus.SetFormatter(newTextFormatter())
+	case JSONFormat:
+		logrus.SetFormatter(newJSONFormatter())
+	default:
+		return fmt.Errorf("unsupported log format %q (valid values: %s, %s)", format, TextFormat, JSONFormat)
+	}
+	return nil
+}
+
+// NewFileFormatter returns a formatter that uses the format of the k0s log,
+// without coloring its output, so that it's suited for files.
+func NewFileFormatter() logrus.Formatter {
+	if _, ok := logrus.StandardLogger().Formatter.(*logrus.JSONFormatter); ok {
+		return newJSONFormatter()
+	}
+	formatter := newTextFormatter()
+	formatter.DisableColors = true
+	return formatter
+}
+
+func newTextFormatter() *logrus.TextFormatter {
+	return &logrus.TextFormatter{
+		TimestampFormat: timestampFormat,
+		FullTimestamp:   true,
+	}
+}
+
+func newJSONFormatter() *logrus.JSONFormatter {
+	return &logrus.JSONFormatter{}
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitBySource is a middleware that limits the rate of requests per
+// source address. Responds with HTTP status code 429 "Too many requests" if a
+// source exceeds the given limit.
+func RateLimitBySource(limit rate.Limit, burst int) func(http.Handler) http.Handler {
+	limiters := sourceLimiters{limit: limit, burst: burst, now: time.Now}
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			source, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				source = r.RemoteAddr
+			}
+
+			if delay := limiters.reserve(source); delay > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+type sourceLimiters struct {
+	limit rate.Limit
+	burst int
+	now   func() time.Time
+
+	mu         sync.Mutex
+	limiters   map[string]*rate.Limiter
+	lastPruned time.Time
+}
+
+// Takes a token for the given source. Returns zero if the request is allowed,
+// or the time after which the source may retry.
+func (s *sourceLimiters) reserve(source string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastPruned) > time.Minute {
+		// Limiters that are full again behave like new ones.
+		for source, limiter := range s.limiters {
+			if limiter.TokensAt(now) >= float64(s.burst) {
+				delete(s.limiters, source)
+			}
+		}
+		s.lastPruned = now
+	}
+
+	limiter, ok := s.limiters[source]
+	if !ok {
+		if s.limiters == nil {
+			s.limiters = make(map[string]*rate.Limiter)
+		}
+		limiter = rate.NewLimiter(s.limit, s.burst)
+		s.limiters[source] = limiter
+	}
+
+	if limiter.AllowN(now, 1) {
+		return 0
+	}
+	reservation := limiter.ReserveN(now, 1)
+	defer reservation.CancelAt(now)
+	return max(reservation.DelayFrom(now), time.Second)
+}
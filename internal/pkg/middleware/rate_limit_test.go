@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestRateLimitBySource(t *testing.T) {
+	h := RateLimitBySource(rate.Every(10*time.Second), 2)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	serve := func(remoteAddr string) *http.Response {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = remoteAddr
+		h.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+
+	assert.Equal(t, http.StatusOK, serve("192.0.2.1:1234").StatusCode)
+	assert.Equal(t, http.StatusOK, serve("192.0.2.1:5678").StatusCode)
+
+	resp := serve("192.0.2.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+
+	// Other sources have their own limit.
+	assert.Equal(t, http.StatusOK, serve("192.0.2.2:1234").StatusCode)
+}
+
+func TestSourceLimiters_Prune(t *testing.T) {
+	now := time.Now()
+	limiters := sourceLimiters{limit: rate.Every(time.Second), burst: 1, now: func() time.Time { return now }}
+
+	assert.Zero(t, limiters.reserve("192.0.2.1"))
+	assert.Equal(t, time.Second, limiters.reserve("192.0.2.1"))
+	assert.Len(t, limiters.limiters, 1)
+
+	now = now.Add(2 * time.Minute)
+	assert.Zero(t, limiters.reserve("192.0.2.2"))
+	assert.Len(t, limiters.limiters, 1, "the idle limiter should have been pruned")
+}
@@ -87,7 +87,7 @@ func (t *TransformingObjectTracker) Create(gvr schema.GroupVersionResource, obj
 
 // Delete implements testing.ObjectTracker.
 func (t *TransformingObjectTracker) Delete(gvr schema.GroupVersionResource, ns string, name string, opts ...metav1.DeleteOptions) error {
-	return t.Inner.Delete(gvr, ns, ns, opts...)
+	return t.Inner.Delete(gvr, ns, name, opts...)
 }
 
 // Get implements testing.ObjectTracker.
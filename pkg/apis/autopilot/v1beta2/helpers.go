@@ -25,3 +25,12 @@ func (t PlanStateType) String() string {
 func (t PlanCommandTargetStateType) String() string {
 	return string(t)
 }
+
+// Targets returns the target status of every chart of a `HelmUpdate` command.
+func (s *PlanCommandHelmUpdateStatus) Targets() []PlanCommandTargetStatus {
+	targets := make([]PlanCommandTargetStatus, len(s.Charts))
+	for i := range s.Charts {
+		targets[i] = s.Charts[i].PlanCommandTargetStatus
+	}
+	return targets
+}
@@ -29,8 +29,20 @@ type ControlNode struct {
 type ControlNodeStatus struct {
 	Addresses  []corev1.NodeAddress `json:"addresses,omitempty"`
 	K0sVersion string               `json:"k0sVersion,omitempty"`
+
+	// Conditions are the current conditions of the controller, such as
+	// whether it's considered stale.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// ControlNodeConditionStale is the type of the condition that indicates that
+// a controller hasn't renewed its lease for longer than the staleness timeout.
+const ControlNodeConditionStale = "Stale"
+
 // GetInternalIP returns the internal IP address for the object. Returns empty string if the object does not have InternalIP set.
 func (c *ControlNodeStatus) GetInternalIP() string {
 	for _, addr := range c.Addresses {
@@ -99,6 +111,185 @@ type PlanSpec struct {
 	// Commands are a collection of all of the commands that need to be executed
 	// in order for this plan to transition to Completed.
 	Commands []PlanCommand `json:"commands"`
+
+	// Notifications are a collection of sinks that are notified when the plan
+	// starts, completes or fails.
+	//
+	// +optional
+	Notifications []PlanNotification `json:"notifications,omitempty"`
+
+	// HealthGates are checks that need to pass before autopilot moves on to
+	// signal the next node.
+	//
+	// +optional
+	HealthGates []PlanHealthGate `json:"healthGates,omitempty"`
+
+	// Proxy overrides the proxy settings that signal nodes use to download the
+	// update payloads of this plan. If omitted, the proxy settings from the
+	// environment of the k0s process are used.
+	//
+	// +optional
+	Proxy *PlanProxy `json:"proxy,omitempty"`
+
+	// NodeTimeout limits the time that a signal node may spend on processing a
+	// command, e.g. downloading or applying an update. A signal node exceeding
+	// it marks itself as failed, which is then handled according to the
+	// failure policy of its target. If omitted, signal nodes have no time limit.
+	//
+	// +optional
+	NodeTimeout *PlanNodeTimeout `json:"nodeTimeout,omitempty"`
+}
+
+// PlanNodeTimeout describes the time limit for signal nodes to process a command.
+type PlanNodeTimeout struct {
+	// Duration is the maximum time that a signal node may spend on processing a
+	// command, counting from the time it has been signaled.
+	Duration metav1.Duration `json:"duration"`
+
+	// Uncordon un-cordons signal nodes that have been cordoned for an update
+	// when they exceed the timeout, so that they can run workloads again.
+	//
+	// +optional
+	Uncordon bool `json:"uncordon,omitempty"`
+}
+
+// PlanProxy describes how signal nodes download the update payloads of a plan.
+type PlanProxy struct {
+	// URL is the URL of the proxy that is used for both HTTP and HTTPS downloads.
+	// If omitted, the proxy settings from the environment of the k0s process are used.
+	//
+	// +kubebuilder:validation:Pattern=`^(https?|socks5)://`
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// NoProxy is a list of hosts, domains, IP addresses or CIDRs that are
+	// downloaded from without using the proxy. Only applicable if URL is set.
+	//
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+
+	// CABundle is a PEM encoded bundle of CA certificates that are trusted for
+	// downloads, in addition to the system's certificate authorities.
+	//
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+}
+
+// PlanHealthGate is a check that needs to pass before autopilot signals the
+// next node. Exactly one of the checks needs to be specified.
+type PlanHealthGate struct {
+	// Name identifies this health gate in the plan status.
+	Name string `json:"name"`
+
+	// Timeout is the time a health gate may keep failing before the plan is
+	// considered to have failed.
+	//
+	// +kubebuilder:default:="10m"
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+
+	// HTTP checks the response of an HTTP GET request.
+	//
+	// +optional
+	HTTP *PlanHealthGateHTTP `json:"http,omitempty"`
+
+	// Deployment checks the availability of a Deployment.
+	//
+	// +optional
+	Deployment *PlanHealthGateDeployment `json:"deployment,omitempty"`
+
+	// NodeCondition checks a condition of all of the nodes that have already
+	// been updated by the plan.
+	//
+	// +optional
+	NodeCondition *PlanHealthGateNodeCondition `json:"nodeCondition,omitempty"`
+}
+
+// PlanHealthGateHTTP passes if an HTTP GET request to the URL responds with a
+// 2xx status code, and its body matches the provided regular expression, if any.
+type PlanHealthGateHTTP struct {
+	// URL is the HTTP or HTTPS URL to query.
+	//
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// Match is a regular expression that the response body needs to match.
+	//
+	// +optional
+	Match string `json:"match,omitempty"`
+}
+
+// PlanHealthGateDeployment passes if all of the replicas of a Deployment are
+// updated and available.
+type PlanHealthGateDeployment struct {
+	// Namespace is the namespace of the Deployment.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the Deployment.
+	Name string `json:"name"`
+}
+
+// PlanHealthGateNodeCondition passes if all of the nodes that have already
+// been updated by the plan have the given condition in the given status.
+type PlanHealthGateNodeCondition struct {
+	// Type is the type of the node condition. (ie. 'Ready')
+	Type corev1.NodeConditionType `json:"type"`
+
+	// Status is the required status of the node condition.
+	//
+	// +kubebuilder:default:="True"
+	// +optional
+	Status corev1.ConditionStatus `json:"status,omitempty"`
+}
+
+// PlanNotificationEvent is an event in the lifecycle of a plan that can be notified.
+//
+// +kubebuilder:validation:Enum=Started;Completed;Failed
+type PlanNotificationEvent string
+
+const (
+	// PlanNotificationStarted is sent when the plan starts its execution.
+	PlanNotificationStarted PlanNotificationEvent = "Started"
+
+	// PlanNotificationCompleted is sent when the plan has been completed successfully.
+	PlanNotificationCompleted PlanNotificationEvent = "Completed"
+
+	// PlanNotificationFailed is sent when the plan has ended in one of its failure states.
+	PlanNotificationFailed PlanNotificationEvent = "Failed"
+)
+
+// PlanNotification is a sink that is notified on plan state transitions.
+type PlanNotification struct {
+	// Events are the events that should be sent to this sink. If omitted,
+	// all events will be sent.
+	//
+	// +optional
+	Events []PlanNotificationEvent `json:"events,omitempty"`
+
+	// Webhook sends the notifications as HTTP POST requests.
+	Webhook PlanNotificationWebhook `json:"webhook"`
+}
+
+// PlanNotificationWebhook describes a generic HTTP webhook that is notified on
+// plan state transitions.
+type PlanNotificationWebhook struct {
+	// URL is the HTTP or HTTPS URL to which the notifications are posted.
+	//
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// Template is a Go template for the request body. The template has access to
+	// the fields `.Event`, `.PlanID`, `.State` and `.Timestamp`. If omitted, a
+	// JSON object containing these fields is sent.
+	//
+	// +optional
+	Template string `json:"template,omitempty"`
+
+	// ContentType is the content type of the request body.
+	//
+	// +kubebuilder:default:="application/json"
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
 }
 
 // PlanCommand is a command that can be run within a `Plan`
@@ -108,6 +299,14 @@ type PlanCommand struct {
 
 	// AirgapUpdate is the `AirgapUpdate` command which is responsible for updating a k0s airgap bundle.
 	AirgapUpdate *PlanCommandAirgapUpdate `json:"airgapupdate,omitempty"`
+
+	// RuntimeUpdate is the `RuntimeUpdate` command which is responsible for updating the bundled
+	// runtime components (containerd, runc, kubelet) of k0s workers without updating k0s itself.
+	RuntimeUpdate *PlanCommandRuntimeUpdate `json:"runtimeupdate,omitempty"`
+
+	// HelmUpdate is the `HelmUpdate` command which is responsible for updating the versions of
+	// the Helm charts that are declared as extensions in the cluster configuration.
+	HelmUpdate *PlanCommandHelmUpdate `json:"helmupdate,omitempty"`
 }
 
 // PlanPlatformResourceURLMap is a mapping of `PlanResourceURL` instances mapped to platform identifiers.
@@ -144,6 +343,65 @@ type PlanCommandAirgapUpdate struct {
 	Workers PlanCommandTarget `json:"workers"`
 }
 
+// PlanCommandRuntimeUpdate provides all of the information to for a `RuntimeUpdate` command to
+// update the bundled runtime components of a set of target signal nodes.
+type PlanCommandRuntimeUpdate struct {
+	// Version is an informational version of the runtime components bundle being
+	// rolled out, such as the CVE fix release identifier.
+	Version string `json:"version"`
+
+	// Components is the collection of bundled runtime binaries that will be replaced.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Components []PlanCommandRuntimeComponent `json:"components"`
+
+	// Workers defines how the k0s workers will be discovered and have their runtime updated.
+	Workers PlanCommandTarget `json:"workers"`
+}
+
+// PlanCommandRuntimeComponent is a single bundled runtime binary that will be replaced
+// as part of a `RuntimeUpdate` command.
+type PlanCommandRuntimeComponent struct {
+	// Name is the name of the bundled binary that will be replaced.
+	//
+	// +kubebuilder:validation:Enum=containerd;containerd-shim;containerd-shim-runc-v1;containerd-shim-runc-v2;runc;kubelet
+	Name string `json:"name"`
+
+	// Platforms is a map of PlanResourceUrls to platform identifiers, allowing a single
+	// component to have multiple URL resources based on platform.
+	Platforms PlanPlatformResourceURLMap `json:"platforms"`
+}
+
+// PlanCommandHelmUpdate provides all of the information for a `HelmUpdate` command to
+// update the Helm charts declared in `spec.extensions.helm` of the cluster configuration.
+type PlanCommandHelmUpdate struct {
+	// Charts is the collection of Helm extension charts that will be updated, one at a time,
+	// in the order given.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Charts []PlanCommandHelmChart `json:"charts"`
+
+	// Timeout is the time to wait for a chart release to be upgraded before it's considered
+	// to have failed and is rolled back. Defaults to 10 minutes.
+	//
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// PlanCommandHelmChart is a single Helm extension chart that will be updated as part of a
+// `HelmUpdate` command.
+type PlanCommandHelmChart struct {
+	// Name is the name of the chart in `spec.extensions.helm.charts` of the cluster configuration.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Version is the chart version that the chart will be updated to.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
+}
+
 // PlanResourceURL is a remote URL resource.
 type PlanResourceURL struct {
 	// URL is the URL of a downloadable resource.
@@ -177,6 +435,61 @@ type PlanCommandTarget struct {
 	// +kubebuilder:default={concurrent:1}
 	// +optional
 	Limits PlanCommandTargetLimits `json:"limits"`
+
+	// Rollout splits the execution of this target into stages, which are gated by bake times.
+	// If omitted, all of the discovered signal nodes are executed in a single stage.
+	// Rollouts are only applicable to worker targets.
+	//
+	// +optional
+	Rollout *PlanCommandTargetRollout `json:"rollout,omitempty"`
+
+	// FailurePolicy defines how failures of individual signal nodes are handled.
+	// If omitted, the first failure of any signal node ends the plan.
+	//
+	// +optional
+	FailurePolicy *PlanCommandTargetFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// PlanCommandTargetFailurePolicy defines how failures of individual signal nodes are handled.
+type PlanCommandTargetFailurePolicy struct {
+	// Retries is the number of times a failed signal node is signaled again before
+	// its failure is considered to be permanent.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Retries int `json:"retries,omitempty"`
+
+	// Skip allows the plan to continue with the remaining signal nodes when a signal
+	// node has failed permanently. The signal node is then marked as skipped.
+	//
+	// +optional
+	Skip bool `json:"skip,omitempty"`
+}
+
+// PlanCommandTargetRollout describes a staged rollout of a command to the signal nodes of a target.
+type PlanCommandTargetRollout struct {
+	// Stages are the stages of the rollout, in ascending order of their percentages.
+	// Any signal nodes that are not covered by the stages are executed after the bake
+	// time of the last stage has elapsed.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Stages []PlanCommandTargetRolloutStage `json:"stages"`
+}
+
+// PlanCommandTargetRolloutStage is a single stage of a staged rollout.
+type PlanCommandTargetRolloutStage struct {
+	// Percentage is the cumulative percentage of signal nodes that will have been
+	// executed once this stage is completed. (ie. '10' == 10% of the signal nodes)
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Percentage int `json:"percentage"`
+
+	// BakeTime is the time to wait after this stage has been completed before the
+	// next stage begins. (ie. '24h')
+	//
+	// +optional
+	BakeTime metav1.Duration `json:"bakeTime,omitempty"`
 }
 
 // PlanCommandTargetLimits are limits that can be imposed on a target of a command.
@@ -186,6 +499,27 @@ type PlanCommandTargetLimits struct {
 	//
 	// +kubebuilder:default=1
 	Concurrent int `json:"concurrent,omitempty"`
+
+	// Groups further restrict the number of concurrent target executions for the
+	// signal nodes that are members of a group, e.g. to update at most one node per
+	// rack at a time. Groups are only applicable to worker targets.
+	//
+	// +optional
+	Groups []PlanCommandTargetConcurrencyGroup `json:"groups,omitempty"`
+}
+
+// PlanCommandTargetConcurrencyGroup limits the number of concurrent target executions
+// for the signal nodes that match its selector.
+type PlanCommandTargetConcurrencyGroup struct {
+	// Selector is a standard kubernetes label selector (key=value,key=value,...)
+	// selecting the signal nodes that are members of this group.
+	Selector string `json:"selector"`
+
+	// MaxConcurrent is the maximum number of members of this group that execute
+	// at the same time.
+	//
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrent int `json:"maxConcurrent"`
 }
 
 // PlanCommandTargetDiscovery contains the type of discovery mechanism that should be used
@@ -228,6 +562,23 @@ type PlanStatus struct {
 	// Commands are a collection of status's for each of the commands defined in the plan,
 	// maintained in their index order.
 	Commands []PlanCommandStatus `json:"commands"`
+
+	// HealthGates are the health gates of the plan that are currently failing.
+	//
+	// +optional
+	HealthGates []PlanHealthGateStatus `json:"healthGates,omitempty"`
+}
+
+// PlanHealthGateStatus is the status of a failing health gate.
+type PlanHealthGateStatus struct {
+	// Name is the name of the health gate.
+	Name string `json:"name"`
+
+	// FailingSince is the time at which the health gate started to fail.
+	FailingSince metav1.Time `json:"failingSince"`
+
+	// Message describes why the health gate is failing.
+	Message string `json:"message,omitempty"`
 }
 
 // PlanCommandStatus is the status of a known command.
@@ -246,6 +597,12 @@ type PlanCommandStatus struct {
 
 	// AirgapUpdate is the status of the `AirgapUpdate` command.
 	AirgapUpdate *PlanCommandAirgapUpdateStatus `json:"airgapupdate,omitempty"`
+
+	// RuntimeUpdate is the status of the `RuntimeUpdate` command.
+	RuntimeUpdate *PlanCommandRuntimeUpdateStatus `json:"runtimeupdate,omitempty"`
+
+	// HelmUpdate is the status of the `HelmUpdate` command.
+	HelmUpdate *PlanCommandHelmUpdateStatus `json:"helmupdate,omitempty"`
 }
 
 // PlanCommandK0sUpdateStatus is the status of a `K0sUpdate` command for a collection
@@ -265,6 +622,37 @@ type PlanCommandAirgapUpdateStatus struct {
 	Workers []PlanCommandTargetStatus `json:"workers,omitempty"`
 }
 
+// PlanCommandRuntimeUpdateStatus is the status of a `RuntimeUpdate` command for
+// k0s worker nodes.
+type PlanCommandRuntimeUpdateStatus struct {
+	// Workers are a collection of status for resolved k0s worker targets.
+	Workers []PlanCommandTargetStatus `json:"workers,omitempty"`
+}
+
+// PlanCommandHelmUpdateStatus is the status of a `HelmUpdate` command for the
+// Helm extension charts of the cluster.
+type PlanCommandHelmUpdateStatus struct {
+	// Charts are a collection of status for the charts being updated.
+	Charts []PlanCommandHelmChartStatus `json:"charts,omitempty"`
+}
+
+// PlanCommandHelmChartStatus is the status of a single Helm extension chart.
+type PlanCommandHelmChartStatus struct {
+	PlanCommandTargetStatus `json:",inline"`
+
+	// PreviousVersion is the version of the chart before the update, which the chart is
+	// rolled back to if the release fails.
+	//
+	// +optional
+	PreviousVersion string `json:"previousVersion,omitempty"`
+
+	// ReleaseUpdated is the last time the chart controller updated the release status
+	// before the update was requested. It's used to tell stale release errors apart.
+	//
+	// +optional
+	ReleaseUpdated string `json:"releaseUpdated,omitempty"`
+}
+
 // PlanCommandTargetStateType is the state of a PlanCommandTarget
 type PlanCommandTargetStateType PlanStateType
 
@@ -278,4 +666,9 @@ type PlanCommandTargetStatus struct {
 
 	// LastUpdatedTimestamp is a timestamp of the last time the status has changed.
 	LastUpdatedTimestamp metav1.Time `json:"lastUpdatedTimestamp"`
+
+	// Failures is the number of times the target signal node has reported a failure.
+	//
+	// +optional
+	Failures int `json:"failures,omitempty"`
 }
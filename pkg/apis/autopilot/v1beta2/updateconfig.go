@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	uc "github.com/k0sproject/k0s/pkg/autopilot/channels"
+
+	"github.com/k0sproject/version"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -40,6 +43,23 @@ type UpdateSpec struct {
 	// UpdateServer defines the update server to use for this update config
 	// +kubebuilder:default:="https://updates.k0sproject.io"
 	UpdateServer string `json:"updateServer,omitempty"`
+	// PollInterval defines how often the update server is polled for new
+	// versions. Defaults to 10 minutes.
+	//
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+	// VersionHold prevents automatic updates beyond a certain version. It's
+	// either a minor version, such as v1.29, which keeps the cluster on the
+	// patch releases of that minor version, or a full version which won't be
+	// exceeded.
+	//
+	// +optional
+	VersionHold string `json:"versionHold,omitempty"`
+	// Cooldown defines the minimum time between two automatically created
+	// plans.
+	//
+	// +optional
+	Cooldown *metav1.Duration `json:"cooldown,omitempty"`
 	// UpdateStrategy defines the update strategy to use for this update config
 	//
 	// +optional
@@ -55,6 +75,30 @@ type AutopilotPlanSpec struct {
 	// Commands are a collection of all of the commands that need to be executed
 	// in order for this plan to transition to Completed.
 	Commands []AutopilotPlanCommand `json:"commands"`
+
+	// Notifications are a collection of sinks that are notified when the
+	// generated plan starts, completes or fails.
+	//
+	// +optional
+	Notifications []PlanNotification `json:"notifications,omitempty"`
+
+	// HealthGates are checks that need to pass before autopilot moves on to
+	// signal the next node of the generated plan.
+	//
+	// +optional
+	HealthGates []PlanHealthGate `json:"healthGates,omitempty"`
+
+	// Proxy overrides the proxy settings that signal nodes use to download the
+	// update payloads of the generated plan.
+	//
+	// +optional
+	Proxy *PlanProxy `json:"proxy,omitempty"`
+
+	// NodeTimeout limits the time that a signal node of the generated plan may
+	// spend on processing a command.
+	//
+	// +optional
+	NodeTimeout *PlanNodeTimeout `json:"nodeTimeout,omitempty"`
 }
 
 // AutopilotPlanCommand is a command that can be run within a `Plan`
@@ -140,6 +184,45 @@ func startTimeForCurrentDay(startTime time.Time) time.Time {
 	return time.Date(now.Year(), now.Month(), now.Day(), startTime.Hour(), startTime.Minute(), 0, 0, time.Local)
 }
 
+// IsVersionHeld determines if an update to the given version is prevented by
+// the version hold of the update config.
+func (s *UpdateSpec) IsVersionHeld(v string) (bool, error) {
+	if s.VersionHold == "" {
+		return false, nil
+	}
+
+	hold, err := version.NewVersion(s.VersionHold)
+	if err != nil {
+		return false, fmt.Errorf("invalid version hold: %w", err)
+	}
+	next, err := version.NewVersion(v)
+	if err != nil {
+		return false, err
+	}
+
+	if isMinorVersion(s.VersionHold) {
+		holdSegments, nextSegments := hold.Segments(), next.Segments()
+		return nextSegments[0] > holdSegments[0] ||
+			(nextSegments[0] == holdSegments[0] && nextSegments[1] > holdSegments[1]), nil
+	}
+
+	return next.GreaterThan(hold), nil
+}
+
+// isMinorVersion determines if the given version only consists of a major and
+// a minor version, such as v1.29.
+func isMinorVersion(v string) bool {
+	core, _, _ := strings.Cut(strings.TrimPrefix(v, "v"), "+")
+	core, _, _ = strings.Cut(core, "-")
+	return strings.Count(core, ".") == 1
+}
+
+// IsCoolingDown determines if the cooldown of the update config, counted from
+// the time the last plan has been created, is still in effect.
+func (s *UpdateSpec) IsCoolingDown(lastPlan, now time.Time) bool {
+	return s.Cooldown != nil && now.Before(lastPlan.Add(s.Cooldown.Duration))
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Cluster
 type UpdateConfigList struct {
@@ -158,7 +241,12 @@ func (uc *UpdateConfig) ToPlan(nextVersion uc.VersionInfo) Plan {
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "autopilot",
 		},
-		Spec: PlanSpec{},
+		Spec: PlanSpec{
+			Notifications: uc.Spec.PlanSpec.Notifications,
+			HealthGates:   uc.Spec.PlanSpec.HealthGates,
+			Proxy:         uc.Spec.PlanSpec.Proxy,
+			NodeTimeout:   uc.Spec.PlanSpec.NodeTimeout,
+		},
 	}
 
 	platforms := make(PlanPlatformResourceURLMap)
@@ -214,25 +302,33 @@ func (uc *UpdateConfig) ToPlan(nextVersion uc.VersionInfo) Plan {
 			},
 		})
 	} else {
+		// A plan command is processed by a single provider, hence every update is
+		// turned into a command of its own. The airgap bundles are distributed
+		// before any k0s update, so that the images are already in place once
+		// the updated workers restart.
+		var airgapCmds, k0sCmds []PlanCommand
 		for _, cmd := range uc.Spec.PlanSpec.Commands {
-			planCmd := PlanCommand{}
-			if cmd.K0sUpdate != nil {
-				planCmd.K0sUpdate = &PlanCommandK0sUpdate{
-					Version:     nextVersion.Version,
-					ForceUpdate: cmd.K0sUpdate.ForceUpdate,
-					Platforms:   platforms,
-					Targets:     cmd.K0sUpdate.Targets,
-				}
-			}
 			if cmd.AirgapUpdate != nil {
-				planCmd.AirgapUpdate = &PlanCommandAirgapUpdate{
-					Version:   nextVersion.Version,
-					Platforms: airgapPlatforms,
-					Workers:   cmd.AirgapUpdate.Workers,
-				}
+				airgapCmds = append(airgapCmds, PlanCommand{
+					AirgapUpdate: &PlanCommandAirgapUpdate{
+						Version:   nextVersion.Version,
+						Platforms: airgapPlatforms,
+						Workers:   cmd.AirgapUpdate.Workers,
+					},
+				})
+			}
+			if cmd.K0sUpdate != nil {
+				k0sCmds = append(k0sCmds, PlanCommand{
+					K0sUpdate: &PlanCommandK0sUpdate{
+						Version:     nextVersion.Version,
+						ForceUpdate: cmd.K0sUpdate.ForceUpdate,
+						Platforms:   platforms,
+						Targets:     cmd.K0sUpdate.Targets,
+					},
+				})
 			}
-			p.Spec.Commands = append(p.Spec.Commands, planCmd)
 		}
+		p.Spec.Commands = append(airgapCmds, k0sCmds...)
 	}
 
 	return p
@@ -9,6 +9,7 @@ import (
 
 	"github.com/k0sproject/k0s/pkg/autopilot/channels"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestPeriodicUpgradeStrategy_IsWithinPeriod(t *testing.T) {
@@ -159,3 +160,102 @@ func TestToPlan_ExistingCommand(t *testing.T) {
 	require.Equal("some_k0s_url", k0sCommand.Platforms["linux-arm64"].URL)
 
 }
+
+func TestToPlan_Notifications(t *testing.T) {
+	notifications := []PlanNotification{
+		{
+			Events:  []PlanNotificationEvent{PlanNotificationFailed},
+			Webhook: PlanNotificationWebhook{URL: "https://example.com/hook"},
+		},
+	}
+
+	uc := UpdateConfig{
+		Spec: UpdateSpec{
+			PlanSpec: AutopilotPlanSpec{
+				Notifications: notifications,
+			},
+		},
+	}
+
+	plan := uc.ToPlan(channels.VersionInfo{Version: "v1.2.3"})
+	require.Equal(t, notifications, plan.Spec.Notifications)
+}
+
+func TestToPlan_AirgapUpdate(t *testing.T) {
+	uc := UpdateConfig{
+		Spec: UpdateSpec{
+			PlanSpec: AutopilotPlanSpec{
+				Commands: []AutopilotPlanCommand{
+					{
+						K0sUpdate:    &AutopilotPlanCommandK0sUpdate{},
+						AirgapUpdate: &AutopilotPlanCommandAirgapUpdate{},
+					},
+				},
+			},
+		},
+	}
+
+	nextVersion := channels.VersionInfo{
+		Version: "v1.2.3",
+		DownloadURLs: []channels.DownloadURL{
+			{
+				Arch:         "arm64",
+				OS:           "linux",
+				K0S:          "some_k0s_url",
+				AirgapBundle: "some_airgap_url",
+				AirgapSha256: "some_airgap_sha",
+			},
+		},
+	}
+	plan := uc.ToPlan(nextVersion)
+	require := require.New(t)
+
+	// The airgap bundle needs to be distributed before k0s gets updated.
+	require.Len(plan.Spec.Commands, 2)
+	airgapCommand := plan.Spec.Commands[0].AirgapUpdate
+	require.NotNil(airgapCommand)
+	require.Nil(plan.Spec.Commands[0].K0sUpdate)
+	require.Equal("v1.2.3", airgapCommand.Version)
+	require.Equal(PlanResourceURL{URL: "some_airgap_url", Sha256: "some_airgap_sha"}, airgapCommand.Platforms["linux-arm64"])
+	require.NotNil(plan.Spec.Commands[1].K0sUpdate)
+	require.Nil(plan.Spec.Commands[1].AirgapUpdate)
+}
+
+func TestUpdateSpec_IsVersionHeld(t *testing.T) {
+	tests := []struct {
+		hold    string
+		version string
+		want    bool
+	}{
+		{"", "v1.30.0+k0s.0", false},
+		{"v1.29", "v1.29.7+k0s.0", false},
+		{"v1.29", "v1.30.0+k0s.0", true},
+		{"v1.29", "v2.0.0+k0s.0", true},
+		{"v1.29", "v1.28.9+k0s.0", false},
+		{"v1.29.3+k0s.0", "v1.29.3+k0s.0", false},
+		{"v1.29.3+k0s.0", "v1.29.4+k0s.0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hold+"/"+tt.version, func(t *testing.T) {
+			spec := UpdateSpec{VersionHold: tt.hold}
+			held, err := spec.IsVersionHeld(tt.version)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, held)
+		})
+	}
+
+	spec := UpdateSpec{VersionHold: "one.twenty-nine"}
+	_, err := spec.IsVersionHeld("v1.30.0+k0s.0")
+	require.ErrorContains(t, err, "invalid version hold")
+}
+
+func TestUpdateSpec_IsCoolingDown(t *testing.T) {
+	lastPlan := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	spec := UpdateSpec{}
+	require.False(t, spec.IsCoolingDown(lastPlan, lastPlan.Add(time.Minute)))
+
+	spec.Cooldown = &metav1.Duration{Duration: 24 * time.Hour}
+	require.True(t, spec.IsCoolingDown(lastPlan, lastPlan.Add(23*time.Hour)))
+	require.False(t, spec.IsCoolingDown(lastPlan, lastPlan.Add(25*time.Hour)))
+}
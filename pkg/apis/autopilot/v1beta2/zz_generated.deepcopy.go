@@ -9,6 +9,7 @@ package v1beta2
 
 import (
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -79,6 +80,30 @@ func (in *AutopilotPlanSpec) DeepCopyInto(out *AutopilotPlanSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]PlanNotification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HealthGates != nil {
+		in, out := &in.HealthGates, &out.HealthGates
+		*out = make([]PlanHealthGate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(PlanProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeTimeout != nil {
+		in, out := &in.NodeTimeout, &out.NodeTimeout
+		*out = new(PlanNodeTimeout)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutopilotPlanSpec.
@@ -157,6 +182,13 @@ func (in *ControlNodeStatus) DeepCopyInto(out *ControlNodeStatus) {
 		*out = make([]v1.NodeAddress, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlNodeStatus.
@@ -229,6 +261,16 @@ func (in *PlanCommand) DeepCopyInto(out *PlanCommand) {
 		*out = new(PlanCommandAirgapUpdate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RuntimeUpdate != nil {
+		in, out := &in.RuntimeUpdate, &out.RuntimeUpdate
+		*out = new(PlanCommandRuntimeUpdate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HelmUpdate != nil {
+		in, out := &in.HelmUpdate, &out.HelmUpdate
+		*out = new(PlanCommandHelmUpdate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommand.
@@ -286,6 +328,84 @@ func (in *PlanCommandAirgapUpdateStatus) DeepCopy() *PlanCommandAirgapUpdateStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandHelmChart) DeepCopyInto(out *PlanCommandHelmChart) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandHelmChart.
+func (in *PlanCommandHelmChart) DeepCopy() *PlanCommandHelmChart {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandHelmChart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandHelmChartStatus) DeepCopyInto(out *PlanCommandHelmChartStatus) {
+	*out = *in
+	in.PlanCommandTargetStatus.DeepCopyInto(&out.PlanCommandTargetStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandHelmChartStatus.
+func (in *PlanCommandHelmChartStatus) DeepCopy() *PlanCommandHelmChartStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandHelmChartStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandHelmUpdate) DeepCopyInto(out *PlanCommandHelmUpdate) {
+	*out = *in
+	if in.Charts != nil {
+		in, out := &in.Charts, &out.Charts
+		*out = make([]PlanCommandHelmChart, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandHelmUpdate.
+func (in *PlanCommandHelmUpdate) DeepCopy() *PlanCommandHelmUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandHelmUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandHelmUpdateStatus) DeepCopyInto(out *PlanCommandHelmUpdateStatus) {
+	*out = *in
+	if in.Charts != nil {
+		in, out := &in.Charts, &out.Charts
+		*out = make([]PlanCommandHelmChartStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandHelmUpdateStatus.
+func (in *PlanCommandHelmUpdateStatus) DeepCopy() *PlanCommandHelmUpdateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandHelmUpdateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlanCommandK0sUpdate) DeepCopyInto(out *PlanCommandK0sUpdate) {
 	*out = *in
@@ -338,6 +458,73 @@ func (in *PlanCommandK0sUpdateStatus) DeepCopy() *PlanCommandK0sUpdateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandRuntimeComponent) DeepCopyInto(out *PlanCommandRuntimeComponent) {
+	*out = *in
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make(PlanPlatformResourceURLMap, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandRuntimeComponent.
+func (in *PlanCommandRuntimeComponent) DeepCopy() *PlanCommandRuntimeComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandRuntimeComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandRuntimeUpdate) DeepCopyInto(out *PlanCommandRuntimeUpdate) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]PlanCommandRuntimeComponent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Workers.DeepCopyInto(&out.Workers)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandRuntimeUpdate.
+func (in *PlanCommandRuntimeUpdate) DeepCopy() *PlanCommandRuntimeUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandRuntimeUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandRuntimeUpdateStatus) DeepCopyInto(out *PlanCommandRuntimeUpdateStatus) {
+	*out = *in
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = make([]PlanCommandTargetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandRuntimeUpdateStatus.
+func (in *PlanCommandRuntimeUpdateStatus) DeepCopy() *PlanCommandRuntimeUpdateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandRuntimeUpdateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlanCommandStatus) DeepCopyInto(out *PlanCommandStatus) {
 	*out = *in
@@ -351,6 +538,16 @@ func (in *PlanCommandStatus) DeepCopyInto(out *PlanCommandStatus) {
 		*out = new(PlanCommandAirgapUpdateStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RuntimeUpdate != nil {
+		in, out := &in.RuntimeUpdate, &out.RuntimeUpdate
+		*out = new(PlanCommandRuntimeUpdateStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HelmUpdate != nil {
+		in, out := &in.HelmUpdate, &out.HelmUpdate
+		*out = new(PlanCommandHelmUpdateStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandStatus.
@@ -367,7 +564,17 @@ func (in *PlanCommandStatus) DeepCopy() *PlanCommandStatus {
 func (in *PlanCommandTarget) DeepCopyInto(out *PlanCommandTarget) {
 	*out = *in
 	in.Discovery.DeepCopyInto(&out.Discovery)
-	out.Limits = in.Limits
+	in.Limits.DeepCopyInto(&out.Limits)
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(PlanCommandTargetRollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(PlanCommandTargetFailurePolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandTarget.
@@ -380,6 +587,21 @@ func (in *PlanCommandTarget) DeepCopy() *PlanCommandTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandTargetConcurrencyGroup) DeepCopyInto(out *PlanCommandTargetConcurrencyGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandTargetConcurrencyGroup.
+func (in *PlanCommandTargetConcurrencyGroup) DeepCopy() *PlanCommandTargetConcurrencyGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandTargetConcurrencyGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlanCommandTargetDiscovery) DeepCopyInto(out *PlanCommandTargetDiscovery) {
 	*out = *in
@@ -440,9 +662,29 @@ func (in *PlanCommandTargetDiscoveryStatic) DeepCopy() *PlanCommandTargetDiscove
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandTargetFailurePolicy) DeepCopyInto(out *PlanCommandTargetFailurePolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandTargetFailurePolicy.
+func (in *PlanCommandTargetFailurePolicy) DeepCopy() *PlanCommandTargetFailurePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandTargetFailurePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlanCommandTargetLimits) DeepCopyInto(out *PlanCommandTargetLimits) {
 	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]PlanCommandTargetConcurrencyGroup, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandTargetLimits.
@@ -455,6 +697,42 @@ func (in *PlanCommandTargetLimits) DeepCopy() *PlanCommandTargetLimits {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandTargetRollout) DeepCopyInto(out *PlanCommandTargetRollout) {
+	*out = *in
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]PlanCommandTargetRolloutStage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandTargetRollout.
+func (in *PlanCommandTargetRollout) DeepCopy() *PlanCommandTargetRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandTargetRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandTargetRolloutStage) DeepCopyInto(out *PlanCommandTargetRolloutStage) {
+	*out = *in
+	out.BakeTime = in.BakeTime
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandTargetRolloutStage.
+func (in *PlanCommandTargetRolloutStage) DeepCopy() *PlanCommandTargetRolloutStage {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandTargetRolloutStage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlanCommandTargetStatus) DeepCopyInto(out *PlanCommandTargetStatus) {
 	*out = *in
@@ -488,6 +766,98 @@ func (in *PlanCommandTargets) DeepCopy() *PlanCommandTargets {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanHealthGate) DeepCopyInto(out *PlanHealthGate) {
+	*out = *in
+	out.Timeout = in.Timeout
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(PlanHealthGateHTTP)
+		**out = **in
+	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(PlanHealthGateDeployment)
+		**out = **in
+	}
+	if in.NodeCondition != nil {
+		in, out := &in.NodeCondition, &out.NodeCondition
+		*out = new(PlanHealthGateNodeCondition)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanHealthGate.
+func (in *PlanHealthGate) DeepCopy() *PlanHealthGate {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanHealthGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanHealthGateDeployment) DeepCopyInto(out *PlanHealthGateDeployment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanHealthGateDeployment.
+func (in *PlanHealthGateDeployment) DeepCopy() *PlanHealthGateDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanHealthGateDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanHealthGateHTTP) DeepCopyInto(out *PlanHealthGateHTTP) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanHealthGateHTTP.
+func (in *PlanHealthGateHTTP) DeepCopy() *PlanHealthGateHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanHealthGateHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanHealthGateNodeCondition) DeepCopyInto(out *PlanHealthGateNodeCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanHealthGateNodeCondition.
+func (in *PlanHealthGateNodeCondition) DeepCopy() *PlanHealthGateNodeCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanHealthGateNodeCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanHealthGateStatus) DeepCopyInto(out *PlanHealthGateStatus) {
+	*out = *in
+	in.FailingSince.DeepCopyInto(&out.FailingSince)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanHealthGateStatus.
+func (in *PlanHealthGateStatus) DeepCopy() *PlanHealthGateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanHealthGateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlanList) DeepCopyInto(out *PlanList) {
 	*out = *in
@@ -520,6 +890,58 @@ func (in *PlanList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanNodeTimeout) DeepCopyInto(out *PlanNodeTimeout) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanNodeTimeout.
+func (in *PlanNodeTimeout) DeepCopy() *PlanNodeTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanNodeTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanNotification) DeepCopyInto(out *PlanNotification) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]PlanNotificationEvent, len(*in))
+		copy(*out, *in)
+	}
+	out.Webhook = in.Webhook
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanNotification.
+func (in *PlanNotification) DeepCopy() *PlanNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanNotificationWebhook) DeepCopyInto(out *PlanNotificationWebhook) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanNotificationWebhook.
+func (in *PlanNotificationWebhook) DeepCopy() *PlanNotificationWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanNotificationWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in PlanPlatformResourceURLMap) DeepCopyInto(out *PlanPlatformResourceURLMap) {
 	{
@@ -541,6 +963,26 @@ func (in PlanPlatformResourceURLMap) DeepCopy() PlanPlatformResourceURLMap {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanProxy) DeepCopyInto(out *PlanProxy) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanProxy.
+func (in *PlanProxy) DeepCopy() *PlanProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlanResourceURL) DeepCopyInto(out *PlanResourceURL) {
 	*out = *in
@@ -566,6 +1008,30 @@ func (in *PlanSpec) DeepCopyInto(out *PlanSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]PlanNotification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HealthGates != nil {
+		in, out := &in.HealthGates, &out.HealthGates
+		*out = make([]PlanHealthGate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(PlanProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeTimeout != nil {
+		in, out := &in.NodeTimeout, &out.NodeTimeout
+		*out = new(PlanNodeTimeout)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanSpec.
@@ -588,6 +1054,13 @@ func (in *PlanStatus) DeepCopyInto(out *PlanStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HealthGates != nil {
+		in, out := &in.HealthGates, &out.HealthGates
+		*out = make([]PlanHealthGateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanStatus.
@@ -661,6 +1134,16 @@ func (in *UpdateConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpdateSpec) DeepCopyInto(out *UpdateSpec) {
 	*out = *in
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	in.UpgradeStrategy.DeepCopyInto(&out.UpgradeStrategy)
 	in.PlanSpec.DeepCopyInto(&out.PlanSpec)
 }
@@ -51,8 +51,34 @@ type APISpec struct {
 	// +listType=set
 	SANs []string `json:"sans,omitempty"`
 
+	// Cloud provider whose instance metadata is queried for additional SANs
+	// when the controller starts: aws, gcp, azure, openstack, or auto to
+	// detect the provider. The discovered IP addresses and DNS names are added
+	// to the SANs, so they needn't be listed again when instances are replaced.
+	// +kubebuilder:validation:Enum=auto;aws;gcp;azure;openstack
+	// +optional
+	SANsFromCloudMetadata string `json:"sansFromCloudMetadata,omitempty"`
+
 	// Custom config for CA certificates.
 	CA *CA `json:"ca,omitempty"`
+
+	// Lets workers join the cluster with the signed identity of their cloud
+	// instance, instead of a join token.
+	// +optional
+	CloudIdentityJoin *CloudIdentityJoin `json:"cloudIdentityJoin,omitempty"`
+
+	// Lets nodes join the cluster by submitting a certificate signing request
+	// via the k0s join API that needs to be approved, instead of a join token.
+	// +optional
+	JoinRequests *JoinRequests `json:"joinRequests,omitempty"`
+
+	// How k0s restarts kube-apiserver after it exited.
+	// +optional
+	RestartPolicy *RestartPolicy `json:"restartPolicy,omitempty"`
+
+	// The resource limits of kube-apiserver.
+	// +optional
+	Resources *ProcessResources `json:"resources,omitempty"`
 }
 
 // DefaultAPISpec default settings for api
@@ -171,6 +197,23 @@ func (a *APISpec) Validate() []error {
 		validateIPAddressOrDNSName(sansPath.Index(idx), san)
 	}
 
+	switch a.SANsFromCloudMetadata {
+	case "", "auto", "aws", "gcp", "azure", "openstack":
+	default:
+		errors = append(errors, field.NotSupported(field.NewPath("sansFromCloudMetadata"), a.SANsFromCloudMetadata, []string{"auto", "aws", "gcp", "azure", "openstack"}))
+	}
+
+	for _, err := range a.CloudIdentityJoin.Validate(field.NewPath("cloudIdentityJoin")) {
+		errors = append(errors, err)
+	}
+
+	for _, err := range a.JoinRequests.Validate(field.NewPath("joinRequests")) {
+		errors = append(errors, err)
+	}
+
+	errors = append(errors, a.RestartPolicy.Validate(field.NewPath("restartPolicy"))...)
+	errors = append(errors, a.Resources.Validate(field.NewPath("resources"))...)
+
 	return errors
 }
 
@@ -57,6 +57,59 @@ func (s *APISuite) TestValidation() {
 			s.ErrorContains(errors[0], `sans[0]: Invalid value: "something.that.is.not.valid//(())": invalid IP address / DNS name`)
 		}
 	})
+	s.Run("sans_from_cloud_metadata", func() {
+		a := APISpec{SANsFromCloudMetadata: "auto"}
+		a.setDefaults()
+		s.NoError(errors.Join(a.Validate()...))
+
+		a.SANsFromCloudMetadata = "digitalocean"
+		errors := a.Validate()
+		if s.Len(errors, 1) {
+			s.ErrorContains(errors[0], `sansFromCloudMetadata: Unsupported value: "digitalocean"`)
+		}
+	})
+
+	s.Run("cloud_identity_join", func() {
+		a := APISpec{CloudIdentityJoin: &CloudIdentityJoin{GCP: &GCPIdentityJoin{ProjectIDs: []string{"my-project"}}}}
+		a.setDefaults()
+		s.NoError(errors.Join(a.Validate()...))
+
+		a.CloudIdentityJoin = &CloudIdentityJoin{}
+		errs := a.Validate()
+		if s.Len(errs, 1) {
+			s.ErrorContains(errs[0], "cloudIdentityJoin: Required value: at least one of aws or gcp needs to be configured")
+		}
+
+		a.CloudIdentityJoin = &CloudIdentityJoin{
+			AWS: &AWSIdentityJoin{Certificates: "not a certificate"},
+			GCP: &GCPIdentityJoin{},
+		}
+		errs = a.Validate()
+		if s.Len(errs, 3) {
+			s.ErrorContains(errs[0], "cloudIdentityJoin.aws.certificates: Invalid value")
+			s.ErrorContains(errs[1], "cloudIdentityJoin.aws.accountIDs: Required value")
+			s.ErrorContains(errs[2], "cloudIdentityJoin.gcp.projectIDs: Required value")
+		}
+
+		a.CloudIdentityJoin = &CloudIdentityJoin{AWS: &AWSIdentityJoin{
+			Method:     AWSIdentityMethodGetCallerIdentity,
+			AccountIDs: []string{"123456789012"},
+			RoleNames:  []string{"k0s-worker"},
+		}}
+		s.NoError(errors.Join(a.Validate()...))
+
+		a.CloudIdentityJoin.AWS.Regions = []string{"eu-west-1"}
+		errs = a.Validate()
+		if s.Len(errs, 1) {
+			s.ErrorContains(errs[0], "cloudIdentityJoin.aws.regions: Forbidden: only supported for InstanceIdentityDocument")
+		}
+
+		a.CloudIdentityJoin.AWS.Method = "Other"
+		errs = a.Validate()
+		if s.Len(errs, 1) {
+			s.ErrorContains(errs[0], `cloudIdentityJoin.aws.method: Unsupported value: "Other"`)
+		}
+	})
 }
 
 func TestApiSuite(t *testing.T) {
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"path/filepath"
+
+	"github.com/robfig/cron"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ Validateable = (*BackupSpec)(nil)
+
+// DefaultBackupRetention is the number of backup archives that are kept if no
+// retention has been configured.
+const DefaultBackupRetention = 7
+
+// BackupSpec defines the settings for scheduled backups. Scheduled backups are
+// taken by the leading controller, in the same way as `k0s backup` does.
+type BackupSpec struct {
+	// Schedule in which backups are taken, in cron format, e.g. "0 3 * * *" or
+	// "@daily". Scheduled backups are disabled if empty.
+	Schedule string `json:"schedule,omitempty"`
+
+	// Absolute path of the directory in which the backup archives are stored.
+	// Required if a schedule is set.
+	SavePath string `json:"savePath,omitempty"`
+
+	// Number of most recent backup archives to keep in the save path. Older
+	// archives are removed after each successful backup.
+	// +kubebuilder:default=7
+	// +kubebuilder:validation:Minimum=0
+	Retention int32 `json:"retention,omitempty"`
+}
+
+// IsEnabled returns true if scheduled backups are enabled.
+func (b *BackupSpec) IsEnabled() bool {
+	return b != nil && b.Schedule != ""
+}
+
+// GetRetention returns the number of backup archives to keep.
+func (b *BackupSpec) GetRetention() int {
+	if b == nil || b.Retention <= 0 {
+		return DefaultBackupRetention
+	}
+	return int(b.Retention)
+}
+
+// Validate validates the backup settings.
+func (b *BackupSpec) Validate() []error {
+	if b == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if b.Schedule != "" {
+		if _, err := cron.ParseStandard(b.Schedule); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("schedule"), b.Schedule, err.Error()))
+		}
+		if b.SavePath == "" {
+			errs = append(errs, field.Required(field.NewPath("savePath"), "required if a schedule is set"))
+		}
+	}
+
+	if b.SavePath != "" && !filepath.IsAbs(b.SavePath) {
+		errs = append(errs, field.Invalid(field.NewPath("savePath"), b.SavePath, "must be an absolute path"))
+	}
+
+	if b.Retention < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("retention"), b.Retention, "must not be negative"))
+	}
+
+	return errs
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		backup *BackupSpec
+		errs   []string
+	}{
+		{"nil", nil, nil},
+		{"disabled", &BackupSpec{}, nil},
+		{"valid", &BackupSpec{Schedule: "0 3 * * *", SavePath: "/var/backups/k0s", Retention: 3}, nil},
+		{"descriptor", &BackupSpec{Schedule: "@daily", SavePath: "/var/backups/k0s"}, nil},
+		{"invalid schedule", &BackupSpec{Schedule: "every day", SavePath: "/var/backups/k0s"}, []string{"schedule: Invalid value"}},
+		{"missing save path", &BackupSpec{Schedule: "@daily"}, []string{"savePath: Required value"}},
+		{"relative save path", &BackupSpec{Schedule: "@daily", SavePath: "backups"}, []string{"savePath: Invalid value"}},
+		{"negative retention", &BackupSpec{Schedule: "@daily", SavePath: "/var/backups/k0s", Retention: -1}, []string{"retention: Invalid value"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := test.backup.Validate()
+			if assert.Len(t, errs, len(test.errs)) {
+				for i, err := range errs {
+					assert.ErrorContains(t, err, test.errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBackupSpec_GetRetention(t *testing.T) {
+	assert.Equal(t, DefaultBackupRetention, (*BackupSpec)(nil).GetRetention())
+	assert.Equal(t, DefaultBackupRetention, (&BackupSpec{}).GetRetention())
+	assert.Equal(t, 3, (&BackupSpec{Retention: 3}).GetRetention())
+}
+
+func TestClusterConfig_GetClusterWideConfig_StripsBackup(t *testing.T) {
+	cfg := DefaultClusterConfig()
+	cfg.Spec.Backup = &BackupSpec{Schedule: "@daily", SavePath: "/var/backups/k0s"}
+	assert.Nil(t, cfg.GetClusterWideConfig().Spec.Backup)
+	assert.NotNil(t, cfg.Spec.Backup)
+}
@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// CloudIdentityJoin configures the k0s join API to let workers join the
+// cluster with the signed identity of their cloud instance, instead of a join
+// token.
+type CloudIdentityJoin struct {
+	// Lets AWS EC2 instances join with their instance identity document.
+	// +optional
+	AWS *AWSIdentityJoin `json:"aws,omitempty"`
+
+	// Lets GCP Compute Engine instances join with their identity token.
+	// +optional
+	GCP *GCPIdentityJoin `json:"gcp,omitempty"`
+}
+
+// AWSIdentityJoin constrains the AWS EC2 instances that may join the cluster.
+type AWSIdentityJoin struct {
+	// How instances prove their identity. Defaults to InstanceIdentityDocument.
+	// +optional
+	Method AWSIdentityMethod `json:"method,omitempty"`
+
+	// The PEM encoded AWS public certificates that are used to verify the
+	// signatures of instance identity documents. AWS publishes a certificate
+	// for each region. Required for InstanceIdentityDocument.
+	// +optional
+	Certificates string `json:"certificates,omitempty"`
+
+	// The AWS accounts that instances need to belong to.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	AccountIDs []string `json:"accountIDs"`
+
+	// The AWS regions that instances need to run in. Any region if empty.
+	// Only supported for InstanceIdentityDocument.
+	// +listType=set
+	// +optional
+	Regions []string `json:"regions,omitempty"`
+
+	// The maximum time since an instance has been launched or started, i.e.
+	// since the pendingTime of its instance identity document, after which
+	// it may no longer join. Only supported for InstanceIdentityDocument.
+	// Defaults to 10 minutes.
+	// +optional
+	MaxDocumentAge *metav1.Duration `json:"maxDocumentAge,omitempty"`
+
+	// The names of the IAM roles of the instance profiles that instances need
+	// to run with. Any role if empty. Only supported for GetCallerIdentity.
+	// +listType=set
+	// +optional
+	RoleNames []string `json:"roleNames,omitempty"`
+}
+
+// AWSIdentityMethod is the way in which AWS EC2 instances prove their
+// identity. The default is [AWSIdentityMethodInstanceIdentityDocument].
+// +kubebuilder:validation:Enum=InstanceIdentityDocument;GetCallerIdentity
+type AWSIdentityMethod string
+
+const (
+	// AWSIdentityMethodInstanceIdentityDocument lets instances present their
+	// signed instance identity document.
+	AWSIdentityMethodInstanceIdentityDocument AWSIdentityMethod = "InstanceIdentityDocument"
+
+	// AWSIdentityMethodGetCallerIdentity lets instances present an STS
+	// GetCallerIdentity request that's signed with the credentials of their
+	// instance profile, which the k0s join API sends to STS.
+	AWSIdentityMethodGetCallerIdentity AWSIdentityMethod = "GetCallerIdentity"
+)
+
+// DefaultAWSMaxDocumentAge is the default maximum time since an AWS instance
+// has been launched or started, after which it may no longer join.
+const DefaultAWSMaxDocumentAge = 10 * time.Minute
+
+// MethodOrDefault returns the way in which instances prove their identity.
+func (a *AWSIdentityJoin) MethodOrDefault() AWSIdentityMethod {
+	if a.Method != "" {
+		return a.Method
+	}
+	return AWSIdentityMethodInstanceIdentityDocument
+}
+
+// MaxDocumentAgeOrDefault returns the maximum time since an instance has been
+// launched or started, after which it may no longer join.
+func (a *AWSIdentityJoin) MaxDocumentAgeOrDefault() time.Duration {
+	if a.MaxDocumentAge != nil {
+		return a.MaxDocumentAge.Duration
+	}
+	return DefaultAWSMaxDocumentAge
+}
+
+// GCPIdentityJoin constrains the GCP Compute Engine instances that may join
+// the cluster.
+type GCPIdentityJoin struct {
+	// The GCP projects that instances need to belong to.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	ProjectIDs []string `json:"projectIDs"`
+
+	// The email addresses of the service accounts that instances need to run
+	// as. Any service account if empty.
+	// +listType=set
+	// +optional
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+
+	// The GCP zones that instances need to run in. Any zone if empty.
+	// +listType=set
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+}
+
+func (c *CloudIdentityJoin) Validate(path *field.Path) (errs field.ErrorList) {
+	if c == nil {
+		return nil
+	}
+
+	if c.AWS == nil && c.GCP == nil {
+		errs = append(errs, field.Required(path, "at least one of aws or gcp needs to be configured"))
+	}
+
+	if aws := c.AWS; aws != nil {
+		path := path.Child("aws")
+		switch aws.MethodOrDefault() {
+		case AWSIdentityMethodInstanceIdentityDocument:
+			if aws.Certificates == "" {
+				errs = append(errs, field.Required(path.Child("certificates"), ""))
+			} else if err := validateCertificates(aws.Certificates); err != nil {
+				errs = append(errs, field.Invalid(path.Child("certificates"), "<certificates>", err.Error()))
+			}
+			if aws.MaxDocumentAge != nil && aws.MaxDocumentAge.Duration <= 0 {
+				errs = append(errs, field.Invalid(path.Child("maxDocumentAge"), aws.MaxDocumentAge.Duration.String(), "must be positive"))
+			}
+			if len(aws.RoleNames) > 0 {
+				errs = append(errs, field.Forbidden(path.Child("roleNames"), "only supported for GetCallerIdentity"))
+			}
+		case AWSIdentityMethodGetCallerIdentity:
+			const detail = "only supported for InstanceIdentityDocument"
+			if aws.Certificates != "" {
+				errs = append(errs, field.Forbidden(path.Child("certificates"), detail))
+			}
+			if len(aws.Regions) > 0 {
+				errs = append(errs, field.Forbidden(path.Child("regions"), detail))
+			}
+			if aws.MaxDocumentAge != nil {
+				errs = append(errs, field.Forbidden(path.Child("maxDocumentAge"), detail))
+			}
+		default:
+			errs = append(errs, field.NotSupported(path.Child("method"), aws.Method, []AWSIdentityMethod{
+				AWSIdentityMethodInstanceIdentityDocument, AWSIdentityMethodGetCallerIdentity,
+			}))
+		}
+		if len(aws.AccountIDs) == 0 {
+			errs = append(errs, field.Required(path.Child("accountIDs"), ""))
+		}
+	}
+
+	if gcp := c.GCP; gcp != nil {
+		if len(gcp.ProjectIDs) == 0 {
+			errs = append(errs, field.Required(path.Child("gcp", "projectIDs"), ""))
+		}
+	}
+
+	return errs
+}
+
+func validateCertificates(certificates string) error {
+	rest, found := []byte(certificates), false
+	for {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return err
+		}
+		found = true
+	}
+	if !found {
+		return errors.New("no PEM encoded certificates found")
+	}
+	return nil
+}
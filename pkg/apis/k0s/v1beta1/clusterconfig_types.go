@@ -6,12 +6,15 @@ package v1beta1
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/k0sproject/k0s/internal/pkg/strictyaml"
 	"github.com/k0sproject/k0s/pkg/constant"
@@ -40,20 +43,109 @@ type ClusterSpec struct {
 	Extensions        *ClusterExtensions     `json:"extensions,omitempty"`
 	Konnectivity      *KonnectivitySpec      `json:"konnectivity,omitempty"`
 	FeatureGates      FeatureGates           `json:"featureGates,omitempty"`
+	K0sFeatureGates   K0sFeatureGates        `json:"k0sFeatureGates,omitempty"`
+	Backup            *BackupSpec            `json:"backup,omitempty"`
 }
 
 // ClusterConfigStatus defines the observed state of ClusterConfig
 type ClusterConfigStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// ObservedGeneration is the most recent generation of the ClusterConfig
+	// that has been reconciled, successfully or not.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// AppliedGeneration is the most recent generation of the ClusterConfig
+	// that all components have reconciled successfully. The configuration has
+	// drifted if it's lower than the ClusterConfig's generation.
+	// +optional
+	AppliedGeneration int64 `json:"appliedGeneration,omitempty"`
+	// Components describe the outcome of the most recent reconciliation of
+	// each component on each controller.
+	// +listType=map
+	// +listMapKey=controller
+	// +listMapKey=name
+	// +optional
+	Components []ComponentStatus `json:"components,omitempty"`
+	// FieldConditions describe how the changes to the fields of the dynamic
+	// configuration have been applied.
+	// +listType=map
+	// +listMapKey=path
+	// +optional
+	FieldConditions []FieldCondition `json:"fieldConditions,omitempty"`
+	// K0sFeatureGates report which k0s features are enabled, as seen by the
+	// controller that reconciled the configuration most recently.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	K0sFeatureGates []K0sFeatureGateStatus `json:"k0sFeatureGates,omitempty"`
+}
+
+// FieldChangeType describes how a change to a field of the dynamic
+// configuration takes effect.
+// +kubebuilder:validation:Enum=AppliedLive;RequiresRestart;Immutable;Ignored;Failed
+type FieldChangeType string
+
+const (
+	// FieldChangeAppliedLive means that the change has been applied to the
+	// running cluster.
+	FieldChangeAppliedLive FieldChangeType = "AppliedLive"
+	// FieldChangeRequiresRestart means that the change takes effect only after
+	// the controllers have been restarted.
+	FieldChangeRequiresRestart FieldChangeType = "RequiresRestart"
+	// FieldChangeImmutable means that the field can't be changed once the
+	// cluster has been created.
+	FieldChangeImmutable FieldChangeType = "Immutable"
+	// FieldChangeIgnored means that the field is node specific and is only
+	// read from the controllers' configuration files.
+	FieldChangeIgnored FieldChangeType = "Ignored"
+	// FieldChangeFailed means that the change couldn't be applied.
+	FieldChangeFailed FieldChangeType = "Failed"
+)
+
+// FieldCondition describes how the change to a field of the dynamic
+// configuration has been applied.
+type FieldCondition struct {
+	// Path is the path of the changed field, e.g. "spec.network.kuberouter.mtu".
+	Path string `json:"path"`
+	// Type describes how the change has been applied.
+	Type FieldChangeType `json:"type"`
+	// Message is a human readable explanation of the type.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// ObservedGeneration is the generation of the ClusterConfig in which the
+	// change has been observed.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastTransitionTime is the time at which the change has been observed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// ComponentStatus describes the outcome of reconciling a component on a
+// controller.
+type ComponentStatus struct {
+	// Name is the name of the component.
+	Name string `json:"name"`
+	// Controller is the name of the controller on which the component runs.
+	Controller string `json:"controller"`
+	// ObservedGeneration is the generation of the ClusterConfig that the
+	// component reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Error is the error that occurred during the reconciliation, if any.
+	// +optional
+	Error string `json:"error,omitempty"`
+	// LastReconcileTime is the time of the reconciliation.
+	LastReconcileTime metav1.Time `json:"lastReconcileTime"`
 }
 
 // ClusterConfig is the Schema for the clusterconfigs API
 //
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Generation",type=integer,JSONPath=`.metadata.generation`
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedGeneration`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +genclient
-// +genclient:onlyVerbs=create,delete,list,get,watch,update
+// +genclient:onlyVerbs=create,delete,list,get,watch,update,updateStatus
 type ClusterConfig struct {
 	metav1.TypeMeta `json:",inline"`
 	// +optional
@@ -201,6 +293,72 @@ func (*InstallSpec) Validate() []error { return nil }
 type ControllerManagerSpec struct {
 	// Map of key-values (strings) for any extra arguments you want to pass down to the Kubernetes controller manager process
 	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+	// The time after which unresponsive nodes are marked unhealthy.
+	// +optional
+	NodeMonitorGracePeriod *metav1.Duration `json:"nodeMonitorGracePeriod,omitempty"`
+	// The number of terminated pods that may exist before they're garbage
+	// collected. Zero disables the garbage collection of terminated pods.
+	// Defaults to 12500.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TerminatedPodGCThreshold *int32 `json:"terminatedPodGCThreshold,omitempty"`
+	// The number of objects that the controllers sync concurrently.
+	// +optional
+	ConcurrentSyncs *ControllerManagerConcurrentSyncs `json:"concurrentSyncs,omitempty"`
+	// Set to "external" if nodes are initialized by an external cloud
+	// controller manager. The workers need to be started with
+	// --enable-cloud-provider in that case.
+	// +kubebuilder:validation:Enum=external
+	// +optional
+	CloudProvider string `json:"cloudProvider,omitempty"`
+	// The resource limits of the Kubernetes controller manager.
+	// +optional
+	Resources *ProcessResources `json:"resources,omitempty"`
+}
+
+// ControllerManagerConcurrentSyncs defines how many objects the controllers of
+// the Kubernetes controller manager sync concurrently.
+type ControllerManagerConcurrentSyncs struct {
+	// The number of CronJob objects that are synced concurrently.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CronJob *int32 `json:"cronJob,omitempty"`
+	// The number of DaemonSet objects that are synced concurrently.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DaemonSet *int32 `json:"daemonSet,omitempty"`
+	// The number of Deployment objects that are synced concurrently.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Deployment *int32 `json:"deployment,omitempty"`
+	// The number of Endpoints objects that are synced concurrently.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Endpoint *int32 `json:"endpoint,omitempty"`
+	// The number of garbage collector workers.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	GarbageCollector *int32 `json:"garbageCollector,omitempty"`
+	// The number of Job objects that are synced concurrently.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Job *int32 `json:"job,omitempty"`
+	// The number of Namespace objects that are synced concurrently.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Namespace *int32 `json:"namespace,omitempty"`
+	// The number of ReplicaSet objects that are synced concurrently.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ReplicaSet *int32 `json:"replicaSet,omitempty"`
+	// The number of ResourceQuota objects that are synced concurrently.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ResourceQuota *int32 `json:"resourceQuota,omitempty"`
+	// The number of StatefulSet objects that are synced concurrently.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	StatefulSet *int32 `json:"statefulSet,omitempty"`
 }
 
 var _ Validateable = (*ControllerManagerSpec)(nil)
@@ -211,12 +369,93 @@ func DefaultControllerManagerSpec() *ControllerManagerSpec {
 	}
 }
 
-func (c *ControllerManagerSpec) Validate() []error { return nil }
+// Args returns the controller manager flags that correspond to the typed
+// fields which are set.
+func (c *ControllerManagerSpec) Args() map[string]string {
+	args := make(map[string]string)
+	if c == nil {
+		return args
+	}
+	if c.NodeMonitorGracePeriod != nil {
+		args["node-monitor-grace-period"] = c.NodeMonitorGracePeriod.Duration.String()
+	}
+	if c.TerminatedPodGCThreshold != nil {
+		args["terminated-pod-gc-threshold"] = strconv.FormatInt(int64(*c.TerminatedPodGCThreshold), 10)
+	}
+	for flag, value := range c.ConcurrentSyncs.flags() {
+		if value != nil {
+			args[flag] = strconv.FormatInt(int64(*value), 10)
+		}
+	}
+	if c.CloudProvider != "" {
+		args["cloud-provider"] = c.CloudProvider
+	}
+	return args
+}
+
+func (c *ControllerManagerSpec) Validate() (errs []error) {
+	if c == nil {
+		return nil
+	}
+
+	if c.NodeMonitorGracePeriod != nil && c.NodeMonitorGracePeriod.Duration <= 0 {
+		errs = append(errs, errors.New("nodeMonitorGracePeriod: must be positive"))
+	}
+	if c.TerminatedPodGCThreshold != nil && *c.TerminatedPodGCThreshold < 0 {
+		errs = append(errs, errors.New("terminatedPodGCThreshold: must not be negative"))
+	}
+	for flag, value := range c.ConcurrentSyncs.flags() {
+		if value != nil && *value < 1 {
+			errs = append(errs, fmt.Errorf("concurrentSyncs: %s must be positive", flag))
+		}
+	}
+	if c.CloudProvider != "" && c.CloudProvider != "external" {
+		errs = append(errs, fmt.Errorf("cloudProvider: unsupported value %q, only external is supported", c.CloudProvider))
+	}
+	errs = append(errs, c.Resources.Validate(field.NewPath("resources"))...)
+
+	for flag := range c.Args() {
+		if _, found := c.ExtraArgs[flag]; found {
+			errs = append(errs, fmt.Errorf("extraArgs: %s is configured by a dedicated field", flag))
+		}
+	}
+
+	slices.SortFunc(errs, func(a, b error) int { return strings.Compare(a.Error(), b.Error()) })
+	return errs
+}
+
+func (s *ControllerManagerConcurrentSyncs) flags() map[string]*int32 {
+	if s == nil {
+		return nil
+	}
+	return map[string]*int32{
+		"concurrent-cron-job-syncs":       s.CronJob,
+		"concurrent-daemonset-syncs":      s.DaemonSet,
+		"concurrent-deployment-syncs":     s.Deployment,
+		"concurrent-endpoint-syncs":       s.Endpoint,
+		"concurrent-gc-syncs":             s.GarbageCollector,
+		"concurrent-job-syncs":            s.Job,
+		"concurrent-namespace-syncs":      s.Namespace,
+		"concurrent-replicaset-syncs":     s.ReplicaSet,
+		"concurrent-resource-quota-syncs": s.ResourceQuota,
+		"concurrent-statefulset-syncs":    s.StatefulSet,
+	}
+}
 
 // SchedulerSpec defines the fields for the Scheduler
 type SchedulerSpec struct {
 	// Map of key-values (strings) for any extra arguments you want to pass down to Kubernetes scheduler process
 	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+	// A KubeSchedulerConfiguration that is passed to the Kubernetes scheduler
+	// via --config, e.g. to configure scheduling profiles. The client
+	// connection's kubeconfig is managed by k0s.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Config *runtime.RawExtension `json:"config,omitempty"`
+	// The resource limits of the Kubernetes scheduler.
+	// +optional
+	Resources *ProcessResources `json:"resources,omitempty"`
 }
 
 func DefaultSchedulerSpec() *SchedulerSpec {
@@ -227,7 +466,41 @@ func DefaultSchedulerSpec() *SchedulerSpec {
 
 var _ Validateable = (*SchedulerSpec)(nil)
 
-func (*SchedulerSpec) Validate() []error { return nil }
+const (
+	kubeSchedulerConfigAPIVersion = "kubescheduler.config.k8s.io/v1"
+	kubeSchedulerConfigKind       = "KubeSchedulerConfiguration"
+)
+
+func (s *SchedulerSpec) Validate() []error {
+	if s == nil {
+		return nil
+	}
+
+	errs := s.Resources.Validate(field.NewPath("resources"))
+	if s.Config == nil {
+		return errs
+	}
+
+	var config struct {
+		APIVersion       *string        `json:"apiVersion"`
+		Kind             *string        `json:"kind"`
+		ClientConnection map[string]any `json:"clientConnection"`
+	}
+	if err := json.Unmarshal(s.Config.Raw, &config); err != nil {
+		return append(errs, fmt.Errorf("config: %w", err))
+	}
+
+	if config.APIVersion != nil && *config.APIVersion != kubeSchedulerConfigAPIVersion {
+		errs = append(errs, fmt.Errorf("config: unsupported apiVersion %q, only %s is supported", *config.APIVersion, kubeSchedulerConfigAPIVersion))
+	}
+	if config.Kind != nil && *config.Kind != kubeSchedulerConfigKind {
+		errs = append(errs, fmt.Errorf("config: unsupported kind %q, only %s is supported", *config.Kind, kubeSchedulerConfigKind))
+	}
+	if _, found := config.ClientConnection["kubeconfig"]; found {
+		errs = append(errs, errors.New("config: clientConnection.kubeconfig is managed by k0s and can't be overridden"))
+	}
+	return errs
+}
 
 // +kubebuilder:object:root=true
 // ClusterConfigList contains a list of ClusterConfig
@@ -241,12 +514,13 @@ var _ Validateable = (*ControllerManagerSpec)(nil)
 
 // IsZero needed to omit empty object from yaml output
 func (c *ControllerManagerSpec) IsZero() bool {
-	return len(c.ExtraArgs) == 0
+	return len(c.ExtraArgs) == 0 && c.NodeMonitorGracePeriod == nil && c.TerminatedPodGCThreshold == nil &&
+		c.ConcurrentSyncs == nil && c.CloudProvider == ""
 }
 
 // IsZero needed to omit empty object from yaml output
 func (s *SchedulerSpec) IsZero() bool {
-	return len(s.ExtraArgs) == 0
+	return len(s.ExtraArgs) == 0 && s.Config == nil
 }
 
 func ConfigFromBytes(bytes []byte) (*ClusterConfig, error) {
@@ -393,6 +667,8 @@ func (s *ClusterSpec) Validate() (errs []error) {
 		"install":           s.Install,
 		"extensions":        s.Extensions,
 		"konnectivity":      s.Konnectivity,
+		"k0sFeatureGates":   s.K0sFeatureGates,
+		"backup":            s.Backup,
 	} {
 		for _, err := range field.Validate() {
 			errs = append(errs, fmt.Errorf("%s: %w", name, err))
@@ -471,6 +747,7 @@ func (c *ClusterConfig) Validate() (errs []error) {
 // - Network.ControlPlaneLoadBalancing
 // - Network.PrimaryAddressFamily
 // - Install
+// - Backup
 func (c *ClusterConfig) GetClusterWideConfig() *ClusterConfig {
 	c = c.DeepCopy()
 	if c != nil && c.Spec != nil {
@@ -483,6 +760,7 @@ func (c *ClusterConfig) GetClusterWideConfig() *ClusterConfig {
 			c.Spec.Network.PrimaryAddressFamily = ""
 		}
 		c.Spec.Install = nil
+		c.Spec.Backup = nil
 	}
 
 	return c
@@ -10,6 +10,7 @@ import (
 	"github.com/k0sproject/k0s/internal/pkg/iface"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/yaml"
 
 	"github.com/stretchr/testify/assert"
@@ -311,6 +312,73 @@ spec:
 	}
 }
 
+func TestControllerManagerSpec(t *testing.T) {
+	yamlData := []byte(`
+spec:
+  controllerManager:
+    nodeMonitorGracePeriod: 20s
+    terminatedPodGCThreshold: 0
+    concurrentSyncs:
+      deployment: 10
+      garbageCollector: 30
+    cloudProvider: external
+`)
+	c, err := ConfigFromBytes(yamlData)
+	require.NoError(t, err)
+	assert.Empty(t, c.Spec.ControllerManager.Validate())
+	assert.Equal(t, map[string]string{
+		"node-monitor-grace-period":   "20s",
+		"terminated-pod-gc-threshold": "0",
+		"concurrent-deployment-syncs": "10",
+		"concurrent-gc-syncs":         "30",
+		"cloud-provider":              "external",
+	}, c.Spec.ControllerManager.Args())
+
+	c.Spec.ControllerManager.NodeMonitorGracePeriod.Duration = 0
+	*c.Spec.ControllerManager.ConcurrentSyncs.Deployment = 0
+	c.Spec.ControllerManager.CloudProvider = "aws"
+	c.Spec.ControllerManager.ExtraArgs["terminated-pod-gc-threshold"] = "100"
+	errs := c.Spec.ControllerManager.Validate()
+	if assert.Len(t, errs, 4) {
+		assert.ErrorContains(t, errs[0], `cloudProvider: unsupported value "aws", only external is supported`)
+		assert.ErrorContains(t, errs[1], "concurrentSyncs: concurrent-deployment-syncs must be positive")
+		assert.ErrorContains(t, errs[2], "extraArgs: terminated-pod-gc-threshold is configured by a dedicated field")
+		assert.ErrorContains(t, errs[3], "nodeMonitorGracePeriod: must be positive")
+	}
+}
+
+func TestSchedulerConfig(t *testing.T) {
+	yamlData := []byte(`
+spec:
+  scheduler:
+    config:
+      profiles:
+      - schedulerName: default-scheduler
+        pluginConfig:
+        - name: NodeResourcesFit
+          args:
+            scoringStrategy:
+              type: MostAllocated
+`)
+	c, err := ConfigFromBytes(yamlData)
+	require.NoError(t, err)
+	require.NotNil(t, c.Spec.Scheduler.Config)
+	assert.Empty(t, c.Spec.Scheduler.Validate())
+	assert.False(t, c.Spec.Scheduler.IsZero())
+
+	for _, test := range []struct{ config, err string }{
+		{`{"apiVersion": "kubescheduler.config.k8s.io/v1beta3"}`, `config: unsupported apiVersion "kubescheduler.config.k8s.io/v1beta3", only kubescheduler.config.k8s.io/v1 is supported`},
+		{`{"kind": "KubeProxyConfiguration"}`, `config: unsupported kind "KubeProxyConfiguration", only KubeSchedulerConfiguration is supported`},
+		{`{"clientConnection": {"kubeconfig": "/tmp/kubeconfig"}}`, "config: clientConnection.kubeconfig is managed by k0s and can't be overridden"},
+	} {
+		scheduler := SchedulerSpec{Config: &runtime.RawExtension{Raw: []byte(test.config)}}
+		errs := scheduler.Validate()
+		if assert.Len(t, errs, 1, test.config) {
+			assert.ErrorContains(t, errs[0], test.err)
+		}
+	}
+}
+
 func TestClusterConfig_StripDefaults_Zero(t *testing.T) {
 	underTest := ClusterConfig{}
 	assert.Equal(t, &underTest, underTest.StripDefaults())
@@ -41,3 +41,66 @@ type EtcdResponse struct {
 	CA             CaResponse `json:"ca"`
 	InitialCluster []string   `json:"initialCluster"`
 }
+
+// CloudIdentityJoinRequest defines the cloud identity join API request
+// structure
+type CloudIdentityJoinRequest struct {
+	// The cloud provider that signed the identity, either aws or gcp.
+	Provider string `json:"provider"`
+	// The signed identity of the instance: the instance identity document on
+	// AWS, the identity token on GCP.
+	Identity string `json:"identity"`
+	// The signature of the instance identity document on AWS.
+	Signature string `json:"signature,omitempty"`
+	// An STS GetCallerIdentity request on AWS, presigned with the credentials
+	// of the instance profile.
+	CallerIdentityRequest *PresignedRequest `json:"callerIdentityRequest,omitempty"`
+}
+
+// PresignedRequest is an HTTP GET request whose signature is part of its URL.
+type PresignedRequest struct {
+	// The presigned URL.
+	URL string `json:"url"`
+	// The headers that have been signed along with the URL.
+	Header map[string][]string `json:"header,omitempty"`
+}
+
+// CloudIdentityJoinResponse defines the cloud identity join API response
+// structure
+type CloudIdentityJoinResponse struct {
+	// A worker join token that's valid for a single join.
+	Token string `json:"token"`
+}
+
+// JoinCodeResponse defines the join code API response structure
+type JoinCodeResponse struct {
+	// The worker join token that belongs to the join code.
+	Token string `json:"token"`
+}
+
+// JoinRequest defines the join request API request structure
+type JoinRequest struct {
+	// The name of the node that wants to join.
+	NodeName string `json:"nodeName"`
+	// The PEM encoded certificate signing request for the node's client
+	// certificate.
+	CSR []byte `json:"csr"`
+}
+
+// JoinRequestResponse defines the join request API response structure
+type JoinRequestResponse struct {
+	// The ID of the join request, which is used to approve or deny it.
+	ID string `json:"id"`
+}
+
+// JoinRequestStatus defines the join request status API response structure
+type JoinRequestStatus struct {
+	// Either Pending, Approved or Denied.
+	State string `json:"state"`
+	// The PEM encoded client certificate of the node, once approved.
+	Certificate []byte `json:"certificate,omitempty"`
+	// The PEM encoded CA certificate of the cluster, once approved.
+	CACertificate []byte `json:"caCertificate,omitempty"`
+	// The URL of the Kubernetes API server, once approved.
+	APIServerURL string `json:"apiServerURL,omitempty"`
+}
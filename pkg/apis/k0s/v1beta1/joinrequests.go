@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DefaultMaxPendingJoinRequests is the default maximum number of pending join
+// requests.
+const DefaultMaxPendingJoinRequests = 10
+
+// DefaultMaxPendingJoinRequestsPerSource is the default maximum number of
+// pending join requests per source address.
+const DefaultMaxPendingJoinRequestsPerSource = 2
+
+// JoinRequests lets nodes join the cluster without a join token. Instead, they
+// submit a certificate signing request via the k0s join API, which needs to be
+// approved before the node gets its credentials.
+type JoinRequests struct {
+	// The maximum number of pending join requests. Further requests are
+	// rejected until pending ones have been decided or have expired.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	// +optional
+	MaxPending int `json:"maxPending,omitempty"`
+
+	// The maximum number of pending join requests per source address, so that
+	// a single source can't exhaust the pending join requests.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=2
+	// +optional
+	MaxPendingPerSource int `json:"maxPendingPerSource,omitempty"`
+}
+
+func (j *JoinRequests) Validate(path *field.Path) (errs field.ErrorList) {
+	if j == nil {
+		return nil
+	}
+
+	if j.MaxPending < 0 {
+		errs = append(errs, field.Invalid(path.Child("maxPending"), j.MaxPending, "must not be negative"))
+	}
+	if j.MaxPendingPerSource < 0 {
+		errs = append(errs, field.Invalid(path.Child("maxPendingPerSource"), j.MaxPendingPerSource, "must not be negative"))
+	}
+
+	return errs
+}
+
+// GetMaxPending returns the maximum number of pending join requests, or its
+// default if unset.
+func (j *JoinRequests) GetMaxPending() int {
+	if j.MaxPending > 0 {
+		return j.MaxPending
+	}
+	return DefaultMaxPendingJoinRequests
+}
+
+// GetMaxPendingPerSource returns the maximum number of pending join requests
+// per source address, or its default if unset.
+func (j *JoinRequests) GetMaxPendingPerSource() int {
+	if j.MaxPendingPerSource > 0 {
+		return j.MaxPendingPerSource
+	}
+	return DefaultMaxPendingJoinRequestsPerSource
+}
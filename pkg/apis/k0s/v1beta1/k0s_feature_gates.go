@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+)
+
+var _ Validateable = (*K0sFeatureGates)(nil)
+
+// K0sFeatureGates enable or disable k0s features that are not yet generally
+// available, such as experimental subsystems. Unlike [FeatureGates], they
+// aren't passed on to the Kubernetes components.
+// +listType=map
+// +listMapKey=name
+type K0sFeatureGates []K0sFeatureGate
+
+// K0sFeatureGate enables or disables a single k0s feature.
+type K0sFeatureGate struct {
+	// Name of the k0s feature
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Enabled or disabled
+	Enabled bool `json:"enabled"`
+}
+
+// Lookup returns whether the feature with the given name has been enabled or
+// disabled explicitly.
+func (gates K0sFeatureGates) Lookup(name string) (enabled bool, found bool) {
+	for _, gate := range gates {
+		if gate.Name == name {
+			return gate.Enabled, true
+		}
+	}
+	return false, false
+}
+
+// Validate checks that all feature gates have distinct names. Whether the
+// features are actually known to k0s is checked in the config package.
+func (gates K0sFeatureGates) Validate() []error {
+	var errs []error
+	seen := make(map[string]bool, len(gates))
+	for _, gate := range gates {
+		switch {
+		case gate.Name == "":
+			errs = append(errs, errors.New("feature gate must have name"))
+		case seen[gate.Name]:
+			errs = append(errs, fmt.Errorf("duplicate feature gate %q", gate.Name))
+		}
+		seen[gate.Name] = true
+	}
+	return errs
+}
+
+// K0sFeatureStage describes the maturity of a k0s feature.
+// +kubebuilder:validation:Enum=Alpha;Beta;GA;Deprecated
+type K0sFeatureStage string
+
+const (
+	// K0sFeatureAlpha features are experimental and disabled by default.
+	K0sFeatureAlpha K0sFeatureStage = "Alpha"
+	// K0sFeatureBeta features are well tested and usually enabled by default.
+	K0sFeatureBeta K0sFeatureStage = "Beta"
+	// K0sFeatureGA features are generally available and always enabled.
+	K0sFeatureGA K0sFeatureStage = "GA"
+	// K0sFeatureDeprecated features are going to be removed.
+	K0sFeatureDeprecated K0sFeatureStage = "Deprecated"
+)
+
+// K0sFeatureGateStatus reports whether a k0s feature is enabled.
+type K0sFeatureGateStatus struct {
+	// Name of the k0s feature
+	Name string `json:"name"`
+	// Stage is the maturity of the feature.
+	Stage K0sFeatureStage `json:"stage"`
+	// Enabled indicates whether the feature is enabled, either by default or
+	// explicitly by a feature gate.
+	Enabled bool `json:"enabled"`
+}
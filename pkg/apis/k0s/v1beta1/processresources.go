@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// The smallest CPU limit that cgroups can enforce, i.e. a quota of 1ms per
+// 100ms period.
+var minProcessCPU = resource.MustParse("10m")
+
+// ProcessResources limits the resources of a process that k0s supervises. The
+// process is placed in a cgroup of its own in which the limits are enforced,
+// which requires cgroup v2.
+type ProcessResources struct {
+	// The CPU limit, e.g. "500m" for half a CPU. Maps to the cgroup's cpu.max.
+	// +optional
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+
+	// The memory limit, e.g. "1Gi". Maps to the cgroup's memory.max. The
+	// process is killed and restarted if it exceeds the limit.
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+}
+
+// Validate validates the resource limits.
+func (r *ProcessResources) Validate(path *field.Path) (errs []error) {
+	if r == nil {
+		return nil
+	}
+
+	if r.CPU != nil && r.CPU.Cmp(minProcessCPU) < 0 {
+		errs = append(errs, field.Invalid(path.Child("cpu"), r.CPU.String(), "must be at least "+minProcessCPU.String()))
+	}
+	if r.Memory != nil && r.Memory.Sign() <= 0 {
+		errs = append(errs, field.Invalid(path.Child("memory"), r.Memory.String(), "must be positive"))
+	}
+
+	return errs
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestProcessResources_Validate(t *testing.T) {
+	quantity := func(s string) *resource.Quantity { q := resource.MustParse(s); return &q }
+
+	tests := []struct {
+		name      string
+		resources *ProcessResources
+		errs      []string
+	}{
+		{"nil", nil, nil},
+		{"empty", &ProcessResources{}, nil},
+		{"valid", &ProcessResources{CPU: quantity("500m"), Memory: quantity("1Gi")}, nil},
+		{"minimal CPU", &ProcessResources{CPU: quantity("10m")}, nil},
+		{"CPU too small", &ProcessResources{CPU: quantity("5m")}, []string{"resources.cpu: Invalid value"}},
+		{"zero memory", &ProcessResources{Memory: quantity("0")}, []string{"resources.memory: Invalid value"}},
+		{"negative memory", &ProcessResources{Memory: quantity("-1Mi")}, []string{"resources.memory: Invalid value"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := test.resources.Validate(field.NewPath("resources"))
+			if assert.Len(t, errs, len(test.errs)) {
+				for i, err := range errs {
+					assert.ErrorContains(t, err, test.errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStorageSpec_Validate_ResourcesExternalCluster(t *testing.T) {
+	storage := DefaultStorageSpec()
+	storage.Etcd.ExternalCluster = &ExternalCluster{
+		Endpoints:  []string{"http://etcd:2379"},
+		EtcdPrefix: "k0s",
+	}
+	memory := resource.MustParse("1Gi")
+	storage.Etcd.Resources = &ProcessResources{Memory: &memory}
+
+	errs := storage.Validate()
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], "etcd.resources: Forbidden")
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// RestartPolicy configures how k0s restarts a supervised process after it
+// exited.
+type RestartPolicy struct {
+	// The delay before restarting the process. Defaults to 5s.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+
+	// The upper bound of the delay, which doubles with each restart of a
+	// process that exited before running for that long. Defaults to the
+	// backoff, i.e. a fixed delay.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// The number of restarts within the window after which k0s gives up
+	// restarting the process, and reports the node as degraded. Zero, the
+	// default, restarts the process forever.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRestarts int32 `json:"maxRestarts,omitempty"`
+
+	// The time window in which the restarts are counted. Defaults to 10m.
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// Validate validates the restart policy.
+func (p *RestartPolicy) Validate(path *field.Path) (errs []error) {
+	if p == nil {
+		return nil
+	}
+
+	if p.Backoff != nil && p.Backoff.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("backoff"), p.Backoff.String(), "must be positive"))
+	}
+	if p.MaxBackoff != nil {
+		if p.MaxBackoff.Duration <= 0 {
+			errs = append(errs, field.Invalid(path.Child("maxBackoff"), p.MaxBackoff.String(), "must be positive"))
+		} else if p.Backoff != nil && p.MaxBackoff.Duration < p.Backoff.Duration {
+			errs = append(errs, field.Invalid(path.Child("maxBackoff"), p.MaxBackoff.String(), "must not be less than the backoff"))
+		}
+	}
+	if p.MaxRestarts < 0 {
+		errs = append(errs, field.Invalid(path.Child("maxRestarts"), p.MaxRestarts, "must not be negative"))
+	}
+	if p.Window != nil && p.Window.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("window"), p.Window.String(), "must be positive"))
+	}
+
+	return errs
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestRestartPolicy_Validate(t *testing.T) {
+	duration := func(d time.Duration) *metav1.Duration { return &metav1.Duration{Duration: d} }
+
+	tests := []struct {
+		name   string
+		policy *RestartPolicy
+		errs   []string
+	}{
+		{"nil", nil, nil},
+		{"empty", &RestartPolicy{}, nil},
+		{"valid", &RestartPolicy{Backoff: duration(time.Second), MaxBackoff: duration(time.Minute), MaxRestarts: 5, Window: duration(time.Hour)}, nil},
+		{"max backoff only", &RestartPolicy{MaxBackoff: duration(time.Minute)}, nil},
+		{"zero backoff", &RestartPolicy{Backoff: duration(0)}, []string{"policy.backoff: Invalid value"}},
+		{"max backoff below backoff", &RestartPolicy{Backoff: duration(time.Minute), MaxBackoff: duration(time.Second)}, []string{"policy.maxBackoff: Invalid value"}},
+		{"negative max restarts", &RestartPolicy{MaxRestarts: -1}, []string{"policy.maxRestarts: Invalid value"}},
+		{"negative window", &RestartPolicy{Window: duration(-time.Second)}, []string{"policy.window: Invalid value"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := test.policy.Validate(field.NewPath("policy"))
+			if assert.Len(t, errs, len(test.errs)) {
+				for i, err := range errs {
+					assert.ErrorContains(t, err, test.errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStorageSpec_Validate_RestartPolicyExternalCluster(t *testing.T) {
+	storage := DefaultStorageSpec()
+	storage.Etcd.ExternalCluster = &ExternalCluster{
+		Endpoints:  []string{"http://etcd:2379"},
+		EtcdPrefix: "k0s",
+	}
+	storage.Etcd.RestartPolicy = &RestartPolicy{MaxRestarts: 3}
+
+	errs := storage.Validate()
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], "etcd.restartPolicy: Forbidden")
+	}
+}
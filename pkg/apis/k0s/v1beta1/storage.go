@@ -12,12 +12,16 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/k0sproject/k0s/internal/pkg/iface"
 	"github.com/k0sproject/k0s/pkg/config/kine"
 	"github.com/k0sproject/k0s/pkg/constant"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/sirupsen/logrus"
@@ -112,6 +116,36 @@ func (s *StorageSpec) Validate() []error {
 	if s.Etcd != nil && s.Etcd.ExternalCluster != nil {
 		errors = append(errors, validateRequiredProperties(s.Etcd.ExternalCluster)...)
 		errors = append(errors, validateOptionalTLSProperties(s.Etcd.ExternalCluster)...)
+		if s.Etcd.Defragmentation.IsEnabled() {
+			errors = append(errors, field.Forbidden(field.NewPath("etcd", "defragmentation"), "not supported for external etcd clusters"))
+		}
+		if s.Etcd.MemberEviction.IsEnabled() {
+			errors = append(errors, field.Forbidden(field.NewPath("etcd", "memberEviction"), "not supported for external etcd clusters"))
+		}
+		if s.Etcd.Metrics != nil {
+			errors = append(errors, field.Forbidden(field.NewPath("etcd", "metrics"), "not supported for external etcd clusters"))
+		}
+		if s.Etcd.AlarmRecovery.IsNoSpaceRecoveryEnabled() {
+			errors = append(errors, field.Forbidden(field.NewPath("etcd", "alarmRecovery"), "not supported for external etcd clusters"))
+		}
+		if s.Etcd.RestartPolicy != nil {
+			errors = append(errors, field.Forbidden(field.NewPath("etcd", "restartPolicy"), "not supported for external etcd clusters"))
+		}
+		if s.Etcd.Resources != nil {
+			errors = append(errors, field.Forbidden(field.NewPath("etcd", "resources"), "not supported for external etcd clusters"))
+		}
+		if s.Etcd.isTuned() {
+			errors = append(errors, field.Forbidden(field.NewPath("etcd"), "autoCompaction, quotaBackendBytes, heartbeatInterval, electionTimeout and snapshotCount are not supported for external etcd clusters"))
+		}
+	}
+
+	if s.Etcd != nil {
+		errors = append(errors, s.Etcd.Defragmentation.Validate(field.NewPath("etcd", "defragmentation"))...)
+		errors = append(errors, s.Etcd.MemberEviction.Validate(field.NewPath("etcd", "memberEviction"))...)
+		errors = append(errors, s.Etcd.Metrics.Validate(field.NewPath("etcd", "metrics"))...)
+		errors = append(errors, s.Etcd.validateTuning(field.NewPath("etcd"))...)
+		errors = append(errors, s.Etcd.RestartPolicy.Validate(field.NewPath("etcd", "restartPolicy"))...)
+		errors = append(errors, s.Etcd.Resources.Validate(field.NewPath("etcd", "resources"))...)
 	}
 
 	return errors
@@ -130,6 +164,283 @@ type EtcdConfig struct {
 
 	// Custom config for CA certificates.
 	CA *CA `json:"ca,omitempty"`
+
+	// Defragmentation configures the automatic defragmentation of the etcd
+	// member running on this controller.
+	Defragmentation *EtcdDefragmentation `json:"defragmentation,omitempty"`
+
+	// MemberEviction configures the automatic removal of permanently failed
+	// members from the etcd cluster.
+	MemberEviction *EtcdMemberEviction `json:"memberEviction,omitempty"`
+
+	// Metrics configures how the metrics of the etcd member running on this
+	// controller are exposed.
+	Metrics *EtcdMetrics `json:"metrics,omitempty"`
+
+	// AutoCompaction configures etcd's automatic compaction of the key space
+	// history.
+	AutoCompaction *EtcdAutoCompaction `json:"autoCompaction,omitempty"`
+
+	// QuotaBackendBytes is the size limit of etcd's database, e.g. "8Gi".
+	// etcd raises a NOSPACE alarm and rejects writes once it's exceeded.
+	QuotaBackendBytes *resource.Quantity `json:"quotaBackendBytes,omitempty"`
+
+	// HeartbeatInterval is the time between the heartbeats of the etcd
+	// leader, e.g. "100ms".
+	HeartbeatInterval *metav1.Duration `json:"heartbeatInterval,omitempty"`
+
+	// ElectionTimeout is the time after which an etcd follower that didn't
+	// receive a heartbeat starts a new leader election, e.g. "1s". Needs to be
+	// at least five times the heartbeat interval.
+	ElectionTimeout *metav1.Duration `json:"electionTimeout,omitempty"`
+
+	// SnapshotCount is the number of committed transactions after which etcd
+	// takes a snapshot to disk.
+	SnapshotCount *uint64 `json:"snapshotCount,omitempty"`
+
+	// AlarmRecovery configures the automatic recovery from alarms raised for
+	// the etcd member running on this controller.
+	AlarmRecovery *EtcdAlarmRecovery `json:"alarmRecovery,omitempty"`
+
+	// RestartPolicy configures how k0s restarts the etcd member running on
+	// this controller after it exited.
+	RestartPolicy *RestartPolicy `json:"restartPolicy,omitempty"`
+
+	// Resources limits the resources of the etcd member running on this
+	// controller.
+	Resources *ProcessResources `json:"resources,omitempty"`
+}
+
+// DefaultEtcdDefragmentationDBSizeThreshold is the database size above which
+// etcd members are defragmented if no threshold has been configured.
+var DefaultEtcdDefragmentationDBSizeThreshold = resource.MustParse("1Gi")
+
+// EtcdDefragmentation defines the settings for the automatic defragmentation
+// of etcd members. Members are defragmented one at a time, and only if the
+// cluster would keep its quorum while a member is being defragmented.
+type EtcdDefragmentation struct {
+	// Interval in which the etcd member is checked, e.g. "24h". Automatic
+	// defragmentation is disabled if zero.
+	Interval metav1.Duration `json:"interval"`
+
+	// The etcd member is defragmented if the size of its database exceeds
+	// this threshold, e.g. "1Gi".
+	// +kubebuilder:default="1Gi"
+	DBSizeThreshold *resource.Quantity `json:"dbSizeThreshold,omitempty"`
+}
+
+// IsEnabled returns true if automatic defragmentation is enabled.
+func (d *EtcdDefragmentation) IsEnabled() bool {
+	return d != nil && d.Interval.Duration > 0
+}
+
+// GetDBSizeThreshold returns the database size threshold in bytes.
+func (d *EtcdDefragmentation) GetDBSizeThreshold() int64 {
+	if d == nil || d.DBSizeThreshold == nil {
+		return DefaultEtcdDefragmentationDBSizeThreshold.Value()
+	}
+	return d.DBSizeThreshold.Value()
+}
+
+// Validate validates the defragmentation settings.
+func (d *EtcdDefragmentation) Validate(path *field.Path) (errs []error) {
+	if d == nil {
+		return nil
+	}
+	if d.Interval.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("interval"), d.Interval.String(), "must not be negative"))
+	}
+	if d.DBSizeThreshold != nil && d.DBSizeThreshold.Sign() < 0 {
+		errs = append(errs, field.Invalid(path.Child("dbSizeThreshold"), d.DBSizeThreshold.String(), "must not be negative"))
+	}
+	return errs
+}
+
+// EtcdMemberEviction defines the settings for the automatic removal of
+// permanently failed etcd members. A member is removed from the etcd cluster
+// if its peer endpoint has been unreachable for the configured timeout, and
+// the ControlNode of its controller is gone.
+type EtcdMemberEviction struct {
+	// The time after which an unreachable etcd member is removed from the
+	// cluster, e.g. "30m". Automatic member eviction is disabled if zero.
+	UnreachableTimeout metav1.Duration `json:"unreachableTimeout"`
+}
+
+// IsEnabled returns true if automatic member eviction is enabled.
+func (e *EtcdMemberEviction) IsEnabled() bool {
+	return e != nil && e.UnreachableTimeout.Duration > 0
+}
+
+// Validate validates the member eviction settings.
+func (e *EtcdMemberEviction) Validate(path *field.Path) (errs []error) {
+	if e == nil {
+		return nil
+	}
+	if e.UnreachableTimeout.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("unreachableTimeout"), e.UnreachableTimeout.String(), "must not be negative"))
+	}
+	return errs
+}
+
+// EtcdAlarmRecovery defines the settings for the automatic recovery from etcd
+// alarms.
+type EtcdAlarmRecovery struct {
+	// NoSpace enables the automatic recovery from NOSPACE alarms. The key space
+	// history is compacted, the etcd member is defragmented and the alarm is
+	// disarmed, so that the cluster accepts writes again.
+	NoSpace bool `json:"noSpace,omitempty"`
+}
+
+// IsNoSpaceRecoveryEnabled returns true if the automatic recovery from NOSPACE
+// alarms is enabled.
+func (r *EtcdAlarmRecovery) IsNoSpaceRecoveryEnabled() bool {
+	return r != nil && r.NoSpace
+}
+
+// EtcdAutoCompactionMode is the mode of etcd's automatic compaction.
+// +kubebuilder:validation:Enum=periodic;revision
+type EtcdAutoCompactionMode string
+
+const (
+	// EtcdAutoCompactionPeriodic keeps the key space history of the
+	// configured retention period.
+	EtcdAutoCompactionPeriodic EtcdAutoCompactionMode = "periodic"
+	// EtcdAutoCompactionRevision keeps the configured number of revisions of
+	// the key space history.
+	EtcdAutoCompactionRevision EtcdAutoCompactionMode = "revision"
+)
+
+// EtcdAutoCompaction defines the settings for etcd's automatic compaction.
+type EtcdAutoCompaction struct {
+	// The compaction mode, either "periodic" or "revision".
+	// +kubebuilder:default=periodic
+	Mode EtcdAutoCompactionMode `json:"mode,omitempty"`
+
+	// The retention of the key space history. A duration for the periodic
+	// mode, e.g. "1h", or a number of revisions for the revision mode, e.g.
+	// "10000". Automatic compaction is disabled if zero.
+	Retention string `json:"retention"`
+}
+
+// GetMode returns the compaction mode.
+func (c *EtcdAutoCompaction) GetMode() EtcdAutoCompactionMode {
+	if c.Mode == "" {
+		return EtcdAutoCompactionPeriodic
+	}
+	return c.Mode
+}
+
+// Validate validates the automatic compaction settings.
+func (c *EtcdAutoCompaction) Validate(path *field.Path) (errs []error) {
+	if c == nil {
+		return nil
+	}
+	switch c.GetMode() {
+	case EtcdAutoCompactionPeriodic:
+		// etcd interprets plain numbers as hours.
+		if _, err := strconv.ParseUint(c.Retention, 10, 64); err == nil {
+			break
+		}
+		if d, err := time.ParseDuration(c.Retention); err != nil || d < 0 {
+			errs = append(errs, field.Invalid(path.Child("retention"), c.Retention, "must be a non-negative duration"))
+		}
+	case EtcdAutoCompactionRevision:
+		if _, err := strconv.ParseUint(c.Retention, 10, 64); err != nil {
+			errs = append(errs, field.Invalid(path.Child("retention"), c.Retention, "must be a non-negative number of revisions"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(path.Child("mode"), c.Mode, []EtcdAutoCompactionMode{EtcdAutoCompactionPeriodic, EtcdAutoCompactionRevision}))
+	}
+	return errs
+}
+
+func (e *EtcdConfig) isTuned() bool {
+	return e.AutoCompaction != nil || e.QuotaBackendBytes != nil ||
+		e.HeartbeatInterval != nil || e.ElectionTimeout != nil || e.SnapshotCount != nil
+}
+
+// etcd rejects election timeouts that are longer than this.
+const maxEtcdElectionTimeout = 50 * time.Second
+
+func (e *EtcdConfig) validateTuning(path *field.Path) (errs []error) {
+	errs = append(errs, e.AutoCompaction.Validate(path.Child("autoCompaction"))...)
+
+	if e.QuotaBackendBytes != nil && e.QuotaBackendBytes.Sign() < 0 {
+		errs = append(errs, field.Invalid(path.Child("quotaBackendBytes"), e.QuotaBackendBytes.String(), "must not be negative"))
+	}
+
+	heartbeatInterval, electionTimeout := 100*time.Millisecond, time.Second
+	if e.HeartbeatInterval != nil {
+		heartbeatInterval = e.HeartbeatInterval.Duration
+		if heartbeatInterval < time.Millisecond {
+			errs = append(errs, field.Invalid(path.Child("heartbeatInterval"), e.HeartbeatInterval.String(), "must be at least 1ms"))
+		}
+	}
+	if e.ElectionTimeout != nil {
+		electionTimeout = e.ElectionTimeout.Duration
+		if electionTimeout > maxEtcdElectionTimeout {
+			errs = append(errs, field.Invalid(path.Child("electionTimeout"), e.ElectionTimeout.String(), "must not exceed "+maxEtcdElectionTimeout.String()))
+		}
+	}
+	if (e.HeartbeatInterval != nil || e.ElectionTimeout != nil) && electionTimeout < 5*heartbeatInterval {
+		errs = append(errs, field.Invalid(path.Child("electionTimeout"), electionTimeout.String(), "must be at least five times the heartbeat interval"))
+	}
+
+	return errs
+}
+
+// DefaultEtcdMetricsProxyPort is the port on which the etcd metrics proxy
+// listens if no port has been configured.
+const DefaultEtcdMetricsProxyPort = 2382
+
+// EtcdMetrics defines how the metrics of an etcd member are exposed. etcd's
+// client endpoint requires client certificates, hence it can't be scraped
+// directly by Prometheus without distributing those certificates.
+type EtcdMetrics struct {
+	// ListenAddress is a loopback address on which etcd serves its /metrics
+	// and /health endpoints via plain HTTP, e.g. "127.0.0.1:2381".
+	ListenAddress string `json:"listenAddress,omitempty"`
+
+	// Proxy exposes etcd's metrics via an authenticated HTTPS endpoint.
+	Proxy *EtcdMetricsProxy `json:"proxy,omitempty"`
+}
+
+// EtcdMetricsProxy defines the settings for the etcd metrics proxy. The proxy
+// serves etcd's metrics on all addresses of the controller. Requests need to
+// present a Kubernetes bearer token that's authorized to get the /metrics
+// non-resource URL.
+type EtcdMetricsProxy struct {
+	// The port on which the proxy listens.
+	// +kubebuilder:default=2382
+	Port int `json:"port,omitempty"`
+}
+
+// GetPort returns the port on which the proxy listens.
+func (p *EtcdMetricsProxy) GetPort() int {
+	if p.Port == 0 {
+		return DefaultEtcdMetricsProxyPort
+	}
+	return p.Port
+}
+
+// Validate validates the metrics settings.
+func (m *EtcdMetrics) Validate(path *field.Path) (errs []error) {
+	if m == nil {
+		return nil
+	}
+	if m.ListenAddress != "" {
+		if host, port, err := net.SplitHostPort(m.ListenAddress); err != nil {
+			errs = append(errs, field.Invalid(path.Child("listenAddress"), m.ListenAddress, err.Error()))
+		} else if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			errs = append(errs, field.Invalid(path.Child("listenAddress"), m.ListenAddress, "must be a loopback address"))
+		} else if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+			errs = append(errs, field.Invalid(path.Child("listenAddress"), m.ListenAddress, "invalid port"))
+		}
+	}
+	if m.Proxy != nil && (m.Proxy.Port < 0 || m.Proxy.Port > 65535) {
+		errs = append(errs, field.Invalid(path.Child("proxy", "port"), m.Proxy.Port, "must be a valid port number"))
+	}
+	return errs
 }
 
 // ExternalCluster defines external etcd cluster related config options
@@ -141,13 +452,16 @@ type ExternalCluster struct {
 	// EtcdPrefix is a prefix to prepend to all resource paths in etcd
 	EtcdPrefix string `json:"etcdPrefix,omitempty"`
 
-	// CaFile is the host path to a file with CA certificate
+	// CaFile is the host path to a file with CA certificate. If omitted, the
+	// server certificates are verified against the host's root CAs.
 	CaFile string `json:"caFile,omitempty"`
 
-	// ClientCertFile is the host path to a file with TLS certificate for etcd client
+	// ClientCertFile is the host path to a file with TLS certificate for etcd
+	// client. Requires ClientKeyFile.
 	ClientCertFile string `json:"clientCertFile,omitempty"`
 
-	// ClientKeyFile is the host path to a file with TLS key for etcd client
+	// ClientKeyFile is the host path to a file with TLS key for etcd client.
+	// Requires ClientCertFile.
 	ClientKeyFile string `json:"clientKeyFile,omitempty"`
 }
 
@@ -251,33 +565,36 @@ func (e *EtcdConfig) IsExternalClusterUsed() bool {
 }
 
 // IsTLSEnabled returns true if external cluster is not configured or external cluster is configured
-// with all TLS properties: caFile, clientCertFile, clientKeyFile. Otherwise it returns false.
+// with a CA certificate or a client certificate. Otherwise it returns false.
 func (e *EtcdConfig) IsTLSEnabled() bool {
-	return !e.IsExternalClusterUsed() || e.ExternalCluster.hasAllTLSPropertiesDefined()
+	return !e.IsExternalClusterUsed() || e.ExternalCluster.CaFile != "" || e.ExternalCluster.hasClientCertificate()
 }
 
-// GetCaFilePath returns the host path to a file with CA certificate if external cluster has configured all TLS properties,
+// GetCaFilePath returns the host path to a file with CA certificate if external cluster is configured,
 // otherwise it returns the host path to a default CA certificate in a given certDir directory.
+// Returns an empty string if the external cluster has no CA certificate configured.
 func (e *EtcdConfig) GetCaFilePath(certDir string) string {
-	if e.IsExternalClusterUsed() && e.ExternalCluster.hasAllTLSPropertiesDefined() {
+	if e.IsExternalClusterUsed() {
 		return e.ExternalCluster.CaFile
 	}
 	return filepath.Join(certDir, "ca.crt")
 }
 
-// GetCertFilePath returns the host path to a file with a client certificate if external cluster has configured all TLS properties,
+// GetCertFilePath returns the host path to a file with a client certificate if external cluster is configured,
 // otherwise it returns the host path to a default client certificate in a given certDir directory.
+// Returns an empty string if the external cluster has no client certificate configured.
 func (e *EtcdConfig) GetCertFilePath(certDir string) string {
-	if e.IsExternalClusterUsed() && e.ExternalCluster.hasAllTLSPropertiesDefined() {
+	if e.IsExternalClusterUsed() {
 		return e.ExternalCluster.ClientCertFile
 	}
 	return filepath.Join(certDir, "apiserver-etcd-client.crt")
 }
 
-// GetCaFilePath returns the host path to a file with client private key if external cluster has configured all TLS properties,
+// GetKeyFilePath returns the host path to a file with client private key if external cluster is configured,
 // otherwise it returns the host path to a default client private key in a given certDir directory.
+// Returns an empty string if the external cluster has no client certificate configured.
 func (e *EtcdConfig) GetKeyFilePath(certDir string) string {
-	if e.IsExternalClusterUsed() && e.ExternalCluster.hasAllTLSPropertiesDefined() {
+	if e.IsExternalClusterUsed() {
 		return e.ExternalCluster.ClientKeyFile
 	}
 	return filepath.Join(certDir, "apiserver-etcd-client.key")
@@ -300,15 +617,13 @@ func validateRequiredProperties(e *ExternalCluster) []error {
 }
 
 func validateOptionalTLSProperties(e *ExternalCluster) []error {
-	noTLSPropertyDefined := e.CaFile == "" && e.ClientCertFile == "" && e.ClientKeyFile == ""
-
-	if noTLSPropertyDefined || e.hasAllTLSPropertiesDefined() {
+	if (e.ClientCertFile == "") == (e.ClientKeyFile == "") {
 		return nil
 	}
 	return []error{errors.New("spec.storage.etcd.externalCluster is invalid: " +
-		"all TLS properties [caFile,clientCertFile,clientKeyFile] must be defined or none of those")}
+		"TLS properties [clientCertFile,clientKeyFile] must both be defined or none of those")}
 }
 
-func (e *ExternalCluster) hasAllTLSPropertiesDefined() bool {
-	return e.CaFile != "" && e.ClientCertFile != "" && e.ClientKeyFile != ""
+func (e *ExternalCluster) hasClientCertificate() bool {
+	return e.ClientCertFile != "" && e.ClientKeyFile != ""
 }
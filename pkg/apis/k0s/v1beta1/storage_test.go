@@ -8,10 +8,14 @@ import (
 	"os"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestStorageSpec_IsJoinable(t *testing.T) {
@@ -197,6 +201,33 @@ func (s *storageSuite) TestValidation() {
 				},
 			},
 		},
+		{
+			desc: "external_cluster_spec_with_ca_only_is_valid",
+			spec: &StorageSpec{
+				Type: EtcdStorageType,
+				Etcd: &EtcdConfig{
+					ExternalCluster: &ExternalCluster{
+						Endpoints:  []string{"https://192.168.10.10"},
+						EtcdPrefix: "tenant-1",
+						CaFile:     "/etc/pki/CA/ca.crt",
+					},
+				},
+			},
+		},
+		{
+			desc: "external_cluster_spec_with_client_cert_only_is_valid",
+			spec: &StorageSpec{
+				Type: EtcdStorageType,
+				Etcd: &EtcdConfig{
+					ExternalCluster: &ExternalCluster{
+						Endpoints:      []string{"https://192.168.10.10"},
+						EtcdPrefix:     "tenant-1",
+						ClientCertFile: "/etc/pki/tls/certs/etcd-client.crt",
+						ClientKeyFile:  "/etc/pki/tls/private/etcd-client.key",
+					},
+				},
+			},
+		},
 		{
 			desc: "kine_is_valid",
 			spec: &StorageSpec{
@@ -255,7 +286,7 @@ func (s *storageSuite) TestValidation() {
 			expectedErrMsg: "spec.storage.etcd.externalCluster.endpoints cannot contain empty strings",
 		},
 		{
-			desc: "external_cluster_must_have_configured_all_tls_properties_or_none_of_them",
+			desc: "external_cluster_must_have_configured_client_cert_and_key_or_none_of_them",
 			spec: &StorageSpec{
 				Type: EtcdStorageType,
 				Etcd: &EtcdConfig{
@@ -268,7 +299,7 @@ func (s *storageSuite) TestValidation() {
 					},
 				},
 			},
-			expectedErrMsg: "spec.storage.etcd.externalCluster is invalid: all TLS properties [caFile,clientCertFile,clientKeyFile] must be defined or none of those",
+			expectedErrMsg: "spec.storage.etcd.externalCluster is invalid: TLS properties [clientCertFile,clientKeyFile] must both be defined or none of those",
 		},
 	}
 
@@ -346,17 +377,31 @@ func (s *storageSuite) TestIsTLSEnabled() {
 			expectedResult: false,
 		},
 		{
-			desc: "is_TLS_enabled_returns_false_when_external_cluster_is_used_but_TLS_properties_are_configured_partially",
+			desc: "is_TLS_enabled_returns_true_when_external_cluster_is_used_and_has_only_set_the_CA",
 			spec: &StorageSpec{
 				Type: EtcdStorageType,
 				Etcd: &EtcdConfig{
 					ExternalCluster: &ExternalCluster{
-						Endpoints:  []string{"http://192.168.10.10"},
+						Endpoints:  []string{"https://192.168.10.10"},
 						EtcdPrefix: "tenant-1",
 						CaFile:     "/etc/pki/CA/ca.crt",
 					},
 				},
 			},
+			expectedResult: true,
+		},
+		{
+			desc: "is_TLS_enabled_returns_false_when_external_cluster_is_used_but_has_only_set_the_client_cert",
+			spec: &StorageSpec{
+				Type: EtcdStorageType,
+				Etcd: &EtcdConfig{
+					ExternalCluster: &ExternalCluster{
+						Endpoints:      []string{"http://192.168.10.10"},
+						EtcdPrefix:     "tenant-1",
+						ClientCertFile: "/etc/pki/tls/certs/etcd-client.crt",
+					},
+				},
+			},
 			expectedResult: false,
 		},
 	}
@@ -410,3 +455,183 @@ func TestEtcdConfig_GetNodeName(t *testing.T) {
 		})
 	}
 }
+
+func TestStorageSpec_Validate_EtcdDefragmentation(t *testing.T) {
+	negativeSize := resource.MustParse("-1")
+	tests := []struct {
+		name   string
+		defrag *EtcdDefragmentation
+		errs   []string
+	}{
+		{"nil", nil, nil},
+		{"disabled", &EtcdDefragmentation{}, nil},
+		{"valid", &EtcdDefragmentation{Interval: metav1.Duration{Duration: 24 * time.Hour}}, nil},
+		{"negative interval", &EtcdDefragmentation{Interval: metav1.Duration{Duration: -time.Hour}}, []string{"etcd.defragmentation.interval: Invalid value"}},
+		{"negative threshold", &EtcdDefragmentation{DBSizeThreshold: &negativeSize}, []string{"etcd.defragmentation.dbSizeThreshold: Invalid value"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := DefaultStorageSpec()
+			spec.Etcd.Defragmentation = test.defrag
+			errs := spec.Validate()
+			if assert.Len(t, errs, len(test.errs)) {
+				for i, err := range errs {
+					assert.ErrorContains(t, err, test.errs[i])
+				}
+			}
+		})
+	}
+
+	t.Run("external_cluster", func(t *testing.T) {
+		spec := DefaultStorageSpec()
+		spec.Etcd.ExternalCluster = &ExternalCluster{
+			Endpoints:  []string{"https://192.168.10.2:2379"},
+			EtcdPrefix: "k0s-tenant-1",
+		}
+		spec.Etcd.Defragmentation = &EtcdDefragmentation{Interval: metav1.Duration{Duration: time.Hour}}
+		errs := spec.Validate()
+		if assert.Len(t, errs, 1) {
+			assert.ErrorContains(t, errs[0], "etcd.defragmentation: Forbidden")
+		}
+	})
+}
+
+func TestEtcdDefragmentation_GetDBSizeThreshold(t *testing.T) {
+	threshold := resource.MustParse("512Mi")
+	assert.Equal(t, int64(1<<30), (*EtcdDefragmentation)(nil).GetDBSizeThreshold())
+	assert.Equal(t, int64(1<<30), (&EtcdDefragmentation{}).GetDBSizeThreshold())
+	assert.Equal(t, int64(512<<20), (&EtcdDefragmentation{DBSizeThreshold: &threshold}).GetDBSizeThreshold())
+}
+
+func TestStorageSpec_Validate_EtcdMemberEviction(t *testing.T) {
+	spec := DefaultStorageSpec()
+	spec.Etcd.MemberEviction = &EtcdMemberEviction{UnreachableTimeout: metav1.Duration{Duration: 30 * time.Minute}}
+	assert.Empty(t, spec.Validate())
+	assert.True(t, spec.Etcd.MemberEviction.IsEnabled())
+
+	spec.Etcd.MemberEviction.UnreachableTimeout.Duration = -time.Minute
+	errs := spec.Validate()
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], "etcd.memberEviction.unreachableTimeout: Invalid value")
+	}
+
+	spec.Etcd.MemberEviction.UnreachableTimeout.Duration = time.Minute
+	spec.Etcd.ExternalCluster = &ExternalCluster{
+		Endpoints:  []string{"https://192.168.10.2:2379"},
+		EtcdPrefix: "k0s-tenant-1",
+	}
+	errs = spec.Validate()
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], "etcd.memberEviction: Forbidden")
+	}
+}
+
+func TestStorageSpec_Validate_EtcdMetrics(t *testing.T) {
+	spec := DefaultStorageSpec()
+	spec.Etcd.Metrics = &EtcdMetrics{ListenAddress: "127.0.0.1:2381", Proxy: &EtcdMetricsProxy{}}
+	assert.Empty(t, spec.Validate())
+	assert.Equal(t, DefaultEtcdMetricsProxyPort, spec.Etcd.Metrics.Proxy.GetPort())
+
+	for _, addr := range []string{"localhost:2381", "[::1]:2381"} {
+		spec.Etcd.Metrics.ListenAddress = addr
+		assert.Empty(t, spec.Validate(), addr)
+	}
+
+	for _, addr := range []string{"0.0.0.0:2381", "192.168.10.2:2381", "127.0.0.1", "127.0.0.1:0"} {
+		spec.Etcd.Metrics.ListenAddress = addr
+		errs := spec.Validate()
+		if assert.Len(t, errs, 1, addr) {
+			assert.ErrorContains(t, errs[0], "etcd.metrics.listenAddress: Invalid value")
+		}
+	}
+
+	spec.Etcd.Metrics.ListenAddress = ""
+	spec.Etcd.Metrics.Proxy.Port = 65536
+	errs := spec.Validate()
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], "etcd.metrics.proxy.port: Invalid value")
+	}
+
+	spec.Etcd.Metrics.Proxy.Port = 0
+	spec.Etcd.ExternalCluster = &ExternalCluster{
+		Endpoints:  []string{"https://192.168.10.2:2379"},
+		EtcdPrefix: "k0s-tenant-1",
+	}
+	errs = spec.Validate()
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], "etcd.metrics: Forbidden")
+	}
+}
+
+func TestStorageSpec_Validate_EtcdAlarmRecovery(t *testing.T) {
+	spec := DefaultStorageSpec()
+	spec.Etcd.AlarmRecovery = &EtcdAlarmRecovery{NoSpace: true}
+	assert.Empty(t, spec.Validate())
+
+	spec.Etcd.ExternalCluster = &ExternalCluster{
+		Endpoints:  []string{"https://192.168.10.2:2379"},
+		EtcdPrefix: "k0s-tenant-1",
+	}
+	errs := spec.Validate()
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], "etcd.alarmRecovery: Forbidden")
+	}
+
+	spec.Etcd.AlarmRecovery.NoSpace = false
+	assert.Empty(t, spec.Validate())
+}
+
+func TestStorageSpec_Validate_EtcdTuning(t *testing.T) {
+	quota := resource.MustParse("8Gi")
+	spec := DefaultStorageSpec()
+	spec.Etcd.AutoCompaction = &EtcdAutoCompaction{Retention: "1h"}
+	spec.Etcd.QuotaBackendBytes = &quota
+	spec.Etcd.HeartbeatInterval = &metav1.Duration{Duration: 200 * time.Millisecond}
+	spec.Etcd.ElectionTimeout = &metav1.Duration{Duration: 2 * time.Second}
+	assert.Empty(t, spec.Validate())
+
+	for _, compaction := range []EtcdAutoCompaction{
+		{Retention: "12"},
+		{Mode: EtcdAutoCompactionRevision, Retention: "10000"},
+	} {
+		spec.Etcd.AutoCompaction = &compaction
+		assert.Empty(t, spec.Validate(), compaction)
+	}
+
+	for _, compaction := range []EtcdAutoCompaction{
+		{Retention: "-1h"},
+		{Retention: "forever"},
+		{Mode: EtcdAutoCompactionRevision, Retention: "1h"},
+	} {
+		spec.Etcd.AutoCompaction = &compaction
+		errs := spec.Validate()
+		if assert.Len(t, errs, 1, compaction) {
+			assert.ErrorContains(t, errs[0], "etcd.autoCompaction.retention: Invalid value")
+		}
+	}
+	spec.Etcd.AutoCompaction = nil
+
+	spec.Etcd.ElectionTimeout.Duration = 500 * time.Millisecond
+	errs := spec.Validate()
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], "etcd.electionTimeout: Invalid value: \"500ms\": must be at least five times the heartbeat interval")
+	}
+
+	spec.Etcd.HeartbeatInterval = nil
+	spec.Etcd.ElectionTimeout.Duration = time.Minute
+	errs = spec.Validate()
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], "etcd.electionTimeout: Invalid value")
+	}
+
+	spec.Etcd.ElectionTimeout = nil
+	spec.Etcd.ExternalCluster = &ExternalCluster{
+		Endpoints:  []string{"https://192.168.10.2:2379"},
+		EtcdPrefix: "k0s-tenant-1",
+	}
+	errs = spec.Validate()
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], "etcd: Forbidden")
+	}
+}
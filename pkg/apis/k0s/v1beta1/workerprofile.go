@@ -5,9 +5,24 @@ package v1beta1
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kubeletv1 "k8s.io/kubelet/config/v1"
+	kubeletv1beta1 "k8s.io/kubelet/config/v1beta1"
+	"k8s.io/utils/cpuset"
+	"sigs.k8s.io/yaml"
 )
 
 var _ Validateable = (*WorkerProfiles)(nil)
@@ -35,6 +50,317 @@ type WorkerProfile struct {
 	// Worker Mapping object
 	// +kubebuilder:validation:type=object
 	Config *runtime.RawExtension `json:"values,omitempty"`
+	// Sysctls that the worker sets at startup, e.g. "net.ipv4.ip_forward": "1".
+	// The worker doesn't start if it can't set them.
+	// +optional
+	Sysctls map[string]string `json:"sysctls,omitempty"`
+	// Kernel modules that the worker loads at startup. The worker doesn't
+	// start if it can't load them.
+	// +listType=set
+	// +optional
+	KernelModules []string `json:"kernelModules,omitempty"`
+	// KubeletConfiguration fragments that the worker passes to the kubelet as
+	// drop-in files. The kubelet merges them in the given order on top of the
+	// configuration that's generated from the values.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	KubeletConfigDropIns []KubeletConfigDropIn `json:"kubeletConfigDropIns,omitempty"`
+	// Graceful node shutdown settings of the kubelet. The worker verifies that
+	// systemd-logind is available at startup, as the kubelet relies on its
+	// inhibitor locks to delay the node shutdown.
+	// +optional
+	GracefulShutdown *GracefulShutdown `json:"gracefulShutdown,omitempty"`
+	// Static pods that the kubelet runs from a node-local directory that's
+	// managed by k0s, i.e. <data-dir>/static-pods.
+	// +optional
+	StaticPods *WorkerStaticPods `json:"staticPods,omitempty"`
+	// Swap usage of the workloads. The worker verifies that the host's cgroup
+	// setup supports it at startup.
+	// +optional
+	Swap *WorkerSwap `json:"swap,omitempty"`
+	// Image credential provider plugins that the kubelet uses to obtain
+	// credentials for pulling images. The worker installs the plugin
+	// executables into <data-dir>/bin/credential-providers.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ImageCredentialProviders []ImageCredentialProvider `json:"imageCredentialProviders,omitempty"`
+	// Container runtime handlers for GPUs. The worker detects the GPU drivers
+	// and container toolkits at startup and configures containerd
+	// accordingly, while the controllers maintain the matching RuntimeClasses.
+	// +optional
+	GPURuntimes *WorkerGPURuntimes `json:"gpuRuntimes,omitempty"`
+	// Garbage collection of images and container logs. Settings that aren't
+	// specified default to values that depend on the size of the disk that
+	// holds the kubelet's root directory.
+	// +optional
+	GarbageCollection *WorkerGarbageCollection `json:"garbageCollection,omitempty"`
+	// The kubelet's CPU, memory and topology manager policies, e.g. for
+	// latency-sensitive workloads. The worker verifies them against the host's
+	// CPU and NUMA topology at startup.
+	// +optional
+	ResourceManagers *WorkerResourceManagers `json:"resourceManagers,omitempty"`
+	// Resources that are reserved for system daemons, and the enforcement of
+	// the node allocatable resources. The worker creates the cgroups in which
+	// the reservations are enforced at startup, which requires cgroup v2.
+	// +optional
+	ResourceReservations *WorkerResourceReservations `json:"resourceReservations,omitempty"`
+	// Seccomp settings of the workloads. The worker writes the seccomp
+	// profiles into <kubelet-root-dir>/seccomp/k0s at startup, replacing the
+	// ones that have been written before.
+	// +optional
+	Seccomp *WorkerSeccomp `json:"seccomp,omitempty"`
+	// How the worker restarts the kubelet and containerd after they exited.
+	// +optional
+	RestartPolicies *WorkerRestartPolicies `json:"restartPolicies,omitempty"`
+}
+
+// GracefulShutdown configures the kubelet's graceful node shutdown.
+type GracefulShutdown struct {
+	// The total time by which the node shutdown is delayed, so that the pods
+	// can terminate gracefully. Maps to the kubelet's shutdownGracePeriod.
+	GracePeriod metav1.Duration `json:"gracePeriod"`
+	// The part of the grace period that's reserved for terminating critical
+	// pods. Maps to the kubelet's shutdownGracePeriodCriticalPods.
+	// +optional
+	CriticalPodsGracePeriod metav1.Duration `json:"criticalPodsGracePeriod,omitempty"`
+}
+
+// KubeletConfigDropIn is a KubeletConfiguration fragment.
+type KubeletConfigDropIn struct {
+	// The name of the drop-in.
+	Name string `json:"name"`
+	// The KubeletConfiguration fields to override. apiVersion and kind are
+	// filled in by k0s.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config *runtime.RawExtension `json:"config"`
+}
+
+// WorkerStaticPods configures the static pod directory of the worker.
+type WorkerStaticPods struct {
+	// Whether the kubelet runs the static pods in the directory.
+	Enabled bool `json:"enabled"`
+	// How often the kubelet checks the directory for changes. Maps to the
+	// kubelet's fileCheckFrequency. Defaults to 20s.
+	// +optional
+	CheckFrequency *metav1.Duration `json:"checkFrequency,omitempty"`
+}
+
+// WorkerSwap configures the swap usage of the workloads.
+type WorkerSwap struct {
+	// How the workloads may use swap. Maps to the kubelet's
+	// memorySwap.swapBehavior. With LimitedSwap, Burstable pods may use swap
+	// in proportion to their memory requests. With NoSwap, the workloads don't
+	// use swap, even if it's enabled on the host.
+	// +kubebuilder:validation:Enum=NoSwap;LimitedSwap
+	Behavior string `json:"behavior"`
+}
+
+const (
+	SwapBehaviorNoSwap      = "NoSwap"
+	SwapBehaviorLimitedSwap = "LimitedSwap"
+)
+
+// ImageCredentialProvider is a kubelet image credential provider plugin.
+type ImageCredentialProvider struct {
+	// The name of the plugin, which is also the name of its executable.
+	Name string `json:"name"`
+	// The executables of the plugin per platform, e.g. linux-amd64.
+	Binaries map[string]ImageCredentialProviderBinary `json:"binaries"`
+	// The kubelet's CredentialProvider configuration of the plugin. The name
+	// is filled in by k0s.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config *runtime.RawExtension `json:"config"`
+}
+
+// ImageCredentialProviderBinary is the source of a plugin executable.
+type ImageCredentialProviderBinary struct {
+	// The URL from which the executable is downloaded.
+	URL string `json:"url"`
+	// The SHA256 hash of the executable.
+	Sha256 string `json:"sha256"`
+}
+
+// WorkerGPURuntimes configures the container runtime handlers for GPUs.
+type WorkerGPURuntimes struct {
+	// The runtime handler for NVIDIA GPUs, which runs containers via the
+	// NVIDIA Container Toolkit.
+	// +optional
+	NVIDIA *WorkerNVIDIARuntime `json:"nvidia,omitempty"`
+}
+
+// WorkerNVIDIARuntime configures the runtime handler for NVIDIA GPUs.
+type WorkerNVIDIARuntime struct {
+	// Whether containerd is configured with the nvidia runtime handler, and
+	// the nvidia RuntimeClass is created. The worker fails to start if the
+	// NVIDIA driver or the NVIDIA Container Toolkit can't be found.
+	Enabled bool `json:"enabled"`
+}
+
+// NVIDIARuntimeHandler is the name of both the containerd runtime handler and
+// the RuntimeClass for NVIDIA GPUs.
+const NVIDIARuntimeHandler = "nvidia"
+
+// NVIDIAEnabled returns whether the runtime handler for NVIDIA GPUs is enabled.
+func (r *WorkerGPURuntimes) NVIDIAEnabled() bool {
+	return r != nil && r.NVIDIA != nil && r.NVIDIA.Enabled
+}
+
+// WorkerGarbageCollection configures the kubelet's garbage collection of
+// images and container logs.
+type WorkerGarbageCollection struct {
+	// The disk usage percentage of the image filesystem above which image
+	// garbage collection always runs. Maps to the kubelet's
+	// imageGCHighThresholdPercent.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ImageHighThresholdPercent *int32 `json:"imageHighThresholdPercent,omitempty"`
+	// The disk usage percentage of the image filesystem to which image
+	// garbage collection attempts to free. Maps to the kubelet's
+	// imageGCLowThresholdPercent.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ImageLowThresholdPercent *int32 `json:"imageLowThresholdPercent,omitempty"`
+	// The minimum age of unused images before they're garbage collected. Maps
+	// to the kubelet's imageMinimumGCAge.
+	// +optional
+	ImageMinimumAge *metav1.Duration `json:"imageMinimumAge,omitempty"`
+	// The maximum size of a container log file before it's rotated, e.g.
+	// 10Mi. Maps to the kubelet's containerLogMaxSize.
+	// +optional
+	ContainerLogMaxSize string `json:"containerLogMaxSize,omitempty"`
+	// The maximum number of log files per container. Maps to the kubelet's
+	// containerLogMaxFiles.
+	// +kubebuilder:validation:Minimum=2
+	// +optional
+	ContainerLogMaxFiles *int32 `json:"containerLogMaxFiles,omitempty"`
+}
+
+// WorkerResourceManagers configures the kubelet's resource managers.
+type WorkerResourceManagers struct {
+	// The CPU manager policy. Maps to the kubelet's cpuManagerPolicy. The
+	// static policy requires a CPU reservation, either via reservedSystemCPUs
+	// or via the kubelet's kubeReserved or systemReserved.
+	// +kubebuilder:validation:Enum=none;static
+	// +optional
+	CPUManagerPolicy string `json:"cpuManagerPolicy,omitempty"`
+	// The memory manager policy. Maps to the kubelet's memoryManagerPolicy.
+	// The Static policy requires the kubelet's reservedMemory.
+	// +kubebuilder:validation:Enum=None;Static
+	// +optional
+	MemoryManagerPolicy string `json:"memoryManagerPolicy,omitempty"`
+	// The topology manager policy. Maps to the kubelet's
+	// topologyManagerPolicy.
+	// +kubebuilder:validation:Enum=none;best-effort;restricted;single-numa-node
+	// +optional
+	TopologyManagerPolicy string `json:"topologyManagerPolicy,omitempty"`
+	// The CPUs that are reserved for system daemons, e.g. 0-1,6. Maps to the
+	// kubelet's reservedSystemCPUs.
+	// +optional
+	ReservedSystemCPUs string `json:"reservedSystemCPUs,omitempty"`
+}
+
+const (
+	CPUManagerPolicyNone   = "none"
+	CPUManagerPolicyStatic = "static"
+
+	MemoryManagerPolicyNone   = "None"
+	MemoryManagerPolicyStatic = "Static"
+
+	TopologyManagerPolicyNone           = "none"
+	TopologyManagerPolicyBestEffort     = "best-effort"
+	TopologyManagerPolicyRestricted     = "restricted"
+	TopologyManagerPolicySingleNUMANode = "single-numa-node"
+)
+
+// WorkerResourceReservations configures the resources that are reserved for
+// system daemons, i.e. that aren't allocatable by pods.
+type WorkerResourceReservations struct {
+	// The resources reserved for Kubernetes system daemons, i.e. the kubelet
+	// and the container runtime, e.g. cpu: 500m. Supported resources are cpu,
+	// memory, ephemeral-storage and pid. Maps to the kubelet's kubeReserved.
+	// +optional
+	KubeReserved map[string]string `json:"kubeReserved,omitempty"`
+	// The resources reserved for operating system daemons, e.g. memory: 1Gi.
+	// Supported resources are cpu, memory, ephemeral-storage and pid. Maps to
+	// the kubelet's systemReserved.
+	// +optional
+	SystemReserved map[string]string `json:"systemReserved,omitempty"`
+	// The hard eviction thresholds, e.g. memory.available: 500Mi or
+	// nodefs.available: 10%. Maps to the kubelet's evictionHard.
+	// +optional
+	EvictionHard map[string]string `json:"evictionHard,omitempty"`
+	// The reservations that the kubelet enforces via cgroups: pods,
+	// kube-reserved and system-reserved, or none. Maps to the kubelet's
+	// enforceNodeAllocatable.
+	// +listType=set
+	// +optional
+	EnforceNodeAllocatable []string `json:"enforceNodeAllocatable,omitempty"`
+	// The cgroup in which kube-reserved is enforced, relative to the cgroup
+	// root. Maps to the kubelet's kubeReservedCgroup. Required to enforce
+	// kube-reserved. Needs to be a slice on systemd hosts, which is then
+	// started by k0s.
+	// +optional
+	KubeReservedCgroup string `json:"kubeReservedCgroup,omitempty"`
+	// The cgroup in which system-reserved is enforced, relative to the cgroup
+	// root. Maps to the kubelet's systemReservedCgroup. Required to enforce
+	// system-reserved.
+	// +optional
+	SystemReservedCgroup string `json:"systemReservedCgroup,omitempty"`
+}
+
+// WorkerSeccomp configures the seccomp profiles of the workloads.
+type WorkerSeccomp struct {
+	// Whether the kubelet applies the RuntimeDefault seccomp profile to all
+	// workloads that don't specify a seccomp profile. Maps to the kubelet's
+	// seccompDefault.
+	// +optional
+	Default bool `json:"default,omitempty"`
+	// Seccomp profiles that are distributed to all workers of the profile.
+	// Pods reference them as Localhost profiles named k0s/<name>.json.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Profiles []SeccompProfile `json:"profiles,omitempty"`
+}
+
+// SeccompProfile is a seccomp profile in the OCI runtime's JSON format.
+type SeccompProfile struct {
+	// The name of the profile, which is also the name of its file, without
+	// the .json extension.
+	Name string `json:"name"`
+	// The seccomp profile, e.g. {"defaultAction": "SCMP_ACT_LOG"}.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Profile *runtime.RawExtension `json:"profile"`
+}
+
+// SeccompProfileDir is the directory, relative to the kubelet's seccomp
+// profile root, that holds the seccomp profiles of the worker profile.
+const SeccompProfileDir = "k0s"
+
+const (
+	EnforceNodeAllocatablePods           = "pods"
+	EnforceNodeAllocatableKubeReserved   = "kube-reserved"
+	EnforceNodeAllocatableSystemReserved = "system-reserved"
+	EnforceNodeAllocatableNone           = "none"
+)
+
+// The resources that can be reserved for system daemons.
+var reservableResources = []string{"cpu", "memory", "ephemeral-storage", "pid"}
+
+// The signals that the kubelet supports for hard eviction thresholds.
+var evictionSignals = []string{
+	"memory.available",
+	"nodefs.available", "nodefs.inodesFree",
+	"imagefs.available", "imagefs.inodesFree",
+	"containerfs.available", "containerfs.inodesFree",
+	"pid.available",
 }
 
 var lockedFields = map[string]struct{}{
@@ -45,18 +371,479 @@ var lockedFields = map[string]struct{}{
 	"staticPodURL":  {},
 }
 
+// sysctlNameRegex matches sysctl names, separated either by dots or slashes.
+var sysctlNameRegex = regexp.MustCompile(`^[a-z0-9]([-_a-z0-9]*[a-z0-9])?([./][a-z0-9]([-_a-z0-9]*[a-z0-9])?)*$`)
+
+// kernelModuleNameRegex matches kernel module names.
+var kernelModuleNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
 // Validate validates instance
 func (wp *WorkerProfile) Validate() error {
-	var parsed map[string]any
-	err := json.Unmarshal(wp.Config.Raw, &parsed)
-	if err != nil {
+	var values map[string]any
+	if wp.Config != nil {
+		err := json.Unmarshal(wp.Config.Raw, &values)
+		if err != nil {
+			return err
+		}
+
+		for field := range values {
+			if _, found := lockedFields[field]; found {
+				return fmt.Errorf("field `%s` is prohibited to override in worker profile", field)
+			}
+		}
+	}
+
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(wp.Sysctls)) {
+		if !sysctlNameRegex.MatchString(name) {
+			errs = append(errs, fmt.Errorf("worker profile %q: invalid sysctl name %q", wp.Name, name))
+		}
+	}
+	for _, module := range wp.KernelModules {
+		if !kernelModuleNameRegex.MatchString(module) {
+			errs = append(errs, fmt.Errorf("worker profile %q: invalid kernel module name %q", wp.Name, module))
+		}
+	}
+	for i, dropIn := range wp.KubeletConfigDropIns {
+		if slices.ContainsFunc(wp.KubeletConfigDropIns[:i], func(other KubeletConfigDropIn) bool { return other.Name == dropIn.Name }) {
+			errs = append(errs, fmt.Errorf("worker profile %q: duplicate kubelet config drop-in %q", wp.Name, dropIn.Name))
+		} else if err := dropIn.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("worker profile %q: kubelet config drop-in %q: %w", wp.Name, dropIn.Name, err))
+		}
+	}
+	if wp.GracefulShutdown != nil {
+		if err := wp.GracefulShutdown.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("worker profile %q: graceful shutdown: %w", wp.Name, err))
+		}
+		errs = append(errs, wp.conflictingValues(values, "gracefulShutdown", "shutdownGracePeriod", "shutdownGracePeriodCriticalPods")...)
+	}
+	if wp.StaticPods != nil {
+		if err := wp.StaticPods.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("worker profile %q: static pods: %w", wp.Name, err))
+		}
+		errs = append(errs, wp.conflictingValues(values, "staticPods", "staticPodPath", "fileCheckFrequency")...)
+	}
+	if wp.Swap != nil {
+		if err := wp.Swap.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("worker profile %q: swap: %w", wp.Name, err))
+		}
+		errs = append(errs, wp.conflictingValues(values, "swap", "memorySwap", "failSwapOn")...)
+	}
+	if wp.GarbageCollection != nil {
+		if err := wp.GarbageCollection.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("worker profile %q: garbage collection: %w", wp.Name, err))
+		}
+		errs = append(errs, wp.conflictingValues(values, "garbageCollection",
+			"imageGCHighThresholdPercent", "imageGCLowThresholdPercent", "imageMinimumGCAge",
+			"containerLogMaxSize", "containerLogMaxFiles",
+		)...)
+	}
+	if managers := wp.ResourceManagers; managers != nil {
+		if err := managers.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("worker profile %q: resource managers: %w", wp.Name, err))
+		}
+		if managers.CPUManagerPolicy == CPUManagerPolicyStatic && managers.ReservedSystemCPUs == "" && !wp.reservesCPU(values) {
+			errs = append(errs, fmt.Errorf("worker profile %q: resource managers: the %s CPU manager policy requires reservedSystemCPUs or a CPU reservation in kubeReserved or systemReserved", wp.Name, managers.CPUManagerPolicy))
+		}
+		if managers.MemoryManagerPolicy == MemoryManagerPolicyStatic {
+			if _, found := values["reservedMemory"]; !found {
+				errs = append(errs, fmt.Errorf("worker profile %q: resource managers: the %s memory manager policy requires reservedMemory", wp.Name, managers.MemoryManagerPolicy))
+			}
+		}
+		errs = append(errs, wp.conflictingValues(values, "resourceManagers",
+			"cpuManagerPolicy", "memoryManagerPolicy", "topologyManagerPolicy", "reservedSystemCPUs",
+		)...)
+	}
+	if wp.ResourceReservations != nil {
+		if err := wp.ResourceReservations.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("worker profile %q: resource reservations: %w", wp.Name, err))
+		}
+		errs = append(errs, wp.conflictingValues(values, "resourceReservations",
+			"kubeReserved", "systemReserved", "evictionHard",
+			"enforceNodeAllocatable", "kubeReservedCgroup", "systemReservedCgroup",
+		)...)
+	}
+	if wp.Seccomp != nil {
+		if err := wp.Seccomp.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("worker profile %q: seccomp: %w", wp.Name, err))
+		}
+		errs = append(errs, wp.conflictingValues(values, "seccomp", "seccompDefault")...)
+	}
+	if wp.RestartPolicies != nil {
+		if err := wp.RestartPolicies.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("worker profile %q: restart policies: %w", wp.Name, err))
+		}
+	}
+	for i, provider := range wp.ImageCredentialProviders {
+		if slices.ContainsFunc(wp.ImageCredentialProviders[:i], func(other ImageCredentialProvider) bool { return other.Name == provider.Name }) {
+			errs = append(errs, fmt.Errorf("worker profile %q: duplicate image credential provider %q", wp.Name, provider.Name))
+		} else if err := provider.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("worker profile %q: image credential provider %q: %w", wp.Name, provider.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// reservesCPU checks if CPUs are reserved for system daemons, either via the
+// resource reservations or via the values.
+func (wp *WorkerProfile) reservesCPU(values map[string]any) bool {
+	if r := wp.ResourceReservations; r != nil {
+		if _, found := r.KubeReserved["cpu"]; found {
+			return true
+		}
+		if _, found := r.SystemReserved["cpu"]; found {
+			return true
+		}
+	}
+	return reservesResource(values, "kubeReserved", "cpu") || reservesResource(values, "systemReserved", "cpu")
+}
+
+// reservesResource checks if the given kubelet reservation in the values
+// contains the given resource.
+func reservesResource(values map[string]any, reservation, resource string) bool {
+	reserved, ok := values[reservation].(map[string]any)
+	if !ok {
+		return false
+	}
+	_, found := reserved[resource]
+	return found
+}
+
+// conflictingValues reports the given kubelet configuration fields that are
+// set in the values, although they're managed by the given profile property.
+func (wp *WorkerProfile) conflictingValues(values map[string]any, property string, fields ...string) []error {
+	var errs []error
+	for _, field := range fields {
+		if _, found := values[field]; found {
+			errs = append(errs, fmt.Errorf("worker profile %q: field `%s` conflicts with %s", wp.Name, field, property))
+		}
+	}
+	return errs
+}
+
+// Validate checks that the grace periods are consistent.
+func (g *GracefulShutdown) Validate() error {
+	if g.GracePeriod.Duration <= 0 {
+		return errors.New("gracePeriod must be positive")
+	}
+	if g.CriticalPodsGracePeriod.Duration < 0 {
+		return errors.New("criticalPodsGracePeriod must not be negative")
+	}
+	if g.CriticalPodsGracePeriod.Duration > g.GracePeriod.Duration {
+		return errors.New("criticalPodsGracePeriod must not exceed gracePeriod")
+	}
+	return nil
+}
+
+// Validate checks that the check frequency is positive.
+func (s *WorkerStaticPods) Validate() error {
+	if s.CheckFrequency != nil && s.CheckFrequency.Duration <= 0 {
+		return errors.New("checkFrequency must be positive")
+	}
+	return nil
+}
+
+// Validate checks that the thresholds are consistent, taking the kubelet's
+// defaults into account for the ones that aren't specified.
+func (g *WorkerGarbageCollection) Validate() error {
+	var errs []error
+	high, low := int32(85), int32(80)
+	if percent := g.ImageHighThresholdPercent; percent != nil {
+		if *percent < 0 || *percent > 100 {
+			errs = append(errs, errors.New("imageHighThresholdPercent must be between 0 and 100"))
+		}
+		high = *percent
+	}
+	if percent := g.ImageLowThresholdPercent; percent != nil {
+		if *percent < 0 || *percent > 100 {
+			errs = append(errs, errors.New("imageLowThresholdPercent must be between 0 and 100"))
+		}
+		low = *percent
+	}
+	if low >= high {
+		errs = append(errs, fmt.Errorf("imageLowThresholdPercent (%d) must be lower than imageHighThresholdPercent (%d)", low, high))
+	}
+	if g.ImageMinimumAge != nil && g.ImageMinimumAge.Duration < 0 {
+		errs = append(errs, errors.New("imageMinimumAge must not be negative"))
+	}
+	if g.ContainerLogMaxSize != "" {
+		if size, err := resource.ParseQuantity(g.ContainerLogMaxSize); err != nil {
+			errs = append(errs, fmt.Errorf("invalid containerLogMaxSize: %w", err))
+		} else if size.Sign() <= 0 {
+			errs = append(errs, errors.New("containerLogMaxSize must be positive"))
+		}
+	}
+	if g.ContainerLogMaxFiles != nil && *g.ContainerLogMaxFiles < 2 {
+		errs = append(errs, errors.New("containerLogMaxFiles must be at least 2"))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks that the policies are supported and that the reserved
+// system CPUs are a valid CPU set.
+func (m *WorkerResourceManagers) Validate() error {
+	var errs []error
+	for _, policy := range []struct {
+		name, value string
+		supported   []string
+	}{
+		{"cpuManagerPolicy", m.CPUManagerPolicy, []string{CPUManagerPolicyNone, CPUManagerPolicyStatic}},
+		{"memoryManagerPolicy", m.MemoryManagerPolicy, []string{MemoryManagerPolicyNone, MemoryManagerPolicyStatic}},
+		{"topologyManagerPolicy", m.TopologyManagerPolicy, []string{
+			TopologyManagerPolicyNone, TopologyManagerPolicyBestEffort,
+			TopologyManagerPolicyRestricted, TopologyManagerPolicySingleNUMANode,
+		}},
+	} {
+		if policy.value != "" && !slices.Contains(policy.supported, policy.value) {
+			errs = append(errs, fmt.Errorf("unsupported %s %q, must be one of %s", policy.name, policy.value, strings.Join(policy.supported, ", ")))
+		}
+	}
+	if m.ReservedSystemCPUs != "" {
+		if cpus, err := cpuset.Parse(m.ReservedSystemCPUs); err != nil {
+			errs = append(errs, fmt.Errorf("invalid reservedSystemCPUs: %w", err))
+		} else if cpus.IsEmpty() {
+			errs = append(errs, errors.New("reservedSystemCPUs must not be empty"))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the reserved resources, the eviction thresholds and that the
+// enforced reservations are complete.
+func (r *WorkerResourceReservations) Validate() error {
+	var errs []error
+	for _, reservation := range []struct {
+		name     string
+		reserved map[string]string
+	}{
+		{"kubeReserved", r.KubeReserved},
+		{"systemReserved", r.SystemReserved},
+	} {
+		for _, name := range slices.Sorted(maps.Keys(reservation.reserved)) {
+			if !slices.Contains(reservableResources, name) {
+				errs = append(errs, fmt.Errorf("%s: unsupported resource %q, must be one of %s", reservation.name, name, strings.Join(reservableResources, ", ")))
+			} else if quantity, err := resource.ParseQuantity(reservation.reserved[name]); err != nil {
+				errs = append(errs, fmt.Errorf("%s[%s]: %w", reservation.name, name, err))
+			} else if quantity.Sign() < 0 {
+				errs = append(errs, fmt.Errorf("%s[%s] must not be negative", reservation.name, name))
+			}
+		}
+	}
+
+	for _, signal := range slices.Sorted(maps.Keys(r.EvictionHard)) {
+		threshold := r.EvictionHard[signal]
+		if !slices.Contains(evictionSignals, signal) {
+			errs = append(errs, fmt.Errorf("evictionHard: unsupported signal %q, must be one of %s", signal, strings.Join(evictionSignals, ", ")))
+		} else if percentage, isPercentage := strings.CutSuffix(threshold, "%"); isPercentage {
+			if value, err := strconv.ParseFloat(percentage, 64); err != nil || value < 0 || value > 100 {
+				errs = append(errs, fmt.Errorf("evictionHard[%s]: invalid percentage %q", signal, threshold))
+			}
+		} else if quantity, err := resource.ParseQuantity(threshold); err != nil {
+			errs = append(errs, fmt.Errorf("evictionHard[%s]: %w", signal, err))
+		} else if quantity.Sign() < 0 {
+			errs = append(errs, fmt.Errorf("evictionHard[%s] must not be negative", signal))
+		}
+	}
+
+	for i, enforced := range r.EnforceNodeAllocatable {
+		switch {
+		case slices.Contains(r.EnforceNodeAllocatable[:i], enforced):
+			errs = append(errs, fmt.Errorf("enforceNodeAllocatable: duplicate value %q", enforced))
+		case enforced == EnforceNodeAllocatableNone:
+			if len(r.EnforceNodeAllocatable) > 1 {
+				errs = append(errs, fmt.Errorf("enforceNodeAllocatable: %q can't be combined with other values", enforced))
+			}
+		case enforced == EnforceNodeAllocatableKubeReserved:
+			if len(r.KubeReserved) == 0 {
+				errs = append(errs, fmt.Errorf("enforceNodeAllocatable: %q requires kubeReserved", enforced))
+			}
+			if r.KubeReservedCgroup == "" {
+				errs = append(errs, fmt.Errorf("enforceNodeAllocatable: %q requires kubeReservedCgroup", enforced))
+			}
+		case enforced == EnforceNodeAllocatableSystemReserved:
+			if len(r.SystemReserved) == 0 {
+				errs = append(errs, fmt.Errorf("enforceNodeAllocatable: %q requires systemReserved", enforced))
+			}
+			if r.SystemReservedCgroup == "" {
+				errs = append(errs, fmt.Errorf("enforceNodeAllocatable: %q requires systemReservedCgroup", enforced))
+			}
+		case enforced != EnforceNodeAllocatablePods:
+			errs = append(errs, fmt.Errorf("enforceNodeAllocatable: unsupported value %q, must be one of %s", enforced, strings.Join([]string{
+				EnforceNodeAllocatablePods, EnforceNodeAllocatableKubeReserved, EnforceNodeAllocatableSystemReserved, EnforceNodeAllocatableNone,
+			}, ", ")))
+		}
+	}
+
+	for _, cgroup := range []struct{ name, value string }{
+		{"kubeReservedCgroup", r.KubeReservedCgroup},
+		{"systemReservedCgroup", r.SystemReservedCgroup},
+	} {
+		if cgroup.value == "" {
+			continue
+		}
+		if path.Clean("/"+cgroup.value) == "/" || slices.Contains(strings.Split(cgroup.value, "/"), "..") {
+			errs = append(errs, fmt.Errorf("invalid %s %q", cgroup.name, cgroup.value))
+		}
+	}
+	if slices.Contains(r.EnforceNodeAllocatable, EnforceNodeAllocatableKubeReserved) &&
+		slices.Contains(r.EnforceNodeAllocatable, EnforceNodeAllocatableSystemReserved) &&
+		path.Clean("/"+r.KubeReservedCgroup) == path.Clean("/"+r.SystemReservedCgroup) {
+		errs = append(errs, errors.New("kube-reserved and system-reserved must be enforced in different cgroups"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate checks that the profiles have unique names and are JSON objects
+// with a default action.
+func (s *WorkerSeccomp) Validate() error {
+	var errs []error
+	for i, profile := range s.Profiles {
+		if slices.ContainsFunc(s.Profiles[:i], func(other SeccompProfile) bool { return other.Name == profile.Name }) {
+			errs = append(errs, fmt.Errorf("duplicate profile %q", profile.Name))
+		} else if err := profile.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("profile %q: %w", profile.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WorkerRestartPolicies configures how the worker restarts the processes that
+// it supervises.
+type WorkerRestartPolicies struct {
+	// +optional
+	Kubelet *RestartPolicy `json:"kubelet,omitempty"`
+	// +optional
+	Containerd *RestartPolicy `json:"containerd,omitempty"`
+}
+
+// Validate validates the restart policies.
+func (p *WorkerRestartPolicies) Validate() error {
+	var errs []error
+	errs = append(errs, p.Kubelet.Validate(field.NewPath("kubelet"))...)
+	errs = append(errs, p.Containerd.Validate(field.NewPath("containerd"))...)
+	return errors.Join(errs...)
+}
+
+// Validate checks the name and the default action of the profile.
+func (p *SeccompProfile) Validate() error {
+	if !dropInNameRegex.MatchString(p.Name) {
+		return errors.New("name must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character")
+	}
+	if p.Profile == nil {
+		return errors.New("profile is required")
+	}
+
+	var profile struct {
+		DefaultAction string `json:"defaultAction"`
+	}
+	if err := json.Unmarshal(p.Profile.Raw, &profile); err != nil {
 		return err
 	}
+	if !strings.HasPrefix(profile.DefaultAction, "SCMP_ACT_") {
+		return fmt.Errorf("invalid defaultAction %q", profile.DefaultAction)
+	}
+	return nil
+}
+
+// Validate checks that the swap behavior is supported.
+func (s *WorkerSwap) Validate() error {
+	switch s.Behavior {
+	case SwapBehaviorNoSwap, SwapBehaviorLimitedSwap:
+		return nil
+	default:
+		return fmt.Errorf("unsupported behavior %q, must be one of %s or %s", s.Behavior, SwapBehaviorNoSwap, SwapBehaviorLimitedSwap)
+	}
+}
+
+// platformRegex matches platforms in the form of <os>-<arch>.
+var platformRegex = regexp.MustCompile(`^[a-z0-9]+-[a-z0-9]+$`)
+
+// sha256Regex matches hex encoded SHA256 hashes.
+var sha256Regex = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+// Validate checks the plugin's binaries and configuration.
+func (p *ImageCredentialProvider) Validate() error {
+	if !dropInNameRegex.MatchString(p.Name) {
+		return errors.New("name must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character")
+	}
+
+	var errs []error
+	if len(p.Binaries) == 0 {
+		errs = append(errs, errors.New("binaries are required"))
+	}
+	for _, platform := range slices.Sorted(maps.Keys(p.Binaries)) {
+		binary := p.Binaries[platform]
+		if !platformRegex.MatchString(platform) {
+			errs = append(errs, fmt.Errorf("binaries: invalid platform %q", platform))
+		}
+		if u, err := url.Parse(binary.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("binaries[%s]: url must be an absolute HTTP(S) URL", platform))
+		}
+		if !sha256Regex.MatchString(binary.Sha256) {
+			errs = append(errs, fmt.Errorf("binaries[%s]: sha256 must be a hex encoded SHA256 hash", platform))
+		}
+	}
+
+	if p.Config == nil {
+		errs = append(errs, errors.New("config is required"))
+	} else if _, err := p.CredentialProvider(); err != nil {
+		errs = append(errs, fmt.Errorf("config: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// CredentialProvider returns the kubelet's configuration for the plugin.
+func (p *ImageCredentialProvider) CredentialProvider() (*kubeletv1.CredentialProvider, error) {
+	var fields map[string]any
+	if err := json.Unmarshal(p.Config.Raw, &fields); err != nil {
+		return nil, err
+	}
+	if _, found := fields["name"]; found {
+		return nil, errors.New("field `name` is prohibited to override")
+	}
+
+	var provider kubeletv1.CredentialProvider
+	if err := yaml.UnmarshalStrict(p.Config.Raw, &provider); err != nil {
+		return nil, err
+	}
+	provider.Name = p.Name
+
+	switch {
+	case len(provider.MatchImages) == 0:
+		return nil, errors.New("matchImages is required")
+	case provider.DefaultCacheDuration == nil:
+		return nil, errors.New("defaultCacheDuration is required")
+	case provider.APIVersion == "":
+		return nil, errors.New("apiVersion is required")
+	}
 
+	return &provider, nil
+}
+
+// dropInNameRegex matches valid drop-in names, which are used as file names.
+var dropInNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Validate checks that the drop-in is a valid KubeletConfiguration fragment.
+func (d *KubeletConfigDropIn) Validate() error {
+	if !dropInNameRegex.MatchString(d.Name) {
+		return errors.New("name must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character")
+	}
+	if d.Config == nil {
+		return errors.New("config is required")
+	}
+
+	var parsed map[string]any
+	if err := json.Unmarshal(d.Config.Raw, &parsed); err != nil {
+		return err
+	}
 	for field := range parsed {
 		if _, found := lockedFields[field]; found {
-			return fmt.Errorf("field `%s` is prohibited to override in worker profile", field)
+			return fmt.Errorf("field `%s` is prohibited to override", field)
 		}
 	}
-	return nil
+
+	var config kubeletv1beta1.KubeletConfiguration
+	return yaml.UnmarshalStrict(d.Config.Raw, &config)
 }
@@ -6,9 +6,12 @@ package v1beta1
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 )
 
 // TestWorkerProfile worker profile test suite
@@ -73,3 +76,404 @@ func TestWorkerProfile(t *testing.T) {
 		}
 	})
 }
+
+func TestWorkerProfile_KubeletConfigDropIns(t *testing.T) {
+	dropIn := func(name, config string) KubeletConfigDropIn {
+		return KubeletConfigDropIn{Name: name, Config: &runtime.RawExtension{Raw: []byte(config)}}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		dropIns []KubeletConfigDropIn
+		err     string
+	}{
+		{"valid", []KubeletConfigDropIn{
+			dropIn("eviction", `{"evictionHard":{"memory.available":"500Mi"}}`),
+			dropIn("image-gc", `{"imageGCHighThresholdPercent":90}`),
+		}, ""},
+		{"invalid_name", []KubeletConfigDropIn{dropIn("Eviction", `{}`)}, "name must consist of"},
+		{"missing_config", []KubeletConfigDropIn{{Name: "eviction"}}, "config is required"},
+		{"duplicate", []KubeletConfigDropIn{dropIn("eviction", `{}`), dropIn("eviction", `{}`)}, `duplicate kubelet config drop-in "eviction"`},
+		{"locked_field", []KubeletConfigDropIn{dropIn("eviction", `{"kind":"Foo"}`)}, "field `kind` is prohibited"},
+		{"unknown_field", []KubeletConfigDropIn{dropIn("eviction", `{"evictionHardd":{}}`)}, `unknown field "evictionHardd"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := WorkerProfile{Name: "test", KubeletConfigDropIns: tc.dropIns}
+			err := profile.Validate()
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWorkerProfile_GracefulShutdown(t *testing.T) {
+	gracefulShutdown := func(gracePeriod, criticalPodsGracePeriod time.Duration) *GracefulShutdown {
+		return &GracefulShutdown{
+			GracePeriod:             metav1.Duration{Duration: gracePeriod},
+			CriticalPodsGracePeriod: metav1.Duration{Duration: criticalPodsGracePeriod},
+		}
+	}
+
+	for _, tc := range []struct {
+		name             string
+		gracefulShutdown *GracefulShutdown
+		values           string
+		err              string
+	}{
+		{"valid", gracefulShutdown(30*time.Second, 10*time.Second), "", ""},
+		{"without_critical_pods", gracefulShutdown(30*time.Second, 0), `{"shutdownGracePeriodByPodPriority":[]}`, ""},
+		{"zero_grace_period", gracefulShutdown(0, 0), "", "gracePeriod must be positive"},
+		{"negative_critical_pods", gracefulShutdown(30*time.Second, -time.Second), "", "criticalPodsGracePeriod must not be negative"},
+		{"critical_pods_exceeding", gracefulShutdown(30*time.Second, time.Minute), "", "criticalPodsGracePeriod must not exceed gracePeriod"},
+		{"conflicting_values", gracefulShutdown(30*time.Second, 0), `{"shutdownGracePeriod":"1m"}`, "field `shutdownGracePeriod` conflicts with gracefulShutdown"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := WorkerProfile{Name: "test", GracefulShutdown: tc.gracefulShutdown}
+			if tc.values != "" {
+				profile.Config = &runtime.RawExtension{Raw: []byte(tc.values)}
+			}
+			err := profile.Validate()
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWorkerProfile_StaticPods(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		staticPods *WorkerStaticPods
+		values     string
+		err        string
+	}{
+		{"enabled", &WorkerStaticPods{Enabled: true}, "", ""},
+		{"check_frequency", &WorkerStaticPods{Enabled: true, CheckFrequency: &metav1.Duration{Duration: 5 * time.Second}}, "", ""},
+		{"zero_check_frequency", &WorkerStaticPods{Enabled: true, CheckFrequency: &metav1.Duration{}}, "", "checkFrequency must be positive"},
+		{"conflicting_values", &WorkerStaticPods{Enabled: true}, `{"staticPodPath":"/etc/kubernetes/manifests"}`, "field `staticPodPath` conflicts with staticPods"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := WorkerProfile{Name: "test", StaticPods: tc.staticPods}
+			if tc.values != "" {
+				profile.Config = &runtime.RawExtension{Raw: []byte(tc.values)}
+			}
+			err := profile.Validate()
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWorkerProfile_Swap(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		swap   *WorkerSwap
+		values string
+		err    string
+	}{
+		{"limited_swap", &WorkerSwap{Behavior: SwapBehaviorLimitedSwap}, "", ""},
+		{"no_swap", &WorkerSwap{Behavior: SwapBehaviorNoSwap}, "", ""},
+		{"unsupported_behavior", &WorkerSwap{Behavior: "UnlimitedSwap"}, "", `unsupported behavior "UnlimitedSwap"`},
+		{"conflicting_values", &WorkerSwap{Behavior: SwapBehaviorLimitedSwap}, `{"memorySwap":{"swapBehavior":"NoSwap"}}`, "field `memorySwap` conflicts with swap"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := WorkerProfile{Name: "test", Swap: tc.swap}
+			if tc.values != "" {
+				profile.Config = &runtime.RawExtension{Raw: []byte(tc.values)}
+			}
+			err := profile.Validate()
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWorkerProfile_ImageCredentialProviders(t *testing.T) {
+	const hash = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	provider := func(name, config string) ImageCredentialProvider {
+		return ImageCredentialProvider{
+			Name:     name,
+			Binaries: map[string]ImageCredentialProviderBinary{"linux-amd64": {URL: "https://example.com/ecr-credential-provider", Sha256: hash}},
+			Config:   &runtime.RawExtension{Raw: []byte(config)},
+		}
+	}
+	const validConfig = `{"matchImages":["*.dkr.ecr.*.amazonaws.com"],"defaultCacheDuration":"12h","apiVersion":"credentialprovider.kubelet.k8s.io/v1"}`
+
+	invalidBinary := provider("ecr", validConfig)
+	invalidBinary.Binaries = map[string]ImageCredentialProviderBinary{"amd64": {URL: "example.com/ecr", Sha256: "abc"}}
+
+	for _, tc := range []struct {
+		name      string
+		providers []ImageCredentialProvider
+		errs      []string
+	}{
+		{"valid", []ImageCredentialProvider{provider("ecr-credential-provider", validConfig)}, nil},
+		{"duplicate", []ImageCredentialProvider{provider("ecr", validConfig), provider("ecr", validConfig)}, []string{`duplicate image credential provider "ecr"`}},
+		{"invalid_binary", []ImageCredentialProvider{invalidBinary}, []string{
+			`invalid platform "amd64"`,
+			"binaries[amd64]: url must be an absolute HTTP(S) URL",
+			"binaries[amd64]: sha256 must be a hex encoded SHA256 hash",
+		}},
+		{"name_in_config", []ImageCredentialProvider{provider("ecr", `{"name":"gcr"}`)}, []string{"field `name` is prohibited"}},
+		{"unknown_field", []ImageCredentialProvider{provider("ecr", `{"matchImage":["gcr.io"]}`)}, []string{`unknown field "matchImage"`}},
+		{"missing_match_images", []ImageCredentialProvider{provider("ecr", `{"defaultCacheDuration":"12h","apiVersion":"credentialprovider.kubelet.k8s.io/v1"}`)}, []string{"matchImages is required"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := WorkerProfile{Name: "test", ImageCredentialProviders: tc.providers}
+			err := profile.Validate()
+			if len(tc.errs) == 0 {
+				assert.NoError(t, err)
+			}
+			for _, expected := range tc.errs {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+func TestWorkerProfile_GarbageCollection(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		gc     WorkerGarbageCollection
+		values string
+		errs   []string
+	}{
+		{"valid", WorkerGarbageCollection{
+			ImageHighThresholdPercent: ptr.To[int32](75),
+			ImageLowThresholdPercent:  ptr.To[int32](60),
+			ImageMinimumAge:           &metav1.Duration{Duration: time.Minute},
+			ContainerLogMaxSize:       "5Mi",
+			ContainerLogMaxFiles:      ptr.To[int32](3),
+		}, "", nil},
+		{"out_of_range", WorkerGarbageCollection{
+			ImageHighThresholdPercent: ptr.To[int32](101),
+			ImageLowThresholdPercent:  ptr.To[int32](-1),
+		}, "", []string{
+			"imageHighThresholdPercent must be between 0 and 100",
+			"imageLowThresholdPercent must be between 0 and 100",
+		}},
+		{"low_above_default_high", WorkerGarbageCollection{ImageLowThresholdPercent: ptr.To[int32](90)}, "", []string{
+			"imageLowThresholdPercent (90) must be lower than imageHighThresholdPercent (85)",
+		}},
+		{"invalid_container_logs", WorkerGarbageCollection{
+			ContainerLogMaxSize:  "ten",
+			ContainerLogMaxFiles: ptr.To[int32](1),
+		}, "", []string{
+			"invalid containerLogMaxSize",
+			"containerLogMaxFiles must be at least 2",
+		}},
+		{"negative_minimum_age", WorkerGarbageCollection{ImageMinimumAge: &metav1.Duration{Duration: -time.Second}}, "", []string{
+			"imageMinimumAge must not be negative",
+		}},
+		{"conflicting_values", WorkerGarbageCollection{}, `{"containerLogMaxSize":"1Mi"}`, []string{
+			"field `containerLogMaxSize` conflicts with garbageCollection",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := WorkerProfile{Name: "test", GarbageCollection: &tc.gc}
+			if tc.values != "" {
+				profile.Config = &runtime.RawExtension{Raw: []byte(tc.values)}
+			}
+			err := profile.Validate()
+			if len(tc.errs) == 0 {
+				assert.NoError(t, err)
+			}
+			for _, expected := range tc.errs {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+func TestWorkerProfile_ResourceManagers(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		managers WorkerResourceManagers
+		values   string
+		errs     []string
+	}{
+		{"valid", WorkerResourceManagers{
+			CPUManagerPolicy:      CPUManagerPolicyStatic,
+			TopologyManagerPolicy: TopologyManagerPolicySingleNUMANode,
+			ReservedSystemCPUs:    "0-1,6",
+		}, "", nil},
+		{"kube_reserved_cpu", WorkerResourceManagers{CPUManagerPolicy: CPUManagerPolicyStatic}, `{"kubeReserved":{"cpu":"500m"}}`, nil},
+		{"static_memory_manager", WorkerResourceManagers{MemoryManagerPolicy: MemoryManagerPolicyStatic}, `{"reservedMemory":[{"numaNode":0,"limits":{"memory":"100Mi"}}]}`, nil},
+		{"unsupported_policies", WorkerResourceManagers{
+			CPUManagerPolicy:      "dynamic",
+			MemoryManagerPolicy:   "static",
+			TopologyManagerPolicy: "strict",
+		}, "", []string{
+			`unsupported cpuManagerPolicy "dynamic", must be one of none, static`,
+			`unsupported memoryManagerPolicy "static", must be one of None, Static`,
+			`unsupported topologyManagerPolicy "strict", must be one of none, best-effort, restricted, single-numa-node`,
+		}},
+		{"invalid_reserved_system_cpus", WorkerResourceManagers{ReservedSystemCPUs: "0-"}, "", []string{"invalid reservedSystemCPUs"}},
+		{"static_cpu_manager_without_reservation", WorkerResourceManagers{CPUManagerPolicy: CPUManagerPolicyStatic}, "", []string{
+			"the static CPU manager policy requires reservedSystemCPUs or a CPU reservation in kubeReserved or systemReserved",
+		}},
+		{"static_memory_manager_without_reserved_memory", WorkerResourceManagers{MemoryManagerPolicy: MemoryManagerPolicyStatic}, "", []string{
+			"the Static memory manager policy requires reservedMemory",
+		}},
+		{"conflicting_values", WorkerResourceManagers{}, `{"topologyManagerPolicy":"restricted"}`, []string{
+			"field `topologyManagerPolicy` conflicts with resourceManagers",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := WorkerProfile{Name: "test", ResourceManagers: &tc.managers}
+			if tc.values != "" {
+				profile.Config = &runtime.RawExtension{Raw: []byte(tc.values)}
+			}
+			err := profile.Validate()
+			if len(tc.errs) == 0 {
+				assert.NoError(t, err)
+			}
+			for _, expected := range tc.errs {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+func TestWorkerProfile_ResourceReservations(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		reservations WorkerResourceReservations
+		values       string
+		errs         []string
+	}{
+		{"valid", WorkerResourceReservations{
+			KubeReserved:           map[string]string{"cpu": "500m", "memory": "1Gi"},
+			SystemReserved:         map[string]string{"memory": "512Mi", "pid": "1000"},
+			EvictionHard:           map[string]string{"memory.available": "500Mi", "nodefs.available": "10%"},
+			EnforceNodeAllocatable: []string{"pods", "kube-reserved", "system-reserved"},
+			KubeReservedCgroup:     "k0s-kube.slice",
+			SystemReservedCgroup:   "k0s-system.slice",
+		}, "", nil},
+		{"unsupported_resources", WorkerResourceReservations{
+			KubeReserved:   map[string]string{"gpu": "1", "memory": "lots"},
+			SystemReserved: map[string]string{"cpu": "-1"},
+		}, "", []string{
+			`kubeReserved: unsupported resource "gpu", must be one of cpu, memory, ephemeral-storage, pid`,
+			"kubeReserved[memory]: quantities must match the regular expression",
+			"systemReserved[cpu] must not be negative",
+		}},
+		{"invalid_eviction_thresholds", WorkerResourceReservations{
+			EvictionHard: map[string]string{"memory.free": "1Gi", "nodefs.available": "110%", "imagefs.available": "lots"},
+		}, "", []string{
+			`evictionHard: unsupported signal "memory.free"`,
+			`evictionHard[nodefs.available]: invalid percentage "110%"`,
+			"evictionHard[imagefs.available]: quantities must match the regular expression",
+		}},
+		{"incomplete_enforcement", WorkerResourceReservations{
+			EnforceNodeAllocatable: []string{"kube-reserved", "system-reserved", "pods", "pods", "everything"},
+		}, "", []string{
+			`enforceNodeAllocatable: "kube-reserved" requires kubeReserved`,
+			`enforceNodeAllocatable: "kube-reserved" requires kubeReservedCgroup`,
+			`enforceNodeAllocatable: "system-reserved" requires systemReserved`,
+			`enforceNodeAllocatable: "system-reserved" requires systemReservedCgroup`,
+			`enforceNodeAllocatable: duplicate value "pods"`,
+			`enforceNodeAllocatable: unsupported value "everything", must be one of pods, kube-reserved, system-reserved, none`,
+		}},
+		{"none_combined", WorkerResourceReservations{EnforceNodeAllocatable: []string{"none", "pods"}}, "", []string{
+			`enforceNodeAllocatable: "none" can't be combined with other values`,
+		}},
+		{"invalid_cgroups", WorkerResourceReservations{KubeReservedCgroup: "/", SystemReservedCgroup: "../escape.slice"}, "", []string{
+			`invalid kubeReservedCgroup "/"`,
+			`invalid systemReservedCgroup "../escape.slice"`,
+		}},
+		{"same_cgroups", WorkerResourceReservations{
+			KubeReserved:           map[string]string{"cpu": "500m"},
+			SystemReserved:         map[string]string{"cpu": "500m"},
+			EnforceNodeAllocatable: []string{"kube-reserved", "system-reserved"},
+			KubeReservedCgroup:     "kube.slice",
+			SystemReservedCgroup:   "/kube.slice",
+		}, "", []string{
+			"kube-reserved and system-reserved must be enforced in different cgroups",
+		}},
+		{"conflicting_values", WorkerResourceReservations{}, `{"kubeReserved":{"cpu":"500m"},"enforceNodeAllocatable":["pods"]}`, []string{
+			"field `kubeReserved` conflicts with resourceReservations",
+			"field `enforceNodeAllocatable` conflicts with resourceReservations",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := WorkerProfile{Name: "test", ResourceReservations: &tc.reservations}
+			if tc.values != "" {
+				profile.Config = &runtime.RawExtension{Raw: []byte(tc.values)}
+			}
+			err := profile.Validate()
+			if len(tc.errs) == 0 {
+				assert.NoError(t, err)
+			}
+			for _, expected := range tc.errs {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+
+	t.Run("static_cpu_manager", func(t *testing.T) {
+		profile := WorkerProfile{
+			Name:                 "test",
+			ResourceManagers:     &WorkerResourceManagers{CPUManagerPolicy: CPUManagerPolicyStatic},
+			ResourceReservations: &WorkerResourceReservations{SystemReserved: map[string]string{"cpu": "1"}},
+		}
+		assert.NoError(t, profile.Validate())
+	})
+}
+
+func TestWorkerProfile_Seccomp(t *testing.T) {
+	profile := func(name, profile string) SeccompProfile {
+		return SeccompProfile{Name: name, Profile: &runtime.RawExtension{Raw: []byte(profile)}}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		seccomp WorkerSeccomp
+		values  string
+		errs    []string
+	}{
+		{"valid", WorkerSeccomp{Default: true, Profiles: []SeccompProfile{
+			profile("audit", `{"defaultAction":"SCMP_ACT_LOG"}`),
+			profile("deny-mounts", `{"defaultAction":"SCMP_ACT_ALLOW","syscalls":[{"names":["mount"],"action":"SCMP_ACT_ERRNO"}]}`),
+		}}, "", nil},
+		{"duplicate", WorkerSeccomp{Profiles: []SeccompProfile{
+			profile("audit", `{"defaultAction":"SCMP_ACT_LOG"}`),
+			profile("audit", `{"defaultAction":"SCMP_ACT_LOG"}`),
+		}}, "", []string{`duplicate profile "audit"`}},
+		{"invalid_name", WorkerSeccomp{Profiles: []SeccompProfile{profile("audit.json", `{"defaultAction":"SCMP_ACT_LOG"}`)}}, "", []string{
+			`profile "audit.json": name must consist of lower case alphanumeric characters`,
+		}},
+		{"missing_profile", WorkerSeccomp{Profiles: []SeccompProfile{{Name: "audit"}}}, "", []string{`profile "audit": profile is required`}},
+		{"invalid_default_action", WorkerSeccomp{Profiles: []SeccompProfile{profile("audit", `{"syscalls":[]}`)}}, "", []string{
+			`profile "audit": invalid defaultAction ""`,
+		}},
+		{"conflicting_values", WorkerSeccomp{Default: true}, `{"seccompDefault":true}`, []string{
+			"field `seccompDefault` conflicts with seccomp",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := WorkerProfile{Name: "test", Seccomp: &tc.seccomp}
+			if tc.values != "" {
+				profile.Config = &runtime.RawExtension{Raw: []byte(tc.values)}
+			}
+			err := profile.Validate()
+			if len(tc.errs) == 0 {
+				assert.NoError(t, err)
+			}
+			for _, expected := range tc.errs {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@
 package v1beta1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -31,6 +32,26 @@ func (in *APISpec) DeepCopyInto(out *APISpec) {
 		*out = new(CA)
 		**out = **in
 	}
+	if in.CloudIdentityJoin != nil {
+		in, out := &in.CloudIdentityJoin, &out.CloudIdentityJoin
+		*out = new(CloudIdentityJoin)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JoinRequests != nil {
+		in, out := &in.JoinRequests, &out.JoinRequests
+		*out = new(JoinRequests)
+		**out = **in
+	}
+	if in.RestartPolicy != nil {
+		in, out := &in.RestartPolicy, &out.RestartPolicy
+		*out = new(RestartPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ProcessResources)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APISpec.
@@ -43,6 +64,56 @@ func (in *APISpec) DeepCopy() *APISpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSIdentityJoin) DeepCopyInto(out *AWSIdentityJoin) {
+	*out = *in
+	if in.AccountIDs != nil {
+		in, out := &in.AccountIDs, &out.AccountIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxDocumentAge != nil {
+		in, out := &in.MaxDocumentAge, &out.MaxDocumentAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RoleNames != nil {
+		in, out := &in.RoleNames, &out.RoleNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSIdentityJoin.
+func (in *AWSIdentityJoin) DeepCopy() *AWSIdentityJoin {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSIdentityJoin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSpec.
+func (in *BackupSpec) DeepCopy() *BackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackwardCompatibleDuration) DeepCopyInto(out *BackwardCompatibleDuration) {
 	*out = *in
@@ -204,6 +275,66 @@ func (in ChartsSettings) DeepCopy() ChartsSettings {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudIdentityJoin) DeepCopyInto(out *CloudIdentityJoin) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSIdentityJoin)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPIdentityJoin)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudIdentityJoin.
+func (in *CloudIdentityJoin) DeepCopy() *CloudIdentityJoin {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudIdentityJoin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudIdentityJoinRequest) DeepCopyInto(out *CloudIdentityJoinRequest) {
+	*out = *in
+	if in.CallerIdentityRequest != nil {
+		in, out := &in.CallerIdentityRequest, &out.CallerIdentityRequest
+		*out = new(PresignedRequest)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudIdentityJoinRequest.
+func (in *CloudIdentityJoinRequest) DeepCopy() *CloudIdentityJoinRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudIdentityJoinRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudIdentityJoinResponse) DeepCopyInto(out *CloudIdentityJoinResponse) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudIdentityJoinResponse.
+func (in *CloudIdentityJoinResponse) DeepCopy() *CloudIdentityJoinResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudIdentityJoinResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 	*out = *in
@@ -217,7 +348,7 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(ClusterConfigStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -274,6 +405,25 @@ func (in *ClusterConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterConfigStatus) DeepCopyInto(out *ClusterConfigStatus) {
 	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FieldConditions != nil {
+		in, out := &in.FieldConditions, &out.FieldConditions
+		*out = make([]FieldCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.K0sFeatureGates != nil {
+		in, out := &in.K0sFeatureGates, &out.K0sFeatureGates
+		*out = make([]K0sFeatureGateStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConfigStatus.
@@ -433,6 +583,16 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.K0sFeatureGates != nil {
+		in, out := &in.K0sFeatureGates, &out.K0sFeatureGates
+		*out = make(K0sFeatureGates, len(*in))
+		copy(*out, *in)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -465,6 +625,22 @@ func (in *ClusterTelemetry) DeepCopy() *ClusterTelemetry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+	in.LastReconcileTime.DeepCopyInto(&out.LastReconcileTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneLoadBalancingSpec) DeepCopyInto(out *ControlPlaneLoadBalancingSpec) {
 	*out = *in
@@ -485,6 +661,71 @@ func (in *ControlPlaneLoadBalancingSpec) DeepCopy() *ControlPlaneLoadBalancingSp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerManagerConcurrentSyncs) DeepCopyInto(out *ControllerManagerConcurrentSyncs) {
+	*out = *in
+	if in.CronJob != nil {
+		in, out := &in.CronJob, &out.CronJob
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DaemonSet != nil {
+		in, out := &in.DaemonSet, &out.DaemonSet
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GarbageCollector != nil {
+		in, out := &in.GarbageCollector, &out.GarbageCollector
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReplicaSet != nil {
+		in, out := &in.ReplicaSet, &out.ReplicaSet
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StatefulSet != nil {
+		in, out := &in.StatefulSet, &out.StatefulSet
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerManagerConcurrentSyncs.
+func (in *ControllerManagerConcurrentSyncs) DeepCopy() *ControllerManagerConcurrentSyncs {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerManagerConcurrentSyncs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerManagerSpec) DeepCopyInto(out *ControllerManagerSpec) {
 	*out = *in
@@ -495,6 +736,26 @@ func (in *ControllerManagerSpec) DeepCopyInto(out *ControllerManagerSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.NodeMonitorGracePeriod != nil {
+		in, out := &in.NodeMonitorGracePeriod, &out.NodeMonitorGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TerminatedPodGCThreshold != nil {
+		in, out := &in.TerminatedPodGCThreshold, &out.TerminatedPodGCThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConcurrentSyncs != nil {
+		in, out := &in.ConcurrentSyncs, &out.ConcurrentSyncs
+		*out = new(ControllerManagerConcurrentSyncs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ProcessResources)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerManagerSpec.
@@ -547,6 +808,36 @@ func (in *EnvoyProxy) DeepCopy() *EnvoyProxy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdAlarmRecovery) DeepCopyInto(out *EtcdAlarmRecovery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdAlarmRecovery.
+func (in *EtcdAlarmRecovery) DeepCopy() *EtcdAlarmRecovery {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdAlarmRecovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdAutoCompaction) DeepCopyInto(out *EtcdAutoCompaction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdAutoCompaction.
+func (in *EtcdAutoCompaction) DeepCopy() *EtcdAutoCompaction {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdAutoCompaction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EtcdConfig) DeepCopyInto(out *EtcdConfig) {
 	*out = *in
@@ -567,6 +858,61 @@ func (in *EtcdConfig) DeepCopyInto(out *EtcdConfig) {
 		*out = new(CA)
 		**out = **in
 	}
+	if in.Defragmentation != nil {
+		in, out := &in.Defragmentation, &out.Defragmentation
+		*out = new(EtcdDefragmentation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemberEviction != nil {
+		in, out := &in.MemberEviction, &out.MemberEviction
+		*out = new(EtcdMemberEviction)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(EtcdMetrics)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoCompaction != nil {
+		in, out := &in.AutoCompaction, &out.AutoCompaction
+		*out = new(EtcdAutoCompaction)
+		**out = **in
+	}
+	if in.QuotaBackendBytes != nil {
+		in, out := &in.QuotaBackendBytes, &out.QuotaBackendBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.HeartbeatInterval != nil {
+		in, out := &in.HeartbeatInterval, &out.HeartbeatInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ElectionTimeout != nil {
+		in, out := &in.ElectionTimeout, &out.ElectionTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SnapshotCount != nil {
+		in, out := &in.SnapshotCount, &out.SnapshotCount
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.AlarmRecovery != nil {
+		in, out := &in.AlarmRecovery, &out.AlarmRecovery
+		*out = new(EtcdAlarmRecovery)
+		**out = **in
+	}
+	if in.RestartPolicy != nil {
+		in, out := &in.RestartPolicy, &out.RestartPolicy
+		*out = new(RestartPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ProcessResources)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdConfig.
@@ -579,6 +925,78 @@ func (in *EtcdConfig) DeepCopy() *EtcdConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdDefragmentation) DeepCopyInto(out *EtcdDefragmentation) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.DBSizeThreshold != nil {
+		in, out := &in.DBSizeThreshold, &out.DBSizeThreshold
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdDefragmentation.
+func (in *EtcdDefragmentation) DeepCopy() *EtcdDefragmentation {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdDefragmentation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdMemberEviction) DeepCopyInto(out *EtcdMemberEviction) {
+	*out = *in
+	out.UnreachableTimeout = in.UnreachableTimeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdMemberEviction.
+func (in *EtcdMemberEviction) DeepCopy() *EtcdMemberEviction {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdMemberEviction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdMetrics) DeepCopyInto(out *EtcdMetrics) {
+	*out = *in
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(EtcdMetricsProxy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdMetrics.
+func (in *EtcdMetrics) DeepCopy() *EtcdMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdMetricsProxy) DeepCopyInto(out *EtcdMetricsProxy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdMetricsProxy.
+func (in *EtcdMetricsProxy) DeepCopy() *EtcdMetricsProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdMetricsProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EtcdRequest) DeepCopyInto(out *EtcdRequest) {
 	*out = *in
@@ -677,14 +1095,77 @@ func (in FeatureGates) DeepCopy() FeatureGates {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HelmExtensions) DeepCopyInto(out *HelmExtensions) {
+func (in *FieldCondition) DeepCopyInto(out *FieldCondition) {
 	*out = *in
-	if in.Repositories != nil {
-		in, out := &in.Repositories, &out.Repositories
-		*out = make(RepositoriesSettings, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldCondition.
+func (in *FieldCondition) DeepCopy() *FieldCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPIdentityJoin) DeepCopyInto(out *GCPIdentityJoin) {
+	*out = *in
+	if in.ProjectIDs != nil {
+		in, out := &in.ProjectIDs, &out.ProjectIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPIdentityJoin.
+func (in *GCPIdentityJoin) DeepCopy() *GCPIdentityJoin {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPIdentityJoin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GracefulShutdown) DeepCopyInto(out *GracefulShutdown) {
+	*out = *in
+	out.GracePeriod = in.GracePeriod
+	out.CriticalPodsGracePeriod = in.CriticalPodsGracePeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GracefulShutdown.
+func (in *GracefulShutdown) DeepCopy() *GracefulShutdown {
+	if in == nil {
+		return nil
+	}
+	out := new(GracefulShutdown)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmExtensions) DeepCopyInto(out *HelmExtensions) {
+	*out = *in
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make(RepositoriesSettings, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Charts != nil {
 		in, out := &in.Charts, &out.Charts
@@ -705,6 +1186,48 @@ func (in *HelmExtensions) DeepCopy() *HelmExtensions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageCredentialProvider) DeepCopyInto(out *ImageCredentialProvider) {
+	*out = *in
+	if in.Binaries != nil {
+		in, out := &in.Binaries, &out.Binaries
+		*out = make(map[string]ImageCredentialProviderBinary, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageCredentialProvider.
+func (in *ImageCredentialProvider) DeepCopy() *ImageCredentialProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageCredentialProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageCredentialProviderBinary) DeepCopyInto(out *ImageCredentialProviderBinary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageCredentialProviderBinary.
+func (in *ImageCredentialProviderBinary) DeepCopy() *ImageCredentialProviderBinary {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageCredentialProviderBinary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 	*out = *in
@@ -740,6 +1263,145 @@ func (in *InstallSpec) DeepCopy() *InstallSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JoinCodeResponse) DeepCopyInto(out *JoinCodeResponse) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JoinCodeResponse.
+func (in *JoinCodeResponse) DeepCopy() *JoinCodeResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(JoinCodeResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JoinRequest) DeepCopyInto(out *JoinRequest) {
+	*out = *in
+	if in.CSR != nil {
+		in, out := &in.CSR, &out.CSR
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JoinRequest.
+func (in *JoinRequest) DeepCopy() *JoinRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(JoinRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JoinRequestResponse) DeepCopyInto(out *JoinRequestResponse) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JoinRequestResponse.
+func (in *JoinRequestResponse) DeepCopy() *JoinRequestResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(JoinRequestResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JoinRequestStatus) DeepCopyInto(out *JoinRequestStatus) {
+	*out = *in
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CACertificate != nil {
+		in, out := &in.CACertificate, &out.CACertificate
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JoinRequestStatus.
+func (in *JoinRequestStatus) DeepCopy() *JoinRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JoinRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JoinRequests) DeepCopyInto(out *JoinRequests) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JoinRequests.
+func (in *JoinRequests) DeepCopy() *JoinRequests {
+	if in == nil {
+		return nil
+	}
+	out := new(JoinRequests)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K0sFeatureGate) DeepCopyInto(out *K0sFeatureGate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K0sFeatureGate.
+func (in *K0sFeatureGate) DeepCopy() *K0sFeatureGate {
+	if in == nil {
+		return nil
+	}
+	out := new(K0sFeatureGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K0sFeatureGateStatus) DeepCopyInto(out *K0sFeatureGateStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K0sFeatureGateStatus.
+func (in *K0sFeatureGateStatus) DeepCopy() *K0sFeatureGateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(K0sFeatureGateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in K0sFeatureGates) DeepCopyInto(out *K0sFeatureGates) {
+	{
+		in := &in
+		*out = make(K0sFeatureGates, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K0sFeatureGates.
+func (in K0sFeatureGates) DeepCopy() K0sFeatureGates {
+	if in == nil {
+		return nil
+	}
+	out := new(K0sFeatureGates)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeepalivedSpec) DeepCopyInto(out *KeepalivedSpec) {
 	*out = *in
@@ -953,6 +1615,26 @@ func (in *KubeRouterImageSpec) DeepCopy() *KubeRouterImageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletConfigDropIn) DeepCopyInto(out *KubeletConfigDropIn) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletConfigDropIn.
+func (in *KubeletConfigDropIn) DeepCopy() *KubeletConfigDropIn {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletConfigDropIn)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Network) DeepCopyInto(out *Network) {
 	*out = *in
@@ -1035,6 +1717,62 @@ func (in RepositoriesSettings) DeepCopy() RepositoriesSettings {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PresignedRequest) DeepCopyInto(out *PresignedRequest) {
+	*out = *in
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PresignedRequest.
+func (in *PresignedRequest) DeepCopy() *PresignedRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(PresignedRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessResources) DeepCopyInto(out *ProcessResources) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessResources.
+func (in *ProcessResources) DeepCopy() *ProcessResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Repository) DeepCopyInto(out *Repository) {
 	*out = *in
@@ -1055,6 +1793,36 @@ func (in *Repository) DeepCopy() *Repository {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartPolicy) DeepCopyInto(out *RestartPolicy) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartPolicy.
+func (in *RestartPolicy) DeepCopy() *RestartPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchedulerSpec) DeepCopyInto(out *SchedulerSpec) {
 	*out = *in
@@ -1065,6 +1833,16 @@ func (in *SchedulerSpec) DeepCopyInto(out *SchedulerSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ProcessResources)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerSpec.
@@ -1077,6 +1855,26 @@ func (in *SchedulerSpec) DeepCopy() *SchedulerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfile) DeepCopyInto(out *SeccompProfile) {
+	*out = *in
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfile.
+func (in *SeccompProfile) DeepCopy() *SeccompProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageExtension) DeepCopyInto(out *StorageExtension) {
 	*out = *in
@@ -1213,6 +2011,76 @@ func (in VirtualServers) DeepCopy() VirtualServers {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerGPURuntimes) DeepCopyInto(out *WorkerGPURuntimes) {
+	*out = *in
+	if in.NVIDIA != nil {
+		in, out := &in.NVIDIA, &out.NVIDIA
+		*out = new(WorkerNVIDIARuntime)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerGPURuntimes.
+func (in *WorkerGPURuntimes) DeepCopy() *WorkerGPURuntimes {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerGPURuntimes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerGarbageCollection) DeepCopyInto(out *WorkerGarbageCollection) {
+	*out = *in
+	if in.ImageHighThresholdPercent != nil {
+		in, out := &in.ImageHighThresholdPercent, &out.ImageHighThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ImageLowThresholdPercent != nil {
+		in, out := &in.ImageLowThresholdPercent, &out.ImageLowThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ImageMinimumAge != nil {
+		in, out := &in.ImageMinimumAge, &out.ImageMinimumAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ContainerLogMaxFiles != nil {
+		in, out := &in.ContainerLogMaxFiles, &out.ContainerLogMaxFiles
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerGarbageCollection.
+func (in *WorkerGarbageCollection) DeepCopy() *WorkerGarbageCollection {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerGarbageCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerNVIDIARuntime) DeepCopyInto(out *WorkerNVIDIARuntime) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerNVIDIARuntime.
+func (in *WorkerNVIDIARuntime) DeepCopy() *WorkerNVIDIARuntime {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerNVIDIARuntime)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerProfile) DeepCopyInto(out *WorkerProfile) {
 	*out = *in
@@ -1221,6 +2089,77 @@ func (in *WorkerProfile) DeepCopyInto(out *WorkerProfile) {
 		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Sysctls != nil {
+		in, out := &in.Sysctls, &out.Sysctls
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KernelModules != nil {
+		in, out := &in.KernelModules, &out.KernelModules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KubeletConfigDropIns != nil {
+		in, out := &in.KubeletConfigDropIns, &out.KubeletConfigDropIns
+		*out = make([]KubeletConfigDropIn, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GracefulShutdown != nil {
+		in, out := &in.GracefulShutdown, &out.GracefulShutdown
+		*out = new(GracefulShutdown)
+		**out = **in
+	}
+	if in.StaticPods != nil {
+		in, out := &in.StaticPods, &out.StaticPods
+		*out = new(WorkerStaticPods)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Swap != nil {
+		in, out := &in.Swap, &out.Swap
+		*out = new(WorkerSwap)
+		**out = **in
+	}
+	if in.ImageCredentialProviders != nil {
+		in, out := &in.ImageCredentialProviders, &out.ImageCredentialProviders
+		*out = make([]ImageCredentialProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GPURuntimes != nil {
+		in, out := &in.GPURuntimes, &out.GPURuntimes
+		*out = new(WorkerGPURuntimes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GarbageCollection != nil {
+		in, out := &in.GarbageCollection, &out.GarbageCollection
+		*out = new(WorkerGarbageCollection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceManagers != nil {
+		in, out := &in.ResourceManagers, &out.ResourceManagers
+		*out = new(WorkerResourceManagers)
+		**out = **in
+	}
+	if in.ResourceReservations != nil {
+		in, out := &in.ResourceReservations, &out.ResourceReservations
+		*out = new(WorkerResourceReservations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Seccomp != nil {
+		in, out := &in.Seccomp, &out.Seccomp
+		*out = new(WorkerSeccomp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestartPolicies != nil {
+		in, out := &in.RestartPolicies, &out.RestartPolicies
+		*out = new(WorkerRestartPolicies)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerProfile.
@@ -1253,3 +2192,141 @@ func (in WorkerProfiles) DeepCopy() WorkerProfiles {
 	in.DeepCopyInto(out)
 	return *out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerResourceManagers) DeepCopyInto(out *WorkerResourceManagers) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerResourceManagers.
+func (in *WorkerResourceManagers) DeepCopy() *WorkerResourceManagers {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerResourceManagers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerResourceReservations) DeepCopyInto(out *WorkerResourceReservations) {
+	*out = *in
+	if in.KubeReserved != nil {
+		in, out := &in.KubeReserved, &out.KubeReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SystemReserved != nil {
+		in, out := &in.SystemReserved, &out.SystemReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictionHard != nil {
+		in, out := &in.EvictionHard, &out.EvictionHard
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnforceNodeAllocatable != nil {
+		in, out := &in.EnforceNodeAllocatable, &out.EnforceNodeAllocatable
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerResourceReservations.
+func (in *WorkerResourceReservations) DeepCopy() *WorkerResourceReservations {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerResourceReservations)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerRestartPolicies) DeepCopyInto(out *WorkerRestartPolicies) {
+	*out = *in
+	if in.Kubelet != nil {
+		in, out := &in.Kubelet, &out.Kubelet
+		*out = new(RestartPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Containerd != nil {
+		in, out := &in.Containerd, &out.Containerd
+		*out = new(RestartPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerRestartPolicies.
+func (in *WorkerRestartPolicies) DeepCopy() *WorkerRestartPolicies {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerRestartPolicies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerSeccomp) DeepCopyInto(out *WorkerSeccomp) {
+	*out = *in
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]SeccompProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerSeccomp.
+func (in *WorkerSeccomp) DeepCopy() *WorkerSeccomp {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerSeccomp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerStaticPods) DeepCopyInto(out *WorkerStaticPods) {
+	*out = *in
+	if in.CheckFrequency != nil {
+		in, out := &in.CheckFrequency, &out.CheckFrequency
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerStaticPods.
+func (in *WorkerStaticPods) DeepCopy() *WorkerStaticPods {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerStaticPods)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerSwap) DeepCopyInto(out *WorkerSwap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerSwap.
+func (in *WorkerSwap) DeepCopy() *WorkerSwap {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerSwap)
+	in.DeepCopyInto(out)
+	return out
+}
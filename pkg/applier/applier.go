@@ -23,6 +23,16 @@ func FindManifestFilesInDir(dir string) ([]string, error) {
 	return filepath.Glob(filepath.Join(dir, manifestFilePattern))
 }
 
+// ReadManifests reads all the resources from the manifest files in the given
+// directory, as they'd be applied by the stack applier.
+func ReadManifests(dir string) ([]*unstructured.Unstructured, error) {
+	files, err := FindManifestFilesInDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return parseManifestFiles(files)
+}
+
 // Applier manages all the "static" manifests and applies them on the k8s API
 type Applier struct {
 	Name string
@@ -55,7 +65,7 @@ func (a *Applier) Apply(ctx context.Context) error {
 		return err
 	}
 
-	resources, err := a.parseFiles(files)
+	resources, err := parseManifestFiles(files)
 	if err != nil {
 		return err
 	}
@@ -82,7 +92,7 @@ func (a *Applier) Delete(ctx context.Context) error {
 	return stack.Apply(ctx, true)
 }
 
-func (a *Applier) parseFiles(files []string) ([]*unstructured.Unstructured, error) {
+func parseManifestFiles(files []string) ([]*unstructured.Unstructured, error) {
 	var resources []*unstructured.Unstructured
 	if len(files) == 0 {
 		return resources, nil
@@ -406,7 +406,7 @@ func (s *Stack) patchResource(ctx context.Context, drClient dynamic.ResourceInte
 }
 
 func (s *Stack) prepareResource(resource *unstructured.Unstructured) {
-	checksum := resourceChecksum(resource)
+	checksum := ResourceChecksum(resource)
 	lastAppliedConfig, _ := resource.MarshalJSON()
 
 	labels := resource.GetLabels()
@@ -434,7 +434,9 @@ func generateResourceID(resource unstructured.Unstructured) string {
 	return fmt.Sprintf("%s/%s:%s@%s", resource.GetObjectKind().GroupVersionKind().Group, resource.GetKind(), resource.GetName(), resource.GetNamespace())
 }
 
-func resourceChecksum(resource *unstructured.Unstructured) string {
+// ResourceChecksum returns the checksum of the given resource, as it's recorded
+// in the [ChecksumAnnotation] when applying it.
+func ResourceChecksum(resource *unstructured.Unstructured) string {
 	json, err := resource.MarshalJSON()
 	if err != nil {
 		return ""
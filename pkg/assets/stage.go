@@ -5,12 +5,16 @@ package assets
 
 import (
 	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 
+	"github.com/k0sproject/k0s/internal/pkg/file"
+	"github.com/k0sproject/k0s/pkg/build"
+
 	"github.com/sirupsen/logrus"
 )
 
@@ -48,11 +52,59 @@ func BinPath(name string, binDir string) string {
 	return name
 }
 
+// OverrideDirName is the name of the directory, relative to the binary
+// directory, in which out-of-band replacements for embedded binaries are
+// placed, e.g. when autopilot updates the bundled runtime components.
+const OverrideDirName = "override"
+
+// OverrideDir returns the directory in which out-of-band replacements for
+// embedded binaries are expected to be found. Replacements are bound to the
+// version of k0s that they have been placed for, so that they are ignored as
+// soon as k0s itself gets updated.
+func OverrideDir(binDir string) string {
+	return filepath.Join(binDir, OverrideDirName, "k0s-"+build.Version)
+}
+
+// OverridePath returns the path at which an out-of-band replacement for the
+// embedded binary with the given name is expected to be found.
+func OverridePath(binDir string, name string) string {
+	return filepath.Join(OverrideDir(binDir), name)
+}
+
+// PruneOverrides removes all replacements for embedded binaries that have been
+// placed for other versions of k0s.
+func PruneOverrides(binDir string) error {
+	dir := filepath.Join(binDir, OverrideDirName)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	current := filepath.Base(OverrideDir(binDir))
+	var errs []error
+	for _, entry := range entries {
+		if entry.Name() != current {
+			logrus.Infof("Removing stale overrides in %s", filepath.Join(dir, entry.Name()))
+			errs = append(errs, os.RemoveAll(filepath.Join(dir, entry.Name())))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // Stage ...
 func Stage(dataDir string, name string) error {
 	p := filepath.Join(dataDir, name)
 	logrus.Infof("Staging '%s'", p)
 
+	// Replacements take precedence over the embedded binaries.
+	if override := OverridePath(dataDir, name); file.Exists(override) {
+		return stageOverride(p, override)
+	}
+
 	selfexe, err := os.Executable()
 	if err != nil {
 		return fmt.Errorf("unable to determine current executable: %w", err)
@@ -109,6 +161,39 @@ func Stage(dataDir string, name string) error {
 	return nil
 }
 
+// stageOverride installs the replacement binary at override into p, unless p
+// is already up-to-date.
+func stageOverride(p, override string) error {
+	ovinfo, err := os.Stat(override)
+	if err != nil {
+		return fmt.Errorf("unable to stat '%s': %w", override, err)
+	}
+
+	if !EmbeddedBinaryNeedsUpdate(ovinfo, p, ovinfo.Size()) {
+		logrus.Debug("Re-use existing file:", p)
+		return nil
+	}
+
+	in, err := os.Open(override)
+	if err != nil {
+		return fmt.Errorf("unable to open override '%s': %w", override, err)
+	}
+	defer in.Close()
+
+	logrus.Debugf("Writing override file: '%s'", p)
+
+	if err := copyTo(p, in); err != nil {
+		return fmt.Errorf("unable to copy to '%s': %w", p, err)
+	}
+	if err := os.Chmod(p, 0550); err != nil {
+		return fmt.Errorf("failed to chmod '%s': %w", p, err)
+	}
+	if err := os.Chtimes(p, ovinfo.ModTime(), ovinfo.ModTime()); err != nil {
+		return fmt.Errorf("failed to set file modification times of '%s': %w", p, err)
+	}
+	return nil
+}
+
 func copyTo(p string, gz io.Reader) error {
 	_ = os.Remove(p)
 	f, err := os.Create(p)
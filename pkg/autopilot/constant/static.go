@@ -10,4 +10,26 @@ const (
 	K0SControlNodeModeAnnotation       = "autopilot.k0sproject.io/mode"
 	K0SControlNodeModeController       = "controller"
 	K0SControlNodeModeControllerWorker = "controller+worker"
+	PlanPausedAnnotation               = "autopilot.k0sproject.io/paused"
+
+	// ControllerMetricsBindAddr is the address on which the autopilot controller
+	// exposes its Prometheus metrics.
+	ControllerMetricsBindAddr = "127.0.0.1:8897"
+
+	// WorkerMetricsBindAddr is the address on which the autopilot worker
+	// exposes its Prometheus metrics.
+	WorkerMetricsBindAddr = "127.0.0.1:8898"
+
+	// WebhookCertName is the name of the serving certificate and key files of
+	// the autopilot admission webhooks, without the file extension.
+	WebhookCertName = "autopilot-webhook"
+
+	// WorkerLeasePrefix is the prefix of the names of the leases in the
+	// autopilot namespace that the autopilot workers hold, followed by their
+	// node name.
+	WorkerLeasePrefix = AutopilotNamespace + "-worker-"
+
+	// InstalledK0sBinaryFilename is the file in the k0s data directory that
+	// records the path of the k0s binary that autopilot has installed.
+	InstalledK0sBinaryFilename = "autopilot-k0s-binary"
 )
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlnodes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+
+	"github.com/sirupsen/logrus"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	cr "sigs.k8s.io/controller-runtime"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crman "sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// controllerLeasePrefix is the prefix of the names of the leases that every
+// controller renews in the node lease namespace, followed by its node name.
+const controllerLeasePrefix = "k0s-ctrl-"
+
+// Config configures the staleness detection of ControlNodes.
+type Config struct {
+	// StaleTimeout is the time after which a ControlNode is marked as stale if
+	// its controller didn't renew its lease.
+	StaleTimeout time.Duration
+
+	// RemovalAge is the time after which a stale ControlNode is removed. Stale
+	// ControlNodes are never removed if zero.
+	RemovalAge time.Duration
+}
+
+type staleController struct {
+	log    *logrus.Entry
+	client crcli.Client
+	// The leases are renewed every few seconds, so they're read directly
+	// instead of being watched.
+	reader   crcli.Reader
+	recorder record.EventRecorder
+	config   Config
+}
+
+// RegisterControllers registers the ControlNode staleness controller to the
+// controller-runtime manager. It only runs on the leading controller.
+func RegisterControllers(logger *logrus.Entry, mgr crman.Manager, leaderMode bool, config Config) error {
+	if !leaderMode || config.StaleTimeout <= 0 {
+		return nil
+	}
+
+	return cr.NewControllerManagedBy(mgr).
+		Named("controlnode-staleness").
+		For(&apv1beta2.ControlNode{}).
+		Complete(
+			&staleController{
+				log:      logger.WithField("reconciler", "controlnode-staleness"),
+				client:   mgr.GetClient(),
+				reader:   mgr.GetAPIReader(),
+				recorder: mgr.GetEventRecorderFor("autopilot"),
+				config:   config,
+			},
+		)
+}
+
+func (c *staleController) Reconcile(ctx context.Context, req cr.Request) (cr.Result, error) {
+	var controlNode apv1beta2.ControlNode
+	if err := c.client.Get(ctx, req.NamespacedName, &controlNode); err != nil {
+		return cr.Result{}, crcli.IgnoreNotFound(err)
+	}
+
+	var lease coordinationv1.Lease
+	leaseKey := crcli.ObjectKey{Namespace: corev1.NamespaceNodeLease, Name: controllerLeasePrefix + controlNode.Name}
+	if err := c.reader.Get(ctx, leaseKey, &lease); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Controllers that don't maintain a lease, such as single node
+			// controllers, can't be checked for staleness.
+			c.log.Debugf("No lease found for ControlNode '%s'", controlNode.Name)
+			return cr.Result{}, nil
+		}
+		return cr.Result{}, fmt.Errorf("unable to get lease for ControlNode '%s': %w", controlNode.Name, err)
+	}
+
+	now := time.Now()
+	lastRenewal := lastRenewal(&lease)
+	stale := now.Sub(lastRenewal) > c.config.StaleTimeout
+
+	if stale && c.config.RemovalAge > 0 && now.Sub(lastRenewal) > c.config.RemovalAge {
+		c.log.Infof("Removing ControlNode '%s', its lease hasn't been renewed since %s", controlNode.Name, lastRenewal.Format(time.RFC3339))
+		c.recorder.Eventf(&controlNode, corev1.EventTypeWarning, "ControlNodeRemoved", "Lease hasn't been renewed since %s", lastRenewal.Format(time.RFC3339))
+		if err := c.client.Delete(ctx, &controlNode); err != nil {
+			return cr.Result{}, crcli.IgnoreNotFound(err)
+		}
+		return cr.Result{}, nil
+	}
+
+	condition := metav1.Condition{
+		Type:               apv1beta2.ControlNodeConditionStale,
+		Status:             metav1.ConditionFalse,
+		Reason:             "LeaseRenewed",
+		Message:            "The controller renews its lease",
+		ObservedGeneration: controlNode.Generation,
+	}
+	if stale {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "LeaseExpired"
+		condition.Message = "The controller hasn't renewed its lease since " + lastRenewal.Format(time.RFC3339)
+	}
+
+	if meta.SetStatusCondition(&controlNode.Status.Conditions, condition) {
+		if err := c.client.Status().Update(ctx, &controlNode); err != nil {
+			return cr.Result{}, fmt.Errorf("unable to update ControlNode '%s': %w", controlNode.Name, err)
+		}
+
+		if stale {
+			c.log.Warnf("ControlNode '%s' is stale, its lease hasn't been renewed since %s", controlNode.Name, lastRenewal.Format(time.RFC3339))
+			c.recorder.Event(&controlNode, corev1.EventTypeWarning, "ControlNodeStale", condition.Message)
+		} else {
+			c.recorder.Event(&controlNode, corev1.EventTypeNormal, "ControlNodeActive", condition.Message)
+		}
+	}
+
+	// Check again once the controller would become stale, and periodically
+	// for stale controllers, so that they're eventually removed.
+	requeueAfter := c.config.StaleTimeout
+	if !stale {
+		requeueAfter = lastRenewal.Add(c.config.StaleTimeout).Sub(now) + time.Second
+	}
+	return cr.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// lastRenewal returns the time at which the lease has last been renewed.
+func lastRenewal(lease *coordinationv1.Lease) time.Time {
+	switch {
+	case lease.Spec.RenewTime != nil:
+		return lease.Spec.RenewTime.Time
+	case lease.Spec.AcquireTime != nil:
+		return lease.Spec.AcquireTime.Time
+	default:
+		return lease.CreationTimestamp.Time
+	}
+}
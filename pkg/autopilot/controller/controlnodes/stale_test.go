@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlnodes
+
+import (
+	"testing"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	apscheme "github.com/k0sproject/k0s/pkg/client/clientset/scheme"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	cr "sigs.k8s.io/controller-runtime"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestStaleController(t *testing.T) {
+	var tests = []struct {
+		name            string
+		renewedAgo      time.Duration // no lease if zero
+		expectedStale   metav1.ConditionStatus
+		expectedRemoved bool
+		expectedEvent   string
+	}{
+		{"NoLease", 0, "", false, ""},
+		{"Active", time.Minute, metav1.ConditionFalse, false, "ControlNodeActive"},
+		{"Stale", time.Hour, metav1.ConditionTrue, false, "ControlNodeStale"},
+		{"Removed", 48 * time.Hour, "", true, "ControlNodeRemoved"},
+	}
+
+	scheme := apimruntime.NewScheme()
+	require.NoError(t, apscheme.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, coordinationv1.AddToScheme(scheme))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			objects := []crcli.Object{
+				&apv1beta2.ControlNode{ObjectMeta: metav1.ObjectMeta{Name: "controller0"}},
+			}
+			if test.renewedAgo != 0 {
+				objects = append(objects, &coordinationv1.Lease{
+					ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceNodeLease, Name: "k0s-ctrl-controller0"},
+					Spec: coordinationv1.LeaseSpec{
+						RenewTime: &metav1.MicroTime{Time: time.Now().Add(-test.renewedAgo)},
+					},
+				})
+			}
+
+			client := crfake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				WithStatusSubresource(&apv1beta2.ControlNode{}).
+				Build()
+			recorder := record.NewFakeRecorder(10)
+
+			controller := &staleController{
+				log:      logrus.NewEntry(logrus.StandardLogger()),
+				client:   client,
+				reader:   client,
+				recorder: recorder,
+				config:   Config{StaleTimeout: 5 * time.Minute, RemovalAge: 24 * time.Hour},
+			}
+
+			ctx := t.Context()
+			_, err := controller.Reconcile(ctx, cr.Request{NamespacedName: crcli.ObjectKey{Name: "controller0"}})
+			require.NoError(t, err)
+
+			var controlNode apv1beta2.ControlNode
+			err = client.Get(ctx, crcli.ObjectKey{Name: "controller0"}, &controlNode)
+			if test.expectedRemoved {
+				assert.True(t, apierrors.IsNotFound(err), "Expected ControlNode to be removed: %v", err)
+			} else if assert.NoError(t, err) {
+				condition := meta.FindStatusCondition(controlNode.Status.Conditions, apv1beta2.ControlNodeConditionStale)
+				if test.expectedStale == "" {
+					assert.Nil(t, condition)
+				} else if assert.NotNil(t, condition) {
+					assert.Equal(t, test.expectedStale, condition.Status)
+				}
+			}
+
+			if test.expectedEvent == "" {
+				assert.Empty(t, recorder.Events)
+			} else if assert.Len(t, recorder.Events, 1) {
+				assert.Contains(t, <-recorder.Events, test.expectedEvent)
+			}
+		})
+	}
+}
@@ -17,7 +17,7 @@ import (
 )
 
 // Schedulable handles the provider state 'schedulable'
-func (aup *airgapupdate) Schedulable(ctx context.Context, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+func (aup *airgapupdate) Schedulable(ctx context.Context, planID string, signal appc.SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 	logger := aup.logger.WithField("state", "schedulable")
 	logger.Info("Processing")
 
@@ -27,8 +27,19 @@ func (aup *airgapupdate) Schedulable(ctx context.Context, planID string, cmd apv
 	// Controllers take priority and are selected before any workers. This implies that
 	// all controllers need to be 'up-to-date' in order for any workers to get selected.
 
-	nextForSignal := findNextSchedulableTarget(logger, status.AirgapUpdate)
+	pendingNodes, err := appku.FindPendingWithinGroups(cmd.AirgapUpdate.Workers.Limits.Groups, status.AirgapUpdate.Workers, appku.SignalNodeLabels(ctx, aup.client, aup.controllerDelegateMap["worker"]))
+	if err != nil {
+		return status.State, false, fmt.Errorf("unable to apply concurrency groups: %w", err)
+	}
+
+	nextForSignal := findNextSchedulableTarget(logger, pendingNodes)
 	if nextForSignal == nil {
+		if len(appku.FindPending(status.AirgapUpdate.Workers)) > 0 {
+			// The remaining targets need to wait for their concurrency groups.
+			logger.Info("All pending targets are held back by their concurrency groups")
+			return appc.PlanSchedulableWait, false, nil
+		}
+
 		// Nothing left to do with this reconciler.
 		logger.Infof("All schedulable targets are completed")
 		return appc.PlanCompleted, false, nil
@@ -56,7 +67,7 @@ func (aup *airgapupdate) Schedulable(ctx context.Context, planID string, cmd apv
 		return appc.PlanIncompleteTargets, false, nil
 	}
 
-	if err := appku.UpdateSignalNode(signalNodeCopy, planID, signalNodeCommandBuilder); err != nil {
+	if err := appku.UpdateSignalNode(signalNodeCopy, planID, signal, signalNodeCommandBuilder); err != nil {
 		logger.Warnf("Unable to update signal node: %v", err)
 		return appc.PlanIncompleteTargets, false, nil
 	}
@@ -75,11 +86,9 @@ func (aup *airgapupdate) Schedulable(ctx context.Context, planID string, cmd apv
 	return appc.PlanSchedulableWait, false, nil
 }
 
-// findNextSchedulableTarget searches through the plan status targets, searching for the
-// first entry that has the status `PendingSignal`. The plan targets are either a 'controller',
-// or a 'worker', and have a label indicating this. If none remain, nil is returned.
-func findNextSchedulableTarget(logger *logrus.Entry, cmd *apv1beta2.PlanCommandAirgapUpdateStatus) *apv1beta2.PlanCommandTargetStatus {
-	pendingNodes := appku.FindPending(cmd.Workers)
+// findNextSchedulableTarget picks a random target out of the provided pending targets.
+// If none remain, nil is returned.
+func findNextSchedulableTarget(logger *logrus.Entry, pendingNodes []apv1beta2.PlanCommandTargetStatus) *apv1beta2.PlanCommandTargetStatus {
 	pendingNodeCount := len(pendingNodes)
 
 	if pendingNodeCount > 0 {
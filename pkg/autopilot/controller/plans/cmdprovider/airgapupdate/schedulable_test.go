@@ -153,7 +153,7 @@ func TestSchedulable(t *testing.T) {
 			)
 
 			ctx := t.Context()
-			nextState, retry, err := provider.Schedulable(ctx, "id123", test.command, &test.status)
+			nextState, retry, err := provider.Schedulable(ctx, "id123", appc.SignalOptions{}, test.command, &test.status)
 
 			assert.Equal(t, test.expectedNextState, nextState)
 			assert.Equal(t, test.expectedRetry, retry)
@@ -5,6 +5,7 @@ package airgapupdate
 
 import (
 	"context"
+	"time"
 
 	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
 	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
@@ -12,6 +13,8 @@ import (
 	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
 	apsigcomm "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common"
 	apsigv2 "github.com/k0sproject/k0s/pkg/autopilot/signaling/v2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // SchedulableWait handles the provider state 'schedulablewait'
@@ -23,7 +26,7 @@ func (aup *airgapupdate) SchedulableWait(ctx context.Context, planID string, cmd
 	// of their respective signal node objects.
 
 	logger.Info("Reconciling controller/worker signal node statuses")
-	aup.reconcileSignalNodeStatusTarget(ctx, planID, *status, aup.controllerDelegateMap["worker"], status.AirgapUpdate.Workers)
+	aup.reconcileSignalNodeStatusTarget(ctx, planID, *status, aup.controllerDelegateMap["worker"], status.AirgapUpdate.Workers, cmd.AirgapUpdate.Workers.FailurePolicy)
 
 	// If any of the nodes have reported a failure in applying an update, the
 	// plan is marked as a failure.
@@ -38,7 +41,7 @@ func (aup *airgapupdate) SchedulableWait(ctx context.Context, planID string, cmd
 		return appc.PlanCompleted, false, nil
 	}
 
-	canScheduleWorkers, _ := isSchedulableWorkers(cmd.AirgapUpdate.Workers, status.AirgapUpdate.Workers)
+	canScheduleWorkers, _ := appku.IsSchedulableWorkers(cmd.AirgapUpdate.Workers, status.AirgapUpdate.Workers, appku.SignalNodeLabels(ctx, aup.client, aup.controllerDelegateMap["worker"]), time.Now())
 
 	if canScheduleWorkers {
 		logger.Info("Workers can be scheduled (controllers done)")
@@ -52,9 +55,10 @@ func (aup *airgapupdate) SchedulableWait(ctx context.Context, planID string, cmd
 // reconcileSignalNodeStatusTarget performs a reconciliation of the status of every signal node provided
 // against the current state maintained in the plan status. This ensures that any signal nodes that
 // have been transitioned to 'Completed' will also appear in the plan status as 'Completed'.
-func (aup *airgapupdate) reconcileSignalNodeStatusTarget(ctx context.Context, planID string, cmdStatus apv1beta2.PlanCommandStatus, delegate apdel.ControllerDelegate, signalNodes []apv1beta2.PlanCommandTargetStatus) {
+// Failures are handled according to the provided failure policy.
+func (aup *airgapupdate) reconcileSignalNodeStatusTarget(ctx context.Context, planID string, cmdStatus apv1beta2.PlanCommandStatus, delegate apdel.ControllerDelegate, signalNodes []apv1beta2.PlanCommandTargetStatus, failurePolicy *apv1beta2.PlanCommandTargetFailurePolicy) {
 	for i := range signalNodes {
-		if signalNodes[i].State == appc.SignalCompleted {
+		if appku.IsDone(signalNodes[i]) {
 			continue
 		}
 
@@ -75,13 +79,17 @@ func (aup *airgapupdate) reconcileSignalNodeStatusTarget(ctx context.Context, pl
 						origState := signalNodes[i].State
 
 						if signalData.Status.Status == apsigcomm.Failed || signalData.Status.Status == apsigcomm.FailedDownload {
-							signalNodes[i].State = appc.SignalApplyFailed
+							if !appku.HandleTargetFailure(&signalNodes[i], failurePolicy) {
+								continue
+							}
 						}
 
 						if signalData.Status.Status == apsigcomm.Completed {
 							signalNodes[i].State = appc.SignalCompleted
 						}
 
+						signalNodes[i].LastUpdatedTimestamp = metav1.Now()
+
 						aup.logger.Infof("Signal node '%s' status changed from '%s' to '%s' (reason: %s)", signalNodes[i].Name, origState, signalNodes[i].State, signalData.Status.Status)
 					}
 				} else {
@@ -93,39 +101,3 @@ func (aup *airgapupdate) reconcileSignalNodeStatusTarget(ctx context.Context, pl
 		}
 	}
 }
-
-// isSchedulableWorkers determines if any of the workers in the plan status have
-// a status which would require the plan to become `schedulable`.
-func isSchedulableWorkers(target apv1beta2.PlanCommandTarget, workers []apv1beta2.PlanCommandTargetStatus) (canSchedule, exclude bool) {
-	return isSchedulable(workers, func(pendingSignalCount, signalingSentCount int) bool {
-		return signalingSentCount < target.Limits.Concurrent
-	})
-}
-
-type targetScheduleCondition func(pendingSignalCount, signalingSentCount int) bool
-
-// isSchedulable determines if the provided collection of PlanCommandTargetStatus can be considered
-// as scehdulable. The predicate evaluation delegates to an external schedule condition for specialization.
-func isSchedulable(status []apv1beta2.PlanCommandTargetStatus, cond targetScheduleCondition) (canSchedule, exclude bool) {
-	pendingSignalCount, signalingSentCount := countPlanCommandTargetStatus(status)
-
-	canSchedule = pendingSignalCount > 0 && cond(pendingSignalCount, signalingSentCount)
-	exclude = pendingSignalCount == 0 && signalingSentCount == 0
-
-	return
-}
-
-// countPlanCommandTargetStatus iterates over the provided slice of PlanCommandTargetStatus,
-// returning a count of nodes in PendingSignal and SignalingSent.
-func countPlanCommandTargetStatus(nodes []apv1beta2.PlanCommandTargetStatus) (pendingSignalCount, signalingSentCount int) {
-	for _, node := range nodes {
-		switch node.State {
-		case appc.SignalPending:
-			pendingSignalCount++
-		case appc.SignalSent:
-			signalingSentCount++
-		}
-	}
-
-	return
-}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helmupdate
+
+import (
+	"context"
+	"fmt"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// NewPlan handles the provider state 'newplan'
+func (hup *helmupdate) NewPlan(ctx context.Context, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+	logger := hup.logger.WithField("state", "newplan")
+	logger.Info("Processing")
+
+	// Setup the response status
+	status.State = appc.PlanSchedulableWait
+	status.HelmUpdate = &apv1beta2.PlanCommandHelmUpdateStatus{}
+
+	// The charts can only be updated through the cluster configuration object,
+	// which requires dynamic configuration.
+	var clusterConfig k0sv1beta1.ClusterConfig
+	if err := hup.client.Get(ctx, clusterConfigKey, &clusterConfig); err != nil {
+		if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return status.State, false, fmt.Errorf("unable to get cluster configuration: %w", err)
+		}
+
+		logger.Warn("Cluster configuration not found, Helm charts can only be updated with dynamic configuration enabled")
+		for _, chart := range cmd.HelmUpdate.Charts {
+			status.HelmUpdate.Charts = append(status.HelmUpdate.Charts, apv1beta2.PlanCommandHelmChartStatus{
+				PlanCommandTargetStatus: apv1beta2.NewPlanCommandTargetStatus(chart.Name, appc.SignalMissingNode),
+			})
+		}
+		return appc.PlanIncompleteTargets, false, nil
+	}
+
+	allChartsAccountedFor := true
+	for _, chart := range cmd.HelmUpdate.Charts {
+		chartStatus := apv1beta2.PlanCommandHelmChartStatus{
+			PlanCommandTargetStatus: apv1beta2.NewPlanCommandTargetStatus(chart.Name, appc.SignalPending),
+		}
+
+		if existing := findChart(&clusterConfig, chart.Name); existing != nil {
+			chartStatus.PreviousVersion = existing.Version
+		} else {
+			logger.Warnf("Chart '%s' not found in cluster configuration", chart.Name)
+			chartStatus.State = appc.SignalMissingNode
+			allChartsAccountedFor = false
+		}
+
+		status.HelmUpdate.Charts = append(status.HelmUpdate.Charts, chartStatus)
+	}
+
+	if !allChartsAccountedFor {
+		return appc.PlanIncompleteTargets, false, nil
+	}
+
+	return appc.PlanSchedulableWait, false, nil
+}
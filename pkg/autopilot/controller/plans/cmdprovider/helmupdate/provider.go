@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helmupdate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+	"github.com/k0sproject/k0s/pkg/constant"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	commandID = "HelmUpdate"
+
+	// chartObjectNamePrefix is the prefix that the extensions controller uses
+	// for the names of the Chart objects of the Helm extensions.
+	chartObjectNamePrefix = "k0s-addon-chart-"
+
+	defaultTimeout = 10 * time.Minute
+)
+
+type helmupdate struct {
+	logger *logrus.Entry
+	client crcli.Client
+}
+
+var _ appc.PlanCommandProvider = (*helmupdate)(nil)
+
+// NewHelmUpdatePlanCommandProvider creates a `PlanCommandProvider` that updates
+// the versions of the Helm extension charts in the cluster configuration.
+func NewHelmUpdatePlanCommandProvider(logger *logrus.Entry, client crcli.Client) appc.PlanCommandProvider {
+	return &helmupdate{
+		logger: logger.WithField("command", "helmupdate"),
+		client: client,
+	}
+}
+
+func (hup *helmupdate) CommandID() string {
+	return commandID
+}
+
+// clusterConfigKey is the key of the cluster configuration object that holds
+// the Helm extensions. It only exists if dynamic configuration is enabled.
+var clusterConfigKey = types.NamespacedName{
+	Namespace: constant.ClusterConfigNamespace,
+	Name:      constant.ClusterConfigObjectName,
+}
+
+// chartObjectKey returns the key of the Chart object that the extensions
+// controller maintains for the extension chart with the given name.
+func chartObjectKey(name string) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: constant.ClusterConfigNamespace,
+		Name:      chartObjectNamePrefix + name,
+	}
+}
+
+// findChart returns the extension chart with the given name, or nil if the
+// cluster configuration doesn't declare such a chart.
+func findChart(clusterConfig *k0sv1beta1.ClusterConfig, name string) *k0sv1beta1.Chart {
+	if clusterConfig.Spec == nil || clusterConfig.Spec.Extensions == nil || clusterConfig.Spec.Extensions.Helm == nil {
+		return nil
+	}
+
+	charts := clusterConfig.Spec.Extensions.Helm.Charts
+	for i := range charts {
+		if charts[i].Name == name {
+			return &charts[i]
+		}
+	}
+
+	return nil
+}
+
+// setChartVersion sets the version of the extension chart with the given name
+// in the cluster configuration. The extensions controller picks up the change
+// and upgrades the release accordingly.
+func (hup *helmupdate) setChartVersion(ctx context.Context, name, version string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var clusterConfig k0sv1beta1.ClusterConfig
+		if err := hup.client.Get(ctx, clusterConfigKey, &clusterConfig); err != nil {
+			return err
+		}
+
+		chart := findChart(&clusterConfig, name)
+		if chart == nil {
+			return fmt.Errorf("chart %q not found in cluster configuration", name)
+		}
+		if chart.Version == version {
+			return nil
+		}
+
+		chart.Version = version
+		return hup.client.Update(ctx, &clusterConfig)
+	})
+}
+
+// timeout returns the time to wait for a chart release to be upgraded.
+func timeout(update *apv1beta2.PlanCommandHelmUpdate) time.Duration {
+	if update.Timeout != nil && update.Timeout.Duration > 0 {
+		return update.Timeout.Duration
+	}
+	return defaultTimeout
+}
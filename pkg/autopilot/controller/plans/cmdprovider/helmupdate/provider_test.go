@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helmupdate
+
+import (
+	"testing"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+	apscheme "github.com/k0sproject/k0s/pkg/client/clientset/scheme"
+	"github.com/k0sproject/k0s/pkg/constant"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimruntime "k8s.io/apimachinery/pkg/runtime"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newClusterConfig(version string) *k0sv1beta1.ClusterConfig {
+	return &k0sv1beta1.ClusterConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: constant.ClusterConfigNamespace,
+			Name:      constant.ClusterConfigObjectName,
+		},
+		Spec: &k0sv1beta1.ClusterSpec{
+			Extensions: &k0sv1beta1.ClusterExtensions{
+				Helm: &k0sv1beta1.HelmExtensions{
+					Charts: k0sv1beta1.ChartsSettings{
+						{Name: "metrics-server", ChartName: "metrics-server/metrics-server", Version: version, TargetNS: "kube-system"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newTestScheme(t *testing.T) *apimruntime.Scheme {
+	scheme := apimruntime.NewScheme()
+	require.NoError(t, apscheme.AddToScheme(scheme))
+	return scheme
+}
+
+// TestNewPlanAndSchedulable ensures that the previous chart version is
+// recorded, and that the chart version is updated in the cluster configuration.
+func TestNewPlanAndSchedulable(t *testing.T) {
+	client := crfake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(newClusterConfig("3.12.1")).
+		Build()
+
+	provider := NewHelmUpdatePlanCommandProvider(logrus.NewEntry(logrus.StandardLogger()), client)
+	cmd := apv1beta2.PlanCommand{
+		HelmUpdate: &apv1beta2.PlanCommandHelmUpdate{
+			Charts: []apv1beta2.PlanCommandHelmChart{{Name: "metrics-server", Version: "3.12.2"}},
+		},
+	}
+
+	ctx := t.Context()
+	var status apv1beta2.PlanCommandStatus
+	nextState, _, err := provider.NewPlan(ctx, cmd, &status)
+	require.NoError(t, err)
+	assert.Equal(t, appc.PlanSchedulableWait, nextState)
+	require.Len(t, status.HelmUpdate.Charts, 1)
+	assert.Equal(t, appc.SignalPending, status.HelmUpdate.Charts[0].State)
+	assert.Equal(t, "3.12.1", status.HelmUpdate.Charts[0].PreviousVersion)
+
+	nextState, _, err = provider.Schedulable(ctx, "id123", appc.SignalOptions{}, cmd, &status)
+	require.NoError(t, err)
+	assert.Equal(t, appc.PlanSchedulableWait, nextState)
+	assert.Equal(t, appc.SignalSent, status.HelmUpdate.Charts[0].State)
+
+	var clusterConfig k0sv1beta1.ClusterConfig
+	require.NoError(t, client.Get(ctx, clusterConfigKey, &clusterConfig))
+	assert.Equal(t, "3.12.2", clusterConfig.Spec.Extensions.Helm.Charts[0].Version)
+
+	t.Run("MissingChart", func(t *testing.T) {
+		cmd := apv1beta2.PlanCommand{
+			HelmUpdate: &apv1beta2.PlanCommandHelmUpdate{
+				Charts: []apv1beta2.PlanCommandHelmChart{{Name: "unknown", Version: "1.0.0"}},
+			},
+		}
+
+		var status apv1beta2.PlanCommandStatus
+		nextState, _, err := provider.NewPlan(ctx, cmd, &status)
+		require.NoError(t, err)
+		assert.Equal(t, appc.PlanIncompleteTargets, nextState)
+		assert.Equal(t, appc.SignalMissingNode, status.HelmUpdate.Charts[0].State)
+	})
+}
+
+// TestSchedulableWait ensures that released charts are completed, and that
+// failed or timed out releases are rolled back to their previous version.
+func TestSchedulableWait(t *testing.T) {
+	var tests = []struct {
+		name                 string
+		chartStatus          helmv1beta1.ChartStatus
+		sent                 time.Duration
+		expectedNextState    apv1beta2.PlanStateType
+		expectedChartState   apv1beta2.PlanCommandTargetStateType
+		expectedChartVersion string
+	}{
+		{"Released", helmv1beta1.ChartStatus{Version: "3.12.2", Updated: "later"}, 0, appc.PlanCompleted, appc.SignalCompleted, "3.12.2"},
+		{"InProgress", helmv1beta1.ChartStatus{Version: "3.12.1", Updated: "before"}, 0, appc.PlanSchedulableWait, appc.SignalSent, "3.12.2"},
+		{"StaleError", helmv1beta1.ChartStatus{Version: "3.12.1", Updated: "before", Error: "boom"}, 0, appc.PlanSchedulableWait, appc.SignalSent, "3.12.2"},
+		{"Failed", helmv1beta1.ChartStatus{Version: "3.12.1", Updated: "later", Error: "boom"}, 0, appc.PlanApplyFailed, appc.SignalApplyFailed, "3.12.1"},
+		{"TimedOut", helmv1beta1.ChartStatus{Version: "3.12.1", Updated: "before"}, time.Hour, appc.PlanApplyFailed, appc.SignalApplyFailed, "3.12.1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chart := &helmv1beta1.Chart{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: constant.ClusterConfigNamespace,
+					Name:      chartObjectNamePrefix + "metrics-server",
+				},
+				Spec:   helmv1beta1.ChartSpec{Version: "3.12.2"},
+				Status: test.chartStatus,
+			}
+
+			client := crfake.NewClientBuilder().
+				WithScheme(newTestScheme(t)).
+				WithObjects([]crcli.Object{newClusterConfig("3.12.2"), chart}...).
+				Build()
+
+			provider := NewHelmUpdatePlanCommandProvider(logrus.NewEntry(logrus.StandardLogger()), client)
+			cmd := apv1beta2.PlanCommand{
+				HelmUpdate: &apv1beta2.PlanCommandHelmUpdate{
+					Charts: []apv1beta2.PlanCommandHelmChart{{Name: "metrics-server", Version: "3.12.2"}},
+				},
+			}
+
+			status := apv1beta2.PlanCommandStatus{
+				State: appc.PlanSchedulableWait,
+				HelmUpdate: &apv1beta2.PlanCommandHelmUpdateStatus{
+					Charts: []apv1beta2.PlanCommandHelmChartStatus{{
+						PlanCommandTargetStatus: apv1beta2.PlanCommandTargetStatus{
+							Name:                 "metrics-server",
+							State:                appc.SignalSent,
+							LastUpdatedTimestamp: metav1.NewTime(time.Now().Add(-test.sent)),
+						},
+						PreviousVersion: "3.12.1",
+						ReleaseUpdated:  "before",
+					}},
+				},
+			}
+
+			ctx := t.Context()
+			nextState, _, err := provider.SchedulableWait(ctx, "id123", cmd, &status)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedNextState, nextState)
+			assert.Equal(t, test.expectedChartState, status.HelmUpdate.Charts[0].State)
+
+			var clusterConfig k0sv1beta1.ClusterConfig
+			require.NoError(t, client.Get(ctx, clusterConfigKey, &clusterConfig))
+			assert.Equal(t, test.expectedChartVersion, clusterConfig.Spec.Extensions.Helm.Charts[0].Version)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helmupdate
+
+import (
+	"context"
+	"fmt"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Schedulable handles the provider state 'schedulable'
+func (hup *helmupdate) Schedulable(ctx context.Context, planID string, signal appc.SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+	logger := hup.logger.WithField("state", "schedulable")
+	logger.Info("Processing")
+
+	// Charts are updated one at a time, in the order of the command, so that
+	// charts depending on each other are updated in a predictable way.
+
+	next := findNextPendingChart(status.HelmUpdate.Charts)
+	if next == nil {
+		// Nothing left to do with this reconciler.
+		logger.Infof("All schedulable charts are completed")
+		return appc.PlanCompleted, false, nil
+	}
+
+	var version string
+	for _, chart := range cmd.HelmUpdate.Charts {
+		if chart.Name == next.Name {
+			version = chart.Version
+			break
+		}
+	}
+
+	// Remember when the release has last been touched by the chart
+	// controller, so that errors of previous releases aren't mistaken as
+	// errors of this update.
+	var chart helmv1beta1.Chart
+	if err := hup.client.Get(ctx, chartObjectKey(next.Name), &chart); err != nil {
+		if crcli.IgnoreNotFound(err) != nil {
+			return status.State, false, fmt.Errorf("unable to get chart '%s': %w", next.Name, err)
+		}
+	}
+	next.ReleaseUpdated = chart.Status.Updated
+
+	logger.Infof("Updating chart '%s' from version '%s' to '%s'", next.Name, next.PreviousVersion, version)
+
+	if err := hup.setChartVersion(ctx, next.Name, version); err != nil {
+		logger.Warnf("Unable to update chart '%s' in cluster configuration: %v", next.Name, err)
+		return status.State, false, fmt.Errorf("unable to update chart '%s' in cluster configuration: %w", next.Name, err)
+	}
+
+	next.State = appc.SignalSent
+	next.LastUpdatedTimestamp = metav1.Now()
+
+	return appc.PlanSchedulableWait, false, nil
+}
+
+// findNextPendingChart returns the first chart that is still pending, or nil
+// if there are none.
+func findNextPendingChart(charts []apv1beta2.PlanCommandHelmChartStatus) *apv1beta2.PlanCommandHelmChartStatus {
+	for i := range charts {
+		if charts[i].State == appc.SignalPending {
+			return &charts[i]
+		}
+	}
+
+	return nil
+}
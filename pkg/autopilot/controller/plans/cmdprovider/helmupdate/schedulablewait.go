@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helmupdate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SchedulableWait handles the provider state 'schedulablewait'
+func (hup *helmupdate) SchedulableWait(ctx context.Context, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+	logger := hup.logger.WithField("state", "schedulablewait")
+	logger.Info("Processing")
+
+	// Update the chart status based on the release status reported by the
+	// chart controller on the respective Chart objects.
+
+	logger.Info("Reconciling chart release statuses")
+	if err := hup.reconcileChartStatus(ctx, cmd.HelmUpdate, status, time.Now()); err != nil {
+		return status.State, false, err
+	}
+
+	var pending, sent int
+	for _, chart := range status.HelmUpdate.Charts {
+		switch chart.State {
+		case appc.SignalApplyFailed:
+			logger.Info("Plan is non-recoverable due to failed chart release")
+			return appc.PlanApplyFailed, false, nil
+		case appc.SignalPending:
+			pending++
+		case appc.SignalSent:
+			sent++
+		}
+	}
+
+	if sent > 0 {
+		logger.Info("Waiting for chart release, requesting retry")
+		return appc.PlanSchedulableWait, true, nil
+	}
+
+	if pending > 0 {
+		logger.Info("Charts can be scheduled")
+		return appc.PlanSchedulable, false, nil
+	}
+
+	logger.Info("Charts completed")
+	return appc.PlanCompleted, false, nil
+}
+
+// reconcileChartStatus transitions every chart that has been updated to
+// either 'Completed', once its release has been upgraded, or 'ApplyFailed', if
+// its release failed or didn't complete in time. Failed charts are rolled back
+// to their previous version.
+func (hup *helmupdate) reconcileChartStatus(ctx context.Context, update *apv1beta2.PlanCommandHelmUpdate, status *apv1beta2.PlanCommandStatus, now time.Time) error {
+	for i := range status.HelmUpdate.Charts {
+		chartStatus := &status.HelmUpdate.Charts[i]
+		if chartStatus.State != appc.SignalSent {
+			continue
+		}
+
+		var version string
+		for _, chart := range update.Charts {
+			if chart.Name == chartStatus.Name {
+				version = chart.Version
+				break
+			}
+		}
+
+		var chart helmv1beta1.Chart
+		if err := hup.client.Get(ctx, chartObjectKey(chartStatus.Name), &chart); crcli.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("unable to get chart '%s': %w", chartStatus.Name, err)
+		}
+
+		var reason string
+		switch {
+		case isReleaseCompleted(&chart, version):
+			chartStatus.State = appc.SignalCompleted
+			chartStatus.LastUpdatedTimestamp = metav1.Now()
+			hup.logger.Infof("Chart '%s' has been updated to version '%s'", chartStatus.Name, version)
+			continue
+
+		case isReleaseFailed(&chart, version, chartStatus.ReleaseUpdated):
+			reason = chart.Status.Error
+
+		case now.After(chartStatus.LastUpdatedTimestamp.Add(timeout(update))):
+			reason = "timed out waiting for the release to be upgraded"
+
+		default:
+			continue
+		}
+
+		hup.logger.Warnf("Release of chart '%s' failed, rolling back to version '%s': %s", chartStatus.Name, chartStatus.PreviousVersion, reason)
+		if err := hup.setChartVersion(ctx, chartStatus.Name, chartStatus.PreviousVersion); err != nil {
+			return fmt.Errorf("unable to roll back chart '%s': %w", chartStatus.Name, err)
+		}
+
+		chartStatus.State = appc.SignalApplyFailed
+		chartStatus.LastUpdatedTimestamp = metav1.Now()
+		status.Description = fmt.Sprintf("chart %s rolled back to version %s: %s", chartStatus.Name, chartStatus.PreviousVersion, reason)
+	}
+
+	return nil
+}
+
+// isReleaseCompleted determines if the chart controller has successfully
+// upgraded the release of the chart to the given version.
+func isReleaseCompleted(chart *helmv1beta1.Chart, version string) bool {
+	return chart.Spec.Version == version && chart.Status.Version == version && chart.Status.Error == ""
+}
+
+// isReleaseFailed determines if the chart controller failed to upgrade the
+// release of the chart to the given version. Errors that have been reported
+// before the update was requested are ignored.
+func isReleaseFailed(chart *helmv1beta1.Chart, version, releaseUpdated string) bool {
+	return chart.Spec.Version == version && chart.Status.Error != "" && chart.Status.Updated != releaseUpdated
+}
@@ -18,7 +18,7 @@ import (
 )
 
 // Schedulable handles the provider state 'schedulable'
-func (kp *k0supdate) Schedulable(ctx context.Context, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+func (kp *k0supdate) Schedulable(ctx context.Context, planID string, signal appc.SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 	logger := kp.logger.WithField("state", "schedulable")
 	logger.Info("Processing")
 
@@ -28,8 +28,19 @@ func (kp *k0supdate) Schedulable(ctx context.Context, planID string, cmd apv1bet
 	// Controllers take priority and are selected before any workers. This implies that
 	// all controllers need to be 'up-to-date' in order for any workers to get selected.
 
-	nextForSignal, nextLabel, _ := findNextSchedulableTarget(logger, status.K0sUpdate)
+	pendingWorkers, err := appku.FindPendingWithinGroups(cmd.K0sUpdate.Targets.Workers.Limits.Groups, status.K0sUpdate.Workers, appku.SignalNodeLabels(ctx, kp.client, kp.controllerDelegateMap["worker"]))
+	if err != nil {
+		return status.State, false, fmt.Errorf("unable to apply concurrency groups: %w", err)
+	}
+
+	nextForSignal, nextLabel, _ := findNextSchedulableTarget(logger, status.K0sUpdate, pendingWorkers)
 	if nextForSignal == nil {
+		if len(appku.FindPending(status.K0sUpdate.Workers)) > 0 {
+			// The remaining targets need to wait for their concurrency groups.
+			logger.Info("All pending targets are held back by their concurrency groups")
+			return appc.PlanSchedulableWait, false, nil
+		}
+
 		// Nothing left to do with this reconciler.
 		logger.Infof("All schedulable targets are completed")
 		return appc.PlanCompleted, false, nil
@@ -82,7 +93,7 @@ func (kp *k0supdate) Schedulable(ctx context.Context, planID string, cmd apv1bet
 		return appc.PlanIncompleteTargets, false, nil
 	}
 
-	if err := appku.UpdateSignalNode(signalNodeCopy, planID, signalNodeCommandBuilder); err != nil {
+	if err := appku.UpdateSignalNode(signalNodeCopy, planID, signal, signalNodeCommandBuilder); err != nil {
 		logger.Warnf("Unable to update signal node: %v", err)
 		return appc.PlanIncompleteTargets, false, nil
 	}
@@ -103,18 +114,20 @@ func (kp *k0supdate) Schedulable(ctx context.Context, planID string, cmd apv1bet
 
 // findNextSchedulableTarget searches through the plan status targets, searching for the
 // first entry that has the status `PendingSignal`. The plan targets are either a 'controller',
-// or a 'worker', and have a label indicating this. If none remain, nil is returned.
-func findNextSchedulableTarget(logger *logrus.Entry, cmd *apv1beta2.PlanCommandK0sUpdateStatus) (*apv1beta2.PlanCommandTargetStatus, string, int) {
+// or a 'worker', and have a label indicating this. Only the provided pending workers are
+// considered, so that workers held back by their concurrency groups are skipped. If none
+// remain, nil is returned.
+func findNextSchedulableTarget(logger *logrus.Entry, cmd *apv1beta2.PlanCommandK0sUpdateStatus, pendingWorkers []apv1beta2.PlanCommandTargetStatus) (*apv1beta2.PlanCommandTargetStatus, string, int) {
 	var targets = []struct {
-		nodes []apv1beta2.PlanCommandTargetStatus
-		label string
+		pendingNodes []apv1beta2.PlanCommandTargetStatus
+		label        string
 	}{
-		{cmd.Controllers, "controller"},
-		{cmd.Workers, "worker"},
+		{appku.FindPending(cmd.Controllers), "controller"},
+		{pendingWorkers, "worker"},
 	}
 
 	for _, target := range targets {
-		pendingNodes := appku.FindPending(target.nodes)
+		pendingNodes := target.pendingNodes
 		pendingNodeCount := len(pendingNodes)
 
 		if pendingNodeCount > 0 {
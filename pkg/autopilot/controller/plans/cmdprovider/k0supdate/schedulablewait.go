@@ -6,6 +6,7 @@ package k0supdate
 import (
 	"context"
 	"fmt"
+	"time"
 
 	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
 	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
@@ -13,6 +14,8 @@ import (
 	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
 	apsigcomm "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common"
 	apsigv2 "github.com/k0sproject/k0s/pkg/autopilot/signaling/v2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // SchedulableWait handles the provider state 'schedulablewait'
@@ -24,7 +27,7 @@ func (kp *k0supdate) SchedulableWait(ctx context.Context, planID string, cmd apv
 	// of their respective signal node objects.
 
 	logger.Info("Reconciling controller/worker signal node statuses")
-	if err := kp.reconcileSignalNodeStatus(ctx, planID, status); err != nil {
+	if err := kp.reconcileSignalNodeStatus(ctx, planID, cmd, status); err != nil {
 		return status.State, false, fmt.Errorf("failed to reconcile signal node status: %w", err)
 	}
 
@@ -45,7 +48,7 @@ func (kp *k0supdate) SchedulableWait(ctx context.Context, planID string, cmd apv
 	}
 
 	canScheduleController, _ := isSchedulableControllers(status.K0sUpdate.Controllers)
-	canScheduleWorkers, _ := isSchedulableWorkers(cmd.K0sUpdate.Targets.Workers, status.K0sUpdate.Workers)
+	canScheduleWorkers, _ := appku.IsSchedulableWorkers(cmd.K0sUpdate.Targets.Workers, status.K0sUpdate.Workers, appku.SignalNodeLabels(ctx, kp.client, kp.controllerDelegateMap["worker"]), time.Now())
 
 	// Controllers have priority for scheduling evaluation, as it is important that controllers
 	// are updated before workers due to the Kubernetes version-skew policy.
@@ -70,13 +73,14 @@ func (kp *k0supdate) SchedulableWait(ctx context.Context, planID string, cmd apv
 
 // reconcileSignalNodeStatus performs a reconciliation of the status of every signal node (controller/worker)
 // defined in the update status, ensuring that signal nodes marked as 'Completed' are updated in the plan status.
-func (kp *k0supdate) reconcileSignalNodeStatus(ctx context.Context, planID string, cmdStatus *apv1beta2.PlanCommandStatus) error {
+func (kp *k0supdate) reconcileSignalNodeStatus(ctx context.Context, planID string, cmd apv1beta2.PlanCommand, cmdStatus *apv1beta2.PlanCommandStatus) error {
 	var targets = []struct {
-		nodes []apv1beta2.PlanCommandTargetStatus
-		label string
+		nodes  []apv1beta2.PlanCommandTargetStatus
+		label  string
+		policy *apv1beta2.PlanCommandTargetFailurePolicy
 	}{
-		{cmdStatus.K0sUpdate.Controllers, "controller"},
-		{cmdStatus.K0sUpdate.Workers, "worker"},
+		{cmdStatus.K0sUpdate.Controllers, "controller", cmd.K0sUpdate.Targets.Controllers.FailurePolicy},
+		{cmdStatus.K0sUpdate.Workers, "worker", cmd.K0sUpdate.Targets.Workers.FailurePolicy},
 	}
 
 	for _, target := range targets {
@@ -85,7 +89,7 @@ func (kp *k0supdate) reconcileSignalNodeStatus(ctx context.Context, planID strin
 			return fmt.Errorf("unable to find controller delegate '%s'", target.label)
 		}
 
-		kp.reconcileSignalNodeStatusTarget(ctx, planID, *cmdStatus, delegate, target.nodes)
+		kp.reconcileSignalNodeStatusTarget(ctx, planID, *cmdStatus, delegate, target.nodes, target.policy)
 	}
 
 	return nil
@@ -94,7 +98,8 @@ func (kp *k0supdate) reconcileSignalNodeStatus(ctx context.Context, planID strin
 // reconcileSignalNodeStatusTarget performs a reconciliation of the status of every signal node provided
 // against the current state maintained in the plan status. This ensures that any signal nodes that
 // have been transitioned to 'Completed' will also appear in the plan status as 'Completed'.
-func (kp *k0supdate) reconcileSignalNodeStatusTarget(ctx context.Context, planID string, cmdStatus apv1beta2.PlanCommandStatus, delegate apdel.ControllerDelegate, signalNodes []apv1beta2.PlanCommandTargetStatus) {
+// Failures are handled according to the provided failure policy.
+func (kp *k0supdate) reconcileSignalNodeStatusTarget(ctx context.Context, planID string, cmdStatus apv1beta2.PlanCommandStatus, delegate apdel.ControllerDelegate, signalNodes []apv1beta2.PlanCommandTargetStatus, failurePolicy *apv1beta2.PlanCommandTargetFailurePolicy) {
 	for i := range signalNodes {
 		key := delegate.CreateNamespacedName(signalNodes[i].Name)
 		signalNode := delegate.CreateObject()
@@ -108,7 +113,7 @@ func (kp *k0supdate) reconcileSignalNodeStatusTarget(ctx context.Context, planID
 			var signalData apsigv2.SignalData
 			if err := signalData.Unmarshal(signalNode.GetAnnotations()); err == nil {
 				if signalData.PlanID == planID {
-					if signalNodes[i].State == appc.SignalCompleted {
+					if appku.IsDone(signalNodes[i]) {
 						continue
 					}
 
@@ -117,13 +122,17 @@ func (kp *k0supdate) reconcileSignalNodeStatusTarget(ctx context.Context, planID
 						origState := signalNodes[i].State
 
 						if signalData.Status.Status == apsigcomm.Failed || signalData.Status.Status == apsigcomm.FailedDownload {
-							signalNodes[i].State = appc.SignalApplyFailed
+							if !appku.HandleTargetFailure(&signalNodes[i], failurePolicy) {
+								continue
+							}
 						}
 
 						if signalData.Status.Status == apsigcomm.Completed {
 							signalNodes[i].State = appc.SignalCompleted
 						}
 
+						signalNodes[i].LastUpdatedTimestamp = metav1.Now()
+
 						kp.logger.Infof("Signal node '%s' status changed from '%s' to '%s' (reason: %s)", signalNodes[i].Name, origState, signalNodes[i].State, signalData.Status.Status)
 					}
 				} else {
@@ -140,43 +149,7 @@ func (kp *k0supdate) reconcileSignalNodeStatusTarget(ctx context.Context, planID
 // a status which would a) require the plan to become `schedulable`, or b) have enough
 // information to exclude controllers from the determination.
 func isSchedulableControllers(status []apv1beta2.PlanCommandTargetStatus) (canSchedule bool, exclude bool) {
-	return isSchedulable(status, func(pendingSignalCount, signalingSentCount int) bool {
+	return appku.IsSchedulable(status, func(pendingSignalCount, signalingSentCount int) bool {
 		return signalingSentCount == 0
 	})
 }
-
-// isSchedulableWorkers determines if any of the workers in the plan status have
-// a status which would require the plan to become `schedulable`.
-func isSchedulableWorkers(target apv1beta2.PlanCommandTarget, workers []apv1beta2.PlanCommandTargetStatus) (canSchedule, exclude bool) {
-	return isSchedulable(workers, func(pendingSignalCount, signalingSentCount int) bool {
-		return signalingSentCount < target.Limits.Concurrent
-	})
-}
-
-type targetScheduleCondition func(pendingSignalCount, signalingSentCount int) bool
-
-// isSchedulable determines if the provided collection of PlanCommandTargetStatus can be considered
-// as scehdulable. The predicate evaluation delegates to an external schedule condition for specialization.
-func isSchedulable(status []apv1beta2.PlanCommandTargetStatus, cond targetScheduleCondition) (canSchedule, exclude bool) {
-	pendingSignalCount, signalingSentCount := countPlanCommandTargetStatus(status)
-
-	canSchedule = pendingSignalCount > 0 && cond(pendingSignalCount, signalingSentCount)
-	exclude = pendingSignalCount == 0 && signalingSentCount == 0
-
-	return
-}
-
-// countPlanCommandTargetStatus iterates over the provided slice of PlanCommandTargetStatus,
-// returning a count of nodes in PendingSignal and SignalingSent.
-func countPlanCommandTargetStatus(nodes []apv1beta2.PlanCommandTargetStatus) (pendingSignalCount, signalingSentCount int) {
-	for _, node := range nodes {
-		switch node.State {
-		case appc.SignalPending:
-			pendingSignalCount++
-		case appc.SignalSent:
-			signalingSentCount++
-		}
-	}
-
-	return
-}
@@ -20,6 +20,8 @@ func IsSignalDataSameCommand(cmdStatus apv1beta2.PlanCommandStatus, signalData a
 		return signalData.Command.K0sUpdate != nil
 	case cmdStatus.AirgapUpdate != nil:
 		return signalData.Command.AirgapUpdate != nil
+	case cmdStatus.RuntimeUpdate != nil:
+		return signalData.Command.RuntimeUpdate != nil
 	}
 
 	return false
@@ -31,10 +33,10 @@ func IsSignalDataStatusDifferent(signalNode apv1beta2.PlanCommandTargetStatus, s
 	return signalDataStatus != nil && signalDataStatus.Status != signalNode.State.String()
 }
 
-// IsCompleted determines if every PlanCommandTargetStatus is marked as 'completed'.
+// IsCompleted determines if every PlanCommandTargetStatus is marked as 'completed' or 'skipped'.
 func IsCompleted(targets []apv1beta2.PlanCommandTargetStatus) bool {
 	for _, target := range targets {
-		if target.State != appc.SignalCompleted {
+		if !IsDone(target) {
 			return false
 		}
 	}
@@ -42,6 +44,11 @@ func IsCompleted(targets []apv1beta2.PlanCommandTargetStatus) bool {
 	return true
 }
 
+// IsDone determines if no more work is to be done for the PlanCommandTargetStatus.
+func IsDone(target apv1beta2.PlanCommandTargetStatus) bool {
+	return target.State == appc.SignalCompleted || target.State == appc.SignalSkipped
+}
+
 // IsNotRecoverable determines if any of the PlanCommandTargetStatus is considered non-recoverable.
 func IsNotRecoverable(groups ...[]apv1beta2.PlanCommandTargetStatus) bool {
 	for _, group := range groups {
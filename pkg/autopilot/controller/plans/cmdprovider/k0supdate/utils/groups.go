@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	"k8s.io/apimachinery/pkg/labels"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NodeLabelsFunc looks up the labels of the signal node with the given name.
+type NodeLabelsFunc func(name string) labels.Set
+
+// SignalNodeLabels looks up the labels of signal nodes using the provided client
+// and delegate. Signal nodes that can't be found have no labels.
+func SignalNodeLabels(ctx context.Context, client crcli.Client, delegate apdel.ControllerDelegate) NodeLabelsFunc {
+	return func(name string) labels.Set {
+		if delegate == nil {
+			return nil
+		}
+
+		signalNode := delegate.CreateObject()
+		if err := client.Get(ctx, delegate.CreateNamespacedName(name), signalNode); err != nil {
+			return nil
+		}
+
+		return signalNode.GetLabels()
+	}
+}
+
+// FindPendingWithinGroups returns the pending targets that can be signaled without
+// exceeding the concurrency of any of the concurrency groups they are a member of.
+func FindPendingWithinGroups(groups []apv1beta2.PlanCommandTargetConcurrencyGroup, targets []apv1beta2.PlanCommandTargetStatus, nodeLabels NodeLabelsFunc) ([]apv1beta2.PlanCommandTargetStatus, error) {
+	if len(groups) == 0 {
+		return FindPending(targets), nil
+	}
+
+	selectors := make([]labels.Selector, len(groups))
+	for i, group := range groups {
+		selector, err := labels.Parse(group.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector for concurrency group %d: %w", i, err)
+		}
+		selectors[i] = selector
+	}
+
+	inProgress := make([]int, len(groups))
+	var pending []apv1beta2.PlanCommandTargetStatus
+	var pendingLabels []labels.Set
+	for _, target := range targets {
+		switch target.State {
+		case appc.SignalSent:
+			nodeLabels := nodeLabels(target.Name)
+			for i, selector := range selectors {
+				if selector.Matches(nodeLabels) {
+					inProgress[i]++
+				}
+			}
+		case appc.SignalPending:
+			pending = append(pending, target)
+			pendingLabels = append(pendingLabels, nodeLabels(target.Name))
+		}
+	}
+
+	var schedulable []apv1beta2.PlanCommandTargetStatus
+	for i, target := range pending {
+		if isWithinGroups(groups, selectors, inProgress, pendingLabels[i]) {
+			schedulable = append(schedulable, target)
+		}
+	}
+
+	return schedulable, nil
+}
+
+func isWithinGroups(groups []apv1beta2.PlanCommandTargetConcurrencyGroup, selectors []labels.Selector, inProgress []int, nodeLabels labels.Set) bool {
+	for i, selector := range selectors {
+		if selector.Matches(nodeLabels) && inProgress[i] >= groups[i].MaxConcurrent {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// TestFindPendingWithinGroups ensures that pending targets are only returned
+// if none of their concurrency groups are exhausted.
+func TestFindPendingWithinGroups(t *testing.T) {
+	nodeLabels := func(name string) labels.Set {
+		return map[string]labels.Set{
+			"worker0": {"zone": "a"},
+			"worker1": {"zone": "a"},
+			"worker2": {"zone": "b"},
+			"worker3": {"zone": "b", "gpu": "true"},
+			"worker4": {"zone": "c"},
+		}[name]
+	}
+
+	targets := []apv1beta2.PlanCommandTargetStatus{
+		{Name: "worker0", State: appc.SignalSent},
+		{Name: "worker1", State: appc.SignalPending},
+		{Name: "worker2", State: appc.SignalPending},
+		{Name: "worker3", State: appc.SignalPending},
+		{Name: "worker4", State: appc.SignalCompleted},
+	}
+
+	names := func(targets []apv1beta2.PlanCommandTargetStatus) []string {
+		var names []string
+		for _, target := range targets {
+			names = append(names, target.Name)
+		}
+		return names
+	}
+
+	var tests = []struct {
+		name     string
+		groups   []apv1beta2.PlanCommandTargetConcurrencyGroup
+		expected []string
+	}{
+		{"NoGroups", nil, []string{"worker1", "worker2", "worker3"}},
+		{"ZoneExhausted", []apv1beta2.PlanCommandTargetConcurrencyGroup{
+			{Selector: "zone=a", MaxConcurrent: 1},
+		}, []string{"worker2", "worker3"}},
+		{"ZoneAvailable", []apv1beta2.PlanCommandTargetConcurrencyGroup{
+			{Selector: "zone=a", MaxConcurrent: 2},
+		}, []string{"worker1", "worker2", "worker3"}},
+		{"OverlappingGroups", []apv1beta2.PlanCommandTargetConcurrencyGroup{
+			{Selector: "zone=a", MaxConcurrent: 1},
+			{Selector: "", MaxConcurrent: 1},
+		}, nil},
+		{"UnrelatedGroup", []apv1beta2.PlanCommandTargetConcurrencyGroup{
+			{Selector: "gpu=true", MaxConcurrent: 1},
+		}, []string{"worker1", "worker2", "worker3"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pending, err := FindPendingWithinGroups(test.groups, targets, nodeLabels)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, names(pending))
+		})
+	}
+
+	t.Run("InvalidSelector", func(t *testing.T) {
+		_, err := FindPendingWithinGroups([]apv1beta2.PlanCommandTargetConcurrencyGroup{
+			{Selector: "zone in (a", MaxConcurrent: 1},
+		}, targets, nodeLabels)
+		assert.ErrorContains(t, err, "invalid selector for concurrency group 0")
+	})
+}
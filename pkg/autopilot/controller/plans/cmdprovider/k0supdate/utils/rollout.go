@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+)
+
+// RolloutAllowance determines how many of the provided targets are allowed to
+// have been signaled at the given time, according to the stages of the rollout.
+// A stage is considered to be completed once the amount of completed (or skipped)
+// targets reaches the size of the stage. The next stage is only entered once the bake
+// time of the completed stage has elapsed since the last target was completed.
+func RolloutAllowance(rollout *apv1beta2.PlanCommandTargetRollout, targets []apv1beta2.PlanCommandTargetStatus, now time.Time) int {
+	total := len(targets)
+	if rollout == nil {
+		return total
+	}
+
+	var completed int
+	var lastCompleted time.Time
+	for _, target := range targets {
+		if IsDone(target) {
+			completed++
+			if ts := target.LastUpdatedTimestamp.Time; ts.After(lastCompleted) {
+				lastCompleted = ts
+			}
+		}
+	}
+
+	for _, stage := range rollout.Stages {
+		size := RolloutStageSize(total, stage.Percentage)
+
+		// The stage is still in progress.
+		if completed < size {
+			return size
+		}
+
+		// The stage is completed, but still baking.
+		if now.Before(lastCompleted.Add(stage.BakeTime.Duration)) {
+			return size
+		}
+	}
+
+	return total
+}
+
+// RolloutStageSize calculates the number of targets that are covered by a
+// stage with the given cumulative percentage. Any non-empty set of targets will
+// have at least one target per stage.
+func RolloutStageSize(total, percentage int) int {
+	size := (total*percentage + 99) / 100
+	return max(min(size, total), min(1, total))
+}
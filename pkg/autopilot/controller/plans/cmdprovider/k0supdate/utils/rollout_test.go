@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestRolloutStageSize ensures that stage sizes are rounded up, capped, and
+// never empty.
+func TestRolloutStageSize(t *testing.T) {
+	var tests = []struct {
+		total, percentage, expected int
+	}{
+		{100, 10, 10},
+		{10, 25, 3},
+		{3, 10, 1},
+		{10, 100, 10},
+		{0, 50, 0},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, RolloutStageSize(test.total, test.percentage), "%d%% of %d", test.percentage, test.total)
+	}
+}
+
+// TestRolloutAllowance ensures that the number of targets allowed to be signaled
+// follows the stages, and waits for the bake times between them.
+func TestRolloutAllowance(t *testing.T) {
+	now := time.Now()
+
+	rollout := &apv1beta2.PlanCommandTargetRollout{
+		Stages: []apv1beta2.PlanCommandTargetRolloutStage{
+			{Percentage: 10, BakeTime: metav1.Duration{Duration: 24 * time.Hour}},
+		},
+	}
+
+	makeTargets := func(completed int, completedAt time.Time) []apv1beta2.PlanCommandTargetStatus {
+		targets := make([]apv1beta2.PlanCommandTargetStatus, 20)
+		for i := range targets {
+			targets[i] = apv1beta2.NewPlanCommandTargetStatus("worker", appc.SignalPending)
+			if i < completed {
+				targets[i].State = appc.SignalCompleted
+				targets[i].LastUpdatedTimestamp = metav1.NewTime(completedAt)
+			}
+		}
+		return targets
+	}
+
+	var tests = []struct {
+		name     string
+		rollout  *apv1beta2.PlanCommandTargetRollout
+		targets  []apv1beta2.PlanCommandTargetStatus
+		expected int
+	}{
+		{"NoRollout", nil, makeTargets(0, now), 20},
+		{"FirstStage", rollout, makeTargets(0, now), 2},
+		{"FirstStageInProgress", rollout, makeTargets(1, now), 2},
+		{"Baking", rollout, makeTargets(2, now.Add(-23*time.Hour)), 2},
+		{"Baked", rollout, makeTargets(2, now.Add(-25*time.Hour)), 20},
+		{
+			"MultipleStages",
+			&apv1beta2.PlanCommandTargetRollout{
+				Stages: []apv1beta2.PlanCommandTargetRolloutStage{
+					{Percentage: 10},
+					{Percentage: 50, BakeTime: metav1.Duration{Duration: time.Hour}},
+				},
+			},
+			makeTargets(2, now),
+			10,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, RolloutAllowance(test.rollout, test.targets, now))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+)
+
+// TargetScheduleCondition decides if targets can be scheduled, given the
+// amount of targets that are pending and have been signaled.
+type TargetScheduleCondition func(pendingSignalCount, signalingSentCount int) bool
+
+// IsSchedulableWorkers determines if any of the workers in the plan status have
+// a status which would require the plan to become `schedulable`. Workers that
+// are outside of the current rollout stage, or whose concurrency groups are
+// exhausted, aren't schedulable.
+func IsSchedulableWorkers(target apv1beta2.PlanCommandTarget, workers []apv1beta2.PlanCommandTargetStatus, nodeLabels NodeLabelsFunc, now time.Time) (canSchedule, exclude bool) {
+	allowance := RolloutAllowance(target.Rollout, workers, now)
+	return IsSchedulable(workers, func(pendingSignalCount, signalingSentCount int) bool {
+		if signalingSentCount >= target.Limits.Concurrent || len(workers)-pendingSignalCount >= allowance {
+			return false
+		}
+		if len(target.Limits.Groups) == 0 {
+			return true
+		}
+		pending, err := FindPendingWithinGroups(target.Limits.Groups, workers, nodeLabels)
+		return err == nil && len(pending) > 0
+	})
+}
+
+// IsSchedulable determines if the provided collection of PlanCommandTargetStatus can be considered
+// as scehdulable. The predicate evaluation delegates to an external schedule condition for specialization.
+func IsSchedulable(status []apv1beta2.PlanCommandTargetStatus, cond TargetScheduleCondition) (canSchedule, exclude bool) {
+	pendingSignalCount, signalingSentCount := countPlanCommandTargetStatus(status)
+
+	canSchedule = pendingSignalCount > 0 && cond(pendingSignalCount, signalingSentCount)
+	exclude = pendingSignalCount == 0 && signalingSentCount == 0
+
+	return
+}
+
+// countPlanCommandTargetStatus iterates over the provided slice of PlanCommandTargetStatus,
+// returning a count of nodes in PendingSignal and SignalingSent.
+func countPlanCommandTargetStatus(nodes []apv1beta2.PlanCommandTargetStatus) (pendingSignalCount, signalingSentCount int) {
+	for _, node := range nodes {
+		switch node.State {
+		case appc.SignalPending:
+			pendingSignalCount++
+		case appc.SignalSent:
+			signalingSentCount++
+		}
+	}
+
+	return
+}
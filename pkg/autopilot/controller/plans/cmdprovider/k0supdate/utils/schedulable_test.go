@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// TestIsSchedulableWorkers ensures that workers are only schedulable within
+// the concurrency limits and the current rollout stage.
+func TestIsSchedulableWorkers(t *testing.T) {
+	now := time.Now()
+
+	makeTargets := func(states ...apv1beta2.PlanCommandTargetStateType) []apv1beta2.PlanCommandTargetStatus {
+		targets := make([]apv1beta2.PlanCommandTargetStatus, len(states))
+		for i, state := range states {
+			targets[i] = apv1beta2.NewPlanCommandTargetStatus("worker", state)
+			targets[i].LastUpdatedTimestamp = metav1.NewTime(now)
+		}
+		return targets
+	}
+
+	halfRollout := &apv1beta2.PlanCommandTargetRollout{
+		Stages: []apv1beta2.PlanCommandTargetRolloutStage{
+			{Percentage: 50, BakeTime: metav1.Duration{Duration: time.Hour}},
+		},
+	}
+
+	var tests = []struct {
+		name        string
+		target      apv1beta2.PlanCommandTarget
+		workers     []apv1beta2.PlanCommandTargetStatus
+		canSchedule bool
+		exclude     bool
+	}{
+		{
+			"Pending",
+			apv1beta2.PlanCommandTarget{Limits: apv1beta2.PlanCommandTargetLimits{Concurrent: 1}},
+			makeTargets(appc.SignalPending, appc.SignalPending),
+			true, false,
+		},
+		{
+			"ConcurrencyExhausted",
+			apv1beta2.PlanCommandTarget{Limits: apv1beta2.PlanCommandTargetLimits{Concurrent: 1}},
+			makeTargets(appc.SignalSent, appc.SignalPending),
+			false, false,
+		},
+		{
+			"StageExhausted",
+			apv1beta2.PlanCommandTarget{Limits: apv1beta2.PlanCommandTargetLimits{Concurrent: 2}, Rollout: halfRollout},
+			makeTargets(appc.SignalSent, appc.SignalPending),
+			false, false,
+		},
+		{
+			"StageBaking",
+			apv1beta2.PlanCommandTarget{Limits: apv1beta2.PlanCommandTargetLimits{Concurrent: 2}, Rollout: halfRollout},
+			makeTargets(appc.SignalCompleted, appc.SignalPending),
+			false, false,
+		},
+		{
+			"Done",
+			apv1beta2.PlanCommandTarget{Limits: apv1beta2.PlanCommandTargetLimits{Concurrent: 1}},
+			makeTargets(appc.SignalCompleted),
+			false, true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			canSchedule, exclude := IsSchedulableWorkers(test.target, test.workers, nil, now)
+			assert.Equal(t, test.canSchedule, canSchedule)
+			assert.Equal(t, test.exclude, exclude)
+		})
+	}
+}
+
+// TestIsSchedulableWorkers_Groups ensures that workers aren't schedulable if
+// all of the pending ones belong to exhausted concurrency groups.
+func TestIsSchedulableWorkers_Groups(t *testing.T) {
+	nodeLabels := func(name string) labels.Set {
+		return map[string]labels.Set{
+			"worker0": {"zone": "a"},
+			"worker1": {"zone": "a"},
+			"worker2": {"zone": "b"},
+		}[name]
+	}
+
+	workers := []apv1beta2.PlanCommandTargetStatus{
+		apv1beta2.NewPlanCommandTargetStatus("worker0", appc.SignalSent),
+		apv1beta2.NewPlanCommandTargetStatus("worker1", appc.SignalPending),
+		apv1beta2.NewPlanCommandTargetStatus("worker2", appc.SignalCompleted),
+	}
+
+	target := apv1beta2.PlanCommandTarget{
+		Limits: apv1beta2.PlanCommandTargetLimits{
+			Concurrent: 2,
+			Groups: []apv1beta2.PlanCommandTargetConcurrencyGroup{
+				{Selector: "zone=a", MaxConcurrent: 1},
+			},
+		},
+	}
+
+	canSchedule, _ := IsSchedulableWorkers(target, workers, nodeLabels, time.Now())
+	assert.False(t, canSchedule, "Zone a is exhausted")
+
+	target.Limits.Groups[0].MaxConcurrent = 2
+	canSchedule, _ = IsSchedulableWorkers(target, workers, nodeLabels, time.Now())
+	assert.True(t, canSchedule, "Zone a has room for another worker")
+
+	target.Limits.Groups[0].Selector = "zone in (a"
+	canSchedule, _ = IsSchedulableWorkers(target, workers, nodeLabels, time.Now())
+	assert.False(t, canSchedule, "Invalid selectors must not schedule anything")
+}
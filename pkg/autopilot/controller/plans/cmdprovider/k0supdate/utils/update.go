@@ -8,6 +8,7 @@ import (
 	"time"
 
 	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
 	apsigv2 "github.com/k0sproject/k0s/pkg/autopilot/signaling/v2"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -16,14 +17,32 @@ import (
 
 type SignalNodeCommandBuilder func() apsigv2.Command
 
-// UpdateSignalNode builds a signaling update request, and adds it to the provided node
-func UpdateSignalNode(node crcli.Object, planID string, cb SignalNodeCommandBuilder) error {
+// UpdateSignalNode builds a signaling update request, and adds it to the provided node.
+// The proxy settings and the node timeout of the plan, if any, are sent along
+// with the request.
+func UpdateSignalNode(node crcli.Object, planID string, signal appc.SignalOptions, cb SignalNodeCommandBuilder) error {
+	now := time.Now()
 	signalData := apsigv2.SignalData{
 		PlanID:  planID,
-		Created: time.Now().Format(time.RFC3339),
+		Created: now.Format(time.RFC3339),
 		Command: cb(),
 	}
 
+	if proxy := signal.Proxy; proxy != nil {
+		signalData.Proxy = &apsigv2.Proxy{
+			URL:      proxy.URL,
+			NoProxy:  proxy.NoProxy,
+			CABundle: proxy.CABundle,
+		}
+	}
+
+	if nodeTimeout := signal.NodeTimeout; nodeTimeout != nil {
+		signalData.Timeout = &apsigv2.Timeout{
+			Deadline: now.Add(nodeTimeout.Duration.Duration).Format(time.RFC3339),
+			Uncordon: nodeTimeout.Uncordon,
+		}
+	}
+
 	if err := signalData.Validate(); err != nil {
 		return fmt.Errorf("unable to validate signaling data: %w", err)
 	}
@@ -50,3 +69,32 @@ func UpdatePlanCommandTargetStatusByName(name string, status apv1beta2.PlanComma
 
 	return false
 }
+
+// HandleTargetFailure records a failure reported by the signal node of the
+// target. Depending on the failure policy, the target is either scheduled to be
+// signaled again, skipped, or marked as failed. Failures reported for targets
+// that are already waiting for a retry are stale and ignored, in which case
+// false is returned.
+func HandleTargetFailure(target *apv1beta2.PlanCommandTargetStatus, policy *apv1beta2.PlanCommandTargetFailurePolicy) bool {
+	if policy == nil {
+		target.State = appc.SignalApplyFailed
+		return true
+	}
+
+	if target.State == appc.SignalPending && target.Failures > 0 {
+		return false
+	}
+
+	target.Failures++
+
+	switch {
+	case target.Failures <= policy.Retries:
+		target.State = appc.SignalPending
+	case policy.Skip:
+		target.State = appc.SignalSkipped
+	default:
+		target.State = appc.SignalApplyFailed
+	}
+
+	return true
+}
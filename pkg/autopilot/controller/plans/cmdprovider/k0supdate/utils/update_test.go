@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHandleTargetFailure ensures that failures are retried, skipped or marked
+// as failed according to the failure policy.
+func TestHandleTargetFailure(t *testing.T) {
+	var tests = []struct {
+		name             string
+		policy           *apv1beta2.PlanCommandTargetFailurePolicy
+		state            apv1beta2.PlanCommandTargetStateType
+		failures         int
+		expectedHandled  bool
+		expectedState    apv1beta2.PlanCommandTargetStateType
+		expectedFailures int
+	}{
+		{"NoPolicy", nil, appc.SignalSent, 0, true, appc.SignalApplyFailed, 0},
+		{"Retry", &apv1beta2.PlanCommandTargetFailurePolicy{Retries: 2}, appc.SignalSent, 1, true, appc.SignalPending, 2},
+		{"RetriesExhausted", &apv1beta2.PlanCommandTargetFailurePolicy{Retries: 2}, appc.SignalSent, 2, true, appc.SignalApplyFailed, 3},
+		{"Skip", &apv1beta2.PlanCommandTargetFailurePolicy{Retries: 1, Skip: true}, appc.SignalSent, 1, true, appc.SignalSkipped, 2},
+		{"SkipWithoutRetries", &apv1beta2.PlanCommandTargetFailurePolicy{Skip: true}, appc.SignalSent, 0, true, appc.SignalSkipped, 1},
+		{"StaleFailure", &apv1beta2.PlanCommandTargetFailurePolicy{Retries: 2}, appc.SignalPending, 1, false, appc.SignalPending, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target := apv1beta2.NewPlanCommandTargetStatus("worker0", test.state)
+			target.Failures = test.failures
+
+			assert.Equal(t, test.expectedHandled, HandleTargetFailure(&target, test.policy))
+			assert.Equal(t, test.expectedState, target.State)
+			assert.Equal(t, test.expectedFailures, target.Failures)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimeupdate
+
+import (
+	"context"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+)
+
+// NewPlan handles the provider state 'newplan'
+func (rup *runtimeupdate) NewPlan(ctx context.Context, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+	logger := rup.logger.WithField("state", "newplan")
+	logger.Info("Processing")
+
+	// Setup the response status
+	status.State = appc.PlanSchedulableWait
+	status.RuntimeUpdate = &apv1beta2.PlanCommandRuntimeUpdateStatus{}
+
+	var allWorkersAccountedFor bool
+	status.RuntimeUpdate.Workers, allWorkersAccountedFor = populateWorkerStatus(ctx, rup.client, *cmd.RuntimeUpdate, rup.controllerDelegateMap)
+
+	if !allWorkersAccountedFor {
+		return appc.PlanIncompleteTargets, false, nil
+	}
+
+	if _, found := rup.excludedFromPlans["worker"]; found && len(status.RuntimeUpdate.Workers) > 0 {
+		return appc.PlanRestricted, false, nil
+	}
+
+	return appc.PlanSchedulableWait, false, nil
+}
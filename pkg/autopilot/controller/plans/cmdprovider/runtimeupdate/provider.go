@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimeupdate
+
+import (
+	"context"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
+	appkd "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/cmdprovider/k0supdate/discovery"
+	appku "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/cmdprovider/k0supdate/utils"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+	"github.com/k0sproject/k0s/pkg/kubernetes"
+
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	commandID = "RuntimeUpdate"
+)
+
+type runtimeupdate struct {
+	logger                *logrus.Entry
+	client                crcli.Client
+	controllerDelegateMap apdel.ControllerDelegateMap
+	excludedFromPlans     map[string]struct{}
+	cf                    kubernetes.ClientFactoryInterface
+}
+
+var _ appc.PlanCommandProvider = (*runtimeupdate)(nil)
+
+// NewRuntimeUpdatePlanCommandProvider creates a `PlanCommandProvider` that updates
+// the bundled runtime components of workers, independently of k0s itself.
+func NewRuntimeUpdatePlanCommandProvider(logger *logrus.Entry, client crcli.Client, dm apdel.ControllerDelegateMap, cf kubernetes.ClientFactoryInterface, excludeFromPlans []string) appc.PlanCommandProvider {
+	excludedFromPlans := make(map[string]struct{})
+	for _, excluded := range excludeFromPlans {
+		excludedFromPlans[excluded] = struct{}{}
+	}
+
+	return &runtimeupdate{
+		logger:                logger.WithField("command", "runtimeupdate"),
+		client:                client,
+		controllerDelegateMap: dm,
+		cf:                    cf,
+		excludedFromPlans:     excludedFromPlans,
+	}
+}
+
+func (rup *runtimeupdate) CommandID() string {
+	return commandID
+}
+
+// populateWorkerStatus is a specialization of `DiscoverNodes` for working
+// with `v1.Node` signal node objects. A node is only accounted for if every
+// component provides a resource for the platform of that node.
+func populateWorkerStatus(ctx context.Context, client crcli.Client, update apv1beta2.PlanCommandRuntimeUpdate, dm apdel.ControllerDelegateMap) ([]apv1beta2.PlanCommandTargetStatus, bool) {
+	return appkd.DiscoverNodes(ctx, client, &update.Workers, dm["worker"], func(name string) (bool, *apv1beta2.PlanCommandTargetStateType) {
+		for _, component := range update.Components {
+			if exists, state := appku.ObjectExistsWithPlatform(ctx, client, name, &v1.Node{}, component.Platforms); !exists {
+				return exists, state
+			}
+		}
+
+		return true, nil
+	})
+}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimeupdate
+
+import (
+	"context"
+	"fmt"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	appku "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/cmdprovider/k0supdate/utils"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+	apsigv2 "github.com/k0sproject/k0s/pkg/autopilot/signaling/v2"
+
+	"github.com/sirupsen/logrus"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Schedulable handles the provider state 'schedulable'
+func (rup *runtimeupdate) Schedulable(ctx context.Context, planID string, signal appc.SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+	logger := rup.logger.WithField("state", "schedulable")
+	logger.Info("Processing")
+
+	// Once in 'Schedulable', we find the first signal node in 'PendingSignal'. If there
+	// are no other candidates, we're considered done.
+	//
+	// Controllers take priority and are selected before any workers. This implies that
+	// all controllers need to be 'up-to-date' in order for any workers to get selected.
+
+	pendingNodes, err := appku.FindPendingWithinGroups(cmd.RuntimeUpdate.Workers.Limits.Groups, status.RuntimeUpdate.Workers, appku.SignalNodeLabels(ctx, rup.client, rup.controllerDelegateMap["worker"]))
+	if err != nil {
+		return status.State, false, fmt.Errorf("unable to apply concurrency groups: %w", err)
+	}
+
+	nextForSignal := findNextSchedulableTarget(logger, pendingNodes)
+	if nextForSignal == nil {
+		if len(appku.FindPending(status.RuntimeUpdate.Workers)) > 0 {
+			// The remaining targets need to wait for their concurrency groups.
+			logger.Info("All pending targets are held back by their concurrency groups")
+			return appc.PlanSchedulableWait, false, nil
+		}
+
+		// Nothing left to do with this reconciler.
+		logger.Infof("All schedulable targets are completed")
+		return appc.PlanCompleted, false, nil
+	}
+
+	signalNodeDelegate, ok := rup.controllerDelegateMap["worker"]
+	if !ok {
+		logger.Warnf("Missing signal delegate for '%s'", "worker")
+		return appc.PlanMissingSignalNode, false, nil
+	}
+
+	nodeKey := signalNodeDelegate.CreateNamespacedName(nextForSignal.Name)
+	signalNode := signalNodeDelegate.CreateObject()
+	if err := rup.client.Get(ctx, nodeKey, signalNode); err != nil {
+		logger.Warnf("Unable to find signal node '%s' for signal: %v", nodeKey, err)
+		return appc.PlanMissingSignalNode, false, nil
+	}
+
+	logger.Infof("Sending signaling to node='%s'", nextForSignal.Name)
+
+	signalNodeCopy := signalNodeDelegate.DeepCopy(signalNode)
+	signalNodeCommandBuilder, err := signalNodeRuntimeUpdateCommandBuilder(signalNodeCopy, cmd, status)
+	if err != nil {
+		logger.Warnf("Unable to build signal node content: %v", err)
+		return appc.PlanIncompleteTargets, false, nil
+	}
+
+	if err := appku.UpdateSignalNode(signalNodeCopy, planID, signal, signalNodeCommandBuilder); err != nil {
+		logger.Warnf("Unable to update signal node: %v", err)
+		return appc.PlanIncompleteTargets, false, nil
+	}
+
+	// .. and update the node
+
+	if err := rup.client.Update(ctx, signalNodeCopy, &crcli.UpdateOptions{}); err != nil {
+		logger.Warnf("Unable to update signalnode with signaling: %v", err)
+		return status.State, false, fmt.Errorf("unable to update signalnode with signaling: %w", err)
+	}
+
+	// Update the status of the node we sent the signal to
+
+	appku.UpdatePlanCommandTargetStatusByName(nextForSignal.Name, appc.SignalSent, status.RuntimeUpdate.Workers)
+
+	return appc.PlanSchedulableWait, false, nil
+}
+
+// findNextSchedulableTarget picks a random target out of the provided pending targets.
+// If none remain, nil is returned.
+func findNextSchedulableTarget(logger *logrus.Entry, pendingNodes []apv1beta2.PlanCommandTargetStatus) *apv1beta2.PlanCommandTargetStatus {
+	pendingNodeCount := len(pendingNodes)
+
+	if pendingNodeCount > 0 {
+		nextNode, err := appku.FindNextPendingRandom(pendingNodes)
+		if err != nil {
+			logger.Errorf("Unable to determine next random node: %v", err)
+		}
+
+		if nextNode != nil {
+			return nextNode
+		}
+	}
+
+	return nil
+}
+
+func signalNodeRuntimeUpdateCommandBuilder(node crcli.Object, cmd apv1beta2.PlanCommand, cmdStatus *apv1beta2.PlanCommandStatus) (appku.SignalNodeCommandBuilder, error) {
+	// Determine the platform identifier of the target signal node
+	nodePlatformID, err := appku.SignalNodePlatformIdentifier(node)
+	if err != nil {
+		appku.UpdatePlanCommandTargetStatusByName(node.GetName(), appc.SignalMissingPlatform, cmdStatus.RuntimeUpdate.Workers)
+		return nil, err
+	}
+
+	// Every component needs to be available for the platform of the node, as
+	// a partially updated runtime is worse than no update at all.
+	components := make([]apsigv2.CommandRuntimeComponent, 0, len(cmd.RuntimeUpdate.Components))
+	for _, component := range cmd.RuntimeUpdate.Components {
+		updateContent, updateContentOk := component.Platforms[nodePlatformID]
+		if !updateContentOk {
+			appku.UpdatePlanCommandTargetStatusByName(node.GetName(), appc.SignalMissingPlatform, cmdStatus.RuntimeUpdate.Workers)
+			return nil, fmt.Errorf("for component %s and platform ID %s: %s", component.Name, nodePlatformID, appc.SignalMissingPlatform)
+		}
+
+		components = append(components, apsigv2.CommandRuntimeComponent{
+			Name:   component.Name,
+			URL:    updateContent.URL,
+			Sha256: updateContent.Sha256,
+		})
+	}
+
+	return func() apsigv2.Command {
+		return apsigv2.Command{
+			ID: &cmdStatus.ID,
+			RuntimeUpdate: &apsigv2.CommandRuntimeUpdate{
+				Version:    cmd.RuntimeUpdate.Version,
+				Components: components,
+			},
+		}
+	}, nil
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimeupdate
+
+import (
+	"testing"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+	apsigv2 "github.com/k0sproject/k0s/pkg/autopilot/signaling/v2"
+	apscheme "github.com/k0sproject/k0s/pkg/client/clientset/scheme"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestSchedulable tests the reconcile function of the `scheduleable` controller.
+// This ensures that the proper signaling data is sent to worker nodes, and that
+// nodes lacking a resource for any of the components aren't signaled.
+func TestSchedulable(t *testing.T) {
+	worker0 := &corev1.Node{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Node",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "worker0",
+			Labels: map[string]string{corev1.LabelOSStable: "theOS", corev1.LabelArchStable: "theArch"},
+		},
+	}
+
+	workers := apv1beta2.PlanCommandTarget{
+		Discovery: apv1beta2.PlanCommandTargetDiscovery{
+			Static: &apv1beta2.PlanCommandTargetDiscoveryStatic{
+				Nodes: []string{"worker0"},
+			},
+		},
+	}
+
+	var tests = []struct {
+		name                      string
+		command                   apv1beta2.PlanCommand
+		expectedNextState         apv1beta2.PlanStateType
+		expectedPlanStatusWorkers []apv1beta2.PlanCommandTargetStatus
+		expectedSignalComponents  []apsigv2.CommandRuntimeComponent
+	}{
+		// Ensures that a signal node can be sent a signal containing all
+		// components, and individually transition to 'SignalingSent'.
+		{
+			"HappyMoveToSchedulableWait",
+			apv1beta2.PlanCommand{
+				RuntimeUpdate: &apv1beta2.PlanCommandRuntimeUpdate{
+					Version: "v99.99.99",
+					Components: []apv1beta2.PlanCommandRuntimeComponent{
+						{
+							Name: "containerd",
+							Platforms: apv1beta2.PlanPlatformResourceURLMap{
+								"theOS-theArch": {URL: "https://k0s.example.com/downloads/containerd-theOS-theArch"},
+							},
+						},
+						{
+							Name: "runc",
+							Platforms: apv1beta2.PlanPlatformResourceURLMap{
+								"theOS-theArch": {URL: "https://k0s.example.com/downloads/runc-theOS-theArch", Sha256: "deadbeef"},
+							},
+						},
+					},
+					Workers: workers,
+				},
+			},
+			appc.PlanSchedulableWait,
+			[]apv1beta2.PlanCommandTargetStatus{
+				apv1beta2.NewPlanCommandTargetStatus("worker0", appc.SignalSent),
+			},
+			[]apsigv2.CommandRuntimeComponent{
+				{Name: "containerd", URL: "https://k0s.example.com/downloads/containerd-theOS-theArch"},
+				{Name: "runc", URL: "https://k0s.example.com/downloads/runc-theOS-theArch", Sha256: "deadbeef"},
+			},
+		},
+
+		// Ensures that a single component without a resource for the platform
+		// of the node prevents the node from being signaled.
+		{
+			"ComponentMissingPlatform",
+			apv1beta2.PlanCommand{
+				RuntimeUpdate: &apv1beta2.PlanCommandRuntimeUpdate{
+					Version: "v99.99.99",
+					Components: []apv1beta2.PlanCommandRuntimeComponent{
+						{
+							Name: "containerd",
+							Platforms: apv1beta2.PlanPlatformResourceURLMap{
+								"theOS-theArch": {URL: "https://k0s.example.com/downloads/containerd-theOS-theArch"},
+							},
+						},
+						{
+							Name: "kubelet",
+							Platforms: apv1beta2.PlanPlatformResourceURLMap{
+								"otherOS-otherArch": {URL: "https://k0s.example.com/downloads/kubelet-otherOS-otherArch"},
+							},
+						},
+					},
+					Workers: workers,
+				},
+			},
+			appc.PlanIncompleteTargets,
+			[]apv1beta2.PlanCommandTargetStatus{
+				apv1beta2.NewPlanCommandTargetStatus("worker0", appc.SignalMissingPlatform),
+			},
+			nil,
+		},
+	}
+
+	scheme := apimruntime.NewScheme()
+	require.NoError(t, apscheme.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := crfake.NewClientBuilder().WithObjects([]crcli.Object{worker0.DeepCopy()}...).WithScheme(scheme).Build()
+
+			provider := NewRuntimeUpdatePlanCommandProvider(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+				map[string]apdel.ControllerDelegate{
+					"worker": apdel.NodeControllerDelegate(),
+				},
+				testutil.NewFakeClientFactory(),
+				[]string{},
+			)
+
+			status := apv1beta2.PlanCommandStatus{
+				State: appc.PlanSchedulable,
+				RuntimeUpdate: &apv1beta2.PlanCommandRuntimeUpdateStatus{
+					Workers: []apv1beta2.PlanCommandTargetStatus{
+						apv1beta2.NewPlanCommandTargetStatus("worker0", appc.SignalPending),
+					},
+				},
+			}
+
+			ctx := t.Context()
+			nextState, retry, err := provider.Schedulable(ctx, "id123", appc.SignalOptions{}, test.command, &status)
+
+			assert.Equal(t, test.expectedNextState, nextState)
+			assert.False(t, retry)
+			assert.NoError(t, err)
+			assert.True(t, cmp.Equal(test.expectedPlanStatusWorkers, status.RuntimeUpdate.Workers, cmpopts.IgnoreFields(apv1beta2.PlanCommandTargetStatus{}, "LastUpdatedTimestamp")))
+
+			var node corev1.Node
+			require.NoError(t, client.Get(ctx, types.NamespacedName{Name: "worker0"}, &node))
+
+			if test.expectedSignalComponents == nil {
+				assert.False(t, apsigv2.IsSignalingPresent(node.GetAnnotations()))
+				return
+			}
+
+			var signalData apsigv2.SignalData
+			require.NoError(t, signalData.Unmarshal(node.GetAnnotations()))
+			require.NotNil(t, signalData.Command.RuntimeUpdate)
+			assert.Equal(t, "v99.99.99", signalData.Command.RuntimeUpdate.Version)
+			assert.Equal(t, test.expectedSignalComponents, signalData.Command.RuntimeUpdate.Components)
+		})
+	}
+}
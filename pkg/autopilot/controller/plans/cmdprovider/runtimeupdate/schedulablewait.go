@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimeupdate
+
+import (
+	"context"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
+	appku "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/cmdprovider/k0supdate/utils"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+	apsigcomm "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common"
+	apsigv2 "github.com/k0sproject/k0s/pkg/autopilot/signaling/v2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SchedulableWait handles the provider state 'schedulablewait'
+func (rup *runtimeupdate) SchedulableWait(ctx context.Context, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+	logger := rup.logger.WithField("state", "schedulablewait")
+	logger.Info("Processing")
+
+	// Update the target status for both controllers and workers based on queries
+	// of their respective signal node objects.
+
+	logger.Info("Reconciling controller/worker signal node statuses")
+	rup.reconcileSignalNodeStatusTarget(ctx, planID, *status, rup.controllerDelegateMap["worker"], status.RuntimeUpdate.Workers, cmd.RuntimeUpdate.Workers.FailurePolicy)
+
+	// If any of the nodes have reported a failure in applying an update, the
+	// plan is marked as a failure.
+
+	if appku.IsNotRecoverable(status.RuntimeUpdate.Workers) {
+		logger.Info("Plan is non-recoverable due to apply failure")
+		return appc.PlanApplyFailed, false, nil
+	}
+
+	if appku.IsCompleted(status.RuntimeUpdate.Workers) {
+		logger.Info("Workers completed")
+		return appc.PlanCompleted, false, nil
+	}
+
+	canScheduleWorkers, _ := appku.IsSchedulableWorkers(cmd.RuntimeUpdate.Workers, status.RuntimeUpdate.Workers, appku.SignalNodeLabels(ctx, rup.client, rup.controllerDelegateMap["worker"]), time.Now())
+
+	if canScheduleWorkers {
+		logger.Info("Workers can be scheduled (controllers done)")
+		return appc.PlanSchedulable, false, nil
+	}
+
+	logger.Info("No applicable transitions available, requesting retry")
+	return appc.PlanSchedulableWait, true, nil
+}
+
+// reconcileSignalNodeStatusTarget performs a reconciliation of the status of every signal node provided
+// against the current state maintained in the plan status. This ensures that any signal nodes that
+// have been transitioned to 'Completed' will also appear in the plan status as 'Completed'.
+// Failures are handled according to the provided failure policy.
+func (rup *runtimeupdate) reconcileSignalNodeStatusTarget(ctx context.Context, planID string, cmdStatus apv1beta2.PlanCommandStatus, delegate apdel.ControllerDelegate, signalNodes []apv1beta2.PlanCommandTargetStatus, failurePolicy *apv1beta2.PlanCommandTargetFailurePolicy) {
+	for i := range signalNodes {
+		if appku.IsDone(signalNodes[i]) {
+			continue
+		}
+
+		key := delegate.CreateNamespacedName(signalNodes[i].Name)
+		signalNode := delegate.CreateObject()
+
+		if err := rup.client.Get(ctx, key, signalNode); err != nil {
+			rup.logger.Warnf("Unable to find signal node '%s'", signalNodes[i].Name)
+			continue
+		}
+
+		if apsigv2.IsSignalingPresent(signalNode.GetAnnotations()) {
+			var signalData apsigv2.SignalData
+			if err := signalData.Unmarshal(signalNode.GetAnnotations()); err == nil {
+				if signalData.PlanID == planID {
+					// Ensure that the commands are the same, but their status's are different before we check completed.
+					if appku.IsSignalDataSameCommand(cmdStatus, signalData) && appku.IsSignalDataStatusDifferent(signalNodes[i], signalData.Status) {
+						origState := signalNodes[i].State
+
+						if signalData.Status.Status == apsigcomm.Failed || signalData.Status.Status == apsigcomm.FailedDownload {
+							if !appku.HandleTargetFailure(&signalNodes[i], failurePolicy) {
+								continue
+							}
+						}
+
+						if signalData.Status.Status == apsigcomm.Completed {
+							signalNodes[i].State = appc.SignalCompleted
+						}
+
+						signalNodes[i].LastUpdatedTimestamp = metav1.Now()
+
+						rup.logger.Infof("Signal node '%s' status changed from '%s' to '%s' (reason: %s)", signalNodes[i].Name, origState, signalNodes[i].State, signalData.Status.Status)
+					}
+				} else {
+					rup.logger.Warnf("Current planid '%v' doesn't match signal node planid '%v'", planID, signalData.PlanID)
+				}
+			} else {
+				rup.logger.Warnf("Unable to unmarshal signaling data from signal node '%s'", signalNode.GetName())
+			}
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	aphg "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/healthgate"
 )
 
 // fakePlanCommandProvider is a testable `PlanCommandProvider` that provides functions for
@@ -14,7 +15,7 @@ import (
 type fakePlanCommandProvider struct {
 	commandID              string
 	handlerNewPlan         func(context.Context, apv1beta2.PlanCommand, *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error)
-	handlerSchedulable     func(context.Context, string, apv1beta2.PlanCommand, *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error)
+	handlerSchedulable     func(context.Context, string, SignalOptions, apv1beta2.PlanCommand, *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error)
 	handlerSchedulableWait func(context.Context, string, apv1beta2.PlanCommand, *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error)
 }
 
@@ -28,10 +29,19 @@ func (f fakePlanCommandProvider) NewPlan(ctx context.Context, cmd apv1beta2.Plan
 	return f.handlerNewPlan(ctx, cmd, status)
 }
 
-func (f fakePlanCommandProvider) Schedulable(ctx context.Context, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
-	return f.handlerSchedulable(ctx, planID, cmd, status)
+func (f fakePlanCommandProvider) Schedulable(ctx context.Context, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+	return f.handlerSchedulable(ctx, planID, signal, cmd, status)
 }
 
 func (f fakePlanCommandProvider) SchedulableWait(ctx context.Context, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 	return f.handlerSchedulableWait(ctx, planID, cmd, status)
 }
+
+// fakeHealthGateEvaluator is a testable `Evaluator` that delegates to a function.
+type fakeHealthGateEvaluator func(context.Context, apv1beta2.PlanHealthGate, []string) error
+
+var _ aphg.Evaluator = (fakeHealthGateEvaluator)(nil)
+
+func (f fakeHealthGateEvaluator) Evaluate(ctx context.Context, gate apv1beta2.PlanHealthGate, nodes []string) error {
+	return f(ctx, gate, nodes)
+}
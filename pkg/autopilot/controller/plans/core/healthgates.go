@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	aphg "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/healthgate"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultHealthGateTimeout = 10 * time.Minute
+
+// checkHealthGates evaluates all of the health gates of the plan, and keeps track of
+// the failing ones in the plan status. It reports whether all of the gates are passing,
+// and whether any of the failing gates has exceeded its timeout.
+func checkHealthGates(ctx context.Context, evaluator aphg.Evaluator, plan *apv1beta2.Plan, now time.Time) (passing, expired bool) {
+	nodes := updatedNodes(plan.Status.Commands)
+	previous := plan.Status.HealthGates
+	plan.Status.HealthGates = nil
+
+	for _, gate := range plan.Spec.HealthGates {
+		err := evaluator.Evaluate(ctx, gate, nodes)
+		if err == nil {
+			continue
+		}
+
+		status := apv1beta2.PlanHealthGateStatus{
+			Name:         gate.Name,
+			FailingSince: metav1.NewTime(now),
+			Message:      err.Error(),
+		}
+
+		for _, prev := range previous {
+			if prev.Name == gate.Name {
+				status.FailingSince = prev.FailingSince
+				break
+			}
+		}
+
+		timeout := gate.Timeout.Duration
+		if timeout == 0 {
+			timeout = defaultHealthGateTimeout
+		}
+
+		if now.Sub(status.FailingSince.Time) > timeout {
+			expired = true
+		}
+
+		plan.Status.HealthGates = append(plan.Status.HealthGates, status)
+	}
+
+	return len(plan.Status.HealthGates) == 0, expired
+}
+
+// updatedNodes returns the names of all worker nodes that have been successfully
+// updated by any of the provided commands.
+func updatedNodes(cmdStatuses []apv1beta2.PlanCommandStatus) []string {
+	var nodes []string
+
+	collect := func(targets []apv1beta2.PlanCommandTargetStatus) {
+		for _, target := range targets {
+			if target.State == SignalCompleted {
+				nodes = append(nodes, target.Name)
+			}
+		}
+	}
+
+	for _, cmdStatus := range cmdStatuses {
+		if cmdStatus.K0sUpdate != nil {
+			collect(cmdStatus.K0sUpdate.Workers)
+		}
+
+		if cmdStatus.AirgapUpdate != nil {
+			collect(cmdStatus.AirgapUpdate.Workers)
+		}
+
+		if cmdStatus.RuntimeUpdate != nil {
+			collect(cmdStatus.RuntimeUpdate.Workers)
+		}
+	}
+
+	return nodes
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestCheckHealthGates ensures that failing health gates are tracked in the plan
+// status, and that they expire after their timeout.
+func TestCheckHealthGates(t *testing.T) {
+	now := time.Now()
+
+	var evaluatedNodes []string
+	evaluator := fakeHealthGateEvaluator(func(ctx context.Context, gate apv1beta2.PlanHealthGate, nodes []string) error {
+		evaluatedNodes = nodes
+		if gate.Name == "failing" {
+			return errors.New("nope")
+		}
+		return nil
+	})
+
+	newPlan := func(gates ...apv1beta2.PlanHealthGate) *apv1beta2.Plan {
+		return &apv1beta2.Plan{
+			Spec: apv1beta2.PlanSpec{HealthGates: gates},
+			Status: apv1beta2.PlanStatus{
+				Commands: []apv1beta2.PlanCommandStatus{{
+					K0sUpdate: &apv1beta2.PlanCommandK0sUpdateStatus{
+						Controllers: []apv1beta2.PlanCommandTargetStatus{{Name: "controller0", State: SignalCompleted}},
+						Workers: []apv1beta2.PlanCommandTargetStatus{
+							{Name: "worker0", State: SignalCompleted},
+							{Name: "worker1", State: SignalPending},
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	t.Run("Passing", func(t *testing.T) {
+		plan := newPlan(apv1beta2.PlanHealthGate{Name: "passing"})
+		plan.Status.HealthGates = []apv1beta2.PlanHealthGateStatus{{Name: "passing", FailingSince: metav1.NewTime(now)}}
+
+		passing, expired := checkHealthGates(t.Context(), evaluator, plan, now)
+		assert.True(t, passing)
+		assert.False(t, expired)
+		assert.Empty(t, plan.Status.HealthGates)
+		assert.Equal(t, []string{"worker0"}, evaluatedNodes)
+	})
+
+	t.Run("Failing", func(t *testing.T) {
+		plan := newPlan(apv1beta2.PlanHealthGate{Name: "passing"}, apv1beta2.PlanHealthGate{Name: "failing"})
+
+		passing, expired := checkHealthGates(t.Context(), evaluator, plan, now)
+		assert.False(t, passing)
+		assert.False(t, expired)
+		assert.Equal(t, []apv1beta2.PlanHealthGateStatus{
+			{Name: "failing", FailingSince: metav1.NewTime(now), Message: "nope"},
+		}, plan.Status.HealthGates)
+	})
+
+	t.Run("StillFailing", func(t *testing.T) {
+		since := metav1.NewTime(now.Add(-5 * time.Minute))
+		plan := newPlan(apv1beta2.PlanHealthGate{Name: "failing"})
+		plan.Status.HealthGates = []apv1beta2.PlanHealthGateStatus{{Name: "failing", FailingSince: since}}
+
+		passing, expired := checkHealthGates(t.Context(), evaluator, plan, now)
+		assert.False(t, passing)
+		assert.False(t, expired)
+		assert.Equal(t, since, plan.Status.HealthGates[0].FailingSince)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		plan := newPlan(apv1beta2.PlanHealthGate{Name: "failing", Timeout: metav1.Duration{Duration: time.Minute}})
+		plan.Status.HealthGates = []apv1beta2.PlanHealthGateStatus{{Name: "failing", FailingSince: metav1.NewTime(now.Add(-2 * time.Minute))}}
+
+		passing, expired := checkHealthGates(t.Context(), evaluator, plan, now)
+		assert.False(t, passing)
+		assert.True(t, expired)
+	})
+}
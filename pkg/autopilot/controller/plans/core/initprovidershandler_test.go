@@ -57,7 +57,7 @@ func TestInitProvidersHandle(t *testing.T) {
 			},
 			NewInitProvidersHandler(
 				logger,
-				func(ctx context.Context, provider PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+				func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 					return provider.NewPlan(ctx, cmd, status)
 				},
 				PlanSchedulableWait,
@@ -68,7 +68,7 @@ func TestInitProvidersHandle(t *testing.T) {
 
 						return PlanSchedulableWait, false, nil
 					},
-					handlerSchedulable: func(ctx context.Context, planID string, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					handlerSchedulable: func(ctx context.Context, planID string, signal SignalOptions, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 						return PlanSchedulableWait, false, errors.New("should not have reached schedulable")
 					},
 					handlerSchedulableWait: func(ctx context.Context, planID string, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
@@ -103,8 +103,8 @@ func TestInitProvidersHandle(t *testing.T) {
 			},
 			NewInitProvidersHandler(
 				logger,
-				func(ctx context.Context, provider PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
-					return provider.Schedulable(ctx, planID, cmd, status)
+				func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					return provider.Schedulable(ctx, planID, signal, cmd, status)
 				},
 				PlanSchedulableWait,
 				fakePlanCommandProvider{
@@ -135,8 +135,8 @@ func TestInitProvidersHandle(t *testing.T) {
 			},
 			NewInitProvidersHandler(
 				logger,
-				func(ctx context.Context, provider PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
-					return provider.Schedulable(ctx, planID, cmd, status)
+				func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					return provider.Schedulable(ctx, planID, signal, cmd, status)
 				},
 				PlanSchedulableWait,
 				fakePlanCommandProvider{
@@ -165,7 +165,7 @@ func TestInitProvidersHandle(t *testing.T) {
 			},
 			NewInitProvidersHandler(
 				logger,
-				func(ctx context.Context, provider PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+				func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 					return PlanSchedulableWait, false, assert.AnError
 				},
 				PlanSchedulableWait,
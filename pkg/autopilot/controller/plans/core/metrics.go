@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	apmetrics "github.com/k0sproject/k0s/pkg/autopilot/metrics"
+)
+
+// recordPlanMetrics records the state of the plan, and the states of all of
+// the targets of its commands.
+func recordPlanMetrics(plan *apv1beta2.Plan) {
+	state := plan.Status.State
+	inProgress := state == PlanSchedulable || state == PlanSchedulableWait
+
+	apmetrics.RecordPlan(plan.Spec.ID, string(state), inProgress, countPlanTargets(plan.Status.Commands))
+}
+
+// countPlanTargets counts the targets of all provided command statuses by their
+// state, grouped by command and target group.
+func countPlanTargets(cmdStatuses []apv1beta2.PlanCommandStatus) map[apmetrics.PlanTargetKey]map[string]int {
+	counts := make(map[apmetrics.PlanTargetKey]map[string]int)
+
+	count := func(command, target string, statuses []apv1beta2.PlanCommandTargetStatus) {
+		key := apmetrics.PlanTargetKey{Command: command, Target: target}
+		if counts[key] == nil {
+			counts[key] = make(map[string]int)
+		}
+
+		for _, status := range statuses {
+			counts[key][string(status.State)]++
+		}
+	}
+
+	for _, cmdStatus := range cmdStatuses {
+		if cmdStatus.K0sUpdate != nil {
+			count("K0sUpdate", "controller", cmdStatus.K0sUpdate.Controllers)
+			count("K0sUpdate", "worker", cmdStatus.K0sUpdate.Workers)
+		}
+
+		if cmdStatus.AirgapUpdate != nil {
+			count("AirgapUpdate", "worker", cmdStatus.AirgapUpdate.Workers)
+		}
+
+		if cmdStatus.RuntimeUpdate != nil {
+			count("RuntimeUpdate", "worker", cmdStatus.RuntimeUpdate.Workers)
+		}
+
+		if cmdStatus.HelmUpdate != nil {
+			count("HelmUpdate", "chart", cmdStatus.HelmUpdate.Targets())
+		}
+	}
+
+	return counts
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+)
+
+// planNotificationEvent determines which notification event, if any, is
+// represented by the transition of a plan from one state to another.
+func planNotificationEvent(from, to apv1beta2.PlanStateType) (apv1beta2.PlanNotificationEvent, bool) {
+	if from == to {
+		return "", false
+	}
+
+	switch to {
+	case PlanCompleted:
+		return apv1beta2.PlanNotificationCompleted, true
+
+	case PlanSchedulable, PlanSchedulableWait:
+		// Only the initial transition of a new plan is of interest, as the
+		// plan alternates between these states while being executed.
+		if from == "" {
+			return apv1beta2.PlanNotificationStarted, true
+		}
+
+		return "", false
+
+	case PlanInconsistentTargets, PlanIncompleteTargets, PlanRestricted,
+		PlanMissingSignalNode, PlanApplyFailed, PlanHealthGateFailed:
+		return apv1beta2.PlanNotificationFailed, true
+
+	default:
+		// Other states, e.g. Warning, don't end the plan.
+		return "", false
+	}
+}
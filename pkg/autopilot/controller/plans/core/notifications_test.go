@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"testing"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPlanNotificationEvent ensures that plan state transitions are mapped to
+// the proper notification events.
+func TestPlanNotificationEvent(t *testing.T) {
+	var tests = []struct {
+		from, to apv1beta2.PlanStateType
+		event    apv1beta2.PlanNotificationEvent
+		ok       bool
+	}{
+		{"", PlanSchedulableWait, apv1beta2.PlanNotificationStarted, true},
+		{PlanSchedulableWait, PlanSchedulable, "", false},
+		{PlanSchedulable, PlanSchedulableWait, "", false},
+		{PlanSchedulableWait, PlanSchedulableWait, "", false},
+		{PlanSchedulableWait, PlanCompleted, apv1beta2.PlanNotificationCompleted, true},
+		{PlanSchedulableWait, PlanApplyFailed, apv1beta2.PlanNotificationFailed, true},
+		{"", PlanRestricted, apv1beta2.PlanNotificationFailed, true},
+		{PlanSchedulable, PlanHealthGateFailed, apv1beta2.PlanNotificationFailed, true},
+		{"", PlanWarning, "", false},
+		{PlanWarning, PlanSchedulableWait, "", false},
+		{"", "SomethingElse", "", false},
+		{PlanCompleted, PlanCompleted, "", false},
+	}
+
+	for _, test := range tests {
+		event, ok := planNotificationEvent(test.from, test.to)
+		assert.Equal(t, test.ok, ok, "%q -> %q", test.from, test.to)
+		assert.Equal(t, test.event, event, "%q -> %q", test.from, test.to)
+	}
+}
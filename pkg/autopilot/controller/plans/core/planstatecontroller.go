@@ -9,6 +9,7 @@ import (
 	"time"
 
 	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	apnotify "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/notifier"
 
 	"github.com/sirupsen/logrus"
 	cr "sigs.k8s.io/controller-runtime"
@@ -21,20 +22,23 @@ const (
 )
 
 type planStateController struct {
-	name    string
-	logger  *logrus.Entry
-	client  crcli.Client
-	handler PlanStateHandler
+	name     string
+	logger   *logrus.Entry
+	client   crcli.Client
+	handler  PlanStateHandler
+	notifier apnotify.Notifier
 }
 
 // NewPlanStateController creates a new `PlanStateController` with parameterized handler
-// for specialized reconciliation processing.
-func NewPlanStateController(name string, logger *logrus.Entry, client crcli.Client, handler PlanStateHandler) crrec.Reconciler {
+// for specialized reconciliation processing. Plan state transitions are reported to the
+// provided notifier, if any.
+func NewPlanStateController(name string, logger *logrus.Entry, client crcli.Client, handler PlanStateHandler, notifier apnotify.Notifier) crrec.Reconciler {
 	return &planStateController{
-		name:    name,
-		logger:  logger,
-		client:  client,
-		handler: handler,
+		name:     name,
+		logger:   logger,
+		client:   client,
+		handler:  handler,
+		notifier: notifier,
 	}
 }
 
@@ -70,5 +74,17 @@ func (c *planStateController) Reconcile(ctx context.Context, req cr.Request) (cr
 		return cr.Result{}, fmt.Errorf("unable to update plan '%s' with status: %w", req.NamespacedName, err)
 	}
 
+	recordPlanMetrics(planCopy)
+
+	if event, ok := planNotificationEvent(plan.Status.State, planCopy.Status.State); ok && c.notifier != nil {
+		c.notifier.Notify(ctx, planCopy.Spec.Notifications, apnotify.NewNotification(event, planCopy))
+	}
+
+	// Plans that are held back need to be polled until they can proceed.
+	if res == ProviderResultHold {
+		logger.Info("Requeuing held back plan")
+		return cr.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
 	return cr.Result{}, nil
 }
@@ -121,7 +121,7 @@ func TestReconcile(t *testing.T) {
 			Build()
 
 		t.Run(test.name, func(t *testing.T) {
-			controller := NewPlanStateController(test.name, logrus.NewEntry(logrus.StandardLogger()), client, test.handler)
+			controller := NewPlanStateController(test.name, logrus.NewEntry(logrus.StandardLogger()), client, test.handler, nil)
 			req := cr.Request{NamespacedName: types.NamespacedName{Name: test.name}}
 
 			ctx := t.Context()
@@ -6,8 +6,11 @@ package core
 import (
 	"context"
 	"fmt"
+	"time"
 
 	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
+	aphg "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/healthgate"
 
 	"github.com/sirupsen/logrus"
 )
@@ -16,18 +19,20 @@ type planStateHandler struct {
 	logger             *logrus.Entry
 	commandProviderMap map[string]PlanCommandProvider
 	adapter            PlanStateHandlerAdapter
+	healthGates        aphg.Evaluator
 }
 
 // NewPlanStateHandler creates a new `PlanStateHandler` that will register the supplied `PlanCommandProvider`s,
-// and setup delegation to the provided adapter for processing.
-func NewPlanStateHandler(logger *logrus.Entry, adapter PlanStateHandlerAdapter, commandProviders ...PlanCommandProvider) PlanStateHandler {
+// and setup delegation to the provided adapter for processing. The health gates of plans are
+// evaluated using the provided evaluator before any plan is allowed to become `Schedulable`.
+func NewPlanStateHandler(logger *logrus.Entry, adapter PlanStateHandlerAdapter, healthGates aphg.Evaluator, commandProviders ...PlanCommandProvider) PlanStateHandler {
 	commandProviderMap := make(map[string]PlanCommandProvider)
 
 	for _, cp := range commandProviders {
 		commandProviderMap[cp.CommandID()] = cp
 	}
 
-	return &planStateHandler{logger, commandProviderMap, adapter}
+	return &planStateHandler{logger, commandProviderMap, adapter, healthGates}
 }
 
 // Handle will attempt to process the first non-Completed command, delegating its functionality
@@ -60,11 +65,22 @@ func (h *planStateHandler) Handle(ctx context.Context, plan *apv1beta2.Plan) (Pr
 			continue
 		}
 
+		// A paused plan must not signal any new nodes. If the plan is about to do so,
+		// send it back to 'SchedulableWait', which keeps track of in-flight updates.
+
+		if plan.Status.State == PlanSchedulable && IsPlanPaused(plan) {
+			logger.Info("Plan is paused, not scheduling any new nodes")
+			cmdStatus.State = PlanSchedulableWait
+			plan.Status.State = PlanSchedulableWait
+			return ProviderResultHold, nil
+		}
+
 		// It is the adapters implementation who is responsible for providing the proper status
 		// for executing the command.
 
+		signal := SignalOptions{Proxy: plan.Spec.Proxy, NodeTimeout: plan.Spec.NodeTimeout}
 		originalPlanCommandState := cmdStatus.State
-		nextState, retry, err := h.adapter(ctx, cmdHandler, plan.Spec.ID, cmd, cmdStatus)
+		nextState, retry, err := h.adapter(ctx, cmdHandler, plan.Spec.ID, signal, cmd, cmdStatus)
 
 		// If we're asked to retry, we can ignore any errors and state transition as this is an effective
 		// 'redo' of the operation.
@@ -79,6 +95,36 @@ func (h *planStateHandler) Handle(ctx context.Context, plan *apv1beta2.Plan) (Pr
 			return ProviderResultFailure, fmt.Errorf("error in plan state adapter: %w", err)
 		}
 
+		// Sending a paused plan to 'Schedulable' is held back until it gets resumed. The
+		// updated status is still persisted.
+
+		if nextState == PlanSchedulable && IsPlanPaused(plan) {
+			logger.Info("Plan is paused, holding back scheduling")
+			cmdStatus.State = PlanSchedulableWait
+			plan.Status.State = PlanSchedulableWait
+			return ProviderResultHold, nil
+		}
+
+		// The same applies to plans with failing health gates. Once a health gate has been
+		// failing for longer than its timeout, the plan fails.
+
+		if nextState == PlanSchedulable && len(plan.Spec.HealthGates) > 0 {
+			passing, expired := checkHealthGates(ctx, h.healthGates, plan, time.Now())
+			if expired {
+				logger.Warn("Health gates have timed out")
+				cmdStatus.State = PlanHealthGateFailed
+				plan.Status.State = PlanHealthGateFailed
+				return ProviderResultSuccess, nil
+			}
+
+			if !passing {
+				logger.Info("Health gates are failing, holding back scheduling")
+				cmdStatus.State = PlanSchedulableWait
+				plan.Status.State = PlanSchedulableWait
+				return ProviderResultHold, nil
+			}
+		}
+
 		// If the command has indicated that it is 'Completed', don't use this state for the plan, as its
 		// the completion of this loop which determines 'Completed'. This requires another iteration.
 
@@ -102,6 +148,11 @@ func (h *planStateHandler) Handle(ctx context.Context, plan *apv1beta2.Plan) (Pr
 	return ProviderResultSuccess, nil
 }
 
+// IsPlanPaused determines if the plan has been paused via annotation.
+func IsPlanPaused(plan *apv1beta2.Plan) bool {
+	return plan.GetAnnotations()[apconst.PlanPausedAnnotation] == "true"
+}
+
 // ensurePlanStatusSymmetry ensures that if any command status are provided, that they
 // match in total with the number of commands in the `Plan`.
 func ensurePlanStatusSymmetry(plan *apv1beta2.Plan) bool {
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
 	apscheme2 "github.com/k0sproject/k0s/pkg/client/clientset/scheme"
 
 	"github.com/sirupsen/logrus"
@@ -46,6 +47,8 @@ func TestHandle(t *testing.T) {
 							},
 						},
 					},
+					Proxy:       &apv1beta2.PlanProxy{URL: "http://proxy.example.com:3128"},
+					NodeTimeout: &apv1beta2.PlanNodeTimeout{Uncordon: true},
 				},
 				Status: apv1beta2.PlanStatus{
 					State: PlanSchedulable,
@@ -53,16 +56,23 @@ func TestHandle(t *testing.T) {
 			},
 			NewPlanStateHandler(
 				logger,
-				func(ctx context.Context, provider PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
-					return provider.Schedulable(ctx, planID, cmd, status)
+				func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					return provider.Schedulable(ctx, planID, signal, cmd, status)
 				},
+				nil,
 				fakePlanCommandProvider{
 					commandID: "K0sUpdate",
 					handlerNewPlan: func(ctx context.Context, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 						return PlanSchedulableWait, false, errors.New("should not have reached newplan")
 					},
-					handlerSchedulable: func(ctx context.Context, planID string, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					handlerSchedulable: func(ctx context.Context, planID string, signal SignalOptions, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 						assert.Equal(t, "v1.2.3", pc.K0sUpdate.Version)
+						if assert.NotNil(t, signal.Proxy) {
+							assert.Equal(t, "http://proxy.example.com:3128", signal.Proxy.URL)
+						}
+						if assert.NotNil(t, signal.NodeTimeout) {
+							assert.True(t, signal.NodeTimeout.Uncordon)
+						}
 						pcs.K0sUpdate = &apv1beta2.PlanCommandK0sUpdateStatus{}
 
 						return PlanCompleted, false, nil
@@ -95,9 +105,10 @@ func TestHandle(t *testing.T) {
 			},
 			NewPlanStateHandler(
 				logger,
-				func(ctx context.Context, provider PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
-					return provider.Schedulable(ctx, planID, cmd, status)
+				func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					return provider.Schedulable(ctx, planID, signal, cmd, status)
 				},
+				nil,
 				fakePlanCommandProvider{
 					commandID: "UnknownProvider",
 				},
@@ -126,9 +137,10 @@ func TestHandle(t *testing.T) {
 			},
 			NewPlanStateHandler(
 				logger,
-				func(ctx context.Context, provider PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
-					return provider.Schedulable(ctx, planID, cmd, status)
+				func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					return provider.Schedulable(ctx, planID, signal, cmd, status)
 				},
+				nil,
 				fakePlanCommandProvider{
 					commandID: "UnknownProvider",
 				},
@@ -168,6 +180,7 @@ func TestHandle(t *testing.T) {
 			NewPlanStateHandler(
 				logger,
 				nil,
+				nil,
 				fakePlanCommandProvider{
 					commandID: "K0sUpdate",
 				},
@@ -194,9 +207,10 @@ func TestHandle(t *testing.T) {
 			},
 			NewPlanStateHandler(
 				logger,
-				func(ctx context.Context, provider PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+				func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 					return PlanSchedulableWait, false, assert.AnError
 				},
+				nil,
 				fakePlanCommandProvider{
 					commandID: "K0sUpdate",
 				},
@@ -242,15 +256,16 @@ func TestHandle(t *testing.T) {
 			},
 			NewPlanStateHandler(
 				logger,
-				func(ctx context.Context, provider PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
-					return provider.Schedulable(ctx, planID, cmd, status)
+				func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					return provider.Schedulable(ctx, planID, signal, cmd, status)
 				},
+				nil,
 				fakePlanCommandProvider{
 					commandID: "K0sUpdate",
 					handlerNewPlan: func(ctx context.Context, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 						return pcs.State, false, errors.New("should not have reached newplan")
 					},
-					handlerSchedulable: func(ctx context.Context, planID string, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					handlerSchedulable: func(ctx context.Context, planID string, signal SignalOptions, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 						// Ensures that only the second command makes it here
 						assert.Equal(t, "v2", pc.K0sUpdate.Version)
 
@@ -296,15 +311,16 @@ func TestHandle(t *testing.T) {
 			},
 			NewPlanStateHandler(
 				logger,
-				func(ctx context.Context, provider PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
-					return provider.Schedulable(ctx, planID, cmd, status)
+				func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					return provider.Schedulable(ctx, planID, signal, cmd, status)
 				},
+				nil,
 				fakePlanCommandProvider{
 					commandID: "K0sUpdate",
 					handlerNewPlan: func(ctx context.Context, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 						return pcs.State, false, errors.New("should not have reached newplan")
 					},
-					handlerSchedulable: func(ctx context.Context, planID string, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					handlerSchedulable: func(ctx context.Context, planID string, signal SignalOptions, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 						pcs.K0sUpdate = &apv1beta2.PlanCommandK0sUpdateStatus{}
 						return PlanCompleted, false, nil
 					},
@@ -347,15 +363,16 @@ func TestHandle(t *testing.T) {
 			},
 			NewPlanStateHandler(
 				logger,
-				func(ctx context.Context, provider PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
-					return provider.Schedulable(ctx, planID, cmd, status)
+				func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					return provider.Schedulable(ctx, planID, signal, cmd, status)
 				},
+				nil,
 				fakePlanCommandProvider{
 					commandID: "K0sUpdate",
 					handlerNewPlan: func(ctx context.Context, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 						return pcs.State, false, errors.New("should not have reached newplan")
 					},
-					handlerSchedulable: func(ctx context.Context, planID string, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					handlerSchedulable: func(ctx context.Context, planID string, signal SignalOptions, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 						pcs.K0sUpdate = &apv1beta2.PlanCommandK0sUpdateStatus{}
 						return PlanCompleted, false, nil
 					},
@@ -403,15 +420,16 @@ func TestHandle(t *testing.T) {
 			},
 			NewPlanStateHandler(
 				logger,
-				func(ctx context.Context, provider PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
-					return provider.Schedulable(ctx, planID, cmd, status)
+				func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					return provider.Schedulable(ctx, planID, signal, cmd, status)
 				},
+				nil,
 				fakePlanCommandProvider{
 					commandID: "K0sUpdate",
 					handlerNewPlan: func(ctx context.Context, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 						return pcs.State, false, errors.New("should not have reached newplan")
 					},
-					handlerSchedulable: func(ctx context.Context, planID string, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					handlerSchedulable: func(ctx context.Context, planID string, signal SignalOptions, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 						pcs.K0sUpdate = &apv1beta2.PlanCommandK0sUpdateStatus{}
 						return pcs.State, true, nil
 					},
@@ -432,6 +450,111 @@ func TestHandle(t *testing.T) {
 				},
 			},
 		},
+
+		// Ensure that a paused plan in 'Schedulable' goes back to 'SchedulableWait' without signaling
+		{
+			"PausedSchedulable",
+			&apv1beta2.Plan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "PausedSchedulable",
+					Annotations: map[string]string{apconst.PlanPausedAnnotation: "true"},
+				},
+				Spec: apv1beta2.PlanSpec{
+					Commands: []apv1beta2.PlanCommand{
+						{
+							K0sUpdate: &apv1beta2.PlanCommandK0sUpdate{},
+						},
+					},
+				},
+				Status: apv1beta2.PlanStatus{
+					State: PlanSchedulable,
+					Commands: []apv1beta2.PlanCommandStatus{
+						{
+							State:     PlanSchedulable,
+							K0sUpdate: &apv1beta2.PlanCommandK0sUpdateStatus{},
+						},
+					},
+				},
+			},
+			NewPlanStateHandler(
+				logger,
+				func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					return provider.Schedulable(ctx, planID, signal, cmd, status)
+				},
+				nil,
+				fakePlanCommandProvider{
+					commandID: "K0sUpdate",
+					handlerSchedulable: func(ctx context.Context, planID string, signal SignalOptions, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+						return pcs.State, false, errors.New("should not have reached schedulable")
+					},
+				},
+			),
+			ProviderResultHold,
+			false,
+			&apv1beta2.PlanStatus{
+				State: PlanSchedulableWait,
+				Commands: []apv1beta2.PlanCommandStatus{
+					{
+						State:     PlanSchedulableWait,
+						K0sUpdate: &apv1beta2.PlanCommandK0sUpdateStatus{},
+					},
+				},
+			},
+		},
+
+		// Ensure that a paused plan in 'SchedulableWait' is held back, but keeps its updated status
+		{
+			"PausedSchedulableWait",
+			&apv1beta2.Plan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "PausedSchedulableWait",
+					Annotations: map[string]string{apconst.PlanPausedAnnotation: "true"},
+				},
+				Spec: apv1beta2.PlanSpec{
+					Commands: []apv1beta2.PlanCommand{
+						{
+							K0sUpdate: &apv1beta2.PlanCommandK0sUpdate{},
+						},
+					},
+				},
+				Status: apv1beta2.PlanStatus{
+					State: PlanSchedulableWait,
+					Commands: []apv1beta2.PlanCommandStatus{
+						{
+							State:     PlanSchedulableWait,
+							K0sUpdate: &apv1beta2.PlanCommandK0sUpdateStatus{},
+						},
+					},
+				},
+			},
+			NewPlanStateHandler(
+				logger,
+				func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					return provider.SchedulableWait(ctx, planID, cmd, status)
+				},
+				nil,
+				fakePlanCommandProvider{
+					commandID: "K0sUpdate",
+					handlerSchedulableWait: func(ctx context.Context, planID string, pc apv1beta2.PlanCommand, pcs *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+						pcs.K0sUpdate.Workers = []apv1beta2.PlanCommandTargetStatus{{Name: "worker0", State: SignalCompleted}}
+						return PlanSchedulable, false, nil
+					},
+				},
+			),
+			ProviderResultHold,
+			false,
+			&apv1beta2.PlanStatus{
+				State: PlanSchedulableWait,
+				Commands: []apv1beta2.PlanCommandStatus{
+					{
+						State: PlanSchedulableWait,
+						K0sUpdate: &apv1beta2.PlanCommandK0sUpdateStatus{
+							Workers: []apv1beta2.PlanCommandTargetStatus{{Name: "worker0", State: SignalCompleted}},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	scheme := runtime.NewScheme()
@@ -21,6 +21,7 @@ var (
 	PlanRestricted          apv1beta2.PlanStateType = "Restricted"
 	PlanMissingSignalNode   apv1beta2.PlanStateType = "MissingSignalNode"
 	PlanApplyFailed         apv1beta2.PlanStateType = "ApplyFailed"
+	PlanHealthGateFailed    apv1beta2.PlanStateType = "HealthGateFailed"
 )
 
 // PlanCommandStatusType
@@ -31,6 +32,7 @@ var (
 	SignalMissingNode     apv1beta2.PlanCommandTargetStateType = "SignalMissingNode"
 	SignalMissingPlatform apv1beta2.PlanCommandTargetStateType = "SignalMissingPlatform"
 	SignalApplyFailed     apv1beta2.PlanCommandTargetStateType = "SignalApplyFailed"
+	SignalSkipped         apv1beta2.PlanCommandTargetStateType = "SignalSkipped"
 )
 
 type ProviderResult int
@@ -39,6 +41,7 @@ const (
 	ProviderResultSuccess ProviderResult = iota
 	ProviderResultFailure
 	ProviderResultRetry
+	ProviderResultHold
 )
 
 // PlanStateHandler defines the implementation of how a `PlanStateController` will perform
@@ -51,7 +54,16 @@ type PlanStateHandler interface {
 
 // PlanStateHandlerAdapter defines an adapter function between the `PlanStateController`, and the
 // specific function to call in the resolved `PlanCommandProvider`.
-type PlanStateHandlerAdapter func(ctx context.Context, provider PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error)
+type PlanStateHandlerAdapter func(ctx context.Context, provider PlanCommandProvider, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error)
+
+// SignalOptions holds the plan-wide settings that are sent along with the
+// signaling of nodes.
+type SignalOptions struct {
+	// Proxy overrides the proxy settings that nodes use for downloads.
+	Proxy *apv1beta2.PlanProxy
+	// NodeTimeout limits the time that nodes may spend applying a command.
+	NodeTimeout *apv1beta2.PlanNodeTimeout
+}
 
 // PlanCommandProviderMap is a mapping of command names to `PlanCommandProvider` instances.
 type PlanCommandProviderMap map[string]PlanCommandProvider
@@ -69,7 +81,7 @@ type PlanCommandProvider interface {
 	NewPlan(ctx context.Context, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error)
 
 	// Schedulable handles the provider state 'schedulable'
-	Schedulable(ctx context.Context, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error)
+	Schedulable(ctx context.Context, planID string, signal SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error)
 
 	// SchedulableWait handles the provider state 'schedulablewait'
 	SchedulableWait(ctx context.Context, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error)
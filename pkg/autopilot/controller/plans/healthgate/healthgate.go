@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthgate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	"github.com/k0sproject/k0s/pkg/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+
+	// maxBodySize limits the amount of data that is read from HTTP responses.
+	maxBodySize = 1 << 20
+)
+
+// Evaluator evaluates health gates.
+type Evaluator interface {
+	// Evaluate checks the health gate, returning an error describing why the
+	// gate is failing, or nil if the gate passes. The nodes are the names of the
+	// nodes that have already been updated by the plan.
+	Evaluate(ctx context.Context, gate apv1beta2.PlanHealthGate, nodes []string) error
+}
+
+type evaluator struct {
+	clientFactory kubernetes.ClientFactoryInterface
+	httpClient    *http.Client
+}
+
+var _ Evaluator = (*evaluator)(nil)
+
+// NewEvaluator creates a new `Evaluator` that uses the provided client factory
+// for querying the Kubernetes API.
+func NewEvaluator(cf kubernetes.ClientFactoryInterface) Evaluator {
+	return &evaluator{
+		clientFactory: cf,
+		httpClient:    &http.Client{Timeout: requestTimeout},
+	}
+}
+
+func (e *evaluator) Evaluate(ctx context.Context, gate apv1beta2.PlanHealthGate, nodes []string) error {
+	switch {
+	case gate.HTTP != nil && gate.Deployment == nil && gate.NodeCondition == nil:
+		return e.evaluateHTTP(ctx, gate.HTTP)
+	case gate.HTTP == nil && gate.Deployment != nil && gate.NodeCondition == nil:
+		return e.evaluateDeployment(ctx, gate.Deployment)
+	case gate.HTTP == nil && gate.Deployment == nil && gate.NodeCondition != nil:
+		return e.evaluateNodeCondition(ctx, gate.NodeCondition, nodes)
+	default:
+		return errors.New("exactly one of http, deployment or nodeCondition needs to be specified")
+	}
+}
+
+// evaluateHTTP checks that the URL responds with a 2xx status code and a
+// matching body.
+func (e *evaluator) evaluateHTTP(ctx context.Context, check *apv1beta2.PlanHealthGateHTTP) error {
+	var match *regexp.Regexp
+	if check.Match != "" {
+		var err error
+		if match, err = regexp.Compile(check.Match); err != nil {
+			return fmt.Errorf("invalid match expression: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, check.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	if match == nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if !match.Match(body) {
+		return fmt.Errorf("response body doesn't match %q", check.Match)
+	}
+
+	return nil
+}
+
+// evaluateDeployment checks that all of the replicas of the Deployment have
+// been updated and are available.
+func (e *evaluator) evaluateDeployment(ctx context.Context, check *apv1beta2.PlanHealthGateDeployment) error {
+	client, err := e.clientFactory.GetClient()
+	if err != nil {
+		return err
+	}
+
+	deployment, err := client.AppsV1().Deployments(check.Namespace).Get(ctx, check.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return errors.New("deployment spec hasn't been observed yet")
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	if status := deployment.Status; status.UpdatedReplicas < replicas || status.AvailableReplicas < replicas {
+		return fmt.Errorf("%d/%d replicas updated, %d/%d replicas available", status.UpdatedReplicas, replicas, status.AvailableReplicas, replicas)
+	}
+
+	return nil
+}
+
+// evaluateNodeCondition checks that all of the nodes have the condition in the
+// required status.
+func (e *evaluator) evaluateNodeCondition(ctx context.Context, check *apv1beta2.PlanHealthGateNodeCondition, nodes []string) error {
+	client, err := e.clientFactory.GetClient()
+	if err != nil {
+		return err
+	}
+
+	status := check.Status
+	if status == "" {
+		status = corev1.ConditionTrue
+	}
+
+	for _, name := range nodes {
+		node, err := client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if actual := nodeConditionStatus(node, check.Type); actual != status {
+			return fmt.Errorf("node %s has condition %s in status %q", name, check.Type, actual)
+		}
+	}
+
+	return nil
+}
+
+func nodeConditionStatus(node *corev1.Node, conditionType corev1.NodeConditionType) corev1.ConditionStatus {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status
+		}
+	}
+
+	return corev1.ConditionUnknown
+}
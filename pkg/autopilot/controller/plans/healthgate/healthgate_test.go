@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthgate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// TestEvaluate ensures that the different kinds of health gates are evaluated properly.
+func TestEvaluate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthy":
+			_, _ = w.Write([]byte(`{"status":"success","data":{"result":[]}}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cf := testutil.NewFakeClientFactory(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "available"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+			Status:     appsv1.DeploymentStatus{UpdatedReplicas: 2, AvailableReplicas: 2},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unavailable"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+			Status:     appsv1.DeploymentStatus{UpdatedReplicas: 2, AvailableReplicas: 1},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			}},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "notready"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionFalse},
+			}},
+		},
+	)
+
+	var tests = []struct {
+		name    string
+		gate    apv1beta2.PlanHealthGate
+		nodes   []string
+		passing bool
+	}{
+		{"Empty", apv1beta2.PlanHealthGate{}, nil, false},
+		{
+			"Ambiguous",
+			apv1beta2.PlanHealthGate{
+				HTTP:       &apv1beta2.PlanHealthGateHTTP{URL: server.URL + "/healthy"},
+				Deployment: &apv1beta2.PlanHealthGateDeployment{Namespace: "default", Name: "available"},
+			},
+			nil,
+			false,
+		},
+		{"HTTP", apv1beta2.PlanHealthGate{HTTP: &apv1beta2.PlanHealthGateHTTP{URL: server.URL + "/healthy"}}, nil, true},
+		{"HTTPBadStatus", apv1beta2.PlanHealthGate{HTTP: &apv1beta2.PlanHealthGateHTTP{URL: server.URL + "/unhealthy"}}, nil, false},
+		{"HTTPMatch", apv1beta2.PlanHealthGate{HTTP: &apv1beta2.PlanHealthGateHTTP{URL: server.URL + "/healthy", Match: `"result":\[\]`}}, nil, true},
+		{"HTTPNoMatch", apv1beta2.PlanHealthGate{HTTP: &apv1beta2.PlanHealthGateHTTP{URL: server.URL + "/healthy", Match: `"status":"error"`}}, nil, false},
+		{"DeploymentAvailable", apv1beta2.PlanHealthGate{Deployment: &apv1beta2.PlanHealthGateDeployment{Namespace: "default", Name: "available"}}, nil, true},
+		{"DeploymentUnavailable", apv1beta2.PlanHealthGate{Deployment: &apv1beta2.PlanHealthGateDeployment{Namespace: "default", Name: "unavailable"}}, nil, false},
+		{"DeploymentMissing", apv1beta2.PlanHealthGate{Deployment: &apv1beta2.PlanHealthGateDeployment{Namespace: "default", Name: "missing"}}, nil, false},
+		{"NodeConditionNoNodes", apv1beta2.PlanHealthGate{NodeCondition: &apv1beta2.PlanHealthGateNodeCondition{Type: corev1.NodeReady}}, nil, true},
+		{"NodeConditionReady", apv1beta2.PlanHealthGate{NodeCondition: &apv1beta2.PlanHealthGateNodeCondition{Type: corev1.NodeReady}}, []string{"ready"}, true},
+		{"NodeConditionNotReady", apv1beta2.PlanHealthGate{NodeCondition: &apv1beta2.PlanHealthGateNodeCondition{Type: corev1.NodeReady}}, []string{"ready", "notready"}, false},
+		{
+			"NodeConditionStatus",
+			apv1beta2.PlanHealthGate{NodeCondition: &apv1beta2.PlanHealthGateNodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionFalse}},
+			[]string{"notready"},
+			true,
+		},
+	}
+
+	evaluator := NewEvaluator(cf)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := evaluator.Evaluate(t.Context(), test.gate, test.nodes)
+			if test.passing {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
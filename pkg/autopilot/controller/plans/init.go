@@ -12,8 +12,12 @@ import (
 	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
 	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
 	appagupdate "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/cmdprovider/airgapupdate"
+	apphelmupdate "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/cmdprovider/helmupdate"
 	appk0supdate "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/cmdprovider/k0supdate"
+	apprtupdate "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/cmdprovider/runtimeupdate"
 	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+	aphg "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/healthgate"
+	apnotify "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/notifier"
 	"github.com/k0sproject/k0s/pkg/kubernetes"
 
 	"github.com/sirupsen/logrus"
@@ -31,6 +35,8 @@ func RegisterControllers(ctx context.Context, logger *logrus.Entry, mgr crman.Ma
 	cmdProviders := []appc.PlanCommandProvider{
 		appk0supdate.NewK0sUpdatePlanCommandProvider(logger, mgr.GetClient(), controllerDelegateMap, cf, excludeFromPlans),
 		appagupdate.NewAirgapUpdatePlanCommandProvider(logger, mgr.GetClient(), controllerDelegateMap, cf, excludeFromPlans),
+		apprtupdate.NewRuntimeUpdatePlanCommandProvider(logger, mgr.GetClient(), controllerDelegateMap, cf, excludeFromPlans),
+		apphelmupdate.NewHelmUpdatePlanCommandProvider(logger, mgr.GetClient()),
 	}
 
 	if leaderMode {
@@ -38,11 +44,11 @@ func RegisterControllers(ctx context.Context, logger *logrus.Entry, mgr crman.Ma
 			return fmt.Errorf("unable to register newplan controller: %w", err)
 		}
 
-		if err := registerSchedulableWaitStateController(logger, mgr, cmdProviders); err != nil {
+		if err := registerSchedulableWaitStateController(logger, mgr, aphg.NewEvaluator(cf), cmdProviders); err != nil {
 			return fmt.Errorf("unable to register schedulablewait controller: %w", err)
 		}
 
-		if err := registerSchedulableStateController(logger, mgr, cmdProviders); err != nil {
+		if err := registerSchedulableStateController(logger, mgr, aphg.NewEvaluator(cf), cmdProviders); err != nil {
 			return fmt.Errorf("unable to register schedulable controller: %w", err)
 		}
 	}
@@ -55,7 +61,7 @@ func RegisterControllers(ctx context.Context, logger *logrus.Entry, mgr crman.Ma
 func registerNewPlanStateController(logger *logrus.Entry, mgr crman.Manager, providers []appc.PlanCommandProvider) error {
 	handler := appc.NewInitProvidersHandler(
 		logger,
-		func(ctx context.Context, provider appc.PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+		func(ctx context.Context, provider appc.PlanCommandProvider, planID string, signal appc.SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 			return provider.NewPlan(ctx, cmd, status)
 		},
 		appc.PlanSchedulableWait,
@@ -67,12 +73,13 @@ func registerNewPlanStateController(logger *logrus.Entry, mgr crman.Manager, pro
 
 // registerSchedulableWaitStateController registers the 'schedulablewait' plan state controller to
 // controller-runtime.
-func registerSchedulableWaitStateController(logger *logrus.Entry, mgr crman.Manager, providers []appc.PlanCommandProvider) error {
+func registerSchedulableWaitStateController(logger *logrus.Entry, mgr crman.Manager, healthGates aphg.Evaluator, providers []appc.PlanCommandProvider) error {
 	handler := appc.NewPlanStateHandler(
 		logger,
-		func(ctx context.Context, provider appc.PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+		func(ctx context.Context, provider appc.PlanCommandProvider, planID string, signal appc.SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 			return provider.SchedulableWait(ctx, planID, cmd, status)
 		},
+		healthGates,
 		providers...,
 	)
 
@@ -81,12 +88,13 @@ func registerSchedulableWaitStateController(logger *logrus.Entry, mgr crman.Mana
 
 // registerSchedulableStateController registers the 'schedulable' plan state controller to
 // controller-runtime.
-func registerSchedulableStateController(logger *logrus.Entry, mgr crman.Manager, providers []appc.PlanCommandProvider) error {
+func registerSchedulableStateController(logger *logrus.Entry, mgr crman.Manager, healthGates aphg.Evaluator, providers []appc.PlanCommandProvider) error {
 	handler := appc.NewPlanStateHandler(
 		logger,
-		func(ctx context.Context, provider appc.PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
-			return provider.Schedulable(ctx, planID, cmd, status)
+		func(ctx context.Context, provider appc.PlanCommandProvider, planID string, signal appc.SignalOptions, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+			return provider.Schedulable(ctx, planID, signal, cmd, status)
 		},
+		healthGates,
 		providers...,
 	)
 
@@ -101,7 +109,7 @@ func registerPlanStateController(name string, logger *logrus.Entry, mgr crman.Ma
 		For(&apv1beta2.Plan{}).
 		WithEventFilter(eventFilter).
 		Complete(
-			appc.NewPlanStateController(name, logger, mgr.GetClient(), handler),
+			appc.NewPlanStateController(name, logger, mgr.GetClient(), handler, apnotify.NewWebhookNotifier(logger)),
 		)
 }
 
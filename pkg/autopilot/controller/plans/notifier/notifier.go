@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"text/template"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+
+	"github.com/sirupsen/logrus"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// Notification is the information about a plan state transition that is sent
+// to notification sinks.
+type Notification struct {
+	Event     apv1beta2.PlanNotificationEvent `json:"event"`
+	PlanID    string                          `json:"planId"`
+	State     string                          `json:"state"`
+	Timestamp string                          `json:"timestamp"`
+}
+
+// NewNotification creates a new notification for the provided plan, with the
+// current time as its timestamp.
+func NewNotification(event apv1beta2.PlanNotificationEvent, plan *apv1beta2.Plan) Notification {
+	return Notification{
+		Event:     event,
+		PlanID:    plan.Spec.ID,
+		State:     string(plan.Status.State),
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+}
+
+// Notifier delivers notifications to notification sinks.
+type Notifier interface {
+	// Notify delivers the notification to all of the sinks that are interested
+	// in its event. Delivery happens asynchronously, failures are logged.
+	Notify(ctx context.Context, sinks []apv1beta2.PlanNotification, notification Notification)
+}
+
+type webhookNotifier struct {
+	logger *logrus.Entry
+	client *http.Client
+}
+
+var _ Notifier = (*webhookNotifier)(nil)
+
+// NewWebhookNotifier creates a `Notifier` that posts notifications to HTTP webhooks.
+func NewWebhookNotifier(logger *logrus.Entry) Notifier {
+	return &webhookNotifier{
+		logger: logger.WithField("component", "notifier"),
+		client: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, sinks []apv1beta2.PlanNotification, notification Notification) {
+	// Don't let the delivery be canceled along with the reconciliation.
+	ctx = context.WithoutCancel(ctx)
+
+	for _, sink := range sinks {
+		if len(sink.Events) > 0 && !slices.Contains(sink.Events, notification.Event) {
+			continue
+		}
+
+		go func() {
+			logger := n.logger.WithFields(logrus.Fields{"url": sink.Webhook.URL, "event": notification.Event})
+			if err := n.send(ctx, sink.Webhook, notification); err != nil {
+				logger.WithError(err).Warn("Failed to send notification")
+				return
+			}
+
+			logger.Info("Notification sent")
+		}()
+	}
+}
+
+// send posts the notification to the webhook, rendering the body using the
+// template of the webhook, if any.
+func (n *webhookNotifier) send(ctx context.Context, webhook apv1beta2.PlanNotificationWebhook, notification Notification) error {
+	body, err := RenderBody(webhook, notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	contentType := webhook.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// RenderBody renders the request body of the notification for the provided webhook.
+func RenderBody(webhook apv1beta2.PlanNotificationWebhook, notification Notification) ([]byte, error) {
+	if webhook.Template == "" {
+		return json.Marshal(notification)
+	}
+
+	tmpl, err := template.New("notification").Option("missingkey=error").Parse(webhook.Template)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, notification); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package notifier
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRenderBody ensures that default and templated request bodies are rendered properly.
+func TestRenderBody(t *testing.T) {
+	notification := Notification{
+		Event:     apv1beta2.PlanNotificationCompleted,
+		PlanID:    "id123",
+		State:     "Completed",
+		Timestamp: "2026-01-02T03:04:05Z",
+	}
+
+	var tests = []struct {
+		name     string
+		template string
+		expected string
+		err      bool
+	}{
+		{"Default", "", `{"event":"Completed","planId":"id123","state":"Completed","timestamp":"2026-01-02T03:04:05Z"}`, false},
+		{"Template", `{"text":"Plan {{ .PlanID }} is {{ .State }}"}`, `{"text":"Plan id123 is Completed"}`, false},
+		{"InvalidTemplate", `{{ .PlanID `, "", true},
+		{"UnknownField", `{{ .Nope }}`, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body, err := RenderBody(apv1beta2.PlanNotificationWebhook{Template: test.template}, notification)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, string(body))
+		})
+	}
+}
+
+// TestNotify ensures that notifications are only sent to sinks that are
+// interested in the event.
+func TestNotify(t *testing.T) {
+	received := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r.URL.Path + " " + r.Header.Get("Content-Type") + " " + string(body)
+	}))
+	t.Cleanup(server.Close)
+
+	sinks := []apv1beta2.PlanNotification{
+		{Webhook: apv1beta2.PlanNotificationWebhook{URL: server.URL + "/all"}},
+		{
+			Events:  []apv1beta2.PlanNotificationEvent{apv1beta2.PlanNotificationStarted},
+			Webhook: apv1beta2.PlanNotificationWebhook{URL: server.URL + "/started"},
+		},
+		{
+			Events:  []apv1beta2.PlanNotificationEvent{apv1beta2.PlanNotificationFailed},
+			Webhook: apv1beta2.PlanNotificationWebhook{URL: server.URL + "/failed", Template: "{{ .Event }}", ContentType: "text/plain"},
+		},
+	}
+
+	notifier := NewWebhookNotifier(logrus.NewEntry(logrus.StandardLogger()))
+	notifier.Notify(t.Context(), sinks, Notification{Event: apv1beta2.PlanNotificationFailed, PlanID: "id123"})
+
+	var requests []string
+	for range 2 {
+		select {
+		case r := <-received:
+			requests = append(requests, r)
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "Timed out waiting for notifications")
+		}
+	}
+
+	assert.ElementsMatch(t, []string{
+		`/all application/json {"event":"Failed","planId":"id123","state":"","timestamp":""}`,
+		`/failed text/plain Failed`,
+	}, requests)
+}
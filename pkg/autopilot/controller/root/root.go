@@ -5,6 +5,7 @@ package root
 
 import (
 	"context"
+	"time"
 )
 
 // TODO: decide on renaming root.RootConfig -> root.Config
@@ -18,6 +19,29 @@ type RootConfig struct {
 	MetricsBindAddr     string
 	HealthProbeBindAddr string
 	ExcludeFromPlans    []string
+
+	// ManagerRetryAttempts is the number of attempts made to start the
+	// controller manager before giving up. Defaults to 120 if zero.
+	ManagerRetryAttempts int
+	// ManagerRetryInterval is the interval between two attempts to start the
+	// controller manager. Defaults to one second if zero.
+	ManagerRetryInterval time.Duration
+	// ShutdownTimeout is the time given to in-flight reconciles to finish when
+	// the controller manager is stopped. Defaults to one minute if zero.
+	ShutdownTimeout time.Duration
+
+	// WebhookCertDir is the directory containing the CA certificate and the
+	// serving certificate of the autopilot admission webhooks. The webhooks
+	// are disabled if empty.
+	WebhookCertDir string
+
+	// ControlNodeStaleTimeout is the time after which a ControlNode is marked
+	// as stale if its controller didn't renew its lease. Staleness detection is
+	// disabled if zero.
+	ControlNodeStaleTimeout time.Duration
+	// ControlNodeRemovalAge is the time after which stale ControlNodes are
+	// removed. Stale ControlNodes are kept if zero.
+	ControlNodeRemovalAge time.Duration
 }
 
 // Root is the 'root' of all controllers
@@ -8,17 +8,24 @@ package controller
 import (
 	"context"
 	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/k0sproject/k0s/internal/sync/value"
 	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
 	apcli "github.com/k0sproject/k0s/pkg/autopilot/client"
 	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
+	"github.com/k0sproject/k0s/pkg/autopilot/controller/controlnodes"
 	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
 	"github.com/k0sproject/k0s/pkg/autopilot/controller/plans"
 	aproot "github.com/k0sproject/k0s/pkg/autopilot/controller/root"
 	"github.com/k0sproject/k0s/pkg/autopilot/controller/signal"
 	"github.com/k0sproject/k0s/pkg/autopilot/controller/updates"
+	apwebhook "github.com/k0sproject/k0s/pkg/autopilot/controller/webhook"
+	"github.com/k0sproject/k0s/pkg/autopilot/controller/workerleases"
 	"github.com/k0sproject/k0s/pkg/kubernetes"
 	"github.com/k0sproject/k0s/pkg/leaderelection"
 
@@ -34,6 +41,10 @@ import (
 	crwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
+// webhookHost is the address that the webhook server listens on. Every API
+// server calls the webhooks of the autopilot controller running on its host.
+const webhookHost = "127.0.0.1"
+
 type leaderElector interface {
 	Run(context.Context, func(leaderelection.Status))
 }
@@ -167,7 +178,11 @@ func (c *rootController) startSubControllerRoutine(ctx context.Context, logger *
 			SkipNameValidation: ptr.To(c.initialized),
 		},
 		WebhookServer: crwebhook.NewServer(crwebhook.Options{
-			Port: c.cfg.ManagerPort,
+			Host:     webhookHost,
+			Port:     c.cfg.ManagerPort,
+			CertDir:  c.cfg.WebhookCertDir,
+			CertName: apconst.WebhookCertName + ".crt",
+			KeyName:  apconst.WebhookCertName + ".key",
 		}),
 		Metrics: crmetricsserver.Options{
 			BindAddress: c.cfg.MetricsBindAddr,
@@ -193,6 +208,13 @@ func (c *rootController) startSubControllerRoutine(ctx context.Context, logger *
 
 	leaderMode := event == leaderelection.StatusLeading
 
+	if c.cfg.WebhookCertDir != "" {
+		if err := c.setupWebhooks(ctx, mgr, leaderMode); err != nil {
+			logger.WithError(err).Error("unable to set up webhooks")
+			return err
+		}
+	}
+
 	prober, err := NewReadyProber(logger, c.autopilotClientFactory, mgr.GetConfig(), 1*time.Minute)
 	if err != nil {
 		logger.WithError(err).Error("unable to create controller prober")
@@ -240,6 +262,19 @@ func (c *rootController) startSubControllerRoutine(ctx context.Context, logger *
 		return err
 	}
 
+	if err := controlnodes.RegisterControllers(logger, mgr, leaderMode, controlnodes.Config{
+		StaleTimeout: c.cfg.ControlNodeStaleTimeout,
+		RemovalAge:   c.cfg.ControlNodeRemovalAge,
+	}); err != nil {
+		logger.WithError(err).Error("unable to register controlnode controllers")
+		return err
+	}
+
+	if err := workerleases.RegisterControllers(logger, mgr, leaderMode); err != nil {
+		logger.WithError(err).Error("unable to register worker lease controllers")
+		return err
+	}
+
 	// All the controller-runtime controllers have been registered.
 	c.initialized = true
 
@@ -252,6 +287,31 @@ func (c *rootController) startSubControllerRoutine(ctx context.Context, logger *
 	return nil
 }
 
+// setupWebhooks registers the admission webhooks at the webhook server of the
+// manager. The leader additionally ensures that the API server is configured to
+// call them.
+func (c *rootController) setupWebhooks(ctx context.Context, mgr crman.Manager, leaderMode bool) error {
+	if err := apwebhook.RegisterWebhooks(c.log, mgr); err != nil {
+		return err
+	}
+
+	if !leaderMode {
+		return nil
+	}
+
+	caBundle, err := os.ReadFile(filepath.Join(c.cfg.WebhookCertDir, "ca.crt"))
+	if err != nil {
+		return fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+
+	address := net.JoinHostPort(webhookHost, strconv.Itoa(c.cfg.ManagerPort))
+	if err := apwebhook.EnsureConfiguration(ctx, c.kubeClientFactory, address, caBundle); err != nil {
+		return fmt.Errorf("failed to ensure webhook configuration: %w", err)
+	}
+
+	return nil
+}
+
 // startSubControllers starts all of the controllers specific to the leader mode.
 // It is expected that this function runs to completion.
 func (c *rootController) startSubControllers(ctx context.Context, event leaderelection.Status) (context.CancelFunc, *errgroup.Group) {
@@ -7,13 +7,18 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/k0sproject/k0s/internal/sync/value"
 	apcli "github.com/k0sproject/k0s/pkg/autopilot/client"
+	apcomm "github.com/k0sproject/k0s/pkg/autopilot/common"
+	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
 	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
 	aproot "github.com/k0sproject/k0s/pkg/autopilot/controller/root"
 	"github.com/k0sproject/k0s/pkg/autopilot/controller/signal"
+	"github.com/k0sproject/k0s/pkg/leaderelection"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -49,9 +54,84 @@ func NewRootWorker(cfg aproot.RootConfig, logger *logrus.Entry, cf apcli.Factory
 	return c, nil
 }
 
+// defaultShutdownTimeout is the default time given to in-flight reconciles to
+// finish when the worker root is shutting down.
+const defaultShutdownTimeout = 1 * time.Minute
+
+// The default budget for starting the controller manager, which may need to
+// wait until the controllers have deployed all autopilot CRDs.
+const (
+	defaultManagerRetryAttempts = 120
+	defaultManagerRetryInterval = 1 * time.Second
+)
+
+// Run runs the controller-runtime manager for workers as long as this k0s
+// invocation holds the worker lease of its node. The lease ensures that only a
+// single manager processes the signals for a node, even when several k0s
+// processes are running on it, e.g. during restarts.
 func (w *rootWorker) Run(ctx context.Context) error {
 	logger := w.log
 
+	hostname, err := apcomm.FindEffectiveHostname()
+	if err != nil {
+		return fmt.Errorf("unable to determine hostname: %w", err)
+	}
+
+	kubeClient, err := w.clientFactory.GetClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Kubernetes client: %w", err)
+	}
+
+	le, err := leaderelection.NewClient(&leaderelection.LeaseConfig{
+		Namespace: apconst.AutopilotNamespace,
+		Name:      apconst.WorkerLeasePrefix + hostname,
+		Identity:  w.cfg.InvocationID,
+		Client:    kubeClient.CoordinationV1(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create leader elector: %w", err)
+	}
+
+	// The lease is held until the manager has been drained, so that no other
+	// manager starts processing signals for this node in the meantime.
+	leaseCtx, cancelLease := context.WithCancel(context.WithoutCancel(ctx))
+	status := value.NewLatest(leaderelection.StatusPending)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		le.Run(leaseCtx, status.Set)
+	}()
+	defer func() { cancelLease(); <-done }()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	leaderelection.RunLeaderTasks(ctx, status.Peek, func(ctx context.Context) {
+		logger.Info("Acquired worker lease, starting controller manager")
+		if err := w.runManager(ctx); err != nil && ctx.Err() == nil {
+			cancel(err)
+			return
+		}
+		logger.Info("Controller manager stopped")
+	})
+
+	if err := context.Cause(ctx); !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// runManager creates and starts the controller-runtime manager for workers,
+// retrying until all the required autopilot CRDs are available. It blocks
+// until the context is done and all in-flight reconciles have been drained.
+func (w *rootWorker) runManager(ctx context.Context) error {
+	logger := w.log
+
+	shutdownTimeout := w.cfg.ShutdownTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+
 	managerOpts := crman.Options{
 		Scheme: scheme,
 		Controller: crconfig.Controller{
@@ -61,7 +141,10 @@ func (w *rootWorker) Run(ctx context.Context) error {
 			// maintains a global checklist of controller names and doesn't
 			// currently provide a way to unregister names from discarded
 			// managers. So it's necessary to suppress the global name check
-			// whenever things retried.
+			// whenever things are retried or the lease is re-acquired. The
+			// names of the worker controllers are prefixed with the name of
+			// their delegate, so they never clash with the ones of the
+			// controller root running in the same process.
 			SkipNameValidation: ptr.To(w.initialized),
 		},
 		WebhookServer: crwebhook.NewServer(crwebhook.Options{
@@ -71,16 +154,17 @@ func (w *rootWorker) Run(ctx context.Context) error {
 			BindAddress: w.cfg.MetricsBindAddr,
 		},
 		HealthProbeBindAddress: w.cfg.HealthProbeBindAddr,
+		// Wait for in-flight reconciles to finish when stopping, so that
+		// signals aren't left half-processed.
+		GracefulShutdownTimeout: &shutdownTimeout,
 	}
 
 	// In some cases, we need to wait on the worker side until controller deploys all autopilot CRDs
 	var attempt uint
-	return k8sretry.OnError(wait.Backoff{
-		Steps:    120,
-		Duration: 1 * time.Second,
-		Factor:   1.0,
-		Jitter:   0.1,
-	}, func(err error) bool {
+	return k8sretry.OnError(managerRetryBackoff(w.cfg), func(err error) bool {
+		if ctx.Err() != nil {
+			return false
+		}
 		attempt++
 		logger := logger.WithError(err).WithField("attempt", attempt)
 		logger.Debug("Failed to run controller manager, retrying after backoff")
@@ -124,3 +208,21 @@ func (w *rootWorker) Run(ctx context.Context) error {
 		return nil
 	})
 }
+
+// Returns the backoff for starting the controller manager, as configured in
+// the given root config.
+func managerRetryBackoff(cfg aproot.RootConfig) wait.Backoff {
+	backoff := wait.Backoff{
+		Steps:    cfg.ManagerRetryAttempts,
+		Duration: cfg.ManagerRetryInterval,
+		Factor:   1.0,
+		Jitter:   0.1,
+	}
+	if backoff.Steps <= 0 {
+		backoff.Steps = defaultManagerRetryAttempts
+	}
+	if backoff.Duration <= 0 {
+		backoff.Duration = defaultManagerRetryInterval
+	}
+	return backoff
+}
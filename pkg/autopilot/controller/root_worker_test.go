@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	aproot "github.com/k0sproject/k0s/pkg/autopilot/controller/root"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagerRetryBackoff(t *testing.T) {
+	backoff := managerRetryBackoff(aproot.RootConfig{})
+	assert.Equal(t, 120, backoff.Steps)
+	assert.Equal(t, 1*time.Second, backoff.Duration)
+
+	backoff = managerRetryBackoff(aproot.RootConfig{ManagerRetryAttempts: 5, ManagerRetryInterval: 10 * time.Second})
+	assert.Equal(t, 5, backoff.Steps)
+	assert.Equal(t, 10*time.Second, backoff.Duration)
+}
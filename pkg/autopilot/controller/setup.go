@@ -163,10 +163,9 @@ func (sc *setupController) createControlNode(ctx context.Context, cf apcli.Facto
 		return err
 	}
 
-	node.Status = apv1beta2.ControlNodeStatus{
-		Addresses:  addresses,
-		K0sVersion: build.Version,
-	}
+	// Keep the conditions, they're maintained by the leading controller.
+	node.Status.Addresses = addresses
+	node.Status.K0sVersion = build.Version
 
 	logger.Infof("Updating controlnode status '%s'", name)
 	if node, err = client.AutopilotV1beta2().ControlNodes().UpdateStatus(ctx, node, metav1.UpdateOptions{}); err != nil {
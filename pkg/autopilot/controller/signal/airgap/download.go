@@ -54,6 +54,7 @@ func (b downloadManfiestBuilderAirgap) Build(signalNode crcli.Object, signalData
 			ExpectedHash: signalData.Command.AirgapUpdate.Sha256,
 			Hasher:       sha256.New(),
 			DownloadDir:  path.Join(b.k0sDataDir, "images"),
+			Proxy:        apsigcomm.DownloadProxy(signalData),
 		},
 		SuccessState: apsigcomm.Completed,
 	}
@@ -23,6 +23,20 @@ type DownloadManifest struct {
 	SuccessState string
 }
 
+// DownloadProxy returns the proxy settings for downloads that have been sent
+// along with the signal data, if any.
+func DownloadProxy(signalData apsigv2.SignalData) *apdl.Proxy {
+	if signalData.Proxy == nil {
+		return nil
+	}
+
+	return &apdl.Proxy{
+		URL:      signalData.Proxy.URL,
+		NoProxy:  signalData.Proxy.NoProxy,
+		CABundle: signalData.Proxy.CABundle,
+	}
+}
+
 type DownloadManifestBuilder interface {
 	Build(signalNode crcli.Object, signalData apsigv2.SignalData) (DownloadManifest, error)
 }
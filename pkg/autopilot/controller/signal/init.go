@@ -12,6 +12,8 @@ import (
 	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
 	"github.com/k0sproject/k0s/pkg/autopilot/controller/signal/airgap"
 	"github.com/k0sproject/k0s/pkg/autopilot/controller/signal/k0s"
+	"github.com/k0sproject/k0s/pkg/autopilot/controller/signal/runtime"
+	"github.com/k0sproject/k0s/pkg/autopilot/controller/signal/timeout"
 
 	"github.com/sirupsen/logrus"
 	crman "sigs.k8s.io/controller-runtime/pkg/manager"
@@ -20,7 +22,7 @@ import (
 // RegisterControllers registers all of the autopilot controllers used by both controller
 // and worker modes.
 func RegisterControllers(ctx context.Context, logger *logrus.Entry, mgr crman.Manager, delegate apdel.ControllerDelegate, k0sDataDir, clusterID string) error {
-	if err := k0s.RegisterControllers(ctx, logger, mgr, delegate, clusterID); err != nil {
+	if err := k0s.RegisterControllers(ctx, logger, mgr, delegate, k0sDataDir, clusterID); err != nil {
 		return fmt.Errorf("unable to register k0s controllers: %w", err)
 	}
 
@@ -28,5 +30,13 @@ func RegisterControllers(ctx context.Context, logger *logrus.Entry, mgr crman.Ma
 		return fmt.Errorf("unable to register airgap controllers: %w", err)
 	}
 
+	if err := runtime.RegisterControllers(ctx, logger, mgr, delegate, k0sDataDir); err != nil {
+		return fmt.Errorf("unable to register runtime controllers: %w", err)
+	}
+
+	if err := timeout.RegisterControllers(logger, mgr, delegate); err != nil {
+		return fmt.Errorf("unable to register timeout controllers: %w", err)
+	}
+
 	return nil
 }
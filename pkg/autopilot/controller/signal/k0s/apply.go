@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/k0sproject/k0s/internal/pkg/file"
 	apcomm "github.com/k0sproject/k0s/pkg/autopilot/common"
 	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
 	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
@@ -54,6 +55,7 @@ type applyingUpdate struct {
 	client       crcli.Client
 	delegate     apdel.ControllerDelegate
 	k0sBinaryDir string
+	k0sDataDir   string
 }
 
 // registeryApplyingUpdate registers the 'applying-update' controller to the
@@ -67,6 +69,7 @@ func registerApplyingUpdate(
 	eventFilter crpred.Predicate,
 	delegate apdel.ControllerDelegate,
 	k0sBinaryDir string,
+	k0sDataDir string,
 ) error {
 	name := strings.ToLower(delegate.Name()) + "_k0s_applying_update"
 	logger.Info("Registering reconciler: ", name)
@@ -81,6 +84,7 @@ func registerApplyingUpdate(
 				client:       mgr.GetClient(),
 				delegate:     delegate,
 				k0sBinaryDir: k0sBinaryDir,
+				k0sDataDir:   k0sDataDir,
 			},
 		)
 }
@@ -115,10 +119,17 @@ func (r *applyingUpdate) Reconcile(ctx context.Context, req cr.Request) (cr.Resu
 	}
 
 	// Perform the update atomically
-	if err := os.Rename(updateFilenamePath, filepath.Join(r.k0sBinaryDir, "k0s")); err != nil {
+	k0sBinaryPath := filepath.Join(r.k0sBinaryDir, "k0s")
+	if err := os.Rename(updateFilenamePath, k0sBinaryPath); err != nil {
 		return cr.Result{}, fmt.Errorf("unable to update (rename) to the new file: %w", err)
 	}
 
+	// Record the installed binary, so that k0s uninstall can remove it
+	markerPath := filepath.Join(r.k0sDataDir, apconst.InstalledK0sBinaryFilename)
+	if err := file.WriteContentAtomically(markerPath, []byte(k0sBinaryPath), 0644); err != nil {
+		logger.WithError(err).Warn("Failed to record the installed k0s binary")
+	}
+
 	// When the k0s process has been terminated, move to 'Restart'
 	signalNodeCopy := r.delegate.DeepCopy(signalNode)
 
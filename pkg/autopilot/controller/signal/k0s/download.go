@@ -83,6 +83,7 @@ func (b downloadManifestBuilderK0s) Build(signalNode crcli.Object, signalData ap
 			Hasher:       sha256.New(),
 			DownloadDir:  b.k0sBinaryDir,
 			Filename:     apconst.K0sTempFilename,
+			Proxy:        apsigcomm.DownloadProxy(signalData),
 		},
 		SuccessState: Cordoning,
 	}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/k0sproject/k0s/pkg/assets"
+	apcomm "github.com/k0sproject/k0s/pkg/autopilot/common"
+	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
+	apsigcomm "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common"
+	apsigpred "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common/predicate"
+	apdl "github.com/k0sproject/k0s/pkg/autopilot/download"
+	apsigv2 "github.com/k0sproject/k0s/pkg/autopilot/signaling/v2"
+
+	"github.com/sirupsen/logrus"
+	cr "sigs.k8s.io/controller-runtime"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crev "sigs.k8s.io/controller-runtime/pkg/event"
+	crman "sigs.k8s.io/controller-runtime/pkg/manager"
+	crpred "sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const Downloading = "Downloading"
+
+// downloadEventFilter creates a controller-runtime predicate that governs which objects
+// will make it into reconciliation, and which will be ignored.
+func downloadEventFilter(hostname string, handler apsigpred.ErrorHandler) crpred.Predicate {
+	return crpred.And(
+		crpred.AnnotationChangedPredicate{},
+		apsigpred.SignalNamePredicate(hostname),
+		apsigpred.NewSignalDataPredicateAdapter(handler).And(
+			signalDataUpdateCommandRuntimePredicate(),
+			apsigpred.SignalDataStatusPredicate(Downloading),
+		),
+		apcomm.FalseFuncs{
+			CreateFunc: func(ce crev.CreateEvent) bool {
+				return true
+			},
+			UpdateFunc: func(ue crev.UpdateEvent) bool {
+				return true
+			},
+		},
+	)
+}
+
+type downloading struct {
+	log      *logrus.Entry
+	client   crcli.Client
+	delegate apdel.ControllerDelegate
+	binDir   string
+}
+
+// registerDownloading registers the 'runtime-downloading' controller to the
+// controller-runtime manager.
+//
+// This controller is only interested when autopilot signaling annotations have
+// moved to a `Downloading` status. At this point, it will attempt to download
+// all of the components provided in the update request.
+func registerDownloading(logger *logrus.Entry, mgr crman.Manager, eventFilter crpred.Predicate, delegate apdel.ControllerDelegate, binDir string) error {
+	name := strings.ToLower(delegate.Name()) + "_runtime_downloading"
+	logger.Info("Registering reconciler: ", name)
+
+	return cr.NewControllerManagedBy(mgr).
+		Named(name).
+		For(delegate.CreateObject()).
+		WithEventFilter(eventFilter).
+		Complete(
+			&downloading{
+				log:      logger.WithFields(logrus.Fields{"reconciler": "runtime-downloading", "object": delegate.Name()}),
+				client:   mgr.GetClient(),
+				delegate: delegate,
+				binDir:   binDir,
+			},
+		)
+}
+
+// Reconcile for the 'runtime-downloading' reconciler downloads all of the
+// requested components. Only when all of them have been downloaded
+// successfully, they are moved into the override directory, from which they
+// will be staged when k0s restarts.
+func (r *downloading) Reconcile(ctx context.Context, req cr.Request) (cr.Result, error) {
+	signalNode := r.delegate.CreateObject()
+	if err := r.client.Get(ctx, req.NamespacedName, signalNode); err != nil {
+		return cr.Result{}, fmt.Errorf("unable to get signal for node='%s': %w", req.Name, err)
+	}
+
+	logger := r.log.WithField("signalnode", signalNode.GetName())
+
+	var signalData apsigv2.SignalData
+	if err := signalData.Unmarshal(signalNode.GetAnnotations()); err != nil {
+		return cr.Result{}, fmt.Errorf("unable to unmarshal signal data for node='%s': %w", req.Name, err)
+	}
+
+	if err := downloadComponents(ctx, logger, r.binDir, signalData.Command.RuntimeUpdate.Components, apsigcomm.DownloadProxy(signalData)); err != nil {
+		logger.WithError(err).Error("Unable to download runtime components")
+		signalData.Status = apsigv2.NewStatus(apsigcomm.FailedDownload)
+	} else {
+		signalData.Status = apsigv2.NewStatus(Restart)
+	}
+
+	signalNodeCopy := r.delegate.DeepCopy(signalNode)
+	if err := signalData.Marshal(signalNodeCopy.GetAnnotations()); err != nil {
+		return cr.Result{}, fmt.Errorf("failed to marshal signal data: %w", err)
+	}
+
+	logger.Infof("Updating signaling response to '%s'", signalData.Status.Status)
+	if err := r.client.Update(ctx, signalNodeCopy, &crcli.UpdateOptions{}); err != nil {
+		return cr.Result{}, fmt.Errorf("failed to update signal node to status '%s': %w", signalData.Status.Status, err)
+	}
+
+	return cr.Result{}, nil
+}
+
+// downloadComponents downloads all components into a temporary directory,
+// and moves them into the override directory once every download succeeded.
+// Overrides placed for previous versions of k0s are removed along the way.
+func downloadComponents(ctx context.Context, logger *logrus.Entry, binDir string, components []apsigv2.CommandRuntimeComponent, proxy *apdl.Proxy) (err error) {
+	if err := assets.PruneOverrides(binDir); err != nil {
+		logger.WithError(err).Warn("Failed to remove stale overrides")
+	}
+
+	overrideDir := assets.OverrideDir(binDir)
+	if err := os.MkdirAll(overrideDir, 0755); err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp(overrideDir, ".download-")
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, os.RemoveAll(tmpDir)) }()
+
+	for _, component := range components {
+		if filepath.Base(component.Name) != component.Name {
+			return fmt.Errorf("invalid component name: %q", component.Name)
+		}
+
+		logger.Infof("Starting download of '%s' from '%s'", component.Name, component.URL)
+		if err := apdl.NewDownloader(apdl.Config{
+			URL:          component.URL,
+			ExpectedHash: component.Sha256,
+			Hasher:       sha256.New(),
+			DownloadDir:  tmpDir,
+			Filename:     component.Name,
+			Proxy:        proxy,
+		}).Download(ctx); err != nil {
+			return fmt.Errorf("failed to download %s: %w", component.Name, err)
+		}
+	}
+
+	for _, component := range components {
+		downloaded := filepath.Join(tmpDir, component.Name)
+		if err := os.Chmod(downloaded, 0755); err != nil {
+			return err
+		}
+		if err := os.Rename(downloaded, assets.OverridePath(binDir, component.Name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/assets"
+	apsigv2 "github.com/k0sproject/k0s/pkg/autopilot/signaling/v2"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDownloadComponents ensures that the components are only placed into the
+// override directory if all of them could be downloaded.
+func TestDownloadComponents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	t.Cleanup(server.Close)
+
+	hash := func(data string) string {
+		sum := sha256.Sum256([]byte(data))
+		return hex.EncodeToString(sum[:])
+	}
+
+	logger := logrus.NewEntry(logrus.StandardLogger())
+
+	t.Run("Happy", func(t *testing.T) {
+		binDir := t.TempDir()
+
+		err := downloadComponents(t.Context(), logger, binDir, []apsigv2.CommandRuntimeComponent{
+			{Name: "containerd", URL: server.URL + "/containerd", Sha256: hash("/containerd")},
+			{Name: "runc", URL: server.URL + "/runc"},
+		}, nil)
+		require.NoError(t, err)
+
+		for _, name := range []string{"containerd", "runc"} {
+			data, err := os.ReadFile(assets.OverridePath(binDir, name))
+			if assert.NoError(t, err) {
+				assert.Equal(t, "/"+name, string(data))
+			}
+		}
+
+		entries, err := os.ReadDir(assets.OverrideDir(binDir))
+		require.NoError(t, err)
+		assert.Len(t, entries, 2, "Temporary download directory not cleaned up")
+	})
+
+	t.Run("PrunesStaleOverrides", func(t *testing.T) {
+		binDir := t.TempDir()
+		stale := filepath.Join(binDir, assets.OverrideDirName, "k0s-v0.0.1", "containerd")
+		require.NoError(t, os.MkdirAll(filepath.Dir(stale), 0755))
+		require.NoError(t, os.WriteFile(stale, []byte("stale"), 0755))
+
+		err := downloadComponents(t.Context(), logger, binDir, []apsigv2.CommandRuntimeComponent{
+			{Name: "runc", URL: server.URL + "/runc"},
+		}, nil)
+		require.NoError(t, err)
+
+		assert.NoFileExists(t, stale)
+		assert.FileExists(t, assets.OverridePath(binDir, "runc"))
+	})
+
+	t.Run("HashMismatch", func(t *testing.T) {
+		binDir := t.TempDir()
+
+		err := downloadComponents(t.Context(), logger, binDir, []apsigv2.CommandRuntimeComponent{
+			{Name: "containerd", URL: server.URL + "/containerd"},
+			{Name: "runc", URL: server.URL + "/runc", Sha256: hash("something else")},
+		}, nil)
+		assert.ErrorContains(t, err, "hash mismatch")
+
+		entries, err := os.ReadDir(assets.OverrideDir(binDir))
+		require.NoError(t, err)
+		assert.Empty(t, entries, "Components placed into override directory")
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		err := downloadComponents(t.Context(), logger, t.TempDir(), []apsigv2.CommandRuntimeComponent{
+			{Name: "../k0s", URL: server.URL + "/k0s"},
+		}, nil)
+		assert.ErrorContains(t, err, "invalid component name")
+	})
+}
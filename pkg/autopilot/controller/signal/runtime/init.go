@@ -0,0 +1,50 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	apcomm "github.com/k0sproject/k0s/pkg/autopilot/common"
+	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
+	apsigpred "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common/predicate"
+
+	"github.com/sirupsen/logrus"
+	crman "sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// RegisterControllers registers all of the autopilot controllers used for updating
+// the bundled runtime components to the controller-runtime manager.
+func RegisterControllers(ctx context.Context, logger *logrus.Entry, mgr crman.Manager, delegate apdel.ControllerDelegate, k0sDataDir string) error {
+	logger = logger.WithField("controller", delegate.Name())
+
+	hostname, err := apcomm.FindEffectiveHostname()
+	if err != nil {
+		return fmt.Errorf("unable to determine hostname: %w", err)
+	}
+
+	binDir := filepath.Join(k0sDataDir, "bin")
+
+	if err := registerSignalController(logger, mgr, signalControllerEventFilter(hostname, apsigpred.DefaultErrorHandler(logger, "runtime signal")), delegate); err != nil {
+		return fmt.Errorf("unable to register signal controller: %w", err)
+	}
+
+	if err := registerDownloading(logger, mgr, downloadEventFilter(hostname, apsigpred.DefaultErrorHandler(logger, "runtime downloading")), delegate, binDir); err != nil {
+		return fmt.Errorf("unable to register downloading controller: %w", err)
+	}
+
+	if err := registerRestart(logger, mgr, restartEventFilter(hostname, apsigpred.DefaultErrorHandler(logger, "runtime restart")), delegate); err != nil {
+		return fmt.Errorf("unable to register restart controller: %w", err)
+	}
+
+	if err := registerRestarted(logger, mgr, restartedEventFilter(hostname, apsigpred.DefaultErrorHandler(logger, "runtime restarted")), delegate, binDir); err != nil {
+		return fmt.Errorf("unable to register restarted controller: %w", err)
+	}
+
+	return nil
+}
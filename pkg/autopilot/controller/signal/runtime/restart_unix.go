@@ -0,0 +1,98 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"syscall"
+
+	apcomm "github.com/k0sproject/k0s/pkg/autopilot/common"
+	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
+	apsigpred "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common/predicate"
+	apsigk0s "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/k0s"
+	"github.com/k0sproject/k0s/pkg/component/status"
+
+	"github.com/sirupsen/logrus"
+	cr "sigs.k8s.io/controller-runtime"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crev "sigs.k8s.io/controller-runtime/pkg/event"
+	crman "sigs.k8s.io/controller-runtime/pkg/manager"
+	crpred "sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// restartEventFilter creates a controller-runtime predicate that governs which
+// objects will make it into reconciliation, and which will be ignored.
+func restartEventFilter(hostname string, handler apsigpred.ErrorHandler) crpred.Predicate {
+	return crpred.And(
+		apsigpred.SignalNamePredicate(hostname),
+		apsigpred.NewSignalDataPredicateAdapter(handler).And(
+			signalDataUpdateCommandRuntimePredicate(),
+			apsigpred.SignalDataStatusPredicate(Restart),
+		),
+		apcomm.FalseFuncs{
+			UpdateFunc: func(ue crev.UpdateEvent) bool {
+				return true
+			},
+		},
+	)
+}
+
+type restart struct {
+	log      *logrus.Entry
+	client   crcli.Client
+	delegate apdel.ControllerDelegate
+}
+
+// registerRestart registers the 'runtime-restart' controller to the controller-runtime manager.
+//
+// This controller is only interested in changes to signal nodes where its signaling
+// status is marked as `Restart`
+func registerRestart(logger *logrus.Entry, mgr crman.Manager, eventFilter crpred.Predicate, delegate apdel.ControllerDelegate) error {
+	name := strings.ToLower(delegate.Name()) + "_runtime_restart"
+	logger.Info("Registering reconciler: ", name)
+
+	return cr.NewControllerManagedBy(mgr).
+		Named(name).
+		For(delegate.CreateObject()).
+		WithEventFilter(eventFilter).
+		Complete(
+			&restart{
+				log:      logger.WithFields(logrus.Fields{"reconciler": "runtime-restart", "object": delegate.Name()}),
+				client:   mgr.GetClient(),
+				delegate: delegate,
+			},
+		)
+}
+
+// Reconcile for the 'runtime-restart' reconciler restarts k0s, so that the
+// downloaded runtime components are staged, and the affected components are
+// restarted. Running containers are left untouched, as neither containerd nor
+// kubelet restarts affect them.
+func (r *restart) Reconcile(ctx context.Context, req cr.Request) (cr.Result, error) {
+	signalNode := r.delegate.CreateObject()
+	if err := r.client.Get(ctx, req.NamespacedName, signalNode); err != nil {
+		return cr.Result{}, fmt.Errorf("unable to get signal for node='%s': %w", req.Name, err)
+	}
+
+	logger := r.log.WithField("signalnode", signalNode.GetName())
+	logger.Info("Preparing to restart k0s")
+
+	status, err := status.GetStatusInfo(apsigk0s.DefaultK0sStatusSocketPath)
+	if err != nil {
+		return cr.Result{}, fmt.Errorf("unable to get k0s pid: %w", err)
+	}
+
+	// We terminate `k0s` by sending it SIGTERM. It is expected that `k0s` will be restarted
+	// by some process init (systemctl, etc).
+
+	if err := syscall.Kill(status.Pid, syscall.SIGTERM); err != nil {
+		return cr.Result{}, fmt.Errorf("unable to send SIGTERM to k0s: %w", err)
+	}
+
+	return cr.Result{}, nil
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/k0sproject/k0s/pkg/assets"
+	apcomm "github.com/k0sproject/k0s/pkg/autopilot/common"
+	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
+	apsigcomm "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common"
+	apsigpred "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common/predicate"
+	apsigv2 "github.com/k0sproject/k0s/pkg/autopilot/signaling/v2"
+
+	"github.com/sirupsen/logrus"
+	cr "sigs.k8s.io/controller-runtime"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crev "sigs.k8s.io/controller-runtime/pkg/event"
+	crman "sigs.k8s.io/controller-runtime/pkg/manager"
+	crpred "sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const Restart = "Restart"
+
+type restarted struct {
+	log      *logrus.Entry
+	client   crcli.Client
+	delegate apdel.ControllerDelegate
+	binDir   string
+}
+
+// restartedEventFilter creates a controller-runtime predicate that governs which
+// objects will make it into reconciliation, and which will be ignored.
+func restartedEventFilter(hostname string, handler apsigpred.ErrorHandler) crpred.Predicate {
+	return crpred.And(
+		apsigpred.SignalNamePredicate(hostname),
+		apsigpred.NewSignalDataPredicateAdapter(handler).And(
+			signalDataUpdateCommandRuntimePredicate(),
+			apsigpred.SignalDataStatusPredicate(Restart),
+		),
+		apcomm.FalseFuncs{
+			CreateFunc: func(ce crev.CreateEvent) bool {
+				return true
+			},
+		},
+	)
+}
+
+// registerRestarted registers the 'runtime-restarted' controller to the controller-runtime manager.
+//
+// This controller is only interested in changes to signal nodes where its signaling
+// status is marked as `Restart`
+func registerRestarted(logger *logrus.Entry, mgr crman.Manager, eventFilter crpred.Predicate, delegate apdel.ControllerDelegate, binDir string) error {
+	name := strings.ToLower(delegate.Name()) + "_runtime_restarted"
+	logger.Info("Registering reconciler: ", name)
+
+	return cr.NewControllerManagedBy(mgr).
+		Named(name).
+		For(delegate.CreateObject()).
+		WithEventFilter(eventFilter).
+		Complete(
+			&restarted{
+				log:      logger.WithFields(logrus.Fields{"reconciler": "runtime-restarted", "object": delegate.Name()}),
+				client:   mgr.GetClient(),
+				delegate: delegate,
+				binDir:   binDir,
+			},
+		)
+}
+
+// Reconcile for the 'runtime-restarted' reconciler triggers when the event is
+// "created", indicating that `k0s` has actually restarted. It verifies that all
+// of the requested components have been staged from the override directory, and
+// moves the signal to 'Completed', or 'Failed' otherwise.
+func (r *restarted) Reconcile(ctx context.Context, req cr.Request) (cr.Result, error) {
+	signalNode := r.delegate.CreateObject()
+	if err := r.client.Get(ctx, req.NamespacedName, signalNode); err != nil {
+		return cr.Result{}, fmt.Errorf("unable to get signal for node='%s': %w", req.Name, err)
+	}
+
+	logger := r.log.WithField("signalnode", signalNode.GetName())
+
+	var signalData apsigv2.SignalData
+	if err := signalData.Unmarshal(signalNode.GetAnnotations()); err != nil {
+		return cr.Result{}, fmt.Errorf("unable to unmarshal signal data for node='%s': %w", req.Name, err)
+	}
+
+	status := apsigcomm.Completed
+	for _, component := range signalData.Command.RuntimeUpdate.Components {
+		if err := isStaged(r.binDir, component.Name); err != nil {
+			logger.WithError(err).Errorf("Runtime component %s hasn't been updated", component.Name)
+			status = apsigcomm.Failed
+			break
+		}
+	}
+
+	signalNodeCopy := r.delegate.DeepCopy(signalNode)
+	signalData.Status = apsigv2.NewStatus(status)
+
+	if err := signalData.Marshal(signalNodeCopy.GetAnnotations()); err != nil {
+		return cr.Result{}, fmt.Errorf("unable to marshal signal data for node='%s': %w", req.Name, err)
+	}
+
+	logger.Infof("Updating signaling response to '%s'", signalData.Status.Status)
+	if err := r.client.Update(ctx, signalNodeCopy, &crcli.UpdateOptions{}); err != nil {
+		return cr.Result{}, fmt.Errorf("unable to update signal node with '%s' status: %w", signalData.Status.Status, err)
+	}
+
+	return cr.Result{}, nil
+}
+
+// isStaged checks that the binary with the given name has been staged from its
+// override.
+func isStaged(binDir, name string) error {
+	override, err := os.Stat(assets.OverridePath(binDir, name))
+	if err != nil {
+		return err
+	}
+
+	if assets.EmbeddedBinaryNeedsUpdate(override, filepath.Join(binDir, name), override.Size()) {
+		return fmt.Errorf("%s differs from its override", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apcomm "github.com/k0sproject/k0s/pkg/autopilot/common"
+	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
+	apsigcomm "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common"
+	apsigpred "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common/predicate"
+	apsigv2 "github.com/k0sproject/k0s/pkg/autopilot/signaling/v2"
+
+	"github.com/sirupsen/logrus"
+	cr "sigs.k8s.io/controller-runtime"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crev "sigs.k8s.io/controller-runtime/pkg/event"
+	crman "sigs.k8s.io/controller-runtime/pkg/manager"
+	crpred "sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// signalDataUpdateCommandRuntimePredicate creates a predicate that ensures that the
+// provided SignalData is a 'runtime' update.
+func signalDataUpdateCommandRuntimePredicate() apsigpred.SignalDataPredicate {
+	return func(signalData apsigv2.SignalData) bool {
+		return signalData.Command.RuntimeUpdate != nil
+	}
+}
+
+// signalControllerEventFilter creates a controller-runtime predicate that governs which objects
+// will make it into reconciliation, and which will be ignored.
+func signalControllerEventFilter(hostname string, handler apsigpred.ErrorHandler) crpred.Predicate {
+	return crpred.And(
+		crpred.AnnotationChangedPredicate{},
+		apsigpred.SignalNamePredicate(hostname),
+		apsigpred.NewSignalDataPredicateAdapter(handler).And(
+			signalDataUpdateCommandRuntimePredicate(),
+			apsigpred.SignalDataNoStatusPredicate(),
+		),
+		apcomm.FalseFuncs{
+			CreateFunc: func(ce crev.CreateEvent) bool {
+				return true
+			},
+			UpdateFunc: func(ue crev.UpdateEvent) bool {
+				return true
+			},
+		},
+	)
+}
+
+type signalControllerHandler struct {
+}
+
+// registerSignalController registers the 'runtime-signal' controller to the
+// controller-runtime manager.
+//
+// This controller is only interested in changes to its own annotations, and is
+// the main mechanism in identifying incoming autopilot runtime signaling
+// updates.
+func registerSignalController(logger *logrus.Entry, mgr crman.Manager, eventFilter crpred.Predicate, delegate apdel.ControllerDelegate) error {
+	logr := logger.WithFields(logrus.Fields{"updatetype": "runtime"})
+	name := strings.ToLower(delegate.Name()) + "_runtime_signal"
+
+	logr.Info("Registering reconciler: ", name)
+
+	return cr.NewControllerManagedBy(mgr).
+		Named(name).
+		For(delegate.CreateObject()).
+		WithEventFilter(eventFilter).
+		Complete(
+			apsigcomm.NewSignalController(
+				logr,
+				mgr.GetClient(),
+				delegate,
+				&signalControllerHandler{},
+			),
+		)
+}
+
+// Handle will move the status to `Downloading` in order to start the download
+// of the runtime components. The bundled runtime components don't carry any
+// version information that could be compared, so always download.
+func (h *signalControllerHandler) Handle(ctx context.Context, sctx apsigcomm.SignalControllerContext) (cr.Result, error) {
+	// A nil SignalData indicates that the request is completed, or invalid. Either way,
+	// there is nothing to process.
+	if sctx.SignalData == nil {
+		return cr.Result{}, nil
+	}
+
+	sctx.Log.Infof("Found available runtime update request for version '%s'", sctx.SignalData.Command.RuntimeUpdate.Version)
+
+	signalNodeCopy := sctx.Delegate.DeepCopy(sctx.SignalNode)
+	status := Downloading
+
+	sctx.SignalData.Status = apsigv2.NewStatus(status)
+	if err := sctx.SignalData.Marshal(signalNodeCopy.GetAnnotations()); err != nil {
+		return cr.Result{}, fmt.Errorf("unable to marshal runtime signal data for node='%s': %w", signalNodeCopy.GetName(), err)
+	}
+
+	sctx.Log.Infof("Updating signaling response to '%s'", status)
+	if err := sctx.Client.Update(ctx, signalNodeCopy, &crcli.UpdateOptions{}); err != nil {
+		return cr.Result{}, fmt.Errorf("unable to update runtime signal node='%s' with status='%s': %w", signalNodeCopy.GetName(), status, err)
+	}
+
+	return cr.Result{}, nil
+}
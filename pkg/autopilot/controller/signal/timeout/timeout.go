@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package timeout
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	apcomm "github.com/k0sproject/k0s/pkg/autopilot/common"
+	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
+	apsigcomm "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common"
+	apsigpred "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common/predicate"
+	apsigv2 "github.com/k0sproject/k0s/pkg/autopilot/signaling/v2"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kubectl/pkg/drain"
+	cr "sigs.k8s.io/controller-runtime"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crev "sigs.k8s.io/controller-runtime/pkg/event"
+	crman "sigs.k8s.io/controller-runtime/pkg/manager"
+	crpred "sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// uncordonFunc un-cordons the given node.
+type uncordonFunc func(ctx context.Context, node *corev1.Node) error
+
+// timeoutEventFilter creates a controller-runtime predicate that governs which
+// objects will make it into reconciliation, and which will be ignored.
+func timeoutEventFilter(hostname string, handler apsigpred.ErrorHandler) crpred.Predicate {
+	return crpred.And(
+		crpred.AnnotationChangedPredicate{},
+		apsigpred.SignalNamePredicate(hostname),
+		apsigpred.NewSignalDataPredicateAdapter(handler).And(
+			func(signalData apsigv2.SignalData) bool {
+				return signalData.Timeout != nil && !isDone(signalData)
+			},
+		),
+		apcomm.FalseFuncs{
+			CreateFunc: func(ce crev.CreateEvent) bool {
+				return true
+			},
+			UpdateFunc: func(ue crev.UpdateEvent) bool {
+				return true
+			},
+		},
+	)
+}
+
+type timeoutController struct {
+	log      *logrus.Entry
+	client   crcli.Client
+	delegate apdel.ControllerDelegate
+	uncordon uncordonFunc
+}
+
+// RegisterControllers registers the 'timeout' controller to the
+// controller-runtime manager.
+//
+// This controller is interested in all signal nodes of this host that are
+// processing a command with a timeout. Once the deadline of the command has
+// passed, it marks the signal node as failed, so that a hung signal node
+// doesn't block the plan indefinitely.
+func RegisterControllers(logger *logrus.Entry, mgr crman.Manager, delegate apdel.ControllerDelegate) error {
+	logger = logger.WithField("controller", delegate.Name())
+
+	hostname, err := apcomm.FindEffectiveHostname()
+	if err != nil {
+		return fmt.Errorf("unable to determine hostname: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+
+	name := strings.ToLower(delegate.Name()) + "_signal_timeout"
+	logger.Info("Registering reconciler: ", name)
+
+	return cr.NewControllerManagedBy(mgr).
+		Named(name).
+		For(delegate.CreateObject()).
+		WithEventFilter(timeoutEventFilter(hostname, apsigpred.DefaultErrorHandler(logger, "signal timeout"))).
+		Complete(
+			&timeoutController{
+				log:      logger.WithFields(logrus.Fields{"reconciler": "signal-timeout", "object": delegate.Name()}),
+				client:   mgr.GetClient(),
+				delegate: delegate,
+				uncordon: func(ctx context.Context, node *corev1.Node) error {
+					return drain.RunCordonOrUncordon(&drain.Helper{Client: clientset, Ctx: ctx}, node, false)
+				},
+			},
+		)
+}
+
+func (r *timeoutController) Reconcile(ctx context.Context, req cr.Request) (cr.Result, error) {
+	signalNode := r.delegate.CreateObject()
+	if err := r.client.Get(ctx, req.NamespacedName, signalNode); err != nil {
+		return cr.Result{}, crcli.IgnoreNotFound(err)
+	}
+
+	logger := r.log.WithField("signalnode", signalNode.GetName())
+
+	var signalData apsigv2.SignalData
+	if err := signalData.Unmarshal(signalNode.GetAnnotations()); err != nil {
+		return cr.Result{}, fmt.Errorf("unable to unmarshal signal data for node='%s': %w", req.Name, err)
+	}
+
+	if signalData.Timeout == nil || isDone(signalData) {
+		return cr.Result{}, nil
+	}
+
+	deadline, err := time.Parse(time.RFC3339, signalData.Timeout.Deadline)
+	if err != nil {
+		return cr.Result{}, fmt.Errorf("invalid deadline for node='%s': %w", req.Name, err)
+	}
+
+	if remaining := time.Until(deadline); remaining > 0 {
+		return cr.Result{RequeueAfter: remaining + time.Second}, nil
+	}
+
+	status := "Signaled"
+	if signalData.Status != nil {
+		status = signalData.Status.Status
+	}
+	logger.Warnf("Signal node exceeded its deadline %s in status '%s', marking it as failed", signalData.Timeout.Deadline, status)
+
+	// Only k0s updates are cordoning nodes.
+	if signalData.Timeout.Uncordon && signalData.Command.K0sUpdate != nil {
+		if err := r.uncordonNode(ctx, signalNode); err != nil {
+			return cr.Result{}, fmt.Errorf("unable to un-cordon node='%s': %w", req.Name, err)
+		}
+	}
+
+	signalData.Status = apsigv2.NewStatus(apsigcomm.Failed)
+	signalNodeCopy := r.delegate.DeepCopy(signalNode)
+	if err := signalData.Marshal(signalNodeCopy.GetAnnotations()); err != nil {
+		return cr.Result{}, fmt.Errorf("unable to marshal signal data for node='%s': %w", req.Name, err)
+	}
+
+	if err := r.client.Update(ctx, signalNodeCopy, &crcli.UpdateOptions{}); err != nil {
+		return cr.Result{}, fmt.Errorf("failed to update signal node to status '%s': %w", signalData.Status.Status, err)
+	}
+
+	return cr.Result{}, nil
+}
+
+// uncordonNode un-cordons the node of the signal node, if it's cordoned.
+func (r *timeoutController) uncordonNode(ctx context.Context, signalNode crcli.Object) error {
+	node, ok := signalNode.(*corev1.Node)
+	if !ok {
+		nodeName := signalNode.GetName()
+		if controlNode, ok := signalNode.(*apv1beta2.ControlNode); ok {
+			for _, addr := range controlNode.Status.Addresses {
+				if addr.Type == corev1.NodeHostName {
+					nodeName = addr.Address
+					break
+				}
+			}
+		}
+
+		// Controllers without a worker don't have a node.
+		node = &corev1.Node{}
+		if err := r.client.Get(ctx, crcli.ObjectKey{Name: nodeName}, node); err != nil {
+			return crcli.IgnoreNotFound(err)
+		}
+	}
+
+	if !node.Spec.Unschedulable {
+		return nil
+	}
+
+	r.log.Infof("Un-cordoning node %s", node.Name)
+	return r.uncordon(ctx, node)
+}
+
+// isDone determines if the signal node has finished processing the command.
+func isDone(signalData apsigv2.SignalData) bool {
+	if signalData.Status == nil {
+		return false
+	}
+
+	switch signalData.Status.Status {
+	case apsigcomm.Completed, apsigcomm.Failed, apsigcomm.FailedDownload:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package timeout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
+	apsigcomm "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common"
+	apsigv2 "github.com/k0sproject/k0s/pkg/autopilot/signaling/v2"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	cr "sigs.k8s.io/controller-runtime"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTimeoutController(t *testing.T) {
+	var tests = []struct {
+		name             string
+		status           string
+		deadline         time.Duration
+		uncordon         bool
+		expectedStatus   string
+		expectedRequeue  bool
+		expectedUncordon bool
+	}{
+		{"NotExpired", "Downloading", time.Hour, false, "Downloading", true, false},
+		{"Expired", "ApplyingUpdate", -time.Minute, false, apsigcomm.Failed, false, false},
+		{"ExpiredUncordon", "ApplyingUpdate", -time.Minute, true, apsigcomm.Failed, false, true},
+		{"Completed", apsigcomm.Completed, -time.Minute, true, apsigcomm.Completed, false, false},
+	}
+
+	scheme := apimruntime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signalData := apsigv2.SignalData{
+				PlanID:  "id123",
+				Created: time.Now().Format(time.RFC3339),
+				Command: apsigv2.Command{
+					ID: ptr.To(0),
+					K0sUpdate: &apsigv2.CommandK0sUpdate{
+						URL:     "https://k0s.example.com/downloads/k0s-v99.99.99",
+						Version: "v99.99.99",
+					},
+				},
+				Status: apsigv2.NewStatus(test.status),
+				Timeout: &apsigv2.Timeout{
+					Deadline: time.Now().Add(test.deadline).Format(time.RFC3339),
+					Uncordon: test.uncordon,
+				},
+			}
+
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker0", Annotations: map[string]string{}},
+				Spec:       corev1.NodeSpec{Unschedulable: true},
+			}
+			require.NoError(t, signalData.Marshal(node.Annotations))
+
+			client := crfake.NewClientBuilder().WithScheme(scheme).WithObjects(node).Build()
+
+			var uncordoned bool
+			controller := &timeoutController{
+				log:      logrus.NewEntry(logrus.StandardLogger()),
+				client:   client,
+				delegate: apdel.NodeControllerDelegate(),
+				uncordon: func(ctx context.Context, node *corev1.Node) error {
+					uncordoned = true
+					return nil
+				},
+			}
+
+			ctx := t.Context()
+			result, err := controller.Reconcile(ctx, cr.Request{NamespacedName: crcli.ObjectKey{Name: "worker0"}})
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedRequeue, result.RequeueAfter > 0)
+			assert.Equal(t, test.expectedUncordon, uncordoned)
+
+			var updated corev1.Node
+			require.NoError(t, client.Get(ctx, crcli.ObjectKey{Name: "worker0"}, &updated))
+			var updatedSignalData apsigv2.SignalData
+			require.NoError(t, updatedSignalData.Unmarshal(updated.Annotations))
+			assert.Equal(t, test.expectedStatus, updatedSignalData.Status.Status)
+		})
+	}
+}
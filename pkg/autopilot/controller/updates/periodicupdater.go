@@ -8,6 +8,7 @@ package updates
 import (
 	"context"
 	"os"
+	"strconv"
 	"time"
 
 	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
@@ -55,6 +56,9 @@ func (u *periodicUpdater) Config() *apv1beta2.UpdateConfig {
 func (u *periodicUpdater) Run() error {
 	u.log.Debug("starting periodic updater")
 	checkDuration := 10 * time.Minute
+	if pollInterval := u.updateConfig.Spec.PollInterval; pollInterval != nil && pollInterval.Duration > 0 {
+		checkDuration = pollInterval.Duration
+	}
 	// ENV var used only for testing purposes
 	if e := os.Getenv("K0S_UPDATE_PERIOD"); e != "" {
 		cd, err := time.ParseDuration(e)
@@ -148,6 +152,14 @@ func (u *periodicUpdater) checkForUpdate() {
 		return
 	}
 
+	if held, err := u.updateConfig.Spec.IsVersionHeld(latestVersion.Version); err != nil {
+		u.log.Errorf("failed to check version hold: %v", err)
+		return
+	} else if held {
+		u.log.Infof("new version %s available but held back by version hold %s", latestVersion.Version, u.updateConfig.Spec.VersionHold)
+		return
+	}
+
 	if !u.updateConfig.Spec.UpgradeStrategy.Periodic.IsWithinPeriod(time.Now()) {
 		u.log.Infof("new version available but not within update window")
 		return
@@ -170,6 +182,11 @@ func (u *periodicUpdater) checkForUpdate() {
 		return
 	}
 
+	if found && u.updateConfig.Spec.IsCoolingDown(planCreationTime(existingPlan), time.Now()) {
+		u.log.Infof("last plan has been created less than %s ago, won't create a new one", u.updateConfig.Spec.Cooldown.Duration)
+		return
+	}
+
 	// Create the update plan
 	plan := u.updateConfig.ToPlan(latestVersion)
 	if err := u.k8sClient.Patch(ctx, &plan, crcli.Apply, patchOpts...); err != nil {
@@ -178,3 +195,13 @@ func (u *periodicUpdater) checkForUpdate() {
 	}
 	u.log.Info("successfully updated plan")
 }
+
+// planCreationTime returns the time at which the given plan has been created.
+// Generated plans carry their creation time as a Unix timestamp, other plans
+// fall back to the creation time of the object.
+func planCreationTime(plan *apv1beta2.Plan) time.Time {
+	if timestamp, err := strconv.ParseInt(plan.Spec.Timestamp, 10, 64); err == nil {
+		return time.Unix(timestamp, 0)
+	}
+	return plan.CreationTimestamp.Time
+}
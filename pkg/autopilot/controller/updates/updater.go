@@ -171,7 +171,12 @@ func (u *cronUpdater) toPlan(nextVersion *uc.Update) apv1beta2.Plan {
 		ObjectMeta: v1.ObjectMeta{
 			Name: "autopilot",
 		},
-		Spec: apv1beta2.PlanSpec{},
+		Spec: apv1beta2.PlanSpec{
+			Notifications: u.updateConfig.Spec.PlanSpec.Notifications,
+			HealthGates:   u.updateConfig.Spec.PlanSpec.HealthGates,
+			Proxy:         u.updateConfig.Spec.PlanSpec.Proxy,
+			NodeTimeout:   u.updateConfig.Spec.PlanSpec.NodeTimeout,
+		},
 	}
 
 	platforms := make(apv1beta2.PlanPlatformResourceURLMap)
@@ -221,25 +226,33 @@ func (u *cronUpdater) toPlan(nextVersion *uc.Update) apv1beta2.Plan {
 			},
 		})
 	} else {
+		// A plan command is processed by a single provider, hence every update is
+		// turned into a command of its own. The airgap bundles are distributed
+		// before any k0s update, so that the images are already in place once
+		// the updated workers restart.
+		var airgapCmds, k0sCmds []apv1beta2.PlanCommand
 		for _, cmd := range u.updateConfig.Spec.PlanSpec.Commands {
-			planCmd := apv1beta2.PlanCommand{}
-			if cmd.K0sUpdate != nil {
-				planCmd.K0sUpdate = &apv1beta2.PlanCommandK0sUpdate{
-					Version:     string(nextVersion.Version),
-					ForceUpdate: cmd.K0sUpdate.ForceUpdate,
-					Platforms:   platforms,
-					Targets:     cmd.K0sUpdate.Targets,
-				}
-			}
 			if cmd.AirgapUpdate != nil {
-				planCmd.AirgapUpdate = &apv1beta2.PlanCommandAirgapUpdate{
-					Version:   string(nextVersion.Version),
-					Platforms: airgapPlatforms,
-					Workers:   cmd.AirgapUpdate.Workers,
-				}
+				airgapCmds = append(airgapCmds, apv1beta2.PlanCommand{
+					AirgapUpdate: &apv1beta2.PlanCommandAirgapUpdate{
+						Version:   string(nextVersion.Version),
+						Platforms: airgapPlatforms,
+						Workers:   cmd.AirgapUpdate.Workers,
+					},
+				})
+			}
+			if cmd.K0sUpdate != nil {
+				k0sCmds = append(k0sCmds, apv1beta2.PlanCommand{
+					K0sUpdate: &apv1beta2.PlanCommandK0sUpdate{
+						Version:     string(nextVersion.Version),
+						ForceUpdate: cmd.K0sUpdate.ForceUpdate,
+						Platforms:   platforms,
+						Targets:     cmd.K0sUpdate.Targets,
+					},
+				})
 			}
-			p.Spec.Commands = append(p.Spec.Commands, planCmd)
 		}
+		p.Spec.Commands = append(airgapCmds, k0sCmds...)
 	}
 
 	return p
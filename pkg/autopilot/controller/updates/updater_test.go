@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package updates
+
+import (
+	"testing"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	uc "github.com/k0sproject/k0s/pkg/autopilot/updater"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCronUpdater_ToPlan(t *testing.T) {
+	planSpec := apv1beta2.AutopilotPlanSpec{
+		Notifications: []apv1beta2.PlanNotification{{
+			Events:  []apv1beta2.PlanNotificationEvent{apv1beta2.PlanNotificationFailed},
+			Webhook: apv1beta2.PlanNotificationWebhook{URL: "https://example.com/hook"},
+		}},
+		HealthGates: []apv1beta2.PlanHealthGate{{
+			Name:    "ready",
+			Timeout: metav1.Duration{Duration: 5 * time.Minute},
+		}},
+		Proxy:       &apv1beta2.PlanProxy{URL: "http://proxy.example.com:3128"},
+		NodeTimeout: &apv1beta2.PlanNodeTimeout{Duration: metav1.Duration{Duration: time.Hour}},
+	}
+
+	u := &cronUpdater{updateConfig: apv1beta2.UpdateConfig{
+		Spec: apv1beta2.UpdateSpec{PlanSpec: planSpec},
+	}}
+
+	plan := u.toPlan(&uc.Update{
+		Version:      "v1.2.3",
+		DownloadURLs: uc.DownloadURLs{"k0s": {"linux-amd64": "https://example.com/k0s"}},
+	})
+
+	assert.Equal(t, planSpec.Notifications, plan.Spec.Notifications)
+	assert.Equal(t, planSpec.HealthGates, plan.Spec.HealthGates)
+	assert.Equal(t, planSpec.Proxy, plan.Spec.Proxy)
+	assert.Equal(t, planSpec.NodeTimeout, plan.Spec.NodeTimeout)
+	if assert.Len(t, plan.Spec.Commands, 1) {
+		cmd := plan.Spec.Commands[0].K0sUpdate
+		require.NotNil(t, cmd)
+		assert.Equal(t, "v1.2.3", cmd.Version)
+		assert.Equal(t, "https://example.com/k0s", cmd.Platforms["linux-amd64"].URL)
+	}
+}
@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"encoding/hex"
+	"net/url"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+
+	"github.com/k0sproject/version"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var weekdays = sets.New("Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday")
+
+// validatePlan checks a plan for errors that would otherwise only surface
+// once the plan is being processed, or even when the nodes are signaled.
+func validatePlan(plan *apv1beta2.Plan) field.ErrorList {
+	var errs field.ErrorList
+
+	commandsPath := field.NewPath("spec", "commands")
+	for i, cmd := range plan.Spec.Commands {
+		path := commandsPath.Index(i)
+
+		var numCommands int
+		if cmd.K0sUpdate != nil {
+			numCommands++
+			errs = append(errs, validateVersion(path.Child("k0supdate", "version"), cmd.K0sUpdate.Version)...)
+			errs = append(errs, validatePlatforms(path.Child("k0supdate", "platforms"), cmd.K0sUpdate.Platforms)...)
+			errs = append(errs, validateTarget(path.Child("k0supdate", "targets", "controllers"), cmd.K0sUpdate.Targets.Controllers)...)
+			errs = append(errs, validateNoGroups(path.Child("k0supdate", "targets", "controllers"), cmd.K0sUpdate.Targets.Controllers)...)
+			errs = append(errs, validateTarget(path.Child("k0supdate", "targets", "workers"), cmd.K0sUpdate.Targets.Workers)...)
+		}
+		if cmd.AirgapUpdate != nil {
+			numCommands++
+			errs = append(errs, validateVersion(path.Child("airgapupdate", "version"), cmd.AirgapUpdate.Version)...)
+			errs = append(errs, validatePlatforms(path.Child("airgapupdate", "platforms"), cmd.AirgapUpdate.Platforms)...)
+			errs = append(errs, validateTarget(path.Child("airgapupdate", "workers"), cmd.AirgapUpdate.Workers)...)
+		}
+		if cmd.RuntimeUpdate != nil {
+			numCommands++
+			for j, component := range cmd.RuntimeUpdate.Components {
+				errs = append(errs, validatePlatforms(path.Child("runtimeupdate", "components").Index(j).Child("platforms"), component.Platforms)...)
+			}
+			errs = append(errs, validateTarget(path.Child("runtimeupdate", "workers"), cmd.RuntimeUpdate.Workers)...)
+		}
+		if cmd.HelmUpdate != nil {
+			numCommands++
+			errs = append(errs, validateHelmCharts(path.Child("helmupdate", "charts"), cmd.HelmUpdate.Charts)...)
+		}
+
+		if numCommands != 1 {
+			errs = append(errs, field.Invalid(path, numCommands, "exactly one of k0supdate, airgapupdate, runtimeupdate or helmupdate needs to be specified"))
+		}
+	}
+
+	errs = append(errs, validateNodeTimeout(field.NewPath("spec", "nodeTimeout"), plan.Spec.NodeTimeout)...)
+
+	return errs
+}
+
+// validateUpdateConfig checks an update config for errors that would otherwise
+// only surface once a plan is generated from it.
+func validateUpdateConfig(uc *apv1beta2.UpdateConfig) field.ErrorList {
+	var errs field.ErrorList
+
+	specPath := field.NewPath("spec")
+	if uc.Spec.UpdateServer != "" {
+		errs = append(errs, validateURL(specPath.Child("updateServer"), uc.Spec.UpdateServer)...)
+	}
+
+	if uc.Spec.PollInterval != nil && uc.Spec.PollInterval.Duration < time.Minute {
+		errs = append(errs, field.Invalid(specPath.Child("pollInterval"), uc.Spec.PollInterval.Duration.String(), "needs to be at least 1m"))
+	}
+	if uc.Spec.VersionHold != "" {
+		errs = append(errs, validateVersion(specPath.Child("versionHold"), uc.Spec.VersionHold)...)
+	}
+	if uc.Spec.Cooldown != nil && uc.Spec.Cooldown.Duration < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("cooldown"), uc.Spec.Cooldown.Duration.String(), "must not be negative"))
+	}
+
+	if uc.Spec.UpgradeStrategy.Type == apv1beta2.UpdateStrategyTypePeriodic {
+		path := specPath.Child("upgradeStrategy", "periodic")
+		periodic := uc.Spec.UpgradeStrategy.Periodic
+		for i, day := range periodic.Days {
+			if !weekdays.Has(day) {
+				errs = append(errs, field.NotSupported(path.Child("days").Index(i), day, sets.List(weekdays)))
+			}
+		}
+		if periodic.StartTime != "" {
+			if _, err := time.Parse("15:04", periodic.StartTime); err != nil {
+				errs = append(errs, field.Invalid(path.Child("startTime"), periodic.StartTime, "needs to be in the format HH:MM"))
+			}
+		}
+		if periodic.Length != "" {
+			if _, err := time.ParseDuration(periodic.Length); err != nil {
+				errs = append(errs, field.Invalid(path.Child("length"), periodic.Length, err.Error()))
+			}
+		}
+	}
+
+	errs = append(errs, validateNodeTimeout(specPath.Child("planSpec", "nodeTimeout"), uc.Spec.PlanSpec.NodeTimeout)...)
+
+	commandsPath := specPath.Child("planSpec", "commands")
+	for i, cmd := range uc.Spec.PlanSpec.Commands {
+		path := commandsPath.Index(i)
+		if cmd.K0sUpdate != nil {
+			errs = append(errs, validateTarget(path.Child("k0supdate", "targets", "controllers"), cmd.K0sUpdate.Targets.Controllers)...)
+			errs = append(errs, validateNoGroups(path.Child("k0supdate", "targets", "controllers"), cmd.K0sUpdate.Targets.Controllers)...)
+			errs = append(errs, validateTarget(path.Child("k0supdate", "targets", "workers"), cmd.K0sUpdate.Targets.Workers)...)
+		}
+		if cmd.AirgapUpdate != nil {
+			errs = append(errs, validateTarget(path.Child("airgapupdate", "workers"), cmd.AirgapUpdate.Workers)...)
+		}
+	}
+
+	return errs
+}
+
+func validateVersion(path *field.Path, v string) field.ErrorList {
+	if v == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+	if _, err := version.NewVersion(v); err != nil {
+		return field.ErrorList{field.Invalid(path, v, err.Error())}
+	}
+	return nil
+}
+
+func validatePlatforms(path *field.Path, platforms apv1beta2.PlanPlatformResourceURLMap) field.ErrorList {
+	if len(platforms) == 0 {
+		return field.ErrorList{field.Required(path, "at least one platform needs to be specified")}
+	}
+
+	var errs field.ErrorList
+	for platform, resource := range platforms {
+		path := path.Key(platform)
+		if resource.URL == "" {
+			errs = append(errs, field.Required(path.Child("url"), ""))
+		} else {
+			errs = append(errs, validateURL(path.Child("url"), resource.URL)...)
+		}
+		if resource.Sha256 != "" {
+			if sum, err := hex.DecodeString(resource.Sha256); err != nil || len(sum) != 32 {
+				errs = append(errs, field.Invalid(path.Child("sha256"), resource.Sha256, "needs to be a hex encoded SHA256 hash"))
+			}
+		}
+	}
+
+	return errs
+}
+
+func validateHelmCharts(path *field.Path, charts []apv1beta2.PlanCommandHelmChart) field.ErrorList {
+	if len(charts) == 0 {
+		return field.ErrorList{field.Required(path, "at least one chart needs to be specified")}
+	}
+
+	var errs field.ErrorList
+	seen := sets.New[string]()
+	for i, chart := range charts {
+		path := path.Index(i)
+		if chart.Name == "" {
+			errs = append(errs, field.Required(path.Child("name"), ""))
+		} else if seen.Has(chart.Name) {
+			errs = append(errs, field.Duplicate(path.Child("name"), chart.Name))
+		}
+		seen.Insert(chart.Name)
+		if chart.Version == "" {
+			errs = append(errs, field.Required(path.Child("version"), ""))
+		}
+	}
+
+	return errs
+}
+
+func validateNodeTimeout(path *field.Path, nodeTimeout *apv1beta2.PlanNodeTimeout) field.ErrorList {
+	if nodeTimeout != nil && nodeTimeout.Duration.Duration <= 0 {
+		return field.ErrorList{field.Invalid(path.Child("duration"), nodeTimeout.Duration.Duration.String(), "needs to be positive")}
+	}
+
+	return nil
+}
+
+func validateURL(path *field.Path, rawURL string) field.ErrorList {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, rawURL, err.Error())}
+	}
+	if !u.IsAbs() || u.Host == "" {
+		return field.ErrorList{field.Invalid(path, rawURL, "needs to be an absolute URL")}
+	}
+	return nil
+}
+
+func validateTarget(path *field.Path, target apv1beta2.PlanCommandTarget) field.ErrorList {
+	var errs field.ErrorList
+
+	discovery := target.Discovery
+	discoveryPath := path.Child("discovery")
+	if discovery.Static != nil && discovery.Selector != nil {
+		errs = append(errs, field.Forbidden(discoveryPath, "only one of static or selector may be specified"))
+	}
+
+	if discovery.Static != nil {
+		seen := sets.New[string]()
+		for i, node := range discovery.Static.Nodes {
+			if seen.Has(node) {
+				errs = append(errs, field.Duplicate(discoveryPath.Child("static", "nodes").Index(i), node))
+			}
+			seen.Insert(node)
+		}
+	}
+
+	if discovery.Selector != nil {
+		if _, err := labels.Parse(discovery.Selector.Labels); err != nil {
+			errs = append(errs, field.Invalid(discoveryPath.Child("selector", "labels"), discovery.Selector.Labels, err.Error()))
+		}
+		if _, err := fields.ParseSelector(discovery.Selector.Fields); err != nil {
+			errs = append(errs, field.Invalid(discoveryPath.Child("selector", "fields"), discovery.Selector.Fields, err.Error()))
+		}
+	}
+
+	groupsPath := path.Child("limits", "groups")
+	for i, group := range target.Limits.Groups {
+		if _, err := labels.Parse(group.Selector); err != nil {
+			errs = append(errs, field.Invalid(groupsPath.Index(i).Child("selector"), group.Selector, err.Error()))
+		}
+		if group.MaxConcurrent < 1 {
+			errs = append(errs, field.Invalid(groupsPath.Index(i).Child("maxConcurrent"), group.MaxConcurrent, "needs to be at least 1"))
+		}
+	}
+
+	return errs
+}
+
+// validateNoGroups rejects concurrency groups for targets that don't support them.
+func validateNoGroups(path *field.Path, target apv1beta2.PlanCommandTarget) field.ErrorList {
+	if len(target.Limits.Groups) > 0 {
+		return field.ErrorList{field.Forbidden(path.Child("limits", "groups"), "concurrency groups are only supported for workers")}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidatePlan(t *testing.T) {
+	validK0sUpdate := func() *apv1beta2.PlanCommandK0sUpdate {
+		return &apv1beta2.PlanCommandK0sUpdate{
+			Version: "v1.33.1+k0s.0",
+			Platforms: apv1beta2.PlanPlatformResourceURLMap{
+				"linux-amd64": {URL: "https://example.com/k0s", Sha256: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"},
+			},
+			Targets: apv1beta2.PlanCommandTargets{
+				Workers: apv1beta2.PlanCommandTarget{
+					Discovery: apv1beta2.PlanCommandTargetDiscovery{
+						Selector: &apv1beta2.PlanCommandTargetDiscoverySelector{Labels: "environment=staging"},
+					},
+				},
+			},
+		}
+	}
+
+	var tests = []struct {
+		name           string
+		modify         func(*apv1beta2.PlanCommand)
+		expectedFields []string
+	}{
+		{"Valid", func(*apv1beta2.PlanCommand) {}, nil},
+		{"NoCommand", func(cmd *apv1beta2.PlanCommand) {
+			cmd.K0sUpdate = nil
+		}, []string{"spec.commands[0]"}},
+		{"MultipleCommands", func(cmd *apv1beta2.PlanCommand) {
+			cmd.AirgapUpdate = &apv1beta2.PlanCommandAirgapUpdate{
+				Version:   cmd.K0sUpdate.Version,
+				Platforms: cmd.K0sUpdate.Platforms,
+			}
+		}, []string{"spec.commands[0]"}},
+		{"HelmUpdate", func(cmd *apv1beta2.PlanCommand) {
+			cmd.K0sUpdate = nil
+			cmd.HelmUpdate = &apv1beta2.PlanCommandHelmUpdate{
+				Charts: []apv1beta2.PlanCommandHelmChart{
+					{Name: "metrics-server", Version: "3.12.2"},
+					{Name: "metrics-server"},
+				},
+			}
+		}, []string{
+			"spec.commands[0].helmupdate.charts[1].name",
+			"spec.commands[0].helmupdate.charts[1].version",
+		}},
+		{"BadVersion", func(cmd *apv1beta2.PlanCommand) {
+			cmd.K0sUpdate.Version = "latest"
+		}, []string{"spec.commands[0].k0supdate.version"}},
+		{"NoPlatforms", func(cmd *apv1beta2.PlanCommand) {
+			cmd.K0sUpdate.Platforms = nil
+		}, []string{"spec.commands[0].k0supdate.platforms"}},
+		{"BadPlatform", func(cmd *apv1beta2.PlanCommand) {
+			cmd.K0sUpdate.Platforms["linux-arm64"] = apv1beta2.PlanResourceURL{Sha256: "thisisthesha"}
+		}, []string{
+			"spec.commands[0].k0supdate.platforms[linux-arm64].url",
+			"spec.commands[0].k0supdate.platforms[linux-arm64].sha256",
+		}},
+		{"RelativeURL", func(cmd *apv1beta2.PlanCommand) {
+			cmd.K0sUpdate.Platforms["linux-arm64"] = apv1beta2.PlanResourceURL{URL: "k0s"}
+		}, []string{"spec.commands[0].k0supdate.platforms[linux-arm64].url"}},
+		{"OverlappingDiscovery", func(cmd *apv1beta2.PlanCommand) {
+			cmd.K0sUpdate.Targets.Workers.Discovery.Static = &apv1beta2.PlanCommandTargetDiscoveryStatic{
+				Nodes: []string{"worker0", "worker1", "worker0"},
+			}
+		}, []string{
+			"spec.commands[0].k0supdate.targets.workers.discovery",
+			"spec.commands[0].k0supdate.targets.workers.discovery.static.nodes[2]",
+		}},
+		{"BadSelector", func(cmd *apv1beta2.PlanCommand) {
+			cmd.K0sUpdate.Targets.Workers.Discovery.Selector.Labels = "environment in (staging"
+			cmd.K0sUpdate.Targets.Workers.Discovery.Selector.Fields = "metadata.name"
+		}, []string{
+			"spec.commands[0].k0supdate.targets.workers.discovery.selector.labels",
+			"spec.commands[0].k0supdate.targets.workers.discovery.selector.fields",
+		}},
+		{"BadGroups", func(cmd *apv1beta2.PlanCommand) {
+			cmd.K0sUpdate.Targets.Workers.Limits.Groups = []apv1beta2.PlanCommandTargetConcurrencyGroup{
+				{Selector: "topology.kubernetes.io/zone=a", MaxConcurrent: 1},
+				{Selector: "zone in (a", MaxConcurrent: 0},
+			}
+			cmd.K0sUpdate.Targets.Controllers.Limits.Groups = []apv1beta2.PlanCommandTargetConcurrencyGroup{
+				{Selector: "topology.kubernetes.io/zone=a", MaxConcurrent: 1},
+			}
+		}, []string{
+			"spec.commands[0].k0supdate.targets.workers.limits.groups[1].selector",
+			"spec.commands[0].k0supdate.targets.workers.limits.groups[1].maxConcurrent",
+			"spec.commands[0].k0supdate.targets.controllers.limits.groups",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := apv1beta2.PlanCommand{K0sUpdate: validK0sUpdate()}
+			test.modify(&cmd)
+
+			plan := &apv1beta2.Plan{Spec: apv1beta2.PlanSpec{Commands: []apv1beta2.PlanCommand{cmd}}}
+
+			var fields []string
+			for _, err := range validatePlan(plan) {
+				fields = append(fields, err.Field)
+			}
+			assert.ElementsMatch(t, test.expectedFields, fields)
+		})
+	}
+}
+
+func TestValidateUpdateConfig(t *testing.T) {
+	var tests = []struct {
+		name           string
+		spec           apv1beta2.UpdateSpec
+		expectedFields []string
+	}{
+		{"Empty", apv1beta2.UpdateSpec{}, nil},
+		{"Valid", apv1beta2.UpdateSpec{
+			UpdateServer: "https://updates.k0sproject.io",
+			UpgradeStrategy: apv1beta2.UpgradeStrategy{
+				Type: apv1beta2.UpdateStrategyTypePeriodic,
+				Periodic: apv1beta2.PeriodicUpgradeStrategy{
+					Days:      []string{"Monday", "Friday"},
+					StartTime: "03:00",
+					Length:    "2h",
+				},
+			},
+		}, nil},
+		{"BadUpdateServer", apv1beta2.UpdateSpec{
+			UpdateServer: "updates.k0sproject.io",
+		}, []string{"spec.updateServer"}},
+		{"BadPolling", apv1beta2.UpdateSpec{
+			PollInterval: &metav1.Duration{Duration: time.Second},
+			VersionHold:  "one.twenty-nine",
+			Cooldown:     &metav1.Duration{Duration: -time.Hour},
+		}, []string{"spec.pollInterval", "spec.versionHold", "spec.cooldown"}},
+		{"BadPeriod", apv1beta2.UpdateSpec{
+			UpgradeStrategy: apv1beta2.UpgradeStrategy{
+				Type: apv1beta2.UpdateStrategyTypePeriodic,
+				Periodic: apv1beta2.PeriodicUpgradeStrategy{
+					Days:      []string{"Monday", "Caturday"},
+					StartTime: "3am",
+					Length:    "two hours",
+				},
+			},
+		}, []string{
+			"spec.upgradeStrategy.periodic.days[1]",
+			"spec.upgradeStrategy.periodic.startTime",
+			"spec.upgradeStrategy.periodic.length",
+		}},
+		{"BadTargets", apv1beta2.UpdateSpec{
+			PlanSpec: apv1beta2.AutopilotPlanSpec{
+				Commands: []apv1beta2.AutopilotPlanCommand{{
+					AirgapUpdate: &apv1beta2.AutopilotPlanCommandAirgapUpdate{
+						Workers: apv1beta2.PlanCommandTarget{
+							Discovery: apv1beta2.PlanCommandTargetDiscovery{
+								Static: &apv1beta2.PlanCommandTargetDiscoveryStatic{Nodes: []string{"worker0", "worker0"}},
+							},
+						},
+					},
+				}},
+			},
+		}, []string{"spec.planSpec.commands[0].airgapupdate.workers.discovery.static.nodes[1]"}},
+		{"BadNodeTimeout", apv1beta2.UpdateSpec{
+			PlanSpec: apv1beta2.AutopilotPlanSpec{
+				NodeTimeout: &apv1beta2.PlanNodeTimeout{},
+			},
+		}, []string{"spec.planSpec.nodeTimeout.duration"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var fields []string
+			for _, err := range validateUpdateConfig(&apv1beta2.UpdateConfig{Spec: test.spec}) {
+				fields = append(fields, err.Field)
+			}
+			assert.ElementsMatch(t, test.expectedFields, fields)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	"github.com/k0sproject/k0s/pkg/kubernetes"
+
+	"github.com/sirupsen/logrus"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	cr "sigs.k8s.io/controller-runtime"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crman "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ConfigurationName is the name of the ValidatingWebhookConfiguration that
+// routes autopilot objects to the webhook server of the autopilot controller.
+const ConfigurationName = "autopilot.k0sproject.io"
+
+// The paths that controller-runtime registers the validating webhooks at.
+const (
+	planPath         = "/validate-autopilot-k0sproject-io-v1beta2-plan"
+	updateConfigPath = "/validate-autopilot-k0sproject-io-v1beta2-updateconfig"
+)
+
+// RegisterWebhooks registers the validating webhooks for plans and update
+// configs at the webhook server of the manager.
+func RegisterWebhooks(logger *logrus.Entry, mgr crman.Manager) error {
+	logger = logger.WithField("component", "webhook")
+
+	if err := cr.NewWebhookManagedBy(mgr).
+		For(&apv1beta2.Plan{}).
+		WithValidator(&validator[*apv1beta2.Plan]{logger, "Plan", validatePlan}).
+		Complete(); err != nil {
+		return fmt.Errorf("unable to register plan webhook: %w", err)
+	}
+
+	if err := cr.NewWebhookManagedBy(mgr).
+		For(&apv1beta2.UpdateConfig{}).
+		WithValidator(&validator[*apv1beta2.UpdateConfig]{logger, "UpdateConfig", validateUpdateConfig}).
+		Complete(); err != nil {
+		return fmt.Errorf("unable to register update config webhook: %w", err)
+	}
+
+	return nil
+}
+
+// EnsureConfiguration creates or updates the ValidatingWebhookConfiguration
+// that points the API server to the webhook server listening on the given
+// address. The webhooks fail open, so that objects can still be changed while
+// no autopilot controller is running.
+func EnsureConfiguration(ctx context.Context, cf kubernetes.ClientFactoryInterface, address string, caBundle []byte) error {
+	client, err := cf.GetClient()
+	if err != nil {
+		return err
+	}
+
+	webhook := func(name, path, resource string) admissionregistrationv1.ValidatingWebhook {
+		return admissionregistrationv1.ValidatingWebhook{
+			Name: name,
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{
+				URL:      ptr.To("https://" + address + path),
+				CABundle: caBundle,
+			},
+			Rules: []admissionregistrationv1.RuleWithOperations{{
+				Operations: []admissionregistrationv1.OperationType{
+					admissionregistrationv1.Create,
+					admissionregistrationv1.Update,
+				},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{apv1beta2.GroupName},
+					APIVersions: []string{apv1beta2.GroupVersion.Version},
+					Resources:   []string{resource},
+					Scope:       ptr.To(admissionregistrationv1.ClusterScope),
+				},
+			}},
+			FailurePolicy:           ptr.To(admissionregistrationv1.Ignore),
+			SideEffects:             ptr.To(admissionregistrationv1.SideEffectClassNone),
+			AdmissionReviewVersions: []string{"v1"},
+			TimeoutSeconds:          ptr.To[int32](5),
+		}
+	}
+
+	config := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: ConfigurationName},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			webhook("plans."+ConfigurationName, planPath, "plans"),
+			webhook("updateconfigs."+ConfigurationName, updateConfigPath, "updateconfigs"),
+		},
+	}
+
+	configs := client.AdmissionregistrationV1().ValidatingWebhookConfigurations()
+	existing, err := configs.Get(ctx, ConfigurationName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = configs.Create(ctx, config, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	existing.Webhooks = config.Webhooks
+	_, err = configs.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// validator is an admission.CustomValidator that validates objects of type T
+// on creation and update.
+type validator[T crcli.Object] struct {
+	logger   *logrus.Entry
+	kind     string
+	validate func(T) field.ErrorList
+}
+
+var _ admission.CustomValidator = (*validator[*apv1beta2.Plan])(nil)
+
+func (v *validator[T]) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validateObject(obj)
+}
+
+func (v *validator[T]) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	// Only spec changes are validated, so that existing objects can still be
+	// annotated or labeled, even if they wouldn't pass the validation.
+	if oldObj, ok := oldObj.(T); ok {
+		if newObj, ok := newObj.(T); ok && oldObj.GetGeneration() != 0 && oldObj.GetGeneration() == newObj.GetGeneration() {
+			return nil, nil
+		}
+	}
+
+	return nil, v.validateObject(newObj)
+}
+
+func (v *validator[T]) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *validator[T]) validateObject(obj runtime.Object) error {
+	typed, ok := obj.(T)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", obj)
+	}
+
+	errs := v.validate(typed)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	v.logger.Infof("Rejecting %s %q: %v", v.kind, typed.GetName(), errs.ToAggregate())
+	return apierrors.NewInvalid(schema.GroupKind{Group: apv1beta2.GroupName, Kind: v.kind}, typed.GetName(), errs)
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package workerleases
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
+
+	"github.com/sirupsen/logrus"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cr "sigs.k8s.io/controller-runtime"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crman "sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// leaseController pre-creates the autopilot worker lease of every node, and
+// allows each node to access its own lease only. Nodes can't be allowed to
+// create leases on their own, since RBAC can't restrict the names of objects
+// being created.
+type leaseController struct {
+	log    *logrus.Entry
+	client crcli.Client
+	// Roles and RoleBindings are read directly, so that they don't need to be
+	// watched cluster-wide.
+	reader crcli.Reader
+}
+
+// RegisterControllers registers the worker lease controller to the
+// controller-runtime manager. It only runs on the leading controller.
+func RegisterControllers(logger *logrus.Entry, mgr crman.Manager, leaderMode bool) error {
+	if !leaderMode {
+		return nil
+	}
+
+	return cr.NewControllerManagedBy(mgr).
+		Named("worker-leases").
+		For(&corev1.Node{}).
+		Complete(
+			&leaseController{
+				log:    logger.WithField("reconciler", "worker-leases"),
+				client: mgr.GetClient(),
+				reader: mgr.GetAPIReader(),
+			},
+		)
+}
+
+func (c *leaseController) Reconcile(ctx context.Context, req cr.Request) (cr.Result, error) {
+	var node corev1.Node
+	if err := c.client.Get(ctx, req.NamespacedName, &node); err != nil {
+		// The lease, Role and RoleBinding of a deleted node are garbage
+		// collected along with it.
+		return cr.Result{}, crcli.IgnoreNotFound(err)
+	}
+
+	if err := c.ensureLease(ctx, &node); err != nil {
+		return cr.Result{}, err
+	}
+	if err := c.ensureRole(ctx, &node); err != nil {
+		return cr.Result{}, err
+	}
+	return cr.Result{}, c.ensureRoleBinding(ctx, &node)
+}
+
+// ownedBy returns the metadata of the node's object with the given name. The
+// object is owned by the node.
+func ownedBy(node *corev1.Node, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: apconst.AutopilotNamespace,
+		Name:      name,
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Node",
+			Name:       node.Name,
+			UID:        node.UID,
+		}},
+	}
+}
+
+// ensureLease creates the worker lease of the given node, unless it exists.
+func (c *leaseController) ensureLease(ctx context.Context, node *corev1.Node) error {
+	lease := coordinationv1.Lease{
+		ObjectMeta: ownedBy(node, apconst.WorkerLeasePrefix+node.Name),
+	}
+
+	if err := c.client.Create(ctx, &lease); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to create worker lease for node %s: %w", node.Name, err)
+	}
+
+	c.log.Infof("Created worker lease for node %s", node.Name)
+	return nil
+}
+
+// ensureRole creates or updates the Role that allows access to the worker
+// lease of the given node. The Role has the same name as the lease.
+func (c *leaseController) ensureRole(ctx context.Context, node *corev1.Node) error {
+	leaseName := apconst.WorkerLeasePrefix + node.Name
+	rules := []rbacv1.PolicyRule{{
+		APIGroups:     []string{coordinationv1.GroupName},
+		Resources:     []string{"leases"},
+		ResourceNames: []string{leaseName},
+		Verbs:         []string{"get", "update"},
+	}}
+
+	var role rbacv1.Role
+	key := crcli.ObjectKey{Namespace: apconst.AutopilotNamespace, Name: leaseName}
+	if err := c.reader.Get(ctx, key, &role); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		role = rbacv1.Role{ObjectMeta: ownedBy(node, leaseName), Rules: rules}
+		if err := c.client.Create(ctx, &role); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create worker lease role for node %s: %w", node.Name, err)
+		}
+		return nil
+	}
+
+	if reflect.DeepEqual(role.Rules, rules) {
+		return nil
+	}
+
+	role.Rules = rules
+	if err := c.client.Update(ctx, &role); err != nil {
+		return fmt.Errorf("failed to update worker lease role for node %s: %w", node.Name, err)
+	}
+
+	return nil
+}
+
+// ensureRoleBinding creates or updates the RoleBinding that grants the worker
+// lease Role of the given node to that node.
+func (c *leaseController) ensureRoleBinding(ctx context.Context, node *corev1.Node) error {
+	leaseName := apconst.WorkerLeasePrefix + node.Name
+	roleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "Role",
+		Name:     leaseName,
+	}
+	subjects := []rbacv1.Subject{{
+		APIGroup: rbacv1.GroupName,
+		Kind:     rbacv1.UserKind,
+		Name:     "system:node:" + node.Name,
+	}}
+
+	var binding rbacv1.RoleBinding
+	key := crcli.ObjectKey{Namespace: apconst.AutopilotNamespace, Name: leaseName}
+	if err := c.reader.Get(ctx, key, &binding); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		binding = rbacv1.RoleBinding{ObjectMeta: ownedBy(node, leaseName), RoleRef: roleRef, Subjects: subjects}
+		if err := c.client.Create(ctx, &binding); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create worker lease role binding for node %s: %w", node.Name, err)
+		}
+		return nil
+	}
+
+	if reflect.DeepEqual(binding.Subjects, subjects) {
+		return nil
+	}
+
+	binding.Subjects = subjects
+	if err := c.client.Update(ctx, &binding); err != nil {
+		return fmt.Errorf("failed to update worker lease role binding for node %s: %w", node.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package workerleases
+
+import (
+	"fmt"
+	"testing"
+
+	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimruntime "k8s.io/apimachinery/pkg/runtime"
+	cr "sigs.k8s.io/controller-runtime"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLeaseController(t *testing.T) {
+	scheme := apimruntime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, coordinationv1.AddToScheme(scheme))
+	require.NoError(t, rbacv1.AddToScheme(scheme))
+
+	client := crfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker0", UID: "uid0"}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker1", UID: "uid1"}},
+		).
+		Build()
+
+	controller := &leaseController{
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		client: client,
+		reader: client,
+	}
+
+	ctx := t.Context()
+	reconcile := func(name string) {
+		_, err := controller.Reconcile(ctx, cr.Request{NamespacedName: crcli.ObjectKey{Name: name}})
+		require.NoError(t, err)
+	}
+
+	reconcile("worker0")
+	reconcile("worker1")
+
+	for i, name := range []string{"worker0", "worker1"} {
+		leaseName := "k0s-autopilot-worker-" + name
+		key := crcli.ObjectKey{Namespace: apconst.AutopilotNamespace, Name: leaseName}
+		uid := fmt.Sprintf("uid%d", i)
+
+		var lease coordinationv1.Lease
+		require.NoError(t, client.Get(ctx, key, &lease))
+		if assert.Len(t, lease.OwnerReferences, 1) {
+			assert.Equal(t, "Node", lease.OwnerReferences[0].Kind)
+			assert.Equal(t, uid, string(lease.OwnerReferences[0].UID))
+		}
+
+		// Each node may only access its own lease.
+		var role rbacv1.Role
+		require.NoError(t, client.Get(ctx, key, &role))
+		if assert.Len(t, role.Rules, 1) {
+			assert.Equal(t, []string{"get", "update"}, role.Rules[0].Verbs)
+			assert.Equal(t, []string{leaseName}, role.Rules[0].ResourceNames)
+		}
+		if assert.Len(t, role.OwnerReferences, 1) {
+			assert.Equal(t, uid, string(role.OwnerReferences[0].UID))
+		}
+
+		var binding rbacv1.RoleBinding
+		require.NoError(t, client.Get(ctx, key, &binding))
+		assert.Equal(t, leaseName, binding.RoleRef.Name)
+		assert.Equal(t, []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: "system:node:" + name}}, binding.Subjects)
+		if assert.Len(t, binding.OwnerReferences, 1) {
+			assert.Equal(t, uid, string(binding.OwnerReferences[0].UID))
+		}
+	}
+
+	// Tampered bindings are restored.
+	key := crcli.ObjectKey{Namespace: apconst.AutopilotNamespace, Name: "k0s-autopilot-worker-worker0"}
+	var binding rbacv1.RoleBinding
+	require.NoError(t, client.Get(ctx, key, &binding))
+	binding.Subjects = []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "system:nodes"}}
+	require.NoError(t, client.Update(ctx, &binding))
+	reconcile("worker0")
+	require.NoError(t, client.Get(ctx, key, &binding))
+	assert.Equal(t, []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: "system:node:worker0"}}, binding.Subjects)
+
+	// Deleted nodes are ignored, their objects are garbage collected.
+	require.NoError(t, client.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker1"}}))
+	reconcile("worker1")
+}
@@ -6,16 +6,23 @@ package download
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"hash"
 	"io"
+	"net/http"
+	"net/url"
 	"path/filepath"
+	"strings"
 	"time"
 
 	internalhttp "github.com/k0sproject/k0s/internal/http"
 	"github.com/k0sproject/k0s/internal/pkg/file"
+	apmetrics "github.com/k0sproject/k0s/pkg/autopilot/metrics"
+
+	"golang.org/x/net/http/httpproxy"
 )
 
 type Downloader interface {
@@ -28,6 +35,21 @@ type Config struct {
 	Hasher       hash.Hash
 	DownloadDir  string
 	Filename     string
+
+	// Proxy overrides the proxy settings from the environment, if set.
+	Proxy *Proxy
+}
+
+// Proxy describes the proxy settings of a download.
+type Proxy struct {
+	// URL is the proxy URL used for both HTTP and HTTPS. If empty, the proxy
+	// settings from the environment are used.
+	URL string
+	// NoProxy are the hosts that are not accessed via the proxy.
+	NoProxy []string
+	// CABundle are PEM encoded CA certificates that are trusted in addition to
+	// the system's certificate authorities.
+	CABundle string
 }
 
 type downloader struct {
@@ -44,7 +66,9 @@ func NewDownloader(config Config) Downloader {
 
 // Performs the download process.
 func (d *downloader) Download(ctx context.Context) (err error) {
-	var targets []io.Writer
+	defer func(start time.Time) { apmetrics.ObserveDownload(start, err) }(time.Now())
+
+	targets := []io.Writer{countingWriter{}}
 
 	// If we've been provided a hash and actual value to compare with, use it.
 	var expectedHash []byte
@@ -57,7 +81,10 @@ func (d *downloader) Download(ctx context.Context) (err error) {
 	}
 
 	fileName := "download"
-	var downloadOpts []internalhttp.DownloadOption
+	downloadOpts, err := d.config.Proxy.downloadOptions()
+	if err != nil {
+		return err
+	}
 	if d.config.Filename == "" {
 		downloadOpts = append(downloadOpts, internalhttp.StoreSuggestedRemoteFileNameInto(&fileName))
 	} else {
@@ -101,3 +128,45 @@ func (d *downloader) Download(ctx context.Context) (err error) {
 
 	return nil
 }
+
+// downloadOptions converts the proxy settings into download options.
+func (p *Proxy) downloadOptions() ([]internalhttp.DownloadOption, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	var opts []internalhttp.DownloadOption
+
+	if p.URL != "" {
+		proxyConfig := httpproxy.Config{
+			HTTPProxy:  p.URL,
+			HTTPSProxy: p.URL,
+			NoProxy:    strings.Join(p.NoProxy, ","),
+		}
+		proxyFunc := proxyConfig.ProxyFunc()
+		opts = append(opts, internalhttp.WithProxy(func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}))
+	}
+
+	if p.CABundle != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM([]byte(p.CABundle)) {
+			return nil, errors.New("no valid certificates found in CA bundle")
+		}
+		opts = append(opts, internalhttp.WithRootCAs(rootCAs))
+	}
+
+	return opts, nil
+}
+
+// countingWriter reports the number of written bytes to the download metrics.
+type countingWriter struct{}
+
+func (countingWriter) Write(p []byte) (int, error) {
+	apmetrics.AddDownloadedBytes(len(p))
+	return len(p), nil
+}
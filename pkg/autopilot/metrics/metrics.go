@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "autopilot"
+
+var (
+	plansInProgress = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "plans_in_progress",
+		Help:      "Number of plans that are currently being executed.",
+	})
+
+	planTargets = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "plan_targets",
+		Help:      "Number of plan targets (signal nodes) per command, target group and state.",
+	}, []string{"command", "target", "state"})
+
+	planStateDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "plan_state_duration_seconds",
+		Help:      "Time spent by plans in a state before transitioning into another one.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+	}, []string{"state"})
+
+	downloadedBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "download_bytes_total",
+		Help:      "Total number of bytes downloaded for update payloads.",
+	})
+
+	downloadDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "download_duration_seconds",
+		Help:      "Duration of update payload downloads.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+	}, []string{"result"})
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(
+		plansInProgress,
+		planTargets,
+		planStateDuration,
+		downloadedBytes,
+		downloadDuration,
+	)
+}
+
+// PlanTargetKey identifies a group of targets of a plan command.
+type PlanTargetKey struct {
+	Command string
+	Target  string
+}
+
+// RecordPlan records the current state of the plan with the given ID, along
+// with the number of plan targets per state. Whenever the state of a plan
+// changes, the time spent in the previous state is observed.
+func RecordPlan(id, state string, inProgress bool, targets map[PlanTargetKey]map[string]int) {
+	defaultTracker.transition(id, state, time.Now())
+
+	if inProgress {
+		plansInProgress.Set(1)
+	} else {
+		plansInProgress.Set(0)
+	}
+
+	planTargets.Reset()
+	for key, states := range targets {
+		for state, count := range states {
+			planTargets.WithLabelValues(key.Command, key.Target, state).Set(float64(count))
+		}
+	}
+}
+
+// AddDownloadedBytes adds the given number of bytes to the total amount of
+// downloaded bytes.
+func AddDownloadedBytes(n int) {
+	downloadedBytes.Add(float64(n))
+}
+
+// ObserveDownload records the duration of a download that started at the given
+// time.
+func ObserveDownload(start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+
+	downloadDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+}
+
+var defaultTracker = stateTracker{observe: func(state string, d time.Duration) {
+	planStateDuration.WithLabelValues(state).Observe(d.Seconds())
+}}
+
+// stateTracker keeps track of the time at which a plan has entered its current
+// state, in order to observe how long plans remain in their states.
+type stateTracker struct {
+	mu      sync.Mutex
+	id      string
+	state   string
+	since   time.Time
+	observe func(state string, d time.Duration)
+}
+
+func (t *stateTracker) transition(id, state string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.id == id && t.state == state {
+		return
+	}
+
+	// Only observe the previous state if it belongs to the same plan.
+	if t.id == id && t.state != "" {
+		t.observe(t.state, now.Sub(t.since))
+	}
+
+	t.id, t.state, t.since = id, state, now
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStateTracker ensures that the durations of plan states are only observed
+// once a plan leaves a state.
+func TestStateTracker(t *testing.T) {
+	type observation struct {
+		state    string
+		duration time.Duration
+	}
+
+	var observed []observation
+	tracker := stateTracker{observe: func(state string, d time.Duration) {
+		observed = append(observed, observation{state, d})
+	}}
+
+	start := time.Now()
+	tracker.transition("id1", "SchedulableWait", start)
+	tracker.transition("id1", "SchedulableWait", start.Add(5*time.Second))
+	tracker.transition("id1", "Schedulable", start.Add(10*time.Second))
+	tracker.transition("id1", "Completed", start.Add(11*time.Second))
+	tracker.transition("id2", "SchedulableWait", start.Add(20*time.Second))
+
+	assert.Equal(t, []observation{
+		{"SchedulableWait", 10 * time.Second},
+		{"Schedulable", 1 * time.Second},
+	}, observed)
+}
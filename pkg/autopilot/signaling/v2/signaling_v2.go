@@ -46,10 +46,25 @@ func NewStatus(status string) *Status {
 // SignalData provides all of the details of the requested `autopilot` operation,
 // as well as its current status.
 type SignalData struct {
-	PlanID  string  `json:"planId" validate:"required"`
-	Created string  `json:"created" validate:"required"`
-	Command Command `json:"command" validate:"required"`
-	Status  *Status `json:"status,omitempty"`
+	PlanID  string   `json:"planId" validate:"required"`
+	Created string   `json:"created" validate:"required"`
+	Command Command  `json:"command" validate:"required"`
+	Status  *Status  `json:"status,omitempty"`
+	Proxy   *Proxy   `json:"proxy,omitempty"`
+	Timeout *Timeout `json:"timeout,omitempty"`
+}
+
+// Proxy overrides the proxy settings used for downloads.
+type Proxy struct {
+	URL      string   `json:"url,omitempty" validate:"omitempty,url"`
+	NoProxy  []string `json:"noProxy,omitempty"`
+	CABundle string   `json:"caBundle,omitempty"`
+}
+
+// Timeout limits the time that the signal node may spend on the operation.
+type Timeout struct {
+	Deadline string `json:"deadline" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	Uncordon bool   `json:"uncordon,omitempty"`
 }
 
 var _ signaling.Validator = (*SignalData)(nil)
@@ -124,9 +139,10 @@ func IsSignalingPresent(m map[string]string) bool {
 // Command contains all of the at-most-one commands that can be used to control
 // an `autopilot` operation. Currently only `update` is supported.
 type Command struct {
-	ID           *int                 `json:"id" validate:"required"`
-	K0sUpdate    *CommandK0sUpdate    `json:"k0supdate,omitempty"`
-	AirgapUpdate *CommandAirgapUpdate `json:"airgapupdate,omitempty"`
+	ID            *int                  `json:"id" validate:"required"`
+	K0sUpdate     *CommandK0sUpdate     `json:"k0supdate,omitempty"`
+	AirgapUpdate  *CommandAirgapUpdate  `json:"airgapupdate,omitempty"`
+	RuntimeUpdate *CommandRuntimeUpdate `json:"runtimeupdate,omitempty"`
 }
 
 // CommandK0sUpdate describes what an update to `k0s` is.
@@ -144,14 +160,35 @@ type CommandAirgapUpdate struct {
 	Sha256  string `json:"sha256,omitempty"`
 }
 
+// CommandRuntimeUpdate describes an update of the bundled runtime components.
+type CommandRuntimeUpdate struct {
+	Version    string                    `json:"version" validate:"required"`
+	Components []CommandRuntimeComponent `json:"components" validate:"required,min=1,dive"`
+}
+
+// CommandRuntimeComponent describes a single bundled runtime binary to be replaced.
+type CommandRuntimeComponent struct {
+	Name   string `json:"name" validate:"required"`
+	URL    string `json:"url" validate:"required,url"`
+	Sha256 string `json:"sha256,omitempty"`
+}
+
 // validateCommand ensures that a `Command` contains at-most-one of
-// the following fields: `K0sUpdate`, `AirgapUpdate`.
+// the following fields: `K0sUpdate`, `AirgapUpdate`, `RuntimeUpdate`.
 func validateCommand(sl validator.StructLevel) {
 	cui := sl.Current().Interface().(Command)
 
+	var defined int
+	for _, cmd := range []bool{cui.K0sUpdate != nil, cui.AirgapUpdate != nil, cui.RuntimeUpdate != nil} {
+		if cmd {
+			defined++
+		}
+	}
+
 	// Provide at-most-one semantics, ensuring that only one field is defined.
-	if (cui.K0sUpdate == nil && cui.AirgapUpdate == nil) || (cui.K0sUpdate != nil && cui.AirgapUpdate != nil) {
+	if defined != 1 {
 		sl.ReportError(reflect.ValueOf(cui.K0sUpdate), "K0sUpdate", "k0supdate", "atmostone", "")
 		sl.ReportError(reflect.ValueOf(cui.AirgapUpdate), "AirgapUpdate", "airgapupdate", "atmostone", "")
+		sl.ReportError(reflect.ValueOf(cui.RuntimeUpdate), "RuntimeUpdate", "runtimeupdate", "atmostone", "")
 	}
 }
@@ -53,11 +53,13 @@ func TestSignalDataValid(t *testing.T) {
 		successful bool
 	}{
 		// K0s data
-		{"Happy", SignalData{"id123", "now", commandK0s, status}, true},
-		{"MissingPlanID", SignalData{"", "now", commandK0s, status}, false},
-		{"MissingTimestamp", SignalData{"id123", "", commandK0s, status}, false},
-		{"MissingStatus", SignalData{"id123", "now", commandK0s, nil}, true},
-		{"MissingCommand", SignalData{"id123", "now", Command{}, status}, false},
+		{"Happy", SignalData{"id123", "now", commandK0s, status, nil, nil}, true},
+		{"MissingPlanID", SignalData{"", "now", commandK0s, status, nil, nil}, false},
+		{"MissingTimestamp", SignalData{"id123", "", commandK0s, status, nil, nil}, false},
+		{"MissingStatus", SignalData{"id123", "now", commandK0s, nil, nil, nil}, true},
+		{"MissingCommand", SignalData{"id123", "now", Command{}, status, nil, nil}, false},
+		{"Timeout", SignalData{"id123", "now", commandK0s, status, nil, &Timeout{Deadline: "2026-01-01T00:00:00Z"}}, true},
+		{"InvalidTimeout", SignalData{"id123", "now", commandK0s, status, nil, &Timeout{Deadline: "tomorrow"}}, false},
 	}
 
 	for _, test := range tests {
@@ -145,6 +147,99 @@ func TestSignalDataUpdateK0sValid(t *testing.T) {
 	}
 }
 
+// TestSignalDataUpdateRuntimeValid tests the validation of `CommandRuntimeUpdate` entries
+// in a `Command`.
+func TestSignalDataUpdateRuntimeValid(t *testing.T) {
+	makeSignalData := func(cmd Command) SignalData {
+		cmd.ID = new(int)
+		return SignalData{
+			PlanID:  "id123",
+			Created: "now",
+			Command: cmd,
+		}
+	}
+
+	containerd := CommandRuntimeComponent{Name: "containerd", URL: "https://foo.bar.baz/containerd"}
+
+	var tests = []struct {
+		name       string
+		data       SignalData
+		successful bool
+	}{
+		{
+			"Happy",
+			makeSignalData(Command{RuntimeUpdate: &CommandRuntimeUpdate{Version: "v1.2.3", Components: []CommandRuntimeComponent{containerd}}}),
+			true,
+		},
+		{
+			"MissingComponents",
+			makeSignalData(Command{RuntimeUpdate: &CommandRuntimeUpdate{Version: "v1.2.3"}}),
+			false,
+		},
+		{
+			"MissingComponentUrl",
+			makeSignalData(Command{RuntimeUpdate: &CommandRuntimeUpdate{Version: "v1.2.3", Components: []CommandRuntimeComponent{{Name: "runc"}}}}),
+			false,
+		},
+		{
+			"MoreThanOneCommand",
+			makeSignalData(Command{
+				RuntimeUpdate: &CommandRuntimeUpdate{Version: "v1.2.3", Components: []CommandRuntimeComponent{containerd}},
+				K0sUpdate:     &CommandK0sUpdate{URL: "https://foo.bar.baz", Version: "v1.2.3"},
+			}),
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.data.Validate()
+			if test.successful {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+// TestSignalDataProxyValid tests the validation of proxy settings.
+func TestSignalDataProxyValid(t *testing.T) {
+	makeSignalData := func(proxy *Proxy) SignalData {
+		return SignalData{
+			PlanID:  "id123",
+			Created: "now",
+			Command: Command{
+				ID:        new(int),
+				K0sUpdate: &CommandK0sUpdate{URL: "https://foo.bar.baz", Version: "v1.2.3"},
+			},
+			Proxy: proxy,
+		}
+	}
+
+	var tests = []struct {
+		name       string
+		data       SignalData
+		successful bool
+	}{
+		{"NoProxy", makeSignalData(nil), true},
+		{"Happy", makeSignalData(&Proxy{URL: "http://proxy:3128", NoProxy: []string{"10.0.0.0/8"}}), true},
+		{"OnlyCABundle", makeSignalData(&Proxy{CABundle: "-----BEGIN CERTIFICATE-----"}), true},
+		{"InvalidUrl", makeSignalData(&Proxy{URL: "proxy"}), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.data.Validate()
+			if test.successful {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 func TestMarshaling(t *testing.T) {
 	signalData1 := SignalData{
 		PlanID:  "id123",
@@ -63,3 +63,9 @@ func (c configurationStep) Restore(restoreFrom, restoreTo string) error {
 	logrus.Infof("restoring from `%s` to `%s`", objectPathInArchive, c.restoredConfigPath)
 	return file.Copy(objectPathInArchive, c.restoredConfigPath)
 }
+
+func (c configurationStep) DryRunRestore(restoreFrom, _ string, r *RestoreReport) {
+	if c.restoredConfigPath != "-" && file.Exists(path.Join(restoreFrom, "k0s.yaml")) && file.Exists(c.restoredConfigPath) {
+		r.conflictf("%s would be overwritten", c.restoredConfigPath)
+	}
+}
@@ -0,0 +1,320 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/pkg/file"
+	"github.com/k0sproject/k0s/pkg/build"
+	"github.com/k0sproject/k0s/pkg/certificate"
+	"github.com/k0sproject/k0s/pkg/config"
+
+	"github.com/cloudflare/cfssl/certinfo"
+	"github.com/k0sproject/version"
+	"github.com/sirupsen/logrus"
+)
+
+// metadataFile is the name of the file describing the backup archive.
+const metadataFile = "k0s-backup.json"
+
+// archiveMetadata describes a backup archive.
+type archiveMetadata struct {
+	// K0sVersion is the version of k0s that created the backup.
+	K0sVersion string `json:"k0sVersion"`
+	// Hostname of the node that has been backed up.
+	Hostname string `json:"hostname,omitempty"`
+	// CreatedAt is the time at which the backup has been taken.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RestoreReport contains the findings of a restore dry-run, or of verifying a
+// backup archive.
+type RestoreReport struct {
+	// Metadata of the backup archive. Empty for archives created by older k0s
+	// versions.
+	K0sVersion string
+	Hostname   string
+	CreatedAt  *time.Time
+
+	// Passed are the checks that have passed. Only reported when verifying.
+	Passed []string
+	// Conflicts are existing files that the restore would overwrite.
+	Conflicts []string
+	// Warnings are findings that don't prevent the restore, but may need
+	// attention.
+	Warnings []string
+	// Errors are findings that would make the restore fail, or leave the node
+	// in a broken state.
+	Errors []string
+}
+
+// OK returns true if no errors have been found.
+func (r *RestoreReport) OK() bool {
+	return len(r.Errors) == 0
+}
+
+// Print writes the report in a human-readable form to out.
+func (r *RestoreReport) Print(out io.Writer) {
+	if r.K0sVersion != "" {
+		fmt.Fprintf(out, "Backup created by k0s %s on %s at %s\n", r.K0sVersion, r.Hostname, r.CreatedAt.Format(time.RFC3339))
+	}
+	for _, section := range []struct {
+		title    string
+		findings []string
+	}{
+		{"Passed checks", r.Passed},
+		{"Conflicts with existing data", r.Conflicts},
+		{"Warnings", r.Warnings},
+		{"Errors", r.Errors},
+	} {
+		if len(section.findings) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "%s:\n", section.title)
+		for _, finding := range section.findings {
+			fmt.Fprintf(out, "  - %s\n", finding)
+		}
+	}
+}
+
+func (r *RestoreReport) passedf(format string, args ...any) {
+	r.Passed = append(r.Passed, fmt.Sprintf(format, args...))
+}
+
+func (r *RestoreReport) conflictf(format string, args ...any) {
+	r.Conflicts = append(r.Conflicts, fmt.Sprintf(format, args...))
+}
+
+func (r *RestoreReport) warnf(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (r *RestoreReport) errorf(format string, args ...any) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+// DryRunRestore unpacks the given backup archive into a temporary directory
+// and checks whether it can be restored onto this node, without changing
+// anything else.
+func (bm *Manager) DryRunRestore(ctx context.Context, archivePath string, k0sVars *config.CfgVars, desiredRestoredConfigPath string) *RestoreReport {
+	var report RestoreReport
+	defer os.RemoveAll(bm.tmpDir)
+
+	if err := bm.unpack(ctx, archivePath); err != nil {
+		report.errorf("%v", err)
+		return &report
+	}
+	if err := bm.remapNode(); err != nil {
+		report.errorf("%v", err)
+		return &report
+	}
+	cfg, err := bm.getConfigForRestore()
+	if err != nil {
+		report.errorf("failed to parse backed-up configuration file: %v", err)
+		return &report
+	}
+
+	bm.checkMetadata(&report)
+
+	bm.discoverSteps(filepath.Join(bm.tmpDir, "k0s.yaml"), cfg.Spec, k0sVars, "restore", desiredRestoredConfigPath, io.Discard)
+	for _, step := range bm.steps {
+		logrus.Debug("Checking restore step: ", step.Name())
+		step.DryRunRestore(bm.tmpDir, bm.dataDir, &report)
+	}
+
+	checkCertificates(bm.tmpDir, &report)
+
+	return &report
+}
+
+func writeArchiveMetadata(dir string) (string, error) {
+	metadata := archiveMetadata{K0sVersion: build.Version, CreatedAt: time.Now().UTC()}
+	if hostname, err := os.Hostname(); err == nil {
+		metadata.Hostname = hostname
+	}
+
+	data, err := json.Marshal(&metadata)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, metadataFile)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// readMetadata reads the metadata of the unpacked archive into the report.
+// Returns nil if there's no valid metadata.
+func (bm *Manager) readMetadata(r *RestoreReport) *archiveMetadata {
+	data, err := os.ReadFile(filepath.Join(bm.tmpDir, metadataFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		r.warnf("The backup archive doesn't contain any metadata, it has been created by an older version of k0s")
+		return nil
+	}
+	if err != nil {
+		r.errorf("failed to read backup metadata: %v", err)
+		return nil
+	}
+
+	var metadata archiveMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		r.errorf("invalid backup metadata: %v", err)
+		return nil
+	}
+	r.K0sVersion, r.Hostname, r.CreatedAt = metadata.K0sVersion, metadata.Hostname, &metadata.CreatedAt
+	return &metadata
+}
+
+func (bm *Manager) checkMetadata(r *RestoreReport) {
+	metadata := bm.readMetadata(r)
+	if metadata == nil {
+		return
+	}
+
+	if hostname, err := os.Hostname(); err == nil && metadata.Hostname != "" && !strings.EqualFold(hostname, metadata.Hostname) {
+		r.warnf("The backup has been taken on host %s, restoring onto host %s", metadata.Hostname, hostname)
+	}
+
+	checkVersion(metadata.K0sVersion, build.Version, r)
+}
+
+// checkVersion checks whether a backup taken by the given k0s version can be
+// restored using the current k0s version. Restoring backups of newer minor
+// versions isn't supported, neither is skipping minor versions, just like for
+// upgrades.
+func checkVersion(backupVersion, currentVersion string, r *RestoreReport) {
+	current, err := version.NewVersion(currentVersion)
+	if err != nil {
+		logrus.WithError(err).Debug("Skipping version check for unparsable k0s version ", currentVersion)
+		return
+	}
+	backup, err := version.NewVersion(backupVersion)
+	if err != nil {
+		r.warnf("The backup has been created by an unknown k0s version %q", backupVersion)
+		return
+	}
+
+	b, c := backup.Segments(), current.Segments()
+	switch {
+	case b[0] != c[0]:
+		r.errorf("The backup has been created by k0s %s, which can't be restored using k0s %s", backup, current)
+	case b[1] > c[1]:
+		r.errorf("The backup has been created by k0s %s, which is newer than k0s %s", backup, current)
+	case c[1]-b[1] > 1:
+		r.warnf("The backup has been created by k0s %s, which is more than one minor version older than k0s %s", backup, current)
+	}
+}
+
+// checkCertificates checks that the backed-up CA certificates haven't expired,
+// and that the serving certificates match this host, unless they're managed by
+// k0s, in which case they're regenerated when k0s starts.
+func checkCertificates(restoreFrom string, r *RestoreReport) {
+	pkiDir := filepath.Join(restoreFrom, "pki")
+	if !file.Exists(pkiDir) {
+		r.errorf("The backup archive doesn't contain any certificates")
+		return
+	}
+
+	checkCAs(pkiDir, r)
+
+	addresses, err := localAddresses()
+	if err != nil {
+		r.warnf("Failed to determine this host's addresses: %v", err)
+		return
+	}
+
+	for _, name := range []string{"server", "etcd/server", "etcd/peer"} {
+		certPath := filepath.Join(pkiDir, name+".crt")
+		if !file.Exists(certPath) {
+			continue
+		}
+		cert, err := certinfo.ParseCertificateFile(certPath)
+		if err != nil {
+			r.errorf("failed to parse certificate %s: %v", name, err)
+			continue
+		}
+		if certificate.IsManagedByK0s(cert) {
+			continue
+		}
+		if !slices.ContainsFunc(cert.SANs, func(san string) bool { return slices.Contains(addresses, strings.ToLower(san)) }) {
+			r.warnf("Certificate %s isn't managed by k0s and its SANs %s don't match this host", name, strings.Join(cert.SANs, ", "))
+		}
+	}
+}
+
+// checkCAs checks that the CA certificates in the given directory haven't
+// expired, and that their keys are present.
+func checkCAs(pkiDir string, r *RestoreReport) {
+	for _, ca := range []string{"ca", "front-proxy-ca", "etcd/ca"} {
+		certPath := filepath.Join(pkiDir, ca+".crt")
+		if !file.Exists(certPath) {
+			continue
+		}
+		cert, err := certinfo.ParseCertificateFile(certPath)
+		if err != nil {
+			r.errorf("failed to parse CA certificate %s: %v", ca, err)
+			continue
+		}
+		if time.Now().After(cert.NotAfter) {
+			r.errorf("CA certificate %s expired at %s", ca, cert.NotAfter.Format(time.RFC3339))
+		}
+		if !file.Exists(filepath.Join(pkiDir, ca+".key")) {
+			r.warnf("The key of CA certificate %s is missing, certificates can't be regenerated", ca)
+		}
+	}
+}
+
+// localAddresses returns the hostname and the IP addresses of this host.
+func localAddresses() ([]string, error) {
+	var addresses []string
+	if hostname, err := os.Hostname(); err == nil {
+		addresses = append(addresses, strings.ToLower(hostname))
+	}
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok {
+			addresses = append(addresses, ipNet.IP.String())
+		}
+	}
+	return addresses, nil
+}
+
+// existingFiles returns the files in the given archived directory that
+// already exist in the destination directory.
+func existingFiles(archived, dest string) ([]string, error) {
+	var existing []string
+	err := filepath.WalkDir(archived, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(archived, path)
+		if err != nil {
+			return err
+		}
+		if destPath := filepath.Join(dest, rel); file.Exists(destPath) {
+			existing = append(existing, destPath)
+		}
+		return nil
+	})
+	return existing, err
+}
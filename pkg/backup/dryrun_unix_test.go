@@ -0,0 +1,82 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckVersion(t *testing.T) {
+	for _, test := range []struct {
+		backup, current string
+		warnings        int
+		errors          int
+	}{
+		{"v1.34.1+k0s.0", "v1.34.2+k0s.0", 0, 0},
+		{"v1.33.4+k0s.0", "v1.34.1+k0s.0", 0, 0},
+		{"v1.32.4+k0s.0", "v1.34.1+k0s.0", 1, 0},
+		{"v1.35.0+k0s.0", "v1.34.1+k0s.0", 0, 1},
+		{"v2.0.0+k0s.0", "v1.34.1+k0s.0", 0, 1},
+		{"garbage", "v1.34.1+k0s.0", 1, 0},
+		{"v1.34.1+k0s.0", "", 0, 0},
+	} {
+		t.Run(test.backup+"_"+test.current, func(t *testing.T) {
+			var report RestoreReport
+			checkVersion(test.backup, test.current, &report)
+			assert.Len(t, report.Warnings, test.warnings, "%v", report.Warnings)
+			assert.Len(t, report.Errors, test.errors, "%v", report.Errors)
+		})
+	}
+}
+
+func TestFileSystemStep_DryRunRestore(t *testing.T) {
+	restoreFrom, restoreTo := t.TempDir(), t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(restoreFrom, "pki", "etcd"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(restoreFrom, "pki", "ca.crt"), nil, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(restoreFrom, "pki", "etcd", "ca.crt"), nil, 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(restoreTo, "pki"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(restoreTo, "pki", "ca.crt"), nil, 0600))
+
+	var report RestoreReport
+	NewFileSystemStep("/var/lib/k0s/pki").DryRunRestore(restoreFrom, restoreTo, &report)
+	NewFileSystemStep("/var/lib/k0s/manifests").DryRunRestore(restoreFrom, restoreTo, &report)
+	assert.Equal(t, []string{filepath.Join(restoreTo, "pki", "ca.crt") + " would be overwritten"}, report.Conflicts)
+	assert.Empty(t, report.Errors)
+}
+
+func TestEtcdStep_DryRunRestore(t *testing.T) {
+	restoreFrom, etcdDataDir := t.TempDir(), t.TempDir()
+	step := newEtcdStep(restoreFrom, "", "", "", etcdDataDir)
+
+	var report RestoreReport
+	step.DryRunRestore(restoreFrom, "", &report)
+	assert.Equal(t, []string{"etcd snapshot not found in the backup archive"}, report.Errors)
+
+	snapshot := make([]byte, 1024)
+	hash := sha256.Sum256(snapshot)
+	hash[0]++
+	require.NoError(t, os.WriteFile(filepath.Join(restoreFrom, etcdBackup), append(snapshot, hash[:]...), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(etcdDataDir, "member"), nil, 0600))
+
+	report = RestoreReport{}
+	step.DryRunRestore(restoreFrom, "", &report)
+	assert.Equal(t, []string{
+		"failed to verify etcd snapshot: etcd snapshot hash mismatch",
+		"etcd data directory " + etcdDataDir + " is not empty",
+	}, report.Errors)
+
+	_, err := writeEtcdManifest(restoreFrom, &etcdBackupManifest{Revision: 2, Chain: []string{"k0s_backup_2026-01-01T03_00_00_000Z.tar.gz"}})
+	require.NoError(t, err)
+	report = RestoreReport{}
+	step.DryRunRestore(restoreFrom, "", &report)
+	assert.Contains(t, report.Errors, "incremental backups can only be restored from local archives")
+}
@@ -0,0 +1,305 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+const (
+	// encryptedArchiveSuffix is appended to the names of encrypted archives.
+	encryptedArchiveSuffix = ".age"
+	// ageIntro is the first line of age-encrypted files.
+	ageIntro = "age-encryption.org/v1\n"
+	// maxScryptWorkFactor is the maximum scrypt work factor accepted when
+	// decrypting with a passphrase.
+	maxScryptWorkFactor = 22
+
+	// awsKMSPrefix is the prefix of AWS KMS key IDs given as recipients.
+	awsKMSPrefix = "awskms:"
+	// awsKMSStanzaType is the type of the age stanzas containing file keys
+	// encrypted with AWS KMS.
+	awsKMSStanzaType = "k0s-awskms"
+	// awsKMSFileKeySize is the size of age file keys.
+	awsKMSFileKeySize = 16
+
+	// PassphraseEnvVar is the environment variable from which the passphrase
+	// is taken if no passphrase file is given.
+	PassphraseEnvVar = "K0S_BACKUP_PASSPHRASE"
+)
+
+// EncryptionOptions configures the encryption of backup archives. Archives are
+// encrypted in the age format (https://age-encryption.org).
+type EncryptionOptions struct {
+	// Recipients for which backups are encrypted. Those are either age
+	// recipients (age1...), or AWS KMS key IDs, ARNs or aliases prefixed with
+	// "awskms:", in which case the file key is encrypted with AWS KMS.
+	Recipients []string
+	// Passphrase to encrypt backups with, or to decrypt them. It can't be
+	// combined with recipients when encrypting.
+	Passphrase string
+	// Identities are the age identities (AGE-SECRET-KEY-1...) used to decrypt
+	// backups. Backups encrypted with AWS KMS are decrypted using the AWS
+	// credentials from the environment.
+	Identities []string
+}
+
+// IsEnabled returns true if backups are to be encrypted.
+func (o *EncryptionOptions) IsEnabled() bool {
+	return len(o.Recipients) > 0 || o.Passphrase != ""
+}
+
+// ValidateRecipients validates the configured recipients.
+func (o *EncryptionOptions) ValidateRecipients() error {
+	// The recipients aren't used to encrypt anything here, so the context
+	// for the AWS KMS requests doesn't matter.
+	_, err := o.recipients(context.Background())
+	return err
+}
+
+// LoadPassphrase reads the passphrase from the given file, or takes it from the
+// K0S_BACKUP_PASSPHRASE environment variable if no file is given. Trailing
+// newlines are removed.
+func LoadPassphrase(path string) (string, error) {
+	if path == "" {
+		return os.Getenv(PassphraseEnvVar), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// ParseAgeIdentities parses an age identity file and returns the identities
+// contained in it.
+func ParseAgeIdentities(r io.Reader) ([]string, error) {
+	var identities []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, err := age.ParseX25519Identity(line); err != nil {
+			return nil, err
+		}
+		identities = append(identities, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(identities) == 0 {
+		return nil, errors.New("no age identities found")
+	}
+	return identities, nil
+}
+
+func (o *EncryptionOptions) recipients(ctx context.Context) ([]age.Recipient, error) {
+	if o.Passphrase != "" {
+		if len(o.Recipients) > 0 {
+			return nil, errors.New("a passphrase can't be combined with other recipients")
+		}
+		recipient, err := age.NewScryptRecipient(o.Passphrase)
+		if err != nil {
+			return nil, err
+		}
+		return []age.Recipient{recipient}, nil
+	}
+
+	var recipients []age.Recipient
+	for _, recipient := range o.Recipients {
+		if keyID, ok := strings.CutPrefix(recipient, awsKMSPrefix); ok {
+			if keyID == "" {
+				return nil, errors.New("empty AWS KMS key ID")
+			}
+			recipients = append(recipients, &awsKMSRecipient{ctx, keyID})
+			continue
+		}
+		r, err := age.ParseX25519Recipient(recipient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid age recipient: %w", err)
+		}
+		recipients = append(recipients, r)
+	}
+	return recipients, nil
+}
+
+func (o *EncryptionOptions) identities(ctx context.Context) ([]age.Identity, error) {
+	var identities []age.Identity
+	if o.Passphrase != "" {
+		identity, err := age.NewScryptIdentity(o.Passphrase)
+		if err != nil {
+			return nil, err
+		}
+		identity.SetMaxWorkFactor(maxScryptWorkFactor)
+		identities = append(identities, identity)
+	}
+	for _, identity := range o.Identities {
+		i, err := age.ParseX25519Identity(identity)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, i)
+	}
+	// AWS KMS comes last, so that it's only asked if no other identity matches.
+	return append(identities, &awsKMSIdentity{ctx}), nil
+}
+
+// encryptArchive returns a writer that encrypts the archive written to it into
+// w. If encryption isn't enabled, w is returned as is.
+func (o *EncryptionOptions) encryptArchive(ctx context.Context, w io.Writer) (io.WriteCloser, error) {
+	if !o.IsEnabled() {
+		return nopWriteCloser{w}, nil
+	}
+	recipients, err := o.recipients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return age.Encrypt(w, recipients...)
+}
+
+// decryptArchive returns a reader that decrypts the archive read from r, if
+// it's encrypted. Unencrypted archives are read as is.
+func (o *EncryptionOptions) decryptArchive(ctx context.Context, r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(len(ageIntro))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if !isAgeEncrypted(header) {
+		return br, nil
+	}
+
+	identities, err := o.identities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	decrypted, err := age.Decrypt(br, identities...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt backup archive: %w", err)
+	}
+	return decrypted, nil
+}
+
+// isAgeEncrypted returns true if the given header starts like an age file.
+func isAgeEncrypted(header []byte) bool {
+	return bytes.HasPrefix(header, []byte(ageIntro))
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// awsKMSRecipient encrypts the file key with an AWS KMS key. The age interfaces
+// don't take a context, so the one for the KMS requests is stored here.
+type awsKMSRecipient struct {
+	ctx   context.Context
+	keyID string
+}
+
+func (r *awsKMSRecipient) Wrap(fileKey []byte) ([]*age.Stanza, error) {
+	client, err := newKMSClient(r.ctx, regionFromARN(r.keyID))
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.Encrypt(r.ctx, &kms.EncryptInput{
+		KeyId:     aws.String(r.keyID),
+		Plaintext: fileKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt the file key with AWS KMS key %s: %w", r.keyID, err)
+	}
+
+	// The response contains the key's ARN, which includes its region.
+	return []*age.Stanza{{
+		Type: awsKMSStanzaType,
+		Args: []string{aws.ToString(out.KeyId)},
+		Body: out.CiphertextBlob,
+	}}, nil
+}
+
+// awsKMSIdentity decrypts file keys that have been encrypted with AWS KMS.
+type awsKMSIdentity struct {
+	ctx context.Context
+}
+
+func (i *awsKMSIdentity) Unwrap(stanzas []*age.Stanza) ([]byte, error) {
+	var errs []error
+	for _, stanza := range stanzas {
+		if stanza.Type != awsKMSStanzaType {
+			continue
+		}
+		fileKey, err := i.unwrap(stanza)
+		if err == nil {
+			return fileKey, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil, age.ErrIncorrectIdentity
+	}
+
+	// Failures, e.g. due to missing AWS credentials, are reported as an
+	// incorrect identity, so that age still tries the other identities.
+	return nil, fmt.Errorf("%w: %w", age.ErrIncorrectIdentity, errors.Join(errs...))
+}
+
+func (i *awsKMSIdentity) unwrap(stanza *age.Stanza) ([]byte, error) {
+	if len(stanza.Args) != 1 {
+		return nil, errors.New("invalid AWS KMS stanza")
+	}
+	keyID := stanza.Args[0]
+
+	client, err := newKMSClient(i.ctx, regionFromARN(keyID))
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.Decrypt(i.ctx, &kms.DecryptInput{
+		KeyId:          aws.String(keyID),
+		CiphertextBlob: stanza.Body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the file key with AWS KMS key %s: %w", keyID, err)
+	}
+	if len(out.Plaintext) != awsKMSFileKeySize {
+		return nil, errors.New("AWS KMS returned an invalid file key")
+	}
+	return out.Plaintext, nil
+}
+
+// regionFromARN returns the region of the given ARN, or an empty string if
+// it's not an ARN.
+func regionFromARN(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) == 6 && parts[0] == "arn" {
+		return parts[3]
+	}
+	return ""
+}
+
+// newKMSClient returns an AWS KMS client for the given region. The endpoint
+// can be overridden via the AWS_ENDPOINT_URL_KMS environment variable.
+func newKMSClient(ctx context.Context, region string) (*kms.Client, error) {
+	cfg, err := loadAWSConfig(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	return kms.NewFromConfig(cfg), nil
+}
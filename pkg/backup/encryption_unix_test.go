@@ -0,0 +1,157 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptionOptions_RoundTrip(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	identityPath := filepath.Join(t.TempDir(), "identity.txt")
+	require.NoError(t, os.WriteFile(identityPath, []byte("# created: today\n"+identity.String()+"\n"), 0600))
+
+	f, err := os.Open(identityPath)
+	require.NoError(t, err)
+	defer f.Close()
+	identities, err := ParseAgeIdentities(f)
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		name       string
+		encryption EncryptionOptions
+		decryption EncryptionOptions
+	}{
+		{"unencrypted", EncryptionOptions{}, EncryptionOptions{}},
+		{"recipient", EncryptionOptions{Recipients: []string{identity.Recipient().String()}}, EncryptionOptions{Identities: identities}},
+		{"passphrase", EncryptionOptions{Passphrase: "secret"}, EncryptionOptions{Passphrase: "secret"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var archive bytes.Buffer
+			w, err := test.encryption.encryptArchive(t.Context(), &archive)
+			require.NoError(t, err)
+			_, err = w.Write([]byte("archive"))
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+			assert.Equal(t, test.encryption.IsEnabled(), isAgeEncrypted(archive.Bytes()))
+
+			r, err := test.decryption.decryptArchive(t.Context(), &archive)
+			require.NoError(t, err)
+			data, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, "archive", string(data))
+		})
+	}
+
+	_, err = (&EncryptionOptions{}).decryptArchive(t.Context(), strings.NewReader(ageIntro))
+	assert.ErrorContains(t, err, "failed to decrypt backup archive")
+}
+
+func TestEncryptionOptions_ValidateRecipients(t *testing.T) {
+	assert.NoError(t, (&EncryptionOptions{Recipients: []string{"awskms:alias/backups"}}).ValidateRecipients())
+	assert.ErrorContains(t, (&EncryptionOptions{Recipients: []string{"awskms:"}}).ValidateRecipients(), "empty AWS KMS key ID")
+	assert.ErrorContains(t, (&EncryptionOptions{Recipients: []string{"age1invalid"}}).ValidateRecipients(), "invalid age recipient")
+	assert.ErrorContains(t, (&EncryptionOptions{Recipients: []string{"awskms:alias/backups"}, Passphrase: "secret"}).ValidateRecipients(), "can't be combined")
+}
+
+func TestLoadPassphrase(t *testing.T) {
+	t.Setenv(PassphraseEnvVar, "from-env")
+	passphrase, err := LoadPassphrase("")
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", passphrase)
+
+	path := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(path, []byte("from-file\n"), 0600))
+	passphrase, err = LoadPassphrase(path)
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", passphrase)
+}
+
+func TestEncryptionOptions_AWSKMS(t *testing.T) {
+	const keyARN = "arn:aws:kms:eu-west-1:123456789012:key/1234abcd"
+
+	// The fake KMS "encrypts" by reversing the plaintext.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.Header.Get("Authorization"), "/kms/aws4_request")
+		var req struct {
+			KeyId                     string
+			Plaintext, CiphertextBlob []byte
+		}
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var resp any
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.Encrypt":
+			assert.Equal(t, "alias/backups", req.KeyId)
+			resp = map[string]any{"KeyId": keyARN, "CiphertextBlob": reversed(req.Plaintext)}
+		case "TrentService.Decrypt":
+			assert.Equal(t, keyARN, req.KeyId)
+			resp = map[string]any{"KeyId": keyARN, "Plaintext": reversed(req.CiphertextBlob)}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	t.Cleanup(server.Close)
+
+	setTestAWSEnv(t)
+	t.Setenv("AWS_ENDPOINT_URL_KMS", server.URL)
+
+	var archive bytes.Buffer
+	w, err := (&EncryptionOptions{Recipients: []string{"awskms:alias/backups"}}).encryptArchive(t.Context(), &archive)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("archive"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Contains(t, archive.String(), "-> k0s-awskms "+keyARN+"\n")
+
+	r, err := (&EncryptionOptions{}).decryptArchive(t.Context(), &archive)
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "archive", string(data))
+}
+
+func reversed(data []byte) []byte {
+	reversed := make([]byte, len(data))
+	for i, b := range data {
+		reversed[len(data)-1-i] = b
+	}
+	return reversed
+}
+
+func TestAWSKMSIdentity_Unwrap(t *testing.T) {
+	setTestAWSEnv(t)
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	underTest := &awsKMSIdentity{t.Context()}
+
+	_, err := underTest.Unwrap([]*age.Stanza{{Type: "X25519"}})
+	assert.ErrorIs(t, err, age.ErrIncorrectIdentity)
+
+	// Without AWS credentials, other identities need to be tried.
+	_, err = underTest.Unwrap([]*age.Stanza{{Type: awsKMSStanzaType, Args: []string{"alias/backups"}}})
+	assert.ErrorIs(t, err, age.ErrIncorrectIdentity)
+	assert.ErrorContains(t, err, "failed to decrypt the file key with AWS KMS key alias/backups")
+}
@@ -0,0 +1,639 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/k0sproject/k0s/internal/pkg/file"
+
+	"github.com/sirupsen/logrus"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+	"go.uber.org/zap"
+)
+
+const (
+	etcdManifest = "etcd-backup.json"
+	etcdDelta    = "etcd-delta.bin"
+)
+
+// DefaultMaxDeltas is the default number of incremental backups that are taken
+// before a full etcd snapshot is taken again.
+const DefaultMaxDeltas = 6
+
+// errFullSnapshotRequired indicates that an incremental backup cannot be taken
+// on top of the previous backup.
+var errFullSnapshotRequired = errors.New("full snapshot required")
+
+// etcdReplayMaxRequestBytes is the maximum size of the requests when replaying
+// deltas. It's way above etcd's default, so that large revisions can be
+// replayed even if the backed-up etcd has been configured to accept them.
+const etcdReplayMaxRequestBytes = 64 << 20
+
+// etcdBackupManifest describes the etcd data contained in a backup archive.
+type etcdBackupManifest struct {
+	// ClusterID is the ID of the etcd cluster that has been backed up.
+	ClusterID uint64 `json:"clusterID"`
+	// Revision up to which the etcd data has been backed up.
+	Revision int64 `json:"revision"`
+	// Chain contains the names of the archives upon which a delta builds,
+	// starting with the archive containing the full snapshot. Empty for full
+	// snapshots.
+	Chain []string `json:"chain,omitempty"`
+	// Leases are the leases that were granted when a delta was taken.
+	Leases []etcdLease `json:"leases,omitempty"`
+}
+
+type etcdLease struct {
+	ID  int64 `json:"id"`
+	TTL int64 `json:"ttl"`
+}
+
+// incrementalBase returns the manifest of the backup upon which the next delta
+// can be taken, based on the newest backup archive in the given directory. It
+// returns nil if a full snapshot should be taken instead.
+func incrementalBase(dir string, maxDeltas int) (*etcdBackupManifest, error) {
+	archives, err := listArchives(dir)
+	if err != nil || len(archives) == 0 {
+		return nil, err
+	}
+
+	latest := archives[len(archives)-1]
+	manifest, err := readArchivedEtcdManifest(filepath.Join(dir, latest))
+	if err != nil {
+		logrus.WithError(err).Warnf("Failed to read etcd backup manifest from %s, taking a full snapshot", latest)
+		return nil, nil
+	}
+	if manifest == nil {
+		logrus.Infof("Archive %s contains no etcd backup manifest, taking a full snapshot", latest)
+		return nil, nil
+	}
+
+	chain := append(slices.Clone(manifest.Chain), latest)
+	if len(chain) > maxDeltas {
+		logrus.Infof("Maximum number of %d incremental backups reached, taking a full snapshot", maxDeltas)
+		return nil, nil
+	}
+	for _, name := range chain {
+		if !slices.Contains(archives, name) {
+			logrus.Infof("Archive %s of the incremental backup chain is missing, taking a full snapshot", name)
+			return nil, nil
+		}
+	}
+
+	return &etcdBackupManifest{
+		ClusterID: manifest.ClusterID,
+		Revision:  manifest.Revision,
+		Chain:     chain,
+	}, nil
+}
+
+// backupDelta writes all the changes since the base revision up to the current
+// revision to the delta file. It returns errFullSnapshotRequired if the
+// changes are no longer available.
+func (e etcdStep) backupDelta(ctx context.Context, cli *clientv3.Client) (StepResult, error) {
+	resp, err := cli.Get(ctx, "", clientv3.WithPrefix(), clientv3.WithCountOnly())
+	if err != nil {
+		return StepResult{}, err
+	}
+
+	manifest := etcdBackupManifest{
+		ClusterID: resp.Header.ClusterId,
+		Revision:  resp.Header.Revision,
+		Chain:     e.base.Chain,
+	}
+	switch {
+	case manifest.ClusterID != e.base.ClusterID:
+		return StepResult{}, fmt.Errorf("%w: etcd cluster ID changed since the previous backup", errFullSnapshotRequired)
+	case manifest.Revision < e.base.Revision:
+		return StepResult{}, fmt.Errorf("%w: etcd revision %d is older than the previous backup's revision %d", errFullSnapshotRequired, manifest.Revision, e.base.Revision)
+	}
+
+	path := filepath.Join(e.tmpDir, etcdDelta)
+	if err := file.WriteAtomically(path, 0600, func(unbuffered io.Writer) error {
+		w := bufio.NewWriter(unbuffered)
+		if manifest.Revision > e.base.Revision {
+			if err := writeEtcdEvents(ctx, cli, w, e.base.Revision+1, manifest.Revision); err != nil {
+				return err
+			}
+		}
+		return w.Flush()
+	}); err != nil {
+		return StepResult{}, err
+	}
+
+	leases, err := cli.Leases(ctx)
+	if err != nil {
+		return StepResult{}, fmt.Errorf("failed to list etcd leases: %w", err)
+	}
+	for _, lease := range leases.Leases {
+		ttl, err := cli.TimeToLive(ctx, lease.ID)
+		if err != nil {
+			return StepResult{}, fmt.Errorf("failed to get etcd lease %x: %w", lease.ID, err)
+		}
+		if ttl.TTL > 0 {
+			manifest.Leases = append(manifest.Leases, etcdLease{ID: int64(lease.ID), TTL: ttl.GrantedTTL})
+		}
+	}
+
+	manifestPath, err := writeEtcdManifest(e.tmpDir, &manifest)
+	if err != nil {
+		return StepResult{}, err
+	}
+
+	logrus.Infof("Backing up etcd revisions %d to %d on top of %s", e.base.Revision+1, manifest.Revision, manifest.Chain[len(manifest.Chain)-1])
+	return StepResult{filesForBackup: []string{manifestPath, path}}, nil
+}
+
+// writeEtcdEvents watches all the keys from the given revision and writes the
+// events to w until the given revision has been reached.
+func writeEtcdEvents(ctx context.Context, cli *clientv3.Client, w io.Writer, fromRev, toRev int64) error {
+	ctx, cancel := context.WithCancel(clientv3.WithRequireLeader(ctx))
+	defer cancel()
+
+	for resp := range cli.Watch(ctx, "", clientv3.WithPrefix(), clientv3.WithRev(fromRev)) {
+		if err := resp.Err(); err != nil {
+			if resp.CompactRevision != 0 {
+				return fmt.Errorf("%w: etcd history has been compacted up to revision %d", errFullSnapshotRequired, resp.CompactRevision)
+			}
+			return err
+		}
+
+		// Watch responses contain all the events of a revision, so that the
+		// watch can be stopped at the end of the response containing the
+		// target revision.
+		var done bool
+		for _, event := range resp.Events {
+			if event.Kv.ModRevision > toRev {
+				return nil
+			}
+			if err := writeEtcdEvent(w, (*mvccpb.Event)(event)); err != nil {
+				return err
+			}
+			done = event.Kv.ModRevision == toRev
+		}
+		if done {
+			return nil
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.New("etcd watch closed unexpectedly")
+}
+
+// writeEtcdEvent writes a length-prefixed event to w.
+func writeEtcdEvent(w io.Writer, event *mvccpb.Event) error {
+	data, err := event.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(len(data))); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// readEtcdEvent reads a length-prefixed event from r. It returns io.EOF if
+// there are no more events.
+func readEtcdEvent(r io.Reader) (*mvccpb.Event, error) {
+	var size uint32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("truncated etcd event: %w", err)
+	}
+	var event mvccpb.Event
+	if err := event.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func writeEtcdManifest(dir string, manifest *etcdBackupManifest) (string, error) {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, etcdManifest)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// readEtcdManifest reads the etcd backup manifest from the given directory. It
+// returns nil if there's no manifest.
+func readEtcdManifest(dir string) (*etcdBackupManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, etcdManifest))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseEtcdManifest(data)
+}
+
+// readArchivedEtcdManifest reads the etcd backup manifest from the given
+// backup archive. It returns nil if there's no manifest.
+func readArchivedEtcdManifest(archivePath string) (*etcdBackupManifest, error) {
+	var buf bytes.Buffer
+	if err := copyFromArchive(archivePath, etcdManifest, &buf); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseEtcdManifest(buf.Bytes())
+}
+
+func parseEtcdManifest(data []byte) (*etcdBackupManifest, error) {
+	var manifest etcdBackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid etcd backup manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// copyFromArchive copies the contents of the named file in the given backup
+// archive to w.
+func copyFromArchive(archivePath, name string, w io.Writer) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%s not found in %s: %w", name, archivePath, fs.ErrNotExist)
+		}
+		if err != nil {
+			return err
+		}
+		if header.Name == name {
+			_, err := io.Copy(w, tr)
+			return err
+		}
+	}
+}
+
+// extractFromArchive extracts the named file in the given backup archive to
+// the destination path.
+func extractFromArchive(archivePath, name, dest string) error {
+	return file.WriteAtomically(dest, 0600, func(w io.Writer) error {
+		return copyFromArchive(archivePath, name, w)
+	})
+}
+
+// extractEtcdSnapshot extracts the full etcd snapshot upon which the chain of
+// incremental backups builds from the first archive in archiveDir.
+func extractEtcdSnapshot(archiveDir string, manifest *etcdBackupManifest, snapshotPath string) error {
+	fullArchive := filepath.Join(archiveDir, manifest.Chain[0])
+	logrus.Infof("Restoring etcd snapshot from %s", fullArchive)
+	if err := extractFromArchive(fullArchive, etcdBackup, snapshotPath); err != nil {
+		return fmt.Errorf("failed to extract etcd snapshot from %s: %w", fullArchive, err)
+	}
+	return nil
+}
+
+// forEachEtcdDelta extracts the deltas of the chain of backup archives in
+// archiveDir into restoreFrom, and calls fn for each of them, ending with the
+// delta in restoreFrom itself.
+func forEachEtcdDelta(archiveDir, restoreFrom string, manifest *etcdBackupManifest, fn func(name, deltaPath string) error) error {
+	deltaPath := filepath.Join(restoreFrom, etcdDelta)
+	defer os.Remove(deltaPath + ".base")
+
+	for _, name := range manifest.Chain[1:] {
+		if err := extractFromArchive(filepath.Join(archiveDir, name), etcdDelta, deltaPath+".base"); err != nil {
+			return fmt.Errorf("failed to extract etcd delta from %s: %w", name, err)
+		}
+		if err := fn(name, deltaPath+".base"); err != nil {
+			return err
+		}
+	}
+
+	return fn("the backup archive", deltaPath)
+}
+
+// verifyEtcdChain verifies the hash of the full etcd snapshot upon which the
+// chain of incremental backups builds, and that all the deltas can be read.
+// The snapshot is extracted to snapshotPath, without its hash.
+func verifyEtcdChain(archiveDir, restoreFrom string, manifest *etcdBackupManifest, snapshotPath string) error {
+	if err := extractEtcdSnapshot(archiveDir, manifest, snapshotPath); err != nil {
+		return err
+	}
+	if err := stripSnapshotHash(snapshotPath); err != nil {
+		return err
+	}
+
+	return forEachEtcdDelta(archiveDir, restoreFrom, manifest, func(name, deltaPath string) error {
+		return readEtcdDelta(deltaPath, func(*mvccpb.Event) error { return nil })
+	})
+}
+
+// readEtcdDelta calls fn for each event in the given delta file.
+func readEtcdDelta(deltaPath string, fn func(*mvccpb.Event) error) error {
+	f, err := os.Open(deltaPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+
+	for {
+		event, err := readEtcdEvent(r)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+}
+
+// stripSnapshotHash verifies and removes the SHA-256 hash that etcd appends to
+// its snapshots.
+func stripSnapshotHash(path string) error {
+	size, hasHash, err := verifySnapshotHash(path)
+	if err != nil || !hasHash {
+		return err
+	}
+	return os.Truncate(path, size-sha256.Size)
+}
+
+// verifySnapshotHash verifies the SHA-256 hash that etcd appends to its
+// snapshots. It returns the size of the snapshot and whether it has a hash.
+func verifySnapshotHash(path string) (int64, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, false, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return 0, false, err
+	}
+	// The database size is always a multiple of the page size, so that the
+	// snapshot has a hash if there's a remainder of exactly the hash size.
+	size := stat.Size()
+	if size%512 != sha256.Size {
+		return size, false, nil
+	}
+
+	h := sha256.New()
+	if _, err := io.CopyN(h, f, size-sha256.Size); err != nil {
+		return size, true, err
+	}
+	expected := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(f, expected); err != nil {
+		return size, true, err
+	}
+	if !bytes.Equal(h.Sum(nil), expected) {
+		return size, true, errors.New("etcd snapshot hash mismatch")
+	}
+	return size, true, nil
+}
+
+// replayEtcdDeltas replays the deltas of the chain of backup archives in
+// archiveDir, and the one in restoreFrom, on top of the etcd data directory
+// that has been restored from the chain's full snapshot. The deltas are
+// replayed through a temporary etcd member, so that etcd keeps track of the
+// revisions, the leases and its consistent index on its own.
+func replayEtcdDeltas(ctx context.Context, dataDir, name, archiveDir, restoreFrom string, manifest *etcdBackupManifest) (err error) {
+	member, err := startTemporaryEtcd(dataDir, name)
+	if err != nil {
+		return err
+	}
+	defer member.Close()
+
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:          []string{member.Clients[0].Addr().String()},
+		Logger:             zap.NewNop(),
+		MaxCallSendMsgSize: etcdReplayMaxRequestBytes,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, cli.Close()) }()
+
+	leases, err := grantEtcdLeases(ctx, cli, manifest.Leases)
+	if err != nil {
+		return fmt.Errorf("failed to restore etcd leases: %w", err)
+	}
+
+	// Leases expiring during the replay would delete their keys, which bumps
+	// the revision and lets the replayed revisions diverge from the backed-up
+	// ones. Stale leases are revoked once the replay is done.
+	stopKeepAlive, err := keepEtcdLeasesAlive(ctx, cli, leases)
+	if err != nil {
+		return fmt.Errorf("failed to keep etcd leases alive: %w", err)
+	}
+	defer stopKeepAlive()
+
+	if err := forEachEtcdDelta(archiveDir, restoreFrom, manifest, func(name, deltaPath string) error {
+		logrus.Infof("Applying etcd delta from %s", name)
+		if err := replayEtcdDelta(ctx, cli, leases, deltaPath); err != nil {
+			return fmt.Errorf("failed to apply etcd delta from %s: %w", name, err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := revokeStaleEtcdLeases(ctx, cli, manifest.Leases); err != nil {
+		return fmt.Errorf("failed to remove stale etcd leases: %w", err)
+	}
+	return nil
+}
+
+// startTemporaryEtcd starts an etcd member for the given data directory that
+// listens on ephemeral loopback ports only.
+func startTemporaryEtcd(dataDir, name string) (*embed.Etcd, error) {
+	loopback := []url.URL{{Scheme: "http", Host: "127.0.0.1:0"}}
+
+	cfg := embed.NewConfig()
+	cfg.Name = name
+	cfg.Dir = dataDir
+	cfg.ListenPeerUrls, cfg.AdvertisePeerUrls = loopback, loopback
+	cfg.ListenClientUrls, cfg.AdvertiseClientUrls = loopback, loopback
+	cfg.InitialCluster = cfg.InitialClusterFromName(name)
+	cfg.EnableGRPCGateway = false
+	cfg.MaxTxnOps = 1 << 20
+	cfg.MaxRequestBytes = etcdReplayMaxRequestBytes
+	cfg.ZapLoggerBuilder = embed.NewZapLoggerBuilder(zap.NewNop())
+
+	member, err := embed.StartEtcd(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start temporary etcd member: %w", err)
+	}
+
+	select {
+	case <-member.Server.ReadyNotify():
+		return member, nil
+	case err := <-member.Err():
+		member.Close()
+		return nil, fmt.Errorf("temporary etcd member failed: %w", err)
+	}
+}
+
+// grantEtcdLeases grants the given leases, unless they exist already. It
+// returns the IDs of all the leases that exist afterwards.
+func grantEtcdLeases(ctx context.Context, cli *clientv3.Client, leases []etcdLease) (map[int64]bool, error) {
+	existing, err := cli.Leases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	granted := make(map[int64]bool, len(existing.Leases)+len(leases))
+	for _, lease := range existing.Leases {
+		granted[int64(lease.ID)] = true
+	}
+
+	leaseClient := pb.NewLeaseClient(cli.ActiveConnection())
+	for _, lease := range leases {
+		if granted[lease.ID] {
+			continue
+		}
+		// The client's Grant doesn't allow to choose the lease ID.
+		if _, err := leaseClient.LeaseGrant(ctx, &pb.LeaseGrantRequest{ID: lease.ID, TTL: lease.TTL}); err != nil {
+			return nil, fmt.Errorf("failed to grant lease %x: %w", lease.ID, err)
+		}
+		granted[lease.ID] = true
+	}
+	return granted, nil
+}
+
+// keepEtcdLeasesAlive keeps the given leases alive until the returned
+// function is called.
+func keepEtcdLeasesAlive(ctx context.Context, cli *clientv3.Client, leases map[int64]bool) (func(), error) {
+	ctx, cancel := context.WithCancel(ctx)
+	for id := range leases {
+		responses, err := cli.KeepAlive(ctx, clientv3.LeaseID(id))
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("lease %x: %w", id, err)
+		}
+		go func() {
+			for range responses {
+			}
+		}()
+	}
+	return cancel, nil
+}
+
+// revokeStaleEtcdLeases revokes all the leases that don't belong to the given
+// ones, i.e. that have been restored from the full snapshot, but expired
+// before the delta has been taken.
+func revokeStaleEtcdLeases(ctx context.Context, cli *clientv3.Client, leases []etcdLease) error {
+	existing, err := cli.Leases(ctx)
+	if err != nil {
+		return err
+	}
+	for _, lease := range existing.Leases {
+		if slices.ContainsFunc(leases, func(l etcdLease) bool { return l.ID == int64(lease.ID) }) {
+			continue
+		}
+		if _, err := cli.Revoke(ctx, lease.ID); err != nil {
+			return fmt.Errorf("failed to revoke lease %x: %w", lease.ID, err)
+		}
+	}
+	return nil
+}
+
+// replayEtcdDelta replays the events in the given delta file. The events of a
+// revision are replayed in a single transaction, so that the revisions match
+// the backed-up ones. Revisions that are already contained in the restored data
+// are skipped. Keys are only attached to the given leases, as all the others
+// have expired before the backup has been taken.
+func replayEtcdDelta(ctx context.Context, cli *clientv3.Client, leases map[int64]bool, deltaPath string) error {
+	resp, err := cli.Get(ctx, "", clientv3.WithPrefix(), clientv3.WithCountOnly())
+	if err != nil {
+		return err
+	}
+	current := resp.Header.Revision
+
+	var rev int64
+	var ops []clientv3.Op
+	commit := func() error {
+		if len(ops) == 0 {
+			return nil
+		}
+		resp, err := cli.Txn(ctx).Then(ops...).Commit()
+		if err != nil {
+			return fmt.Errorf("failed to replay revision %d: %w", rev, err)
+		}
+		if resp.Header.Revision != rev {
+			return fmt.Errorf("replayed revision %d as revision %d", rev, resp.Header.Revision)
+		}
+		ops = ops[:0]
+		return nil
+	}
+
+	if err := readEtcdDelta(deltaPath, func(event *mvccpb.Event) error {
+		if event.Kv.ModRevision <= current {
+			return nil
+		}
+		if event.Kv.ModRevision != rev {
+			if err := commit(); err != nil {
+				return err
+			}
+			rev = event.Kv.ModRevision
+		}
+
+		key := string(event.Kv.Key)
+		switch event.Type {
+		case mvccpb.PUT:
+			var opts []clientv3.OpOption
+			if leases[event.Kv.Lease] {
+				opts = append(opts, clientv3.WithLease(clientv3.LeaseID(event.Kv.Lease)))
+			}
+			ops = append(ops, clientv3.OpPut(key, string(event.Kv.Value), opts...))
+		case mvccpb.DELETE:
+			ops = append(ops, clientv3.OpDelete(key))
+		default:
+			return fmt.Errorf("unknown etcd event type %d", event.Type)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return commit()
+}
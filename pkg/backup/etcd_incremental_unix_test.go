@@ -0,0 +1,276 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/snapshot"
+	"go.uber.org/zap"
+)
+
+func TestEtcdEventEncoding(t *testing.T) {
+	var buf bytes.Buffer
+	events := []*mvccpb.Event{
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("a"), Value: []byte("1"), ModRevision: 2, CreateRevision: 2, Version: 1}},
+		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("a"), ModRevision: 3}},
+	}
+	for _, event := range events {
+		require.NoError(t, writeEtcdEvent(&buf, event))
+	}
+
+	for _, expected := range events {
+		event, err := readEtcdEvent(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, expected, event)
+	}
+	_, err := readEtcdEvent(&buf)
+	assert.ErrorIs(t, err, io.EOF)
+
+	buf.Write([]byte{0, 0, 0, 5, 1})
+	_, err = readEtcdEvent(&buf)
+	assert.ErrorContains(t, err, "truncated etcd event")
+}
+
+func TestIncrementalBase(t *testing.T) {
+	dir := t.TempDir()
+
+	base, err := incrementalBase(dir, 2)
+	require.NoError(t, err)
+	assert.Nil(t, base, "No archives, expected a full snapshot")
+
+	writeTestArchive(t, dir, "k0s_backup_2026-01-01T03_00_00_000Z.tar.gz", nil, nil)
+	base, err = incrementalBase(dir, 2)
+	require.NoError(t, err)
+	assert.Nil(t, base, "No manifest, expected a full snapshot")
+
+	writeTestArchive(t, dir, "k0s_backup_2026-01-02T03_00_00_000Z.tar.gz", &etcdBackupManifest{ClusterID: 1, Revision: 10}, nil)
+	base, err = incrementalBase(dir, 2)
+	require.NoError(t, err)
+	assert.Equal(t, &etcdBackupManifest{
+		ClusterID: 1,
+		Revision:  10,
+		Chain:     []string{"k0s_backup_2026-01-02T03_00_00_000Z.tar.gz"},
+	}, base)
+
+	writeTestArchive(t, dir, "k0s_backup_2026-01-03T03_00_00_000Z.tar.gz", &etcdBackupManifest{
+		ClusterID: 1,
+		Revision:  20,
+		Chain:     []string{"k0s_backup_2026-01-02T03_00_00_000Z.tar.gz"},
+	}, nil)
+	base, err = incrementalBase(dir, 2)
+	require.NoError(t, err)
+	assert.Equal(t, &etcdBackupManifest{
+		ClusterID: 1,
+		Revision:  20,
+		Chain: []string{
+			"k0s_backup_2026-01-02T03_00_00_000Z.tar.gz",
+			"k0s_backup_2026-01-03T03_00_00_000Z.tar.gz",
+		},
+	}, base)
+
+	base, err = incrementalBase(dir, 1)
+	require.NoError(t, err)
+	assert.Nil(t, base, "Maximum number of deltas reached, expected a full snapshot")
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "k0s_backup_2026-01-02T03_00_00_000Z.tar.gz")))
+	base, err = incrementalBase(dir, 2)
+	require.NoError(t, err)
+	assert.Nil(t, base, "Archive of the chain is missing, expected a full snapshot")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "k0s_backup_2026-01-04T03_00_00_000Z.tar.gz"), []byte("garbage"), 0600))
+	base, err = incrementalBase(dir, 2)
+	require.NoError(t, err)
+	assert.Nil(t, base, "Unreadable archive, expected a full snapshot")
+}
+
+func TestReplayEtcdDeltas(t *testing.T) {
+	archiveDir, restoreFrom := t.TempDir(), t.TempDir()
+
+	var expected *clientv3.GetResponse
+	var leaseID clientv3.LeaseID
+	withTestEtcd(t, t.TempDir(), func(cli *clientv3.Client) {
+		ctx := t.Context()
+
+		// The full snapshot contains "a", and "stale" attached to a lease.
+		_, err := cli.Put(ctx, "a", "1")
+		require.NoError(t, err)
+		stale, err := cli.Grant(ctx, 600)
+		require.NoError(t, err)
+		_, err = cli.Put(ctx, "stale", "1", clientv3.WithLease(stale.ID))
+		require.NoError(t, err)
+		full := "k0s_backup_2026-01-01T03_00_00_000Z.tar.gz"
+		fullRev := takeTestEtcdBackup(t, cli, archiveDir, full, 0, nil)
+
+		// The first delta puts "b" and updates "a" in a single revision.
+		_, err = cli.Txn(ctx).Then(clientv3.OpPut("b", "1"), clientv3.OpPut("a", "2")).Commit()
+		require.NoError(t, err)
+		delta := "k0s_backup_2026-01-02T03_00_00_000Z.tar.gz"
+		deltaRev := takeTestEtcdBackup(t, cli, archiveDir, delta, fullRev, []string{full})
+
+		// The delta being restored deletes "b", revokes the stale lease, and
+		// attaches "c" to a new lease.
+		_, err = cli.Delete(ctx, "b")
+		require.NoError(t, err)
+		_, err = cli.Revoke(ctx, stale.ID)
+		require.NoError(t, err)
+		lease, err := cli.Grant(ctx, 600)
+		require.NoError(t, err)
+		leaseID = lease.ID
+		_, err = cli.Put(ctx, "c", "1", clientv3.WithLease(lease.ID))
+		require.NoError(t, err)
+		takeTestEtcdBackup(t, cli, restoreFrom, "", deltaRev, []string{full, delta})
+
+		expected, err = cli.Get(ctx, "", clientv3.WithPrefix())
+		require.NoError(t, err)
+	})
+
+	underTest := etcdStep{peerAddress: "127.0.0.1", etcdDataDir: filepath.Join(t.TempDir(), "etcd"), archiveDir: archiveDir}
+	require.NoError(t, underTest.Restore(restoreFrom, ""))
+	assert.NoFileExists(t, filepath.Join(restoreFrom, etcdDelta+".base"))
+
+	withTestEtcd(t, underTest.etcdDataDir, func(cli *clientv3.Client) {
+		actual, err := cli.Get(t.Context(), "", clientv3.WithPrefix())
+		require.NoError(t, err)
+		assert.Equal(t, expected.Header.Revision, actual.Header.Revision)
+		assert.Equal(t, expected.Kvs, actual.Kvs)
+
+		leases, err := cli.Leases(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []clientv3.LeaseStatus{{ID: leaseID}}, leases.Leases)
+	})
+}
+
+func TestKeepEtcdLeasesAlive(t *testing.T) {
+	withTestEtcd(t, t.TempDir(), func(cli *clientv3.Client) {
+		ctx := t.Context()
+
+		// Leases like the ones of the kube-apiserver endpoints may expire
+		// while the deltas are being replayed.
+		lease, err := cli.Grant(ctx, 2)
+		require.NoError(t, err)
+		put, err := cli.Put(ctx, "short-lived", "1", clientv3.WithLease(lease.ID))
+		require.NoError(t, err)
+
+		stop, err := keepEtcdLeasesAlive(ctx, cli, map[int64]bool{int64(lease.ID): true})
+		require.NoError(t, err)
+		time.Sleep(3 * time.Second)
+		stop()
+
+		resp, err := cli.Get(ctx, "short-lived")
+		require.NoError(t, err)
+		assert.Equal(t, put.Header.Revision, resp.Header.Revision, "The revision has been bumped")
+		assert.Len(t, resp.Kvs, 1, "The key has been deleted")
+	})
+}
+
+// withTestEtcd runs an etcd member for the given data directory while calling
+// fn with a client connected to it.
+func withTestEtcd(t *testing.T, dataDir string, fn func(*clientv3.Client)) {
+	member, err := startTemporaryEtcd(dataDir, "test")
+	require.NoError(t, err)
+	defer member.Close()
+
+	cli, err := clientv3.New(clientv3.Config{Endpoints: []string{member.Clients[0].Addr().String()}, Logger: zap.NewNop()})
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, cli.Close()) }()
+
+	fn(cli)
+}
+
+// takeTestEtcdBackup writes the etcd part of a backup archive. It contains a
+// full snapshot if chain is empty, or the delta since the given revision
+// otherwise. The archive is written into dir, or the files themselves if name
+// is empty. Returns the backed-up revision.
+func takeTestEtcdBackup(t *testing.T, cli *clientv3.Client, dir, name string, baseRev int64, chain []string) int64 {
+	filesDir := dir
+	if name != "" {
+		filesDir = t.TempDir()
+	}
+	resp, err := cli.Get(t.Context(), "", clientv3.WithPrefix(), clientv3.WithCountOnly())
+	require.NoError(t, err)
+
+	var result StepResult
+	if len(chain) == 0 {
+		_, err := writeEtcdManifest(filesDir, &etcdBackupManifest{ClusterID: resp.Header.ClusterId, Revision: resp.Header.Revision})
+		require.NoError(t, err)
+		_, err = snapshot.SaveWithVersion(t.Context(), zap.NewNop(), clientv3.Config{Endpoints: cli.Endpoints(), Logger: zap.NewNop()}, filepath.Join(filesDir, etcdBackup))
+		require.NoError(t, err)
+		result.filesForBackup = []string{filepath.Join(filesDir, etcdManifest), filepath.Join(filesDir, etcdBackup)}
+	} else {
+		step := etcdStep{tmpDir: filesDir, base: &etcdBackupManifest{ClusterID: resp.Header.ClusterId, Revision: baseRev, Chain: chain}}
+		result, err = step.backupDelta(t.Context(), cli)
+		require.NoError(t, err)
+	}
+
+	if name != "" {
+		f, err := os.Create(filepath.Join(dir, name))
+		require.NoError(t, err)
+		defer f.Close()
+		require.NoError(t, createArchive(f, result.filesForBackup, filepath.Join(dir, "data")))
+	}
+	return resp.Header.Revision
+}
+
+func TestStripSnapshotHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), etcdBackup)
+	data := bytes.Repeat([]byte{1}, 1024)
+	hash := sha256.Sum256(data)
+
+	require.NoError(t, os.WriteFile(path, append(data, hash[:]...), 0600))
+	require.NoError(t, stripSnapshotHash(path))
+	stripped, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, data, stripped)
+
+	// Snapshots without a hash are left alone.
+	require.NoError(t, stripSnapshotHash(path))
+	assert.FileExists(t, path)
+
+	hash[0]++
+	require.NoError(t, os.WriteFile(path, append(data, hash[:]...), 0600))
+	assert.ErrorContains(t, stripSnapshotHash(path), "hash mismatch")
+}
+
+func encodeTestEvents(t *testing.T, events ...*mvccpb.Event) []byte {
+	var buf bytes.Buffer
+	for _, event := range events {
+		require.NoError(t, writeEtcdEvent(&buf, event))
+	}
+	return buf.Bytes()
+}
+
+// writeTestArchive writes a backup archive containing the given manifest, if
+// any, and the given files.
+func writeTestArchive(t *testing.T, dir, name string, manifest *etcdBackupManifest, files map[string][]byte) {
+	filesDir := t.TempDir()
+	var paths []string
+	if manifest != nil {
+		path, err := writeEtcdManifest(filesDir, manifest)
+		require.NoError(t, err)
+		paths = append(paths, path)
+	}
+	for fileName, content := range files {
+		path := filepath.Join(filesDir, fileName)
+		require.NoError(t, os.WriteFile(path, content, 0600))
+		paths = append(paths, path)
+	}
+
+	f, err := os.Create(filepath.Join(dir, name))
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, createArchive(f, paths, filepath.Join(dir, "data")))
+}
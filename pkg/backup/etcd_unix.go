@@ -7,12 +7,17 @@ package backup
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 
+	"github.com/sirupsen/logrus"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/snapshot"
 	"go.uber.org/zap"
 
@@ -31,6 +36,13 @@ type etcdStep struct {
 	peerAddress string
 	etcdDataDir string
 	tmpDir      string
+
+	// base is the previous backup upon which an incremental backup is taken.
+	// A full snapshot is taken if nil.
+	base *etcdBackupManifest
+	// archiveDir is the directory containing the backup archives upon which an
+	// incremental backup builds when restoring.
+	archiveDir string
 }
 
 func newEtcdStep(tmpDir string, certRootDir string, etcdCertDir string, peerAddress string, etcdDataDir string) *etcdStep {
@@ -47,6 +59,35 @@ func (e etcdStep) Backup() (StepResult, error) {
 	if err != nil {
 		return StepResult{}, err
 	}
+	cli, err := clientv3.New(*etcdClient.Config)
+	if err != nil {
+		return StepResult{}, err
+	}
+	defer cli.Close()
+
+	if e.base != nil {
+		result, err := e.backupDelta(ctx, cli)
+		if !errors.Is(err, errFullSnapshotRequired) {
+			return result, err
+		}
+		logrus.WithError(err).Warn("Cannot take an incremental etcd backup, taking a full snapshot")
+	}
+
+	// The revision is determined before taking the snapshot. The snapshot may
+	// contain later revisions, too, which are then contained in the next delta
+	// as well. They're skipped when replaying the delta on restore.
+	resp, err := cli.Get(ctx, "", clientv3.WithPrefix(), clientv3.WithCountOnly())
+	if err != nil {
+		return StepResult{}, err
+	}
+	manifestPath, err := writeEtcdManifest(e.tmpDir, &etcdBackupManifest{
+		ClusterID: resp.Header.ClusterId,
+		Revision:  resp.Header.Revision,
+	})
+	if err != nil {
+		return StepResult{}, err
+	}
+
 	path := filepath.Join(e.tmpDir, etcdBackup)
 
 	// disable etcd's logging
@@ -56,12 +97,27 @@ func (e etcdStep) Backup() (StepResult, error) {
 	if _, err = snapshot.SaveWithVersion(ctx, lg, *etcdClient.Config, path); err != nil {
 		return StepResult{}, err
 	}
-	// add snapshot's path to assets
-	return StepResult{filesForBackup: []string{path}}, nil
+	// add manifest's and snapshot's paths to assets
+	return StepResult{filesForBackup: []string{manifestPath, path}}, nil
 }
 
 func (e etcdStep) Restore(restoreFrom, _ string) error {
 	snapshotPath := filepath.Join(restoreFrom, etcdBackup)
+
+	manifest, err := readEtcdManifest(restoreFrom)
+	if err != nil {
+		return err
+	}
+	incremental := manifest != nil && len(manifest.Chain) > 0
+	if incremental {
+		if e.archiveDir == "" {
+			return errors.New("incremental backups can only be restored from local archives")
+		}
+		if err := extractEtcdSnapshot(e.archiveDir, manifest, snapshotPath); err != nil {
+			return err
+		}
+	}
+
 	if !file.Exists(snapshotPath) {
 		return fmt.Errorf("etcd snapshot not found at %s", snapshotPath)
 	}
@@ -91,5 +147,44 @@ func (e etcdStep) Restore(restoreFrom, _ string) error {
 		return err
 	}
 
+	if incremental {
+		if err := replayEtcdDeltas(context.TODO(), e.etcdDataDir, name, e.archiveDir, restoreFrom, manifest); err != nil {
+			return fmt.Errorf("failed to restore incremental etcd backup: %w", err)
+		}
+	}
+
 	return nil
 }
+
+func (e etcdStep) DryRunRestore(restoreFrom, _ string, r *RestoreReport) {
+	snapshotPath := filepath.Join(restoreFrom, etcdBackup)
+
+	manifest, err := readEtcdManifest(restoreFrom)
+	if err != nil {
+		r.errorf("%v", err)
+		return
+	}
+	if manifest != nil && len(manifest.Chain) > 0 {
+		if e.archiveDir == "" {
+			r.errorf("incremental backups can only be restored from local archives")
+		} else if err := verifyEtcdChain(e.archiveDir, restoreFrom, manifest, snapshotPath); err != nil {
+			r.errorf("failed to verify incremental etcd backup: %v", err)
+		}
+	} else if !file.Exists(snapshotPath) {
+		r.errorf("etcd snapshot not found in the backup archive")
+	} else if _, hasHash, err := verifySnapshotHash(snapshotPath); err != nil {
+		r.errorf("failed to verify etcd snapshot: %v", err)
+	} else if !hasHash {
+		r.warnf("The etcd snapshot has no integrity hash")
+	}
+
+	if entries, err := os.ReadDir(e.etcdDataDir); err == nil && len(entries) > 0 {
+		r.errorf("etcd data directory %s is not empty", e.etcdDataDir)
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		r.errorf("failed to read etcd data directory: %v", err)
+	}
+
+	if addresses, err := localAddresses(); err == nil && e.peerAddress != "" && !slices.Contains(addresses, e.peerAddress) {
+		r.errorf("etcd peer address %s isn't an address of this host", e.peerAddress)
+	}
+}
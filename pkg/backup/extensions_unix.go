@@ -0,0 +1,334 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/pkg/file"
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+	"github.com/k0sproject/k0s/pkg/applier"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+
+	"github.com/sirupsen/logrus"
+)
+
+// extensionsStateFile is the name of the file recording the state of the
+// extensions in the backup archive.
+const extensionsStateFile = "extensions-state.json"
+
+// extensionsState records the state of the Helm extensions and the manifest
+// stacks, as it's stored in the cluster at backup time. It is used to check
+// that the restored manifests match the restored cluster state, i.e. that the
+// applier and the chart controller don't immediately start to change things
+// after a restore.
+type extensionsState struct {
+	// Charts are the release states of the Helm charts, by chart name.
+	Charts map[string]helmv1beta1.ChartStatus `json:"charts,omitempty"`
+	// Stacks are the applied resources of the manifest stacks, by stack name.
+	Stacks map[string][]stackResource `json:"stacks,omitempty"`
+}
+
+// stackResource is a resource that has been applied by a manifest stack.
+type stackResource struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Checksum is the value of the stack checksum annotation.
+	Checksum string `json:"checksum,omitempty"`
+}
+
+func (r *stackResource) String() string {
+	kind := r.Kind
+	if r.Group != "" {
+		kind += "." + r.Group
+	}
+	if r.Namespace != "" {
+		return fmt.Sprintf("%s %s/%s", kind, r.Namespace, r.Name)
+	}
+	return fmt.Sprintf("%s %s", kind, r.Name)
+}
+
+func (r *stackResource) isChart() bool {
+	return r.Group == helmv1beta1.GroupName && r.Kind == "Chart"
+}
+
+func newStackResource(resource *unstructured.Unstructured) stackResource {
+	return stackResource{
+		Group:     resource.GroupVersionKind().Group,
+		Kind:      resource.GetKind(),
+		Namespace: resource.GetNamespace(),
+		Name:      resource.GetName(),
+	}
+}
+
+type extensionsStep struct {
+	tmpDir         string
+	kubeconfigPath string
+	manifestsDir   string
+}
+
+func newExtensionsStep(tmpDir, kubeconfigPath, manifestsDir string) *extensionsStep {
+	return &extensionsStep{tmpDir: tmpDir, kubeconfigPath: kubeconfigPath, manifestsDir: manifestsDir}
+}
+
+func (e *extensionsStep) Name() string {
+	return "extensions state"
+}
+
+// Backup records the state of the extensions using the cluster admin
+// kubeconfig. The state itself is part of the datastore backup, so this is
+// best effort: the backup isn't failed if the API server can't be reached.
+func (e *extensionsStep) Backup() (StepResult, error) {
+	if !file.Exists(e.kubeconfigPath) {
+		logrus.Warnf("Admin kubeconfig %s not found, not recording the state of the extensions", e.kubeconfigPath)
+		return StepResult{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 2*time.Minute)
+	defer cancel()
+
+	clients := &kubeutil.ClientFactory{LoadRESTConfig: func() (*rest.Config, error) {
+		return kubeutil.ClientConfig(kubeutil.KubeconfigFromFile(e.kubeconfigPath))
+	}}
+	state, err := getExtensionsState(ctx, clients)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to record the state of the extensions")
+		return StepResult{}, nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return StepResult{}, err
+	}
+	path := filepath.Join(e.tmpDir, extensionsStateFile)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return StepResult{}, fmt.Errorf("failed to write extensions state: %w", err)
+	}
+	return StepResult{filesForBackup: []string{path}}, nil
+}
+
+// Restore doesn't restore anything, as the extensions state is part of the
+// restored datastore. It warns about the changes that will be made to the
+// cluster once k0s is started, as those indicate that the datastore and the
+// manifests haven't been backed up consistently.
+func (e *extensionsStep) Restore(restoreFrom, _ string) error {
+	findings, err := e.check(restoreFrom)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to check the state of the extensions")
+		return nil
+	}
+	for _, finding := range findings {
+		logrus.Warn(finding)
+	}
+	return nil
+}
+
+func (e *extensionsStep) DryRunRestore(restoreFrom, _ string, r *RestoreReport) {
+	findings, err := e.check(restoreFrom)
+	if err != nil {
+		r.warnf("Failed to check the state of the extensions: %v", err)
+		return
+	}
+	for _, finding := range findings {
+		r.warnf("%s", finding)
+	}
+}
+
+func (e *extensionsStep) check(restoreFrom string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(restoreFrom, extensionsStateFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		logrus.Debug("The backup archive doesn't contain the state of the extensions")
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state extensionsState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("invalid extensions state: %w", err)
+	}
+	return checkExtensions(filepath.Join(restoreFrom, filepath.Base(e.manifestsDir)), &state)
+}
+
+// getExtensionsState lists the charts and all the resources that have been
+// applied by manifest stacks.
+func getExtensionsState(ctx context.Context, clients kubeutil.ClientFactoryInterface) (*extensionsState, error) {
+	k0sClient, err := clients.GetK0sClient()
+	if err != nil {
+		return nil, err
+	}
+	charts, err := k0sClient.HelmV1beta1().Charts(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list charts: %w", err)
+	}
+
+	state := extensionsState{
+		Charts: make(map[string]helmv1beta1.ChartStatus, len(charts.Items)),
+		Stacks: make(map[string][]stackResource),
+	}
+	for _, chart := range charts.Items {
+		state.Charts[chart.Name] = chart.Status
+	}
+
+	discoveryClient, err := clients.GetDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := clients.GetDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// Like the applier, tolerate API services that fail discovery.
+	apiResourceLists, err := discoveryClient.ServerPreferredResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("failed to discover API resources: %w", err)
+	}
+
+	for _, apiResourceList := range apiResourceLists {
+		gv, err := schema.ParseGroupVersion(apiResourceList.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, apiResource := range apiResourceList.APIResources {
+			if strings.Contains(apiResource.Name, "/") || !slices.Contains(apiResource.Verbs, "list") {
+				continue
+			}
+
+			resources, err := dynamicClient.Resource(gv.WithResource(apiResource.Name)).List(ctx, metav1.ListOptions{
+				LabelSelector: applier.NameLabel,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list %s: %w", gv.WithResource(apiResource.Name), err)
+			}
+
+			for _, resource := range resources.Items {
+				stack := resource.GetLabels()[applier.NameLabel]
+				// Resources created on behalf of stack resources inherit the
+				// stack label, but aren't applied by the stack.
+				if stack == "" || len(resource.GetOwnerReferences()) > 0 {
+					continue
+				}
+				r := newStackResource(&resource)
+				r.Checksum = resource.GetAnnotations()[applier.ChecksumAnnotation]
+				state.Stacks[stack] = append(state.Stacks[stack], r)
+			}
+		}
+	}
+
+	return &state, nil
+}
+
+// checkExtensions compares the manifests in the given manifests directory with
+// the recorded extensions state, and describes the changes that the applier
+// and the chart controller would make to the cluster.
+func checkExtensions(manifestsDir string, state *extensionsState) ([]string, error) {
+	entries, err := os.ReadDir(manifestsDir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	var findings []string
+	restoredStacks := make(map[string]bool)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		stack := entry.Name()
+		restoredStacks[stack] = true
+
+		// Stacks that are handled internally by k0s contain an ignore notice
+		// written by the applier manager.
+		if file.Exists(filepath.Join(manifestsDir, stack, "ignored.txt")) {
+			continue
+		}
+
+		resources, err := applier.ReadManifests(filepath.Join(manifestsDir, stack))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifests of stack %s: %w", stack, err)
+		}
+
+		applied := state.Stacks[stack]
+		for _, resource := range resources {
+			r := newStackResource(resource)
+			idx := slices.IndexFunc(applied, func(a stackResource) bool {
+				return a.Group == r.Group && a.Kind == r.Kind && a.Namespace == r.Namespace && a.Name == r.Name
+			})
+			switch {
+			case idx < 0:
+				findings = append(findings, fmt.Sprintf("%s of stack %s will be created", &r, stack))
+			case applied[idx].Checksum != applier.ResourceChecksum(resource):
+				findings = append(findings, fmt.Sprintf("%s of stack %s will be updated", &r, stack))
+			}
+			if idx >= 0 {
+				applied = slices.Delete(slices.Clone(applied), idx, idx+1)
+			}
+
+			if r.isChart() {
+				if finding := checkChart(resource, state); finding != "" {
+					findings = append(findings, finding)
+				}
+			}
+		}
+
+		for _, r := range applied {
+			if r.isChart() {
+				findings = append(findings, fmt.Sprintf("Helm chart %s of stack %s will be uninstalled", r.Name, stack))
+			} else {
+				findings = append(findings, fmt.Sprintf("%s of stack %s will be pruned", &r, stack))
+			}
+		}
+	}
+
+	for stack, applied := range state.Stacks {
+		if !restoredStacks[stack] && len(applied) > 0 {
+			findings = append(findings, fmt.Sprintf("Stack %s isn't part of the backup, its %d resources won't be managed anymore", stack, len(applied)))
+		}
+	}
+
+	return findings, nil
+}
+
+// checkChart checks whether the chart controller would install or upgrade the
+// given chart, based on its recorded release state.
+func checkChart(resource *unstructured.Unstructured, state *extensionsState) string {
+	var chart helmv1beta1.Chart
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(resource.Object, &chart); err != nil {
+		return fmt.Sprintf("Failed to parse Helm chart %s: %v", resource.GetName(), err)
+	}
+
+	status, ok := state.Charts[chart.Name]
+	switch {
+	case !ok || status.ReleaseName == "":
+		return fmt.Sprintf("Helm chart %s will be installed", chart.Name)
+	case status.Namespace != chart.Spec.Namespace,
+		status.ReleaseName != chart.Spec.ReleaseName,
+		status.Version != chart.Spec.Version,
+		status.ValuesHash != chart.Spec.HashValues():
+		return fmt.Sprintf("Helm chart %s will be upgraded from revision %d", chart.Name, status.Revision)
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+	"github.com/k0sproject/k0s/pkg/applier"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckExtensions(t *testing.T) {
+	manifestsDir := t.TempDir()
+	stackDir := filepath.Join(manifestsDir, "stack")
+	require.NoError(t, os.Mkdir(stackDir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(stackDir, "resources.yaml"), []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unchanged
+  namespace: default
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: changed
+  namespace: default
+data:
+  foo: bar
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: new
+  namespace: default
+---
+apiVersion: helm.k0sproject.io/v1beta1
+kind: Chart
+metadata:
+  name: k0s-addon-chart-metrics
+  namespace: kube-system
+spec:
+  chartName: metrics/metrics-server
+  releaseName: metrics
+  namespace: kube-system
+  version: 1.2.0
+`), 0600))
+
+	ignoredDir := filepath.Join(manifestsDir, "ignored")
+	require.NoError(t, os.Mkdir(ignoredDir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(ignoredDir, "ignored.txt"), nil, 0600))
+
+	resources, err := applier.ReadManifests(stackDir)
+	require.NoError(t, err)
+	require.Len(t, resources, 4)
+
+	state := extensionsState{
+		Charts: map[string]helmv1beta1.ChartStatus{
+			"k0s-addon-chart-metrics": {ReleaseName: "metrics", Namespace: "kube-system", Version: "1.1.0", Revision: 3},
+		},
+		Stacks: map[string][]stackResource{
+			"stack": {
+				{Kind: "ConfigMap", Namespace: "default", Name: "unchanged", Checksum: applier.ResourceChecksum(resources[0])},
+				{Kind: "ConfigMap", Namespace: "default", Name: "changed", Checksum: "outdated"},
+				{Kind: "ConfigMap", Namespace: "default", Name: "removed"},
+				{Group: "helm.k0sproject.io", Kind: "Chart", Namespace: "kube-system", Name: "k0s-addon-chart-metrics", Checksum: applier.ResourceChecksum(resources[3])},
+				{Group: "helm.k0sproject.io", Kind: "Chart", Namespace: "kube-system", Name: "k0s-addon-chart-removed"},
+			},
+			"ignored": {{Kind: "ConfigMap", Namespace: "kube-system", Name: "internal"}},
+			"gone":    {{Kind: "ConfigMap", Namespace: "default", Name: "orphaned"}},
+		},
+	}
+
+	findings, err := checkExtensions(manifestsDir, &state)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"ConfigMap default/changed of stack stack will be updated",
+		"ConfigMap default/new of stack stack will be created",
+		"Helm chart k0s-addon-chart-metrics will be upgraded from revision 3",
+		"ConfigMap default/removed of stack stack will be pruned",
+		"Helm chart k0s-addon-chart-removed of stack stack will be uninstalled",
+		"Stack gone isn't part of the backup, its 1 resources won't be managed anymore",
+	}, findings)
+}
+
+func TestCheckExtensions_Consistent(t *testing.T) {
+	findings, err := checkExtensions(filepath.Join(t.TempDir(), "manifests"), &extensionsState{})
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
@@ -53,6 +53,17 @@ func (d FileSystemStep) Restore(restoreFrom, restoreTo string) error {
 	return err
 }
 
+func (d FileSystemStep) DryRunRestore(restoreFrom, restoreTo string, r *RestoreReport) {
+	childName := filepath.Base(d.path)
+	existing, err := existingFiles(filepath.Join(restoreFrom, childName), filepath.Join(restoreTo, childName))
+	if err != nil && !os.IsNotExist(err) {
+		r.errorf("failed to check %s: %v", d.path, err)
+	}
+	for _, path := range existing {
+		r.conflictf("%s would be overwritten", path)
+	}
+}
+
 // NewFileSystemStep constructor
 func NewFileSystemStep(path string) FileSystemStep {
 	return FileSystemStep{path: path}
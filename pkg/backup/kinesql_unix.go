@@ -0,0 +1,344 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+// kineDump is the name of the logical dump of a kine SQL database in the
+// backup archive. It contains one JSON encoded kineRow per line.
+const kineDump = "kine-dump.jsonl"
+
+// kineRow is a row of kine's table. Since all the SQL backends of kine share
+// the same table layout, dumps can be restored into any of them.
+type kineRow struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	Created        int64  `json:"created"`
+	Deleted        int64  `json:"deleted"`
+	CreateRevision int64  `json:"createRevision"`
+	PrevRevision   int64  `json:"prevRevision"`
+	Lease          int64  `json:"lease"`
+	Value          []byte `json:"value"`
+	OldValue       []byte `json:"oldValue"`
+}
+
+const kineColumns = "id, name, created, deleted, create_revision, prev_revision, lease, value, old_value"
+
+// kineSQLDialect describes the differences between the SQL backends of kine.
+type kineSQLDialect struct {
+	driverName string
+	// createTable creates kine's table, as kine itself would do.
+	createTable string
+	// insert inserts a kineRow into kine's table.
+	insert string
+	// afterRestore is executed after all rows have been inserted, if not empty.
+	afterRestore string
+}
+
+var (
+	kineMySQLDialect = kineSQLDialect{
+		driverName: "mysql",
+		createTable: `CREATE TABLE IF NOT EXISTS kine (
+			id BIGINT UNSIGNED AUTO_INCREMENT,
+			name VARCHAR(630) CHARACTER SET ascii,
+			created INTEGER,
+			deleted INTEGER,
+			create_revision BIGINT UNSIGNED,
+			prev_revision BIGINT UNSIGNED,
+			lease INTEGER,
+			value MEDIUMBLOB,
+			old_value MEDIUMBLOB,
+			PRIMARY KEY (id)
+		)`,
+		insert: "INSERT INTO kine (" + kineColumns + ") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+	}
+
+	kinePostgresDialect = kineSQLDialect{
+		driverName: "postgres",
+		createTable: `CREATE TABLE IF NOT EXISTS kine (
+			id SERIAL PRIMARY KEY,
+			name text COLLATE "C",
+			created INTEGER,
+			deleted INTEGER,
+			create_revision BIGINT,
+			prev_revision BIGINT,
+			lease INTEGER,
+			value bytea,
+			old_value bytea
+		)`,
+		insert: "INSERT INTO kine (" + kineColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		// The rows are inserted with explicit IDs, so the sequence needs to
+		// be advanced manually.
+		afterRestore: "SELECT setval(pg_get_serial_sequence('kine', 'id'), MAX(id)) FROM kine",
+	}
+)
+
+// kineSQLStep backs up and restores the kine table of an external MySQL or
+// PostgreSQL database by means of a logical dump.
+type kineSQLStep struct {
+	backend string
+	dsn     string
+	tmpDir  string
+}
+
+func newKineSQLStep(tmpDir, backend, dsn string) *kineSQLStep {
+	return &kineSQLStep{backend: backend, dsn: dsn, tmpDir: tmpDir}
+}
+
+func (s *kineSQLStep) Name() string {
+	return "kine " + s.backend + " database"
+}
+
+func (s *kineSQLStep) Backup() (StepResult, error) {
+	ctx := context.TODO()
+	db, dialect, err := s.open(ctx)
+	if err != nil {
+		return StepResult{}, err
+	}
+	defer db.Close()
+
+	path := filepath.Join(s.tmpDir, kineDump)
+	f, err := os.Create(path)
+	if err != nil {
+		return StepResult{}, fmt.Errorf("failed to create kine dump: %w", err)
+	}
+	defer f.Close()
+
+	logrus.Debugf("dumping kine %s database to %v", dialect.driverName, path)
+	w := bufio.NewWriter(f)
+	if err := dumpKineTable(ctx, db, w); err != nil {
+		return StepResult{}, fmt.Errorf("failed to dump kine database: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		return StepResult{}, err
+	}
+	if err := f.Close(); err != nil {
+		return StepResult{}, err
+	}
+	return StepResult{filesForBackup: []string{path}}, nil
+}
+
+// Restore inserts the dumped rows into the database. The kine table has to
+// be empty, as this database might still be in use by other controllers.
+func (s *kineSQLStep) Restore(restoreFrom, _ string) error {
+	f, err := os.Open(filepath.Join(restoreFrom, kineDump))
+	if err != nil {
+		return fmt.Errorf("failed to open kine dump: %w", err)
+	}
+	defer f.Close()
+
+	ctx := context.TODO()
+	db, dialect, err := s.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, dialect.createTable); err != nil {
+		return fmt.Errorf("failed to create kine table: %w", err)
+	}
+	if err := checkKineTableEmpty(ctx, db); err != nil {
+		return err
+	}
+
+	logrus.Infof("restoring kine %s database", dialect.driverName)
+	rows, err := restoreKineTable(ctx, db, dialect, bufio.NewReader(f))
+	if err != nil {
+		return fmt.Errorf("failed to restore kine database: %w", err)
+	}
+	logrus.Infof("restored %d rows into the kine %s database", rows, dialect.driverName)
+	return nil
+}
+
+func (s *kineSQLStep) DryRunRestore(restoreFrom, _ string, r *RestoreReport) {
+	if _, err := os.Stat(filepath.Join(restoreFrom, kineDump)); err != nil {
+		r.errorf("kine database dump not found in the backup archive")
+	}
+
+	ctx := context.TODO()
+	db, _, err := s.open(ctx)
+	if err != nil {
+		r.errorf("%v", err)
+		return
+	}
+	defer db.Close()
+
+	if err := checkKineTableEmpty(ctx, db); err != nil {
+		// A missing table is fine, it'll be created.
+		var mysqlErr *mysql.MySQLError
+		var pqErr *pq.Error
+		if (errors.As(err, &mysqlErr) && mysqlErr.Number == 1146) || (errors.As(err, &pqErr) && pqErr.Code == "42P01") {
+			return
+		}
+		r.errorf("%v", err)
+	}
+}
+
+func (s *kineSQLStep) open(ctx context.Context) (*sql.DB, *kineSQLDialect, error) {
+	var (
+		dialect *kineSQLDialect
+		dsn     string
+		err     error
+	)
+	switch s.backend {
+	case "mysql":
+		dialect = &kineMySQLDialect
+		dsn, err = kineMySQLDSN(s.dsn)
+	case "postgres", "postgresql":
+		dialect = &kinePostgresDialect
+		dsn, err = kinePostgresDSN(s.dsn)
+	default:
+		return nil, nil, fmt.Errorf("unsupported kine backend %q", s.backend)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid kine %s data source: %w", s.backend, err)
+	}
+
+	db, err := sql.Open(dialect.driverName, dsn)
+	if err == nil {
+		err = db.PingContext(ctx)
+		if errors.Is(err, pq.ErrSSLNotSupported) && !strings.Contains(dsn, "sslmode=") {
+			// Emulate PostgreSQL's default sslmode=prefer, which isn't
+			// supported by lib/pq.
+			db.Close()
+			db, err = sql.Open(dialect.driverName, dsn+"&sslmode=disable")
+			if err == nil {
+				err = db.PingContext(ctx)
+			}
+		}
+	}
+	if err != nil {
+		if db != nil {
+			db.Close()
+		}
+		return nil, nil, fmt.Errorf("failed to connect to the kine %s database: %w", s.backend, err)
+	}
+	return db, dialect, nil
+}
+
+// kineMySQLDSN converts the DSN of a kine MySQL data source into a DSN for the
+// MySQL driver. Like kine, default to the "kubernetes" database.
+func kineMySQLDSN(dsn string) (string, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return "", err
+	}
+	if cfg.DBName == "" {
+		cfg.DBName = "kubernetes"
+	}
+	return cfg.FormatDSN(), nil
+}
+
+// kinePostgresDSN converts the DSN of a kine PostgreSQL data source into a
+// connection URL for the PostgreSQL driver. Like kine, default to the
+// "kubernetes" database. There's always a query string in the returned URL,
+// so that parameters may be appended.
+func kinePostgresDSN(dsn string) (string, error) {
+	u, err := url.Parse("postgres://" + dsn)
+	if err != nil {
+		return "", err
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/kubernetes"
+	}
+	query := u.Query()
+	query.Set("application_name", "k0s-backup")
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func checkKineTableEmpty(ctx context.Context, db *sql.DB) error {
+	var rows int64
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM kine").Scan(&rows); err != nil {
+		return fmt.Errorf("failed to check kine table: %w", err)
+	}
+	if rows > 0 {
+		return fmt.Errorf("the kine table already contains %d rows, restoring requires an empty database", rows)
+	}
+	return nil
+}
+
+// dumpKineTable writes all rows of kine's table to w. The rows are read in a
+// read-only, repeatable-read transaction, so that the dump is consistent.
+func dumpKineTable(ctx context.Context, db *sql.DB, w io.Writer) error {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, "SELECT "+kineColumns+" FROM kine ORDER BY id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	enc := json.NewEncoder(w)
+	for rows.Next() {
+		var row kineRow
+		if err := rows.Scan(&row.ID, &row.Name, &row.Created, &row.Deleted, &row.CreateRevision, &row.PrevRevision, &row.Lease, &row.Value, &row.OldValue); err != nil {
+			return err
+		}
+		if err := enc.Encode(&row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// restoreKineTable inserts the rows read from r into kine's table within a
+// single transaction, and returns the number of inserted rows.
+func restoreKineTable(ctx context.Context, db *sql.DB, dialect *kineSQLDialect, r io.Reader) (int64, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	insert, err := tx.PrepareContext(ctx, dialect.insert)
+	if err != nil {
+		return 0, err
+	}
+	defer insert.Close()
+
+	var inserted int64
+	dec := json.NewDecoder(r)
+	for {
+		var row kineRow
+		if err := dec.Decode(&row); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return 0, fmt.Errorf("invalid kine dump: %w", err)
+		}
+		if _, err := insert.ExecContext(ctx, row.ID, row.Name, row.Created, row.Deleted, row.CreateRevision, row.PrevRevision, row.Lease, row.Value, row.OldValue); err != nil {
+			return 0, fmt.Errorf("failed to insert row %d: %w", row.ID, err)
+		}
+		inserted++
+	}
+
+	if dialect.afterRestore != "" && inserted > 0 {
+		if _, err := tx.ExecContext(ctx, dialect.afterRestore); err != nil {
+			return 0, err
+		}
+	}
+	return inserted, tx.Commit()
+}
@@ -0,0 +1,83 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKineMySQLDSN(t *testing.T) {
+	dsn, err := kineMySQLDSN("kine:secret@tcp(db:3306)/")
+	require.NoError(t, err)
+	assert.Equal(t, "kine:secret@tcp(db:3306)/kubernetes", dsn)
+
+	dsn, err = kineMySQLDSN("kine:secret@tcp(db:3306)/k0s?tls=true")
+	require.NoError(t, err)
+	assert.Equal(t, "kine:secret@tcp(db:3306)/k0s?tls=true", dsn)
+}
+
+func TestKinePostgresDSN(t *testing.T) {
+	dsn, err := kinePostgresDSN("kine:secret@db:5432")
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://kine:secret@db:5432/kubernetes?application_name=k0s-backup", dsn)
+
+	dsn, err = kinePostgresDSN("kine:secret@db/k0s?sslmode=disable")
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://kine:secret@db/k0s?application_name=k0s-backup&sslmode=disable", dsn)
+}
+
+func TestKineTable_RoundTrip(t *testing.T) {
+	// SQLite understands the MySQL placeholders.
+	dialect := kineSQLDialect{
+		driverName: "sqlite3",
+		createTable: `CREATE TABLE IF NOT EXISTS kine (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT, created INTEGER, deleted INTEGER,
+			create_revision INTEGER, prev_revision INTEGER, lease INTEGER,
+			value BLOB, old_value BLOB
+		)`,
+		insert: kineMySQLDialect.insert,
+	}
+
+	openDB := func(t *testing.T) *sql.DB {
+		db, err := sql.Open(dialect.driverName, ":memory:")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+		db.SetMaxOpenConns(1)
+		_, err = db.Exec(dialect.createTable)
+		require.NoError(t, err)
+		return db
+	}
+
+	src := openDB(t)
+	_, err := src.Exec(`INSERT INTO kine (name, created, deleted, create_revision, prev_revision, lease, value, old_value) VALUES
+		('compact_rev_key', 0, 0, 0, 0, 0, '', NULL),
+		('/registry/foo', 1, 0, 0, 0, 0, x'00ff', NULL),
+		('/registry/foo', 0, 0, 2, 2, 30, x'01', x'00ff')`)
+	require.NoError(t, err)
+
+	var dump bytes.Buffer
+	require.NoError(t, dumpKineTable(t.Context(), src, &dump))
+
+	dst := openDB(t)
+	require.NoError(t, checkKineTableEmpty(t.Context(), dst))
+	inserted, err := restoreKineTable(t.Context(), dst, &dialect, &dump)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), inserted)
+	assert.ErrorContains(t, checkKineTableEmpty(t.Context(), dst), "already contains 3 rows")
+
+	var restored bytes.Buffer
+	require.NoError(t, dumpKineTable(t.Context(), dst, &restored))
+	var original bytes.Buffer
+	require.NoError(t, dumpKineTable(t.Context(), src, &original))
+	assert.Equal(t, original.String(), restored.String())
+	assert.Contains(t, restored.String(), `"value":"AP8=","oldValue":null`)
+}
@@ -6,6 +6,8 @@
 package backup
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -23,21 +25,73 @@ import (
 
 // Manager hold configuration for particular backup-restore process
 type Manager struct {
+	// S3 configures the access to S3-compatible object storage, for backups
+	// saved to, or restored from, S3 URLs.
+	S3 S3Options
+
+	// Encryption configures the encryption of backup archives, and the keys
+	// to decrypt them when restoring.
+	Encryption EncryptionOptions
+
+	// Incremental enables incremental etcd backups. Those contain only the
+	// changes since the newest backup archive in the save path, until MaxDeltas
+	// incremental backups have been taken and a full snapshot is taken again.
+	Incremental bool
+	MaxDeltas   int
+
+	// Remap configures how backups are restored onto a controller with a
+	// different hostname or IP address than the backed-up one.
+	Remap NodeRemapOptions
+
+	// Stdin is the reader from which archives are restored if the archive
+	// path is "-". Defaults to [os.Stdin].
+	Stdin io.Reader
+
+	// etcdBase is the backup upon which an incremental backup is taken.
+	etcdBase *etcdBackupManifest
+	// archiveDir is the directory of the archive being restored.
+	archiveDir string
+
 	steps   []Backuper
 	tmpDir  string
 	dataDir string
 }
 
 // RunBackup backups cluster
-func (bm *Manager) RunBackup(nodeSpec *v1beta1.ClusterSpec, vars *config.CfgVars, savePathDir string, out io.Writer) error {
+func (bm *Manager) RunBackup(ctx context.Context, nodeSpec *v1beta1.ClusterSpec, vars *config.CfgVars, savePathDir string, out io.Writer) error {
 	_, err := vars.NodeConfig()
 	if err != nil {
 		return err
 	}
 
+	if bm.Incremental {
+		if bm.Encryption.IsEnabled() {
+			return errors.New("incremental backups can't be encrypted")
+		}
+		if nodeSpec.Storage.Type != v1beta1.EtcdStorageType || nodeSpec.Storage.Etcd.IsExternalClusterUsed() {
+			return errors.New("incremental backups are only supported for the embedded etcd")
+		}
+		if savePathDir == "-" || IsS3URL(savePathDir) {
+			return errors.New("incremental backups can only be saved to local directories")
+		}
+		maxDeltas := bm.MaxDeltas
+		if maxDeltas <= 0 {
+			maxDeltas = DefaultMaxDeltas
+		}
+		if bm.etcdBase, err = incrementalBase(savePathDir, maxDeltas); err != nil {
+			return err
+		}
+	}
+
 	bm.discoverSteps(vars.StartupConfigPath, nodeSpec, vars, "backup", "", out)
 	defer os.RemoveAll(bm.tmpDir)
-	assets := make([]string, 0, len(bm.steps))
+	assets := make([]string, 0, len(bm.steps)+1)
+
+	metadataPath, err := writeArchiveMetadata(bm.tmpDir)
+	if err != nil {
+		return fmt.Errorf("failed to write backup metadata: %w", err)
+	}
+	assets = append(assets, metadataPath)
 
 	logrus.Info("Starting backup")
 	for _, step := range bm.steps {
@@ -50,14 +104,25 @@ func (bm *Manager) RunBackup(nodeSpec *v1beta1.ClusterSpec, vars *config.CfgVars
 	}
 
 	if savePathDir == "-" {
-		return createArchive(out, assets, bm.dataDir)
+		return bm.writeArchive(ctx, out, assets)
 	}
 
-	backupFileName := fmt.Sprintf("k0s_backup_%s.tar.gz", timeStamp())
-	if err := bm.save(backupFileName, assets); err != nil {
+	backupFileName := archivePrefix + timeStamp() + archiveSuffix
+	if bm.Encryption.IsEnabled() {
+		backupFileName += encryptedArchiveSuffix
+	}
+	if err := bm.save(ctx, backupFileName, assets); err != nil {
 		return fmt.Errorf("failed to create archive `%s`: %w", backupFileName, err)
 	}
 	srcBackupFile := filepath.Join(bm.tmpDir, backupFileName)
+	if IsS3URL(savePathDir) {
+		dest, err := UploadToS3(ctx, bm.S3, savePathDir, srcBackupFile)
+		if err != nil {
+			return err
+		}
+		logrus.Infof("archive %s created successfully", dest)
+		return nil
+	}
 	destBackupFile := filepath.Join(savePathDir, backupFileName)
 	if err := file.Copy(srcBackupFile, destBackupFile); err != nil {
 		return fmt.Errorf("failed to rename temporary archive: %w", err)
@@ -72,12 +137,16 @@ func (bm *Manager) discoverSteps(configFilePath string, nodeSpec *v1beta1.Cluste
 		if nodeSpec.Storage.Etcd.IsExternalClusterUsed() {
 			logrus.Warnf("%s is not supported for an external etcd cluster, it must be done manually", action)
 		} else {
-			bm.Add(newEtcdStep(bm.tmpDir, vars.CertRootDir, vars.EtcdCertDir, nodeSpec.Storage.Etcd.PeerAddress, vars.EtcdDataDir))
+			step := newEtcdStep(bm.tmpDir, vars.CertRootDir, vars.EtcdCertDir, nodeSpec.Storage.Etcd.PeerAddress, vars.EtcdDataDir)
+			step.base, step.archiveDir = bm.etcdBase, bm.archiveDir
+			bm.Add(step)
 		}
 
 	case v1beta1.KineStorageType:
 		if backend, dsn, err := kine.SplitDataSource(nodeSpec.Storage.Kine.DataSource); err != nil {
 			logrus.WithError(err).Warnf("cannot %s kine data source, it must be done manually", action)
+		} else if backend == "mysql" || backend == "postgres" || backend == "postgresql" {
+			bm.Add(newKineSQLStep(bm.tmpDir, backend, dsn))
 		} else if backend != "sqlite" {
 			logrus.Warnf("%s is not supported for %q kine data sources, it must be done manually", action, backend)
 		} else if dbPath, err := kine.GetSQLiteFilePath(vars.DataDir, dsn); err != nil {
@@ -87,6 +156,10 @@ func (bm *Manager) discoverSteps(configFilePath string, nodeSpec *v1beta1.Cluste
 		}
 	}
 
+	// Record the state of the extensions right after the datastore, to be
+	// able to check it against the restored manifests.
+	bm.Add(newExtensionsStep(bm.tmpDir, vars.AdminKubeConfigPath, vars.ManifestsDir))
+
 	bm.dataDir = vars.DataDir
 	for _, path := range []string{
 		vars.CertRootDir,
@@ -112,7 +185,7 @@ func (bm *Manager) Add(step Backuper) {
 	bm.steps = append(bm.steps, step)
 }
 
-func (bm Manager) save(backupFileName string, assets []string) error {
+func (bm Manager) save(ctx context.Context, backupFileName string, assets []string) error {
 	archiveFile := filepath.Join(bm.tmpDir, backupFileName)
 	logrus.Debugf("creating temporary archive file: %v", archiveFile)
 	out, err := os.Create(archiveFile)
@@ -121,7 +194,7 @@ func (bm Manager) save(backupFileName string, assets []string) error {
 	}
 	defer out.Close()
 	// Create the archive and write the output to the "out" Writer
-	err = createArchive(out, assets, bm.dataDir)
+	err = bm.writeArchive(ctx, out, assets)
 	if err != nil {
 		return fmt.Errorf("error creating archive: %w", err)
 	}
@@ -134,23 +207,28 @@ func (bm Manager) save(backupFileName string, assets []string) error {
 	return nil
 }
 
-// RunRestore restores cluster
-func (bm *Manager) RunRestore(archivePath string, k0sVars *config.CfgVars, desiredRestoredConfigPath string, out io.Writer) error {
-	var input io.Reader
-	if archivePath == "-" {
-		input = os.Stdin
-	} else {
-		i, err := os.Open(archivePath)
-		if err != nil {
-			return err
-		}
-		defer i.Close()
-		input = i
+// writeArchive writes the archive containing the given assets to w, encrypting
+// it if configured.
+func (bm Manager) writeArchive(ctx context.Context, w io.Writer, assets []string) error {
+	encrypted, err := bm.Encryption.encryptArchive(ctx, w)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt archive: %w", err)
 	}
-	if err := archive.Extract(input, bm.tmpDir); err != nil {
-		return fmt.Errorf("failed to unpack backup archive `%s`: %w", archivePath, err)
+	if err := createArchive(encrypted, assets, bm.dataDir); err != nil {
+		return err
 	}
+	return encrypted.Close()
+}
+
+// RunRestore restores cluster
+func (bm *Manager) RunRestore(ctx context.Context, archivePath string, k0sVars *config.CfgVars, desiredRestoredConfigPath string, out io.Writer) error {
 	defer os.RemoveAll(bm.tmpDir)
+	if err := bm.unpack(ctx, archivePath); err != nil {
+		return err
+	}
+	if err := bm.remapNode(); err != nil {
+		return err
+	}
 	cfg, err := bm.getConfigForRestore()
 	if err != nil {
 		return fmt.Errorf("failed to parse backed-up configuration file, check the backup archive: %w", err)
@@ -167,6 +245,51 @@ func (bm *Manager) RunRestore(archivePath string, k0sVars *config.CfgVars, desir
 	return nil
 }
 
+// unpack downloads, decrypts and extracts the backup archive into the
+// temporary directory, as needed.
+func (bm *Manager) unpack(ctx context.Context, archivePath string) error {
+	var input io.Reader
+	if archivePath == "-" {
+		input = bm.Stdin
+		if input == nil {
+			input = os.Stdin
+		}
+	} else {
+		localPath := archivePath
+		if IsS3URL(archivePath) {
+			downloaded, err := os.CreateTemp("", "k0s-backup-*.tar.gz")
+			if err != nil {
+				return err
+			}
+			localPath = downloaded.Name()
+			defer os.Remove(localPath)
+			if err := downloaded.Close(); err != nil {
+				return err
+			}
+			if err := downloadFromS3(ctx, bm.S3, archivePath, localPath); err != nil {
+				return err
+			}
+		} else {
+			bm.archiveDir = filepath.Dir(archivePath)
+		}
+
+		i, err := os.Open(localPath)
+		if err != nil {
+			return err
+		}
+		defer i.Close()
+		input = i
+	}
+	input, err := bm.Encryption.decryptArchive(ctx, input)
+	if err != nil {
+		return err
+	}
+	if err := archive.Extract(input, bm.tmpDir); err != nil {
+		return fmt.Errorf("failed to unpack backup archive `%s`: %w", archivePath, err)
+	}
+	return nil
+}
+
 func (bm Manager) getConfigForRestore() (*v1beta1.ClusterConfig, error) {
 	configFromBackup := path.Join(bm.tmpDir, "k0s.yaml")
 	logrus.Debugf("Using k0s.yaml from: %s", configFromBackup)
@@ -202,6 +325,9 @@ type Backuper interface {
 	Name() string
 	Backup() (StepResult, error)
 	Restore(from, to string) error
+	// DryRunRestore checks whether the step can be restored, and records
+	// its findings in the report.
+	DryRunRestore(from, to string, report *RestoreReport)
 }
 
 // StepResult backup result for the particular step
@@ -0,0 +1,212 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/k0sproject/k0s/internal/pkg/file"
+	"github.com/k0sproject/k0s/internal/pkg/stringslice"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NodeRemapOptions configure how a backup is restored onto a controller with
+// a different hostname or IP address than the one it has been taken on.
+type NodeRemapOptions struct {
+	// Address replaces the backed-up API and etcd peer addresses in the
+	// restored k0s configuration. The backed-up address and hostname in the API
+	// server's SANs are replaced by this address and this host's hostname.
+	Address string
+
+	// RegenerateCertificates removes all certificates issued by the backed-up
+	// CAs from the restored PKI, so that k0s issues them anew when it starts.
+	RegenerateCertificates bool
+}
+
+func (o *NodeRemapOptions) enabled() bool {
+	return o.Address != "" || o.RegenerateCertificates
+}
+
+// remapNode rewrites the unpacked backup archive according to the node
+// remapping options.
+func (bm *Manager) remapNode() error {
+	if !bm.Remap.enabled() {
+		return nil
+	}
+
+	if bm.Remap.Address != "" {
+		if net.ParseIP(bm.Remap.Address) == nil {
+			return fmt.Errorf("invalid address %q: not an IP address", bm.Remap.Address)
+		}
+
+		var oldHostname string
+		if metadata := bm.readMetadata(&RestoreReport{}); metadata != nil {
+			oldHostname = metadata.Hostname
+		}
+		newHostname, err := os.Hostname()
+		if err != nil {
+			return err
+		}
+
+		if configPath := filepath.Join(bm.tmpDir, "k0s.yaml"); file.Exists(configPath) {
+			if err := remapConfig(configPath, bm.Remap.Address, oldHostname, newHostname); err != nil {
+				return fmt.Errorf("failed to remap the k0s configuration: %w", err)
+			}
+		}
+	}
+
+	if bm.Remap.RegenerateCertificates {
+		if pkiDir := filepath.Join(bm.tmpDir, "pki"); file.Exists(pkiDir) {
+			if err := removeIssuedCertificates(pkiDir); err != nil {
+				return fmt.Errorf("failed to prepare certificate regeneration: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// remapConfig sets the API address and, if present, the etcd peer address in
+// the k0s configuration file to the given address. The old address and the
+// old hostname are replaced in the API server's SANs.
+func remapConfig(configPath, address, oldHostname, newHostname string) error {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+	var cfg map[string]any
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+	if cfg == nil {
+		cfg = make(map[string]any)
+	}
+
+	oldAddress, _, err := unstructured.NestedString(cfg, "spec", "api", "address")
+	if err != nil {
+		return err
+	}
+	if err := unstructured.SetNestedField(cfg, address, "spec", "api", "address"); err != nil {
+		return err
+	}
+	logrus.Infof("Setting API address to %s", address)
+
+	if peerAddress, found, err := unstructured.NestedString(cfg, "spec", "storage", "etcd", "peerAddress"); err != nil {
+		return err
+	} else if found {
+		if err := unstructured.SetNestedField(cfg, address, "spec", "storage", "etcd", "peerAddress"); err != nil {
+			return err
+		}
+		logrus.Infof("Remapping etcd peer address from %s to %s", peerAddress, address)
+	}
+
+	if sans, found, err := unstructured.NestedStringSlice(cfg, "spec", "api", "sans"); err != nil {
+		return err
+	} else if found {
+		for i, san := range sans {
+			switch {
+			case oldAddress != "" && san == oldAddress:
+				sans[i] = address
+			case oldHostname != "" && strings.EqualFold(san, oldHostname):
+				sans[i] = newHostname
+			}
+		}
+		if err := unstructured.SetNestedStringSlice(cfg, stringslice.Unique(sans), "spec", "api", "sans"); err != nil {
+			return err
+		}
+	}
+
+	if data, err = yaml.Marshal(cfg); err != nil {
+		return err
+	}
+	return os.WriteFile(configPath, data, 0600)
+}
+
+// removeIssuedCertificates removes all certificates, and their keys, that have
+// been issued by one of the CAs in the given directory. When k0s starts, it
+// issues all missing certificates for the node it's running on. Certificates
+// that haven't been issued by those CAs are kept, as k0s can't reissue them.
+func removeIssuedCertificates(pkiDir string) error {
+	type ca struct {
+		name   string
+		cert   *x509.Certificate
+		hasKey bool
+	}
+	var cas []ca
+	for _, name := range []string{"ca", "front-proxy-ca", "etcd/ca"} {
+		cert, err := readCertificate(filepath.Join(pkiDir, name+".crt"))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("CA certificate %s: %w", name, err)
+		}
+		cas = append(cas, ca{name, cert, file.Exists(filepath.Join(pkiDir, name+".key"))})
+	}
+
+	var issued []string
+	if err := filepath.WalkDir(pkiDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".crt" {
+			return err
+		}
+		name, err := filepath.Rel(pkiDir, path)
+		if err != nil {
+			return err
+		}
+		name = strings.TrimSuffix(name, ".crt")
+
+		cert, err := readCertificate(path)
+		if err != nil {
+			return fmt.Errorf("certificate %s: %w", name, err)
+		}
+		if cert.IsCA {
+			return nil
+		}
+		for _, ca := range cas {
+			if cert.CheckSignatureFrom(ca.cert) != nil {
+				continue
+			}
+			if !ca.hasKey {
+				return fmt.Errorf("certificate %s can't be regenerated, the key of CA certificate %s is missing", name, ca.name)
+			}
+			issued = append(issued, name)
+			return nil
+		}
+		logrus.Warnf("Certificate %s hasn't been issued by a k0s CA, it won't be regenerated", name)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, name := range issued {
+		logrus.Infof("Removing certificate %s, it will be regenerated when k0s starts", name)
+		for _, ext := range []string{".crt", ".key"} {
+			if err := os.Remove(filepath.Join(pkiDir, name+ext)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func readCertificate(path string) (*x509.Certificate, error) {
+	block, err := readPEMFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
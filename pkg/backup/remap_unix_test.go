@@ -0,0 +1,131 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/pkg/file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemapConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "k0s.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+apiVersion: k0s.k0sproject.io/v1beta1
+kind: ClusterConfig
+spec:
+  api:
+    address: 10.0.0.1
+    sans: [10.0.0.1, old-host, 10.0.0.100, new-host]
+  storage:
+    etcd:
+      peerAddress: 10.0.0.1
+`), 0600))
+
+	require.NoError(t, remapConfig(configPath, "10.0.0.2", "OLD-HOST", "new-host"))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.YAMLEq(t, `
+apiVersion: k0s.k0sproject.io/v1beta1
+kind: ClusterConfig
+spec:
+  api:
+    address: 10.0.0.2
+    sans: [10.0.0.2, new-host, 10.0.0.100]
+  storage:
+    etcd:
+      peerAddress: 10.0.0.2
+`, string(data))
+
+	t.Run("minimal", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(configPath, []byte("apiVersion: k0s.k0sproject.io/v1beta1\nkind: ClusterConfig\n"), 0600))
+		require.NoError(t, remapConfig(configPath, "10.0.0.2", "old-host", "new-host"))
+
+		data, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		assert.YAMLEq(t, `
+apiVersion: k0s.k0sproject.io/v1beta1
+kind: ClusterConfig
+spec:
+  api:
+    address: 10.0.0.2
+`, string(data))
+	})
+}
+
+func TestRemoveIssuedCertificates(t *testing.T) {
+	pkiDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(pkiDir, "etcd"), 0700))
+	ca, caKey := writeTestCertificate(t, filepath.Join(pkiDir, "ca"), nil, nil)
+	etcdCA, etcdCAKey := writeTestCertificate(t, filepath.Join(pkiDir, "etcd", "ca"), nil, nil)
+	writeTestCertificate(t, filepath.Join(pkiDir, "server"), ca, caKey)
+	writeTestCertificate(t, filepath.Join(pkiDir, "etcd", "peer"), etcdCA, etcdCAKey)
+	externalCA, externalCAKey := writeTestCertificate(t, filepath.Join(t.TempDir(), "ca"), nil, nil)
+	writeTestCertificate(t, filepath.Join(pkiDir, "custom"), externalCA, externalCAKey)
+
+	require.NoError(t, removeIssuedCertificates(pkiDir))
+
+	for _, name := range []string{"ca", "etcd/ca", "custom"} {
+		assert.FileExists(t, filepath.Join(pkiDir, name+".crt"))
+		assert.FileExists(t, filepath.Join(pkiDir, name+".key"))
+	}
+	for _, name := range []string{"server", "etcd/peer"} {
+		assert.False(t, file.Exists(filepath.Join(pkiDir, name+".crt")), "%s.crt", name)
+		assert.False(t, file.Exists(filepath.Join(pkiDir, name+".key")), "%s.key", name)
+	}
+
+	t.Run("missing_ca_key", func(t *testing.T) {
+		writeTestCertificate(t, filepath.Join(pkiDir, "server"), ca, caKey)
+		require.NoError(t, os.Remove(filepath.Join(pkiDir, "ca.key")))
+
+		err := removeIssuedCertificates(pkiDir)
+		assert.ErrorContains(t, err, "certificate server can't be regenerated, the key of CA certificate ca is missing")
+		assert.FileExists(t, filepath.Join(pkiDir, "server.crt"))
+	})
+}
+
+// writeTestCertificate writes a certificate and its key to the given path,
+// suffixed with .crt and .key. The certificate is a CA certificate if parent
+// is nil, otherwise it is issued by parent.
+func writeTestCertificate(t *testing.T, path string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: filepath.Base(path)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	if parent == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+		template.KeyUsage = x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path+".crt", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(path+".key", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600))
+	return cert, key
+}
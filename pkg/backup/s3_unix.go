@@ -0,0 +1,228 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/sirupsen/logrus"
+)
+
+// S3Options configures access to S3-compatible object storage. Empty fields
+// are taken from the standard AWS environment variables and shared
+// configuration files. Credentials are taken from the default AWS credential
+// chain, i.e. the environment, the shared credentials file, web identity
+// tokens or the EC2 instance metadata service.
+type S3Options struct {
+	// Endpoint is the URL of an S3-compatible object storage. Defaults to the
+	// AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL environment variables, or to AWS.
+	// Buckets are addressed in path-style if an endpoint is set.
+	Endpoint string
+	// Region is the region of the bucket. Defaults to the AWS_REGION or
+	// AWS_DEFAULT_REGION environment variables, to the region of the AWS
+	// profile, or to us-east-1.
+	Region string
+	// SSE is the server-side encryption to request when uploading backup
+	// archives, i.e. AES256 or aws:kms. No encryption is requested if empty.
+	SSE string
+	// SSEKMSKeyID is the ID of the KMS key to use for aws:kms encryption.
+	SSEKMSKeyID string
+}
+
+// s3Client uploads and downloads objects.
+type s3Client struct {
+	client  *s3.Client
+	options S3Options
+}
+
+// s3Location is the location of an object, or of a prefix, in a bucket.
+type s3Location struct {
+	bucket, key string
+}
+
+// IsS3URL returns true if the given path is an S3 URL, i.e. s3://bucket/key.
+func IsS3URL(path string) bool {
+	return strings.HasPrefix(path, "s3://")
+}
+
+func parseS3URL(rawURL string) (*s3Location, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "s3" || u.Host == "" {
+		return nil, fmt.Errorf("invalid S3 URL %q, expected s3://bucket/key", rawURL)
+	}
+	return &s3Location{bucket: u.Host, key: strings.TrimPrefix(u.Path, "/")}, nil
+}
+
+func newS3Client(ctx context.Context, options S3Options) (*s3Client, error) {
+	cfg, err := loadAWSConfig(ctx, options.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoint *url.URL
+	if rawEndpoint := cmpOrEnv(options.Endpoint, "AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL"); rawEndpoint != "" {
+		endpoint, err = url.Parse(rawEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid S3 endpoint: %w", err)
+		}
+		if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+			return nil, fmt.Errorf("invalid S3 endpoint %q, expected an http or https URL", rawEndpoint)
+		}
+	}
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if endpoint != nil {
+			o.BaseEndpoint = aws.String(endpoint.String())
+			o.UsePathStyle = true
+			// Not all S3-compatible object storages support the additional
+			// checksums that the SDK would send by default.
+			o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+			o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
+		}
+	})
+
+	return &s3Client{client, options}, nil
+}
+
+// loadAWSConfig loads the AWS configuration using the default credential
+// chain. The region defaults to the AWS_REGION or AWS_DEFAULT_REGION
+// environment variables, to the region of the AWS profile, or to us-east-1.
+func loadAWSConfig(ctx context.Context, region string) (aws.Config, error) {
+	var opts []func(*config.LoadOptions) error
+	if region := cmpOrEnv(region, "AWS_REGION", "AWS_DEFAULT_REGION"); region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	return cfg, nil
+}
+
+// cmpOrEnv returns value, or the first non-empty environment variable.
+func cmpOrEnv(value string, envVars ...string) string {
+	for _, envVar := range envVars {
+		if value != "" {
+			break
+		}
+		value = os.Getenv(envVar)
+	}
+	return value
+}
+
+// putObject uploads the given file to the given location. Large files are
+// uploaded in multiple parts.
+func (c *s3Client) putObject(ctx context.Context, loc *s3Location, filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(loc.bucket),
+		Key:         aws.String(loc.key),
+		Body:        f,
+		ContentType: aws.String("application/octet-stream"),
+	}
+	if strings.HasSuffix(filePath, ".gz") {
+		input.ContentType = aws.String("application/gzip")
+	}
+	if c.options.SSE != "" {
+		input.ServerSideEncryption = s3types.ServerSideEncryption(c.options.SSE)
+		if c.options.SSEKMSKeyID != "" {
+			input.SSEKMSKeyId = aws.String(c.options.SSEKMSKeyID)
+		}
+	}
+
+	//nolint:staticcheck // The transfermanager that supersedes it isn't stable yet.
+	_, err = manager.NewUploader(c.client).Upload(ctx, input)
+	return err
+}
+
+// getObject downloads the object at the given location to the given file.
+func (c *s3Client) getObject(ctx context.Context, loc *s3Location, filePath string) error {
+	resp, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(loc.bucket),
+		Key:    aws.String(loc.key),
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return errors.Join(err, f.Close())
+	}
+	return f.Close()
+}
+
+// UploadToS3 uploads the given file to the given S3 URL, which is treated as
+// a prefix for the file name. Returns the S3 URL of the uploaded object.
+func UploadToS3(ctx context.Context, options S3Options, s3URL, filePath string) (string, error) {
+	loc, err := parseS3URL(s3URL)
+	if err != nil {
+		return "", err
+	}
+	client, err := newS3Client(ctx, options)
+	if err != nil {
+		return "", err
+	}
+
+	loc.key = path.Join(loc.key, filepath.Base(filePath))
+	dest := "s3://" + loc.bucket + "/" + loc.key
+	logrus.Debugf("Uploading %s to %s", filePath, dest)
+	if err := client.putObject(ctx, loc, filePath); err != nil {
+		return "", fmt.Errorf("failed to upload %s to %s: %w", filepath.Base(filePath), dest, err)
+	}
+	return dest, nil
+}
+
+// downloadFromS3 downloads the backup archive at the given S3 URL to the
+// given file.
+func downloadFromS3(ctx context.Context, options S3Options, s3URL, archivePath string) error {
+	loc, err := parseS3URL(s3URL)
+	if err != nil {
+		return err
+	}
+	if loc.key == "" {
+		return fmt.Errorf("invalid S3 URL %q, expected the URL of a backup archive", s3URL)
+	}
+	client, err := newS3Client(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	logrus.Debugf("Downloading %s to %s", s3URL, archivePath)
+	if err := client.getObject(ctx, loc, archivePath); err != nil {
+		return fmt.Errorf("failed to download backup archive from %s: %w", s3URL, err)
+	}
+	return nil
+}
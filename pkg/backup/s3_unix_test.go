@@ -0,0 +1,134 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseS3URL(t *testing.T) {
+	loc, err := parseS3URL("s3://bucket/some/prefix")
+	require.NoError(t, err)
+	assert.Equal(t, &s3Location{"bucket", "some/prefix"}, loc)
+
+	loc, err = parseS3URL("s3://bucket")
+	require.NoError(t, err)
+	assert.Equal(t, &s3Location{"bucket", ""}, loc)
+
+	_, err = parseS3URL("s3:///key")
+	assert.ErrorContains(t, err, "expected s3://bucket/key")
+}
+
+func TestS3Client_PutAndGetObject(t *testing.T) {
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	var sseHeader string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=id/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			data, err := io.ReadAll(r.Body)
+			if !assert.NoError(t, err) {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			objects[r.URL.Path] = data
+			sseHeader = r.Header.Get("X-Amz-Server-Side-Encryption")
+		case http.MethodGet:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte("<Error><Code>NoSuchKey</Code></Error>"))
+				return
+			}
+			_, _ = w.Write(data)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	setTestAWSEnv(t)
+	underTest, err := newS3Client(t.Context(), S3Options{Endpoint: server.URL, SSE: "AES256"})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "k0s_backup.tar.gz")
+	require.NoError(t, os.WriteFile(archive, []byte("archive"), 0600))
+
+	require.NoError(t, underTest.putObject(t.Context(), &s3Location{"bucket", "prefix/k0s_backup.tar.gz"}, archive))
+	assert.Equal(t, []byte("archive"), objects["/bucket/prefix/k0s_backup.tar.gz"])
+	assert.Equal(t, "AES256", sseHeader)
+
+	downloaded := filepath.Join(dir, "downloaded.tar.gz")
+	require.NoError(t, underTest.getObject(t.Context(), &s3Location{"bucket", "prefix/k0s_backup.tar.gz"}, downloaded))
+	assert.FileExists(t, downloaded)
+	data, err := os.ReadFile(downloaded)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("archive"), data)
+
+	err = underTest.getObject(t.Context(), &s3Location{"bucket", "missing"}, downloaded)
+	assert.ErrorContains(t, err, "StatusCode: 404")
+	assert.ErrorContains(t, err, "NoSuchKey")
+}
+
+func TestNewS3Client(t *testing.T) {
+	setTestAWSEnv(t)
+
+	_, err := newS3Client(t.Context(), S3Options{Endpoint: "minio:9000"})
+	assert.ErrorContains(t, err, "expected an http or https URL")
+
+	t.Setenv("AWS_REGION", "eu-west-1")
+	underTest, err := newS3Client(t.Context(), S3Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-1", underTest.client.Options().Region)
+	assert.False(t, underTest.client.Options().UsePathStyle)
+
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_ENDPOINT_URL_S3", "http://minio:9000")
+	underTest, err = newS3Client(t.Context(), S3Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-1", underTest.client.Options().Region)
+	assert.True(t, underTest.client.Options().UsePathStyle)
+}
+
+// setTestAWSEnv isolates the AWS configuration from the host and provides
+// static credentials.
+func setTestAWSEnv(t *testing.T) {
+	dir := t.TempDir()
+	for name, value := range map[string]string{
+		"AWS_ACCESS_KEY_ID":           "id",
+		"AWS_SECRET_ACCESS_KEY":       "secret",
+		"AWS_SESSION_TOKEN":           "",
+		"AWS_PROFILE":                 "",
+		"AWS_REGION":                  "",
+		"AWS_DEFAULT_REGION":          "",
+		"AWS_ENDPOINT_URL":            "",
+		"AWS_ENDPOINT_URL_S3":         "",
+		"AWS_ENDPOINT_URL_KMS":        "",
+		"AWS_CONFIG_FILE":             filepath.Join(dir, "config"),
+		"AWS_SHARED_CREDENTIALS_FILE": filepath.Join(dir, "credentials"),
+		"AWS_EC2_METADATA_DISABLED":   "true",
+	} {
+		t.Setenv(name, value)
+	}
+}
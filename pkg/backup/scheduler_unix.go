@@ -0,0 +1,244 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/controller/leaderelector"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/component/status"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/leaderelection"
+
+	"github.com/robfig/cron"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	archivePrefix = "k0s_backup_"
+	archiveSuffix = ".tar.gz"
+)
+
+// Scheduler takes backups periodically on the leading controller, and removes
+// the oldest backup archives according to the configured retention.
+type Scheduler struct {
+	K0sVars       *config.CfgVars
+	NodeSpec      *v1beta1.ClusterSpec
+	LeaderElector leaderelector.Interface
+
+	log      *logrus.Entry
+	schedule cron.Schedule
+	stop     func()
+
+	mu     sync.Mutex
+	status status.BackupStatus
+}
+
+var _ manager.Component = (*Scheduler)(nil)
+
+// Init parses the backup schedule and determines the last successful backup
+// from the archives in the save path.
+func (s *Scheduler) Init(context.Context) error {
+	s.log = logrus.WithField("component", "backup-scheduler")
+
+	if s.NodeSpec.Storage.Etcd.IsExternalClusterUsed() {
+		return errors.New("scheduled backups are not supported for external etcd clusters")
+	}
+
+	spec := s.NodeSpec.Backup
+	schedule, err := cron.ParseStandard(spec.Schedule)
+	if err != nil {
+		return fmt.Errorf("invalid backup schedule: %w", err)
+	}
+	s.schedule = schedule
+	s.status.Schedule = spec.Schedule
+
+	if err := os.MkdirAll(spec.SavePath, 0700); err != nil {
+		return fmt.Errorf("failed to create backup save path: %w", err)
+	}
+
+	archives, err := listArchives(spec.SavePath)
+	if err != nil {
+		return err
+	}
+	if len(archives) > 0 {
+		latest := filepath.Join(spec.SavePath, archives[len(archives)-1])
+		if stat, err := os.Stat(latest); err == nil {
+			modTime := stat.ModTime()
+			s.status.LastSuccess, s.status.LastArchive = &modTime, latest
+		}
+	}
+
+	return nil
+}
+
+// Start takes backups according to the schedule whenever this controller is
+// the leader.
+func (s *Scheduler) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		leaderelection.RunLeaderTasks(ctx, s.LeaderElector.CurrentStatus, s.run)
+	}()
+
+	s.stop = func() { cancel(); <-done }
+	return nil
+}
+
+// Stop stops taking backups.
+func (s *Scheduler) Stop() error {
+	if s.stop != nil {
+		s.stop()
+	}
+	return nil
+}
+
+// BackupStatus returns the status of the scheduled backups.
+func (s *Scheduler) BackupStatus() *status.BackupStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	backupStatus := s.status
+	return &backupStatus
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	s.log.Info("Acquired leader lease, scheduling backups")
+	defer s.log.Info("Stopped to schedule backups")
+
+	for {
+		next := s.schedule.Next(time.Now())
+		s.log.Debug("Next backup at ", next)
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		archive, err := s.backup(ctx)
+
+		s.mu.Lock()
+		now := time.Now()
+		if err != nil {
+			s.log.WithError(err).Error("Failed to take scheduled backup")
+			s.status.LastFailure, s.status.LastError = &now, err.Error()
+		} else {
+			s.log.Info("Took scheduled backup ", archive)
+			s.status.LastSuccess, s.status.LastArchive = &now, archive
+		}
+		s.mu.Unlock()
+	}
+}
+
+// backup takes a backup and removes the oldest archives exceeding the
+// retention. It returns the path to the new archive.
+func (s *Scheduler) backup(ctx context.Context) (string, error) {
+	savePath := s.NodeSpec.Backup.SavePath
+
+	previous, err := listArchives(savePath)
+	if err != nil {
+		return "", err
+	}
+
+	mgr, err := NewBackupManager()
+	if err != nil {
+		return "", err
+	}
+	if err := mgr.RunBackup(ctx, s.NodeSpec, s.K0sVars, savePath, io.Discard); err != nil {
+		return "", err
+	}
+
+	archives, err := listArchives(savePath)
+	if err != nil {
+		return "", err
+	}
+	var archive string
+	for _, name := range archives {
+		if !slices.Contains(previous, name) {
+			archive = filepath.Join(savePath, name)
+		}
+	}
+
+	if err := pruneArchives(savePath, archives, s.NodeSpec.Backup.GetRetention()); err != nil {
+		return archive, fmt.Errorf("failed to remove old backup archives: %w", err)
+	}
+
+	return archive, nil
+}
+
+// listArchives returns the names of the backup archives in the given directory,
+// from the oldest to the newest one.
+func listArchives(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list backup archives: %w", err)
+	}
+
+	var archives []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.Type().IsRegular() && strings.HasPrefix(name, archivePrefix) && strings.HasSuffix(name, archiveSuffix) {
+			archives = append(archives, name)
+		}
+	}
+
+	// The archive names contain their timestamps, so that sorting them
+	// alphabetically sorts them chronologically, too.
+	slices.Sort(archives)
+	return archives, nil
+}
+
+// pruneArchives removes the oldest archives, so that only the given number of
+// archives are kept. Archives upon which kept incremental backups build are
+// kept, too.
+func pruneArchives(dir string, archives []string, keep int) error {
+	if len(archives) <= keep {
+		return nil
+	}
+
+	kept := archives[len(archives)-keep:]
+	var chains []string
+	for _, name := range kept {
+		manifest, err := readArchivedEtcdManifest(filepath.Join(dir, name))
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to read etcd backup manifest from ", name)
+		} else if manifest != nil {
+			chains = append(chains, manifest.Chain...)
+		}
+	}
+
+	var errs []error
+	for _, name := range archives[:len(archives)-keep] {
+		if slices.Contains(chains, name) {
+			logrus.Info("Keeping old backup archive ", name, ", as incremental backups build upon it")
+			continue
+		}
+		logrus.Info("Removing old backup archive ", name)
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,74 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAndPruneArchives(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"k0s_backup_2026-01-03T03_00_00_000Z.tar.gz",
+		"k0s_backup_2026-01-01T03_00_00_000Z.tar.gz",
+		"k0s_backup_2026-01-02T03_00_00_000Z.tar.gz",
+		"k0s_backup_2026-01-04T03_00_00_000Z.tar.gz",
+		"unrelated.tar.gz",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0600))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "k0s_backup_dir.tar.gz"), 0700))
+
+	archives, err := listArchives(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"k0s_backup_2026-01-01T03_00_00_000Z.tar.gz",
+		"k0s_backup_2026-01-02T03_00_00_000Z.tar.gz",
+		"k0s_backup_2026-01-03T03_00_00_000Z.tar.gz",
+		"k0s_backup_2026-01-04T03_00_00_000Z.tar.gz",
+	}, archives)
+
+	require.NoError(t, pruneArchives(dir, archives, 5))
+	archives, err = listArchives(dir)
+	require.NoError(t, err)
+	assert.Len(t, archives, 4)
+
+	require.NoError(t, pruneArchives(dir, archives, 2))
+	archives, err = listArchives(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"k0s_backup_2026-01-03T03_00_00_000Z.tar.gz",
+		"k0s_backup_2026-01-04T03_00_00_000Z.tar.gz",
+	}, archives)
+	assert.FileExists(t, filepath.Join(dir, "unrelated.tar.gz"))
+
+	archives, err = listArchives(filepath.Join(dir, "nonexistent"))
+	assert.NoError(t, err)
+	assert.Empty(t, archives)
+}
+
+func TestPruneArchives_KeepsChains(t *testing.T) {
+	dir := t.TempDir()
+	full := "k0s_backup_2026-01-01T03_00_00_000Z.tar.gz"
+	delta := "k0s_backup_2026-01-02T03_00_00_000Z.tar.gz"
+	writeTestArchive(t, dir, "k0s_backup_2025-12-31T03_00_00_000Z.tar.gz", &etcdBackupManifest{Revision: 1}, nil)
+	writeTestArchive(t, dir, full, &etcdBackupManifest{Revision: 2}, nil)
+	writeTestArchive(t, dir, delta, &etcdBackupManifest{Revision: 3, Chain: []string{full}}, nil)
+	writeTestArchive(t, dir, "k0s_backup_2026-01-03T03_00_00_000Z.tar.gz", &etcdBackupManifest{Revision: 4, Chain: []string{full, delta}}, nil)
+
+	archives, err := listArchives(dir)
+	require.NoError(t, err)
+	require.NoError(t, pruneArchives(dir, archives, 1))
+
+	archives, err = listArchives(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{full, delta, "k0s_backup_2026-01-03T03_00_00_000Z.tar.gz"}, archives)
+}
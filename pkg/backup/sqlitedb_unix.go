@@ -73,3 +73,12 @@ func (s *sqliteStep) Restore(restoreFrom string, _ string) error {
 	}
 	return nil
 }
+
+func (s *sqliteStep) DryRunRestore(restoreFrom, _ string, r *RestoreReport) {
+	if !file.Exists(filepath.Join(restoreFrom, kineBackup)) {
+		r.errorf("sqlite snapshot not found in the backup archive")
+	}
+	if file.Exists(s.dbPath) {
+		r.conflictf("%s would be overwritten", s.dbPath)
+	}
+}
@@ -7,7 +7,9 @@ package backup
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"os"
@@ -20,6 +22,10 @@ import (
 
 const timeStampLayout = "2006-01-02T15_04_05_000Z"
 
+// checksumsFile is the name of the file in the backup archive that contains
+// the SHA-256 checksums of all other files, in the format of sha256sum.
+const checksumsFile = "SHA256SUMS"
+
 // createArchive compresses and adds files to the backup archive file
 func createArchive(archive io.Writer, files []string, baseDir string) error {
 	gw := gzip.NewWriter(archive)
@@ -28,16 +34,31 @@ func createArchive(archive io.Writer, files []string, baseDir string) error {
 	defer tw.Close()
 
 	// Iterate over files and add them to the tar archive
+	var checksums bytes.Buffer
 	for _, file := range files {
-		err := addToArchive(tw, file, baseDir)
+		err := addToArchive(tw, file, baseDir, &checksums)
 		if err != nil {
 			return fmt.Errorf("failed to add file to backup archive: %w", err)
 		}
 	}
+
+	// The checksums come last, so that the archive can be streamed.
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     checksumsFile,
+		Size:     int64(checksums.Len()),
+		Mode:     0600,
+		ModTime:  time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to write checksums header to archive: %w", err)
+	}
+	if _, err := tw.Write(checksums.Bytes()); err != nil {
+		return fmt.Errorf("failed to write checksums to archive: %w", err)
+	}
 	return nil
 }
 
-func addToArchive(tw *tar.Writer, filename string, baseDir string) error {
+func addToArchive(tw *tar.Writer, filename string, baseDir string, checksums io.Writer) error {
 	// Open the file which will be written into the archive
 	file, err := os.Open(filename)
 	if err != nil {
@@ -73,10 +94,14 @@ func addToArchive(tw *tar.Writer, filename string, baseDir string) error {
 
 	if !dir.IsDirectory(filename) {
 		// Copy file content to tar archive
-		_, err = io.Copy(tw, file)
+		h := sha256.New()
+		_, err = io.Copy(io.MultiWriter(tw, h), file)
 		if err != nil {
 			return fmt.Errorf("failed to copy file contents info archive: %w", err)
 		}
+		if _, err := fmt.Fprintf(checksums, "%x  %s\n", h.Sum(nil), header.Name); err != nil {
+			return err
+		}
 	}
 	return nil
 }
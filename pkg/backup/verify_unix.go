@@ -0,0 +1,332 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"bufio"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/pkg/file"
+
+	"github.com/dustin/go-humanize"
+	"github.com/rqlite/rqlite/db"
+	utilsnapshot "go.etcd.io/etcd/etcdutl/v3/snapshot"
+	"go.uber.org/zap"
+)
+
+// Verify unpacks the given backup archive into a temporary directory and
+// validates it, without restoring anything: the archive can be unpacked and
+// decrypted, the files match the checksums embedded in the archive, the
+// datastore snapshot is intact, and the certificates match their keys.
+func (bm *Manager) Verify(ctx context.Context, archivePath string) *RestoreReport {
+	var report RestoreReport
+	defer os.RemoveAll(bm.tmpDir)
+
+	if err := bm.unpack(ctx, archivePath); err != nil {
+		report.errorf("%v", err)
+		return &report
+	}
+	report.passedf("The archive has been unpacked")
+
+	bm.readMetadata(&report)
+	verifyChecksums(bm.tmpDir, &report)
+
+	if _, err := bm.getConfigForRestore(); err != nil {
+		report.errorf("failed to parse backed-up configuration file: %v", err)
+	} else {
+		report.passedf("The k0s configuration is valid")
+	}
+
+	bm.verifyEtcdSnapshot(&report)
+	verifyKine(bm.tmpDir, &report)
+
+	if pkiDir := filepath.Join(bm.tmpDir, "pki"); !file.Exists(pkiDir) {
+		report.errorf("The backup archive doesn't contain any certificates")
+	} else {
+		checkCAs(pkiDir, &report)
+		verifyKeyPairs(pkiDir, &report)
+	}
+
+	return &report
+}
+
+// verifyChecksums verifies the files in dir against the checksums file.
+func verifyChecksums(dir string, r *RestoreReport) {
+	f, err := os.Open(filepath.Join(dir, checksumsFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		r.warnf("The backup archive doesn't contain any checksums, it has been created by an older version of k0s")
+		return
+	}
+	if err != nil {
+		r.errorf("failed to read checksums: %v", err)
+		return
+	}
+	defer f.Close()
+
+	expected := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		sum, name, ok := strings.Cut(scanner.Text(), "  ")
+		if !ok {
+			r.errorf("invalid checksums line %q", scanner.Text())
+			return
+		}
+		expected[name] = sum
+	}
+	if err := scanner.Err(); err != nil {
+		r.errorf("failed to read checksums: %v", err)
+		return
+	}
+
+	mismatches := len(r.Errors)
+	if err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil || name == checksumsFile {
+			return err
+		}
+		sum, ok := expected[name]
+		if !ok {
+			r.errorf("%s isn't covered by the checksums", name)
+			return nil
+		}
+		delete(expected, name)
+
+		actual, err := sha256File(path)
+		if err != nil {
+			return err
+		}
+		if actual != sum {
+			r.errorf("checksum mismatch for %s", name)
+		}
+		return nil
+	}); err != nil {
+		r.errorf("failed to verify checksums: %v", err)
+		return
+	}
+	for name := range expected {
+		r.errorf("%s is missing in the backup archive", name)
+	}
+
+	if len(r.Errors) == mismatches {
+		r.passedf("The checksums of all files match")
+	}
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verifyEtcdSnapshot verifies the hash of the etcd snapshot, along with the
+// deltas for incremental backups, and checks the snapshot's database.
+func (bm *Manager) verifyEtcdSnapshot(r *RestoreReport) {
+	snapshotPath := filepath.Join(bm.tmpDir, etcdBackup)
+
+	manifest, err := readEtcdManifest(bm.tmpDir)
+	if err != nil {
+		r.errorf("%v", err)
+		return
+	}
+	if manifest != nil && len(manifest.Chain) > 0 {
+		if bm.archiveDir == "" {
+			r.errorf("incremental backups can only be verified from local archives")
+			return
+		}
+		if err := verifyEtcdChain(bm.archiveDir, bm.tmpDir, manifest, snapshotPath); err != nil {
+			r.errorf("failed to verify incremental etcd backup: %v", err)
+			return
+		}
+		r.passedf("The %d etcd deltas can be read", len(manifest.Chain))
+	} else if !file.Exists(snapshotPath) {
+		return // Not an etcd backup.
+	} else if _, hasHash, err := verifySnapshotHash(snapshotPath); err != nil {
+		r.errorf("failed to verify etcd snapshot: %v", err)
+		return
+	} else if !hasHash {
+		r.warnf("The etcd snapshot has no integrity hash")
+	} else if err := stripSnapshotHash(snapshotPath); err != nil {
+		r.errorf("failed to verify etcd snapshot: %v", err)
+		return
+	}
+
+	status, err := utilsnapshot.NewV3(zap.NewNop()).Status(snapshotPath)
+	if err != nil {
+		r.errorf("invalid etcd snapshot: %v", err)
+		return
+	}
+	r.passedf("The etcd snapshot is valid: revision %d, %d keys, %s", status.Revision, status.TotalKey, humanize.IBytes(uint64(status.TotalSize)))
+}
+
+// verifyKine checks the integrity of the kine SQLite database snapshot, or
+// that the kine database dump can be decoded.
+func verifyKine(dir string, r *RestoreReport) {
+	if snapshotPath := filepath.Join(dir, kineBackup); file.Exists(snapshotPath) {
+		kineDB, err := db.Open(snapshotPath)
+		if err != nil {
+			r.errorf("failed to open kine SQLite snapshot: %v", err)
+			return
+		}
+		defer kineDB.Close()
+
+		rows, err := kineDB.Query([]string{"PRAGMA integrity_check"}, false, false)
+		switch {
+		case err != nil:
+			r.errorf("failed to check kine SQLite snapshot: %v", err)
+		case rows[0].Error != "":
+			r.errorf("failed to check kine SQLite snapshot: %s", rows[0].Error)
+		case len(rows[0].Values) != 1 || fmt.Sprint(rows[0].Values[0]...) != "ok":
+			r.errorf("The kine SQLite snapshot is corrupt: %v", rows[0].Values)
+		default:
+			r.passedf("The kine SQLite snapshot is intact")
+		}
+	}
+
+	if dumpPath := filepath.Join(dir, kineDump); file.Exists(dumpPath) {
+		f, err := os.Open(dumpPath)
+		if err != nil {
+			r.errorf("failed to open kine database dump: %v", err)
+			return
+		}
+		defer f.Close()
+
+		var rows int
+		for dec := json.NewDecoder(bufio.NewReader(f)); ; rows++ {
+			var row kineRow
+			if err := dec.Decode(&row); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				r.errorf("invalid kine database dump: %v", err)
+				return
+			}
+		}
+		r.passedf("The kine database dump is valid: %d rows", rows)
+	}
+}
+
+// verifyKeyPairs checks that all certificates in the given directory match
+// their private keys, and that the service account keys match.
+func verifyKeyPairs(pkiDir string, r *RestoreReport) {
+	var pairs int
+	errs := len(r.Errors)
+	if err := filepath.WalkDir(pkiDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".crt" {
+			return err
+		}
+		name, err := filepath.Rel(pkiDir, path)
+		if err != nil {
+			return err
+		}
+		name = strings.TrimSuffix(name, ".crt")
+
+		keyPath := strings.TrimSuffix(path, ".crt") + ".key"
+		if !file.Exists(keyPath) {
+			return nil
+		}
+		pairs++
+		pair, err := tls.LoadX509KeyPair(path, keyPath)
+		if err != nil {
+			r.errorf("certificate %s doesn't match its key: %v", name, err)
+			return nil
+		}
+		if time.Now().After(pair.Leaf.NotAfter) {
+			r.warnf("Certificate %s expired at %s", name, pair.Leaf.NotAfter.Format(time.RFC3339))
+		}
+		return nil
+	}); err != nil {
+		r.errorf("failed to check certificates: %v", err)
+		return
+	}
+
+	saKey, saPub := filepath.Join(pkiDir, "sa.key"), filepath.Join(pkiDir, "sa.pub")
+	if file.Exists(saKey) && file.Exists(saPub) {
+		pairs++
+		if err := verifyPublicKey(saKey, saPub); err != nil {
+			r.errorf("service account key doesn't match its public key: %v", err)
+		}
+	}
+
+	if len(r.Errors) == errs {
+		r.passedf("All %d certificates and keys match", pairs)
+	}
+}
+
+// verifyPublicKey checks that the PEM encoded public key matches the PEM
+// encoded private key.
+func verifyPublicKey(privateKeyPath, publicKeyPath string) error {
+	privateKeyBlock, err := readPEMFile(privateKeyPath)
+	if err != nil {
+		return err
+	}
+	var privateKey any
+	switch privateKeyBlock.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(privateKeyBlock.Bytes)
+	case "EC PRIVATE KEY":
+		privateKey, err = x509.ParseECPrivateKey(privateKeyBlock.Bytes)
+	default:
+		privateKey, err = x509.ParsePKCS8PrivateKey(privateKeyBlock.Bytes)
+	}
+	if err != nil {
+		return err
+	}
+
+	publicKeyBlock, err := readPEMFile(publicKeyPath)
+	if err != nil {
+		return err
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(publicKeyBlock.Bytes)
+	if err != nil {
+		return err
+	}
+
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return fmt.Errorf("unsupported private key type %T", privateKey)
+	}
+	if equal, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool }); !ok || !equal.Equal(publicKey) {
+		return errors.New("key mismatch")
+	}
+	return nil
+}
+
+func readPEMFile(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", filepath.Base(path))
+	}
+	return block, nil
+}
@@ -0,0 +1,125 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/pkg/archive"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyChecksums(t *testing.T) {
+	dir := t.TempDir()
+	writeTestArchive(t, dir, "archive.tar.gz", nil, map[string][]byte{
+		"k0s.yaml":         []byte("apiVersion: k0s.k0sproject.io/v1beta1"),
+		"etcd-snapshot.db": []byte("snapshot"),
+	})
+
+	unpack := func(t *testing.T) string {
+		f, err := os.Open(filepath.Join(dir, "archive.tar.gz"))
+		require.NoError(t, err)
+		defer f.Close()
+		unpacked := t.TempDir()
+		require.NoError(t, archive.Extract(f, unpacked))
+		return unpacked
+	}
+
+	t.Run("intact", func(t *testing.T) {
+		var report RestoreReport
+		verifyChecksums(unpack(t), &report)
+		assert.Empty(t, report.Errors)
+		assert.Equal(t, []string{"The checksums of all files match"}, report.Passed)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		unpacked := unpack(t)
+		require.NoError(t, os.WriteFile(filepath.Join(unpacked, "etcd-snapshot.db"), []byte("tampered"), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(unpacked, "extra"), nil, 0600))
+		require.NoError(t, os.Remove(filepath.Join(unpacked, "k0s.yaml")))
+
+		var report RestoreReport
+		verifyChecksums(unpacked, &report)
+		assert.ElementsMatch(t, []string{
+			"checksum mismatch for etcd-snapshot.db",
+			"extra isn't covered by the checksums",
+			"k0s.yaml is missing in the backup archive",
+		}, report.Errors)
+		assert.Empty(t, report.Passed)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		unpacked := unpack(t)
+		require.NoError(t, os.Remove(filepath.Join(unpacked, checksumsFile)))
+
+		var report RestoreReport
+		verifyChecksums(unpacked, &report)
+		assert.Empty(t, report.Errors)
+		assert.Len(t, report.Warnings, 1)
+	})
+}
+
+func TestVerifyKeyPairs(t *testing.T) {
+	pkiDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(pkiDir, "etcd"), 0700))
+	key := writeTestKeyPair(t, filepath.Join(pkiDir, "ca"))
+	writeTestKeyPair(t, filepath.Join(pkiDir, "etcd", "ca"))
+	publicKey, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	require.NoError(t, os.Rename(filepath.Join(pkiDir, "ca.key"), filepath.Join(pkiDir, "sa.key")))
+	require.NoError(t, os.WriteFile(filepath.Join(pkiDir, "sa.pub"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), 0600))
+	writeTestKeyPair(t, filepath.Join(pkiDir, "ca"))
+
+	var report RestoreReport
+	verifyKeyPairs(pkiDir, &report)
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, []string{"All 3 certificates and keys match"}, report.Passed)
+
+	// Swap the keys.
+	require.NoError(t, os.Rename(filepath.Join(pkiDir, "ca.key"), filepath.Join(pkiDir, "tmp.key")))
+	require.NoError(t, os.Rename(filepath.Join(pkiDir, "etcd", "ca.key"), filepath.Join(pkiDir, "ca.key")))
+	require.NoError(t, os.Rename(filepath.Join(pkiDir, "tmp.key"), filepath.Join(pkiDir, "sa.key")))
+
+	report = RestoreReport{}
+	verifyKeyPairs(pkiDir, &report)
+	assert.Len(t, report.Errors, 2)
+	assert.Contains(t, report.Errors[0], "certificate ca doesn't match its key")
+	assert.Contains(t, report.Errors[1], "service account key doesn't match its public key")
+	assert.Empty(t, report.Passed)
+}
+
+// writeTestKeyPair writes a self-signed certificate and its key to the given
+// path, suffixed with .crt and .key.
+func writeTestKeyPair(t *testing.T, path string) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: filepath.Base(path)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, &template, &template, key.Public(), key)
+	require.NoError(t, err)
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path+".crt", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), 0600))
+	require.NoError(t, os.WriteFile(path+".key", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600))
+	return key
+}
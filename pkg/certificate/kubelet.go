@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package certificate
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// The usages of the kubelet's certificates.
+const (
+	KubeletClientCertificate  = "client"
+	KubeletServingCertificate = "serving"
+)
+
+// The kubelet rotates its certificates at a random point in time between 70
+// and 90 percent of their lifetime.
+const kubeletRotationDeadline = 0.9
+
+// KubeletCertificate describes a certificate that's used by the kubelet.
+type KubeletCertificate struct {
+	// Usage is either [KubeletClientCertificate] or
+	// [KubeletServingCertificate].
+	Usage     string
+	Path      string
+	NotBefore time.Time
+	NotAfter  time.Time
+	// Rotated indicates whether the kubelet rotates the certificate. Self
+	// signed serving certificates aren't rotated.
+	Rotated bool
+}
+
+// RotationOverdue reports whether the kubelet should have rotated the
+// certificate by the given time. It's a strong indication that the rotation
+// is failing, e.g. because the kubelet can't reach the API server.
+func (c *KubeletCertificate) RotationOverdue(now time.Time) bool {
+	if !c.Rotated {
+		return false
+	}
+	lifetime := c.NotAfter.Sub(c.NotBefore)
+	deadline := c.NotBefore.Add(time.Duration(float64(lifetime) * kubeletRotationDeadline))
+	return now.After(deadline)
+}
+
+// LoadKubeletCertificates loads the certificates from the kubelet's
+// certificate directory. Certificates that don't exist are omitted.
+func LoadKubeletCertificates(certDir string) ([]KubeletCertificate, error) {
+	var certs []KubeletCertificate
+	for _, candidate := range []struct {
+		usage, name string
+		rotated     bool
+	}{
+		{KubeletClientCertificate, "kubelet-client-current.pem", true},
+		// The kubelet requests its serving certificate from the API server if
+		// serverTLSBootstrap is enabled, and signs it on its own otherwise.
+		{KubeletServingCertificate, "kubelet-server-current.pem", true},
+		{KubeletServingCertificate, "kubelet.crt", false},
+	} {
+		if len(certs) > 0 && certs[len(certs)-1].Usage == candidate.usage {
+			continue
+		}
+
+		path := filepath.Join(certDir, candidate.name)
+		cert, err := loadFirstCertificate(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load kubelet %s certificate: %w", candidate.usage, err)
+		}
+
+		certs = append(certs, KubeletCertificate{
+			Usage:     candidate.usage,
+			Path:      path,
+			NotBefore: cert.NotBefore,
+			NotAfter:  cert.NotAfter,
+			Rotated:   candidate.rotated,
+		})
+	}
+	return certs, nil
+}
+
+// loadFirstCertificate parses the first certificate found in the given PEM
+// file. The kubelet stores the private key in the same file.
+func loadFirstCertificate(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no certificate found in %s", path)
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadKubeletCertificates(t *testing.T) {
+	notBefore := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := notBefore.Add(100 * time.Hour)
+
+	t.Run("empty", func(t *testing.T) {
+		certs, err := LoadKubeletCertificates(t.TempDir())
+		require.NoError(t, err)
+		assert.Empty(t, certs)
+	})
+
+	t.Run("self_signed_serving", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestCertificate(t, filepath.Join(dir, "kubelet-client-current.pem"), notBefore, notAfter, true)
+		writeTestCertificate(t, filepath.Join(dir, "kubelet.crt"), notBefore, notAfter, false)
+
+		certs, err := LoadKubeletCertificates(dir)
+		require.NoError(t, err)
+		assert.Equal(t, []KubeletCertificate{{
+			Usage:     KubeletClientCertificate,
+			Path:      filepath.Join(dir, "kubelet-client-current.pem"),
+			NotBefore: notBefore,
+			NotAfter:  notAfter,
+			Rotated:   true,
+		}, {
+			Usage:     KubeletServingCertificate,
+			Path:      filepath.Join(dir, "kubelet.crt"),
+			NotBefore: notBefore,
+			NotAfter:  notAfter,
+		}}, certs)
+	})
+
+	t.Run("bootstrapped_serving", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestCertificate(t, filepath.Join(dir, "kubelet-server-current.pem"), notBefore, notAfter, true)
+		writeTestCertificate(t, filepath.Join(dir, "kubelet.crt"), notBefore, notAfter, false)
+
+		certs, err := LoadKubeletCertificates(dir)
+		require.NoError(t, err)
+		require.Len(t, certs, 1)
+		assert.Equal(t, KubeletServingCertificate, certs[0].Usage)
+		assert.Equal(t, filepath.Join(dir, "kubelet-server-current.pem"), certs[0].Path)
+		assert.True(t, certs[0].Rotated)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "kubelet-client-current.pem"), []byte("garbage"), 0600))
+
+		_, err := LoadKubeletCertificates(dir)
+		assert.ErrorContains(t, err, "failed to load kubelet client certificate: no certificate found in ")
+	})
+}
+
+func TestKubeletCertificate_RotationOverdue(t *testing.T) {
+	notBefore := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cert := KubeletCertificate{NotBefore: notBefore, NotAfter: notBefore.Add(100 * time.Hour), Rotated: true}
+
+	assert.False(t, cert.RotationOverdue(notBefore.Add(89*time.Hour)))
+	assert.True(t, cert.RotationOverdue(notBefore.Add(91*time.Hour)))
+
+	cert.Rotated = false
+	assert.False(t, cert.RotationOverdue(notBefore.Add(91*time.Hour)), "not rotated")
+}
+
+// writeTestCertificate writes a self-signed certificate to the given path,
+// optionally preceded by its private key, the way the kubelet does.
+func writeTestCertificate(t *testing.T, path string, notBefore, notAfter time.Time, withKey bool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: notBefore, NotAfter: notAfter}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	var data []byte
+	if withKey {
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+		data = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	}
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
@@ -204,7 +204,7 @@ func (m *Manager) regenerateCert(keyFile string, certFile string) bool {
 		return true
 	}
 
-	if isManagedByK0s(cert) {
+	if IsManagedByK0s(cert) {
 		return true
 	}
 
@@ -212,8 +212,9 @@ func (m *Manager) regenerateCert(keyFile string, certFile string) bool {
 	return false
 }
 
-// checks if the cert issuer (CA) is a k0s setup one
-func isManagedByK0s(cert *certinfo.Certificate) bool {
+// IsManagedByK0s checks if the cert issuer (CA) is a k0s setup one. Such
+// certificates are regenerated whenever k0s starts.
+func IsManagedByK0s(cert *certinfo.Certificate) bool {
 	switch cert.Issuer.CommonName {
 	case "kubernetes-ca":
 		return true
@@ -1,4 +1,4 @@
-//go:build linux
+//go:build linux || windows
 
 // SPDX-FileCopyrightText: 2021 k0s authors
 // SPDX-License-Identifier: Apache-2.0
@@ -8,94 +8,169 @@ package cleanup
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
-	"github.com/k0sproject/k0s/pkg/component/worker"
-	workerconfig "github.com/k0sproject/k0s/pkg/component/worker/config"
-	"github.com/k0sproject/k0s/pkg/component/worker/containerd"
 	"github.com/k0sproject/k0s/pkg/config"
-	"github.com/k0sproject/k0s/pkg/constant"
-	"github.com/k0sproject/k0s/pkg/container/runtime"
 
 	"github.com/sirupsen/logrus"
 )
 
+// The kinds of state that can be preserved during cleanup.
+const (
+	// PreserveCNI preserves the CNI configuration and network interfaces.
+	PreserveCNI = "cni"
+	// PreserveImages preserves the images of the containerd managed by k0s.
+	PreserveImages = "images"
+	// PreserveEtcd preserves the etcd data.
+	PreserveEtcd = "etcd"
+	// PreserveManifests preserves the manifests of the stack applier.
+	PreserveManifests = "manifests"
+)
+
+// PreservableStates are all the kinds of state that can be preserved.
+var PreservableStates = []string{PreserveCNI, PreserveImages, PreserveEtcd, PreserveManifests}
+
 type Config struct {
-	cleanupSteps []Step
+	cleanupSteps []namedStep
+	preserve     []string
 }
 
-func NewConfig(debug bool, k0sVars *config.CfgVars, systemUsers *k0sv1beta1.SystemUser, criSocketFlag string) (*Config, error) {
-	containers, err := newContainersStep(debug, k0sVars, criSocketFlag)
+// NewConfig creates the cleanup config. The given kinds of state are preserved,
+// i.e. the steps cleaning them up are skipped, and their directories are kept.
+// If purge is set, the leftovers of the k0s services and users are removed, as
+// well. If purgePodLogs is set, the logs of the pods are removed, too.
+func NewConfig(debug bool, k0sVars *config.CfgVars, systemUsers *k0sv1beta1.SystemUser, criSocketFlag string, preserve []string, purge, purgePodLogs bool) (*Config, error) {
+	for _, state := range preserve {
+		if !slices.Contains(PreservableStates, state) {
+			return nil, fmt.Errorf("unknown state to preserve: %q (valid values: %s)", state, strings.Join(PreservableStates, ", "))
+		}
+	}
+
+	preservedDirs := map[string]string{
+		PreserveImages:    filepath.Join(k0sVars.DataDir, "containerd"),
+		PreserveEtcd:      k0sVars.EtcdDataDir,
+		PreserveManifests: k0sVars.ManifestsDir,
+	}
+
+	directories := &directories{
+		dataDir:        k0sVars.DataDir,
+		kubeletRootDir: k0sVars.KubeletRootDir,
+		runDir:         k0sVars.RunDir,
+	}
+	for _, state := range preserve {
+		if dir, ok := preservedDirs[state]; ok {
+			directories.preserved = append(directories.preserved, dir)
+		}
+	}
+
+	builtinSteps, err := newSteps(debug, k0sVars, systemUsers, criSocketFlag, directories, purge, purgePodLogs)
 	if err != nil {
 		return nil, err
 	}
 
-	cleanupSteps := []Step{
-		containers,
-		&users{systemUsers: systemUsers},
-		&services{},
-		&directories{
-			dataDir:        k0sVars.DataDir,
-			kubeletRootDir: k0sVars.KubeletRootDir,
-			runDir:         k0sVars.RunDir,
-		},
-		&cni{},
+	cleanupSteps, err := addRegisteredSteps(builtinSteps, k0sVars)
+	if err != nil {
+		return nil, err
 	}
 
-	if bridge := newBridgeStep(); bridge != nil {
-		cleanupSteps = append(cleanupSteps, bridge)
-	}
+	return &Config{cleanupSteps, preserve}, nil
+}
 
-	return &Config{cleanupSteps}, nil
+// statefulStep is implemented by steps that clean up state that can be
+// preserved.
+type statefulStep interface {
+	// State returns the kind of state that the step cleans up.
+	State() string
 }
 
-func (c *Config) Cleanup() error {
+// preservedState returns the kind of preserved state that the step cleans up,
+// if any, in which case the step is skipped.
+func (c *Config) preservedState(step Step) string {
+	if s, ok := step.(statefulStep); ok && slices.Contains(c.preserve, s.State()) {
+		return s.State()
+	}
+	return ""
+}
+
+// Cleanup runs all cleanup steps and returns their results. If recordChanges
+// is set, the changes that each step is about to make are determined right
+// before it runs, and are included in its result.
+func (c *Config) Cleanup(recordChanges bool) (*Result, error) {
+	result := Result{Success: true}
 	var errs []error
 
 	for _, step := range c.cleanupSteps {
+		stepResult := StepResult{Name: step.name, Description: step.Name()}
+
+		if state := c.preservedState(step.Step); state != "" {
+			logrus.Debugf("Skipping %s, preserving %s state", step.Name(), state)
+			stepResult.Status = StepSkipped
+			stepResult.Reason = "preserving " + state + " state"
+			result.Steps = append(result.Steps, stepResult)
+			continue
+		}
+
+		if recordChanges {
+			var changes Report
+			if err := step.DryRun(&changes); err != nil {
+				logrus.WithError(err).Debug("Failed to determine the changes of ", step.Name())
+			}
+			stepResult.Changes = &changes
+		}
+
 		logrus.Info("* ", step.Name())
-		err := step.Run()
-		if err != nil {
+		if err := step.Run(); err != nil {
 			logrus.Debug(err)
 			errs = append(errs, err)
+			stepResult.Status = StepFailed
+			stepResult.Error = err.Error()
+			result.Success = false
+		} else {
+			stepResult.Status = StepSucceeded
 		}
+		result.Steps = append(result.Steps, stepResult)
 	}
 	if len(errs) > 0 {
-		return fmt.Errorf("errors occurred during clean-up: %w", errors.Join(errs...))
+		return &result, fmt.Errorf("errors occurred during clean-up: %w", errors.Join(errs...))
 	}
-	return nil
+	return &result, nil
 }
 
-func newContainersStep(debug bool, k0sVars *config.CfgVars, criSocketFlag string) (*containers, error) {
-	runtimeEndpoint, err := worker.GetContainerRuntimeEndpoint(criSocketFlag, k0sVars.RunDir)
-	if err != nil {
-		return nil, err
-	}
+// DryRun runs all cleanup steps in read-only mode and reports the changes
+// that they would make to the host. A partial report is returned along with
+// any errors that occurred.
+func (c *Config) DryRun() (*Report, error) {
+	var report Report
+	var errs []error
 
-	containers := containers{
-		containerRuntime: runtime.NewContainerRuntime(runtimeEndpoint),
-	}
+	for _, step := range c.cleanupSteps {
+		if state := c.preservedState(step.Step); state != "" {
+			logrus.Debugf("Skipping %s, preserving %s state", step.Name(), state)
+			continue
+		}
 
-	if criSocketFlag == "" {
-		logLevel := "error"
-		if debug {
-			logLevel = "debug"
+		logrus.Debug("* ", step.Name())
+		if err := step.DryRun(&report); err != nil {
+			logrus.Debug(err)
+			errs = append(errs, err)
 		}
-		containers.managedContainerd = containerd.NewComponent(logLevel, k0sVars, &workerconfig.Profile{
-			PauseImage: &k0sv1beta1.ImageSpec{
-				Image:   constant.KubePauseContainerImage,
-				Version: constant.KubePauseContainerImageVersion,
-			},
-		})
 	}
-
-	return &containers, nil
+	if len(errs) > 0 {
+		return &report, fmt.Errorf("errors occurred during clean-up dry-run: %w", errors.Join(errs...))
+	}
+	return &report, nil
 }
 
 // Step interface is used to implement cleanup steps
 type Step interface {
 	// Run impelements specific cleanup operations
 	Run() error
+	// DryRun adds the changes that Run would make to the host to the report,
+	// without making them.
+	DryRun(*Report) error
 	// Name returns name of the step for conveninece
 	Name() string
 }
@@ -0,0 +1,54 @@
+//go:build linux || windows
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type resultStep struct {
+	name  string
+	state string
+	err   error
+}
+
+func (s *resultStep) Name() string  { return s.name }
+func (s *resultStep) State() string { return s.state }
+func (s *resultStep) Run() error    { return s.err }
+func (s *resultStep) DryRun(r *Report) error {
+	r.Directories = append(r.Directories, "/"+s.name)
+	return nil
+}
+
+func TestConfig_Cleanup(t *testing.T) {
+	cfg := Config{
+		cleanupSteps: []namedStep{
+			{name: "ok", Step: &resultStep{name: "ok step"}},
+			{name: "preserved", Step: &resultStep{name: "preserved step", state: PreserveCNI}},
+			{name: "failing", Step: &resultStep{name: "failing step", err: errors.New("boom")}},
+		},
+		preserve: []string{PreserveCNI},
+	}
+
+	result, err := cfg.Cleanup(true)
+	assert.ErrorContains(t, err, "boom")
+	require.NotNil(t, result)
+	assert.False(t, result.Success)
+	assert.Equal(t, []StepResult{
+		{Name: "ok", Description: "ok step", Status: StepSucceeded, Changes: &Report{Directories: []string{"/ok step"}}},
+		{Name: "preserved", Description: "preserved step", Status: StepSkipped, Reason: "preserving cni state"},
+		{Name: "failing", Description: "failing step", Status: StepFailed, Error: "boom", Changes: &Report{Directories: []string{"/failing step"}}},
+	}, result.Steps)
+
+	result, err = cfg.Cleanup(false)
+	assert.Error(t, err)
+	require.Len(t, result.Steps, 3)
+	assert.Nil(t, result.Steps[0].Changes)
+}
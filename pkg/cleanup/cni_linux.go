@@ -14,21 +14,27 @@ import (
 
 type cni struct{}
 
+var cniFiles = []string{
+	"/etc/cni/net.d/10-calico.conflist",
+	"/etc/cni/net.d/calico-kubeconfig",
+	"/etc/cni/net.d/10-kuberouter.conflist",
+}
+
 // Name returns the name of the step
 func (c *cni) Name() string {
 	return "CNI leftovers cleanup step"
 }
 
+// State returns the kind of state that the step cleans up
+func (c *cni) State() string {
+	return PreserveCNI
+}
+
 // Run removes found CNI leftovers
 func (c *cni) Run() error {
 	var errs []error
 
-	files := []string{
-		"/etc/cni/net.d/10-calico.conflist",
-		"/etc/cni/net.d/calico-kubeconfig",
-		"/etc/cni/net.d/10-kuberouter.conflist",
-	}
-	for _, f := range files {
+	for _, f := range cniFiles {
 		if err := os.Remove(f); err != nil && !errors.Is(err, fs.ErrNotExist) {
 			logrus.Debug("failed to remove", f, err)
 			errs = append(errs, err)
@@ -39,3 +45,17 @@ func (c *cni) Run() error {
 	}
 	return nil
 }
+
+// DryRun reports the CNI leftovers that Run would remove
+func (c *cni) DryRun(r *Report) error {
+	var errs []error
+
+	for _, f := range cniFiles {
+		if _, err := os.Lstat(f); err == nil {
+			r.Files = append(r.Files, f)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
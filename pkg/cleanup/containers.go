@@ -7,7 +7,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/k0sproject/k0s/pkg/component/worker/containerd"
@@ -15,12 +14,17 @@ import (
 
 	"github.com/avast/retry-go"
 	"github.com/sirupsen/logrus"
-	"k8s.io/mount-utils"
 )
 
 type containers struct {
 	managedContainerd *containerd.Component
 	containerRuntime  runtime.ContainerRuntime
+	// containerdAddress is the address of the containerd API, if the container
+	// runtime turns out to be containerd.
+	containerdAddress string
+	// podNetNamespaces are the network namespaces of the pods that have been
+	// removed, which are cleaned up by the netns step.
+	podNetNamespaces []string
 }
 
 // Name returns the name of the step
@@ -55,29 +59,18 @@ func (c *containers) Run() error {
 	return nil
 }
 
-func removeMount(path string) error {
-	var errs []error
-
-	mounter := mount.New("")
-	procMounts, err := mounter.List()
+// DryRun reports the containers that Run would stop and remove. Containers
+// can only be listed if the container runtime is running, which is usually
+// not the case for the containerd managed by k0s.
+func (c *containers) DryRun(r *Report) error {
+	pods, err := c.containerRuntime.ListContainers(context.TODO())
 	if err != nil {
-		return err
-	}
-	for _, v := range procMounts {
-		if strings.Contains(v.Path, path) {
-			logrus.Debugf("Unmounting: %s", v.Path)
-			if err = mounter.Unmount(v.Path); err != nil {
-				errs = append(errs, err)
-			}
-
-			logrus.Debugf("Removing: %s", v.Path)
-			if err := os.RemoveAll(v.Path); err != nil {
-				errs = append(errs, err)
-			}
-		}
+		logrus.WithError(err).Warn("Failed to list containers, all containers of the container runtime would be stopped and removed")
+		return nil
 	}
 
-	return errors.Join(errs...)
+	r.Containers = append(r.Containers, pods...)
+	return nil
 }
 
 func (c *containers) stopAllContainers() error {
@@ -97,12 +90,12 @@ func (c *containers) stopAllContainers() error {
 	if err != nil {
 		return fmt.Errorf("failed at listing pods %w", err)
 	}
-	if len(pods) > 0 {
-		if err := removeMount("run/netns"); err != nil {
-			errs = append(errs, err)
-		}
-	}
 
+	// The sandbox metadata, including the network namespaces, is gone once
+	// the pods have been removed.
+	if c.podNetNamespaces, err = c.containerRuntime.PodNetworkNamespaces(ctx); err != nil {
+		logrus.WithError(err).Debug("Failed to get the network namespaces of the pods")
+	}
 	for _, pod := range pods {
 		logrus.Debugf("stopping container: %v", pod)
 		err := c.containerRuntime.StopContainer(ctx, pod)
@@ -121,6 +114,14 @@ func (c *containers) stopAllContainers() error {
 		}
 	}
 
+	// Stop any containers that weren't stopped along with their pods, so that
+	// no shims are left behind that keep the mounts of the containers busy.
+	if c.isContainerd(ctx) {
+		if err := stopContainerdTasks(ctx, c.containerdAddress); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	pods, err = c.containerRuntime.ListContainers(ctx)
 	if err == nil && len(pods) == 0 {
 		logrus.Info("successfully removed k0s containers!")
@@ -131,3 +132,23 @@ func (c *containers) stopAllContainers() error {
 	}
 	return nil
 }
+
+// isContainerd checks if the container runtime is containerd, and its API is
+// available. Other container runtimes may listen on unix sockets, too.
+func (c *containers) isContainerd(ctx context.Context) bool {
+	if c.containerdAddress == "" {
+		return false
+	}
+
+	name, err := c.containerRuntime.RuntimeName(ctx)
+	if err != nil {
+		logrus.WithError(err).Debug("Failed to determine the container runtime, skipping containerd cleanup")
+		return false
+	}
+	if name != "containerd" {
+		logrus.Debugf("Container runtime is %s, skipping containerd cleanup", name)
+		return false
+	}
+
+	return true
+}
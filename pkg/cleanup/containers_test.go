@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/container/runtime"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRuntime struct {
+	runtime.ContainerRuntime
+	name string
+	err  error
+}
+
+func (r *fakeRuntime) RuntimeName(context.Context) (string, error) {
+	return r.name, r.err
+}
+
+func TestContainers_IsContainerd(t *testing.T) {
+	var tests = []struct {
+		name     string
+		address  string
+		runtime  *fakeRuntime
+		expected bool
+	}{
+		{"containerd", "/run/k0s/containerd.sock", &fakeRuntime{name: "containerd"}, true},
+		{"cri-o", "/run/crio/crio.sock", &fakeRuntime{name: "cri-o"}, false},
+		{"unreachable", "/run/k0s/containerd.sock", &fakeRuntime{err: errors.New("unreachable")}, false},
+		{"no_address", "", &fakeRuntime{name: "containerd"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &containers{containerRuntime: test.runtime, containerdAddress: test.address}
+			assert.Equal(t, test.expected, c.isContainerd(t.Context()))
+		})
+	}
+}
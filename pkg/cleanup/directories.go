@@ -6,8 +6,10 @@ package cleanup
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -18,6 +20,10 @@ type directories struct {
 	dataDir        string
 	kubeletRootDir string
 	runDir         string
+
+	// preserved are the paths that are kept, along with their parent
+	// directories.
+	preserved []string
 }
 
 // Name returns the name of the step
@@ -25,24 +31,69 @@ func (d *directories) Name() string {
 	return "remove directories step"
 }
 
+// directoriesPlan describes the changes that the directories step makes.
+type directoriesPlan struct {
+	// unmounts are the mount points under the kubelet root dir and the data
+	// dir, in the order in which they have to be unmounted.
+	unmounts []string
+	// dataDirMounted is set if the data dir is a mount point on its own, in
+	// which case only its contents can be removed.
+	dataDirMounted bool
+	// removals are the paths that are removed recursively.
+	removals []string
+}
+
 // Run removes all kubelet mounts and deletes generated dataDir and runDir
 func (d *directories) Run() error {
+	plan, err := d.plan()
+	if err != nil {
+		return err
+	}
+
+	return d.execute(plan)
+}
+
+// DryRun reports the mounts that Run would unmount and the directories it
+// would delete
+func (d *directories) DryRun(r *Report) error {
+	plan, err := d.plan()
+	if err != nil {
+		return err
+	}
+
+	r.Unmounts = append(r.Unmounts, plan.unmounts...)
+	for _, path := range plan.removals {
+		if info, err := os.Lstat(path); err == nil {
+			if info.IsDir() {
+				r.Directories = append(r.Directories, path)
+			} else {
+				r.Files = append(r.Files, path)
+			}
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// plan determines the mounts that need to be unmounted before the directories
+// can be deleted.
+func (d *directories) plan() (*directoriesPlan, error) {
 	// unmount any leftover overlays (such as in alpine)
 	mounter := mount.New("")
 	procMounts, err := mounter.List()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var dataDirMounted bool
+	var plan directoriesPlan
 
 	// ensure that we don't delete any persistent data volumes that may be
 	// mounted by kubernetes by unmount every mount point under DataDir.
 	//
 	// Unmount in the reverse order it was mounted so we handle recursive
-	// bind mounts and over mounts properly. If we for any reason are not
-	// able to unmount, fall back to lazy unmount and if that also fails
-	// bail out and don't delete anything.
+	// bind mounts and over mounts properly.
 	//
 	// Note that if there are any shared bind mounts under k0s data
 	// directory, we may end up unmounting stuff outside the k0s DataDir.
@@ -55,46 +106,128 @@ func (d *directories) Run() error {
 		// avoid unmount datadir if its mounted on separate partition
 		// k0s didn't mount it so leave it alone
 		if v.Path == d.dataDir {
-			dataDirMounted = true
+			plan.dataDirMounted = true
 			continue
 		}
 		if isUnderPath(v.Path, d.kubeletRootDir) || isUnderPath(v.Path, d.dataDir) {
-			logrus.Debugf("%v is mounted! attempting to unmount...", v.Path)
-			if err = mounter.Unmount(v.Path); err != nil {
-				// if we fail to unmount, try lazy unmount so
-				// we don't end up deleting stuff that we
-				// shouldn't
-				logrus.Warningf("lazy unmounting %v", v.Path)
-				if err = UnmountLazy(v.Path); err != nil {
-					return fmt.Errorf("failed unmount %v", v.Path)
-				}
+			plan.unmounts = append(plan.unmounts, v.Path)
+		}
+	}
+
+	if plan.removals, err = d.removals(d.preserved); err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+// removals returns the paths that need to be removed recursively in order to
+// remove the k0s directories, except for the given paths to be kept.
+func (d *directories) removals(keep []string) ([]string, error) {
+	var removals []string
+	for _, dir := range []string{d.kubeletRootDir, d.dataDir, d.runDir} {
+		dirRemovals, err := pathsToRemove(dir, keep)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range dirRemovals {
+			// The kubelet root dir may be located inside the data dir.
+			if !slices.ContainsFunc(removals, func(removal string) bool { return isUnderPath(path, removal) }) {
+				removals = append(removals, path)
 			}
 		}
 	}
 
-	logrus.Debugf("removing kubelet root dir (%s)", d.kubeletRootDir)
-	if err := os.RemoveAll(d.kubeletRootDir); err != nil {
-		return fmt.Errorf("failed to delete k0s kubelet root direcotory: %w", err)
+	return removals, nil
+}
+
+// pathsToRemove returns the paths that need to be removed recursively in
+// order to remove the given path, except for the paths to be kept.
+func pathsToRemove(path string, keep []string) ([]string, error) {
+	if slices.ContainsFunc(keep, func(kept string) bool { return isUnderPath(path, kept) }) {
+		return nil, nil
+	}
+	if !slices.ContainsFunc(keep, func(kept string) bool { return isUnderPath(kept, path) }) {
+		return []string{path}, nil
+	}
+
+	// Some path to be kept is located below this path. Descend into it.
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var removals []string
+	for _, entry := range entries {
+		entryRemovals, err := pathsToRemove(filepath.Join(path, entry.Name()), keep)
+		if err != nil {
+			return nil, err
+		}
+		removals = append(removals, entryRemovals...)
+	}
+
+	return removals, nil
+}
+
+// execute unmounts and deletes everything according to the plan.
+func (d *directories) execute(plan *directoriesPlan) error {
+	if plan.dataDirMounted {
+		logrus.Infof("Not unmounting data-dir (%s), since it's a mount point on its own", d.dataDir)
 	}
 
-	if dataDirMounted {
-		logrus.Debugf("removing the contents of mounted data-dir (%s)", d.dataDir)
-	} else {
-		logrus.Debugf("removing k0s generated data-dir (%s)", d.dataDir)
+	// Mount points that can't be unmounted at all are skipped. They're kept,
+	// along with their parent directories, so that we don't end up deleting
+	// stuff that we shouldn't.
+	var skipped []string
+	for _, path := range plan.unmounts {
+		logrus.Debugf("%v is mounted! attempting to unmount...", path)
+		if err := unmount(path); err != nil {
+			logrus.WithError(err).Warnf("Skipping mount point %s", path)
+			skipped = append(skipped, path)
+		}
 	}
 
-	if err := os.RemoveAll(d.dataDir); err != nil {
-		if !dataDirMounted {
-			return fmt.Errorf("failed to delete k0s generated data-dir: %w", err)
+	removals := plan.removals
+	if len(skipped) > 0 {
+		var err error
+		if removals, err = d.removals(append(slices.Clone(d.preserved), skipped...)); err != nil {
+			return err
 		}
-		if !errorIsUnlinkat(err, d.dataDir) {
-			return fmt.Errorf("failed to delete contents of mounted data-dir: %w", err)
+	}
+
+	for _, path := range removals {
+		switch {
+		case path == d.kubeletRootDir:
+			logrus.Debugf("removing kubelet root dir (%s)", path)
+			if err := os.RemoveAll(path); err != nil {
+				return fmt.Errorf("failed to delete k0s kubelet root direcotory: %w", err)
+			}
+
+		case path == d.dataDir && plan.dataDirMounted:
+			logrus.Debugf("removing the contents of mounted data-dir (%s)", path)
+			if err := os.RemoveAll(path); err != nil && !errorIsUnlinkat(err, path) {
+				return fmt.Errorf("failed to delete contents of mounted data-dir: %w", err)
+			}
+
+		case path == d.dataDir:
+			logrus.Debugf("removing k0s generated data-dir (%s)", path)
+			if err := os.RemoveAll(path); err != nil {
+				return fmt.Errorf("failed to delete k0s generated data-dir: %w", err)
+			}
+
+		default:
+			logrus.Debugf("removing %s", path)
+			if err := os.RemoveAll(path); err != nil {
+				return fmt.Errorf("failed to delete %s: %w", path, err)
+			}
 		}
 	}
 
-	logrus.Debugf("deleting k0s generated run-dir (%s)", d.runDir)
-	if err := os.RemoveAll(d.runDir); err != nil {
-		return fmt.Errorf("failed to delete %s: %w", d.runDir, err)
+	if len(skipped) > 0 {
+		return fmt.Errorf("failed to unmount %d mount point(s), kept them along with their parent directories: %s", len(skipped), strings.Join(skipped, ", "))
 	}
 
 	return nil
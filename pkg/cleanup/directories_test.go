@@ -0,0 +1,46 @@
+//go:build linux || windows
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectoriesRemovals(t *testing.T) {
+	dataDir := t.TempDir()
+	for _, dir := range []string{
+		"bin",
+		"containerd/io.containerd.content.v1.content",
+		"kubelet/pods/uid/volumes/nfs",
+		"kubelet/plugins",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dataDir, filepath.FromSlash(dir)), 0700))
+	}
+
+	d := &directories{
+		dataDir:        dataDir,
+		kubeletRootDir: filepath.Join(dataDir, "kubelet"),
+		runDir:         filepath.Join(t.TempDir(), "run"),
+	}
+
+	removals, err := d.removals(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{d.kubeletRootDir, dataDir, d.runDir}, removals)
+
+	stuckMount := filepath.Join(dataDir, "kubelet", "pods", "uid", "volumes", "nfs")
+	removals, err = d.removals([]string{filepath.Join(dataDir, "containerd"), stuckMount})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dataDir, "kubelet", "plugins"),
+		filepath.Join(dataDir, "bin"),
+		d.runDir,
+	}, removals)
+}
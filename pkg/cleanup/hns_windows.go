@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Microsoft/hcsshim/hcn"
+	"github.com/sirupsen/logrus"
+)
+
+// hnsNetworks removes the Host Networking Service (HNS) state created by
+// Calico and kube-proxy.
+type hnsNetworks struct{}
+
+// hnsState is the HNS state created by Calico and kube-proxy.
+type hnsState struct {
+	networks      []hcn.HostComputeNetwork
+	loadBalancers []hcn.HostComputeLoadBalancer
+}
+
+// Name returns the name of the step
+func (h *hnsNetworks) Name() string {
+	return "HNS networks cleanup step"
+}
+
+// State returns the kind of state that the step cleans up
+func (h *hnsNetworks) State() string {
+	return PreserveCNI
+}
+
+// Run removes the HNS load balancers of kube-proxy and the HNS networks of
+// Calico, along with their endpoints
+func (h *hnsNetworks) Run() error {
+	state, err := h.list()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range state.loadBalancers {
+		logrus.Debugf("Deleting HNS load balancer %s", state.loadBalancers[i].Id)
+		if err := state.loadBalancers[i].Delete(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete HNS load balancer %s: %w", state.loadBalancers[i].Id, err))
+		}
+	}
+
+	for i := range state.networks {
+		logrus.Debugf("Deleting HNS network %s", state.networks[i].Name)
+		if err := state.networks[i].Delete(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete HNS network %s: %w", state.networks[i].Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// DryRun reports the HNS load balancers and networks that Run would remove
+func (h *hnsNetworks) DryRun(r *Report) error {
+	state, err := h.list()
+	if err != nil {
+		return err
+	}
+
+	for _, loadBalancer := range state.loadBalancers {
+		r.HNSLoadBalancers = append(r.HNSLoadBalancers, loadBalancer.Id)
+	}
+	for _, network := range state.networks {
+		r.HNSNetworks = append(r.HNSNetworks, network.Name)
+	}
+
+	return nil
+}
+
+// list returns the HNS networks created by Calico, and the load balancers of
+// kube-proxy, i.e. the ones for endpoints in these networks. The networks are
+// the pod network, whose name starts with "Calico", and the "External" network
+// that Calico creates when bootstrapping the VXLAN backend.
+func (h *hnsNetworks) list() (*hnsState, error) {
+	networks, err := hcn.ListNetworks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list HNS networks: %w", err)
+	}
+
+	var state hnsState
+	var endpointIDs []string
+	for _, network := range networks {
+		if !strings.HasPrefix(network.Name, "Calico") && network.Name != "External" {
+			continue
+		}
+
+		endpoints, err := hcn.ListEndpointsOfNetwork(network.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list endpoints of HNS network %s: %w", network.Name, err)
+		}
+		for _, endpoint := range endpoints {
+			endpointIDs = append(endpointIDs, endpoint.Id)
+		}
+
+		state.networks = append(state.networks, network)
+	}
+
+	loadBalancers, err := hcn.ListLoadBalancers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list HNS load balancers: %w", err)
+	}
+	for _, loadBalancer := range loadBalancers {
+		if slices.ContainsFunc(loadBalancer.HostComputeEndpoints, func(id string) bool {
+			return slices.Contains(endpointIDs, id)
+		}) {
+			state.loadBalancers = append(state.loadBalancers, loadBalancer)
+		}
+	}
+
+	return &state, nil
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// netnsDir is the directory in which the CNI plugins pin the network
+// namespaces of pods.
+const netnsDir = "/run/netns"
+
+// podNetnsPrefix is the prefix of the names of the network namespaces that
+// containerd creates for pods.
+const podNetnsPrefix = "cni-"
+
+// netns removes the network namespaces of pods that are left behind. The
+// pinned network namespaces keep their veth pairs and conntrack entries
+// alive, even if all the processes in them are gone.
+type netns struct {
+	dir        string
+	containers *containers
+}
+
+// Name returns the name of the step
+func (n *netns) Name() string {
+	return "pod network namespaces cleanup step"
+}
+
+// Run unmounts and removes the pinned network namespaces of pods
+func (n *netns) Run() error {
+	paths, err := n.namespaces(n.containers.podNetNamespaces)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, path := range paths {
+		logrus.Debugf("Removing network namespace %s", path)
+		if err := unix.Unmount(path, unix.MNT_DETACH); err != nil && !errors.Is(err, unix.EINVAL) && !errors.Is(err, unix.ENOENT) {
+			errs = append(errs, fmt.Errorf("failed to unmount network namespace %s: %w", path, err))
+			continue
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("failed to remove network namespace %s: %w", path, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("errors occurred while removing network namespaces: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// DryRun reports the network namespaces that Run would remove. The network
+// namespaces of the sandboxes can only be determined if the container runtime
+// is running.
+func (n *netns) DryRun(r *Report) error {
+	sandboxNamespaces, err := n.containers.containerRuntime.PodNetworkNamespaces(context.TODO())
+	if err != nil {
+		logrus.WithError(err).Debug("Failed to get the network namespaces of the pods")
+	}
+
+	paths, err := n.namespaces(sandboxNamespaces)
+	if err != nil {
+		return err
+	}
+
+	r.NetworkNamespaces = append(r.NetworkNamespaces, paths...)
+	return nil
+}
+
+// namespaces returns the network namespaces of pods. Those are the given
+// network namespaces of the pod sandboxes, and the network namespaces pinned
+// by containerd, which are recognized by their names. Only the network
+// namespaces that are bind mounted into the netns directory are considered.
+// Others, such as /proc/<pid>/ns/net, aren't pinned and must not be removed.
+func (n *netns) namespaces(sandboxNamespaces []string) ([]string, error) {
+	var paths []string
+	for _, path := range sandboxNamespaces {
+		path = filepath.Clean(path)
+		if filepath.Dir(path) != filepath.Clean(n.dir) {
+			logrus.Debugf("Ignoring network namespace %s outside of %s", path, n.dir)
+			continue
+		}
+		if _, err := os.Lstat(path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+
+	entries, err := os.ReadDir(n.dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list network namespaces: %w", err)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), podNetnsPrefix) {
+			paths = append(paths, filepath.Join(n.dir, entry.Name()))
+		}
+	}
+
+	slices.Sort(paths)
+	return slices.Compact(paths), nil
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetnsNamespaces(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"cni-1234", "cni-5678", "host-ns", "custom"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+
+	underTest := netns{dir: dir}
+	paths, err := underTest.namespaces([]string{
+		filepath.Join(dir, "cni-1234"), // pinned by containerd, as well
+		filepath.Join(dir, "custom"),
+		filepath.Join(dir, "gone"),
+		"/proc/self/ns/net", // not pinned
+		filepath.Join(dir, "..", filepath.Base(dir), "cni-5678"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "cni-1234"),
+		filepath.Join(dir, "cni-5678"),
+		filepath.Join(dir, "custom"),
+	}, paths)
+
+	underTest.dir = filepath.Join(dir, "nonexistent")
+	paths, err = underTest.namespaces(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, paths)
+}
@@ -0,0 +1,374 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+)
+
+// networkInterfaces are the network interfaces created by the network
+// providers, kube-proxy and node-local DNS.
+var networkInterfaces = []string{
+	"kube-bridge",
+	"kube-dummy-if",
+	"kube-ipvs0",
+	"vxlan.calico",
+	"vxlan-v6.calico",
+	"tunl0",
+	"nodelocaldns",
+}
+
+// calicoInterfacePattern matches the names of the host-side veth interfaces
+// that Calico creates for pods: "cali" followed by the first 11 hex digits of
+// a hash of the workload endpoint.
+var calicoInterfacePattern = regexp.MustCompile(`^cali[0-9a-f]{11}$`)
+
+// iptablesChainPrefixes are the prefixes of the iptables chains created by
+// Kubernetes and the network providers.
+var iptablesChainPrefixes = []string{"KUBE-", "cali-"}
+
+type network struct {
+	binDir string
+}
+
+// iptables describes a set of iptables binaries.
+type iptables struct {
+	name          string
+	save, restore []string
+}
+
+// Name returns the name of the step
+func (n *network) Name() string {
+	return "network leftovers cleanup step"
+}
+
+// State returns the kind of state that the step cleans up
+func (n *network) State() string {
+	return PreserveCNI
+}
+
+// Run removes the IPVS virtual servers, iptables chains and network interfaces
+// created by k0s
+func (n *network) Run() error {
+	var errs []error
+
+	services, err := ipvsServices()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, service := range services {
+		logrus.Debugf("Deleting IPVS virtual server %s", service)
+		if out, err := exec.Command("ipvsadm", "-D", service.protocol, service.address).CombinedOutput(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete IPVS virtual server %s: %w: %s", service, err, bytes.TrimSpace(out)))
+		}
+	}
+
+	for _, ipt := range n.iptables() {
+		rules, chains, err := ipt.cleanupRules()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(chains) == 0 && rules == "" {
+			continue
+		}
+
+		logrus.Debugf("Deleting %s chains %s", ipt.name, strings.Join(chains, ", "))
+		cmd := exec.Command(ipt.restore[0], append(ipt.restore[1:], "--noflush")...)
+		cmd.Stdin = strings.NewReader(rules)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s chains: %w: %s", ipt.name, err, bytes.TrimSpace(out)))
+		}
+	}
+
+	links, err := networkLinks()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, l := range links {
+		logrus.Debugf("Deleting network interface %s", l.Attrs().Name)
+		if err := netlink.LinkDel(l); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete network interface %s: %w", l.Attrs().Name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("errors occurred while removing network leftovers: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// DryRun reports the IPVS virtual servers, iptables chains and network
+// interfaces that Run would remove
+func (n *network) DryRun(r *Report) error {
+	var errs []error
+
+	services, err := ipvsServices()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, service := range services {
+		r.IPVSServices = append(r.IPVSServices, service.String())
+	}
+
+	for _, ipt := range n.iptables() {
+		_, chains, err := ipt.cleanupRules()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, chain := range chains {
+			r.IPTablesChains = append(r.IPTablesChains, ipt.name+" "+chain)
+		}
+	}
+
+	links, err := networkLinks()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, l := range links {
+		r.Interfaces = append(r.Interfaces, l.Attrs().Name)
+	}
+
+	return errors.Join(errs...)
+}
+
+// iptables returns the iptables binaries to be cleaned up. Those are the
+// binaries staged by k0s in both legacy and nft mode, or the ones of the host,
+// if k0s didn't stage any.
+func (n *network) iptables() []iptables {
+	var ipts []iptables
+	for _, mode := range []string{"legacy", "nft"} {
+		multi := filepath.Join(n.binDir, "xtables-"+mode+"-multi")
+		if _, err := os.Stat(multi); err != nil {
+			continue
+		}
+		for _, family := range []string{"iptables", "ip6tables"} {
+			ipts = append(ipts, iptables{
+				name:    family + "-" + mode,
+				save:    []string{multi, family + "-save"},
+				restore: []string{multi, family + "-restore"},
+			})
+		}
+	}
+
+	if len(ipts) > 0 {
+		return ipts
+	}
+
+	for _, family := range []string{"iptables", "ip6tables"} {
+		save, err := exec.LookPath(family + "-save")
+		if err != nil {
+			continue
+		}
+		restore, err := exec.LookPath(family + "-restore")
+		if err != nil {
+			continue
+		}
+		ipts = append(ipts, iptables{name: family, save: []string{save}, restore: []string{restore}})
+	}
+
+	return ipts
+}
+
+// cleanupRules returns the iptables-restore input that removes all chains
+// created by k0s, along with the names of these chains.
+func (ipt *iptables) cleanupRules() (string, []string, error) {
+	out, err := exec.Command(ipt.save[0], ipt.save[1:]...).Output()
+	if err != nil {
+		// The kernel modules for a family or mode may not be available.
+		logrus.WithError(err).Debugf("Failed to list %s rules", ipt.name)
+		return "", nil, nil
+	}
+
+	rules, chains := iptablesCleanupRules(string(out))
+	return rules, chains, nil
+}
+
+// iptablesCleanupRules builds the input for iptables-restore --noflush that
+// removes the chains created by k0s from the given iptables-save output.
+// Rules in other chains that jump to these chains are deleted, as well. The
+// chains are returned in the "table/chain" format.
+func iptablesCleanupRules(save string) (string, []string) {
+	var (
+		out, tableOut strings.Builder
+		table         string
+		tableChains   []string
+		allChains     []string
+		hasDeletions  bool
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(save))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "*"):
+			table, tableChains, hasDeletions = line[1:], nil, false
+			tableOut.Reset()
+
+		case strings.HasPrefix(line, ":"):
+			if chain, _, _ := strings.Cut(line[1:], " "); isK0sChain(chain) {
+				tableChains = append(tableChains, chain)
+			}
+
+		case strings.HasPrefix(line, "-A "):
+			fields := strings.Fields(line)
+			if len(fields) < 2 || isK0sChain(fields[1]) || !jumpsToK0sChain(fields[2:]) {
+				continue
+			}
+			tableOut.WriteString("-D " + line[len("-A "):] + "\n")
+			hasDeletions = true
+
+		case line == "COMMIT":
+			if len(tableChains) == 0 && !hasDeletions {
+				continue
+			}
+
+			out.WriteString("*" + table + "\n")
+			out.WriteString(tableOut.String())
+			// Declaring the chains flushes them, so that they can be deleted
+			// regardless of any references between them.
+			for _, chain := range tableChains {
+				out.WriteString(":" + chain + " - [0:0]\n")
+			}
+			for _, chain := range tableChains {
+				out.WriteString("-X " + chain + "\n")
+				allChains = append(allChains, table+"/"+chain)
+			}
+			out.WriteString("COMMIT\n")
+		}
+	}
+
+	return out.String(), allChains
+}
+
+func isK0sChain(chain string) bool {
+	return slices.ContainsFunc(iptablesChainPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(chain, prefix)
+	})
+}
+
+func jumpsToK0sChain(ruleFields []string) bool {
+	for i := 0; i < len(ruleFields)-1; i++ {
+		switch ruleFields[i] {
+		case "-j", "--jump", "-g", "--goto":
+			if isK0sChain(ruleFields[i+1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// networkLinks returns the network interfaces created by k0s.
+func networkLinks() ([]netlink.Link, error) {
+	lnks, err := netlink.LinkList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link list from netlink: %w", err)
+	}
+
+	var k0sLinks []netlink.Link
+	for _, l := range lnks {
+		if isK0sNetworkInterface(l.Attrs().Name) {
+			k0sLinks = append(k0sLinks, l)
+		}
+	}
+	return k0sLinks, nil
+}
+
+// isK0sNetworkInterface checks if the network interface with the given name
+// has been created by the network providers, kube-proxy or node-local DNS.
+func isK0sNetworkInterface(name string) bool {
+	return slices.Contains(networkInterfaces, name) || calicoInterfacePattern.MatchString(name)
+}
+
+// ipvsService is an IPVS virtual server, as understood by ipvsadm.
+type ipvsService struct {
+	protocol, address string
+}
+
+func (s ipvsService) String() string {
+	return s.protocol + " " + s.address
+}
+
+// ipvsServices returns the IPVS virtual servers created by kube-proxy. Those
+// are the ones whose addresses are bound to the kube-ipvs0 interface. IPVS
+// virtual servers can only be listed if ipvsadm is installed.
+func ipvsServices() ([]ipvsService, error) {
+	link, err := netlink.LinkByName("kube-ipvs0")
+	if err != nil {
+		var notFound netlink.LinkNotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get kube-ipvs0 interface: %w", err)
+	}
+
+	addrs, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get addresses of kube-ipvs0 interface: %w", err)
+	}
+
+	if _, err := exec.LookPath("ipvsadm"); err != nil {
+		logrus.WithError(err).Warn("Unable to clean up IPVS virtual servers")
+		return nil, nil
+	}
+
+	out, err := exec.Command("ipvsadm", "--save", "--numeric").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IPVS virtual servers: %w", err)
+	}
+
+	var services []ipvsService
+	for _, rule := range parseIPVSServices(string(out)) {
+		if slices.ContainsFunc(addrs, func(addr netlink.Addr) bool {
+			return ipvsServiceHost(rule.address) == addr.IP.String()
+		}) {
+			services = append(services, rule)
+		}
+	}
+
+	return services, nil
+}
+
+// parseIPVSServices parses the virtual servers from the output of ipvsadm --save.
+func parseIPVSServices(save string) []ipvsService {
+	var services []ipvsService
+
+	scanner := bufio.NewScanner(strings.NewReader(save))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[0] != "-A" {
+			continue
+		}
+		switch fields[1] {
+		case "-t", "-u", "--tcp-service", "--udp-service", "--sctp-service":
+			services = append(services, ipvsService{fields[1], fields[2]})
+		}
+	}
+
+	return services
+}
+
+// ipvsServiceHost returns the host of an IPVS virtual server address, which
+// is either in the IPv4 "host:port" or the IPv6 "[host]:port" format.
+func ipvsServiceHost(address string) string {
+	host := address
+	if i := strings.LastIndex(address, ":"); i >= 0 {
+		host = address[:i]
+	}
+	return strings.Trim(host, "[]")
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPTablesCleanupRules(t *testing.T) {
+	save := `# Generated by iptables-save
+*mangle
+:PREROUTING ACCEPT [0:0]
+:OUTPUT ACCEPT [0:0]
+-A OUTPUT -j ACCEPT
+COMMIT
+*filter
+:INPUT ACCEPT [0:0]
+:FORWARD ACCEPT [0:0]
+:KUBE-FIREWALL - [0:0]
+:KUBE-ROUTER-INPUT - [0:0]
+:cali-INPUT - [0:0]
+:USER-CHAIN - [0:0]
+-A INPUT -m comment --comment "kube-router netpol" -j KUBE-ROUTER-INPUT
+-A INPUT -j KUBE-FIREWALL
+-A INPUT -j cali-INPUT
+-A INPUT -j USER-CHAIN
+-A KUBE-ROUTER-INPUT -j KUBE-FIREWALL
+-A USER-CHAIN -s 10.0.0.0/8 -j ACCEPT
+COMMIT
+`
+
+	rules, chains := iptablesCleanupRules(save)
+
+	assert.Equal(t, `*filter
+-D INPUT -m comment --comment "kube-router netpol" -j KUBE-ROUTER-INPUT
+-D INPUT -j KUBE-FIREWALL
+-D INPUT -j cali-INPUT
+:KUBE-FIREWALL - [0:0]
+:KUBE-ROUTER-INPUT - [0:0]
+:cali-INPUT - [0:0]
+-X KUBE-FIREWALL
+-X KUBE-ROUTER-INPUT
+-X cali-INPUT
+COMMIT
+`, rules)
+	assert.Equal(t, []string{"filter/KUBE-FIREWALL", "filter/KUBE-ROUTER-INPUT", "filter/cali-INPUT"}, chains)
+
+	rules, chains = iptablesCleanupRules("*filter\n:INPUT ACCEPT [0:0]\n-A INPUT -j ACCEPT\nCOMMIT\n")
+	assert.Empty(t, rules)
+	assert.Empty(t, chains)
+}
+
+func TestIsK0sNetworkInterface(t *testing.T) {
+	for _, name := range []string{"cali1234567890a", "vxlan.calico", "tunl0", "kube-bridge"} {
+		assert.True(t, isK0sNetworkInterface(name), name)
+	}
+	for _, name := range []string{"calico0", "cali", "cali1234567890ab", "caliXYZ4567890a", "eth0", "tunl1"} {
+		assert.False(t, isK0sNetworkInterface(name), name)
+	}
+}
+
+func TestParseIPVSServices(t *testing.T) {
+	save := `-A -t 10.96.0.1:443 -s rr
+-a -t 10.96.0.1:443 -r 192.168.1.10:6443 -m -w 1
+-A -u [fd00::a]:53 -s rr
+-A -f 1 -s rr
+`
+
+	services := parseIPVSServices(save)
+	assert.Equal(t, []ipvsService{{"-t", "10.96.0.1:443"}, {"-u", "[fd00::a]:53"}}, services)
+	assert.Equal(t, "10.96.0.1", ipvsServiceHost(services[0].address))
+	assert.Equal(t, "fd00::a", ipvsServiceHost(services[1].address))
+}
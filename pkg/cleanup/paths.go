@@ -0,0 +1,61 @@
+//go:build linux || windows
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// paths removes arbitrary paths. It's meant to be used by components that
+// register cleanup steps for the state that they leave behind on the host.
+type paths struct {
+	name  string
+	paths []string
+}
+
+// NewRemovePathsStep returns a cleanup step that recursively removes the
+// given paths, e.g. the host path volumes of a storage provider.
+func NewRemovePathsStep(name string, pathsToRemove ...string) Step {
+	return &paths{name, pathsToRemove}
+}
+
+// Name returns the name of the step
+func (p *paths) Name() string {
+	return p.name
+}
+
+// Run removes the paths
+func (p *paths) Run() error {
+	var errs []error
+	for _, path := range p.paths {
+		logrus.Debugf("removing %s", path)
+		if err := os.RemoveAll(path); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s: %w", path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DryRun reports the existing paths that Run would remove
+func (p *paths) DryRun(r *Report) error {
+	for _, path := range p.paths {
+		if info, err := os.Lstat(path); err == nil {
+			if info.IsDir() {
+				r.Directories = append(r.Directories, path)
+			} else {
+				r.Files = append(r.Files, path)
+			}
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/dustin/go-humanize"
+	"github.com/sirupsen/logrus"
+)
+
+// podLogs removes the logs of the pods and their containers.
+type podLogs struct {
+	// podLogsDir is the directory in which kubelet stores the pod logs.
+	podLogsDir string
+	// containerLogsDir is the directory in which kubelet creates symlinks to
+	// the container logs.
+	containerLogsDir string
+	kubeletRootDir   string
+}
+
+// podLogPaths are the files and directories containing pod logs.
+type podLogPaths struct {
+	dirs, files []string
+	size        int64
+}
+
+// Name returns the name of the step
+func (p *podLogs) Name() string {
+	return "purge pod logs step"
+}
+
+// Run removes the pod log directories, the container log symlinks, and the
+// container log directories in the kubelet root directory
+func (p *podLogs) Run() error {
+	paths, err := p.paths()
+	errs := []error{err}
+	for _, file := range paths.files {
+		logrus.Debugf("removing %s", file)
+		if err := os.Remove(file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	for _, dir := range paths.dirs {
+		logrus.Debugf("removing %s", dir)
+		if err := os.RemoveAll(dir); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("errors occurred while removing pod logs: %w", err)
+	}
+
+	logrus.Infof("Removed %s of pod logs", humanize.IBytes(uint64(paths.size)))
+	return nil
+}
+
+// DryRun reports the pod log files and directories that Run would remove,
+// along with their total size
+func (p *podLogs) DryRun(r *Report) error {
+	paths, err := p.paths()
+	r.Directories = append(r.Directories, paths.dirs...)
+	r.Files = append(r.Files, paths.files...)
+	r.PodLogsSize += paths.size
+	return err
+}
+
+func (p *podLogs) paths() (*podLogPaths, error) {
+	var paths podLogPaths
+	var errs []error
+
+	if _, err := os.Stat(p.podLogsDir); err == nil {
+		paths.dirs = append(paths.dirs, p.podLogsDir)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		errs = append(errs, err)
+	}
+
+	// Only the symlinks are removed from the container logs directory, since
+	// it's a well-known location that may be used by other tools, too.
+	entries, err := os.ReadDir(p.containerLogsDir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		errs = append(errs, err)
+	}
+	for _, entry := range entries {
+		if entry.Type()&fs.ModeSymlink != 0 {
+			paths.files = append(paths.files, filepath.Join(p.containerLogsDir, entry.Name()))
+		}
+	}
+
+	// The container directories of the pods in the kubelet root directory
+	// contain the termination logs of the containers.
+	containerDirs, err := filepath.Glob(filepath.Join(p.kubeletRootDir, "pods", "*", "containers"))
+	if err != nil {
+		errs = append(errs, err)
+	}
+	paths.dirs = append(paths.dirs, containerDirs...)
+
+	for _, dir := range paths.dirs {
+		size, err := dirSize(dir)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		paths.size += size
+	}
+
+	return &paths, errors.Join(errs...)
+}
+
+// dirSize returns the total size of the regular files in the given directory,
+// without following symlinks.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
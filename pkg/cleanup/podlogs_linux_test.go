@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPodLogs(t *testing.T) {
+	dir := t.TempDir()
+	underTest := podLogs{
+		podLogsDir:       filepath.Join(dir, "log", "pods"),
+		containerLogsDir: filepath.Join(dir, "log", "containers"),
+		kubeletRootDir:   filepath.Join(dir, "kubelet"),
+	}
+
+	podLog := filepath.Join(underTest.podLogsDir, "default_pod_1234", "app", "0.log")
+	terminationLog := filepath.Join(underTest.kubeletRootDir, "pods", "1234", "containers", "app", "abcd")
+	for path, content := range map[string]string{podLog: "hello", terminationLog: "bye"} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	require.NoError(t, os.MkdirAll(underTest.containerLogsDir, 0755))
+	symlink := filepath.Join(underTest.containerLogsDir, "pod_default_app-abcd.log")
+	require.NoError(t, os.Symlink(podLog, symlink))
+	other := filepath.Join(underTest.containerLogsDir, "other.log")
+	require.NoError(t, os.WriteFile(other, nil, 0644))
+
+	var report Report
+	require.NoError(t, underTest.DryRun(&report))
+	assert.Equal(t, []string{
+		underTest.podLogsDir,
+		filepath.Join(underTest.kubeletRootDir, "pods", "1234", "containers"),
+	}, report.Directories)
+	assert.Equal(t, []string{symlink}, report.Files)
+	assert.Equal(t, int64(len("hello")+len("bye")), report.PodLogsSize)
+
+	require.NoError(t, underTest.Run())
+	assert.NoDirExists(t, underTest.podLogsDir)
+	assert.NoDirExists(t, filepath.Dir(filepath.Dir(terminationLog)))
+	assert.NoFileExists(t, symlink)
+	assert.FileExists(t, other)
+
+	// Nothing to do on another run
+	report = Report{}
+	require.NoError(t, underTest.DryRun(&report))
+	assert.Zero(t, report)
+	assert.NoError(t, underTest.Run())
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+	"unsafe"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/windows"
+)
+
+// processes terminates the processes started from the k0s bin dir, such as
+// containerd and kubelet, which may have been left behind by k0s.
+type processes struct {
+	binDir string
+}
+
+// process is a process started from the k0s bin dir.
+type process struct {
+	pid    uint32
+	path   string
+	handle windows.Handle
+}
+
+// Name returns the name of the step
+func (p *processes) Name() string {
+	return "terminate processes step"
+}
+
+// Run terminates the processes started from the k0s bin dir
+func (p *processes) Run() error {
+	procs, err := p.list()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, proc := range procs {
+		logrus.Debugf("Terminating %s (PID %d)", proc.path, proc.pid)
+		if err := terminate(proc.handle); err != nil {
+			errs = append(errs, fmt.Errorf("failed to terminate %s (PID %d): %w", proc.path, proc.pid, err))
+		}
+		windows.CloseHandle(proc.handle)
+	}
+
+	return errors.Join(errs...)
+}
+
+// DryRun reports the processes that Run would terminate
+func (p *processes) DryRun(r *Report) error {
+	procs, err := p.list()
+	if err != nil {
+		return err
+	}
+
+	for _, proc := range procs {
+		r.Processes = append(r.Processes, proc.path+" (PID "+strconv.FormatUint(uint64(proc.pid), 10)+")")
+		windows.CloseHandle(proc.handle)
+	}
+
+	return nil
+}
+
+// list returns the processes started from the k0s bin dir. The caller is
+// responsible for closing their handles.
+func (p *processes) list() ([]process, error) {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, os.NewSyscallError("CreateToolhelp32Snapshot", err)
+	}
+	defer windows.CloseHandle(snapshot)
+
+	var procs []process
+	entry := windows.ProcessEntry32{Size: uint32(unsafe.Sizeof(windows.ProcessEntry32{}))}
+	for err = windows.Process32First(snapshot, &entry); err == nil; err = windows.Process32Next(snapshot, &entry) {
+		if entry.ProcessID == 0 || entry.ProcessID == uint32(os.Getpid()) {
+			continue
+		}
+
+		handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION|windows.PROCESS_TERMINATE|windows.SYNCHRONIZE, false, entry.ProcessID)
+		if err != nil {
+			// System processes can't be opened.
+			continue
+		}
+
+		path, err := imagePath(handle)
+		if err != nil || !isUnderPath(path, p.binDir) {
+			windows.CloseHandle(handle)
+			continue
+		}
+
+		procs = append(procs, process{entry.ProcessID, path, handle})
+	}
+	if !errors.Is(err, windows.ERROR_NO_MORE_FILES) {
+		for _, proc := range procs {
+			windows.CloseHandle(proc.handle)
+		}
+		return nil, os.NewSyscallError("Process32Next", err)
+	}
+
+	return procs, nil
+}
+
+// imagePath returns the path to the executable of the given process.
+func imagePath(handle windows.Handle) (string, error) {
+	buf := make([]uint16, windows.MAX_LONG_PATH)
+	size := uint32(len(buf))
+	if err := windows.QueryFullProcessImageName(handle, 0, &buf[0], &size); err != nil {
+		return "", os.NewSyscallError("QueryFullProcessImageName", err)
+	}
+	return windows.UTF16ToString(buf[:size]), nil
+}
+
+// terminate terminates the given process and waits until it's gone.
+func terminate(handle windows.Handle) error {
+	if err := windows.TerminateProcess(handle, 1); err != nil {
+		return os.NewSyscallError("TerminateProcess", err)
+	}
+
+	event, err := windows.WaitForSingleObject(handle, uint32((30 * time.Second).Milliseconds()))
+	switch {
+	case err != nil:
+		return os.NewSyscallError("WaitForSingleObject", err)
+	case event == uint32(windows.WAIT_TIMEOUT):
+		return errors.New("timed out waiting for the process to terminate")
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/install"
+
+	"github.com/sirupsen/logrus"
+)
+
+// purge removes the leftovers of the k0s services and users, i.e. the log and
+// PID files of the services, and the groups named after the users.
+type purge struct {
+	systemUsers *k0sv1beta1.SystemUser
+}
+
+// Name returns the name of the step
+func (p *purge) Name() string {
+	return "purge service and user leftovers step"
+}
+
+// Run removes the service log and PID files and the groups of the k0s users
+func (p *purge) Run() error {
+	files, err := p.files()
+	errs := []error{err}
+	for _, file := range files {
+		logrus.Debugf("removing %s", file)
+		if err := os.Remove(file); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := install.DeleteControllerGroups(p.systemUsers); err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete controller groups: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// DryRun reports the files and groups that Run would remove
+func (p *purge) DryRun(r *Report) error {
+	files, err := p.files()
+	r.Files = append(r.Files, files...)
+
+	groupNames, groupsErr := install.ExistingControllerGroups(p.systemUsers)
+	r.Groups = append(r.Groups, groupNames...)
+
+	return errors.Join(err, groupsErr)
+}
+
+func (p *purge) files() ([]string, error) {
+	var files []string
+	var errs []error
+	for _, role := range []string{"controller", "worker"} {
+		leftovers, err := install.ServiceLeftovers(role)
+		files = append(files, leftovers...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return files, errors.Join(errs...)
+}
@@ -0,0 +1,146 @@
+//go:build linux || windows
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/k0sproject/k0s/pkg/config"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The names of the built-in cleanup steps, which can be used as ordering hints
+// for registered steps. Not all of them are available on all platforms.
+const (
+	StepStopServices = "stop-services" // Windows only
+	StepProcesses    = "processes"     // Windows only
+	StepContainers   = "containers"    // Linux only
+	StepNetns        = "netns"         // Linux only
+	StepPodLogs      = "pod-logs"      // Linux only, if enabled
+	StepUsers        = "users"         // Linux only
+	StepServices     = "services"
+	StepPurge        = "purge"   // Linux only, if enabled
+	StepNetwork      = "network" // Linux only
+	StepHNS          = "hns"     // Windows only
+	StepDirectories  = "directories"
+	StepCNI          = "cni" // Linux only
+)
+
+// StepFactory creates a cleanup step for the given k0s configuration. It may
+// return a nil step if there's nothing to clean up on this host.
+type StepFactory func(k0sVars *config.CfgVars) (Step, error)
+
+// OrderHint specifies when a registered step runs, relative to the other
+// steps. Use [Before] or [After] to create one. The zero value runs the step
+// after all the other steps.
+type OrderHint struct {
+	before, after string
+}
+
+// Before returns a hint to run a step right before the step with the given name.
+func Before(step string) OrderHint {
+	return OrderHint{before: step}
+}
+
+// After returns a hint to run a step right after the step with the given name.
+func After(step string) OrderHint {
+	return OrderHint{after: step}
+}
+
+type registration struct {
+	name    string
+	hint    OrderHint
+	factory StepFactory
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []registration
+)
+
+// RegisterStep registers an additional cleanup step which is run by k0s reset.
+// This allows components to clean up any state that they leave behind on the
+// host. Steps implementing a State() string method are skipped if that state
+// is to be preserved. RegisterStep panics if the name is already taken, so
+// it's meant to be called from init functions.
+func RegisterStep(name string, hint OrderHint, factory StepFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if isBuiltinStep(name) || slices.ContainsFunc(registry, func(r registration) bool { return r.name == name }) {
+		panic(fmt.Sprintf("cleanup step %q is already registered", name))
+	}
+
+	registry = append(registry, registration{name, hint, factory})
+}
+
+func isBuiltinStep(name string) bool {
+	return slices.Contains([]string{
+		StepStopServices, StepProcesses, StepContainers, StepNetns, StepPodLogs, StepUsers, StepServices,
+		StepPurge, StepNetwork, StepHNS, StepDirectories, StepCNI,
+	}, name)
+}
+
+// namedStep is a cleanup step along with the name used for ordering.
+type namedStep struct {
+	name string
+	Step
+	// anchor is the name of the step that this step has been placed after.
+	anchor string
+}
+
+// addRegisteredSteps creates all registered steps and adds them to the
+// built-in steps, according to their ordering hints. Steps are placed in
+// registration order if they share the same hint. If a hint refers to an
+// unknown step, the step is run after all the other steps.
+func addRegisteredSteps(steps []namedStep, k0sVars *config.CfgVars) ([]namedStep, error) {
+	registryMu.Lock()
+	registrations := slices.Clone(registry)
+	registryMu.Unlock()
+
+	for _, r := range registrations {
+		step, err := r.factory(k0sVars)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cleanup step %q: %w", r.name, err)
+		}
+		if step == nil {
+			continue
+		}
+
+		steps = insertStep(steps, namedStep{name: r.name, Step: step}, r.hint)
+	}
+
+	return steps, nil
+}
+
+// insertStep inserts the step into the steps, according to the ordering hint.
+func insertStep(steps []namedStep, step namedStep, hint OrderHint) []namedStep {
+	switch {
+	case hint.before != "":
+		if i := slices.IndexFunc(steps, func(s namedStep) bool { return s.name == hint.before }); i >= 0 {
+			return slices.Insert(steps, i, step)
+		}
+		logrus.Debugf("Unknown cleanup step %q, running %q last", hint.before, step.name)
+
+	case hint.after != "":
+		if i := slices.IndexFunc(steps, func(s namedStep) bool { return s.name == hint.after }); i >= 0 {
+			// Keep steps that have been placed after the same step in
+			// registration order.
+			i++
+			for i < len(steps) && steps[i].anchor == hint.after {
+				i++
+			}
+			step.anchor = hint.after
+			return slices.Insert(steps, i, step)
+		}
+		logrus.Debugf("Unknown cleanup step %q, running %q last", hint.after, step.name)
+	}
+
+	return append(steps, step)
+}
@@ -0,0 +1,61 @@
+//go:build linux || windows
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStep string
+
+func (s fakeStep) Name() string         { return string(s) }
+func (s fakeStep) Run() error           { return nil }
+func (s fakeStep) DryRun(*Report) error { return nil }
+
+func TestInsertStep(t *testing.T) {
+	steps := []namedStep{
+		{name: StepContainers, Step: fakeStep(StepContainers)},
+		{name: StepServices, Step: fakeStep(StepServices)},
+		{name: StepDirectories, Step: fakeStep(StepDirectories)},
+	}
+
+	for _, step := range []struct {
+		name string
+		hint OrderHint
+	}{
+		{"last", OrderHint{}},
+		{"before-directories", Before(StepDirectories)},
+		{"after-containers-1", After(StepContainers)},
+		{"after-containers-2", After(StepContainers)},
+		{"after-unknown", After("unknown")},
+		{"before-last", Before("last")},
+	} {
+		steps = insertStep(steps, namedStep{name: step.name, Step: fakeStep(step.name)}, step.hint)
+	}
+
+	var names []string
+	for _, step := range steps {
+		names = append(names, step.Name())
+	}
+
+	assert.Equal(t, []string{
+		StepContainers,
+		"after-containers-1",
+		"after-containers-2",
+		StepServices,
+		"before-directories",
+		StepDirectories,
+		"before-last",
+		"last",
+		"after-unknown",
+	}, names)
+}
+
+func TestRegisterStep_Duplicate(t *testing.T) {
+	assert.Panics(t, func() { RegisterStep(StepDirectories, OrderHint{}, nil) })
+}
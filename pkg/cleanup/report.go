@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+// Report lists the changes that the cleanup steps would make to the host.
+type Report struct {
+	// Unmounts are the mount points that would be unmounted.
+	Unmounts []string `json:"unmounts,omitempty"`
+	// Directories are the directories that would be removed recursively.
+	Directories []string `json:"directories,omitempty"`
+	// Files are the files that would be removed.
+	Files []string `json:"files,omitempty"`
+	// PodLogsSize is the total size of the pod logs that would be removed, in
+	// bytes.
+	PodLogsSize int64 `json:"podLogsSize,omitempty"`
+	// Services are the k0s services that would be uninstalled.
+	Services []string `json:"services,omitempty"`
+	// Users are the system users that would be deleted.
+	Users []string `json:"users,omitempty"`
+	// Groups are the system groups that would be deleted.
+	Groups []string `json:"groups,omitempty"`
+	// Containers are the IDs of the containers that would be stopped and
+	// removed, along with their processes.
+	Containers []string `json:"containers,omitempty"`
+	// Interfaces are the network interfaces that would be deleted.
+	Interfaces []string `json:"interfaces,omitempty"`
+	// NetworkNamespaces are the pinned network namespaces of pods that would
+	// be removed.
+	NetworkNamespaces []string `json:"networkNamespaces,omitempty"`
+	// IPTablesChains are the iptables chains that would be deleted, along with
+	// the rules jumping to them, in the "binary table/chain" format.
+	IPTablesChains []string `json:"iptablesChains,omitempty"`
+	// IPVSServices are the IPVS virtual servers that would be deleted.
+	IPVSServices []string `json:"ipvsServices,omitempty"`
+	// Processes are the processes that would be terminated.
+	Processes []string `json:"processes,omitempty"`
+	// HNSNetworks are the names of the Host Networking Service networks that
+	// would be deleted, along with their endpoints.
+	HNSNetworks []string `json:"hnsNetworks,omitempty"`
+	// HNSLoadBalancers are the IDs of the Host Networking Service load balancers
+	// that would be deleted.
+	HNSLoadBalancers []string `json:"hnsLoadBalancers,omitempty"`
+	// EtcdSnapshot is the file to which an etcd snapshot would be saved
+	// before the reset.
+	EtcdSnapshot string `json:"etcdSnapshot,omitempty"`
+}
+
+// Result is the outcome of a cleanup.
+type Result struct {
+	// Success is set if all cleanup steps succeeded.
+	Success bool `json:"success"`
+	// Error is the error that made the reset fail, if any.
+	Error string `json:"error,omitempty"`
+	// EtcdSnapshot is the file to which an etcd snapshot has been saved
+	// before the reset.
+	EtcdSnapshot string `json:"etcdSnapshot,omitempty"`
+	// Steps are the results of the cleanup steps, in the order they ran.
+	Steps []StepResult `json:"steps"`
+}
+
+// StepStatus is the status of a cleanup step.
+type StepStatus string
+
+const (
+	StepSucceeded StepStatus = "Succeeded"
+	StepFailed    StepStatus = "Failed"
+	StepSkipped   StepStatus = "Skipped"
+)
+
+// StepResult is the outcome of a cleanup step.
+type StepResult struct {
+	// Name is the name of the step, as used for ordering hints.
+	Name string `json:"name"`
+	// Description is the human readable name of the step.
+	Description string `json:"description"`
+	// Status is the status of the step.
+	Status StepStatus `json:"status"`
+	// Reason is the reason for skipping the step.
+	Reason string `json:"reason,omitempty"`
+	// Error is the error that made the step fail.
+	Error string `json:"error,omitempty"`
+	// Changes are the changes that the step was about to make, as determined
+	// right before it ran.
+	Changes *Report `json:"changes,omitempty"`
+}
@@ -31,6 +31,21 @@ func (s *services) Run() error {
 	return errors.Join(errs...)
 }
 
+// DryRun reports the k0s services that Run would uninstall
+func (s *services) DryRun(r *Report) error {
+	var errs []error
+
+	for _, role := range []string{"controller", "worker"} {
+		if installed, err := install.IsServiceInstalled(role); err != nil {
+			errs = append(errs, err)
+		} else if installed {
+			r.Services = append(r.Services, install.GetServiceConfig(role).Name)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 func isExitCode(err error, exitcode int) bool {
 	var e *exec.ExitError
 	return errors.As(err, &e) && e.ExitCode() == exitcode
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/k0sproject/k0s/pkg/install"
+
+	"github.com/sirupsen/logrus"
+)
+
+// stopServices stops the k0s services, so that they don't restart any
+// components while the host is being cleaned up.
+type stopServices struct{}
+
+// Name returns the name of the step
+func (s *stopServices) Name() string {
+	return "stop service step"
+}
+
+// Run stops the k0s services that are running on the host
+func (s *stopServices) Run() error {
+	var errs []error
+
+	for _, role := range []string{"controller", "worker"} {
+		installed, err := install.IsServiceInstalled(role)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !installed {
+			continue
+		}
+
+		logrus.Debugf("Stopping %s service", install.GetServiceConfig(role).Name)
+		if err := install.StopService(role); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %s service: %w", role, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// DryRun doesn't report anything, since the services that Run would stop are
+// reported as services to be uninstalled
+func (s *stopServices) DryRun(*Report) error {
+	return nil
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/worker"
+	workerconfig "github.com/k0sproject/k0s/pkg/component/worker/config"
+	"github.com/k0sproject/k0s/pkg/component/worker/containerd"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/constant"
+	"github.com/k0sproject/k0s/pkg/container/runtime"
+)
+
+func newSteps(debug bool, k0sVars *config.CfgVars, systemUsers *k0sv1beta1.SystemUser, criSocketFlag string, directories *directories, purgeLeftovers, purgePodLogs bool) ([]namedStep, error) {
+	containers, err := newContainersStep(debug, k0sVars, criSocketFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []namedStep{
+		{name: StepContainers, Step: containers},
+		{name: StepNetns, Step: &netns{dir: netnsDir, containers: containers}},
+	}
+	if purgePodLogs {
+		steps = append(steps, namedStep{name: StepPodLogs, Step: &podLogs{
+			podLogsDir:       "/var/log/pods",
+			containerLogsDir: "/var/log/containers",
+			kubeletRootDir:   k0sVars.KubeletRootDir,
+		}})
+	}
+	steps = append(steps,
+		namedStep{name: StepUsers, Step: &users{systemUsers: systemUsers}},
+		namedStep{name: StepServices, Step: &services{}},
+	)
+	if purgeLeftovers {
+		steps = append(steps, namedStep{name: StepPurge, Step: &purge{systemUsers: systemUsers}})
+	}
+
+	return append(steps, []namedStep{
+		// The network step uses the iptables binaries in the bin dir, so it
+		// needs to run before the directories step removes them.
+		{name: StepNetwork, Step: &network{binDir: k0sVars.BinDir}},
+		{name: StepDirectories, Step: directories},
+		{name: StepCNI, Step: &cni{}},
+	}...), nil
+}
+
+func newContainersStep(debug bool, k0sVars *config.CfgVars, criSocketFlag string) (*containers, error) {
+	runtimeEndpoint, err := worker.GetContainerRuntimeEndpoint(criSocketFlag, k0sVars.RunDir)
+	if err != nil {
+		return nil, err
+	}
+
+	containers := containers{
+		containerRuntime: runtime.NewContainerRuntime(runtimeEndpoint),
+	}
+
+	// Custom container runtimes may be containerd, as well. This is checked
+	// via the CRI API before the containerd API is used.
+	if runtimeEndpoint.Scheme == "unix" {
+		containers.containerdAddress = runtimeEndpoint.Path
+	}
+
+	if criSocketFlag == "" {
+		logLevel := "error"
+		if debug {
+			logLevel = "debug"
+		}
+		containers.managedContainerd = containerd.NewComponent(logLevel, k0sVars, &workerconfig.Profile{
+			PauseImage: &k0sv1beta1.ImageSpec{
+				Image:   constant.KubePauseContainerImage,
+				Version: constant.KubePauseContainerImageVersion,
+			},
+		})
+	}
+
+	return &containers, nil
+}
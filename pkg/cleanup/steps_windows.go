@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/config"
+)
+
+func newSteps(_ bool, k0sVars *config.CfgVars, _ *k0sv1beta1.SystemUser, _ string, directories *directories, _, _ bool) ([]namedStep, error) {
+	return []namedStep{
+		{name: StepStopServices, Step: &stopServices{}},
+		// Any leftover processes need to be gone before the bin dir can be
+		// removed, since Windows doesn't allow to delete running executables.
+		{name: StepProcesses, Step: &processes{binDir: k0sVars.BinDir}},
+		{name: StepHNS, Step: &hnsNetworks{}},
+		{name: StepServices, Step: &services{}},
+		{name: StepDirectories, Step: directories},
+	}, nil
+}
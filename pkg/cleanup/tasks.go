@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/sirupsen/logrus"
+)
+
+// taskStopTimeout is the time that tasks get to exit after being signaled.
+const taskStopTimeout = 10 * time.Second
+
+// stopContainerdTasks stops and deletes the tasks of all Kubernetes
+// containers via the containerd API, along with the containers themselves.
+// This reaps the containerd shims, and releases the mounts of the containers.
+func stopContainerdTasks(ctx context.Context, address string) error {
+	client, err := containerd.New(address, containerd.WithDefaultNamespace("k8s.io"), containerd.WithTimeout(10*time.Second))
+	if err != nil {
+		return fmt.Errorf("failed to connect to containerd: %w", err)
+	}
+	defer client.Close()
+
+	containers, err := client.Containers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var errs []error
+	for _, container := range containers {
+		if err := stopContainerdTask(ctx, container); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop task of container %s: %w", container.ID(), err))
+			continue
+		}
+
+		logrus.Debugf("Deleting container %s", container.ID())
+		if err := container.Delete(ctx, containerd.WithSnapshotCleanup); err != nil && !errdefs.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete container %s: %w", container.ID(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// stopContainerdTask stops the task of the given container, if any, and
+// deletes it. The task is terminated gracefully and killed if it doesn't exit
+// in time.
+func stopContainerdTask(ctx context.Context, container containerd.Container) error {
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	status, err := task.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	if status.Status == containerd.Running || status.Status == containerd.Paused {
+		exited, err := task.Wait(ctx)
+		if err != nil {
+			return err
+		}
+
+		logrus.Debugf("Terminating task of container %s", container.ID())
+		if err := task.Kill(ctx, syscall.SIGTERM, containerd.WithKillAll); err != nil && !errdefs.IsNotFound(err) {
+			return err
+		}
+
+		select {
+		case <-exited:
+		case <-time.After(taskStopTimeout):
+			logrus.Debugf("Killing task of container %s", container.ID())
+			if err := task.Kill(ctx, syscall.SIGKILL, containerd.WithKillAll); err != nil && !errdefs.IsNotFound(err) {
+				return err
+			}
+			select {
+			case <-exited:
+			case <-time.After(taskStopTimeout):
+				return fmt.Errorf("task didn't exit within %s after being killed", taskStopTimeout)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if _, err := task.Delete(ctx, containerd.WithProcessKill); err != nil && !errdefs.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
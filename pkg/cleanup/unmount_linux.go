@@ -4,9 +4,80 @@
 package cleanup
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
+	"k8s.io/mount-utils"
 )
 
+// unmountTimeout is the time after which an unmount, or checking a mount
+// point, is considered to be stuck.
+const unmountTimeout = 10 * time.Second
+
 func UnmountLazy(path string) error {
 	return unix.Unmount(path, unix.MNT_DETACH)
 }
+
+// unmount unmounts the given path. Stale or stuck mounts, such as NFS mounts
+// whose server is gone, are unmounted forcibly, falling back to a lazy
+// unmount if that fails, too.
+func unmount(path string) error {
+	if err := checkMount(path); err != nil {
+		logrus.WithError(err).Warnf("%s seems to be stale, force unmounting", path)
+	} else {
+		err := withTimeout(func() error { return mount.New("").Unmount(path) })
+		if err == nil {
+			return nil
+		}
+		logrus.WithError(err).Warnf("failed to unmount %s, force unmounting", path)
+	}
+
+	err := withTimeout(func() error { return unix.Unmount(path, unix.MNT_FORCE) })
+	if err == nil {
+		return nil
+	}
+
+	// if we fail to unmount, try lazy unmount so
+	// we don't end up deleting stuff that we
+	// shouldn't
+	logrus.WithError(err).Warnf("lazy unmounting %v", path)
+	if lazyErr := UnmountLazy(path); lazyErr != nil {
+		return fmt.Errorf("failed to unmount %s: %w", path, errors.Join(err, lazyErr))
+	}
+
+	return nil
+}
+
+// checkMount checks if the given mount point is accessible. It returns an
+// error if the mount point is stale, or if it can't be stat-ed in time.
+func checkMount(path string) error {
+	return withTimeout(func() error {
+		if _, err := os.Stat(path); mount.IsCorruptedMnt(err) {
+			return err
+		}
+		return nil
+	})
+}
+
+// withTimeout runs the given function, giving up after the unmount timeout.
+// Since file system operations on stuck mounts can't be interrupted, the
+// function may continue to run in the background.
+func withTimeout(f func() error) error {
+	done := make(chan error, 1)
+	go func() { done <- f() }()
+
+	timer := time.NewTimer(unmountTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return context.DeadlineExceeded
+	}
+}
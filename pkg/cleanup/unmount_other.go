@@ -14,3 +14,7 @@ import (
 func UnmountLazy(string) error {
 	return fmt.Errorf("%w on %s", errors.ErrUnsupported, runtime.GOOS)
 }
+
+func unmount(string) error {
+	return fmt.Errorf("%w on %s", errors.ErrUnsupported, runtime.GOOS)
+}
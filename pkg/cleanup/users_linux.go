@@ -27,3 +27,10 @@ func (u *users) Run() error {
 	}
 	return nil
 }
+
+// DryRun reports the controller users that Run would remove
+func (u *users) DryRun(r *Report) error {
+	userNames, err := install.ExistingControllerUsers(u.systemUsers)
+	r.Users = append(r.Users, userNames...)
+	return err
+}
@@ -25,6 +25,8 @@ type ClusterConfigsGetter interface {
 type ClusterConfigInterface interface {
 	Create(ctx context.Context, clusterConfig *k0sv1beta1.ClusterConfig, opts v1.CreateOptions) (*k0sv1beta1.ClusterConfig, error)
 	Update(ctx context.Context, clusterConfig *k0sv1beta1.ClusterConfig, opts v1.UpdateOptions) (*k0sv1beta1.ClusterConfig, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, clusterConfig *k0sv1beta1.ClusterConfig, opts v1.UpdateOptions) (*k0sv1beta1.ClusterConfig, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*k0sv1beta1.ClusterConfig, error)
 	List(ctx context.Context, opts v1.ListOptions) (*k0sv1beta1.ClusterConfigList, error)
@@ -0,0 +1,317 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cloudidentity verifies the signed identities that cloud providers
+// issue to their instances.
+package cloudidentity
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/cloudmetadata"
+
+	"github.com/golang-jwt/jwt/v5"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+// Audience is the audience of the GCP identity tokens that instances present
+// to join the cluster.
+const Audience = "k0s-join"
+
+// The URL of the certificates that Google signs identity tokens with.
+const googleCertsURL = "https://www.googleapis.com/oauth2/v1/certs"
+
+// Instance is a cloud instance whose identity has been verified.
+type Instance struct {
+	Provider cloudmetadata.Provider
+	ID       string
+
+	// When the verified identity has been issued, and until when it's valid.
+	IssuedAt, ExpiresAt time.Time
+}
+
+func (i *Instance) String() string {
+	return string(i.Provider) + "/" + i.ID
+}
+
+// Verifier verifies signed instance identities and checks that the instances
+// satisfy the configured constraints.
+type Verifier struct {
+	config         *v1beta1.CloudIdentityJoin
+	awsCerts       []*x509.Certificate
+	caFingerprint  string
+	googleCertsURL string
+	httpClient     *http.Client
+	now            func() time.Time
+
+	googleKeysMu      sync.Mutex
+	googleKeys        map[string]any
+	googleKeysFetched time.Time
+	googleKeysExpire  time.Time
+}
+
+// NewVerifier creates a verifier for the given configuration. The cluster's
+// PEM encoded CA certificate is what presigned GetCallerIdentity requests need
+// to be bound to.
+func NewVerifier(config *v1beta1.CloudIdentityJoin, caCert []byte) (*Verifier, error) {
+	v := &Verifier{
+		config:         config,
+		googleCertsURL: googleCertsURL,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+
+	if config.AWS != nil && config.AWS.MethodOrDefault() == v1beta1.AWSIdentityMethodGetCallerIdentity {
+		fingerprint, err := caFingerprint(caCert)
+		if err != nil {
+			return nil, err
+		}
+		v.caFingerprint = fingerprint
+	}
+
+	if config.AWS != nil && config.AWS.MethodOrDefault() == v1beta1.AWSIdentityMethodInstanceIdentityDocument {
+		certs, err := certutil.ParseCertsPEM([]byte(config.AWS.Certificates))
+		if err != nil {
+			return nil, fmt.Errorf("invalid AWS certificates: %w", err)
+		}
+		v.awsCerts = certs
+	}
+
+	return v, nil
+}
+
+// Verify verifies the identity in the given request and returns the instance
+// that it belongs to.
+func (v *Verifier) Verify(ctx context.Context, req *v1beta1.CloudIdentityJoinRequest) (*Instance, error) {
+	switch provider := cloudmetadata.Provider(req.Provider); provider {
+	case cloudmetadata.ProviderAWS:
+		if v.config.AWS == nil {
+			return nil, errors.New("joining AWS instances isn't enabled")
+		}
+		if v.config.AWS.MethodOrDefault() == v1beta1.AWSIdentityMethodGetCallerIdentity {
+			return v.verifyAWSCallerIdentity(ctx, req.CallerIdentityRequest)
+		}
+		return v.verifyAWS(req.Identity, req.Signature)
+
+	case cloudmetadata.ProviderGCP:
+		if v.config.GCP == nil {
+			return nil, errors.New("joining GCP instances isn't enabled")
+		}
+		return v.verifyGCP(ctx, req.Identity)
+
+	default:
+		return nil, fmt.Errorf("unsupported cloud provider %q", provider)
+	}
+}
+
+// verifyAWS verifies an instance identity document. Its signature is an
+// RSA-SHA256 signature, made with one of the regional AWS certificates. As
+// instance identity documents don't expire, they're only accepted for a
+// limited time after the instance has been launched or started.
+func (v *Verifier) verifyAWS(document, signature string) (*Instance, error) {
+	sig, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(signature), ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	digest := sha256.Sum256([]byte(document))
+	if !slices.ContainsFunc(v.awsCerts, func(cert *x509.Certificate) bool {
+		key, ok := cert.PublicKey.(*rsa.PublicKey)
+		return ok && rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+	}) {
+		return nil, errors.New("the instance identity document isn't signed by any of the AWS certificates")
+	}
+
+	var identity struct {
+		AccountID   string    `json:"accountId"`
+		Region      string    `json:"region"`
+		InstanceID  string    `json:"instanceId"`
+		PendingTime time.Time `json:"pendingTime"`
+	}
+	if err := json.Unmarshal([]byte(document), &identity); err != nil {
+		return nil, fmt.Errorf("failed to parse instance identity document: %w", err)
+	}
+
+	config := v.config.AWS
+	maxAge := config.MaxDocumentAgeOrDefault()
+	switch {
+	case identity.InstanceID == "":
+		return nil, errors.New("the instance identity document has no instance ID")
+	case identity.PendingTime.IsZero():
+		return nil, errors.New("the instance identity document has no pending time")
+	case v.now().Sub(identity.PendingTime) > maxAge:
+		return nil, fmt.Errorf("the instance has been launched or started more than %s ago", maxAge)
+	case !slices.Contains(config.AccountIDs, identity.AccountID):
+		return nil, fmt.Errorf("account %q isn't allowed to join", identity.AccountID)
+	case len(config.Regions) > 0 && !slices.Contains(config.Regions, identity.Region):
+		return nil, fmt.Errorf("region %q isn't allowed to join", identity.Region)
+	}
+
+	return &Instance{
+		Provider:  cloudmetadata.ProviderAWS,
+		ID:        identity.InstanceID,
+		IssuedAt:  identity.PendingTime,
+		ExpiresAt: identity.PendingTime.Add(maxAge),
+	}, nil
+}
+
+type gcpClaims struct {
+	jwt.RegisteredClaims
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Google        struct {
+		ComputeEngine struct {
+			ProjectID  string `json:"project_id"`
+			Zone       string `json:"zone"`
+			InstanceID string `json:"instance_id"`
+		} `json:"compute_engine"`
+	} `json:"google"`
+}
+
+// verifyGCP verifies an identity token in the full format, which includes the
+// details of the Compute Engine instance.
+func (v *Verifier) verifyGCP(ctx context.Context, token string) (*Instance, error) {
+	var claims gcpClaims
+	if _, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.googleKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithAudience(Audience),
+		jwt.WithIssuer("https://accounts.google.com"),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithTimeFunc(v.now),
+	); err != nil {
+		return nil, fmt.Errorf("invalid identity token: %w", err)
+	}
+
+	config, instance := v.config.GCP, &claims.Google.ComputeEngine
+	switch {
+	case instance.InstanceID == "":
+		return nil, errors.New("the identity token has no instance ID, it needs to be in the full format")
+	case !slices.Contains(config.ProjectIDs, instance.ProjectID):
+		return nil, fmt.Errorf("project %q isn't allowed to join", instance.ProjectID)
+	case len(config.ServiceAccounts) > 0 && (!claims.EmailVerified || !slices.Contains(config.ServiceAccounts, claims.Email)):
+		return nil, fmt.Errorf("service account %q isn't allowed to join", claims.Email)
+	case len(config.Zones) > 0 && !slices.Contains(config.Zones, instance.Zone):
+		return nil, fmt.Errorf("zone %q isn't allowed to join", instance.Zone)
+	}
+
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+	return &Instance{
+		Provider:  cloudmetadata.ProviderGCP,
+		ID:        instance.InstanceID,
+		IssuedAt:  issuedAt,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// The minimum time between fetching Google's certificates for unknown key IDs.
+const googleKeysMinRefresh = time.Minute
+
+// googleKey returns the public key with the given ID from the certificates
+// that Google signs identity tokens with. The certificates are cached for as
+// long as Google's Cache-Control header allows, and refreshed early, but not
+// too often, when encountering an unknown key ID.
+func (v *Verifier) googleKey(ctx context.Context, kid string) (any, error) {
+	v.googleKeysMu.Lock()
+	defer v.googleKeysMu.Unlock()
+
+	now := v.now()
+	if key, ok := v.googleKeys[kid]; ok && now.Before(v.googleKeysExpire) {
+		return key, nil
+	}
+	if now.Before(v.googleKeysExpire) && now.Sub(v.googleKeysFetched) < googleKeysMinRefresh {
+		return nil, fmt.Errorf("unknown key ID %q", kid)
+	}
+
+	keys, maxAge, err := v.fetchGoogleKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v.googleKeys, v.googleKeysFetched, v.googleKeysExpire = keys, now, now.Add(maxAge)
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID %q", kid)
+	}
+	return key, nil
+}
+
+// fetchGoogleKeys fetches the public keys that Google signs identity tokens
+// with, along with the time for which they may be cached.
+func (v *Verifier) fetchGoogleKeys(ctx context.Context) (map[string]any, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.googleCertsURL, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch Google certificates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("failed to fetch Google certificates: %s", resp.Status)
+	}
+
+	var certs map[string]string
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&certs); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse Google certificates: %w", err)
+	}
+	keys := make(map[string]any, len(certs))
+	for kid, cert := range certs {
+		parsed, err := certutil.ParseCertsPEM([]byte(cert))
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to parse Google certificate %q: %w", kid, err)
+		}
+		keys[kid] = parsed[0].PublicKey
+	}
+
+	return keys, cacheMaxAge(resp.Header), nil
+}
+
+// cacheMaxAge returns the time for which a response with the given header may
+// be cached, based on its Cache-Control header.
+func cacheMaxAge(header http.Header) time.Duration {
+	var maxAge time.Duration
+	for directive := range strings.SplitSeq(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-cache", "no-store":
+			return 0
+		case "max-age":
+			if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+				maxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	if age, err := strconv.ParseUint(header.Get("Age"), 10, 32); err == nil {
+		maxAge -= time.Duration(age) * time.Second
+	}
+	return max(maxAge, 0)
+}
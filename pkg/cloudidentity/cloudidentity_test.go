@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudidentity
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/cloudmetadata"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifier_AWS(t *testing.T) {
+	key, cert := generateCert(t)
+	otherKey, _ := generateCert(t)
+
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	verifier, err := NewVerifier(&v1beta1.CloudIdentityJoin{AWS: &v1beta1.AWSIdentityJoin{
+		Certificates: cert,
+		AccountIDs:   []string{"123456789012"},
+		Regions:      []string{"eu-west-1"},
+	}}, nil)
+	require.NoError(t, err)
+	verifier.now = func() time.Time { return now }
+
+	sign := func(key *rsa.PrivateKey, document string) string {
+		digest := sha256.Sum256([]byte(document))
+		sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(sig)
+	}
+
+	for _, test := range []struct {
+		name, document string
+		key            *rsa.PrivateKey
+		err            string
+	}{
+		{"valid", `{"accountId":"123456789012","region":"eu-west-1","instanceId":"i-0123","pendingTime":"2026-10-15T11:55:00Z"}`, key, ""},
+		{"other_key", `{"accountId":"123456789012","region":"eu-west-1","instanceId":"i-0123","pendingTime":"2026-10-15T11:55:00Z"}`, otherKey, "isn't signed by any of the AWS certificates"},
+		{"other_account", `{"accountId":"210987654321","region":"eu-west-1","instanceId":"i-0123","pendingTime":"2026-10-15T11:55:00Z"}`, key, `account "210987654321" isn't allowed to join`},
+		{"other_region", `{"accountId":"123456789012","region":"us-east-1","instanceId":"i-0123","pendingTime":"2026-10-15T11:55:00Z"}`, key, `region "us-east-1" isn't allowed to join`},
+		{"started_long_ago", `{"accountId":"123456789012","region":"eu-west-1","instanceId":"i-0123","pendingTime":"2026-10-15T11:45:00Z"}`, key, "the instance has been launched or started more than 10m0s ago"},
+		{"no_pending_time", `{"accountId":"123456789012","region":"eu-west-1","instanceId":"i-0123"}`, key, "the instance identity document has no pending time"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			instance, err := verifier.Verify(t.Context(), &v1beta1.CloudIdentityJoinRequest{
+				Provider:  "aws",
+				Identity:  test.document,
+				Signature: sign(test.key, test.document),
+			})
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+			} else if assert.NoError(t, err) {
+				pendingTime := time.Date(2026, 10, 15, 11, 55, 0, 0, time.UTC)
+				assert.Equal(t, &Instance{
+					Provider:  cloudmetadata.ProviderAWS,
+					ID:        "i-0123",
+					IssuedAt:  pendingTime,
+					ExpiresAt: pendingTime.Add(10 * time.Minute),
+				}, instance)
+			}
+		})
+	}
+
+	t.Run("tampered", func(t *testing.T) {
+		document := `{"accountId":"123456789012","region":"eu-west-1","instanceId":"i-0123","pendingTime":"2026-10-15T11:55:00Z"}`
+		_, err := verifier.Verify(t.Context(), &v1beta1.CloudIdentityJoinRequest{
+			Provider:  "aws",
+			Identity:  document + " ",
+			Signature: sign(key, document),
+		})
+		assert.ErrorContains(t, err, "isn't signed by any of the AWS certificates")
+	})
+
+	t.Run("gcp_disabled", func(t *testing.T) {
+		_, err := verifier.Verify(t.Context(), &v1beta1.CloudIdentityJoinRequest{Provider: "gcp"})
+		assert.ErrorContains(t, err, "joining GCP instances isn't enabled")
+	})
+}
+
+func TestVerifier_GCP(t *testing.T) {
+	key, cert := generateCert(t)
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]string{"the-kid": cert}))
+	}))
+	t.Cleanup(server.Close)
+
+	now := time.Now().Truncate(time.Second)
+	verifier, err := NewVerifier(&v1beta1.CloudIdentityJoin{GCP: &v1beta1.GCPIdentityJoin{
+		ProjectIDs:      []string{"the-project"},
+		ServiceAccounts: []string{"workers@the-project.iam.gserviceaccount.com"},
+	}}, nil)
+	require.NoError(t, err)
+	verifier.googleCertsURL = server.URL
+	verifier.httpClient = server.Client()
+	verifier.now = func() time.Time { return now }
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":            "https://accounts.google.com",
+			"aud":            Audience,
+			"iat":            now.Add(-time.Minute).Unix(),
+			"exp":            now.Add(time.Hour).Unix(),
+			"email":          "workers@the-project.iam.gserviceaccount.com",
+			"email_verified": true,
+			"google": map[string]any{"compute_engine": map[string]any{
+				"project_id":  "the-project",
+				"zone":        "us-central1-a",
+				"instance_id": "1234567890",
+			}},
+		}
+	}
+
+	for _, test := range []struct {
+		name   string
+		kid    string
+		modify func(jwt.MapClaims)
+		err    string
+	}{
+		{"valid", "the-kid", func(jwt.MapClaims) {}, ""},
+		{"unknown_kid", "other-kid", func(jwt.MapClaims) {}, `unknown key ID "other-kid"`},
+		{"other_audience", "the-kid", func(c jwt.MapClaims) { c["aud"] = "other" }, "invalid identity token"},
+		{"expired", "the-kid", func(c jwt.MapClaims) { c["exp"] = now.Add(-time.Minute).Unix() }, "token is expired"},
+		{"other_project", "the-kid", func(c jwt.MapClaims) {
+			c["google"].(map[string]any)["compute_engine"].(map[string]any)["project_id"] = "other-project"
+		}, `project "other-project" isn't allowed to join`},
+		{"other_service_account", "the-kid", func(c jwt.MapClaims) { c["email"] = "other@the-project.iam.gserviceaccount.com" }, `service account "other@the-project.iam.gserviceaccount.com" isn't allowed to join`},
+		{"standard_format", "the-kid", func(c jwt.MapClaims) { delete(c, "google") }, "it needs to be in the full format"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			claims := validClaims()
+			test.modify(claims)
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+			token.Header["kid"] = test.kid
+			signed, err := token.SignedString(key)
+			require.NoError(t, err)
+
+			instance, err := verifier.Verify(t.Context(), &v1beta1.CloudIdentityJoinRequest{Provider: "gcp", Identity: signed})
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, &Instance{
+					Provider:  cloudmetadata.ProviderGCP,
+					ID:        "1234567890",
+					IssuedAt:  now.Add(-time.Minute),
+					ExpiresAt: now.Add(time.Hour),
+				}, instance)
+			}
+		})
+	}
+
+	assert.Equal(t, int32(1), fetches.Load(), "the certificates should have been fetched once and cached afterwards")
+}
+
+func TestCacheMaxAge(t *testing.T) {
+	for _, test := range []struct {
+		cacheControl, age string
+		expected          time.Duration
+	}{
+		{"public, max-age=19528, must-revalidate, no-transform", "", 19528 * time.Second},
+		{"max-age=60", "20", 40 * time.Second},
+		{"max-age=60", "120", 0},
+		{"no-cache, max-age=60", "", 0},
+		{"", "", 0},
+	} {
+		t.Run(test.cacheControl, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("Cache-Control", test.cacheControl)
+			header.Set("Age", test.age)
+			assert.Equal(t, test.expected, cacheMaxAge(header))
+		})
+	}
+}
+
+func generateCert(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "test"}}, &key.PublicKey, key)
+	require.NoError(t, err)
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
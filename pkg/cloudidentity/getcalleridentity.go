@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudidentity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/cloudmetadata"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pubkeypin"
+)
+
+// CallerIdentityHeader is the header that binds presigned GetCallerIdentity
+// requests to a cluster, so that they can't be used to join other clusters.
+// Its value is the fingerprint of the cluster's CA certificate.
+const CallerIdentityHeader = "X-K0s-Cluster-Ca"
+
+// The maximum age of presigned GetCallerIdentity requests. STS rejects them
+// after 15 minutes, too.
+const callerIdentityMaxAge = 15 * time.Minute
+
+// The hosts of the regional and global STS endpoints.
+var stsHost = regexp.MustCompile(`^sts(\.[a-z0-9-]+)?\.amazonaws\.com(\.cn)?$`)
+
+// PresignCallerIdentity presigns an STS GetCallerIdentity request with the
+// credentials of the instance profile, bound to the cluster with the given PEM
+// encoded CA certificate.
+func PresignCallerIdentity(ctx context.Context, caCert []byte) (*v1beta1.PresignedRequest, error) {
+	fingerprint, err := caFingerprint(caCert)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithEC2IMDSRegion())
+	if err != nil {
+		return nil, err
+	}
+
+	presigned, err := sts.NewPresignClient(sts.NewFromConfig(cfg)).PresignGetCallerIdentity(ctx, nil, func(o *sts.PresignOptions) {
+		o.ClientOptions = append(o.ClientOptions, func(o *sts.Options) {
+			o.APIOptions = append(o.APIOptions, smithyhttp.SetHeaderValue(CallerIdentityHeader, fingerprint))
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1beta1.PresignedRequest{URL: presigned.URL, Header: presigned.SignedHeader}, nil
+}
+
+// verifyAWSCallerIdentity verifies the identity of an instance by sending its
+// presigned GetCallerIdentity request to STS. Instance profiles assume their
+// role with the instance ID as the session name.
+func (v *Verifier) verifyAWSCallerIdentity(ctx context.Context, presigned *v1beta1.PresignedRequest) (*Instance, error) {
+	if presigned == nil {
+		return nil, errors.New("no presigned GetCallerIdentity request")
+	}
+
+	stsURL, err := url.Parse(presigned.URL)
+	if err != nil {
+		return nil, err
+	}
+	query := stsURL.Query()
+	switch {
+	case stsURL.Scheme != "https" || stsURL.Port() != "" || !stsHost.MatchString(stsURL.Host):
+		return nil, fmt.Errorf("not an STS URL: %s://%s", stsURL.Scheme, stsURL.Host)
+	case query.Get("Action") != "GetCallerIdentity":
+		return nil, fmt.Errorf("not a GetCallerIdentity request: %q", query.Get("Action"))
+	case !slices.Contains(strings.Split(query.Get("X-Amz-SignedHeaders"), ";"), strings.ToLower(CallerIdentityHeader)):
+		return nil, fmt.Errorf("the %s header isn't signed", CallerIdentityHeader)
+	case http.Header(presigned.Header).Get(CallerIdentityHeader) != v.caFingerprint:
+		return nil, errors.New("the request is bound to another cluster")
+	}
+
+	signedAt, err := time.Parse("20060102T150405Z", query.Get("X-Amz-Date"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing time: %w", err)
+	}
+	if age := v.now().Sub(signedAt); age > callerIdentityMaxAge || age < -time.Minute {
+		return nil, fmt.Errorf("the request has been signed at %s, which is too far from now", signedAt.Format(time.RFC3339))
+	}
+
+	callerARN, err := v.getCallerIdentity(ctx, stsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// arn:aws:sts::123456789012:assumed-role/role-name/i-0123456789abcdef0
+	parsed, err := arn.Parse(callerARN)
+	if err != nil {
+		return nil, fmt.Errorf("invalid caller ARN %q: %w", callerARN, err)
+	}
+	resource := strings.Split(parsed.Resource, "/")
+	if parsed.Service != "sts" || len(resource) != 3 || resource[0] != "assumed-role" || !strings.HasPrefix(resource[2], "i-") {
+		return nil, fmt.Errorf("caller %q isn't the instance profile of an instance", callerARN)
+	}
+
+	config := v.config.AWS
+	switch roleName, instanceID := resource[1], resource[2]; {
+	case !slices.Contains(config.AccountIDs, parsed.AccountID):
+		return nil, fmt.Errorf("account %q isn't allowed to join", parsed.AccountID)
+	case len(config.RoleNames) > 0 && !slices.Contains(config.RoleNames, roleName):
+		return nil, fmt.Errorf("role %q isn't allowed to join", roleName)
+	default:
+		return &Instance{
+			Provider:  cloudmetadata.ProviderAWS,
+			ID:        instanceID,
+			IssuedAt:  signedAt,
+			ExpiresAt: signedAt.Add(callerIdentityMaxAge),
+		}, nil
+	}
+}
+
+// getCallerIdentity sends the presigned GetCallerIdentity request with the
+// given URL to STS, and returns the ARN of the caller.
+func (v *Verifier) getCallerIdentity(ctx context.Context, stsURL *url.URL) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, stsURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(CallerIdentityHeader, v.caFingerprint)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send GetCallerIdentity request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("STS rejected the GetCallerIdentity request: %s", resp.Status)
+	}
+
+	var identity struct {
+		Response struct {
+			Result struct {
+				Arn string
+			} `json:"GetCallerIdentityResult"`
+		} `json:"GetCallerIdentityResponse"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&identity); err != nil {
+		return "", fmt.Errorf("failed to parse GetCallerIdentity response: %w", err)
+	}
+	return identity.Response.Result.Arn, nil
+}
+
+// caFingerprint returns the fingerprint of the given PEM encoded CA
+// certificate, in the same format as the fingerprints of join codes.
+func caFingerprint(caCert []byte) (string, error) {
+	certs, err := certutil.ParseCertsPEM(caCert)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+	return pubkeypin.Hash(certs[0]), nil
+}
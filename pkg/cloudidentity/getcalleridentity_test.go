@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudidentity
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/cloudmetadata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestVerifier_AWSCallerIdentity(t *testing.T) {
+	_, caCert := generateCert(t)
+	fingerprint, err := caFingerprint([]byte(caCert))
+	require.NoError(t, err)
+
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	verifier, err := NewVerifier(&v1beta1.CloudIdentityJoin{AWS: &v1beta1.AWSIdentityJoin{
+		Method:     v1beta1.AWSIdentityMethodGetCallerIdentity,
+		AccountIDs: []string{"123456789012"},
+		RoleNames:  []string{"k0s-worker"},
+	}}, []byte(caCert))
+	require.NoError(t, err)
+	verifier.now = func() time.Time { return now }
+
+	var callerARN string
+	verifier.httpClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "sts.eu-west-1.amazonaws.com", req.URL.Host)
+		assert.Equal(t, fingerprint, req.Header.Get(CallerIdentityHeader))
+		body := `{"GetCallerIdentityResponse":{"GetCallerIdentityResult":{"Account":"123456789012","Arn":"` + callerARN + `"}}}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})}
+
+	const validURL = "https://sts.eu-west-1.amazonaws.com/?Action=GetCallerIdentity&Version=2011-06-15" +
+		"&X-Amz-Date=20261015T115800Z&X-Amz-SignedHeaders=host%3Bx-k0s-cluster-ca&X-Amz-Signature=abc"
+
+	for _, test := range []struct {
+		name, url, fingerprint, arn, err string
+	}{
+		{"valid", validURL, fingerprint, "arn:aws:sts::123456789012:assumed-role/k0s-worker/i-0123", ""},
+		{"other_host", strings.Replace(validURL, "sts.eu-west-1.amazonaws.com", "sts.example.com", 1), fingerprint, "", "not an STS URL"},
+		{"other_action", strings.Replace(validURL, "GetCallerIdentity", "AssumeRole", 1), fingerprint, "", "not a GetCallerIdentity request"},
+		{"unsigned_header", strings.Replace(validURL, "%3Bx-k0s-cluster-ca", "", 1), fingerprint, "", "header isn't signed"},
+		{"other_cluster", validURL, "sha256:0123", "", "the request is bound to another cluster"},
+		{"expired", strings.Replace(validURL, "20261015T115800Z", "20261015T114000Z", 1), fingerprint, "", "which is too far from now"},
+		{"other_account", validURL, fingerprint, "arn:aws:sts::210987654321:assumed-role/k0s-worker/i-0123", `account "210987654321" isn't allowed to join`},
+		{"other_role", validURL, fingerprint, "arn:aws:sts::123456789012:assumed-role/admin/i-0123", `role "admin" isn't allowed to join`},
+		{"no_instance", validURL, fingerprint, "arn:aws:sts::123456789012:assumed-role/k0s-worker/someone", "isn't the instance profile of an instance"},
+		{"user", validURL, fingerprint, "arn:aws:iam::123456789012:user/someone", "isn't the instance profile of an instance"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			callerARN = test.arn
+			instance, err := verifier.Verify(t.Context(), &v1beta1.CloudIdentityJoinRequest{
+				Provider: "aws",
+				CallerIdentityRequest: &v1beta1.PresignedRequest{
+					URL:    test.url,
+					Header: http.Header{CallerIdentityHeader: []string{test.fingerprint}},
+				},
+			})
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+			} else if assert.NoError(t, err) {
+				signedAt := time.Date(2026, 10, 15, 11, 58, 0, 0, time.UTC)
+				assert.Equal(t, &Instance{
+					Provider:  cloudmetadata.ProviderAWS,
+					ID:        "i-0123",
+					IssuedAt:  signedAt,
+					ExpiresAt: signedAt.Add(15 * time.Minute),
+				}, instance)
+			}
+		})
+	}
+
+	t.Run("no_request", func(t *testing.T) {
+		_, err := verifier.Verify(t.Context(), &v1beta1.CloudIdentityJoinRequest{Provider: "aws"})
+		assert.ErrorContains(t, err, "no presigned GetCallerIdentity request")
+	})
+}
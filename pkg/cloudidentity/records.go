@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudidentity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The label of the config maps that record the instances that have joined.
+const recordLabel = "k0s.k0sproject.io/cloud-identity-join"
+
+// ErrIdentityUsed is returned if an identity has been used already.
+var ErrIdentityUsed = errors.New("the identity has been used already")
+
+// Records keeps track of the identities with which instances have joined, so
+// that each identity can be used only once. There's a config map per instance
+// in the kube-system namespace, which is removed once the identity that it
+// records has expired.
+type Records struct {
+	configMaps clientcorev1.ConfigMapInterface
+	now        func() time.Time
+}
+
+func NewRecords(client kubernetes.Interface) *Records {
+	return &Records{
+		configMaps: client.CoreV1().ConfigMaps(metav1.NamespaceSystem),
+		now:        time.Now,
+	}
+}
+
+// Consume records that the given instance joins with its identity. Returns
+// [ErrIdentityUsed] if the instance has joined with the same or a more recent
+// identity already. Concurrent joins of the same instance are detected via
+// the config map's resource version, so that only one of them succeeds.
+func (r *Records) Consume(ctx context.Context, instance *Instance) error {
+	r.prune(ctx)
+
+	hash := sha256.Sum256([]byte(instance.String()))
+	record := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "k0s-cloud-identity-" + hex.EncodeToString(hash[:10]),
+			Labels: map[string]string{recordLabel: "true"},
+		},
+		Data: map[string]string{
+			"instance":  instance.String(),
+			"issuedAt":  instance.IssuedAt.UTC().Format(time.RFC3339Nano),
+			"expiresAt": instance.ExpiresAt.UTC().Format(time.RFC3339Nano),
+		},
+	}
+
+	_, err := r.configMaps.Create(ctx, record, metav1.CreateOptions{})
+	if !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	existing, err := r.configMaps.Get(ctx, record.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if issuedAt, err := time.Parse(time.RFC3339Nano, existing.Data["issuedAt"]); err == nil && !instance.IssuedAt.After(issuedAt) {
+		return ErrIdentityUsed
+	}
+
+	existing.Data = record.Data
+	_, err = r.configMaps.Update(ctx, existing, metav1.UpdateOptions{})
+	if apierrors.IsConflict(err) {
+		return ErrIdentityUsed
+	}
+	return err
+}
+
+// prune removes the records of identities that have expired.
+func (r *Records) prune(ctx context.Context) {
+	records, err := r.configMaps.List(ctx, metav1.ListOptions{LabelSelector: recordLabel + "=true"})
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to list cloud identity records")
+		return
+	}
+
+	now := r.now()
+	for i := range records.Items {
+		record := &records.Items[i]
+		if expiresAt, err := time.Parse(time.RFC3339Nano, record.Data["expiresAt"]); err != nil || now.Before(expiresAt) {
+			continue
+		}
+		err := r.configMaps.Delete(ctx, record.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{UID: &record.UID, ResourceVersion: &record.ResourceVersion},
+		})
+		if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+			logrus.WithError(err).Warn("Failed to remove cloud identity record ", record.Name)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudidentity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/cloudmetadata"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecords_Consume(t *testing.T) {
+	client := fake.NewClientset()
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	records := NewRecords(client)
+	records.now = func() time.Time { return now }
+
+	instance := func(id string, issuedAt time.Time) *Instance {
+		return &Instance{Provider: cloudmetadata.ProviderAWS, ID: id, IssuedAt: issuedAt, ExpiresAt: issuedAt.Add(10 * time.Minute)}
+	}
+
+	require.NoError(t, records.Consume(t.Context(), instance("i-0123", now.Add(-5*time.Minute))))
+	assert.ErrorIs(t, records.Consume(t.Context(), instance("i-0123", now.Add(-5*time.Minute))), ErrIdentityUsed)
+	assert.ErrorIs(t, records.Consume(t.Context(), instance("i-0123", now.Add(-6*time.Minute))), ErrIdentityUsed, "older identities must be rejected")
+	require.NoError(t, records.Consume(t.Context(), instance("i-4567", now.Add(-5*time.Minute))))
+	require.NoError(t, records.Consume(t.Context(), instance("i-0123", now.Add(-time.Minute))), "more recent identities are fine")
+
+	list, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 2)
+
+	t.Run("prunes_expired", func(t *testing.T) {
+		now = now.Add(time.Hour)
+		require.NoError(t, records.Consume(t.Context(), instance("i-89ab", now)))
+
+		list, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).List(t.Context(), metav1.ListOptions{})
+		require.NoError(t, err)
+		if assert.Len(t, list.Items, 1) {
+			assert.Equal(t, "aws/i-89ab", list.Items[0].Data["instance"])
+		}
+	})
+}
@@ -0,0 +1,457 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cloudmetadata queries the instance metadata services of cloud
+// providers.
+package cloudmetadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Provider is a cloud provider whose instance metadata service can be queried.
+type Provider string
+
+const (
+	// ProviderAuto detects the provider by probing the metadata services of
+	// all supported providers.
+	ProviderAuto      Provider = "auto"
+	ProviderAWS       Provider = "aws"
+	ProviderGCP       Provider = "gcp"
+	ProviderAzure     Provider = "azure"
+	ProviderOpenStack Provider = "openstack"
+)
+
+// DefaultEndpoint is the link-local address on which all supported providers
+// serve their instance metadata.
+const DefaultEndpoint = "http://169.254.169.254"
+
+// errNotFound indicates that the metadata service doesn't know about a value.
+var errNotFound = errors.New("not found")
+
+// Client queries the instance metadata service of the cloud provider.
+type Client struct {
+	// Endpoint is the base URL of the metadata service.
+	Endpoint string
+	// HTTPClient is used to query the metadata service.
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client for the default metadata endpoint. Requests
+// time out quickly, as the metadata services are either available right away
+// or not at all.
+func NewClient() *Client {
+	return &Client{
+		Endpoint: DefaultEndpoint,
+		HTTPClient: &http.Client{
+			Transport: &http.Transport{DisableKeepAlives: true},
+			Timeout:   2 * time.Second,
+		},
+	}
+}
+
+// Addresses returns the IP addresses and DNS names of the instance, both
+// private and public, as reported by the metadata service of the given
+// provider. If the provider is [ProviderAuto], the providers are probed one
+// after another and the first one that answers is used.
+func (c *Client) Addresses(ctx context.Context, provider Provider) ([]string, Provider, error) {
+	return probe(ctx, provider, c.addresses)
+}
+
+// Instance describes where an instance is placed and how it's provisioned.
+// Values that the provider doesn't expose are left empty.
+type Instance struct {
+	Region       string
+	Zone         string
+	InstanceType string
+	// Spot indicates that the instance may be reclaimed by the provider at
+	// any time, i.e. it's a spot, preemptible or low priority instance.
+	Spot bool
+}
+
+// Instance returns the placement and provisioning details of the instance, as
+// reported by the metadata service of the given provider. If the provider is
+// [ProviderAuto], the providers are probed the same way as in
+// [Client.Addresses].
+func (c *Client) Instance(ctx context.Context, provider Provider) (*Instance, Provider, error) {
+	return probe(ctx, provider, c.instance)
+}
+
+// probe queries the metadata service of the given provider. If the provider is
+// [ProviderAuto], the providers are probed one after another and the result of
+// the first one that answers is returned.
+func probe[T any](ctx context.Context, provider Provider, query func(context.Context, Provider) (T, error)) (T, Provider, error) {
+	if provider != ProviderAuto {
+		result, err := query(ctx, provider)
+		return result, provider, err
+	}
+
+	// OpenStack serves an EC2 compatible API, so it needs to be probed
+	// before AWS.
+	var (
+		zero T
+		errs []error
+	)
+	for _, provider := range []Provider{ProviderGCP, ProviderAzure, ProviderOpenStack, ProviderAWS} {
+		result, err := query(ctx, provider)
+		if err == nil {
+			return result, provider, nil
+		}
+		if ctx.Err() != nil {
+			return zero, "", context.Cause(ctx)
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", provider, err))
+	}
+	return zero, "", fmt.Errorf("failed to detect cloud provider: %w", errors.Join(errs...))
+}
+
+func (c *Client) addresses(ctx context.Context, provider Provider) ([]string, error) {
+	var addresses []string
+	var err error
+	switch provider {
+	case ProviderAWS:
+		addresses, err = c.awsAddresses(ctx)
+	case ProviderGCP:
+		addresses, err = c.gcpAddresses(ctx)
+	case ProviderAzure:
+		addresses, err = c.azureAddresses(ctx)
+	case ProviderOpenStack:
+		addresses, err = c.openStackAddresses(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported cloud provider %q", provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	addresses = slices.DeleteFunc(addresses, func(address string) bool { return address == "" })
+	slices.Sort(addresses)
+	return slices.Compact(addresses), nil
+}
+
+// awsAddresses queries the EC2 instance metadata service.
+func (c *Client) awsAddresses(ctx context.Context) ([]string, error) {
+	token, err := c.awsToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	header := map[string]string{"X-Aws-Ec2-Metadata-Token": token}
+	return c.getOptional(ctx, header,
+		"/latest/meta-data/local-ipv4",
+		"/latest/meta-data/public-ipv4",
+		"/latest/meta-data/ipv6",
+		"/latest/meta-data/local-hostname",
+		"/latest/meta-data/public-hostname",
+	)
+}
+
+// awsToken obtains a session token, as required by IMDSv2.
+func (c *Client) awsToken(ctx context.Context) (string, error) {
+	token, err := c.get(ctx, http.MethodPut, "/latest/api/token", map[string]string{
+		"X-Aws-Ec2-Metadata-Token-Ttl-Seconds": "60",
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to obtain session token: %w", err)
+	}
+	return token, nil
+}
+
+// gcpAddresses queries the Compute Engine metadata server.
+func (c *Client) gcpAddresses(ctx context.Context) ([]string, error) {
+	header := map[string]string{"Metadata-Flavor": "Google"}
+	if _, err := c.get(ctx, http.MethodGet, "/computeMetadata/v1/instance/id", header); err != nil {
+		return nil, err
+	}
+	return c.getOptional(ctx, header,
+		"/computeMetadata/v1/instance/network-interfaces/0/ip",
+		"/computeMetadata/v1/instance/network-interfaces/0/ipv6s",
+		"/computeMetadata/v1/instance/network-interfaces/0/access-configs/0/external-ip",
+		"/computeMetadata/v1/instance/hostname",
+	)
+}
+
+// azureAddresses queries the Azure Instance Metadata Service.
+func (c *Client) azureAddresses(ctx context.Context) ([]string, error) {
+	body, err := c.get(ctx, http.MethodGet, "/metadata/instance/network?api-version=2021-02-01", map[string]string{"Metadata": "true"})
+	if err != nil {
+		return nil, err
+	}
+
+	type ipAddress struct {
+		PrivateIPAddress string `json:"privateIpAddress"`
+		PublicIPAddress  string `json:"publicIpAddress"`
+	}
+	var network struct {
+		Interface []struct {
+			IPv4 struct {
+				IPAddress []ipAddress `json:"ipAddress"`
+			} `json:"ipv4"`
+			IPv6 struct {
+				IPAddress []ipAddress `json:"ipAddress"`
+			} `json:"ipv6"`
+		} `json:"interface"`
+	}
+	if err := json.Unmarshal([]byte(body), &network); err != nil {
+		return nil, fmt.Errorf("failed to parse network metadata: %w", err)
+	}
+
+	var addresses []string
+	for _, iface := range network.Interface {
+		for _, ip := range slices.Concat(iface.IPv4.IPAddress, iface.IPv6.IPAddress) {
+			addresses = append(addresses, ip.PrivateIPAddress, ip.PublicIPAddress)
+		}
+	}
+	return addresses, nil
+}
+
+// openStackAddresses queries the OpenStack metadata service for the host name
+// and its EC2 compatible API for the addresses.
+func (c *Client) openStackAddresses(ctx context.Context) ([]string, error) {
+	body, err := c.get(ctx, http.MethodGet, "/openstack/latest/meta_data.json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata struct {
+		Hostname string `json:"hostname"`
+	}
+	if err := json.Unmarshal([]byte(body), &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	addresses, err := c.getOptional(ctx, nil,
+		"/latest/meta-data/local-ipv4",
+		"/latest/meta-data/public-ipv4",
+	)
+	if err != nil {
+		return nil, err
+	}
+	return append(addresses, metadata.Hostname), nil
+}
+
+func (c *Client) instance(ctx context.Context, provider Provider) (*Instance, error) {
+	switch provider {
+	case ProviderAWS:
+		return c.awsInstance(ctx)
+	case ProviderGCP:
+		return c.gcpInstance(ctx)
+	case ProviderAzure:
+		return c.azureInstance(ctx)
+	case ProviderOpenStack:
+		return c.openStackInstance(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported cloud provider %q", provider)
+	}
+}
+
+// awsInstance queries the EC2 instance metadata service.
+func (c *Client) awsInstance(ctx context.Context) (*Instance, error) {
+	token, err := c.awsToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	header := map[string]string{"X-Aws-Ec2-Metadata-Token": token}
+	var instance Instance
+	for path, value := range map[string]*string{
+		"/latest/meta-data/placement/region":            &instance.Region,
+		"/latest/meta-data/placement/availability-zone": &instance.Zone,
+		"/latest/meta-data/instance-type":               &instance.InstanceType,
+	} {
+		if *value, err = c.get(ctx, http.MethodGet, path, header); err != nil {
+			return nil, err
+		}
+	}
+
+	lifecycle, err := c.get(ctx, http.MethodGet, "/latest/meta-data/instance-life-cycle", header)
+	if err != nil && !errors.Is(err, errNotFound) {
+		return nil, err
+	}
+	instance.Spot = lifecycle == "spot"
+
+	return &instance, nil
+}
+
+// gcpInstance queries the Compute Engine metadata server.
+func (c *Client) gcpInstance(ctx context.Context) (*Instance, error) {
+	header := map[string]string{"Metadata-Flavor": "Google"}
+
+	// Zones and machine types are returned as resource paths, e.g.
+	// projects/1234/zones/us-central1-a.
+	zone, err := c.get(ctx, http.MethodGet, "/computeMetadata/v1/instance/zone", header)
+	if err != nil {
+		return nil, err
+	}
+	machineType, err := c.get(ctx, http.MethodGet, "/computeMetadata/v1/instance/machine-type", header)
+	if err != nil {
+		return nil, err
+	}
+	preemptible, err := c.get(ctx, http.MethodGet, "/computeMetadata/v1/instance/scheduling/preemptible", header)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := Instance{
+		Zone:         path.Base(zone),
+		InstanceType: path.Base(machineType),
+		Spot:         preemptible == "TRUE",
+	}
+	// Zones are named after their region, e.g. us-central1-a.
+	if i := strings.LastIndexByte(instance.Zone, '-'); i > 0 {
+		instance.Region = instance.Zone[:i]
+	}
+	return &instance, nil
+}
+
+// azureInstance queries the Azure Instance Metadata Service.
+func (c *Client) azureInstance(ctx context.Context) (*Instance, error) {
+	body, err := c.get(ctx, http.MethodGet, "/metadata/instance/compute?api-version=2021-02-01", map[string]string{"Metadata": "true"})
+	if err != nil {
+		return nil, err
+	}
+
+	var compute struct {
+		Location string `json:"location"`
+		Zone     string `json:"zone"`
+		VMSize   string `json:"vmSize"`
+		Priority string `json:"priority"`
+	}
+	if err := json.Unmarshal([]byte(body), &compute); err != nil {
+		return nil, fmt.Errorf("failed to parse compute metadata: %w", err)
+	}
+
+	instance := Instance{
+		Region:       compute.Location,
+		InstanceType: compute.VMSize,
+		Spot:         compute.Priority == "Spot" || compute.Priority == "Low",
+	}
+	// Availability zones are numbered per region. Prefix them with the
+	// region, the same way the Azure cloud provider does.
+	if compute.Zone != "" {
+		instance.Zone = compute.Location + "-" + compute.Zone
+	}
+	return &instance, nil
+}
+
+// openStackInstance queries the OpenStack metadata service for the
+// availability zone and its EC2 compatible API for the instance type.
+// OpenStack doesn't expose regions to instances.
+func (c *Client) openStackInstance(ctx context.Context) (*Instance, error) {
+	body, err := c.get(ctx, http.MethodGet, "/openstack/latest/meta_data.json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata struct {
+		AvailabilityZone string `json:"availability_zone"`
+	}
+	if err := json.Unmarshal([]byte(body), &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	instanceType, err := c.get(ctx, http.MethodGet, "/latest/meta-data/instance-type", nil)
+	if err != nil && !errors.Is(err, errNotFound) {
+		return nil, err
+	}
+
+	return &Instance{Zone: metadata.AvailabilityZone, InstanceType: instanceType}, nil
+}
+
+// Identity returns the signed identity of the instance, as issued by the
+// metadata service of the given provider, along with its signature, if the
+// signature isn't part of the identity itself. On AWS, the identity is the
+// instance identity document, on GCP it's an identity token for the given
+// audience.
+func (c *Client) Identity(ctx context.Context, provider Provider, audience string) (identity, signature string, _ error) {
+	switch provider {
+	case ProviderAWS:
+		token, err := c.awsToken(ctx)
+		if err != nil {
+			return "", "", err
+		}
+		header := map[string]string{"X-Aws-Ec2-Metadata-Token": token}
+		// The signature is over the exact document, so it mustn't be trimmed.
+		document, err := c.getRaw(ctx, http.MethodGet, "/latest/dynamic/instance-identity/document", header)
+		if err != nil {
+			return "", "", err
+		}
+		identity = string(document)
+		if signature, err = c.get(ctx, http.MethodGet, "/latest/dynamic/instance-identity/signature", header); err != nil {
+			return "", "", err
+		}
+		return identity, signature, nil
+
+	case ProviderGCP:
+		query := url.Values{"audience": {audience}, "format": {"full"}}
+		identity, err := c.get(ctx, http.MethodGet, "/computeMetadata/v1/instance/service-accounts/default/identity?"+query.Encode(), map[string]string{"Metadata-Flavor": "Google"})
+		return identity, "", err
+
+	default:
+		return "", "", fmt.Errorf("unsupported cloud provider %q", provider)
+	}
+}
+
+// getOptional gets the values at the given paths, skipping the ones that the
+// metadata service doesn't know about. Values may consist of multiple lines.
+func (c *Client) getOptional(ctx context.Context, header map[string]string, paths ...string) ([]string, error) {
+	var values []string
+	for _, path := range paths {
+		value, err := c.get(ctx, http.MethodGet, path, header)
+		if errors.Is(err, errNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, strings.Fields(value)...)
+	}
+	return values, nil
+}
+
+func (c *Client) get(ctx context.Context, method, path string, header map[string]string) (string, error) {
+	body, err := c.getRaw(ctx, method, path, header)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func (c *Client) getRaw(ctx context.Context, method, path string, header map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.Endpoint+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%s: %w", path, errNotFound)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, fmt.Errorf("%s: %s", path, resp.Status)
+	}
+	return body, nil
+}
@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudmetadata
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Addresses(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		routes   map[string]func(*http.Request) (int, string)
+		expected []string
+		provider Provider
+	}{
+		{
+			"aws",
+			map[string]func(*http.Request) (int, string){
+				"PUT /latest/api/token": func(r *http.Request) (int, string) {
+					if r.Header.Get("X-Aws-Ec2-Metadata-Token-Ttl-Seconds") == "" {
+						return http.StatusBadRequest, ""
+					}
+					return http.StatusOK, "the-token"
+				},
+				"GET /latest/meta-data/local-ipv4":      awsValue("10.0.0.5"),
+				"GET /latest/meta-data/public-ipv4":     awsValue("203.0.113.5"),
+				"GET /latest/meta-data/local-hostname":  awsValue("ip-10-0-0-5.ec2.internal"),
+				"GET /latest/meta-data/public-hostname": awsValue("ec2-203-0-113-5.compute.amazonaws.com"),
+			},
+			[]string{"10.0.0.5", "203.0.113.5", "ec2-203-0-113-5.compute.amazonaws.com", "ip-10-0-0-5.ec2.internal"},
+			ProviderAWS,
+		},
+		{
+			"gcp",
+			map[string]func(*http.Request) (int, string){
+				"GET /computeMetadata/v1/instance/id":                                                gcpValue("1234"),
+				"GET /computeMetadata/v1/instance/network-interfaces/0/ip":                           gcpValue("10.128.0.2"),
+				"GET /computeMetadata/v1/instance/network-interfaces/0/access-configs/0/external-ip": gcpValue("198.51.100.2"),
+				"GET /computeMetadata/v1/instance/hostname":                                          gcpValue("controller-0.c.project.internal"),
+			},
+			[]string{"10.128.0.2", "198.51.100.2", "controller-0.c.project.internal"},
+			ProviderGCP,
+		},
+		{
+			"azure",
+			map[string]func(*http.Request) (int, string){
+				"GET /metadata/instance/network": func(r *http.Request) (int, string) {
+					if r.Header.Get("Metadata") != "true" {
+						return http.StatusBadRequest, ""
+					}
+					return http.StatusOK, `{"interface":[{"ipv4":{"ipAddress":[{"privateIpAddress":"10.1.0.4","publicIpAddress":"192.0.2.4"}]},"ipv6":{"ipAddress":[{"privateIpAddress":"fd00::4","publicIpAddress":""}]}}]}`
+				},
+			},
+			[]string{"10.1.0.4", "192.0.2.4", "fd00::4"},
+			ProviderAzure,
+		},
+		{
+			"openstack",
+			map[string]func(*http.Request) (int, string){
+				"GET /openstack/latest/meta_data.json": func(*http.Request) (int, string) {
+					return http.StatusOK, `{"hostname":"controller-0.novalocal"}`
+				},
+				"GET /latest/meta-data/local-ipv4": func(*http.Request) (int, string) { return http.StatusOK, "172.16.0.7" },
+			},
+			[]string{"172.16.0.7", "controller-0.novalocal"},
+			ProviderOpenStack,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			for pattern, handler := range test.routes {
+				mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+					status, body := handler(r)
+					w.WriteHeader(status)
+					_, _ = io.WriteString(w, body)
+				})
+			}
+			server := httptest.NewServer(mux)
+			t.Cleanup(server.Close)
+			client := &Client{Endpoint: server.URL, HTTPClient: server.Client()}
+
+			addresses, provider, err := client.Addresses(t.Context(), test.provider)
+			require.NoError(t, err)
+			assert.Equal(t, test.provider, provider)
+			assert.Equal(t, test.expected, addresses)
+
+			addresses, provider, err = client.Addresses(t.Context(), ProviderAuto)
+			require.NoError(t, err)
+			assert.Equal(t, test.provider, provider, "auto detection")
+			assert.Equal(t, test.expected, addresses)
+		})
+	}
+
+	t.Run("unavailable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		t.Cleanup(server.Close)
+		client := &Client{Endpoint: server.URL, HTTPClient: server.Client()}
+
+		_, _, err := client.Addresses(t.Context(), ProviderAuto)
+		assert.ErrorContains(t, err, "failed to detect cloud provider")
+	})
+}
+
+func TestClient_Instance(t *testing.T) {
+	awsToken := func(*http.Request) (int, string) { return http.StatusOK, "the-token" }
+
+	for _, test := range []struct {
+		name     string
+		routes   map[string]func(*http.Request) (int, string)
+		expected Instance
+		provider Provider
+	}{
+		{
+			"aws",
+			map[string]func(*http.Request) (int, string){
+				"PUT /latest/api/token":                             awsToken,
+				"GET /latest/meta-data/placement/region":            awsValue("eu-west-1"),
+				"GET /latest/meta-data/placement/availability-zone": awsValue("eu-west-1b"),
+				"GET /latest/meta-data/instance-type":               awsValue("m5.large"),
+				"GET /latest/meta-data/instance-life-cycle":         awsValue("spot"),
+			},
+			Instance{Region: "eu-west-1", Zone: "eu-west-1b", InstanceType: "m5.large", Spot: true},
+			ProviderAWS,
+		},
+		{
+			"gcp",
+			map[string]func(*http.Request) (int, string){
+				"GET /computeMetadata/v1/instance/zone":                   gcpValue("projects/1234/zones/us-central1-a"),
+				"GET /computeMetadata/v1/instance/machine-type":           gcpValue("projects/1234/machineTypes/e2-standard-4"),
+				"GET /computeMetadata/v1/instance/scheduling/preemptible": gcpValue("FALSE"),
+			},
+			Instance{Region: "us-central1", Zone: "us-central1-a", InstanceType: "e2-standard-4"},
+			ProviderGCP,
+		},
+		{
+			"azure",
+			map[string]func(*http.Request) (int, string){
+				"GET /metadata/instance/compute": func(r *http.Request) (int, string) {
+					if r.Header.Get("Metadata") != "true" {
+						return http.StatusBadRequest, ""
+					}
+					return http.StatusOK, `{"location":"westeurope","zone":"2","vmSize":"Standard_D4s_v5","priority":"Spot"}`
+				},
+			},
+			Instance{Region: "westeurope", Zone: "westeurope-2", InstanceType: "Standard_D4s_v5", Spot: true},
+			ProviderAzure,
+		},
+		{
+			"openstack",
+			map[string]func(*http.Request) (int, string){
+				"GET /openstack/latest/meta_data.json": func(*http.Request) (int, string) {
+					return http.StatusOK, `{"availability_zone":"nova"}`
+				},
+				"GET /latest/meta-data/instance-type": func(*http.Request) (int, string) { return http.StatusOK, "m1.medium" },
+			},
+			Instance{Zone: "nova", InstanceType: "m1.medium"},
+			ProviderOpenStack,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			for pattern, handler := range test.routes {
+				mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+					status, body := handler(r)
+					w.WriteHeader(status)
+					_, _ = io.WriteString(w, body)
+				})
+			}
+			server := httptest.NewServer(mux)
+			t.Cleanup(server.Close)
+			client := &Client{Endpoint: server.URL, HTTPClient: server.Client()}
+
+			instance, provider, err := client.Instance(t.Context(), test.provider)
+			require.NoError(t, err)
+			assert.Equal(t, test.provider, provider)
+			assert.Equal(t, &test.expected, instance)
+
+			instance, provider, err = client.Instance(t.Context(), ProviderAuto)
+			require.NoError(t, err)
+			assert.Equal(t, test.provider, provider, "auto detection")
+			assert.Equal(t, &test.expected, instance)
+		})
+	}
+}
+
+func TestClient_Identity(t *testing.T) {
+	mux := http.NewServeMux()
+	for pattern, handler := range map[string]func(*http.Request) (int, string){
+		"PUT /latest/api/token":                           func(*http.Request) (int, string) { return http.StatusOK, "the-token" },
+		"GET /latest/dynamic/instance-identity/document":  awsValue(`{"accountId":"123456789012"}`),
+		"GET /latest/dynamic/instance-identity/signature": awsValue("c2lnbmF0dXJl"),
+		"GET /computeMetadata/v1/instance/service-accounts/default/identity": func(r *http.Request) (int, string) {
+			if query := r.URL.Query(); query.Get("audience") != "the-audience" || query.Get("format") != "full" {
+				return http.StatusBadRequest, ""
+			}
+			return gcpValue("the.identity.token")(r)
+		},
+	} {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			status, body := handler(r)
+			w.WriteHeader(status)
+			_, _ = io.WriteString(w, body)
+		})
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	client := &Client{Endpoint: server.URL, HTTPClient: server.Client()}
+
+	identity, signature, err := client.Identity(t.Context(), ProviderAWS, "the-audience")
+	require.NoError(t, err)
+	assert.Equal(t, `{"accountId":"123456789012"}`, identity)
+	assert.Equal(t, "c2lnbmF0dXJl", signature)
+
+	identity, signature, err = client.Identity(t.Context(), ProviderGCP, "the-audience")
+	require.NoError(t, err)
+	assert.Equal(t, "the.identity.token", identity)
+	assert.Empty(t, signature)
+
+	_, _, err = client.Identity(t.Context(), ProviderAzure, "the-audience")
+	assert.ErrorContains(t, err, `unsupported cloud provider "azure"`)
+}
+
+func awsValue(value string) func(*http.Request) (int, string) {
+	return func(r *http.Request) (int, string) {
+		if r.Header.Get("X-Aws-Ec2-Metadata-Token") != "the-token" {
+			return http.StatusUnauthorized, ""
+		}
+		return http.StatusOK, value
+	}
+}
+
+func gcpValue(value string) func(*http.Request) (int, string) {
+	return func(r *http.Request) (int, string) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			return http.StatusForbidden, ""
+		}
+		return http.StatusOK, value
+	}
+}
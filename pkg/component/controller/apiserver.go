@@ -167,6 +167,8 @@ func (a *APIServer) Start(_ context.Context) error {
 		UID:     a.uid,
 		GID:     a.gid,
 	}
+	a.supervisor.ApplyRestartPolicy(a.ClusterConfig.Spec.API.RestartPolicy)
+	a.supervisor.Resources = a.ClusterConfig.Spec.API.Resources
 
 	etcdArgs, err := getEtcdArgs(a.ClusterConfig.Spec.Storage, a.K0sVars)
 	if err != nil {
@@ -254,10 +256,15 @@ func getEtcdArgs(storage *v1beta1.StorageSpec, k0sVars *config.CfgVars) ([]strin
 	case v1beta1.EtcdStorageType:
 		args = append(args, "--etcd-servers="+storage.Etcd.GetEndpointsAsString())
 		if storage.Etcd.IsTLSEnabled() {
-			args = append(args,
-				"--etcd-cafile="+storage.Etcd.GetCaFilePath(k0sVars.EtcdCertDir),
-				"--etcd-certfile="+storage.Etcd.GetCertFilePath(k0sVars.CertRootDir),
-				"--etcd-keyfile="+storage.Etcd.GetKeyFilePath(k0sVars.CertRootDir))
+			// An external cluster may be configured with only some of the TLS files.
+			if caFile := storage.Etcd.GetCaFilePath(k0sVars.EtcdCertDir); caFile != "" {
+				args = append(args, "--etcd-cafile="+caFile)
+			}
+			if certFile := storage.Etcd.GetCertFilePath(k0sVars.CertRootDir); certFile != "" {
+				args = append(args,
+					"--etcd-certfile="+certFile,
+					"--etcd-keyfile="+storage.Etcd.GetKeyFilePath(k0sVars.CertRootDir))
+			}
 		}
 		if storage.Etcd.IsExternalClusterUsed() {
 			args = append(args, "--etcd-prefix="+storage.Etcd.ExternalCluster.EtcdPrefix)
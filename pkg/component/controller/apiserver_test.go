@@ -89,6 +89,54 @@ func (a *apiServerSuite) TestGetEtcdArgs() {
 		require.Contains(result[4], "--etcd-prefix=k0s-tenant-1")
 	})
 
+	a.Run("external etcd cluster with CA only", func() {
+		storageSpec := &v1beta1.StorageSpec{
+			Etcd: &v1beta1.EtcdConfig{
+				ExternalCluster: &v1beta1.ExternalCluster{
+					Endpoints:  []string{"https://192.168.10.10:2379"},
+					EtcdPrefix: "k0s-tenant-1",
+					CaFile:     "/etc/pki/CA/ca.crt",
+				},
+			},
+			Type: "etcd",
+		}
+
+		result, err := getEtcdArgs(storageSpec, k0sVars)
+
+		require := a.Require()
+		require.NoError(err)
+		require.Equal([]string{
+			"--etcd-servers=https://192.168.10.10:2379",
+			"--etcd-cafile=/etc/pki/CA/ca.crt",
+			"--etcd-prefix=k0s-tenant-1",
+		}, result)
+	})
+
+	a.Run("external etcd cluster with client certificate only", func() {
+		storageSpec := &v1beta1.StorageSpec{
+			Etcd: &v1beta1.EtcdConfig{
+				ExternalCluster: &v1beta1.ExternalCluster{
+					Endpoints:      []string{"https://192.168.10.10:2379"},
+					EtcdPrefix:     "k0s-tenant-1",
+					ClientCertFile: "/etc/pki/tls/certs/etcd-client.crt",
+					ClientKeyFile:  "/etc/pki/tls/private/etcd-client.key",
+				},
+			},
+			Type: "etcd",
+		}
+
+		result, err := getEtcdArgs(storageSpec, k0sVars)
+
+		require := a.Require()
+		require.NoError(err)
+		require.Equal([]string{
+			"--etcd-servers=https://192.168.10.10:2379",
+			"--etcd-certfile=/etc/pki/tls/certs/etcd-client.crt",
+			"--etcd-keyfile=/etc/pki/tls/private/etcd-client.key",
+			"--etcd-prefix=k0s-tenant-1",
+		}, result)
+	})
+
 	a.Run("external etcd cluster without TLS", func() {
 		storageSpec := &v1beta1.StorageSpec{
 			Etcd: &v1beta1.EtcdConfig{
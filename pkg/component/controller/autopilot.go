@@ -8,8 +8,10 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	apcli "github.com/k0sproject/k0s/pkg/autopilot/client"
+	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
 	apcont "github.com/k0sproject/k0s/pkg/autopilot/controller"
 	aproot "github.com/k0sproject/k0s/pkg/autopilot/controller/root"
 	"github.com/k0sproject/k0s/pkg/component/manager"
@@ -26,6 +28,9 @@ type Autopilot struct {
 	KubeletExtraArgs   string
 	AdminClientFactory kubernetes.ClientFactoryInterface
 	Workloads          bool
+
+	ControlNodeStaleTimeout time.Duration
+	ControlNodeRemovalAge   time.Duration
 }
 
 func (a *Autopilot) Init(ctx context.Context) error {
@@ -46,8 +51,12 @@ func (a *Autopilot) Start(ctx context.Context) error {
 		KubeletExtraArgs:    a.KubeletExtraArgs,
 		Mode:                "controller",
 		ManagerPort:         8899,
-		MetricsBindAddr:     "0",
+		MetricsBindAddr:     apconst.ControllerMetricsBindAddr,
 		HealthProbeBindAddr: "0",
+		WebhookCertDir:      a.K0sVars.CertRootDir,
+
+		ControlNodeStaleTimeout: a.ControlNodeStaleTimeout,
+		ControlNodeRemovalAge:   a.ControlNodeRemovalAge,
 	}, logrus.WithFields(logrus.Fields{"component": "autopilot"}), a.Workloads, a.AdminClientFactory, autopilotClientFactory)
 	if err != nil {
 		return fmt.Errorf("failed to create autopilot controller: %w", err)
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
@@ -17,6 +19,7 @@ import (
 	"github.com/k0sproject/k0s/pkg/component/controller/clusterconfig"
 	"github.com/k0sproject/k0s/pkg/component/controller/leaderelector"
 	"github.com/k0sproject/k0s/pkg/component/manager"
+	k0sconfig "github.com/k0sproject/k0s/pkg/config"
 	"github.com/k0sproject/k0s/pkg/constant"
 	"github.com/k0sproject/k0s/pkg/kubernetes"
 	"github.com/k0sproject/k0s/static"
@@ -25,6 +28,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
 
 	"github.com/sirupsen/logrus"
 	"sigs.k8s.io/yaml"
@@ -37,12 +41,13 @@ type ClusterConfigReconciler struct {
 	KubeClientFactory kubernetes.ClientFactoryInterface
 
 	log          *logrus.Entry
-	reconciler   manager.Reconciler
+	reconciler   manager.ComponentsReconciler
 	configSource clusterconfig.ConfigSource
+	lastConfig   *k0sv1beta1.ClusterConfig
 }
 
 // NewClusterConfigReconciler creates a new clusterConfig reconciler
-func NewClusterConfigReconciler(reconciler manager.Reconciler, kubeClientFactory kubernetes.ClientFactoryInterface, configSource clusterconfig.ConfigSource) *ClusterConfigReconciler {
+func NewClusterConfigReconciler(reconciler manager.ComponentsReconciler, kubeClientFactory kubernetes.ClientFactoryInterface, configSource clusterconfig.ConfigSource) *ClusterConfigReconciler {
 	return &ClusterConfigReconciler{
 		KubeClientFactory: kubeClientFactory,
 		log:               logrus.WithFields(logrus.Fields{"component": "clusterConfig-reconciler"}),
@@ -65,13 +70,23 @@ func (r *ClusterConfigReconciler) Start(ctx context.Context) error {
 					r.log.Debug("config source closed channel")
 					return
 				}
-				err := errors.Join(cfg.Validate()...)
+				conditions, condErr := clusterconfig.FieldConditions(r.lastConfig, cfg)
+				if condErr != nil {
+					r.log.WithError(condErr).Warn("Failed to determine the changed fields of the cluster configuration")
+				}
+				var results []manager.ReconcileResult
+				err := errors.Join(append(cfg.Validate(), k0sconfig.ValidateK0sFeatureGates(cfg.Spec.K0sFeatureGates)...)...)
 				if err != nil {
 					err = fmt.Errorf("failed to validate cluster configuration: %w", err)
 				} else {
-					err = r.reconciler.Reconcile(ctx, cfg)
+					results = r.reconciler.ReconcileComponents(ctx, cfg)
+					err = reconcileError(results)
+				}
+				if err == nil {
+					r.lastConfig = cfg
 				}
 				r.reportStatus(statusCtx, cfg, err)
+				r.updateStatus(statusCtx, cfg, conditions, results, err)
 				if err != nil {
 					r.log.WithError(err).Error("Failed to reconcile cluster configuration")
 				} else {
@@ -138,6 +153,138 @@ func (r *ClusterConfigReconciler) reportStatus(ctx context.Context, config *k0sv
 	}
 }
 
+// reconcileError combines the errors of the failed components, if any.
+func reconcileError(results []manager.ReconcileResult) error {
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	if len(errs) > 0 {
+		return manager.ReconcileError{Errors: errs}
+	}
+	return nil
+}
+
+// updateStatus records the outcome of the reconciliation in the status of the
+// cluster configuration: the reconciled generation, the outcome for each of
+// this controller's components, and how the changes to the individual fields
+// have been applied. Changes that should have been applied live are reported
+// as failed if the reconciliation failed.
+func (r *ClusterConfigReconciler) updateStatus(ctx context.Context, config *k0sv1beta1.ClusterConfig, conditions []k0sv1beta1.FieldCondition, results []manager.ReconcileResult, reconcileError error) {
+	if reconcileError != nil {
+		for i := range conditions {
+			if conditions[i].Type == k0sv1beta1.FieldChangeAppliedLive {
+				conditions[i].Type = k0sv1beta1.FieldChangeFailed
+				conditions[i].Message = reconcileError.Error()
+			}
+		}
+	}
+
+	controllerName, err := os.Hostname()
+	if err != nil {
+		r.log.WithError(err).Error("Failed to get hostname")
+		return
+	}
+
+	clientset, err := r.KubeClientFactory.GetK0sClient()
+	if err != nil {
+		r.log.WithError(err).Error("Failed to get k0s client")
+		return
+	}
+	client := clientset.K0sV1beta1().ClusterConfigs(config.Namespace)
+
+	now := metav1.Now()
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := client.Get(ctx, config.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if latest.Status == nil {
+			latest.Status = &k0sv1beta1.ClusterConfigStatus{}
+		}
+		status := latest.Status
+
+		status.ObservedGeneration = max(status.ObservedGeneration, config.Generation)
+		if results != nil {
+			status.Components = mergeComponentStatuses(status.Components, controllerName, config.Generation, results, now)
+		}
+		if reconcileError == nil && !slices.ContainsFunc(status.Components, func(component k0sv1beta1.ComponentStatus) bool {
+			return component.ObservedGeneration == config.Generation && component.Error != ""
+		}) {
+			status.AppliedGeneration = max(status.AppliedGeneration, config.Generation)
+		}
+		if results != nil {
+			status.K0sFeatureGates = k0sconfig.K0sFeatureGateStatuses(config.Spec.K0sFeatureGates)
+		}
+		if len(conditions) > 0 {
+			status.FieldConditions = mergeFieldConditions(status.FieldConditions, conditions)
+		}
+
+		_, err = client.UpdateStatus(ctx, latest, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		r.log.WithError(err).Error("Failed to update the status of the cluster configuration")
+	}
+}
+
+// mergeComponentStatuses replaces the statuses of the given controller's
+// components with the given results.
+func mergeComponentStatuses(existing []k0sv1beta1.ComponentStatus, controllerName string, generation int64, results []manager.ReconcileResult, now metav1.Time) []k0sv1beta1.ComponentStatus {
+	merged := slices.DeleteFunc(slices.Clone(existing), func(component k0sv1beta1.ComponentStatus) bool {
+		return component.Controller == controllerName
+	})
+	for _, result := range results {
+		status := k0sv1beta1.ComponentStatus{
+			Name:               result.Component,
+			Controller:         controllerName,
+			ObservedGeneration: generation,
+			LastReconcileTime:  now,
+		}
+		if result.Err != nil {
+			status.Error = result.Err.Error()
+		}
+		merged = append(merged, status)
+	}
+
+	slices.SortFunc(merged, func(a, b k0sv1beta1.ComponentStatus) int {
+		if c := strings.Compare(a.Controller, b.Controller); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return merged
+}
+
+// maxFieldConditions is the maximum number of field conditions that are kept
+// in the status of the cluster configuration.
+const maxFieldConditions = 50
+
+// mergeFieldConditions replaces the existing conditions with the updated ones
+// that refer to the same path. Keeps the most recent conditions only.
+func mergeFieldConditions(existing, updated []k0sv1beta1.FieldCondition) []k0sv1beta1.FieldCondition {
+	merged := slices.DeleteFunc(slices.Clone(existing), func(existing k0sv1beta1.FieldCondition) bool {
+		return slices.ContainsFunc(updated, func(updated k0sv1beta1.FieldCondition) bool {
+			return existing.Path == updated.Path
+		})
+	})
+	merged = append(merged, updated...)
+
+	if len(merged) > maxFieldConditions {
+		slices.SortStableFunc(merged, func(a, b k0sv1beta1.FieldCondition) int {
+			return a.LastTransitionTime.Compare(b.LastTransitionTime.Time)
+		})
+		merged = merged[len(merged)-maxFieldConditions:]
+	}
+
+	slices.SortFunc(merged, func(a, b k0sv1beta1.FieldCondition) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return merged
+}
+
 type ClusterConfigInitializer struct {
 	log           logrus.FieldLogger
 	clients       kubernetes.ClientFactoryInterface
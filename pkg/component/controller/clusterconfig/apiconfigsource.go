@@ -41,7 +41,10 @@ func (*apiConfigSource) Init(context.Context) error { return nil }
 
 // Start implements [manager.Component].
 func (a *apiConfigSource) Start(context.Context) error {
-	var lastObservedVersion string
+	var (
+		lastObservedVersion    string
+		lastObservedGeneration int64
+	)
 
 	log := logrus.WithField("component", "clusterconfig.apiConfigSource")
 	watch := watch.ClusterConfigs(a.configClient).
@@ -75,10 +78,12 @@ func (a *apiConfigSource) Start(context.Context) error {
 		defer close(done)
 		defer close(a.resultChan)
 		_ = watch.Until(ctx, func(cfg *v1beta1.ClusterConfig) (bool, error) {
-			// Push changes only when the config actually changes
-			if lastObservedVersion != cfg.ResourceVersion {
+			// Push changes only when the config actually changes. Status
+			// updates don't change the generation.
+			if lastObservedVersion != cfg.ResourceVersion && (cfg.Generation == 0 || cfg.Generation != lastObservedGeneration) {
 				log.Debugf("Cluster configuration update to resource version %q", cfg.ResourceVersion)
 				lastObservedVersion = cfg.ResourceVersion
+				lastObservedGeneration = cfg.Generation
 				select {
 				case a.resultChan <- cfg:
 				case <-ctx.Done():
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterconfig
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+)
+
+// FieldDiff is a field whose value differs between the built-in defaults, the
+// configuration file and the dynamic configuration of the cluster. Values are
+// nil if they're not set.
+type FieldDiff struct {
+	Path    string
+	Default any
+	File    any
+	Cluster any
+
+	// Note explains which of the values takes effect, if the file and the
+	// cluster disagree. Empty if the file's value is in effect.
+	Note string
+}
+
+// Diff compares the spec of the given configurations field by field and
+// returns the fields whose values differ, ordered by path. Maps are compared
+// field by field, any other values as a whole. The cluster configuration may
+// be nil if dynamic configuration isn't enabled.
+func Diff(defaults, file, cluster *v1beta1.ClusterConfig) ([]FieldDiff, error) {
+	configs := []*v1beta1.ClusterConfig{defaults, file, cluster}
+	fields := make([]map[string]any, len(configs))
+	for i, config := range configs {
+		fields[i] = make(map[string]any)
+		if config == nil {
+			continue
+		}
+		spec, err := specOf(config)
+		if err != nil {
+			return nil, err
+		}
+		collectFields("spec", spec, fields[i])
+	}
+
+	var paths []string
+	for _, f := range fields {
+		for path := range f {
+			if !slices.Contains(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+	slices.Sort(paths)
+
+	var diffs []FieldDiff
+	for _, path := range paths {
+		diff := FieldDiff{
+			Path:    path,
+			Default: fields[0][path],
+			File:    fields[1][path],
+			Cluster: fields[2][path],
+		}
+
+		policy := policyFor(path)
+		fileDiffers := !reflect.DeepEqual(diff.Default, diff.File)
+		clusterDiffers := cluster != nil && !reflect.DeepEqual(diff.File, diff.Cluster)
+		// Node specific fields aren't stored in the cluster, but they may be
+		// defaulted when the cluster configuration is read.
+		if policy.changeType == v1beta1.FieldChangeIgnored &&
+			(diff.Cluster == nil || reflect.DeepEqual(diff.Default, diff.Cluster)) {
+			clusterDiffers = false
+		}
+		if !fileDiffers && !clusterDiffers {
+			continue
+		}
+
+		if clusterDiffers {
+			if policy.changeType == v1beta1.FieldChangeIgnored {
+				diff.Note = "Cluster value ignored: " + policy.message
+			} else {
+				diff.Note = "File value overridden by the cluster configuration"
+			}
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	return diffs, nil
+}
+
+// collectFields adds the values of all fields below path to fields. Maps are
+// descended into, any other values are added as a whole.
+func collectFields(path string, value any, fields map[string]any) {
+	switch value := value.(type) {
+	case nil:
+	case map[string]any:
+		for key, value := range value {
+			collectFields(path+"."+key, value, fields)
+		}
+	default:
+		fields[path] = value
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterconfig_test
+
+import (
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/controller/clusterconfig"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	defaults := v1beta1.DefaultClusterConfig()
+
+	file := defaults.DeepCopy()
+	file.Spec.API.Address = "10.0.0.1"
+	file.Spec.Network.KubeRouter.MTU = 1400
+	file.Spec.Konnectivity.AgentPort = 8133
+
+	t.Run("unchanged", func(t *testing.T) {
+		diffs, err := clusterconfig.Diff(defaults, defaults.DeepCopy(), defaults.DeepCopy())
+		assert.NoError(t, err)
+		assert.Empty(t, diffs)
+	})
+
+	t.Run("no_cluster", func(t *testing.T) {
+		diffs, err := clusterconfig.Diff(defaults, file, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []clusterconfig.FieldDiff{
+			{Path: "spec.api.address", Default: defaults.Spec.API.Address, File: "10.0.0.1"},
+			{Path: "spec.konnectivity.agentPort", Default: int64(8132), File: int64(8133)},
+			{Path: "spec.network.kuberouter.mtu", File: int64(1400)},
+		}, diffs)
+	})
+
+	t.Run("cluster", func(t *testing.T) {
+		cluster := file.GetClusterWideConfig()
+		cluster.Spec.Network.KubeRouter.MTU = 1350
+		cluster.Spec.Network.ClusterDomain = "example.com"
+		cluster.Spec.Network.ServiceCIDR = defaults.Spec.Network.ServiceCIDR
+
+		diffs, err := clusterconfig.Diff(defaults, file, cluster)
+		require.NoError(t, err)
+
+		notes := make(map[string]string, len(diffs))
+		for _, diff := range diffs {
+			notes[diff.Path] = diff.Note
+		}
+		assert.Equal(t, map[string]string{
+			"spec.api.address":            "",
+			"spec.konnectivity.agentPort": "",
+			"spec.network.clusterDomain":  "Cluster value ignored: Node specific, configure it in the configuration file of each controller",
+			"spec.network.kuberouter.mtu": "File value overridden by the cluster configuration",
+		}, notes)
+	})
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterconfig
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const nodeSpecificMessage = "Node specific, configure it in the configuration file of each controller"
+
+// fieldPolicy describes how changes to the field at path, and to all of its
+// subfields that have no policy of their own, take effect.
+type fieldPolicy struct {
+	path       string
+	changeType v1beta1.FieldChangeType
+	message    string
+}
+
+// fieldPolicies lists how the changes to the fields of the dynamic
+// configuration take effect. Keep this in sync with the documentation.
+var fieldPolicies = []fieldPolicy{
+	{"spec", v1beta1.FieldChangeAppliedLive, ""},
+
+	{"spec.api", v1beta1.FieldChangeIgnored, nodeSpecificMessage},
+	{"spec.storage", v1beta1.FieldChangeIgnored, nodeSpecificMessage},
+	{"spec.installConfig", v1beta1.FieldChangeIgnored, nodeSpecificMessage},
+	{"spec.backup", v1beta1.FieldChangeIgnored, nodeSpecificMessage},
+	{"spec.network.serviceCIDR", v1beta1.FieldChangeIgnored, nodeSpecificMessage},
+	{"spec.network.clusterDomain", v1beta1.FieldChangeIgnored, nodeSpecificMessage},
+	{"spec.network.controlPlaneLoadBalancing", v1beta1.FieldChangeIgnored, nodeSpecificMessage},
+	{"spec.network.primaryAddressFamily", v1beta1.FieldChangeIgnored, nodeSpecificMessage},
+
+	{"spec.network.podCIDR", v1beta1.FieldChangeImmutable, "Can't be changed once the cluster has been created"},
+	{"spec.network.provider", v1beta1.FieldChangeImmutable, "Can't be changed once the cluster has been created"},
+
+	{"spec.featureGates", v1beta1.FieldChangeRequiresRestart, "Applied to kube-controller-manager, kube-scheduler and the workers, the Kubernetes API servers pick it up when their controllers are restarted"},
+	{"spec.k0sFeatureGates", v1beta1.FieldChangeRequiresRestart, "Applied when the k0s controllers and workers are restarted"},
+	{"spec.controllerManager.cloudProvider", v1beta1.FieldChangeRequiresRestart, "Applied to kube-controller-manager, the workers need to be restarted with a matching --enable-cloud-provider flag"},
+}
+
+// FieldConditions compares two revisions of the dynamic configuration and
+// returns a condition for each changed field, describing how the change takes
+// effect. Maps are compared field by field, any other values as a whole.
+// Returns nil if there's no previous revision.
+func FieldConditions(previous, current *v1beta1.ClusterConfig) ([]v1beta1.FieldCondition, error) {
+	if previous == nil {
+		return nil, nil
+	}
+
+	prevSpec, err := specOf(previous)
+	if err != nil {
+		return nil, err
+	}
+	currSpec, err := specOf(current)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	diffPaths("spec", prevSpec, currSpec, &changed)
+	slices.Sort(changed)
+
+	now := metav1.Now()
+	conditions := make([]v1beta1.FieldCondition, 0, len(changed))
+	for _, path := range changed {
+		policy := policyFor(path)
+		conditions = append(conditions, v1beta1.FieldCondition{
+			Path:               path,
+			Type:               policy.changeType,
+			Message:            policy.message,
+			ObservedGeneration: current.Generation,
+			LastTransitionTime: now,
+		})
+	}
+
+	return conditions, nil
+}
+
+func specOf(config *v1beta1.ClusterConfig) (any, error) {
+	if config.Spec == nil {
+		return nil, nil
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(config.Spec)
+}
+
+// diffPaths appends the paths of all values that differ between a and b.
+func diffPaths(path string, a, b any, changed *[]string) {
+	aMap, aIsMap := a.(map[string]any)
+	bMap, bIsMap := b.(map[string]any)
+	if !aIsMap || !bIsMap {
+		if !reflect.DeepEqual(a, b) {
+			*changed = append(*changed, path)
+		}
+		return
+	}
+
+	for key, aVal := range aMap {
+		diffPaths(path+"."+key, aVal, bMap[key], changed)
+	}
+	for key, bVal := range bMap {
+		if _, ok := aMap[key]; !ok {
+			diffPaths(path+"."+key, nil, bVal, changed)
+		}
+	}
+}
+
+// policyFor returns the most specific policy for the given path.
+func policyFor(path string) (policy fieldPolicy) {
+	for _, candidate := range fieldPolicies {
+		if (path == candidate.path || strings.HasPrefix(path, candidate.path+".")) &&
+			len(candidate.path) > len(policy.path) {
+			policy = candidate
+		}
+	}
+	return policy
+}
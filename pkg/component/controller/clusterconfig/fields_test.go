@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterconfig_test
+
+import (
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/controller/clusterconfig"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldConditions(t *testing.T) {
+	t.Run("no_previous", func(t *testing.T) {
+		conditions, err := clusterconfig.FieldConditions(nil, v1beta1.DefaultClusterConfig())
+		assert.NoError(t, err)
+		assert.Nil(t, conditions)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		config := v1beta1.DefaultClusterConfig()
+		conditions, err := clusterconfig.FieldConditions(config, config.DeepCopy())
+		assert.NoError(t, err)
+		assert.Empty(t, conditions)
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		previous := v1beta1.DefaultClusterConfig()
+		current := previous.DeepCopy()
+		current.Generation = 3
+		current.Spec.Network.KubeRouter.MTU = 1350
+		current.Spec.Network.PodCIDR = "10.128.0.0/16"
+		current.Spec.Network.ServiceCIDR = "10.112.0.0/12"
+		current.Spec.API.ExtraArgs = map[string]string{"v": "4"}
+		current.Spec.Konnectivity.AgentPort = 8133
+		current.Spec.FeatureGates = v1beta1.FeatureGates{{Name: "Foo", Enabled: true}}
+
+		conditions, err := clusterconfig.FieldConditions(previous, current)
+		require.NoError(t, err)
+
+		types := make(map[string]v1beta1.FieldChangeType, len(conditions))
+		for _, condition := range conditions {
+			assert.Equal(t, int64(3), condition.ObservedGeneration, condition.Path)
+			types[condition.Path] = condition.Type
+		}
+		assert.Equal(t, map[string]v1beta1.FieldChangeType{
+			"spec.api.extraArgs":          v1beta1.FieldChangeIgnored,
+			"spec.featureGates":           v1beta1.FieldChangeRequiresRestart,
+			"spec.konnectivity.agentPort": v1beta1.FieldChangeAppliedLive,
+			"spec.network.kuberouter.mtu": v1beta1.FieldChangeAppliedLive,
+			"spec.network.podCIDR":        v1beta1.FieldChangeImmutable,
+			"spec.network.serviceCIDR":    v1beta1.FieldChangeIgnored,
+		}, types)
+	})
+}
@@ -0,0 +1,279 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	k0sconfig "github.com/k0sproject/k0s/pkg/config"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+
+	"github.com/sirupsen/logrus"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	crwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// WebhookCertName is the name of the serving certificate and key files of
+	// the ClusterConfig admission webhooks.
+	WebhookCertName = "clusterconfig-webhook"
+
+	// WebhookPort is the port on which the ClusterConfig admission webhooks
+	// are served.
+	WebhookPort = 8896
+
+	// webhookConfigurationName is the name of the mutating and validating
+	// webhook configurations that route ClusterConfigs to the webhooks.
+	webhookConfigurationName = "clusterconfigs.k0s.k0sproject.io"
+
+	webhookHost  = "127.0.0.1"
+	mutatePath   = "/mutate-k0s-k0sproject-io-v1beta1-clusterconfig"
+	validatePath = "/validate-k0s-k0sproject-io-v1beta1-clusterconfig"
+)
+
+// Webhook serves the admission webhooks for the dynamic configuration, so that
+// changes made via the Kubernetes API are checked the same way as "k0s config
+// validate" does, before the controllers pick them up. The webhooks are called
+// by the API server running on the same host. They fail open, so that the
+// configuration can still be changed while no controller is running.
+type Webhook struct {
+	CertDir           string
+	KubeClientFactory kubeutil.ClientFactoryInterface
+
+	stop func()
+}
+
+var _ manager.Component = (*Webhook)(nil)
+
+// Init implements [manager.Component].
+func (*Webhook) Init(context.Context) error { return nil }
+
+// Start implements [manager.Component].
+func (w *Webhook) Start(ctx context.Context) error {
+	log := logrus.WithField("component", "clusterconfig.webhook")
+
+	caBundle, err := os.ReadFile(filepath.Join(w.CertDir, "ca.crt"))
+	if err != nil {
+		return fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+
+	server := crwebhook.NewServer(crwebhook.Options{
+		Host:     webhookHost,
+		Port:     WebhookPort,
+		CertDir:  w.CertDir,
+		CertName: WebhookCertName + ".crt",
+		KeyName:  WebhookCertName + ".key",
+	})
+	server.Register(mutatePath, &admission.Webhook{Handler: admission.HandlerFunc(func(_ context.Context, req admission.Request) admission.Response {
+		return mutate(req)
+	})})
+	server.Register(validatePath, &admission.Webhook{Handler: admission.HandlerFunc(func(_ context.Context, req admission.Request) admission.Response {
+		resp := validate(req)
+		if !resp.Allowed {
+			log.Info("Rejecting cluster configuration: ", resp.Result.Message)
+		}
+		return resp
+	})})
+
+	serverCtx, cancel := context.WithCancelCause(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := server.Start(serverCtx); err != nil {
+			log.WithError(err).Error("Webhook server terminated")
+		}
+	}()
+	w.stop = func() {
+		cancel(errors.New("cluster configuration webhook is stopping"))
+		<-done
+	}
+
+	address := net.JoinHostPort(webhookHost, strconv.Itoa(WebhookPort))
+	if err := ensureWebhookConfigurations(ctx, w.KubeClientFactory, address, caBundle); err != nil {
+		w.stop()
+		return fmt.Errorf("failed to ensure webhook configurations: %w", err)
+	}
+
+	return nil
+}
+
+// Stop implements [manager.Component].
+func (w *Webhook) Stop() error {
+	if w.stop != nil {
+		w.stop()
+	}
+	return nil
+}
+
+// mutate removes the node specific fields from the configuration, as they are
+// only read from the controllers' configuration files. Defaults aren't filled
+// in, so that they keep following the defaults of the running k0s version.
+func mutate(req admission.Request) admission.Response {
+	var obj map[string]any
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var warnings []string
+	for _, policy := range fieldPolicies {
+		if policy.changeType != v1beta1.FieldChangeIgnored {
+			continue
+		}
+		fields := strings.Split(policy.path, ".")
+		if _, found, _ := unstructured.NestedFieldNoCopy(obj, fields...); found {
+			unstructured.RemoveNestedField(obj, fields...)
+			warnings = append(warnings, fmt.Sprintf("%s has been removed: %s", policy.path, policy.message))
+		}
+	}
+
+	if len(warnings) == 0 {
+		return admission.Allowed("")
+	}
+
+	mutated, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	resp := admission.PatchResponseFromRaw(req.Object.Raw, mutated)
+	resp.Warnings = warnings
+	return resp
+}
+
+// validate rejects invalid configurations, and changes to fields that can't
+// be changed once the cluster has been created.
+func validate(req admission.Request) admission.Response {
+	var config v1beta1.ClusterConfig
+	if err := json.Unmarshal(req.Object.Raw, &config); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var old *v1beta1.ClusterConfig
+	if req.Operation == admissionv1.Update {
+		old = new(v1beta1.ClusterConfig)
+		if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+
+		// Only spec changes are validated, so that existing configurations
+		// can still be annotated or labeled, even if they are invalid.
+		if reflect.DeepEqual(old.Spec, config.Spec) {
+			return admission.Allowed("")
+		}
+	}
+
+	if errs := append(config.Validate(), k0sconfig.ValidateK0sFeatureGates(config.Spec.K0sFeatureGates)...); len(errs) > 0 {
+		return admission.Denied(fmt.Sprintf("invalid cluster configuration: %v", errors.Join(errs...)))
+	}
+
+	conditions, err := FieldConditions(old, &config)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	var errs []error
+	for _, condition := range conditions {
+		if condition.Type == v1beta1.FieldChangeImmutable {
+			errs = append(errs, fmt.Errorf("%s: %s", condition.Path, condition.Message))
+		}
+	}
+	if len(errs) > 0 {
+		return admission.Denied(fmt.Sprintf("invalid cluster configuration change: %v", errors.Join(errs...)))
+	}
+
+	return admission.Allowed("")
+}
+
+// ensureWebhookConfigurations creates or updates the mutating and validating
+// webhook configurations that point the API server to the webhooks listening
+// on the given address.
+func ensureWebhookConfigurations(ctx context.Context, cf kubeutil.ClientFactoryInterface, address string, caBundle []byte) error {
+	client, err := cf.GetClient()
+	if err != nil {
+		return err
+	}
+
+	clientConfig := func(path string) admissionregistrationv1.WebhookClientConfig {
+		return admissionregistrationv1.WebhookClientConfig{
+			URL:      ptr.To("https://" + address + path),
+			CABundle: caBundle,
+		}
+	}
+	rules := []admissionregistrationv1.RuleWithOperations{{
+		Operations: []admissionregistrationv1.OperationType{
+			admissionregistrationv1.Create,
+			admissionregistrationv1.Update,
+		},
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{v1beta1.GroupName},
+			APIVersions: []string{v1beta1.GroupVersion.Version},
+			Resources:   []string{"clusterconfigs"},
+			Scope:       ptr.To(admissionregistrationv1.NamespacedScope),
+		},
+	}}
+
+	mutating := client.AdmissionregistrationV1().MutatingWebhookConfigurations()
+	mutatingWebhooks := []admissionregistrationv1.MutatingWebhook{{
+		Name:                    "mutate." + webhookConfigurationName,
+		ClientConfig:            clientConfig(mutatePath),
+		Rules:                   rules,
+		FailurePolicy:           ptr.To(admissionregistrationv1.Ignore),
+		SideEffects:             ptr.To(admissionregistrationv1.SideEffectClassNone),
+		AdmissionReviewVersions: []string{"v1"},
+		TimeoutSeconds:          ptr.To[int32](5),
+	}}
+	existingMutating, err := mutating.Get(ctx, webhookConfigurationName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		_, err = mutating.Create(ctx, &admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: webhookConfigurationName},
+			Webhooks:   mutatingWebhooks,
+		}, metav1.CreateOptions{})
+	case err == nil:
+		existingMutating.Webhooks = mutatingWebhooks
+		_, err = mutating.Update(ctx, existingMutating, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	validating := client.AdmissionregistrationV1().ValidatingWebhookConfigurations()
+	validatingWebhooks := []admissionregistrationv1.ValidatingWebhook{{
+		Name:                    "validate." + webhookConfigurationName,
+		ClientConfig:            clientConfig(validatePath),
+		Rules:                   rules,
+		FailurePolicy:           ptr.To(admissionregistrationv1.Ignore),
+		SideEffects:             ptr.To(admissionregistrationv1.SideEffectClassNone),
+		AdmissionReviewVersions: []string{"v1"},
+		TimeoutSeconds:          ptr.To[int32](5),
+	}}
+	existingValidating, err := validating.Get(ctx, webhookConfigurationName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		_, err = validating.Create(ctx, &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: webhookConfigurationName},
+			Webhooks:   validatingWebhooks,
+		}, metav1.CreateOptions{})
+	case err == nil:
+		existingValidating.Webhooks = validatingWebhooks
+		_, err = validating.Update(ctx, existingValidating, metav1.UpdateOptions{})
+	}
+	return err
+}
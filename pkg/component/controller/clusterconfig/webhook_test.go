@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterconfig
+
+import (
+	"testing"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestMutate(t *testing.T) {
+	t.Run("unchanged", func(t *testing.T) {
+		resp := mutate(admissionRequest(admissionv1.Create, `{"spec":{"network":{"podCIDR":"10.244.0.0/16"}}}`, ""))
+		assert.True(t, resp.Allowed)
+		assert.Empty(t, resp.Patches)
+		assert.Empty(t, resp.Warnings)
+	})
+
+	t.Run("node_specific", func(t *testing.T) {
+		resp := mutate(admissionRequest(admissionv1.Create, `{"spec":{"api":{"port":6443},"network":{"serviceCIDR":"10.96.0.0/12","podCIDR":"10.244.0.0/16"}}}`, ""))
+		assert.True(t, resp.Allowed)
+		var paths []string
+		for _, patch := range resp.Patches {
+			assert.Equal(t, "remove", patch.Operation)
+			paths = append(paths, patch.Path)
+		}
+		assert.ElementsMatch(t, []string{"/spec/api", "/spec/network/serviceCIDR"}, paths)
+		assert.Len(t, resp.Warnings, 2)
+		assert.Contains(t, resp.Warnings[0], "spec.api has been removed")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		resp := validate(admissionRequest(admissionv1.Create, `{"spec":{"network":{"podCIDR":"10.244.0.0/16"}}}`, ""))
+		assert.True(t, resp.Allowed, resp.Result)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		resp := validate(admissionRequest(admissionv1.Create, `{"spec":{"network":{"podCIDR":"not-a-cidr"}}}`, ""))
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "invalid cluster configuration: ")
+		assert.Contains(t, resp.Result.Message, "podCIDR")
+	})
+
+	t.Run("immutable", func(t *testing.T) {
+		resp := validate(admissionRequest(admissionv1.Update,
+			`{"spec":{"network":{"provider":"calico"}}}`,
+			`{"spec":{"network":{"provider":"kuberouter"}}}`,
+		))
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "invalid cluster configuration change: spec.network.provider")
+	})
+
+	t.Run("dynamic", func(t *testing.T) {
+		resp := validate(admissionRequest(admissionv1.Update,
+			`{"spec":{"network":{"kuberouter":{"mtu":1350}}}}`,
+			`{"spec":{"network":{"kuberouter":{"mtu":1450}}}}`,
+		))
+		assert.True(t, resp.Allowed, resp.Result)
+	})
+
+	t.Run("metadata_only", func(t *testing.T) {
+		resp := validate(admissionRequest(admissionv1.Update,
+			`{"metadata":{"labels":{"foo":"bar"}},"spec":{"network":{"podCIDR":"not-a-cidr"}}}`,
+			`{"spec":{"network":{"podCIDR":"not-a-cidr"}}}`,
+		))
+		assert.True(t, resp.Allowed, resp.Result)
+	})
+}
+
+func TestEnsureWebhookConfigurations(t *testing.T) {
+	clients := testutil.NewFakeClientFactory()
+	caBundle := []byte("the-ca")
+
+	require.NoError(t, ensureWebhookConfigurations(t.Context(), clients, "127.0.0.1:8896", caBundle))
+	// A second run updates the existing configurations.
+	require.NoError(t, ensureWebhookConfigurations(t.Context(), clients, "127.0.0.1:8896", caBundle))
+
+	admissionregistration := clients.Client.AdmissionregistrationV1()
+	mutating, err := admissionregistration.MutatingWebhookConfigurations().Get(t.Context(), webhookConfigurationName, metav1.GetOptions{})
+	require.NoError(t, err)
+	if assert.Len(t, mutating.Webhooks, 1) {
+		webhook := mutating.Webhooks[0]
+		assert.Equal(t, "https://127.0.0.1:8896"+mutatePath, *webhook.ClientConfig.URL)
+		assert.Equal(t, caBundle, webhook.ClientConfig.CABundle)
+		assert.Equal(t, []string{"clusterconfigs"}, webhook.Rules[0].Resources)
+	}
+
+	validating, err := admissionregistration.ValidatingWebhookConfigurations().Get(t.Context(), webhookConfigurationName, metav1.GetOptions{})
+	require.NoError(t, err)
+	if assert.Len(t, validating.Webhooks, 1) {
+		webhook := validating.Webhooks[0]
+		assert.Equal(t, "https://127.0.0.1:8896"+validatePath, *webhook.ClientConfig.URL)
+		assert.Equal(t, caBundle, webhook.ClientConfig.CABundle)
+		assert.Equal(t, []string{v1beta1.GroupName}, webhook.Rules[0].APIGroups)
+	}
+}
+
+func admissionRequest(operation admissionv1.Operation, object, oldObject string) admission.Request {
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: operation,
+		Object:    runtime.RawExtension{Raw: []byte(object)},
+	}}
+	if oldObject != "" {
+		req.OldObject = runtime.RawExtension{Raw: []byte(oldObject)}
+	}
+	return req
+}
@@ -8,12 +8,14 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	internallog "github.com/k0sproject/k0s/internal/pkg/log"
 	"github.com/k0sproject/k0s/internal/testutil"
 	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/component/controller"
 	"github.com/k0sproject/k0s/pkg/component/controller/leaderelector"
+	"github.com/k0sproject/k0s/pkg/component/manager"
 	"github.com/k0sproject/k0s/pkg/constant"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -124,6 +126,69 @@ func TestClusterConfigInitializer_Exists(t *testing.T) {
 	})
 }
 
+func TestClusterConfigReconciler_Status(t *testing.T) {
+	config := k0sv1beta1.DefaultClusterConfig()
+	config.Generation = 2
+	clients := testutil.NewFakeClientFactory(config)
+	source := fakeConfigSource(make(chan *k0sv1beta1.ClusterConfig))
+	reconciler := fakeComponentsReconciler{
+		{Component: "controller.CoreDNS"},
+		{Component: "controller.Calico", Err: errors.New("boom")},
+	}
+
+	underTest := controller.NewClusterConfigReconciler(reconciler, clients, source)
+	require.NoError(t, underTest.Init(t.Context()))
+	require.NoError(t, underTest.Start(t.Context()))
+	t.Cleanup(func() { assert.NoError(t, underTest.Stop()) })
+
+	source <- config.DeepCopy()
+
+	var status *k0sv1beta1.ClusterConfigStatus
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		actual, err := clients.K0sClient.K0sV1beta1().
+			ClusterConfigs(constant.ClusterConfigNamespace).
+			Get(t.Context(), "k0s", metav1.GetOptions{})
+		require.NoError(c, err)
+		require.NotNil(c, actual.Status)
+		status = actual.Status
+	}, 10*time.Second, 10*time.Millisecond)
+
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), status.ObservedGeneration)
+	assert.Zero(t, status.AppliedGeneration, "Shouldn't be applied if a component failed")
+	if assert.Len(t, status.Components, 2) {
+		assert.Equal(t, "controller.Calico", status.Components[0].Name)
+		assert.Equal(t, hostname, status.Components[0].Controller)
+		assert.Equal(t, int64(2), status.Components[0].ObservedGeneration)
+		assert.Equal(t, "boom", status.Components[0].Error)
+		assert.Equal(t, "controller.CoreDNS", status.Components[1].Name)
+		assert.Empty(t, status.Components[1].Error)
+	}
+}
+
+type fakeConfigSource chan *k0sv1beta1.ClusterConfig
+
+func (s fakeConfigSource) ResultChan() <-chan *k0sv1beta1.ClusterConfig { return s }
+func (fakeConfigSource) Init(context.Context) error                     { return nil }
+func (fakeConfigSource) Start(context.Context) error                    { return nil }
+func (fakeConfigSource) Stop() error                                    { return nil }
+
+type fakeComponentsReconciler []manager.ReconcileResult
+
+func (r fakeComponentsReconciler) Reconcile(ctx context.Context, cfg *k0sv1beta1.ClusterConfig) error {
+	var errs []error
+	for _, result := range r.ReconcileComponents(ctx, cfg) {
+		errs = append(errs, result.Err)
+	}
+	return errors.Join(errs...)
+}
+
+func (r fakeComponentsReconciler) ReconcileComponents(context.Context, *k0sv1beta1.ClusterConfig) []manager.ReconcileResult {
+	return r
+}
+
 func TestMain(m *testing.M) {
 	internallog.SetDebugLevel()
 	os.Exit(m.Run())
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -32,9 +33,10 @@ type Manager struct {
 	ServiceClusterIPRange string
 	ExtraArgs             string
 
-	supervisor     *supervisor.Supervisor
-	uid, gid       int
-	previousConfig stringmap.StringMap
+	supervisor        *supervisor.Supervisor
+	uid, gid          int
+	previousConfig    stringmap.StringMap
+	previousResources *v1beta1.ProcessResources
 }
 
 var cmDefaultArgs = stringmap.StringMap{
@@ -110,6 +112,7 @@ func (a *Manager) Reconcile(_ context.Context, clusterConfig *v1beta1.ClusterCon
 	} else {
 		args["node-cidr-mask-size"] = "24"
 	}
+	args.Merge(clusterConfig.Spec.ControllerManager.Args())
 	for name, value := range clusterConfig.Spec.ControllerManager.ExtraArgs {
 		if _, ok := args[name]; ok {
 			logger.Warnf("overriding kube-controller-manager flag with user provided value: %s", name)
@@ -127,7 +130,8 @@ func (a *Manager) Reconcile(_ context.Context, clusterConfig *v1beta1.ClusterCon
 
 	args = clusterConfig.Spec.FeatureGates.BuildArgs(args, kubeControllerManagerComponent)
 
-	if args.Equals(a.previousConfig) && a.supervisor != nil {
+	resources := clusterConfig.Spec.ControllerManager.Resources
+	if args.Equals(a.previousConfig) && reflect.DeepEqual(resources, a.previousResources) && a.supervisor != nil {
 		// no changes and supervisor already running, do nothing
 		logger.Info("reconcile has nothing to do")
 		return nil
@@ -140,15 +144,18 @@ func (a *Manager) Reconcile(_ context.Context, clusterConfig *v1beta1.ClusterCon
 	}
 
 	a.supervisor = &supervisor.Supervisor{
-		Name:    kubeControllerManagerComponent,
-		BinPath: assets.BinPath(kubeControllerManagerComponent, a.K0sVars.BinDir),
-		RunDir:  a.K0sVars.RunDir,
-		DataDir: a.K0sVars.DataDir,
-		Args:    args.ToDashedArgs(),
-		UID:     a.uid,
-		GID:     a.gid,
+		Name:        kubeControllerManagerComponent,
+		BinPath:     assets.BinPath(kubeControllerManagerComponent, a.K0sVars.BinDir),
+		RunDir:      a.K0sVars.RunDir,
+		DataDir:     a.K0sVars.DataDir,
+		Args:        args.ToDashedArgs(),
+		UID:         a.uid,
+		GID:         a.gid,
+		Resources:   resources,
+		HealthProbe: healthzProbe(args, "10257"),
 	}
 	a.previousConfig = args
+	a.previousResources = resources.DeepCopy()
 	return a.supervisor.Supervise()
 }
 
@@ -9,12 +9,15 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"net"
+	"slices"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	authorization "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/certificates/v1"
 	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	clientset "k8s.io/client-go/kubernetes"
@@ -34,16 +37,21 @@ type CSRApprover struct {
 	KubeClientFactory kubeutil.ClientFactoryInterface
 	leaderElector     leaderelector.Interface
 	clientset         clientset.Interface
+
+	// Whether the node identity and the SANs of kubelet serving CSRs are
+	// verified against the Node objects before approving them.
+	verifyNodes bool
 }
 
 var _ manager.Component = (*CSRApprover)(nil)
 
 // NewCSRApprover creates the CSRApprover component
-func NewCSRApprover(c *v1beta1.ClusterConfig, leaderElector leaderelector.Interface, kubeClientFactory kubeutil.ClientFactoryInterface) *CSRApprover {
+func NewCSRApprover(c *v1beta1.ClusterConfig, leaderElector leaderelector.Interface, kubeClientFactory kubeutil.ClientFactoryInterface, verifyNodes bool) *CSRApprover {
 	return &CSRApprover{
 		ClusterConfig:     c,
 		leaderElector:     leaderElector,
 		KubeClientFactory: kubeClientFactory,
+		verifyNodes:       verifyNodes,
 		log:               logrus.WithFields(logrus.Fields{"component": "csrapprover"}),
 	}
 }
@@ -121,6 +129,13 @@ func (a *CSRApprover) approveCSR(ctx context.Context) error {
 			continue
 		}
 
+		if a.verifyNodes {
+			if err := a.verifyNode(ctx, &csr, x509cr); err != nil {
+				a.log.WithError(err).Infof("Not approving CSR %q as it doesn't match the requesting node", csr.Name)
+				continue
+			}
+		}
+
 		approved, err := a.authorize(ctx, &csr, authorization.ResourceAttributes{
 			Group:    "certificates.k8s.io",
 			Resource: "certificatesigningrequests",
@@ -180,6 +195,49 @@ func (a *CSRApprover) ensureKubeletServingCert(csr *v1.CertificateSigningRequest
 	return certificates.ValidateKubeletServingCSR(x509cr, usages)
 }
 
+// verifyNode checks that the CSR has been requested by the node that it's
+// for, and that the requested SANs are among the addresses of that node.
+func (a *CSRApprover) verifyNode(ctx context.Context, csr *v1.CertificateSigningRequest, x509cr *x509.CertificateRequest) error {
+	// The common name has been validated to be system:node:<node name>.
+	if csr.Spec.Username != x509cr.Subject.CommonName {
+		return fmt.Errorf("requested by %q instead of %q", csr.Spec.Username, x509cr.Subject.CommonName)
+	}
+	nodeName := x509cr.Subject.CommonName[len("system:node:"):]
+
+	node, err := a.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("node %s doesn't exist", nodeName)
+	} else if err != nil {
+		return fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+
+	var dnsNames []string
+	var ips []net.IP
+	for _, addr := range node.Status.Addresses {
+		switch addr.Type {
+		case core.NodeHostName, core.NodeInternalDNS, core.NodeExternalDNS:
+			dnsNames = append(dnsNames, addr.Address)
+		case core.NodeInternalIP, core.NodeExternalIP:
+			if ip := net.ParseIP(addr.Address); ip != nil {
+				ips = append(ips, ip)
+			}
+		}
+	}
+
+	for _, dnsName := range x509cr.DNSNames {
+		if !slices.Contains(dnsNames, dnsName) {
+			return fmt.Errorf("DNS name %s isn't an address of node %s", dnsName, nodeName)
+		}
+	}
+	for _, ip := range x509cr.IPAddresses {
+		if !slices.ContainsFunc(ips, ip.Equal) {
+			return fmt.Errorf("IP address %s isn't an address of node %s", ip, nodeName)
+		}
+	}
+
+	return nil
+}
+
 func getCertApprovalCondition(status *v1.CertificateSigningRequestStatus) (approved bool, denied bool) {
 	for _, c := range status.Conditions {
 		if c.Type == v1.CertificateApproved {
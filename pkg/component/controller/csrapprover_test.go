@@ -10,15 +10,21 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"net"
 	"testing"
 
 	"github.com/k0sproject/k0s/internal/testutil"
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/component/controller/leaderelector"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	certv1 "k8s.io/api/certificates/v1"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestBasicCRSApprover(t *testing.T) {
@@ -57,7 +63,7 @@ func TestBasicCRSApprover(t *testing.T) {
 			},
 		},
 	}
-	c := NewCSRApprover(config, &leaderelector.Dummy{Leader: true}, fakeFactory)
+	c := NewCSRApprover(config, &leaderelector.Dummy{Leader: true}, fakeFactory, false)
 
 	assert.NoError(t, c.Init(ctx))
 	assert.NoError(t, c.approveCSR(ctx))
@@ -71,6 +77,98 @@ func TestBasicCRSApprover(t *testing.T) {
 	}
 }
 
+func TestCSRApprover_VerifyNodes(t *testing.T) {
+	node := &core.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Status: core.NodeStatus{Addresses: []core.NodeAddress{
+			{Type: core.NodeHostName, Address: "worker"},
+			{Type: core.NodeInternalIP, Address: "10.0.0.1"},
+			{Type: core.NodeExternalDNS, Address: "worker.example.com"},
+		}},
+	}
+	fakeFactory := testutil.NewFakeClientFactory(node)
+	fakeClient, ok := fakeFactory.Client.(*kubernetesfake.Clientset)
+	require.True(t, ok)
+	fakeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = true
+		return true, review, nil
+	})
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	servingCSR := func(name, nodeName, username string, dnsNames []string, ips ...string) *certv1.CertificateSigningRequest {
+		template := &x509.CertificateRequest{
+			Subject: pkix.Name{
+				CommonName:   "system:node:" + nodeName,
+				Organization: []string{"system:nodes"},
+			},
+			DNSNames: dnsNames,
+		}
+		for _, ip := range ips {
+			template.IPAddresses = append(template.IPAddresses, net.ParseIP(ip))
+		}
+		return &certv1.CertificateSigningRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: certv1.CertificateSigningRequestSpec{
+				Request:    pemWithTemplate(template, privateKey),
+				SignerName: certv1.KubeletServingSignerName,
+				Usages:     []certv1.KeyUsage{certv1.UsageDigitalSignature, certv1.UsageKeyEncipherment, certv1.UsageServerAuth},
+				Username:   username,
+				Groups:     []string{"system:nodes", "system:authenticated"},
+			},
+		}
+	}
+
+	underTest := NewCSRApprover(&v1beta1.ClusterConfig{}, &leaderelector.Dummy{Leader: true}, fakeFactory, true)
+	require.NoError(t, underTest.Init(t.Context()))
+
+	for _, test := range []struct {
+		name string
+		csr  *certv1.CertificateSigningRequest
+		err  string
+	}{
+		{"valid", servingCSR("valid", "worker", "system:node:worker", []string{"worker", "worker.example.com"}, "10.0.0.1"), ""},
+		{"other_requester", servingCSR("other_requester", "worker", "system:node:other", []string{"worker"}), `requested by "system:node:other" instead of "system:node:worker"`},
+		{"unknown_node", servingCSR("unknown_node", "other", "system:node:other", []string{"other"}), "node other doesn't exist"},
+		{"foreign_dns_name", servingCSR("foreign_dns_name", "worker", "system:node:worker", []string{"worker", "kubernetes.default"}), "DNS name kubernetes.default isn't an address of node worker"},
+		{"foreign_ip", servingCSR("foreign_ip", "worker", "system:node:worker", []string{"worker"}, "10.0.0.2"), "IP address 10.0.0.2 isn't an address of node worker"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			x509cr, err := parseCSR(test.csr)
+			require.NoError(t, err)
+			err = underTest.verifyNode(t.Context(), test.csr, x509cr)
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+
+	t.Run("approval", func(t *testing.T) {
+		csrs := fakeClient.CertificatesV1().CertificateSigningRequests()
+		for _, csr := range []*certv1.CertificateSigningRequest{
+			servingCSR("foreign", "worker", "system:node:worker", []string{"kubernetes.default"}),
+			servingCSR("matching", "worker", "system:node:worker", []string{"worker"}, "10.0.0.1"),
+		} {
+			_, err := csrs.Create(t.Context(), csr, metav1.CreateOptions{})
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, underTest.approveCSR(t.Context()))
+
+		csr, err := csrs.Get(t.Context(), "foreign", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, csr.Status.Conditions, "CSR for foreign SANs should be left pending")
+		csr, err = csrs.Get(t.Context(), "matching", metav1.GetOptions{})
+		require.NoError(t, err)
+		approved, denied := getCertApprovalCondition(&csr.Status)
+		assert.True(t, approved)
+		assert.False(t, denied)
+	})
+}
+
 func pemWithPrivateKey(pk crypto.PrivateKey) []byte {
 	template := &x509.CertificateRequest{
 		Subject: pkix.Name{
@@ -6,9 +6,12 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,6 +19,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"go.etcd.io/etcd/client/pkg/v3/tlsutil"
 	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/k0sproject/k0s/internal/pkg/dir"
 	"github.com/k0sproject/k0s/internal/pkg/file"
@@ -32,6 +36,10 @@ import (
 	"github.com/k0sproject/k0s/pkg/token"
 )
 
+// etcdLearnerPromotionTimeout is the time to wait for a joining etcd member to
+// catch up with the leader and be promoted to a voting member.
+const etcdLearnerPromotionTimeout = 10 * time.Minute
+
 // Etcd implement the component interface to run etcd
 type Etcd struct {
 	CertManager certificate.Manager
@@ -135,12 +143,8 @@ func (e *Etcd) syncEtcdConfig(ctx context.Context, etcdRequest v1beta1.EtcdReque
 	return etcdResponse.InitialCluster, nil
 }
 
-// Run runs etcd if external cluster is not configured
+// Start runs etcd
 func (e *Etcd) Start(ctx context.Context) error {
-	if e.Config.IsExternalClusterUsed() {
-		return nil
-	}
-
 	etcdCaCert := filepath.Join(e.K0sVars.EtcdCertDir, "ca.crt")
 	etcdCaCertKey := filepath.Join(e.K0sVars.EtcdCertDir, "ca.key")
 	etcdServerCert := filepath.Join(e.K0sVars.EtcdCertDir, "server.crt")
@@ -183,11 +187,13 @@ func (e *Etcd) Start(ctx context.Context) error {
 		"--enable-pprof":                "false",
 	}
 
+	var joined bool
 	// Use the main etcd data directory as the source of truth to determine if this node has already joined
 	// See https://etcd.io/docs/v3.5/learning/persistent-storage-files/#bbolt-btree-membersnapdb
 	if file.Exists(filepath.Join(e.K0sVars.EtcdDataDir, "member", "snap", "db")) {
 		logrus.Warnf("etcd db file(s) already exist, not gonna run join process")
 	} else if e.JoinClient != nil {
+		joined = true
 		etcdRequest := v1beta1.EtcdRequest{
 			Node:        name,
 			PeerAddress: peerURL,
@@ -210,6 +216,12 @@ func (e *Etcd) Start(ctx context.Context) error {
 		args["--auth-token"] = auth
 	}
 
+	if e.Config.Metrics != nil && e.Config.Metrics.ListenAddress != "" {
+		args["--listen-metrics-urls"] = (&url.URL{Scheme: "http", Host: e.Config.Metrics.ListenAddress}).String()
+	}
+
+	maps.Copy(args, tuningArgs(e.Config))
+
 	for name, value := range e.Config.ExtraArgs {
 		argName := "--" + name
 		if _, ok := args[argName]; ok {
@@ -237,16 +249,78 @@ func (e *Etcd) Start(ctx context.Context) error {
 		GID:           e.gid,
 		KeepEnvPrefix: true,
 	}
+	e.supervisor.ApplyRestartPolicy(e.Config.RestartPolicy)
+	e.supervisor.Resources = e.Config.Resources
 
-	return e.supervisor.Supervise()
+	if err := e.supervisor.Supervise(); err != nil {
+		return err
+	}
+
+	if joined {
+		return e.waitForPromotion(ctx)
+	}
+	return nil
 }
 
-// Stop stops etcd
-func (e *Etcd) Stop() error {
-	if e.Config.IsExternalClusterUsed() {
-		return nil
+// waitForPromotion waits until the local member, which joined the cluster as
+// a learner, has been promoted to a voting member by the other controllers. A
+// learner rejects most client requests, so nothing can make use of it before.
+func (e *Etcd) waitForPromotion(ctx context.Context) error {
+	client, err := etcd.NewClient(e.K0sVars.CertRootDir, e.K0sVars.EtcdCertDir, e.Config)
+	if err != nil {
+		return fmt.Errorf("failed to create etcd client: %w", err)
+	}
+	defer client.Close()
+
+	logrus.Info("Waiting for the etcd learner to be promoted")
+	var lastErr error
+	err = wait.PollUntilContextTimeout(ctx, 2*time.Second, etcdLearnerPromotionTimeout, true, func(ctx context.Context) (bool, error) {
+		learner, err := client.IsLearner(ctx)
+		if err != nil {
+			// etcd might still be starting.
+			lastErr = err
+			return false, nil
+		}
+		lastErr = nil
+		return !learner, nil
+	})
+	if err != nil {
+		if lastErr != nil && wait.Interrupted(err) {
+			err = lastErr
+		}
+		return fmt.Errorf("etcd learner hasn't been promoted: %w", err)
+	}
+
+	logrus.Info("etcd learner has been promoted to a voting member")
+	return nil
+}
+
+// tuningArgs returns the etcd flags for the tuning parameters that have been
+// configured. Parameters that haven't been configured are left to etcd's
+// defaults.
+func tuningArgs(config *v1beta1.EtcdConfig) stringmap.StringMap {
+	args := stringmap.StringMap{}
+	if c := config.AutoCompaction; c != nil {
+		args["--auto-compaction-mode"] = string(c.GetMode())
+		args["--auto-compaction-retention"] = c.Retention
 	}
+	if config.QuotaBackendBytes != nil {
+		args["--quota-backend-bytes"] = strconv.FormatInt(config.QuotaBackendBytes.Value(), 10)
+	}
+	if config.HeartbeatInterval != nil {
+		args["--heartbeat-interval"] = strconv.FormatInt(config.HeartbeatInterval.Milliseconds(), 10)
+	}
+	if config.ElectionTimeout != nil {
+		args["--election-timeout"] = strconv.FormatInt(config.ElectionTimeout.Milliseconds(), 10)
+	}
+	if config.SnapshotCount != nil {
+		args["--snapshot-count"] = strconv.FormatUint(*config.SnapshotCount, 10)
+	}
+	return args
+}
 
+// Stop stops etcd
+func (e *Etcd) Stop() error {
 	e.supervisor.Stop()
 	return nil
 }
@@ -326,6 +400,24 @@ func (e *Etcd) setupCerts(ctx context.Context) error {
 	return eg.Wait()
 }
 
+// rotateCertificates reissues the etcd certificates and restarts etcd, so that
+// it picks up the new certificates. Waits until etcd is ready again.
+func (e *Etcd) rotateCertificates(ctx context.Context) error {
+	if err := e.setupCerts(ctx); err != nil {
+		return fmt.Errorf("failed to reissue etcd certs: %w", err)
+	}
+
+	logrus.Info("Restarting etcd")
+	e.supervisor.Stop()
+	if err := e.supervisor.Supervise(); err != nil {
+		return fmt.Errorf("failed to restart etcd: %w", err)
+	}
+
+	return wait.PollUntilContextTimeout(ctx, time.Second, 2*time.Minute, true, func(context.Context) (bool, error) {
+		return e.Ready() == nil, nil
+	})
+}
+
 // Health-check interface
 func (e *Etcd) Ready() error {
 	logrus.WithField("component", "etcd").Debug("checking etcd endpoint for health")
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	autopilotv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/etcd"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// etcdAlarmCheckInterval is the interval in which the alarms of the local etcd
+// member are checked.
+const etcdAlarmCheckInterval = 30 * time.Second
+
+var (
+	etcdAlarmActive = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "k0s",
+		Name:      "etcd_alarm_active",
+		Help:      "Whether an alarm is raised for the local etcd member.",
+	}, []string{"alarm"})
+
+	etcdAlarmRecoveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "k0s",
+		Name:      "etcd_alarm_recoveries_total",
+		Help:      "Number of automatic recoveries from alarms of the local etcd member.",
+	}, []string{"alarm", "result"})
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(etcdAlarmActive, etcdAlarmRecoveries)
+}
+
+// EtcdAlarmMonitor watches the alarms raised for the etcd member running on
+// this controller. Raised and cleared alarms are reported as Events on the
+// controller's ControlNode, and as metrics. Optionally recovers from NOSPACE
+// alarms, which would leave the cluster read-only otherwise.
+type EtcdAlarmMonitor struct {
+	K0sVars           *config.CfgVars
+	EtcdConfig        *v1beta1.EtcdConfig
+	KubeClientFactory kubeutil.ClientFactoryInterface
+
+	stop func()
+}
+
+var _ manager.Component = (*EtcdAlarmMonitor)(nil)
+
+func (m *EtcdAlarmMonitor) Init(context.Context) error {
+	return nil
+}
+
+func (m *EtcdAlarmMonitor) Start(context.Context) error {
+	log := logrus.WithField("component", "etcd-alarm-monitor")
+
+	client, err := etcd.NewClient(m.K0sVars.CertRootDir, m.K0sVars.EtcdCertDir, m.EtcdConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create etcd client: %w", err)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer client.Close()
+		var raised []string
+		wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+			var err error
+			if raised, err = m.check(ctx, log, client, raised); err != nil && !errors.Is(err, context.Cause(ctx)) {
+				log.WithError(err).Error("Failed to check etcd alarms")
+			}
+		}, etcdAlarmCheckInterval, 0.1, true)
+	}()
+
+	m.stop = func() {
+		cancel(errors.New("etcd alarm monitor is stopping"))
+		<-done
+	}
+
+	return nil
+}
+
+func (m *EtcdAlarmMonitor) Stop() error {
+	if m.stop != nil {
+		m.stop()
+	}
+	return nil
+}
+
+// check reports the alarms of the local member that have been raised or
+// cleared since the previous check, and recovers from NOSPACE alarms, if
+// enabled. Returns the alarms that are currently raised.
+func (m *EtcdAlarmMonitor) check(ctx context.Context, log logrus.FieldLogger, client *etcd.Client, previous []string) ([]string, error) {
+	member, err := client.LocalAlarms(ctx)
+	if err != nil {
+		return previous, err
+	}
+
+	for _, alarm := range member.Alarms {
+		etcdAlarmActive.WithLabelValues(alarm).Set(1)
+		if !slices.Contains(previous, alarm) {
+			log.Warnf("etcd alarm %s raised for member %s (%x)", alarm, member.Name, member.MemberID)
+			m.recordEvent(ctx, log, member.Name, corev1.EventTypeWarning, "EtcdAlarmRaised", "etcd alarm "+alarm+" raised")
+		}
+	}
+	for _, alarm := range previous {
+		if !slices.Contains(member.Alarms, alarm) {
+			etcdAlarmActive.WithLabelValues(alarm).Set(0)
+			log.Infof("etcd alarm %s cleared for member %s (%x)", alarm, member.Name, member.MemberID)
+			m.recordEvent(ctx, log, member.Name, corev1.EventTypeNormal, "EtcdAlarmCleared", "etcd alarm "+alarm+" cleared")
+		}
+	}
+
+	if !slices.Contains(member.Alarms, etcd.NoSpaceAlarm) || !m.EtcdConfig.AlarmRecovery.IsNoSpaceRecoveryEnabled() {
+		return member.Alarms, nil
+	}
+
+	log.Info("Recovering from etcd alarm ", etcd.NoSpaceAlarm)
+	if err := client.RecoverFromNoSpace(ctx, log); err != nil {
+		etcdAlarmRecoveries.WithLabelValues(etcd.NoSpaceAlarm, "failure").Inc()
+		m.recordEvent(ctx, log, member.Name, corev1.EventTypeWarning, "EtcdAlarmRecoveryFailed", fmt.Sprintf("Failed to recover from etcd alarm %s: %v", etcd.NoSpaceAlarm, err))
+		return member.Alarms, fmt.Errorf("failed to recover from etcd alarm %s: %w", etcd.NoSpaceAlarm, err)
+	}
+
+	etcdAlarmRecoveries.WithLabelValues(etcd.NoSpaceAlarm, "success").Inc()
+	m.recordEvent(ctx, log, member.Name, corev1.EventTypeNormal, "EtcdAlarmRecovered", "Compacted and defragmented etcd member, disarmed etcd alarm "+etcd.NoSpaceAlarm)
+
+	// Let the next check report the cleared alarm.
+	return member.Alarms, nil
+}
+
+// recordEvent creates an Event for the ControlNode of this controller, which
+// has the same name as its etcd member. Failures are only logged: the API
+// server can't persist any Events while the cluster rejects writes.
+func (m *EtcdAlarmMonitor) recordEvent(ctx context.Context, log logrus.FieldLogger, nodeName, eventType, reason, message string) {
+	client, err := m.KubeClientFactory.GetClient()
+	if err != nil {
+		log.WithError(err).Warn("Failed to get Kubernetes client, not recording event ", reason)
+		return
+	}
+
+	now := metav1.Now()
+	_, err = client.CoreV1().Events(metav1.NamespaceDefault).Create(ctx, &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: nodeName + ".",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: autopilotv1beta2.SchemeGroupVersion.String(),
+			Kind:       "ControlNode",
+			Name:       nodeName,
+		},
+		Reason:              reason,
+		Message:             message,
+		Type:                eventType,
+		FirstTimestamp:      now,
+		LastTimestamp:       now,
+		Count:               1,
+		Source:              corev1.EventSource{Component: "k0s-controller", Host: nodeName},
+		ReportingController: "k0s-controller",
+		ReportingInstance:   nodeName,
+	}, metav1.CreateOptions{})
+	if err != nil {
+		log.WithError(err).Warn("Failed to record event ", reason)
+	}
+}
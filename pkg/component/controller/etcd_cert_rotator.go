@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/certificate"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/etcd"
+
+	"github.com/cloudflare/cfssl/certinfo"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// etcdCertRotationCheckInterval is the interval in which the etcd
+	// certificates are checked for expiry and rotation requests.
+	etcdCertRotationCheckInterval = 1 * time.Minute
+
+	// etcdCertRenewalThreshold is the fraction of the validity period of a
+	// certificate after which it gets rotated automatically.
+	etcdCertRenewalThreshold = 0.8
+)
+
+// EtcdCertificateRotator rotates the certificates of the etcd member running
+// on this controller, either if they're about to expire, or if a rotation has
+// been requested via "k0s etcd rotate-certs". The etcd member is restarted
+// afterwards, one member of the cluster at a time.
+type EtcdCertificateRotator struct {
+	Etcd *Etcd
+
+	stop func()
+}
+
+var _ manager.Component = (*EtcdCertificateRotator)(nil)
+
+func (r *EtcdCertificateRotator) Init(context.Context) error {
+	return nil
+}
+
+func (r *EtcdCertificateRotator) Start(context.Context) error {
+	log := logrus.WithField("component", "etcd-cert-rotator")
+	k0sVars := r.Etcd.K0sVars
+
+	client, err := etcd.NewClient(k0sVars.CertRootDir, k0sVars.EtcdCertDir, r.Etcd.Config)
+	if err != nil {
+		return fmt.Errorf("failed to create etcd client: %w", err)
+	}
+	peerTLS, err := etcd.PeerTLSConfig(k0sVars.EtcdCertDir)
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("failed to load etcd peer certificates: %w", err)
+	}
+	rotator := etcd.CertificateRotator{
+		Client:  client,
+		PeerTLS: peerTLS,
+		Rotate:  r.Etcd.rotateCertificates,
+		Log:     log,
+	}
+	certFiles := []string{
+		filepath.Join(k0sVars.EtcdCertDir, "server.crt"),
+		filepath.Join(k0sVars.EtcdCertDir, "peer.crt"),
+		filepath.Join(k0sVars.CertRootDir, "apiserver-etcd-client.crt"),
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer client.Close()
+		wait.UntilWithContext(ctx, func(ctx context.Context) {
+			due, err := certificatesDue(time.Now(), certFiles...)
+			if err != nil {
+				log.WithError(err).Error("Failed to check etcd certificates for expiry")
+				return
+			}
+			if due {
+				log.Info("etcd certificates are about to expire")
+			} else if due, err = rotator.RotationRequested(ctx); err != nil {
+				if !errors.Is(err, context.Cause(ctx)) {
+					log.WithError(err).Error("Failed to check for etcd certificate rotation requests")
+				}
+				return
+			} else if due {
+				log.Info("Rotation of etcd certificates has been requested")
+			}
+
+			if due {
+				if err := rotator.RotateCertificates(ctx); err != nil && !errors.Is(err, context.Cause(ctx)) {
+					log.WithError(err).Error("Failed to rotate etcd certificates")
+				}
+			}
+		}, etcdCertRotationCheckInterval)
+	}()
+
+	r.stop = func() {
+		cancel(errors.New("etcd certificate rotator is stopping"))
+		<-done
+	}
+
+	return nil
+}
+
+func (r *EtcdCertificateRotator) Stop() error {
+	if r.stop != nil {
+		r.stop()
+	}
+	return nil
+}
+
+// certificatesDue returns true if any of the given certificates has exceeded
+// the renewal threshold of its validity period. Certificates that aren't
+// managed by k0s are ignored, as k0s won't reissue them.
+func certificatesDue(now time.Time, certFiles ...string) (bool, error) {
+	for _, certFile := range certFiles {
+		cert, err := certinfo.ParseCertificateFile(certFile)
+		if err != nil {
+			return false, err
+		}
+		if !certificate.IsManagedByK0s(cert) {
+			continue
+		}
+
+		validity := cert.NotAfter.Sub(cert.NotBefore)
+		renewAt := cert.NotBefore.Add(time.Duration(float64(validity) * etcdCertRenewalThreshold))
+		if !now.Before(renewAt) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificatesDue(t *testing.T) {
+	notBefore := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := notBefore.Add(100 * time.Hour)
+
+	dir := t.TempDir()
+	managed := writeTestCert(t, dir, "managed.crt", "etcd-ca", notBefore, notAfter)
+	custom := writeTestCert(t, dir, "custom.crt", "custom-ca", notBefore, notAfter)
+
+	due, err := certificatesDue(notBefore.Add(79*time.Hour), managed)
+	assert.NoError(t, err)
+	assert.False(t, due, "certificate shouldn't be due before 80% of its validity")
+
+	due, err = certificatesDue(notBefore.Add(80*time.Hour), managed)
+	assert.NoError(t, err)
+	assert.True(t, due, "certificate should be due after 80% of its validity")
+
+	due, err = certificatesDue(notAfter.Add(time.Hour), custom)
+	assert.NoError(t, err)
+	assert.False(t, due, "certificates not managed by k0s should be ignored")
+
+	_, err = certificatesDue(notBefore, filepath.Join(dir, "missing.crt"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func writeTestCert(t *testing.T, dir, name, issuer string, notBefore, notAfter time.Time) string {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: issuer},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, &caTemplate, &caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "etcd-server"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	return path
+}
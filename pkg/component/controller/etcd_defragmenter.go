@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/etcd"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// EtcdDefragmenter periodically defragments the etcd member running on this
+// controller, if the size of its database exceeds the configured threshold.
+type EtcdDefragmenter struct {
+	K0sVars    *config.CfgVars
+	EtcdConfig *v1beta1.EtcdConfig
+
+	defragmenter *etcd.Defragmenter
+	stop         func()
+}
+
+var _ manager.Component = (*EtcdDefragmenter)(nil)
+
+func (d *EtcdDefragmenter) Init(context.Context) error {
+	return nil
+}
+
+func (d *EtcdDefragmenter) Start(context.Context) error {
+	log := logrus.WithField("component", "etcd-defragmenter")
+
+	client, err := etcd.NewClient(d.K0sVars.CertRootDir, d.K0sVars.EtcdCertDir, d.EtcdConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create etcd client: %w", err)
+	}
+	peerTLS, err := etcd.PeerTLSConfig(d.K0sVars.EtcdCertDir)
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("failed to load etcd peer certificates: %w", err)
+	}
+	d.defragmenter = &etcd.Defragmenter{
+		Client:    client,
+		PeerTLS:   peerTLS,
+		MinDBSize: d.EtcdConfig.Defragmentation.GetDBSizeThreshold(),
+		Log:       log,
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer client.Close()
+		// Add some jitter, so that the controllers don't all compete for the
+		// defragmentation lock at the same time.
+		wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+			if _, err := d.defragmenter.Defragment(ctx); err != nil && !errors.Is(err, context.Cause(ctx)) {
+				log.WithError(err).Error("Failed to defragment etcd member")
+			}
+		}, d.EtcdConfig.Defragmentation.Interval.Duration, 0.1, false)
+	}()
+
+	d.stop = func() {
+		cancel(errors.New("etcd defragmenter is stopping"))
+		<-done
+	}
+
+	return nil
+}
+
+func (d *EtcdDefragmenter) Stop() error {
+	if d.stop != nil {
+		d.stop()
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/etcd"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// externalEtcdStartupTimeout is the time to wait for any endpoint of an
+// external etcd cluster to become healthy during startup.
+const externalEtcdStartupTimeout = 2 * time.Minute
+
+// ExternalEtcd implements the component interface for an external etcd
+// cluster. Nothing is run or supervised by k0s. The component only ensures
+// that the cluster is usable before the API server is started.
+type ExternalEtcd struct {
+	Config  *v1beta1.EtcdConfig
+	K0sVars *config.CfgVars
+}
+
+var _ manager.Component = (*ExternalEtcd)(nil)
+var _ manager.Ready = (*ExternalEtcd)(nil)
+
+// Init verifies that the configured TLS files are accessible.
+func (e *ExternalEtcd) Init(context.Context) error {
+	for field, path := range map[string]string{
+		"caFile":         e.Config.ExternalCluster.CaFile,
+		"clientCertFile": e.Config.ExternalCluster.ClientCertFile,
+		"clientKeyFile":  e.Config.ExternalCluster.ClientKeyFile,
+	} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("invalid external etcd %s: %w", field, err)
+		}
+	}
+
+	return nil
+}
+
+// Start waits until at least one of the endpoints of the external etcd cluster
+// is healthy. Unhealthy endpoints are reported, but tolerated.
+func (e *ExternalEtcd) Start(ctx context.Context) error {
+	log := logrus.WithField("component", "external-etcd")
+
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, 2*time.Second, externalEtcdStartupTimeout, true, func(ctx context.Context) (bool, error) {
+		health, err := etcd.CheckEndpointsHealth(ctx, e.K0sVars.CertRootDir, e.K0sVars.EtcdCertDir, e.Config)
+		if err != nil {
+			return false, fmt.Errorf("failed to initialize etcd client: %w", err)
+		}
+
+		var errs []error
+		for _, h := range health {
+			if h.Error != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", h.Endpoint, h.Error))
+			}
+		}
+		if len(errs) == len(health) {
+			lastErr = errors.Join(errs...)
+			log.WithError(lastErr).Debug("No external etcd endpoint is healthy yet")
+			return false, nil
+		}
+
+		for _, err := range errs {
+			log.WithError(err).Warn("External etcd endpoint is unhealthy")
+		}
+		return true, nil
+	})
+	if err != nil {
+		if lastErr != nil && wait.Interrupted(err) {
+			return fmt.Errorf("none of the external etcd endpoints is healthy: %w", lastErr)
+		}
+		return err
+	}
+
+	log.Info("External etcd cluster is healthy")
+	return nil
+}
+
+// Stop does nothing, as the external etcd cluster isn't managed by k0s.
+func (e *ExternalEtcd) Stop() error {
+	return nil
+}
+
+// Ready implements [manager.Ready].
+func (e *ExternalEtcd) Ready() error {
+	ctx, cancel := context.WithTimeout(context.TODO(), 1*time.Second)
+	defer cancel()
+	return etcd.CheckEtcdReady(ctx, e.K0sVars.CertRootDir, e.K0sVars.EtcdCertDir, e.Config)
+}
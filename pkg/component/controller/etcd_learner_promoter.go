@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/etcd"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// etcdLearnerPromotionInterval is the interval in which etcd learners are
+// checked for promotion.
+const etcdLearnerPromotionInterval = 5 * time.Second
+
+// EtcdLearnerPromoter promotes joining etcd members, which are added to the
+// cluster as learners, to voting members as soon as they have caught up with
+// the leader. A learner can't promote itself, and the client endpoints of the
+// other members are only reachable locally, hence this runs on all
+// controllers. Concurrent promotions are harmless.
+type EtcdLearnerPromoter struct {
+	K0sVars    *config.CfgVars
+	EtcdConfig *v1beta1.EtcdConfig
+
+	stop func()
+}
+
+var _ manager.Component = (*EtcdLearnerPromoter)(nil)
+
+func (p *EtcdLearnerPromoter) Init(context.Context) error {
+	return nil
+}
+
+func (p *EtcdLearnerPromoter) Start(context.Context) error {
+	log := logrus.WithField("component", "etcd-learner-promoter")
+
+	client, err := etcd.NewClient(p.K0sVars.CertRootDir, p.K0sVars.EtcdCertDir, p.EtcdConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create etcd client: %w", err)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer client.Close()
+		wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+			if err := p.promote(ctx, log, client); err != nil && !errors.Is(err, context.Cause(ctx)) {
+				log.WithError(err).Error("Failed to promote etcd learners")
+			}
+		}, etcdLearnerPromotionInterval, 0.5, true)
+	}()
+
+	p.stop = func() {
+		cancel(errors.New("etcd learner promoter is stopping"))
+		<-done
+	}
+
+	return nil
+}
+
+func (p *EtcdLearnerPromoter) Stop() error {
+	if p.stop != nil {
+		p.stop()
+	}
+	return nil
+}
+
+func (p *EtcdLearnerPromoter) promote(ctx context.Context, log logrus.FieldLogger, client *etcd.Client) error {
+	// A learner can't promote anyone, its client endpoint rejects the request.
+	if learner, err := client.IsLearner(ctx); err != nil || learner {
+		return err
+	}
+	return client.PromoteLearners(ctx, log)
+}
@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/controller/leaderelector"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/etcd"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/leaderelection"
+
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// etcdMemberProbeInterval is the interval in which the etcd members are probed
+// for reachability.
+const etcdMemberProbeInterval = 30 * time.Second
+
+// EtcdMemberEvictor removes permanently failed members from the etcd cluster.
+// A member is considered to be permanently failed if its peer endpoint has
+// been unreachable for longer than the configured timeout, and the ControlNode
+// of its controller is gone. Restores the quorum math after controllers have
+// been lost for good. Only runs on the leading controller.
+type EtcdMemberEvictor struct {
+	K0sVars           *config.CfgVars
+	EtcdConfig        *v1beta1.EtcdConfig
+	KubeClientFactory kubeutil.ClientFactoryInterface
+	LeaderElector     leaderelector.Interface
+
+	stop func()
+}
+
+var _ manager.Component = (*EtcdMemberEvictor)(nil)
+
+func (e *EtcdMemberEvictor) Init(context.Context) error {
+	return nil
+}
+
+func (e *EtcdMemberEvictor) Start(context.Context) error {
+	log := logrus.WithField("component", "etcd-member-evictor")
+
+	peerTLS, err := etcd.PeerTLSConfig(e.K0sVars.EtcdCertDir)
+	if err != nil {
+		return fmt.Errorf("failed to load etcd peer certificates: %w", err)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		leaderelection.RunLeaderTasks(ctx, e.LeaderElector.CurrentStatus, func(ctx context.Context) {
+			// Start from scratch whenever the lead is taken, as the
+			// observations of the previous leader aren't known.
+			tracker := unreachableMembers{timeout: e.EtcdConfig.MemberEviction.UnreachableTimeout.Duration}
+			wait.UntilWithContext(ctx, func(ctx context.Context) {
+				if err := e.evict(ctx, log, peerTLS, &tracker); err != nil && !errors.Is(err, context.Cause(ctx)) {
+					log.WithError(err).Error("Failed to evict failed etcd members")
+				}
+			}, etcdMemberProbeInterval)
+		})
+	}()
+
+	e.stop = func() {
+		cancel(errors.New("etcd member evictor is stopping"))
+		<-done
+	}
+
+	return nil
+}
+
+func (e *EtcdMemberEvictor) Stop() error {
+	if e.stop != nil {
+		e.stop()
+	}
+	return nil
+}
+
+func (e *EtcdMemberEvictor) evict(ctx context.Context, log logrus.FieldLogger, peerTLS *tls.Config, tracker *unreachableMembers) error {
+	etcdClient, err := etcd.NewClient(e.K0sVars.CertRootDir, e.K0sVars.EtcdCertDir, e.EtcdConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create etcd client: %w", err)
+	}
+	defer etcdClient.Close()
+
+	status, err := etcdClient.Status(ctx, peerTLS)
+	if err != nil {
+		return err
+	}
+
+	candidates := tracker.observe(time.Now(), status.Members)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	k0sClient, err := e.KubeClientFactory.GetK0sClient()
+	if err != nil {
+		return err
+	}
+
+	for _, member := range candidates {
+		log := log.WithFields(logrus.Fields{"name": member.Name, "memberID": member.ID, "peerURL": member.PeerURL})
+
+		_, err := k0sClient.AutopilotV1beta2().ControlNodes().Get(ctx, member.Name, metav1.GetOptions{})
+		if err == nil {
+			log.Debug("Not evicting unreachable etcd member, its ControlNode still exists")
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get ControlNode %s: %w", member.Name, err)
+		}
+
+		// Prefer to let the EtcdMember reconciler remove the member, so that
+		// the EtcdMember object reflects that the member has left.
+		log.Warnf("Evicting etcd member that has been unreachable for more than %s", tracker.timeout)
+		_, err = k0sClient.EtcdV1beta1().EtcdMembers().Patch(ctx, member.Name, types.MergePatchType, []byte(`{"spec":{"leave":true}}`), metav1.PatchOptions{})
+		if apierrors.IsNotFound(err) {
+			var memberID uint64
+			if memberID, err = strconv.ParseUint(member.ID, 16, 64); err == nil {
+				err = etcdClient.DeleteMember(ctx, memberID)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to evict etcd member %s: %w", member.Name, err)
+		}
+
+		tracker.forget(member.ID)
+
+		// Evict only a single member per round. The member list and the
+		// quorum will have changed.
+		return nil
+	}
+
+	return nil
+}
+
+// unreachableMembers keeps track of the time since when etcd members have been
+// unreachable.
+type unreachableMembers struct {
+	timeout time.Duration
+	since   map[string]time.Time
+}
+
+// observe records the reachability of the given members, and returns the ones
+// that have been unreachable for longer than the timeout. The local member and
+// members that haven't been started yet are never returned.
+func (u *unreachableMembers) observe(now time.Time, members []etcd.MemberStatus) (candidates []etcd.MemberStatus) {
+	since := make(map[string]time.Time, len(members))
+	for _, member := range members {
+		if member.Local || member.Healthy || member.Name == "" {
+			continue
+		}
+
+		unreachableSince, ok := u.since[member.ID]
+		if !ok {
+			unreachableSince = now
+		}
+		since[member.ID] = unreachableSince
+
+		if now.Sub(unreachableSince) > u.timeout {
+			candidates = append(candidates, member)
+		}
+	}
+
+	u.since = since
+	return candidates
+}
+
+func (u *unreachableMembers) forget(memberID string) {
+	delete(u.since, memberID)
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/etcd"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnreachableMembers_Observe(t *testing.T) {
+	underTest := unreachableMembers{timeout: time.Minute}
+	start := time.Now()
+
+	members := []etcd.MemberStatus{
+		{ID: "1", Name: "controller-1", Local: true, Healthy: true},
+		{ID: "2", Name: "controller-2", Healthy: true},
+		{ID: "3", Name: "controller-3"},
+		{ID: "4", Name: ""},
+	}
+
+	assert.Empty(t, underTest.observe(start, members), "members shouldn't be evicted right away")
+	assert.Empty(t, underTest.observe(start.Add(time.Minute), members), "members shouldn't be evicted before the timeout")
+
+	candidates := underTest.observe(start.Add(61*time.Second), members)
+	if assert.Len(t, candidates, 1) {
+		assert.Equal(t, "3", candidates[0].ID)
+	}
+
+	t.Run("reachable_members_are_reset", func(t *testing.T) {
+		members := []etcd.MemberStatus{
+			{ID: "1", Name: "controller-1", Local: true, Healthy: true},
+			{ID: "3", Name: "controller-3", Healthy: true},
+		}
+		assert.Empty(t, underTest.observe(start.Add(2*time.Minute), members))
+		members[1].Healthy = false
+		assert.Empty(t, underTest.observe(start.Add(3*time.Minute), members))
+	})
+
+	t.Run("forgotten_members_start_over", func(t *testing.T) {
+		underTest := unreachableMembers{timeout: time.Minute}
+		members := []etcd.MemberStatus{{ID: "3", Name: "controller-3"}}
+		assert.Empty(t, underTest.observe(start, members))
+		underTest.forget("3")
+		assert.Empty(t, underTest.observe(start.Add(2*time.Minute), members))
+	})
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/constant"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+
+	"github.com/sirupsen/logrus"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EtcdMetricsProxy serves the metrics of the etcd member running on this
+// controller via HTTPS. Requests need to present a Kubernetes bearer token
+// that's authorized to get the /metrics non-resource URL, e.g. the service
+// account token of a Prometheus instance.
+type EtcdMetricsProxy struct {
+	K0sVars           *config.CfgVars
+	Metrics           *v1beta1.EtcdMetrics
+	KubeClientFactory kubeutil.ClientFactoryInterface
+
+	stop func()
+}
+
+var _ manager.Component = (*EtcdMetricsProxy)(nil)
+
+func (p *EtcdMetricsProxy) Init(context.Context) error {
+	return nil
+}
+
+func (p *EtcdMetricsProxy) Start(context.Context) error {
+	log := logrus.WithField("component", "etcd-metrics-proxy")
+
+	upstream, err := p.upstream()
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", p.authorize(log, upstream))
+
+	srv := &http.Server{
+		Handler: mux,
+		Addr:    net.JoinHostPort("", strconv.Itoa(p.Metrics.Proxy.GetPort())),
+		TLSConfig: &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			CipherSuites: constant.AllowedTLS12CipherSuiteIDs,
+		},
+		ReadHeaderTimeout: 15 * time.Second,
+		WriteTimeout:      time.Minute,
+	}
+
+	cert := filepath.Join(p.K0sVars.CertRootDir, "k0s-api.crt")
+	key := filepath.Join(p.K0sVars.CertRootDir, "k0s-api.key")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		log.Info("Listening on ", srv.Addr)
+		if err := srv.ListenAndServeTLS(cert, key); !errors.Is(err, http.ErrServerClosed) {
+			log.WithError(err).Error("Failed to serve etcd metrics")
+		}
+	}()
+
+	p.stop = func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.WithError(err).Warn("Failed to shut down etcd metrics proxy")
+		}
+		<-done
+	}
+
+	return nil
+}
+
+func (p *EtcdMetricsProxy) Stop() error {
+	if p.stop != nil {
+		p.stop()
+	}
+	return nil
+}
+
+// upstream returns a reverse proxy for etcd's metrics endpoint. Prefers the
+// plain HTTP metrics listener, if configured. Falls back to etcd's client
+// endpoint, which requires a client certificate.
+func (p *EtcdMetricsProxy) upstream() (*httputil.ReverseProxy, error) {
+	if p.Metrics.ListenAddress != "" {
+		return httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: p.Metrics.ListenAddress}), nil
+	}
+
+	tlsInfo := transport.TLSInfo{
+		CertFile:      filepath.Join(p.K0sVars.CertRootDir, "apiserver-etcd-client.crt"),
+		KeyFile:       filepath.Join(p.K0sVars.CertRootDir, "apiserver-etcd-client.key"),
+		TrustedCAFile: filepath.Join(p.K0sVars.EtcdCertDir, "ca.crt"),
+	}
+	tlsConfig, err := tlsInfo.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load etcd client certificates: %w", err)
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "https", Host: "127.0.0.1:2379"})
+	httpTransport := http.DefaultTransport.(*http.Transport).Clone()
+	httpTransport.TLSClientConfig = tlsConfig
+	proxy.Transport = httpTransport
+	return proxy, nil
+}
+
+// authorize only passes requests to the next handler whose bearer token is
+// authorized to get the /metrics non-resource URL.
+func (p *EtcdMetricsProxy) authorize(log logrus.FieldLogger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		allowed, err := p.isAllowed(r.Context(), token, r.URL.Path)
+		if err != nil {
+			log.WithError(err).Error("Failed to authorize request")
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if !allowed {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		// Don't pass the token on to etcd.
+		r.Header.Del("Authorization")
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (p *EtcdMetricsProxy) isAllowed(ctx context.Context, token, path string) (bool, error) {
+	client, err := p.KubeClientFactory.GetClient()
+	if err != nil {
+		return false, err
+	}
+
+	review, err := client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return false, nil
+	}
+
+	user := review.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	sar, err := client.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+				Path: path,
+				Verb: "get",
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to review access: %w", err)
+	}
+
+	return sar.Status.Allowed, nil
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestEtcdMetricsProxy_Authorize(t *testing.T) {
+	clients := testutil.NewFakeClientFactory()
+	fakeClient, ok := clients.Client.(*kubernetesfake.Clientset)
+	require.True(t, ok)
+
+	fakeClient.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		switch review.Spec.Token {
+		case "prometheus":
+			review.Status.Authenticated = true
+			review.Status.User.Username = "system:serviceaccount:monitoring:prometheus"
+		case "nobody":
+			review.Status.Authenticated = true
+			review.Status.User.Username = "system:serviceaccount:default:nobody"
+		}
+		return true, review, nil
+	})
+	fakeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attrs := review.Spec.NonResourceAttributes
+		review.Status.Allowed = review.Spec.User == "system:serviceaccount:monitoring:prometheus" &&
+			attrs != nil && attrs.Path == "/metrics" && attrs.Verb == "get"
+		return true, review, nil
+	})
+
+	underTest := &EtcdMetricsProxy{KubeClientFactory: clients}
+	handler := underTest.authorize(logrus.New(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"), "token shouldn't be passed on")
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, test := range []struct {
+		name          string
+		authorization string
+		expected      int
+	}{
+		{"no_token", "", http.StatusUnauthorized},
+		{"invalid_token", "Bearer invalid", http.StatusForbidden},
+		{"unauthorized_token", "Bearer nobody", http.StatusForbidden},
+		{"authorized_token", "Bearer prometheus", http.StatusOK},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expected, rec.Code)
+		})
+	}
+}
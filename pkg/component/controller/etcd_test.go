@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestTuningArgs(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		assert.Empty(t, tuningArgs(&v1beta1.EtcdConfig{}))
+	})
+
+	t.Run("all", func(t *testing.T) {
+		quota := resource.MustParse("8Gi")
+		config := &v1beta1.EtcdConfig{
+			AutoCompaction:    &v1beta1.EtcdAutoCompaction{Retention: "1h"},
+			QuotaBackendBytes: &quota,
+			HeartbeatInterval: &metav1.Duration{Duration: 250 * time.Millisecond},
+			ElectionTimeout:   &metav1.Duration{Duration: 2500 * time.Millisecond},
+			SnapshotCount:     ptr.To[uint64](50000),
+		}
+
+		assert.Equal(t, map[string]string{
+			"--auto-compaction-mode":      "periodic",
+			"--auto-compaction-retention": "1h",
+			"--quota-backend-bytes":       "8589934592",
+			"--heartbeat-interval":        "250",
+			"--election-timeout":          "2500",
+			"--snapshot-count":            "50000",
+		}, map[string]string(tuningArgs(config)))
+	})
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/controller/leaderelector"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/leaderelection"
+	"github.com/k0sproject/k0s/pkg/token"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	tokenutil "k8s.io/cluster-bootstrap/token/util"
+	bootstraptokenv1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/bootstraptoken/v1"
+)
+
+// JoinTokenRotator maintains a pool of short-lived worker join tokens. As soon
+// as the current token has reached half of its validity, a new one is created
+// and published in the [token.RotatedWorkerTokenSecretName] secret. Previous
+// tokens stay valid until they expire, so that they can still be used by
+// provisioning tooling that has already picked them up. Only runs on the
+// leading controller.
+type JoinTokenRotator struct {
+	KubeClientFactory kubeutil.ClientFactoryInterface
+	LeaderElector     leaderelector.Interface
+	APISpec           *v1beta1.APISpec
+	CertRootDir       string
+	Validity          time.Duration
+
+	stop func()
+}
+
+var _ manager.Component = (*JoinTokenRotator)(nil)
+
+func (r *JoinTokenRotator) Init(context.Context) error {
+	return nil
+}
+
+func (r *JoinTokenRotator) Start(context.Context) error {
+	log := logrus.WithField("component", "join-token-rotator")
+
+	client, err := r.KubeClientFactory.GetClient()
+	if err != nil {
+		return err
+	}
+
+	rotator := joinTokenRotation{
+		client:   client,
+		validity: r.Validity,
+		now:      time.Now,
+		generateToken: func(bootstrapToken *bootstraptokenv1.BootstrapTokenString) (string, error) {
+			caCert, err := os.ReadFile(filepath.Join(r.CertRootDir, "ca.crt"))
+			if err != nil {
+				return "", err
+			}
+			kubeconfig, err := token.GenerateKubeconfig(r.APISpec.APIAddressURL(), caCert, token.WorkerTokenAuthName, bootstrapToken)
+			if err != nil {
+				return "", err
+			}
+			return token.JoinEncode(bytes.NewReader(kubeconfig))
+		},
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		leaderelection.RunLeaderTasks(ctx, r.LeaderElector.CurrentStatus, func(ctx context.Context) {
+			wait.UntilWithContext(ctx, func(ctx context.Context) {
+				if err := rotator.rotate(ctx, log); err != nil && !errors.Is(err, context.Cause(ctx)) {
+					log.WithError(err).Error("Failed to rotate worker join token")
+				}
+			}, min(max(r.Validity/10, 10*time.Second), time.Minute))
+		})
+	}()
+
+	r.stop = func() {
+		cancel(errors.New("join token rotator is stopping"))
+		<-done
+	}
+
+	return nil
+}
+
+func (r *JoinTokenRotator) Stop() error {
+	if r.stop != nil {
+		r.stop()
+	}
+	return nil
+}
+
+type joinTokenRotation struct {
+	client        kubernetes.Interface
+	validity      time.Duration
+	now           func() time.Time
+	generateToken func(*bootstraptokenv1.BootstrapTokenString) (string, error)
+}
+
+// rotate creates and publishes a new worker join token if the published one
+// is about to expire, or has been removed.
+func (r *joinTokenRotation) rotate(ctx context.Context, log logrus.FieldLogger) error {
+	secrets := r.client.CoreV1().Secrets(metav1.NamespaceSystem)
+
+	published, err := secrets.Get(ctx, token.RotatedWorkerTokenSecretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		published = nil
+	} else if err != nil {
+		return err
+	} else if r.isFresh(ctx, published) {
+		return nil
+	}
+
+	tokens, err := token.NewManagerForClient(r.client)
+	if err != nil {
+		return err
+	}
+	bootstrapToken, err := tokens.CreateFor(ctx, r.validity, token.RoleWorker, false, "join-token-rotator")
+	if err != nil {
+		return fmt.Errorf("failed to create worker join token: %w", err)
+	}
+	joinToken, err := r.generateToken(bootstrapToken)
+	if err != nil {
+		return fmt.Errorf("failed to generate worker join token: %w", err)
+	}
+
+	data := map[string][]byte{
+		"token":      []byte(joinToken),
+		"token-id":   []byte(bootstrapToken.ID),
+		"expiration": []byte(r.now().Add(r.validity).UTC().Format(time.RFC3339)),
+	}
+	if published == nil {
+		_, err = secrets.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: token.RotatedWorkerTokenSecretName},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       data,
+		}, metav1.CreateOptions{})
+	} else {
+		published.Data = data
+		_, err = secrets.Update(ctx, published, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to publish worker join token: %w", err)
+	}
+
+	log.Infof("Rotated worker join token, the current one has the ID %s", bootstrapToken.ID)
+	return nil
+}
+
+// isFresh checks if the given published token has more than half of its
+// validity left, and if it hasn't been removed in the meantime. Tokens that
+// are valid for longer than the validity, e.g. because it has been shortened,
+// aren't fresh either.
+func (r *joinTokenRotation) isFresh(ctx context.Context, published *corev1.Secret) bool {
+	expiration, err := time.Parse(time.RFC3339, string(published.Data["expiration"]))
+	if err != nil {
+		return false
+	}
+	if left := expiration.Sub(r.now()); left <= r.validity/2 || left > r.validity {
+		return false
+	}
+
+	secretName := tokenutil.BootstrapTokenSecretName(string(published.Data["token-id"]))
+	_, err = r.client.CoreV1().Secrets(metav1.NamespaceSystem).Get(ctx, secretName, metav1.GetOptions{})
+	return err == nil
+}
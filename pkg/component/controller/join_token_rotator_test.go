@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+	"github.com/k0sproject/k0s/pkg/token"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	tokenutil "k8s.io/cluster-bootstrap/token/util"
+	bootstraptokenv1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/bootstraptoken/v1"
+)
+
+func TestJoinTokenRotation(t *testing.T) {
+	clients := testutil.NewFakeClientFactory()
+	client, err := clients.GetClient()
+	require.NoError(t, err)
+	secrets := client.CoreV1().Secrets(metav1.NamespaceSystem)
+
+	now := time.Now()
+	rotation := joinTokenRotation{
+		client:   client,
+		validity: time.Hour,
+		now:      func() time.Time { return now },
+		generateToken: func(bootstrapToken *bootstraptokenv1.BootstrapTokenString) (string, error) {
+			return "join-token-" + bootstrapToken.ID, nil
+		},
+	}
+	log, _ := logtest.NewNullLogger()
+
+	rotate := func(t *testing.T) (tokenID string) {
+		require.NoError(t, rotation.rotate(t.Context(), log))
+		published, err := secrets.Get(t.Context(), token.RotatedWorkerTokenSecretName, metav1.GetOptions{})
+		require.NoError(t, err)
+		tokenID = string(published.Data["token-id"])
+		assert.Equal(t, "join-token-"+tokenID, string(published.Data["token"]))
+		return tokenID
+	}
+	bootstrapTokenIDs := func(t *testing.T) (ids []string) {
+		list, err := secrets.List(t.Context(), metav1.ListOptions{})
+		require.NoError(t, err)
+		for _, secret := range list.Items {
+			if secret.Type == corev1.SecretTypeBootstrapToken {
+				ids = append(ids, string(secret.Data["token-id"]))
+			}
+		}
+		return ids
+	}
+
+	first := rotate(t)
+	assert.Equal(t, []string{first}, bootstrapTokenIDs(t))
+
+	t.Run("keeps_fresh_token", func(t *testing.T) {
+		now = now.Add(29 * time.Minute)
+		assert.Equal(t, first, rotate(t))
+		assert.Len(t, bootstrapTokenIDs(t), 1)
+	})
+
+	var second string
+	t.Run("rotates_at_half_validity", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		second = rotate(t)
+		assert.NotEqual(t, first, second)
+		assert.ElementsMatch(t, []string{first, second}, bootstrapTokenIDs(t), "the previous token stays valid")
+	})
+
+	t.Run("rotates_removed_token", func(t *testing.T) {
+		require.NoError(t, secrets.Delete(t.Context(), tokenutil.BootstrapTokenSecretName(second), metav1.DeleteOptions{}))
+		third := rotate(t)
+		assert.NotEqual(t, second, third)
+	})
+
+	t.Run("rotates_on_shortened_validity", func(t *testing.T) {
+		current := rotate(t)
+		rotation.validity = 10 * time.Minute
+		assert.NotEqual(t, current, rotate(t))
+	})
+}
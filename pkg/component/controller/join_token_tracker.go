@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/component/controller/leaderelector"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/leaderelection"
+	"github.com/k0sproject/k0s/pkg/token"
+
+	"github.com/sirupsen/logrus"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
+)
+
+// JoinTokenTracker keeps track of the worker join tokens. It records the usage
+// of join tokens, based on the kubelet client certificates that have been
+// requested with them. One-time join tokens are invalidated as soon as a node
+// has joined the cluster with them. A node has joined once its kubelet client
+// certificate, requested with the token, has been issued, and the kubelet has
+// renewed its node lease afterwards. Worker tokens that have been created by
+// earlier k0s versions get the worker token group added once, so that they're
+// still authorized to join. Only runs on the leading controller.
+type JoinTokenTracker struct {
+	KubeClientFactory kubeutil.ClientFactoryInterface
+	LeaderElector     leaderelector.Interface
+
+	stop func()
+}
+
+var _ manager.Component = (*JoinTokenTracker)(nil)
+
+func (t *JoinTokenTracker) Init(context.Context) error {
+	return nil
+}
+
+func (t *JoinTokenTracker) Start(context.Context) error {
+	log := logrus.WithField("component", "join-token-tracker")
+
+	client, err := t.KubeClientFactory.GetClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		leaderelection.RunLeaderTasks(ctx, t.LeaderElector.CurrentStatus, func(ctx context.Context) {
+			var migrated bool
+			wait.UntilWithContext(ctx, func(ctx context.Context) {
+				if !migrated {
+					if err := migrateWorkerTokens(ctx, log, client); err != nil && !errors.Is(err, context.Cause(ctx)) {
+						log.WithError(err).Error("Failed to migrate worker join tokens")
+					} else if err == nil {
+						migrated = true
+					}
+				}
+				if err := trackJoinTokens(ctx, log, client); err != nil && !errors.Is(err, context.Cause(ctx)) {
+					log.WithError(err).Error("Failed to track join tokens")
+				}
+			}, 10*time.Second)
+		})
+	}()
+
+	t.stop = func() {
+		cancel(errors.New("join token tracker is stopping"))
+		<-done
+	}
+
+	return nil
+}
+
+func (t *JoinTokenTracker) Stop() error {
+	if t.stop != nil {
+		t.stop()
+	}
+	return nil
+}
+
+func trackJoinTokens(ctx context.Context, log logrus.FieldLogger, client kubernetes.Interface) error {
+	secrets, err := client.CoreV1().Secrets(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(corev1.SecretTypeBootstrapToken)).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list join tokens: %w", err)
+	}
+	if len(secrets.Items) == 0 {
+		return nil
+	}
+
+	csrs, err := client.CertificatesV1().CertificateSigningRequests().List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.signerName", certificatesv1.KubeAPIServerClientKubeletSignerName).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list kubelet client CSRs: %w", err)
+	}
+
+	var errs []error
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		tokenID := string(secret.Data[bootstrapapi.BootstrapTokenIDKey])
+		username := bootstrapapi.BootstrapUserPrefix + tokenID
+
+		if token.IsOneTime(secret) {
+			if invalidated, err := invalidateUsedOneTimeToken(ctx, log, client, csrs.Items, secret, username); err != nil {
+				errs = append(errs, fmt.Errorf("token %s: %w", tokenID, err))
+				continue
+			} else if invalidated {
+				continue
+			}
+		}
+
+		if err := recordTokenUsage(ctx, log, client, csrs.Items, secret, username); err != nil {
+			errs = append(errs, fmt.Errorf("token %s: %w", tokenID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Adds the worker token group to the worker tokens that have been created by
+// earlier k0s versions.
+func migrateWorkerTokens(ctx context.Context, log logrus.FieldLogger, client kubernetes.Interface) error {
+	secrets := client.CoreV1().Secrets(metav1.NamespaceSystem)
+	list, err := secrets.List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(corev1.SecretTypeBootstrapToken)).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list join tokens: %w", err)
+	}
+
+	var errs []error
+	for i := range list.Items {
+		secret := &list.Items[i]
+		tokenID := string(secret.Data[bootstrapapi.BootstrapTokenIDKey])
+		if migrated, err := token.MigrateWorkerToken(ctx, secrets, secret); err != nil {
+			errs = append(errs, fmt.Errorf("token %s: %w", tokenID, err))
+		} else if migrated {
+			log.Infof("Added the %s group to worker join token %s", token.WorkerTokenGroup, tokenID)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Records the most recent kubelet client CSR that has been requested by the
+// given bootstrap user as the last usage of the token, unless a more recent
+// usage has already been recorded.
+func recordTokenUsage(ctx context.Context, log logrus.FieldLogger, client kubernetes.Interface, csrs []certificatesv1.CertificateSigningRequest, secret *corev1.Secret, username string) error {
+	var lastUsed time.Time
+	if recorded, err := time.Parse(time.RFC3339, secret.Annotations[token.LastUsedAnnotation]); err == nil {
+		lastUsed = recorded
+	}
+
+	var usedBy string
+	for i := range csrs {
+		csr := &csrs[i]
+		if csr.Spec.Username != username || !csr.CreationTimestamp.After(lastUsed) {
+			continue
+		}
+		x509cr, err := parseCSR(csr)
+		if err != nil {
+			continue
+		}
+		if nodeName, ok := strings.CutPrefix(x509cr.Subject.CommonName, "system:node:"); ok {
+			lastUsed, usedBy = csr.CreationTimestamp.Time, nodeName
+		}
+	}
+	if usedBy == "" {
+		return nil
+	}
+
+	err := token.RecordUsage(ctx, client.CoreV1().Secrets(metav1.NamespaceSystem), secret.Name, usedBy, lastUsed)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	log.Infof("Join token %s used by node %s", secret.Data[bootstrapapi.BootstrapTokenIDKey], usedBy)
+	return nil
+}
+
+// Invalidates the given one-time token if a node has joined with it. Returns
+// whether the token has been invalidated.
+func invalidateUsedOneTimeToken(ctx context.Context, log logrus.FieldLogger, client kubernetes.Interface, csrs []certificatesv1.CertificateSigningRequest, secret *corev1.Secret, username string) (bool, error) {
+	nodeName, err := joinedNodeName(ctx, client, csrs, username)
+	if err != nil || nodeName == "" {
+		return false, err
+	}
+
+	err = client.CoreV1().Secrets(metav1.NamespaceSystem).Delete(ctx, secret.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &secret.UID},
+	})
+	switch {
+	case err == nil:
+		log.Infof("Invalidated one-time join token %s, node %s joined with it", secret.Data[bootstrapapi.BootstrapTokenIDKey], nodeName)
+		return true, nil
+	case apierrors.IsNotFound(err), apierrors.IsConflict(err):
+		// The token has been deleted or replaced in the meantime.
+		return true, nil
+	default:
+		return false, err
+	}
+}
+
+// joinedNodeName returns the name of a node that has joined the cluster with
+// the given bootstrap user, or an empty string if there's none.
+func joinedNodeName(ctx context.Context, client kubernetes.Interface, csrs []certificatesv1.CertificateSigningRequest, username string) (string, error) {
+	for i := range csrs {
+		csr := &csrs[i]
+		if csr.Spec.Username != username || len(csr.Status.Certificate) == 0 {
+			continue
+		}
+
+		x509cr, err := parseCSR(csr)
+		if err != nil {
+			continue
+		}
+		nodeName, ok := strings.CutPrefix(x509cr.Subject.CommonName, "system:node:")
+		if !ok {
+			continue
+		}
+
+		// The kubelet renews its lease only after it obtained its certificate.
+		lease, err := client.CoordinationV1().Leases(corev1.NamespaceNodeLease).Get(ctx, nodeName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return "", err
+		}
+		if renewTime := lease.Spec.RenewTime; renewTime != nil && renewTime.After(csr.CreationTimestamp.Time) {
+			return nodeName, nil
+		}
+	}
+
+	return "", nil
+}
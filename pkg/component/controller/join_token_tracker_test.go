@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+	"github.com/k0sproject/k0s/pkg/token"
+
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestTrackJoinTokens(t *testing.T) {
+	now := time.Now()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	bootstrapSecret := func(tokenID string, oneTime bool) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "bootstrap-token-" + tokenID},
+			Type:       corev1.SecretTypeBootstrapToken,
+			Data:       map[string][]byte{"token-id": []byte(tokenID)},
+		}
+		if oneTime {
+			secret.Labels = map[string]string{token.OneTimeLabel: "true"}
+		}
+		return secret
+	}
+	clientCSR := func(name, tokenID, nodeName string, issued bool) *certificatesv1.CertificateSigningRequest {
+		csr := &certificatesv1.CertificateSigningRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(now.Add(-time.Minute))},
+			Spec: certificatesv1.CertificateSigningRequestSpec{
+				Request: pemWithTemplate(&x509.CertificateRequest{Subject: pkix.Name{
+					CommonName:   "system:node:" + nodeName,
+					Organization: []string{"system:nodes"},
+				}}, privateKey),
+				SignerName: certificatesv1.KubeAPIServerClientKubeletSignerName,
+				Username:   "system:bootstrap:" + tokenID,
+			},
+		}
+		if issued {
+			csr.Status.Certificate = []byte("issued")
+		}
+		return csr
+	}
+	nodeLease := func(nodeName string, renewTime time.Time) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceNodeLease, Name: nodeName},
+			Spec:       coordinationv1.LeaseSpec{RenewTime: &metav1.MicroTime{Time: renewTime}},
+		}
+	}
+
+	clients := testutil.NewFakeClientFactory([]runtime.Object{
+		// A one-time token that has been used to join.
+		bootstrapSecret("joined", true), clientCSR("csr-joined", "joined", "worker-1", true), nodeLease("worker-1", now),
+		// A one-time token whose CSR hasn't been issued yet.
+		bootstrapSecret("pending", true), clientCSR("csr-pending", "pending", "worker-2", false), nodeLease("worker-2", now),
+		// A one-time token whose node lease hasn't been renewed since.
+		bootstrapSecret("stale", true), clientCSR("csr-stale", "stale", "worker-3", true), nodeLease("worker-3", now.Add(-time.Hour)),
+		// A regular token that has been used to join.
+		bootstrapSecret("regular", false), clientCSR("csr-regular", "regular", "worker-4", true), nodeLease("worker-4", now),
+		// A regular token that hasn't been used.
+		bootstrapSecret("unused", false),
+	}...)
+	client, err := clients.GetClient()
+	require.NoError(t, err)
+
+	log, logs := logtest.NewNullLogger()
+	require.NoError(t, trackJoinTokens(t.Context(), log, client))
+
+	secrets, err := client.CoreV1().Secrets(metav1.NamespaceSystem).List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	lastUsedBy := make(map[string]string)
+	for _, secret := range secrets.Items {
+		lastUsedBy[secret.Name] = secret.Annotations[token.LastUsedByAnnotation]
+		if lastUsedBy[secret.Name] != "" {
+			assert.Equal(t, now.Add(-time.Minute).UTC().Format(time.RFC3339), secret.Annotations[token.LastUsedAnnotation])
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"bootstrap-token-pending": "worker-2",
+		"bootstrap-token-stale":   "worker-3",
+		"bootstrap-token-regular": "worker-4",
+		"bootstrap-token-unused":  "",
+	}, lastUsedBy)
+
+	var messages []string
+	for _, entry := range logs.AllEntries() {
+		assert.Equal(t, logrus.InfoLevel, entry.Level)
+		messages = append(messages, entry.Message)
+	}
+	assert.ElementsMatch(t, []string{
+		"Invalidated one-time join token joined, node worker-1 joined with it",
+		"Join token pending used by node worker-2",
+		"Join token stale used by node worker-3",
+		"Join token regular used by node worker-4",
+	}, messages)
+
+	// Already recorded usages aren't recorded again.
+	logs.Reset()
+	require.NoError(t, trackJoinTokens(t.Context(), log, client))
+	assert.Empty(t, logs.AllEntries())
+}
+
+func TestMigrateWorkerTokens(t *testing.T) {
+	workerSecret := func(tokenID string, mutate func(*corev1.Secret)) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "bootstrap-token-" + tokenID},
+			Type:       corev1.SecretTypeBootstrapToken,
+			Data: map[string][]byte{
+				"token-id":                       []byte(tokenID),
+				"usage-bootstrap-authentication": []byte("true"),
+			},
+		}
+		mutate(secret)
+		return secret
+	}
+
+	clients := testutil.NewFakeClientFactory(
+		// Created by a k0s version that didn't annotate its tokens.
+		workerSecret("legacy", func(s *corev1.Secret) {
+			s.Data["description"] = []byte("Worker bootstrap token generated by k0s")
+		}),
+		// Created by a k0s version that annotated, but didn't group its tokens.
+		workerSecret("annotated", func(s *corev1.Secret) {
+			s.Annotations = map[string]string{token.CreatedByAnnotation: "root@controller-0"}
+		}),
+		// Not created by k0s.
+		workerSecret("custom", func(s *corev1.Secret) {
+			s.Data["auth-extra-groups"] = []byte("system:bootstrappers:custom")
+		}),
+		// Migrated before, and the group has been removed deliberately since.
+		workerSecret("migrated", func(s *corev1.Secret) {
+			s.Data["description"] = []byte("Worker bootstrap token generated by k0s")
+			s.Annotations = map[string]string{token.WorkerGroupMigratedAnnotation: "true"}
+		}),
+	)
+	client, err := clients.GetClient()
+	require.NoError(t, err)
+
+	log, logs := logtest.NewNullLogger()
+	require.NoError(t, migrateWorkerTokens(t.Context(), log, client))
+
+	secrets, err := client.CoreV1().Secrets(metav1.NamespaceSystem).List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	groups := make(map[string]string)
+	for _, secret := range secrets.Items {
+		groups[secret.Name] = string(secret.Data["auth-extra-groups"])
+	}
+	assert.Equal(t, map[string]string{
+		"bootstrap-token-legacy":    "system:bootstrappers:k0s-worker",
+		"bootstrap-token-annotated": "system:bootstrappers:k0s-worker",
+		"bootstrap-token-custom":    "system:bootstrappers:custom",
+		"bootstrap-token-migrated":  "",
+	}, groups)
+
+	var messages []string
+	for _, entry := range logs.AllEntries() {
+		messages = append(messages, entry.Message)
+	}
+	assert.ElementsMatch(t, []string{
+		"Added the system:bootstrappers:k0s-worker group to worker join token legacy",
+		"Added the system:bootstrappers:k0s-worker group to worker join token annotated",
+	}, messages)
+
+	// Tokens are migrated only once.
+	logs.Reset()
+	require.NoError(t, migrateWorkerTokens(t.Context(), log, client))
+	assert.Empty(t, logs.AllEntries())
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -37,15 +38,17 @@ type Konnectivity struct {
 	supervisor *supervisor.Supervisor
 	uid        int
 
-	stopFunc      context.CancelFunc
-	clusterConfig *v1beta1.ClusterConfig
-	log           *logrus.Entry
+	stopFunc     context.CancelFunc
+	konnectivity atomic.Pointer[v1beta1.KonnectivitySpec]
+	reconfigure  chan struct{}
+	log          *logrus.Entry
 
 	*prober.EventEmitter
 }
 
 var _ manager.Component = (*Konnectivity)(nil)
 var _ manager.Ready = (*Konnectivity)(nil)
+var _ manager.Reconciler = (*Konnectivity)(nil)
 var _ prober.Healthz = (*Konnectivity)(nil)
 
 // Init ...
@@ -78,7 +81,8 @@ func (k *Konnectivity) Init(ctx context.Context) error {
 	}
 	defer k.Emit("successfully initialized konnectivity component")
 
-	k.clusterConfig = k0scontext.GetNodeConfig(ctx)
+	k.konnectivity.Store(k0scontext.GetNodeConfig(ctx).Spec.Konnectivity)
+	k.reconfigure = make(chan struct{}, 1)
 
 	return nil
 }
@@ -104,6 +108,9 @@ func (k *Konnectivity) Start(ctx context.Context) error {
 					continue
 				}
 
+			case <-k.reconfigure:
+				k.log.Info("Restarting konnectivity server due to configuration change")
+
 			case <-retry:
 				k.Emit("retrying to start konnectivity server")
 				k.log.Info("Retrying to start konnectivity server")
@@ -129,6 +136,7 @@ func (k *Konnectivity) Start(ctx context.Context) error {
 }
 
 func (k *Konnectivity) serverArgs(count uint) []string {
+	spec := k.konnectivity.Load()
 	return stringmap.StringMap{
 		"--uds-name":                 filepath.Join(k.K0sVars.KonnectivitySocketDir, "konnectivity-server.sock"),
 		"--cluster-cert":             filepath.Join(k.K0sVars.CertRootDir, "server.crt"),
@@ -136,8 +144,8 @@ func (k *Konnectivity) serverArgs(count uint) []string {
 		"--kubeconfig":               k.K0sVars.KonnectivityKubeConfigPath,
 		"--mode":                     "grpc",
 		"--server-port":              "0",
-		"--agent-port":               strconv.FormatInt(int64(k.clusterConfig.Spec.Konnectivity.AgentPort), 10),
-		"--admin-port":               strconv.FormatInt(int64(k.clusterConfig.Spec.Konnectivity.AdminPort), 10),
+		"--agent-port":               strconv.FormatInt(int64(spec.AgentPort), 10),
+		"--admin-port":               strconv.FormatInt(int64(spec.AdminPort), 10),
 		"--health-bind-address":      "localhost",
 		"--health-port":              "8092",
 		"--agent-namespace":          "kube-system",
@@ -170,6 +178,9 @@ func (k *Konnectivity) runServer(count uint) error {
 		RunDir:  k.K0sVars.RunDir,
 		Args:    k.serverArgs(count),
 		UID:     k.uid,
+		// Restart the server if it's wedged. Readyz fails as long as there are
+		// no agents connected, so check healthz.
+		HealthProbe: &supervisor.HealthProbe{HTTPGet: "http://localhost:8092/healthz"},
 	}
 	err := k.supervisor.Supervise()
 	if err != nil {
@@ -181,6 +192,22 @@ func (k *Konnectivity) runServer(count uint) error {
 	return nil
 }
 
+// Reconcile implements [manager.Reconciler]. Restarts the konnectivity server
+// if its ports have been changed.
+func (k *Konnectivity) Reconcile(_ context.Context, cfg *v1beta1.ClusterConfig) error {
+	spec := cfg.Spec.Konnectivity
+	if current := k.konnectivity.Load(); spec == nil || (current != nil && *spec == *current) {
+		return nil
+	}
+
+	k.konnectivity.Store(spec.DeepCopy())
+	select {
+	case k.reconfigure <- struct{}{}:
+	default: // a restart is already pending
+	}
+	return nil
+}
+
 // Ready implements manager.Ready.
 func (k *Konnectivity) Ready() error {
 	ctx, cancel := context.WithTimeout(context.TODO(), 3*time.Second)
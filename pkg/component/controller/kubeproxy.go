@@ -239,7 +239,7 @@ roleRef:
   name: kube-proxy
 subjects:
 - kind: Group
-  name: system:bootstrappers
+  name: system:bootstrappers:k0s-worker
 ---
 kind: ConfigMap
 apiVersion: v1
@@ -5,6 +5,7 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"path"
 	"strings"
@@ -21,9 +22,12 @@ import (
 	"github.com/k0sproject/k0s/pkg/config"
 	"github.com/k0sproject/k0s/pkg/constant"
 	k8sutil "github.com/k0sproject/k0s/pkg/kubernetes"
+	k0snode "github.com/k0sproject/k0s/pkg/node"
 )
 
-// NodeRole implements the component interface to manage node role labels for worker nodes
+// NodeRole implements the component interface to manage node role labels for
+// worker nodes. It also reconciles the labels and taints that workers declare
+// for their nodes.
 type NodeRole struct {
 	log logrus.FieldLogger
 
@@ -71,6 +75,9 @@ func (n *NodeRole) Start(ctx context.Context) error {
 					if err != nil {
 						n.log.Error(err)
 					}
+					if err := n.reconcileDeclaredMetadata(ctx, client, &node); err != nil {
+						n.log.Error(err)
+					}
 				}
 			}
 		}
@@ -108,6 +115,29 @@ func (n *NodeRole) addNodeLabel(ctx context.Context, client kubernetes.Interface
 	return client.CoreV1().Nodes().Patch(ctx, node, types.JSONPatchType, []byte(patch), metav1.PatchOptions{})
 }
 
+// reconcileDeclaredMetadata applies the labels and taints that the worker has
+// declared for its node, if any.
+func (n *NodeRole) reconcileDeclaredMetadata(ctx context.Context, client kubernetes.Interface, node *corev1.Node) error {
+	annotation, found := node.Annotations[k0snode.DeclaredMetadataAnnotation]
+	if !found {
+		return nil
+	}
+
+	var declared k0snode.DeclaredMetadata
+	if err := json.Unmarshal([]byte(annotation), &declared); err != nil {
+		return fmt.Errorf("invalid declared labels and taints of node %s: %w", node.Name, err)
+	}
+	if !declared.Reconcile(node) {
+		return nil
+	}
+
+	if _, err := client.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to reconcile labels and taints of node %s: %w", node.Name, err)
+	}
+	n.log.Infof("Reconciled labels and taints of node %s", node.Name)
+	return nil
+}
+
 // Stop no-op
 func (n *NodeRole) Stop() error {
 	return nil
@@ -4,12 +4,17 @@
 package controller
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"reflect"
 
 	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
 
+	"github.com/k0sproject/k0s/internal/pkg/file"
 	"github.com/k0sproject/k0s/internal/pkg/stringmap"
 	"github.com/k0sproject/k0s/internal/pkg/users"
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
@@ -29,6 +34,8 @@ type Scheduler struct {
 	supervisor            *supervisor.Supervisor
 	uid                   int
 	previousConfig        stringmap.StringMap
+	previousSchedulerConf []byte
+	previousResources     *v1beta1.ProcessResources
 }
 
 var _ manager.Component = (*Scheduler)(nil)
@@ -87,7 +94,26 @@ func (a *Scheduler) Reconcile(_ context.Context, clusterConfig *v1beta1.ClusterC
 	}
 	args = clusterConfig.Spec.FeatureGates.BuildArgs(args, kubeSchedulerComponentName)
 
-	if args.Equals(a.previousConfig) && a.supervisor != nil {
+	var schedulerConf []byte
+	if config := clusterConfig.Spec.Scheduler.Config; config != nil {
+		var err error
+		if schedulerConf, err = renderSchedulerConfig(config.Raw, schedulerAuthConf); err != nil {
+			return fmt.Errorf("failed to render %s configuration: %w", kubeSchedulerComponentName, err)
+		}
+		configPath := filepath.Join(a.K0sVars.RunDir, "kube-scheduler-config.yaml")
+		if err := file.WriteContentAtomically(configPath, schedulerConf, 0644); err != nil {
+			return fmt.Errorf("failed to write %s configuration: %w", kubeSchedulerComponentName, err)
+		}
+		// The kubeconfig and profiling flags are ignored when a configuration
+		// file is given. They're part of the configuration file instead.
+		delete(args, "kubeconfig")
+		delete(args, "profiling")
+		args["config"] = configPath
+	}
+
+	resources := clusterConfig.Spec.Scheduler.Resources
+	if args.Equals(a.previousConfig) && bytes.Equal(schedulerConf, a.previousSchedulerConf) &&
+		reflect.DeepEqual(resources, a.previousResources) && a.supervisor != nil {
 		// no changes and supervisor already running, do nothing
 		logrus.WithField("component", kubeSchedulerComponentName).Info("reconcile has nothing to do")
 		return nil
@@ -100,14 +126,62 @@ func (a *Scheduler) Reconcile(_ context.Context, clusterConfig *v1beta1.ClusterC
 	}
 
 	a.supervisor = &supervisor.Supervisor{
-		Name:    kubeSchedulerComponentName,
-		BinPath: assets.BinPath(kubeSchedulerComponentName, a.K0sVars.BinDir),
-		RunDir:  a.K0sVars.RunDir,
-		DataDir: a.K0sVars.DataDir,
-		Args:    args.ToDashedArgs(),
-		UID:     a.uid,
-		GID:     a.gid,
+		Name:        kubeSchedulerComponentName,
+		BinPath:     assets.BinPath(kubeSchedulerComponentName, a.K0sVars.BinDir),
+		RunDir:      a.K0sVars.RunDir,
+		DataDir:     a.K0sVars.DataDir,
+		Args:        args.ToDashedArgs(),
+		UID:         a.uid,
+		GID:         a.gid,
+		Resources:   resources,
+		HealthProbe: healthzProbe(args, "10259"),
 	}
 	a.previousConfig = args
+	a.previousSchedulerConf = schedulerConf
+	a.previousResources = resources.DeepCopy()
 	return a.supervisor.Supervise()
 }
+
+// healthzProbe returns a health probe for the healthz endpoint of the Kubernetes
+// component with the given args. The endpoint is served on the secure port,
+// which defaults to the given one. Returns nil if there's no secure port.
+func healthzProbe(args stringmap.StringMap, defaultPort string) *supervisor.HealthProbe {
+	port := args["secure-port"]
+	if port == "" {
+		port = defaultPort
+	} else if port == "0" {
+		return nil
+	}
+	return &supervisor.HealthProbe{HTTPGet: "https://localhost:" + port + "/healthz"}
+}
+
+// renderSchedulerConfig renders the given KubeSchedulerConfiguration, filling
+// in the fields that are managed by k0s.
+func renderSchedulerConfig(raw []byte, kubeconfig string) ([]byte, error) {
+	var config map[string]any
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = make(map[string]any)
+	}
+
+	if _, ok := config["apiVersion"]; !ok {
+		config["apiVersion"] = "kubescheduler.config.k8s.io/v1"
+	}
+	if _, ok := config["kind"]; !ok {
+		config["kind"] = "KubeSchedulerConfiguration"
+	}
+	if _, ok := config["enableProfiling"]; !ok {
+		config["enableProfiling"] = false
+	}
+
+	clientConnection, _ := config["clientConnection"].(map[string]any)
+	if clientConnection == nil {
+		clientConnection = make(map[string]any)
+	}
+	clientConnection["kubeconfig"] = kubeconfig
+	config["clientConnection"] = clientConnection
+
+	return yaml.Marshal(config)
+}
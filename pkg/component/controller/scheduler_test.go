@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderSchedulerConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		rendered, err := renderSchedulerConfig([]byte(`{"profiles":[{"schedulerName":"bin-packing"}],"clientConnection":{"qps":100}}`), "/var/lib/k0s/pki/scheduler.conf")
+		require.NoError(t, err)
+		assert.Equal(t, `apiVersion: kubescheduler.config.k8s.io/v1
+clientConnection:
+  kubeconfig: /var/lib/k0s/pki/scheduler.conf
+  qps: 100
+enableProfiling: false
+kind: KubeSchedulerConfiguration
+profiles:
+- schedulerName: bin-packing
+`, string(rendered))
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		rendered, err := renderSchedulerConfig([]byte(`{"apiVersion":"kubescheduler.config.k8s.io/v1","kind":"KubeSchedulerConfiguration","enableProfiling":true}`), "scheduler.conf")
+		require.NoError(t, err)
+		assert.Equal(t, `apiVersion: kubescheduler.config.k8s.io/v1
+clientConnection:
+  kubeconfig: scheduler.conf
+enableProfiling: true
+kind: KubeSchedulerConfiguration
+`, string(rendered))
+	})
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	autopilotv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	apcomm "github.com/k0sproject/k0s/pkg/autopilot/common"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/supervisor"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UnhealthyEventRecorder reports the restarts of supervised processes that
+// failed their health probes as Events on the controller's ControlNode.
+type UnhealthyEventRecorder struct {
+	KubeClientFactory kubeutil.ClientFactoryInterface
+
+	nodeName   string
+	unregister func()
+}
+
+var _ manager.Component = (*UnhealthyEventRecorder)(nil)
+
+func (r *UnhealthyEventRecorder) Init(context.Context) error {
+	nodeName, err := apcomm.FindEffectiveHostname()
+	if err != nil {
+		return fmt.Errorf("failed to determine hostname: %w", err)
+	}
+	r.nodeName = nodeName
+	return nil
+}
+
+func (r *UnhealthyEventRecorder) Start(context.Context) error {
+	log := logrus.WithField("component", "unhealthy-event-recorder")
+	r.unregister = supervisor.OnUnhealthy(func(component string, err error) {
+		// Don't block the health probe while the Event is being recorded.
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			r.recordEvent(ctx, log, component, err)
+		}()
+	})
+	return nil
+}
+
+func (r *UnhealthyEventRecorder) Stop() error {
+	if r.unregister != nil {
+		r.unregister()
+	}
+	return nil
+}
+
+// recordEvent creates an Event for the ControlNode of this controller. Failures
+// are only logged: the API server itself may be unhealthy.
+func (r *UnhealthyEventRecorder) recordEvent(ctx context.Context, log logrus.FieldLogger, component string, probeErr error) {
+	const reason = "ComponentUnhealthy"
+
+	client, err := r.KubeClientFactory.GetClient()
+	if err != nil {
+		log.WithError(err).Warn("Failed to get Kubernetes client, not recording event ", reason)
+		return
+	}
+
+	now := metav1.Now()
+	_, err = client.CoreV1().Events(metav1.NamespaceDefault).Create(ctx, &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: r.nodeName + ".",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: autopilotv1beta2.SchemeGroupVersion.String(),
+			Kind:       "ControlNode",
+			Name:       r.nodeName,
+		},
+		Reason:              reason,
+		Message:             fmt.Sprintf("Restarted %s, it failed its health probe: %v", component, probeErr),
+		Type:                corev1.EventTypeWarning,
+		FirstTimestamp:      now,
+		LastTimestamp:       now,
+		Count:               1,
+		Source:              corev1.EventSource{Component: "k0s-controller", Host: r.nodeName},
+		ReportingController: "k0s-controller",
+		ReportingInstance:   r.nodeName,
+	}, metav1.CreateOptions{})
+	if err != nil {
+		log.WithError(err).Warn("Failed to record event ", reason)
+	}
+}
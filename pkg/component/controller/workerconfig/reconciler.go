@@ -8,6 +8,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"net"
 	"reflect"
@@ -27,8 +28,10 @@ import (
 	"github.com/k0sproject/k0s/pkg/constant"
 	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
 	"github.com/k0sproject/k0s/pkg/kubernetes/watch"
+	"github.com/k0sproject/k0s/pkg/token"
 
 	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -471,6 +474,7 @@ func (r *Reconciler) generateResources(snapshot *snapshot) (resources, error) {
 	for _, configMap := range configMaps {
 		objects = append(objects, configMap)
 	}
+	objects = append(objects, buildRuntimeClasses(snapshot.profiles)...)
 
 	// Ensure a stable order, so that reflect.DeepEqual on slices will work.
 	slices.SortFunc(objects, func(l, r resource) int {
@@ -504,9 +508,98 @@ func (r *Reconciler) buildConfigMaps(snapshot *snapshot) ([]*corev1.ConfigMap, e
 		if !ok {
 			workerProfile = r.buildProfile(snapshot)
 		}
-		if err := yaml.Unmarshal(profile.Config.Raw, &workerProfile.KubeletConfiguration); err != nil {
-			return nil, fmt.Errorf("failed to decode worker profile %q: %w", profile.Name, err)
+		if profile.Config != nil {
+			if err := yaml.Unmarshal(profile.Config.Raw, &workerProfile.KubeletConfiguration); err != nil {
+				return nil, fmt.Errorf("failed to decode worker profile %q: %w", profile.Name, err)
+			}
+		}
+		workerProfile.Sysctls = profile.Sysctls
+		workerProfile.KernelModules = profile.KernelModules
+		workerProfile.KubeletConfigDropIns = profile.KubeletConfigDropIns
+		if gracefulShutdown := profile.GracefulShutdown; gracefulShutdown != nil {
+			workerProfile.KubeletConfiguration.ShutdownGracePeriod = gracefulShutdown.GracePeriod
+			workerProfile.KubeletConfiguration.ShutdownGracePeriodCriticalPods = gracefulShutdown.CriticalPodsGracePeriod
+			workerProfile.GracefulShutdown = gracefulShutdown
 		}
+		if staticPods := profile.StaticPods; staticPods != nil && staticPods.Enabled {
+			if staticPods.CheckFrequency != nil {
+				workerProfile.KubeletConfiguration.FileCheckFrequency = *staticPods.CheckFrequency
+			}
+			workerProfile.StaticPods = staticPods
+		}
+		if swap := profile.Swap; swap != nil {
+			kubeletConfig := &workerProfile.KubeletConfiguration
+			kubeletConfig.MemorySwap.SwapBehavior = swap.Behavior
+			if swap.Behavior == v1beta1.SwapBehaviorLimitedSwap {
+				if kubeletConfig.FeatureGates == nil {
+					kubeletConfig.FeatureGates = make(map[string]bool, 1)
+				}
+				kubeletConfig.FeatureGates["NodeSwap"] = true
+			}
+			workerProfile.Swap = swap
+		}
+		if gc := profile.GarbageCollection; gc != nil {
+			kubeletConfig := &workerProfile.KubeletConfiguration
+			if gc.ImageHighThresholdPercent != nil {
+				kubeletConfig.ImageGCHighThresholdPercent = ptr.To(*gc.ImageHighThresholdPercent)
+			}
+			if gc.ImageLowThresholdPercent != nil {
+				kubeletConfig.ImageGCLowThresholdPercent = ptr.To(*gc.ImageLowThresholdPercent)
+			}
+			if gc.ImageMinimumAge != nil {
+				kubeletConfig.ImageMinimumGCAge = *gc.ImageMinimumAge
+			}
+			if gc.ContainerLogMaxSize != "" {
+				kubeletConfig.ContainerLogMaxSize = gc.ContainerLogMaxSize
+			}
+			if gc.ContainerLogMaxFiles != nil {
+				kubeletConfig.ContainerLogMaxFiles = ptr.To(*gc.ContainerLogMaxFiles)
+			}
+		}
+		if managers := profile.ResourceManagers; managers != nil {
+			kubeletConfig := &workerProfile.KubeletConfiguration
+			if managers.CPUManagerPolicy != "" {
+				kubeletConfig.CPUManagerPolicy = managers.CPUManagerPolicy
+			}
+			if managers.MemoryManagerPolicy != "" {
+				kubeletConfig.MemoryManagerPolicy = managers.MemoryManagerPolicy
+			}
+			if managers.TopologyManagerPolicy != "" {
+				kubeletConfig.TopologyManagerPolicy = managers.TopologyManagerPolicy
+			}
+			if managers.ReservedSystemCPUs != "" {
+				kubeletConfig.ReservedSystemCPUs = managers.ReservedSystemCPUs
+			}
+		}
+		if reservations := profile.ResourceReservations; reservations != nil {
+			kubeletConfig := &workerProfile.KubeletConfiguration
+			if reservations.KubeReserved != nil {
+				kubeletConfig.KubeReserved = maps.Clone(reservations.KubeReserved)
+			}
+			if reservations.SystemReserved != nil {
+				kubeletConfig.SystemReserved = maps.Clone(reservations.SystemReserved)
+			}
+			if reservations.EvictionHard != nil {
+				kubeletConfig.EvictionHard = maps.Clone(reservations.EvictionHard)
+			}
+			if reservations.EnforceNodeAllocatable != nil {
+				kubeletConfig.EnforceNodeAllocatable = slices.Clone(reservations.EnforceNodeAllocatable)
+			}
+			if reservations.KubeReservedCgroup != "" {
+				kubeletConfig.KubeReservedCgroup = reservations.KubeReservedCgroup
+			}
+			if reservations.SystemReservedCgroup != "" {
+				kubeletConfig.SystemReservedCgroup = reservations.SystemReservedCgroup
+			}
+			workerProfile.ResourceReservations = reservations
+		}
+		if seccomp := profile.Seccomp; seccomp != nil {
+			workerProfile.KubeletConfiguration.SeccompDefault = ptr.To(seccomp.Default)
+			workerProfile.Seccomp = seccomp
+		}
+		workerProfile.ImageCredentialProviders = profile.ImageCredentialProviders
+		workerProfile.GPURuntimes = profile.GPURuntimes
+		workerProfile.RestartPolicies = profile.RestartPolicies
 		workerProfiles[profile.Name] = workerProfile
 	}
 
@@ -558,7 +651,7 @@ func buildRBACResources(configMaps []*corev1.ConfigMap) []resource {
 		Subjects: []rbacv1.Subject{{
 			APIGroup: rbacv1.GroupName,
 			Kind:     rbacv1.GroupKind,
-			Name:     "system:bootstrappers",
+			Name:     token.WorkerTokenGroup,
 		}, {
 			APIGroup: rbacv1.GroupName,
 			Kind:     rbacv1.GroupKind,
@@ -569,6 +662,24 @@ func buildRBACResources(configMaps []*corev1.ConfigMap) []resource {
 	return objects
 }
 
+// buildRuntimeClasses returns the RuntimeClasses for the GPU runtime handlers
+// that are enabled in any of the worker profiles.
+func buildRuntimeClasses(profiles v1beta1.WorkerProfiles) []resource {
+	if !slices.ContainsFunc(profiles, func(profile v1beta1.WorkerProfile) bool {
+		return profile.GPURuntimes.NVIDIAEnabled()
+	}) {
+		return nil
+	}
+
+	return []resource{&nodev1.RuntimeClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   v1beta1.NVIDIARuntimeHandler,
+			Labels: applier.CommonLabels(constant.WorkerConfigComponentName),
+		},
+		Handler: v1beta1.NVIDIARuntimeHandler,
+	}}
+}
+
 func (r *Reconciler) buildProfile(snapshot *snapshot) *workerconfig.Profile {
 	cipherSuites := make([]string, len(constant.AllowedTLS12CipherSuiteIDs))
 	for i, cipherSuite := range constant.AllowedTLS12CipherSuiteIDs {
@@ -602,6 +713,7 @@ func (r *Reconciler) buildProfile(snapshot *snapshot) *workerconfig.Profile {
 			AgentPort: snapshot.konnectivityAgentPort,
 		},
 		DualStackEnabled: snapshot.dualStackEnabled,
+		K0sFeatureGates:  snapshot.k0sFeatureGates.DeepCopy(),
 	}
 
 	if workerProfile.NodeLocalLoadBalancing != nil &&
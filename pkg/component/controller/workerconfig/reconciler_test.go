@@ -363,6 +363,43 @@ func TestReconciler_ResourceGeneration(t *testing.T) {
 			}, {
 				Name:   "profile_ZZZ",
 				Config: &runtime.RawExtension{Raw: []byte(`{"cgroupsPerQOS": false, "kubeletCgroups": "", "kubeReservedCgroup": ""}`)},
+			}, {
+				Name: "profile_swap",
+				Swap: &v1beta1.WorkerSwap{Behavior: v1beta1.SwapBehaviorLimitedSwap},
+			}, {
+				Name:        "profile_gpu",
+				GPURuntimes: &v1beta1.WorkerGPURuntimes{NVIDIA: &v1beta1.WorkerNVIDIARuntime{Enabled: true}},
+			}, {
+				Name: "profile_gc",
+				GarbageCollection: &v1beta1.WorkerGarbageCollection{
+					ImageHighThresholdPercent: ptr.To[int32](75),
+					ImageMinimumAge:           &metav1.Duration{Duration: time.Hour},
+					ContainerLogMaxFiles:      ptr.To[int32](3),
+				},
+			}, {
+				Name: "profile_realtime",
+				ResourceManagers: &v1beta1.WorkerResourceManagers{
+					CPUManagerPolicy:      v1beta1.CPUManagerPolicyStatic,
+					TopologyManagerPolicy: v1beta1.TopologyManagerPolicySingleNUMANode,
+					ReservedSystemCPUs:    "0-1",
+				},
+			}, {
+				Name: "profile_reserved",
+				ResourceReservations: &v1beta1.WorkerResourceReservations{
+					SystemReserved:         map[string]string{"cpu": "500m", "memory": "1Gi"},
+					EvictionHard:           map[string]string{"memory.available": "500Mi"},
+					EnforceNodeAllocatable: []string{"pods", "system-reserved"},
+					SystemReservedCgroup:   "k0s-system.slice",
+				},
+			}, {
+				Name: "profile_seccomp",
+				Seccomp: &v1beta1.WorkerSeccomp{
+					Default: true,
+					Profiles: []v1beta1.SeccompProfile{{
+						Name:    "audit",
+						Profile: &runtime.RawExtension{Raw: []byte(`{"defaultAction":"SCMP_ACT_LOG"}`)},
+					}},
+				},
 			}},
 		},
 	}))
@@ -395,10 +432,46 @@ func TestReconciler_ResourceGeneration(t *testing.T) {
 			expected.KubeletCgroups = ""
 			expected.KubeReservedCgroup = ""
 		},
+
+		"worker-config-profile_swap-1.34": func(expected *kubeletConfig) {
+			expected.FeatureGates = map[string]bool{"kubelet-feature": true, "NodeSwap": true}
+			expected.MemorySwap.SwapBehavior = v1beta1.SwapBehaviorLimitedSwap
+		},
+
+		"worker-config-profile_gpu-1.34": func(expected *kubeletConfig) {
+			expected.FeatureGates = map[string]bool{"kubelet-feature": true}
+		},
+
+		"worker-config-profile_gc-1.34": func(expected *kubeletConfig) {
+			expected.FeatureGates = map[string]bool{"kubelet-feature": true}
+			expected.ImageGCHighThresholdPercent = ptr.To[int32](75)
+			expected.ImageMinimumGCAge = metav1.Duration{Duration: time.Hour}
+			expected.ContainerLogMaxFiles = ptr.To[int32](3)
+		},
+
+		"worker-config-profile_realtime-1.34": func(expected *kubeletConfig) {
+			expected.FeatureGates = map[string]bool{"kubelet-feature": true}
+			expected.CPUManagerPolicy = v1beta1.CPUManagerPolicyStatic
+			expected.TopologyManagerPolicy = v1beta1.TopologyManagerPolicySingleNUMANode
+			expected.ReservedSystemCPUs = "0-1"
+		},
+
+		"worker-config-profile_reserved-1.34": func(expected *kubeletConfig) {
+			expected.FeatureGates = map[string]bool{"kubelet-feature": true}
+			expected.SystemReserved = map[string]string{"cpu": "500m", "memory": "1Gi"}
+			expected.EvictionHard = map[string]string{"memory.available": "500Mi"}
+			expected.EnforceNodeAllocatable = []string{"pods", "system-reserved"}
+			expected.SystemReservedCgroup = "k0s-system.slice"
+		},
+
+		"worker-config-profile_seccomp-1.34": func(expected *kubeletConfig) {
+			expected.FeatureGates = map[string]bool{"kubelet-feature": true}
+			expected.SeccompDefault = ptr.To(true)
+		},
 	}
 
 	appliedResources := applied()
-	assert.Len(t, appliedResources, len(expectedConfigMaps)+2)
+	assert.Len(t, appliedResources, len(expectedConfigMaps)+3)
 
 	for name, configModFn := range expectedConfigMaps {
 		t.Run(name, func(t *testing.T) {
@@ -435,6 +508,20 @@ func TestReconciler_ResourceGeneration(t *testing.T) {
 		}
 	})
 
+	t.Run("RuntimeClass", func(t *testing.T) {
+		runtimeClass := findResource(t, "Expected to find a RuntimeClass named nvidia",
+			appliedResources, func(resource *unstructured.Unstructured) bool {
+				return resource.GetKind() == "RuntimeClass" && resource.GetName() == "nvidia"
+			},
+		)
+
+		assert.Equal(t, "node.k8s.io/v1", runtimeClass.GetAPIVersion())
+		handler, ok, err := unstructured.NestedString(runtimeClass.Object, "handler")
+		if assert.NoError(t, err) && assert.True(t, ok, "No handler field") {
+			assert.Equal(t, "nvidia", handler)
+		}
+	})
+
 	t.Run("RoleBinding", func(t *testing.T) {
 		binding := findResource(t, "Expected to find a RoleBinding named "+rbacName,
 			appliedResources, func(resource *unstructured.Unstructured) bool {
@@ -458,7 +545,7 @@ func TestReconciler_ResourceGeneration(t *testing.T) {
 			expected := []any{map[string]any{
 				"apiGroup": "rbac.authorization.k8s.io",
 				"kind":     "Group",
-				"name":     "system:bootstrappers",
+				"name":     "system:bootstrappers:k0s-worker",
 			}, map[string]any{
 				"apiGroup": "rbac.authorization.k8s.io",
 				"kind":     "Group",
@@ -37,6 +37,7 @@ type configSnapshot struct {
 	profiles               v1beta1.WorkerProfiles
 	featureGates           v1beta1.FeatureGates
 	pauseImage             *v1beta1.ImageSpec
+	k0sFeatureGates        v1beta1.K0sFeatureGates
 }
 
 func (s *snapshot) DeepCopy() *snapshot {
@@ -67,6 +68,7 @@ func (s *configSnapshot) DeepCopyInto(out *configSnapshot) {
 	*out = *s
 	out.nodeLocalLoadBalancing = s.nodeLocalLoadBalancing.DeepCopy()
 	out.profiles = s.profiles.DeepCopy()
+	out.k0sFeatureGates = s.k0sFeatureGates.DeepCopy()
 }
 
 // takeConfigSnapshot converts ClusterSpec to a delta snapshot
@@ -86,5 +88,6 @@ func takeConfigSnapshot(spec *v1beta1.ClusterSpec) configSnapshot {
 		spec.WorkerProfiles.DeepCopy(),
 		spec.FeatureGates.DeepCopy(),
 		spec.Images.Pause.DeepCopy(),
+		spec.K0sFeatureGates.DeepCopy(),
 	}
 }
@@ -132,17 +132,32 @@ func (r ReconcileError) Error() string {
 	return strings.Join(messages, "\n")
 }
 
+// ReconcileResult is the outcome of reconciling a single component.
+type ReconcileResult struct {
+	// Component is the name of the reconciled component.
+	Component string
+	// Err is the error returned by the component, if any.
+	Err error
+}
+
+// ComponentsReconciler reconciles several components and reports the outcome
+// for each of them.
+type ComponentsReconciler interface {
+	Reconciler
+	ReconcileComponents(context.Context, *v1beta1.ClusterConfig) []ReconcileResult
+}
+
+var _ ComponentsReconciler = (*Manager)(nil)
+
 // Reconcile reconciles all managed components
 func (m *Manager) Reconcile(ctx context.Context, cfg *v1beta1.ClusterConfig) error {
 	errors := make([]error, 0)
 	var ret error
-	logrus.Infof("starting component reconciling for %d components", len(m.Components))
-	for _, component := range m.Components {
-		if err := m.reconcileComponent(ctx, component, cfg); err != nil {
-			errors = append(errors, err)
+	for _, result := range m.ReconcileComponents(ctx, cfg) {
+		if result.Err != nil {
+			errors = append(errors, result.Err)
 		}
 	}
-	m.lastReconciledConfig = cfg
 	if len(errors) > 0 {
 		ret = ReconcileError{
 			Errors: errors,
@@ -152,6 +167,50 @@ func (m *Manager) Reconcile(ctx context.Context, cfg *v1beta1.ClusterConfig) err
 	return ret
 }
 
+// ReconcileComponents reconciles all managed components and returns the
+// outcome for each component that implements [Reconciler].
+func (m *Manager) ReconcileComponents(ctx context.Context, cfg *v1beta1.ClusterConfig) []ReconcileResult {
+	var results []ReconcileResult
+	logrus.Infof("starting component reconciling for %d components", len(m.Components))
+	for _, component := range m.Components {
+		if !isReconcileComponent(component) {
+			continue
+		}
+		results = append(results, ReconcileResult{
+			Component: strings.TrimPrefix(reflect.TypeOf(component).String(), "*"),
+			Err:       m.reconcileComponent(ctx, component, cfg),
+		})
+	}
+	m.lastReconciledConfig = cfg
+	return results
+}
+
+// Reconcilers reconciles several reconcilers in order.
+type Reconcilers []ComponentsReconciler
+
+var _ ComponentsReconciler = Reconcilers(nil)
+
+// Reconcile reconciles all reconcilers, even if some of them fail.
+func (r Reconcilers) Reconcile(ctx context.Context, cfg *v1beta1.ClusterConfig) error {
+	var errs []error
+	for _, reconciler := range r {
+		if err := reconciler.Reconcile(ctx, cfg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ReconcileComponents reconciles all reconcilers and returns the outcome for
+// all of their components.
+func (r Reconcilers) ReconcileComponents(ctx context.Context, cfg *v1beta1.ClusterConfig) []ReconcileResult {
+	var results []ReconcileResult
+	for _, reconciler := range r {
+		results = append(results, reconciler.ReconcileComponents(ctx, cfg)...)
+	}
+	return results
+}
+
 func (m *Manager) reconcileComponent(ctx context.Context, comp Component, cfg *v1beta1.ClusterConfig) error {
 	clusterComponent, ok := comp.(Reconciler)
 	compName := reflect.TypeOf(comp).String()
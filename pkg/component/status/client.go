@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/component/prober"
@@ -31,7 +32,58 @@ type K0sStatus struct {
 	WorkerToAPIConnectionStatus ProbeStatus
 	ClusterConfig               *v1beta1.ClusterConfig
 	K0sVars                     *config.CfgVars
+	Backup                      *BackupStatus `json:",omitempty"`
+	// The SANs that have been discovered from the cloud instance metadata
+	// when the controller started, if enabled.
+	CloudMetadataSANs []string `json:",omitempty"`
+	// The certificates used by the kubelet, if workloads are enabled.
+	KubeletCertificates []KubeletCertificateStatus `json:",omitempty"`
+	// The supervised processes that k0s gave up restarting, along with the
+	// reason. The node is degraded if there are any.
+	Degraded map[string]string `json:",omitempty"`
 }
+
+// KubeletCertificateStatus is the status of a certificate used by the kubelet.
+type KubeletCertificateStatus struct {
+	// The certificate's usage, i.e. client or serving.
+	Usage    string
+	Path     string
+	NotAfter time.Time
+	// Whether the kubelet should have rotated the certificate by now.
+	RotationOverdue bool `json:",omitempty"`
+}
+
+// BackupStatus is the status of the scheduled backups taken by a controller.
+type BackupStatus struct {
+	// The schedule in which backups are taken.
+	Schedule string
+	// The time of the last successful backup, if any.
+	LastSuccess *time.Time `json:",omitempty"`
+	// The archive written by the last successful backup.
+	LastArchive string `json:",omitempty"`
+	// The time of the last failed backup, if any.
+	LastFailure *time.Time `json:",omitempty"`
+	// The error of the last failed backup.
+	LastError string `json:",omitempty"`
+}
+
+// CreateJoinTokenRequest is the request body to create a join token via the
+// status socket.
+type CreateJoinTokenRequest struct {
+	// Either worker or controller.
+	Role string `json:"role"`
+	// The time after which the token expires, e.g. 1h30m. Never if empty.
+	Expiry string `json:"expiry,omitempty"`
+	// Whether the token is invalidated after the first successful join.
+	OneTime bool `json:"oneTime,omitempty"`
+}
+
+// CreateJoinTokenResponse is the response body of a join token creation via
+// the status socket.
+type CreateJoinTokenResponse struct {
+	Token string `json:"token"`
+}
+
 type ProbeStatus struct {
 	Message string
 	Success bool
@@ -0,0 +1,15 @@
+//go:build unix && !linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"errors"
+	"net"
+)
+
+func getPeerCred(net.Conn) (*peerCred, error) {
+	return nil, errors.ErrUnsupported
+}
@@ -12,12 +12,15 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 
+	"github.com/k0sproject/k0s/pkg/certificate"
 	"github.com/k0sproject/k0s/pkg/component/manager"
 	"github.com/k0sproject/k0s/pkg/component/prober"
 	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/supervisor"
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -37,12 +40,19 @@ type Status struct {
 	httpserver        http.Server
 	listener          net.Listener
 	CertManager       certManager
+	Backups           backupStater
+	// Enables the join token endpoints, if set.
+	JoinTokens *JoinTokens
 }
 
 type certManager interface {
 	GetRestConfig(ctx context.Context) (*rest.Config, error)
 }
 
+type backupStater interface {
+	BackupStatus() *BackupStatus
+}
+
 var _ manager.Component = (*Status)(nil)
 
 const defaultMaxEvents = 5
@@ -62,9 +72,17 @@ func (s *Status) Init(_ context.Context) error {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	})
+	socketGID := -1
+	if s.JoinTokens != nil {
+		var err error
+		if socketGID, err = s.JoinTokens.register(mux, s.L); err != nil {
+			return err
+		}
+	}
 	var err error
 	s.httpserver = http.Server{
-		Handler: mux,
+		Handler:     mux,
+		ConnContext: withPeerCred,
 	}
 
 	removeLeftovers(s.Socket)
@@ -73,6 +91,15 @@ func (s *Status) Init(_ context.Context) error {
 		s.L.Errorf("failed to create listener %s", err)
 		return err
 	}
+	if socketGID >= 0 {
+		// Let the members of the join token socket group connect.
+		if err := os.Chown(s.Socket, -1, socketGID); err != nil {
+			return err
+		}
+		if err := os.Chmod(s.Socket, 0660); err != nil {
+			return err
+		}
+	}
 	s.L.Infof("Listening address %s", s.Socket)
 
 	return nil
@@ -130,10 +157,20 @@ const (
 
 func (sh *statusHandler) getCurrentStatus(ctx context.Context) K0sStatus {
 	status := sh.Status.StatusInformation
+	if sh.Status.Backups != nil {
+		status.Backup = sh.Status.Backups.BackupStatus()
+	}
+	if degraded := supervisor.GivenUp(); len(degraded) > 0 {
+		status.Degraded = degraded
+	}
 	if !status.Workloads {
 		return status
 	}
 
+	if status.K0sVars != nil {
+		status.KubeletCertificates = sh.kubeletCertificates(filepath.Join(status.K0sVars.KubeletRootDir, "pki"))
+	}
+
 	if sh.client == nil {
 		kubeClient, err := sh.buildWorkerSideKubeAPIClient(ctx)
 		if err != nil {
@@ -151,6 +188,26 @@ func (sh *statusHandler) getCurrentStatus(ctx context.Context) K0sStatus {
 	return status
 }
 
+func (sh *statusHandler) kubeletCertificates(certDir string) []KubeletCertificateStatus {
+	certs, err := certificate.LoadKubeletCertificates(certDir)
+	if err != nil {
+		sh.Status.L.WithError(err).Warn("Failed to load kubelet certificates")
+		return nil
+	}
+
+	now := time.Now()
+	statuses := make([]KubeletCertificateStatus, len(certs))
+	for i, cert := range certs {
+		statuses[i] = KubeletCertificateStatus{
+			Usage:           cert.Usage,
+			Path:            cert.Path,
+			NotAfter:        cert.NotAfter,
+			RotationOverdue: cert.RotationOverdue(now),
+		}
+	}
+	return statuses
+}
+
 func (sh *statusHandler) buildWorkerSideKubeAPIClient(ctx context.Context) (client kubernetes.Interface, _ error) {
 	timeout, cancel := context.WithTimeout(ctx, defaultPollTimeout)
 	defer cancel()
@@ -0,0 +1,224 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/user"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/config"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/token"
+
+	"github.com/sirupsen/logrus"
+)
+
+// JoinTokens configures the join token endpoints of the status socket. They
+// let host-local automation create, list and invalidate join tokens without
+// having a kubeconfig. The endpoints may only be used by root and by the
+// members of the configured group.
+type JoinTokens struct {
+	KubeClientFactory kubeutil.ClientFactoryInterface
+	ClusterConfig     *v1beta1.ClusterConfig
+	K0sVars           *config.CfgVars
+	// The group whose members may use the join token endpoints, in addition
+	// to root. Only root may use them if empty.
+	Group string
+
+	log *logrus.Entry
+	gid int
+}
+
+// peerCred are the credentials of the process on the other end of a status
+// socket connection.
+type peerCred struct {
+	PID      int32
+	UID, GID uint32
+}
+
+type peerCredKey struct{}
+
+// withPeerCred stores the peer credentials of the given connection in the
+// given context. Connections whose peer credentials can't be determined
+// aren't authorized to use the join token endpoints.
+func withPeerCred(ctx context.Context, conn net.Conn) context.Context {
+	if cred, err := getPeerCred(conn); err == nil {
+		return context.WithValue(ctx, peerCredKey{}, cred)
+	}
+	return ctx
+}
+
+// register adds the join token endpoints to the given mux. It returns the ID
+// of the configured group, or -1 if there's none.
+func (t *JoinTokens) register(mux *http.ServeMux, log *logrus.Entry) (int, error) {
+	t.log, t.gid = log, -1
+	if t.Group != "" {
+		group, err := user.LookupGroup(t.Group)
+		if err != nil {
+			group, err = user.LookupGroupId(t.Group)
+		}
+		if err != nil {
+			return -1, fmt.Errorf("failed to look up join token socket group: %w", err)
+		}
+		if t.gid, err = strconv.Atoi(group.Gid); err != nil {
+			return -1, err
+		}
+	}
+
+	mux.Handle("GET /tokens", t.authorize(t.list))
+	mux.Handle("POST /tokens", t.authorize(t.create))
+	mux.Handle("DELETE /tokens/{id}", t.authorize(t.remove))
+
+	return t.gid, nil
+}
+
+func (t *JoinTokens) authorize(next func(http.ResponseWriter, *http.Request, *peerCred)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cred, ok := r.Context().Value(peerCredKey{}).(*peerCred)
+		if !ok {
+			http.Error(w, "unable to determine peer credentials", http.StatusForbidden)
+			return
+		}
+		if !t.isAuthorized(cred) {
+			t.log.Warnf("Denied join token request %s %s from process %d with UID %d", r.Method, r.URL.Path, cred.PID, cred.UID)
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next(w, r, cred)
+	})
+}
+
+// isAuthorized checks if the process with the given credentials is root or a
+// member of the configured group.
+func (t *JoinTokens) isAuthorized(cred *peerCred) bool {
+	if cred.UID == 0 {
+		return true
+	}
+	if t.gid < 0 {
+		return false
+	}
+	if cred.GID == uint32(t.gid) {
+		return true
+	}
+	u, err := user.LookupId(strconv.FormatUint(uint64(cred.UID), 10))
+	if err != nil {
+		return false
+	}
+	groupIDs, err := u.GroupIds()
+	return err == nil && slices.Contains(groupIDs, strconv.Itoa(t.gid))
+}
+
+func (t *JoinTokens) manager() (*token.Manager, error) {
+	client, err := t.KubeClientFactory.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	return token.NewManagerForClient(client)
+}
+
+func (t *JoinTokens) list(w http.ResponseWriter, r *http.Request, _ *peerCred) {
+	manager, err := t.manager()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tokens, err := manager.List(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if json.NewEncoder(w).Encode(tokens) != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (t *JoinTokens) create(w http.ResponseWriter, r *http.Request, cred *peerCred) {
+	var req CreateJoinTokenRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<16)).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var expiry time.Duration
+	if req.Expiry != "" {
+		var err error
+		if expiry, err = time.ParseDuration(req.Expiry); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if expiry < 0 {
+			http.Error(w, "expiry must not be negative", http.StatusBadRequest)
+			return
+		}
+	}
+	switch req.Role {
+	case token.RoleWorker:
+	case token.RoleController:
+		if !t.ClusterConfig.Spec.Storage.IsJoinable() {
+			http.Error(w, "cannot join controller into current storage", http.StatusConflict)
+			return
+		}
+	default:
+		http.Error(w, fmt.Sprintf("unsupported role %q", req.Role), http.StatusBadRequest)
+		return
+	}
+
+	manager, err := t.manager()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	joinToken, err := manager.CreateJoinToken(r.Context(), t.ClusterConfig.Spec.API, t.K0sVars, req.Role, expiry, req.OneTime, creatorOf(cred))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	t.log.Infof("Created %s join token for process %d with UID %d", req.Role, cred.PID, cred.UID)
+
+	w.Header().Set("Content-Type", "application/json")
+	if json.NewEncoder(w).Encode(&CreateJoinTokenResponse{Token: joinToken}) != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (t *JoinTokens) remove(w http.ResponseWriter, r *http.Request, cred *peerCred) {
+	manager, err := t.manager()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	id := r.PathValue("id")
+	if err := manager.Remove(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	t.log.Infof("Invalidated join token with ID %s for process %d with UID %d", id, cred.PID, cred.UID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// creatorOf returns the creator of join tokens that are created on behalf of
+// the process with the given credentials, in the form of user@host.
+func creatorOf(cred *peerCred) string {
+	name := strconv.FormatUint(uint64(cred.UID), 10)
+	if u, err := user.LookupId(name); err == nil {
+		name = u.Username
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		name += "@" + hostname
+	}
+	return name
+}
@@ -0,0 +1,96 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/token"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinTokens(t *testing.T) {
+	certRootDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(certRootDir, "ca.crt"), []byte("the CA"), 0644))
+
+	joinTokens := &JoinTokens{
+		KubeClientFactory: testutil.NewFakeClientFactory(),
+		ClusterConfig:     v1beta1.DefaultClusterConfig(),
+		K0sVars:           &config.CfgVars{CertRootDir: certRootDir},
+		Group:             strconv.Itoa(os.Getgid()),
+	}
+	mux := http.NewServeMux()
+	_, err := joinTokens.register(mux, logrus.NewEntry(logrus.StandardLogger()))
+	require.NoError(t, err)
+
+	root := &peerCred{UID: 0, GID: 0}
+	member := &peerCred{UID: 4242, GID: uint32(os.Getgid())}
+	stranger := &peerCred{UID: 4242, GID: uint32(os.Getgid() + 1)}
+
+	do := func(cred *peerCred, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(t.Context(), method, path, strings.NewReader(body))
+		if cred != nil {
+			req = req.WithContext(context.WithValue(req.Context(), peerCredKey{}, cred))
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, do(nil, http.MethodGet, "/tokens", "").Code)
+		assert.Equal(t, http.StatusForbidden, do(stranger, http.MethodGet, "/tokens", "").Code)
+		assert.Equal(t, http.StatusForbidden, do(stranger, http.MethodPost, "/tokens", `{"role":"worker"}`).Code)
+	})
+
+	t.Run("invalid_requests", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, do(root, http.MethodPost, "/tokens", `{"role":"admin"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, do(root, http.MethodPost, "/tokens", `{"role":"worker","expiry":"-1h"}`).Code)
+	})
+
+	var tokenID string
+	t.Run("create", func(t *testing.T) {
+		rec := do(member, http.MethodPost, "/tokens", `{"role":"worker","expiry":"1h","oneTime":true}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp CreateJoinTokenResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		kubeconfig, err := token.DecodeJoinToken(resp.Token)
+		require.NoError(t, err)
+		assert.Contains(t, string(kubeconfig), token.WorkerTokenAuthName)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		rec := do(root, http.MethodGet, "/tokens", "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var tokens []token.Token
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&tokens))
+		require.Len(t, tokens, 1)
+		assert.Equal(t, token.RoleWorker, tokens[0].Role)
+		assert.True(t, strings.HasPrefix(tokens[0].CreatedBy, "4242@"), "unexpected creator %q", tokens[0].CreatedBy)
+		tokenID = tokens[0].ID
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		require.Equal(t, http.StatusNoContent, do(root, http.MethodDelete, "/tokens/"+tokenID, "").Code)
+		rec := do(root, http.MethodGet, "/tokens", "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.JSONEq(t, "null", rec.Body.String())
+	})
+}
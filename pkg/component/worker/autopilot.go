@@ -12,6 +12,7 @@ import (
 	"time"
 
 	apcli "github.com/k0sproject/k0s/pkg/autopilot/client"
+	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
 	apcont "github.com/k0sproject/k0s/pkg/autopilot/controller"
 	aproot "github.com/k0sproject/k0s/pkg/autopilot/controller/root"
 	"github.com/k0sproject/k0s/pkg/component/manager"
@@ -33,6 +34,8 @@ var _ manager.Component = (*Autopilot)(nil)
 type Autopilot struct {
 	K0sVars     *config.CfgVars
 	CertManager *CertificateManager
+
+	stop func()
 }
 
 func (a *Autopilot) Init(ctx context.Context) error {
@@ -74,29 +77,39 @@ func (a *Autopilot) Start(ctx context.Context) error {
 
 	log.Info("Autopilot client factory created, booting up worker root controller")
 	autopilotRoot, err := apcont.NewRootWorker(aproot.RootConfig{
+		InvocationID:        a.K0sVars.InvocationID,
 		KubeConfig:          a.K0sVars.KubeletAuthConfigPath,
 		K0sDataDir:          a.K0sVars.DataDir,
 		Mode:                "worker",
 		ManagerPort:         8899,
-		MetricsBindAddr:     "0",
+		MetricsBindAddr:     apconst.WorkerMetricsBindAddr,
 		HealthProbeBindAddr: "0",
 	}, log, autopilotClientFactory)
 	if err != nil {
 		return fmt.Errorf("failed to create autopilot worker: %w", err)
 	}
 
+	// The root is stopped explicitly, so that it can drain its in-flight
+	// reconciles before the worker shuts down.
+	rootCtx, cancelRoot := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
 	go func() {
-		if err := autopilotRoot.Run(ctx); err != nil {
+		defer close(done)
+		if err := autopilotRoot.Run(rootCtx); err != nil {
 			logrus.WithError(err).Error("Error running autopilot")
 
 			// TODO: We now have a service with nothing running.. now what?
 		}
 	}()
+	a.stop = func() { cancelRoot(); <-done }
 
 	return nil
 }
 
 // Stop stops Autopilot
 func (a *Autopilot) Stop() error {
+	if a.stop != nil {
+		a.stop()
+	}
 	return nil
 }
@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/certificate"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// The interval in which the kubelet certificates are checked.
+	kubeletCertificateCheckInterval = time.Minute
+	// The number of consecutive checks after which an overdue rotation is
+	// reported as failing.
+	kubeletCertificateRotationFailureThreshold = 5
+	// The number of checks after which a failing rotation is reported again.
+	kubeletCertificateRotationFailureRepeat = 60
+)
+
+var (
+	kubeletCertificateExpiration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "k0s",
+		Name:      "kubelet_certificate_expiration_timestamp_seconds",
+		Help:      "The time at which the kubelet's certificate expires.",
+	}, []string{"usage"})
+
+	kubeletCertificateRotationOverdue = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "k0s",
+		Name:      "kubelet_certificate_rotation_overdue",
+		Help:      "Whether the kubelet should have rotated its certificate by now.",
+	}, []string{"usage"})
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(kubeletCertificateExpiration, kubeletCertificateRotationOverdue)
+}
+
+var _ manager.Component = (*KubeletCertificateMonitor)(nil)
+
+// KubeletCertificateMonitor watches the expiration of the kubelet's client and
+// serving certificates. The expiration times are reported as metrics. If the
+// kubelet fails to rotate a certificate, this is reported as an Event for the
+// node, so that it doesn't silently drop off when its certificate expires.
+type KubeletCertificateMonitor struct {
+	NodeName   apitypes.NodeName
+	CertDir    string
+	Kubeconfig string
+
+	overdueChecks map[string]int
+	stop          func()
+}
+
+func (m *KubeletCertificateMonitor) Init(context.Context) error {
+	m.overdueChecks = make(map[string]int)
+	return nil
+}
+
+func (m *KubeletCertificateMonitor) Start(ctx context.Context) error {
+	client, err := kubernetes.NewClientFromFile(m.Kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	log := logrus.WithField("component", "kubelet-certificate-monitor")
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+			certs, err := certificate.LoadKubeletCertificates(m.CertDir)
+			if err != nil {
+				log.WithError(err).Error("Failed to check kubelet certificates")
+				return
+			}
+			m.check(ctx, log, client.CoreV1().Events(metav1.NamespaceDefault), certs, time.Now())
+		}, kubeletCertificateCheckInterval, 0.1, true)
+	}()
+	m.stop = func() { cancel(); <-done }
+
+	return nil
+}
+
+func (m *KubeletCertificateMonitor) Stop() error {
+	if m.stop != nil {
+		m.stop()
+	}
+	return nil
+}
+
+// check updates the metrics for the given certificates, and reports rotations
+// that have been overdue for a number of consecutive checks.
+func (m *KubeletCertificateMonitor) check(ctx context.Context, log logrus.FieldLogger, events corev1client.EventInterface, certs []certificate.KubeletCertificate, now time.Time) {
+	for _, cert := range certs {
+		kubeletCertificateExpiration.WithLabelValues(cert.Usage).Set(float64(cert.NotAfter.Unix()))
+
+		if !cert.RotationOverdue(now) {
+			kubeletCertificateRotationOverdue.WithLabelValues(cert.Usage).Set(0)
+			if m.overdueChecks[cert.Usage] >= kubeletCertificateRotationFailureThreshold {
+				log.Infof("Kubelet %s certificate has been rotated", cert.Usage)
+				m.recordEvent(ctx, log, events, corev1.EventTypeNormal, "KubeletCertificateRotated",
+					fmt.Sprintf("Kubelet %s certificate has been rotated, it expires at %s", cert.Usage, cert.NotAfter.Format(time.RFC3339)))
+			}
+			delete(m.overdueChecks, cert.Usage)
+			continue
+		}
+
+		kubeletCertificateRotationOverdue.WithLabelValues(cert.Usage).Set(1)
+		m.overdueChecks[cert.Usage]++
+		if checks := m.overdueChecks[cert.Usage] - kubeletCertificateRotationFailureThreshold; checks >= 0 && checks%kubeletCertificateRotationFailureRepeat == 0 {
+			message := fmt.Sprintf("Kubelet %s certificate hasn't been rotated, it expires at %s, check the kubelet logs", cert.Usage, cert.NotAfter.Format(time.RFC3339))
+			log.Warn(message)
+			m.recordEvent(ctx, log, events, corev1.EventTypeWarning, "KubeletCertificateRotationFailed", message)
+		}
+	}
+}
+
+// recordEvent creates an Event for the Node object of this worker. Failures are
+// only logged: the kubelet credentials may not be accepted anymore.
+func (m *KubeletCertificateMonitor) recordEvent(ctx context.Context, log logrus.FieldLogger, events corev1client.EventInterface, eventType, reason, message string) {
+	nodeName := string(m.NodeName)
+	now := metav1.Now()
+	_, err := events.Create(ctx, &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: nodeName + ".",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Node",
+			Name:       nodeName,
+			UID:        apitypes.UID(nodeName),
+		},
+		Reason:              reason,
+		Message:             message,
+		Type:                eventType,
+		FirstTimestamp:      now,
+		LastTimestamp:       now,
+		Count:               1,
+		Source:              corev1.EventSource{Component: "k0s-worker", Host: nodeName},
+		ReportingController: "k0s-worker",
+		ReportingInstance:   nodeName,
+	}, metav1.CreateOptions{})
+	if err != nil {
+		log.WithError(err).Warn("Failed to record event ", reason)
+	}
+}
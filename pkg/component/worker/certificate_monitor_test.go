@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/certificate"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKubeletCertificateMonitor_Check(t *testing.T) {
+	client := fake.NewClientset()
+	var recorded []*corev1.Event
+	client.PrependReactor("create", "events", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		event := action.(k8stesting.CreateAction).GetObject().(*corev1.Event)
+		recorded = append(recorded, event)
+		return true, event, nil
+	})
+	events := client.CoreV1().Events(metav1.NamespaceDefault)
+	log, _ := logtest.NewNullLogger()
+
+	underTest := KubeletCertificateMonitor{NodeName: "worker"}
+	require.NoError(t, underTest.Init(t.Context()))
+
+	notBefore := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	certs := []certificate.KubeletCertificate{{
+		Usage:     certificate.KubeletClientCertificate,
+		NotBefore: notBefore,
+		NotAfter:  notBefore.Add(100 * time.Hour),
+		Rotated:   true,
+	}, {
+		Usage:     certificate.KubeletServingCertificate,
+		NotBefore: notBefore,
+		NotAfter:  notBefore.Add(100 * time.Hour),
+	}}
+
+	check := func(now time.Time, times int) {
+		for range times {
+			underTest.check(t.Context(), log, events, certs, now)
+		}
+	}
+
+	check(notBefore.Add(50*time.Hour), 10)
+	assert.Empty(t, recorded, "rotation not due yet")
+
+	check(notBefore.Add(95*time.Hour), kubeletCertificateRotationFailureThreshold-1)
+	assert.Empty(t, recorded, "rotation overdue, but below the threshold")
+
+	check(notBefore.Add(95*time.Hour), 1)
+	if assert.Len(t, recorded, 1) {
+		assert.Equal(t, corev1.EventTypeWarning, recorded[0].Type)
+		assert.Equal(t, "KubeletCertificateRotationFailed", recorded[0].Reason)
+		assert.Equal(t, "Kubelet client certificate hasn't been rotated, it expires at 2026-01-05T04:00:00Z, check the kubelet logs", recorded[0].Message)
+		assert.Equal(t, "Node", recorded[0].InvolvedObject.Kind)
+		assert.Equal(t, "worker", recorded[0].InvolvedObject.Name)
+	}
+
+	check(notBefore.Add(95*time.Hour), kubeletCertificateRotationFailureRepeat-1)
+	assert.Len(t, recorded, 1, "failure not repeated yet")
+	check(notBefore.Add(95*time.Hour), 1)
+	assert.Len(t, recorded, 2, "failure repeated")
+
+	certs[0].NotBefore, certs[0].NotAfter = notBefore.Add(90*time.Hour), notBefore.Add(190*time.Hour)
+	check(notBefore.Add(95*time.Hour), 2)
+	if assert.Len(t, recorded, 3) {
+		assert.Equal(t, corev1.EventTypeNormal, recorded[2].Type)
+		assert.Equal(t, "KubeletCertificateRotated", recorded[2].Reason)
+	}
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/k0sproject/k0s/pkg/cloudmetadata"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SpotLabel is the node label and taint key that marks nodes that run on spot,
+// preemptible or low priority instances.
+const SpotLabel = "node.k0sproject.io/spot"
+
+// AddCloudMetadataLabelsAndTaints queries the instance metadata service of the
+// given cloud provider and adds the well-known topology and instance type
+// labels to the given labels. Spot instances are labeled, and tainted with the
+// given effect, if any. The given labels and taints take precedence over the
+// discovered ones.
+func AddCloudMetadataLabelsAndTaints(ctx context.Context, provider, spotTaintEffect string, labels map[string]string, taints []string) (map[string]string, []string, error) {
+	if spotTaintEffect != "" {
+		if err := validateTaintEffect(corev1.TaintEffect(spotTaintEffect)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	instance, detected, err := cloudmetadata.NewClient().Instance(ctx, cloudmetadata.Provider(provider))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query cloud metadata: %w", err)
+	}
+
+	discoveredLabels, discoveredTaints := instanceLabelsAndTaints(instance, corev1.TaintEffect(spotTaintEffect))
+	logrus.Infof("Discovered node labels from %s instance metadata: %v", detected, discoveredLabels)
+
+	maps.Copy(discoveredLabels, labels)
+	for _, taint := range taints {
+		if parsed, err := parseTaint(taint); err == nil && parsed.Key == SpotLabel {
+			discoveredTaints = nil
+		}
+	}
+	return discoveredLabels, append(discoveredTaints, taints...), nil
+}
+
+func instanceLabelsAndTaints(instance *cloudmetadata.Instance, spotTaintEffect corev1.TaintEffect) (map[string]string, []string) {
+	labels := make(map[string]string)
+	for key, value := range map[string]string{
+		corev1.LabelTopologyRegion:     instance.Region,
+		corev1.LabelTopologyZone:       instance.Zone,
+		corev1.LabelInstanceTypeStable: instance.InstanceType,
+	} {
+		if value != "" {
+			labels[key] = value
+		}
+	}
+
+	var taints []string
+	if instance.Spot {
+		labels[SpotLabel] = "true"
+		if spotTaintEffect != "" {
+			taints = append(taints, SpotLabel+"=true:"+string(spotTaintEffect))
+		}
+	}
+
+	return labels, taints
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/cloudmetadata"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstanceLabelsAndTaints(t *testing.T) {
+	t.Run("on_demand", func(t *testing.T) {
+		labels, taints := instanceLabelsAndTaints(&cloudmetadata.Instance{
+			Zone:         "nova",
+			InstanceType: "m1.medium",
+		}, corev1.TaintEffectNoSchedule)
+
+		assert.Equal(t, map[string]string{
+			"topology.kubernetes.io/zone":      "nova",
+			"node.kubernetes.io/instance-type": "m1.medium",
+		}, labels)
+		assert.Empty(t, taints)
+	})
+
+	t.Run("spot", func(t *testing.T) {
+		instance := &cloudmetadata.Instance{
+			Region:       "eu-west-1",
+			Zone:         "eu-west-1b",
+			InstanceType: "m5.large",
+			Spot:         true,
+		}
+		expectedLabels := map[string]string{
+			"topology.kubernetes.io/region":    "eu-west-1",
+			"topology.kubernetes.io/zone":      "eu-west-1b",
+			"node.kubernetes.io/instance-type": "m5.large",
+			"node.k0sproject.io/spot":          "true",
+		}
+
+		labels, taints := instanceLabelsAndTaints(instance, "")
+		assert.Equal(t, expectedLabels, labels)
+		assert.Empty(t, taints, "no taint effect given")
+
+		labels, taints = instanceLabelsAndTaints(instance, corev1.TaintEffectPreferNoSchedule)
+		assert.Equal(t, expectedLabels, labels)
+		assert.Equal(t, []string{"node.k0sproject.io/spot=true:PreferNoSchedule"}, taints)
+	})
+}
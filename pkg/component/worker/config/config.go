@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"slices"
 
@@ -21,12 +22,24 @@ import (
 )
 
 type Profile struct {
-	APIServerAddresses     []net.HostPort
-	KubeletConfiguration   kubeletv1beta1.KubeletConfiguration
-	NodeLocalLoadBalancing *v1beta1.NodeLocalLoadBalancing
-	Konnectivity           Konnectivity
-	PauseImage             *v1beta1.ImageSpec
-	DualStackEnabled       bool
+	APIServerAddresses       []net.HostPort
+	KubeletConfiguration     kubeletv1beta1.KubeletConfiguration
+	NodeLocalLoadBalancing   *v1beta1.NodeLocalLoadBalancing
+	Konnectivity             Konnectivity
+	PauseImage               *v1beta1.ImageSpec
+	DualStackEnabled         bool
+	Sysctls                  map[string]string
+	KernelModules            []string
+	KubeletConfigDropIns     []v1beta1.KubeletConfigDropIn
+	GracefulShutdown         *v1beta1.GracefulShutdown
+	StaticPods               *v1beta1.WorkerStaticPods
+	Swap                     *v1beta1.WorkerSwap
+	ImageCredentialProviders []v1beta1.ImageCredentialProvider
+	GPURuntimes              *v1beta1.WorkerGPURuntimes
+	ResourceReservations     *v1beta1.WorkerResourceReservations
+	Seccomp                  *v1beta1.WorkerSeccomp
+	RestartPolicies          *v1beta1.WorkerRestartPolicies
+	K0sFeatureGates          v1beta1.K0sFeatureGates
 }
 
 func (p *Profile) DeepCopy() *Profile {
@@ -50,6 +63,32 @@ func (p *Profile) DeepCopyInto(out *Profile) {
 		*out = new(v1beta1.NodeLocalLoadBalancing)
 		(*in).DeepCopyInto(*out)
 	}
+	if p.Sysctls != nil {
+		out.Sysctls = maps.Clone(p.Sysctls)
+	}
+	if p.KernelModules != nil {
+		out.KernelModules = slices.Clone(p.KernelModules)
+	}
+	if p.KubeletConfigDropIns != nil {
+		out.KubeletConfigDropIns = make([]v1beta1.KubeletConfigDropIn, len(p.KubeletConfigDropIns))
+		for i := range p.KubeletConfigDropIns {
+			p.KubeletConfigDropIns[i].DeepCopyInto(&out.KubeletConfigDropIns[i])
+		}
+	}
+	out.GracefulShutdown = p.GracefulShutdown.DeepCopy()
+	out.StaticPods = p.StaticPods.DeepCopy()
+	out.Swap = p.Swap.DeepCopy()
+	if p.ImageCredentialProviders != nil {
+		out.ImageCredentialProviders = make([]v1beta1.ImageCredentialProvider, len(p.ImageCredentialProviders))
+		for i := range p.ImageCredentialProviders {
+			p.ImageCredentialProviders[i].DeepCopyInto(&out.ImageCredentialProviders[i])
+		}
+	}
+	out.GPURuntimes = p.GPURuntimes.DeepCopy()
+	out.ResourceReservations = p.ResourceReservations.DeepCopy()
+	out.Seccomp = p.Seccomp.DeepCopy()
+	out.RestartPolicies = p.RestartPolicies.DeepCopy()
+	out.K0sFeatureGates = p.K0sFeatureGates.DeepCopy()
 }
 
 func (p *Profile) Validate(path *field.Path) (errs field.ErrorList) {
@@ -137,12 +176,24 @@ func ToConfigMapData(profile *Profile) (map[string]string, error) {
 
 func forEachConfigMapEntry(profile *Profile, f func(fieldName string, ptr any)) {
 	for fieldName, ptr := range map[string]any{
-		"apiServerAddresses":     &profile.APIServerAddresses,
-		"kubeletConfiguration":   &profile.KubeletConfiguration,
-		"nodeLocalLoadBalancing": &profile.NodeLocalLoadBalancing,
-		"konnectivity":           &profile.Konnectivity,
-		"pauseImage":             &profile.PauseImage,
-		"dualStackEnabled":       &profile.DualStackEnabled,
+		"apiServerAddresses":       &profile.APIServerAddresses,
+		"kubeletConfiguration":     &profile.KubeletConfiguration,
+		"nodeLocalLoadBalancing":   &profile.NodeLocalLoadBalancing,
+		"konnectivity":             &profile.Konnectivity,
+		"pauseImage":               &profile.PauseImage,
+		"dualStackEnabled":         &profile.DualStackEnabled,
+		"sysctls":                  &profile.Sysctls,
+		"kernelModules":            &profile.KernelModules,
+		"kubeletConfigDropIns":     &profile.KubeletConfigDropIns,
+		"gracefulShutdown":         &profile.GracefulShutdown,
+		"staticPods":               &profile.StaticPods,
+		"swap":                     &profile.Swap,
+		"imageCredentialProviders": &profile.ImageCredentialProviders,
+		"gpuRuntimes":              &profile.GPURuntimes,
+		"resourceReservations":     &profile.ResourceReservations,
+		"seccomp":                  &profile.Seccomp,
+		"restartPolicies":          &profile.RestartPolicies,
+		"k0sFeatureGates":          &profile.K0sFeatureGates,
 	} {
 		f(fieldName, ptr)
 	}
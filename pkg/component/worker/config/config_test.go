@@ -5,12 +5,15 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/k0sproject/k0s/internal/pkg/net"
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	logsv1 "k8s.io/component-base/logs/api/v1"
 	kubeletv1beta1 "k8s.io/kubelet/config/v1beta1"
 	"k8s.io/utils/ptr"
@@ -167,6 +170,159 @@ var roundtripTests = []roundtripTest{
 			"konnectivity": `{"enabled":true,"agentPort":1337}`,
 		},
 	},
+	{
+		"kernel_parameters",
+		&Profile{
+			Konnectivity:  Konnectivity{AgentPort: 1337},
+			Sysctls:       map[string]string{"vm.max_map_count": "262144"},
+			KernelModules: []string{"ip_vs", "nf_conntrack"},
+		},
+		map[string]string{
+			"konnectivity":  `{"agentPort":1337}`,
+			"sysctls":       `{"vm.max_map_count":"262144"}`,
+			"kernelModules": `["ip_vs","nf_conntrack"]`,
+		},
+	},
+	{
+		"kubelet_config_drop_ins",
+		&Profile{
+			Konnectivity: Konnectivity{AgentPort: 1337},
+			KubeletConfigDropIns: []v1beta1.KubeletConfigDropIn{{
+				Name:   "eviction",
+				Config: &runtime.RawExtension{Raw: []byte(`{"evictionHard":{"memory.available":"500Mi"}}`)},
+			}},
+		},
+		map[string]string{
+			"konnectivity":         `{"agentPort":1337}`,
+			"kubeletConfigDropIns": `[{"name":"eviction","config":{"evictionHard":{"memory.available":"500Mi"}}}]`,
+		},
+	},
+	{
+		"graceful_shutdown",
+		&Profile{
+			Konnectivity: Konnectivity{AgentPort: 1337},
+			GracefulShutdown: &v1beta1.GracefulShutdown{
+				GracePeriod:             metav1.Duration{Duration: 30 * time.Second},
+				CriticalPodsGracePeriod: metav1.Duration{Duration: 10 * time.Second},
+			},
+		},
+		map[string]string{
+			"konnectivity":     `{"agentPort":1337}`,
+			"gracefulShutdown": `{"gracePeriod":"30s","criticalPodsGracePeriod":"10s"}`,
+		},
+	},
+	{
+		"static_pods",
+		&Profile{
+			Konnectivity: Konnectivity{AgentPort: 1337},
+			StaticPods: &v1beta1.WorkerStaticPods{
+				Enabled:        true,
+				CheckFrequency: &metav1.Duration{Duration: 5 * time.Second},
+			},
+		},
+		map[string]string{
+			"konnectivity": `{"agentPort":1337}`,
+			"staticPods":   `{"enabled":true,"checkFrequency":"5s"}`,
+		},
+	},
+	{
+		"swap",
+		&Profile{
+			Konnectivity: Konnectivity{AgentPort: 1337},
+			Swap:         &v1beta1.WorkerSwap{Behavior: v1beta1.SwapBehaviorLimitedSwap},
+		},
+		map[string]string{
+			"konnectivity": `{"agentPort":1337}`,
+			"swap":         `{"behavior":"LimitedSwap"}`,
+		},
+	},
+	{
+		"image_credential_providers",
+		&Profile{
+			Konnectivity: Konnectivity{AgentPort: 1337},
+			ImageCredentialProviders: []v1beta1.ImageCredentialProvider{{
+				Name: "ecr",
+				Binaries: map[string]v1beta1.ImageCredentialProviderBinary{
+					"linux-amd64": {URL: "https://example.com/ecr", Sha256: "abc"},
+				},
+				Config: &runtime.RawExtension{Raw: []byte(`{"matchImages":["*.ecr.aws"]}`)},
+			}},
+		},
+		map[string]string{
+			"konnectivity":             `{"agentPort":1337}`,
+			"imageCredentialProviders": `[{"name":"ecr","binaries":{"linux-amd64":{"url":"https://example.com/ecr","sha256":"abc"}},"config":{"matchImages":["*.ecr.aws"]}}]`,
+		},
+	},
+	{
+		"gpu_runtimes",
+		&Profile{
+			Konnectivity: Konnectivity{AgentPort: 1337},
+			GPURuntimes:  &v1beta1.WorkerGPURuntimes{NVIDIA: &v1beta1.WorkerNVIDIARuntime{Enabled: true}},
+		},
+		map[string]string{
+			"konnectivity": `{"agentPort":1337}`,
+			"gpuRuntimes":  `{"nvidia":{"enabled":true}}`,
+		},
+	},
+	{
+		"resource_reservations",
+		&Profile{
+			Konnectivity: Konnectivity{AgentPort: 1337},
+			ResourceReservations: &v1beta1.WorkerResourceReservations{
+				SystemReserved:         map[string]string{"memory": "1Gi"},
+				EnforceNodeAllocatable: []string{"pods", "system-reserved"},
+				SystemReservedCgroup:   "k0s-system.slice",
+			},
+		},
+		map[string]string{
+			"konnectivity":         `{"agentPort":1337}`,
+			"resourceReservations": `{"systemReserved":{"memory":"1Gi"},"enforceNodeAllocatable":["pods","system-reserved"],"systemReservedCgroup":"k0s-system.slice"}`,
+		},
+	},
+	{
+		"seccomp",
+		&Profile{
+			Konnectivity: Konnectivity{AgentPort: 1337},
+			Seccomp: &v1beta1.WorkerSeccomp{
+				Default: true,
+				Profiles: []v1beta1.SeccompProfile{{
+					Name:    "audit",
+					Profile: &runtime.RawExtension{Raw: []byte(`{"defaultAction":"SCMP_ACT_LOG"}`)},
+				}},
+			},
+		},
+		map[string]string{
+			"konnectivity": `{"agentPort":1337}`,
+			"seccomp":      `{"default":true,"profiles":[{"name":"audit","profile":{"defaultAction":"SCMP_ACT_LOG"}}]}`,
+		},
+	},
+	{
+		"restartPolicies",
+		&Profile{
+			Konnectivity: Konnectivity{AgentPort: 1337},
+			RestartPolicies: &v1beta1.WorkerRestartPolicies{
+				Kubelet: &v1beta1.RestartPolicy{
+					MaxBackoff:  &metav1.Duration{Duration: 5 * time.Minute},
+					MaxRestarts: 10,
+				},
+			},
+		},
+		map[string]string{
+			"konnectivity":    `{"agentPort":1337}`,
+			"restartPolicies": `{"kubelet":{"maxBackoff":"5m0s","maxRestarts":10}}`,
+		},
+	},
+	{
+		"k0sFeatureGates",
+		&Profile{
+			Konnectivity:    Konnectivity{AgentPort: 1337},
+			K0sFeatureGates: v1beta1.K0sFeatureGates{{Name: "RootlessWorker", Enabled: true}},
+		},
+		map[string]string{
+			"konnectivity":    `{"agentPort":1337}`,
+			"k0sFeatureGates": `[{"name":"RootlessWorker","enabled":true}]`,
+		},
+	},
 }
 
 func makeHostPort(host string, port uint16) net.HostPort {
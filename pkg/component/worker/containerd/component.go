@@ -25,12 +25,15 @@ import (
 
 	"github.com/k0sproject/k0s/internal/pkg/dir"
 	"github.com/k0sproject/k0s/internal/pkg/file"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/assets"
 	"github.com/k0sproject/k0s/pkg/component/manager"
 	workerconfig "github.com/k0sproject/k0s/pkg/component/worker/config"
 	"github.com/k0sproject/k0s/pkg/config"
 	containerruntime "github.com/k0sproject/k0s/pkg/container/runtime"
 	"github.com/k0sproject/k0s/pkg/debounce"
+	"github.com/k0sproject/k0s/pkg/rootless"
+	"github.com/k0sproject/k0s/pkg/selinux"
 	"github.com/k0sproject/k0s/pkg/supervisor"
 
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -74,6 +77,11 @@ func NewComponent(logLevel string, vars *config.CfgVars, profile *workerconfig.P
 		c.binaries = []string{"containerd", "containerd-shim", "containerd-shim-runc-v1", "containerd-shim-runc-v2", "runc"}
 		c.confPath = confPathPosix
 		c.importsPath = importsPathPosix
+		// Rootless workers can't write to /etc/k0s.
+		if rootless.InNamespace() {
+			c.confPath = filepath.Join(vars.DataDir, "containerd.toml")
+			c.importsPath = filepath.Join(vars.DataDir, "containerd.d") + string(filepath.Separator)
+		}
 	}
 	return c
 }
@@ -103,6 +111,10 @@ func (c *Component) Init(ctx context.Context) error {
 		return err
 	}
 
+	if err := selinux.CheckLabels(ctx, c.K0sVars.DataDir); err != nil {
+		logrus.WithField("component", "containerd").WithError(err).Warn(`Containerd will likely be denied access by SELinux, run "k0s selinux install" to fix this`)
+	}
+
 	if err := c.windowsInit(); err != nil {
 		return fmt.Errorf("windows init failed: %w", err)
 	}
@@ -147,6 +159,9 @@ func (c *Component) Start(ctx context.Context) error {
 				"--config=" + c.confPath,
 			},
 		}
+		if policies := c.Profile.RestartPolicies; policies != nil {
+			c.supervisor.ApplyRestartPolicy(policies.Containerd)
+		}
 
 		if err := c.supervisor.Supervise(); err != nil {
 			return err
@@ -217,9 +232,21 @@ func (c *Component) setupConfig() error {
 	configurer := &configurer{
 		loadPath:   filepath.Join(c.importsPath, "*.toml"),
 		pauseImage: c.Profile.PauseImage.URI(),
+		rootless:   rootless.InNamespace(),
 		log:        logrus.WithField("component", "containerd"),
 	}
 
+	// The GPU runtime handlers are detected on each start, so that they're
+	// based on the defaults of the containerd version that's bundled with k0s.
+	if c.Profile.GPURuntimes.NVIDIAEnabled() {
+		nvidiaRuntime, err := lookupNVIDIARuntime(nvidiaDriverVersionPath, nvidiaToolkitDirs)
+		if err != nil {
+			return err
+		}
+		configurer.log.Infof("Configuring the %s runtime handler using %s", v1beta1.NVIDIARuntimeHandler, nvidiaRuntime)
+		configurer.runcRuntimes = map[string]string{v1beta1.NVIDIARuntimeHandler: nvidiaRuntime}
+	}
+
 	config, err := configurer.handleImports()
 	if err != nil {
 		return fmt.Errorf("can't handle imports: %w", err)
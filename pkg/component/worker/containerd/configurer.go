@@ -5,6 +5,7 @@ package containerd
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -30,6 +31,13 @@ type configurer struct {
 	loadPath   string
 	pauseImage string
 
+	// Additional runtime handlers that are based on k0s's default runc
+	// runtime, but use another runc compatible binary, keyed by name.
+	runcRuntimes map[string]string
+
+	// Whether containerd runs in the user namespace of a rootless worker.
+	rootless bool
+
 	log *logrus.Entry
 }
 
@@ -40,7 +48,7 @@ type configurer struct {
 func (c *configurer) handleImports() (*resolvedConfig, error) {
 	var importPaths []string
 
-	defaultConfig, err := generateDefaultCRIConfig(c.pauseImage)
+	defaultConfig, err := generateDefaultCRIConfig(c.pauseImage, c.runcRuntimes, c.rootless)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate containerd default CRI config: %w", err)
 	}
@@ -90,11 +98,34 @@ func (c *configurer) handleImports() (*resolvedConfig, error) {
 // Returns the default containerd config, including only the CRI plugin
 // configuration, using the given image for sandbox containers. Uses the
 // containerd package to generate all the rest, so this will be in sync with
-// containerd's defaults for the CRI plugin.
-func generateDefaultCRIConfig(sandboxContainerImage string) ([]byte, error) {
+// containerd's defaults for the CRI plugin. The runc runtimes are added as
+// copies of the default runc runtime, using the given binaries instead. In a
+// user namespace, the features that require privileges on the host are
+// disabled.
+func generateDefaultCRIConfig(sandboxContainerImage string, runcRuntimes map[string]string, rootless bool) ([]byte, error) {
 	criPluginConfig := criconfig.DefaultConfig()
 	// Set pause image
 	criPluginConfig.SandboxImage = sandboxContainerImage
+	if len(runcRuntimes) > 0 {
+		runc, ok := criPluginConfig.Runtimes[criPluginConfig.DefaultRuntimeName]
+		if !ok {
+			return nil, fmt.Errorf("default runtime %q not found", criPluginConfig.DefaultRuntimeName)
+		}
+		for name, binaryName := range runcRuntimes {
+			handler := runc
+			handler.Options = maps.Clone(runc.Options)
+			if handler.Options == nil {
+				handler.Options = make(map[string]any, 1)
+			}
+			handler.Options["BinaryName"] = binaryName
+			criPluginConfig.Runtimes[name] = handler
+		}
+	}
+	if rootless {
+		criPluginConfig.RestrictOOMScoreAdj = true
+		criPluginConfig.DisableApparmor = true
+		criPluginConfig.DisableHugetlbController = true
+	}
 	if runtime.GOOS == "windows" {
 		// The default config for Windows uses %ProgramFiles%/containerd/cni/{bin,conf}.
 		// Maybe k0s can use the default in the future, so there's no need for this override.
@@ -81,4 +81,55 @@ version = 2
 		assert.NoError(t, err)
 		assert.Equal(t, []string{nonCriConfigPath}, criConfig.ImportPaths)
 	})
+
+	t.Run("should add runc runtimes based on the default runc runtime", func(t *testing.T) {
+		importsPath := t.TempDir()
+		criRuntimeConfig := `
+[plugins]
+  [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia.options]
+    SystemdCgroup = true
+`
+		require.NoError(t, os.WriteFile(filepath.Join(importsPath, "nvidia.toml"), []byte(criRuntimeConfig), 0644))
+		c := configurer{
+			loadPath:     filepath.Join(importsPath, "*.toml"),
+			runcRuntimes: map[string]string{"nvidia": "/usr/bin/nvidia-container-runtime"},
+			log:          logrus.New().WithField("test", t.Name()),
+		}
+		criConfig, err := c.handleImports()
+		require.NoError(t, err)
+
+		criConfigPath := filepath.Join(t.TempDir(), "cri.toml")
+		require.NoError(t, os.WriteFile(criConfigPath, []byte(criConfig.CRIConfig), 0644))
+		var containerdConfig serverconfig.Config
+		require.NoError(t, serverconfig.LoadConfig(criConfigPath, &containerdConfig))
+
+		criPluginConfig := containerdConfig.Plugins["io.containerd.grpc.v1.cri"]
+		require.NotNil(t, criPluginConfig, "No CRI plugin configuration section found")
+		runtimes := []string{"containerd", "runtimes"}
+		assert.Equal(t, "io.containerd.runc.v2", criPluginConfig.GetPath(append(runtimes, "nvidia", "runtime_type")))
+		assert.Equal(t, "/usr/bin/nvidia-container-runtime", criPluginConfig.GetPath(append(runtimes, "nvidia", "options", "BinaryName")))
+		assert.Equal(t, true, criPluginConfig.GetPath(append(runtimes, "nvidia", "options", "SystemdCgroup")), "Drop-ins should be merged into the runtime")
+		assert.Empty(t, criPluginConfig.GetPath(append(runtimes, "runc", "options", "BinaryName")), "The default runc runtime should be unchanged")
+	})
+
+	t.Run("should disable privileged features when rootless", func(t *testing.T) {
+		c := configurer{
+			loadPath: filepath.Join(t.TempDir(), "*.toml"),
+			rootless: true,
+			log:      logrus.New().WithField("test", t.Name()),
+		}
+		criConfig, err := c.handleImports()
+		require.NoError(t, err)
+
+		criConfigPath := filepath.Join(t.TempDir(), "cri.toml")
+		require.NoError(t, os.WriteFile(criConfigPath, []byte(criConfig.CRIConfig), 0644))
+		var containerdConfig serverconfig.Config
+		require.NoError(t, serverconfig.LoadConfig(criConfigPath, &containerdConfig))
+
+		criPluginConfig := containerdConfig.Plugins["io.containerd.grpc.v1.cri"]
+		require.NotNil(t, criPluginConfig, "No CRI plugin configuration section found")
+		assert.Equal(t, true, criPluginConfig.Get("restrict_oom_score_adj"))
+		assert.Equal(t, true, criPluginConfig.Get("disable_apparmor"))
+		assert.Equal(t, true, criPluginConfig.Get("disable_hugetlb_controller"))
+	})
 }
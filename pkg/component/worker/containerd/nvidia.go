@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package containerd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/k0sproject/k0s/internal/pkg/file"
+)
+
+const nvidiaRuntimeBinary = "nvidia-container-runtime"
+
+// The file that's present if the NVIDIA kernel driver is loaded.
+const nvidiaDriverVersionPath = "/proc/driver/nvidia/version"
+
+// Directories that are searched for the NVIDIA Container Runtime if it's not in
+// the PATH. The NVIDIA GPU Operator installs the toolkit into
+// /usr/local/nvidia/toolkit.
+var nvidiaToolkitDirs = []string{"/usr/local/nvidia/toolkit"}
+
+// Checks that the NVIDIA driver is loaded and returns the path to the NVIDIA
+// Container Runtime.
+func lookupNVIDIARuntime(driverVersionPath string, toolkitDirs []string) (string, error) {
+	if runtime.GOOS != "linux" {
+		return "", fmt.Errorf("the NVIDIA runtime handler is unsupported on %s", runtime.GOOS)
+	}
+
+	if _, err := os.Stat(driverVersionPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("the NVIDIA driver isn't loaded: %s doesn't exist", driverVersionPath)
+		}
+		return "", fmt.Errorf("failed to detect the NVIDIA driver: %w", err)
+	}
+
+	if path, err := exec.LookPath(nvidiaRuntimeBinary); err == nil {
+		return path, nil
+	}
+	for _, dir := range toolkitDirs {
+		if path := filepath.Join(dir, nvidiaRuntimeBinary); file.Exists(path) {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("the NVIDIA Container Toolkit isn't installed: %s not found", nvidiaRuntimeBinary)
+}
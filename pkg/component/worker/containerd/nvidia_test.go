@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package containerd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupNVIDIARuntime(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		_, err := lookupNVIDIARuntime(nvidiaDriverVersionPath, nvidiaToolkitDirs)
+		assert.ErrorContains(t, err, "the NVIDIA runtime handler is unsupported on "+runtime.GOOS)
+		return
+	}
+
+	tmpDir := t.TempDir()
+	driverVersionPath := filepath.Join(tmpDir, "version")
+	pathDir, toolkitDir := filepath.Join(tmpDir, "path"), filepath.Join(tmpDir, "toolkit")
+	require.NoError(t, os.Mkdir(pathDir, 0755))
+	require.NoError(t, os.Mkdir(toolkitDir, 0755))
+	t.Setenv("PATH", pathDir)
+
+	_, err := lookupNVIDIARuntime(driverVersionPath, []string{toolkitDir})
+	assert.ErrorContains(t, err, "the NVIDIA driver isn't loaded")
+
+	require.NoError(t, os.WriteFile(driverVersionPath, []byte("NVRM version: NVIDIA UNIX x86_64 Kernel Module"), 0644))
+	_, err = lookupNVIDIARuntime(driverVersionPath, []string{toolkitDir})
+	assert.ErrorContains(t, err, "the NVIDIA Container Toolkit isn't installed")
+
+	toolkitRuntime := filepath.Join(toolkitDir, nvidiaRuntimeBinary)
+	require.NoError(t, os.WriteFile(toolkitRuntime, nil, 0755))
+	path, err := lookupNVIDIARuntime(driverVersionPath, []string{toolkitDir})
+	require.NoError(t, err)
+	assert.Equal(t, toolkitRuntime, path)
+
+	pathRuntime := filepath.Join(pathDir, nvidiaRuntimeBinary)
+	require.NoError(t, os.WriteFile(pathRuntime, nil, 0755))
+	path, err = lookupNVIDIARuntime(driverVersionPath, []string{toolkitDir})
+	require.NoError(t, err)
+	assert.Equal(t, pathRuntime, path, "The runtime in the PATH should take precedence")
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+
+	internalhttp "github.com/k0sproject/k0s/internal/http"
+	"github.com/k0sproject/k0s/internal/pkg/dir"
+	"github.com/k0sproject/k0s/internal/pkg/file"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/constant"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeletv1 "k8s.io/kubelet/config/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/sirupsen/logrus"
+)
+
+// installImageCredentialProviders installs the executables of the given image
+// credential provider plugins for the current platform into binDir, and
+// removes all other files from it. Executables whose hash already matches
+// aren't downloaded again.
+func installImageCredentialProviders(ctx context.Context, binDir string, providers []v1beta1.ImageCredentialProvider) error {
+	if len(providers) == 0 {
+		return os.RemoveAll(binDir)
+	}
+	if err := dir.Init(binDir, constant.BinDirMode); err != nil {
+		return err
+	}
+
+	platform := runtime.GOOS + "-" + runtime.GOARCH
+	for _, provider := range providers {
+		binary, ok := provider.Binaries[platform]
+		if !ok {
+			return fmt.Errorf("image credential provider %s has no binary for %s", provider.Name, platform)
+		}
+
+		// The kubelet expects the executables to be named after the plugins.
+		path := filepath.Join(binDir, provider.Name)
+		if hash, err := sha256File(path); err == nil && hash == binary.Sha256 {
+			continue
+		} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		logrus.Infof("Downloading image credential provider %s from %s", provider.Name, binary.URL)
+		if err := downloadExecutable(ctx, binary, path); err != nil {
+			return fmt.Errorf("failed to download image credential provider %s: %w", provider.Name, err)
+		}
+	}
+
+	entries, err := os.ReadDir(binDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !slices.ContainsFunc(providers, func(provider v1beta1.ImageCredentialProvider) bool { return provider.Name == entry.Name() }) {
+			if err := os.RemoveAll(filepath.Join(binDir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func downloadExecutable(ctx context.Context, binary v1beta1.ImageCredentialProviderBinary, path string) (err error) {
+	target, err := file.AtomicWithTarget(path).WithPermissions(0755).Open()
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, target.Close()) }()
+
+	hasher := sha256.New()
+	if err := internalhttp.Download(ctx, binary.URL, io.MultiWriter(target, hasher)); err != nil {
+		return err
+	}
+	if hash := hex.EncodeToString(hasher.Sum(nil)); hash != binary.Sha256 {
+		return fmt.Errorf("hash mismatch: expected %s, got %s", binary.Sha256, hash)
+	}
+
+	return target.Finish()
+}
+
+func sha256File(path string) (_ string, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { err = errors.Join(err, f.Close()) }()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// writeCredentialProviderConfig writes the kubelet's CredentialProviderConfig
+// for the given image credential provider plugins.
+func writeCredentialProviderConfig(path string, providers []v1beta1.ImageCredentialProvider) error {
+	config := kubeletv1.CredentialProviderConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kubeletv1.SchemeGroupVersion.String(),
+			Kind:       "CredentialProviderConfig",
+		},
+	}
+	for i := range providers {
+		provider, err := providers[i].CredentialProvider()
+		if err != nil {
+			return fmt.Errorf("image credential provider %s: %w", providers[i].Name, err)
+		}
+		config.Providers = append(config.Providers, *provider)
+	}
+
+	data, err := yaml.Marshal(&config)
+	if err != nil {
+		return err
+	}
+	return file.WriteContentAtomically(path, data, 0644)
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallImageCredentialProviders(t *testing.T) {
+	const content = "#!/bin/sh\necho credentials\n"
+	sum := sha256.Sum256([]byte(content))
+	hash := hex.EncodeToString(sum[:])
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+
+	provider := func(name, hash string) v1beta1.ImageCredentialProvider {
+		return v1beta1.ImageCredentialProvider{
+			Name: name,
+			Binaries: map[string]v1beta1.ImageCredentialProviderBinary{
+				runtime.GOOS + "-" + runtime.GOARCH: {URL: server.URL + "/" + name, Sha256: hash},
+			},
+		}
+	}
+
+	binDir := filepath.Join(t.TempDir(), "credential-providers")
+	require.NoError(t, os.MkdirAll(binDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "stale"), nil, 0755))
+
+	require.NoError(t, installImageCredentialProviders(t.Context(), binDir, []v1beta1.ImageCredentialProvider{provider("ecr", hash)}))
+	assert.Equal(t, int32(1), requests.Load())
+	data, err := os.ReadFile(filepath.Join(binDir, "ecr"))
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+	assert.NoFileExists(t, filepath.Join(binDir, "stale"))
+
+	// Unchanged executables aren't downloaded again.
+	require.NoError(t, installImageCredentialProviders(t.Context(), binDir, []v1beta1.ImageCredentialProvider{provider("ecr", hash)}))
+	assert.Equal(t, int32(1), requests.Load())
+
+	err = installImageCredentialProviders(t.Context(), binDir, []v1beta1.ImageCredentialProvider{provider("gcr", hex.EncodeToString(make([]byte, sha256.Size)))})
+	assert.ErrorContains(t, err, "hash mismatch")
+	assert.NoFileExists(t, filepath.Join(binDir, "gcr"))
+
+	noBinary := provider("acr", hash)
+	noBinary.Binaries = nil
+	err = installImageCredentialProviders(t.Context(), binDir, []v1beta1.ImageCredentialProvider{noBinary})
+	assert.ErrorContains(t, err, "image credential provider acr has no binary for "+runtime.GOOS+"-"+runtime.GOARCH)
+
+	require.NoError(t, installImageCredentialProviders(t.Context(), binDir, nil))
+	assert.NoDirExists(t, binDir)
+}
+
+func TestWriteCredentialProviderConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credential-providers.yaml")
+	providers := []v1beta1.ImageCredentialProvider{{
+		Name: "ecr-credential-provider",
+		Config: &k8sruntime.RawExtension{Raw: []byte(`{
+			"matchImages": ["*.dkr.ecr.*.amazonaws.com"],
+			"defaultCacheDuration": "12h",
+			"apiVersion": "credentialprovider.kubelet.k8s.io/v1",
+			"args": ["get-credentials"]
+		}`)},
+	}}
+
+	require.NoError(t, writeCredentialProviderConfig(path, providers))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.YAMLEq(t, `
+apiVersion: kubelet.config.k8s.io/v1
+kind: CredentialProviderConfig
+providers:
+  - name: ecr-credential-provider
+    matchImages: ["*.dkr.ecr.*.amazonaws.com"]
+    defaultCacheDuration: 12h0m0s
+    apiVersion: credentialprovider.kubelet.k8s.io/v1
+    args: [get-credentials]
+`, string(data))
+
+	providers[0].Config = &k8sruntime.RawExtension{Raw: []byte(`{"name":"other"}`)}
+	assert.ErrorContains(t, writeCredentialProviderConfig(path, providers), "image credential provider ecr-credential-provider:")
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"errors"
+)
+
+// filesystemSize isn't implemented on this platform.
+func filesystemSize(string) (uint64, error) {
+	return 0, errors.ErrUnsupported
+}
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// filesystemSize returns the total size in bytes of the filesystem that
+// contains the given path.
+func filesystemSize(path string) (uint64, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+	return st.Blocks * uint64(st.Bsize), nil
+}
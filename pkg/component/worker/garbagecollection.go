@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	kubeletv1beta1 "k8s.io/kubelet/config/v1beta1"
+	"k8s.io/utils/ptr"
+)
+
+// The garbage collection settings for small disks, ordered by disk size.
+// Larger disks use the kubelet's defaults, i.e. image garbage collection
+// between 85% and 80% disk usage, and five container log files of 10Mi.
+var smallDiskGarbageCollection = []struct {
+	maxDiskSize               uint64
+	imageHighThresholdPercent int32
+	imageLowThresholdPercent  int32
+	containerLogMaxSize       string
+	containerLogMaxFiles      int32
+}{
+	{16 << 30, 70, 50, "5Mi", 3},
+	{64 << 30, 80, 70, "10Mi", 3},
+}
+
+// applyGarbageCollectionDefaults fills in the garbage collection settings that
+// aren't specified in the kubelet configuration, based on the given disk size.
+// The image garbage collection thresholds are only filled in if neither of
+// them is specified, so that they stay consistent.
+func applyGarbageCollectionDefaults(config *kubeletv1beta1.KubeletConfiguration, diskSize uint64) bool {
+	for _, defaults := range smallDiskGarbageCollection {
+		if diskSize > defaults.maxDiskSize {
+			continue
+		}
+
+		if config.ImageGCHighThresholdPercent == nil && config.ImageGCLowThresholdPercent == nil {
+			config.ImageGCHighThresholdPercent = ptr.To(defaults.imageHighThresholdPercent)
+			config.ImageGCLowThresholdPercent = ptr.To(defaults.imageLowThresholdPercent)
+		}
+		if config.ContainerLogMaxSize == "" {
+			config.ContainerLogMaxSize = defaults.containerLogMaxSize
+		}
+		if config.ContainerLogMaxFiles == nil {
+			config.ContainerLogMaxFiles = ptr.To(defaults.containerLogMaxFiles)
+		}
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"testing"
+
+	kubeletv1beta1 "k8s.io/kubelet/config/v1beta1"
+	"k8s.io/utils/ptr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyGarbageCollectionDefaults(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		diskSize uint64
+		config   kubeletv1beta1.KubeletConfiguration
+		expected kubeletv1beta1.KubeletConfiguration
+	}{
+		{
+			"tiny_disk", 8 << 30,
+			kubeletv1beta1.KubeletConfiguration{},
+			kubeletv1beta1.KubeletConfiguration{
+				ImageGCHighThresholdPercent: ptr.To[int32](70),
+				ImageGCLowThresholdPercent:  ptr.To[int32](50),
+				ContainerLogMaxSize:         "5Mi",
+				ContainerLogMaxFiles:        ptr.To[int32](3),
+			},
+		},
+		{
+			"small_disk", 32 << 30,
+			kubeletv1beta1.KubeletConfiguration{},
+			kubeletv1beta1.KubeletConfiguration{
+				ImageGCHighThresholdPercent: ptr.To[int32](80),
+				ImageGCLowThresholdPercent:  ptr.To[int32](70),
+				ContainerLogMaxSize:         "10Mi",
+				ContainerLogMaxFiles:        ptr.To[int32](3),
+			},
+		},
+		{
+			"large_disk", 128 << 30,
+			kubeletv1beta1.KubeletConfiguration{},
+			kubeletv1beta1.KubeletConfiguration{},
+		},
+		{
+			"explicit_settings", 8 << 30,
+			kubeletv1beta1.KubeletConfiguration{
+				ImageGCHighThresholdPercent: ptr.To[int32](95),
+				ContainerLogMaxFiles:        ptr.To[int32](2),
+			},
+			kubeletv1beta1.KubeletConfiguration{
+				ImageGCHighThresholdPercent: ptr.To[int32](95),
+				ContainerLogMaxSize:         "5Mi",
+				ContainerLogMaxFiles:        ptr.To[int32](2),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			config := tc.config.DeepCopy()
+			applied := applyGarbageCollectionDefaults(config, tc.diskSize)
+			assert.Equal(t, tc.diskSize <= 64<<30, applied)
+			assert.Equal(t, &tc.expected, config)
+		})
+	}
+}
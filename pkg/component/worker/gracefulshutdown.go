@@ -0,0 +1,18 @@
+//go:build !linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// VerifyGracefulShutdown fails, as graceful node shutdown is only supported on
+// Linux.
+func VerifyGracefulShutdown(time.Duration) error {
+	return fmt.Errorf("graceful node shutdown is not supported on %s", runtime.GOOS)
+}
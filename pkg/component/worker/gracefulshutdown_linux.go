@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/pkg/dir"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// VerifyGracefulShutdown checks that the host is able to delay its shutdown
+// for the given grace period. The kubelet takes a delay inhibitor lock from
+// systemd-logind, so the host needs to run systemd, and logind needs to be
+// reachable via the D-Bus system bus.
+func VerifyGracefulShutdown(gracePeriod time.Duration) error {
+	// Same check as sd_booted(3).
+	if !dir.IsDirectory("/run/systemd/system") {
+		return errors.New("the host isn't running systemd")
+	}
+
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the D-Bus system bus: %w", err)
+	}
+	defer conn.Close()
+
+	login1 := conn.Object("org.freedesktop.login1", "/org/freedesktop/login1")
+	prop, err := login1.GetProperty("org.freedesktop.login1.Manager.InhibitDelayMaxUSec")
+	if err != nil {
+		return fmt.Errorf("systemd-logind isn't available: %w", err)
+	}
+	var maxDelayUSec uint64
+	if err := prop.Store(&maxDelayUSec); err != nil {
+		return fmt.Errorf("failed to get systemd-logind's maximum inhibitor delay: %w", err)
+	}
+
+	// The kubelet raises logind's InhibitDelayMaxSec via a drop-in in
+	// /etc/systemd/logind.conf.d if it's too low.
+	if maxDelay := time.Duration(maxDelayUSec) * time.Microsecond; maxDelay < gracePeriod {
+		logrus.Infof("systemd-logind's maximum inhibitor delay of %s is less than the shutdown grace period of %s, the kubelet will raise it", maxDelay, gracePeriod)
+	}
+
+	return nil
+}
@@ -5,5 +5,19 @@
 
 package worker
 
+import (
+	"fmt"
+	"runtime"
+)
+
 // KernelSetup comment
 func KernelSetup() {}
+
+// ApplyKernelParameters fails if any sysctls or kernel modules are given, as
+// they are only supported on Linux.
+func ApplyKernelParameters(sysctls map[string]string, modules []string) error {
+	if len(sysctls) > 0 || len(modules) > 0 {
+		return fmt.Errorf("sysctls and kernel modules are not supported on %s", runtime.GOOS)
+	}
+	return nil
+}
@@ -4,11 +4,17 @@
 package worker
 
 import (
+	"errors"
+	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/k0sproject/k0s/internal/pkg/dir"
 	"github.com/k0sproject/k0s/internal/pkg/file"
 
 	"github.com/sirupsen/logrus"
@@ -67,3 +73,60 @@ func KernelSetup() {
 	enableSysCtl("net/bridge/bridge-nf-call-iptables")
 	enableSysCtl("net/bridge/bridge-nf-call-ip6tables")
 }
+
+// ApplyKernelParameters loads the given kernel modules and sets the given
+// sysctls, as declared by the worker profile. Other than [KernelSetup], it
+// fails if the host can't satisfy them, and verifies that the sysctls have
+// actually been set.
+func ApplyKernelParameters(sysctls map[string]string, modules []string) error {
+	return applyKernelParameters("/proc/sys", "/sys/module", sysctls, modules, func(module string) error {
+		if out, err := exec.Command("modprobe", module).CombinedOutput(); err != nil {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	})
+}
+
+func applyKernelParameters(procSysDir, sysModuleDir string, sysctls map[string]string, modules []string, modprobe func(string) error) error {
+	var errs []error
+
+	for _, module := range modules {
+		// Loaded modules are listed with underscores instead of dashes.
+		if dir.IsDirectory(filepath.Join(sysModuleDir, strings.ReplaceAll(module, "-", "_"))) {
+			continue
+		}
+		if err := modprobe(module); err != nil {
+			errs = append(errs, fmt.Errorf("failed to load kernel module %s: %w", module, err))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(sysctls)) {
+		expected := sysctls[name]
+		path := filepath.Join(procSysDir, sysctlPath(name))
+		if err := os.WriteFile(path, []byte(expected), 0644); err != nil {
+			errs = append(errs, fmt.Errorf("failed to set sysctl %s: %w", name, err))
+			continue
+		}
+		actual, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to verify sysctl %s: %w", name, err))
+			continue
+		}
+		// Multi-valued sysctls are read back separated by tabs.
+		if strings.Join(strings.Fields(string(actual)), " ") != strings.Join(strings.Fields(expected), " ") {
+			errs = append(errs, fmt.Errorf("sysctl %s is %q instead of %q", name, strings.TrimSpace(string(actual)), expected))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sysctlPath converts a sysctl name into a path relative to /proc/sys. Names
+// may either be separated by dots or by slashes. In the latter case, dots are
+// part of the name, e.g. for network interfaces such as "eth0.100".
+func sysctlPath(name string) string {
+	if strings.Contains(name, "/") {
+		return name
+	}
+	return strings.ReplaceAll(name, ".", "/")
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyKernelParameters(t *testing.T) {
+	procSysDir, sysModuleDir := t.TempDir(), t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(procSysDir, "vm"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(procSysDir, "net", "ipv4", "conf", "eth0.100"), 0755))
+	require.NoError(t, os.Mkdir(filepath.Join(sysModuleDir, "nf_conntrack"), 0755))
+
+	var loaded []string
+	modprobe := func(module string) error {
+		if module == "missing" {
+			return errors.New("module not found")
+		}
+		loaded = append(loaded, module)
+		return nil
+	}
+
+	t.Run("satisfied", func(t *testing.T) {
+		loaded = nil
+		err := applyKernelParameters(procSysDir, sysModuleDir, map[string]string{
+			"vm.max_map_count":                  "262144",
+			"net/ipv4/conf/eth0.100/forwarding": "1",
+		}, []string{"nf-conntrack", "ip_vs"}, modprobe)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ip_vs"}, loaded, "nf-conntrack is already loaded")
+
+		value, err := os.ReadFile(filepath.Join(procSysDir, "vm", "max_map_count"))
+		require.NoError(t, err)
+		assert.Equal(t, "262144", string(value))
+		value, err = os.ReadFile(filepath.Join(procSysDir, "net", "ipv4", "conf", "eth0.100", "forwarding"))
+		require.NoError(t, err)
+		assert.Equal(t, "1", string(value))
+	})
+
+	t.Run("unknown_sysctl", func(t *testing.T) {
+		err := applyKernelParameters(procSysDir, sysModuleDir, map[string]string{"net.foo.bar": "1"}, nil, modprobe)
+		assert.ErrorContains(t, err, "failed to set sysctl net.foo.bar")
+	})
+
+	t.Run("missing_module", func(t *testing.T) {
+		err := applyKernelParameters(procSysDir, sysModuleDir, nil, []string{"missing"}, modprobe)
+		assert.ErrorContains(t, err, "failed to load kernel module missing: module not found")
+	})
+}
+
+func TestSysctlPath(t *testing.T) {
+	assert.Equal(t, "net/ipv4/ip_forward", sysctlPath("net.ipv4.ip_forward"))
+	assert.Equal(t, "net/ipv4/conf/eth0.100/forwarding", sysctlPath("net/ipv4/conf/eth0.100/forwarding"))
+}
@@ -6,6 +6,8 @@ package worker
 import (
 	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -17,11 +19,13 @@ import (
 	"github.com/k0sproject/k0s/internal/pkg/dir"
 	"github.com/k0sproject/k0s/internal/pkg/file"
 	"github.com/k0sproject/k0s/internal/pkg/stringmap"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/assets"
 	"github.com/k0sproject/k0s/pkg/component/manager"
 	"github.com/k0sproject/k0s/pkg/config"
 	"github.com/k0sproject/k0s/pkg/constant"
 	"github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/rootless"
 	"github.com/k0sproject/k0s/pkg/supervisor"
 
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +34,7 @@ import (
 	cliflag "k8s.io/component-base/cli/flag"
 	kubeletv1beta1 "k8s.io/kubelet/config/v1beta1"
 
+	"github.com/dustin/go-humanize"
 	"github.com/sirupsen/logrus"
 	"sigs.k8s.io/yaml"
 )
@@ -43,15 +48,24 @@ type Kubelet struct {
 	Kubeconfig          string
 	Configuration       kubeletv1beta1.KubeletConfiguration
 	StaticPods          StaticPods
+	StaticPodPath       string
 	LogLevel            string
 	ClusterDNS          string
 	Labels              map[string]string
 	Taints              []string
 	ExtraArgs           stringmap.StringMap
 	DualStackEnabled    bool
-
-	configPath string
-	supervisor supervisor.Supervisor
+	ConfigDropIns       []v1beta1.KubeletConfigDropIn
+	CredentialProviders []v1beta1.ImageCredentialProvider
+	SeccompProfiles     []v1beta1.SeccompProfile
+	Rootless            *rootless.Environment
+	RestartPolicy       *v1beta1.RestartPolicy
+
+	configPath                   string
+	configDropInDir              string
+	credentialProviderConfigPath string
+	credentialProviderBinDir     string
+	supervisor                   supervisor.Supervisor
 }
 
 var _ manager.Component = (*Kubelet)(nil)
@@ -81,6 +95,15 @@ func (k *Kubelet) Init(_ context.Context) error {
 		return fmt.Errorf("failed to create %s: %w", runDir, err)
 	}
 	k.configPath = filepath.Join(runDir, "config.yaml")
+	k.configDropInDir = filepath.Join(runDir, "config.d")
+	k.credentialProviderConfigPath = filepath.Join(runDir, "credential-providers.yaml")
+	k.credentialProviderBinDir = filepath.Join(k.K0sVars.BinDir, "credential-providers")
+
+	if k.StaticPodPath != "" {
+		if err := dir.Init(k.StaticPodPath, constant.ManifestsDirMode); err != nil {
+			return fmt.Errorf("failed to create %s: %w", k.StaticPodPath, err)
+		}
+	}
 
 	return nil
 }
@@ -144,7 +167,9 @@ func (k *Kubelet) Start(ctx context.Context) error {
 
 	switch runtime.GOOS {
 	case "linux":
-		args["--runtime-cgroups"] = "/system.slice/containerd.service"
+		if k.Rootless == nil {
+			args["--runtime-cgroups"] = "/system.slice/containerd.service"
+		}
 
 	case "windows":
 		args["--enforce-node-allocatable"] = ""
@@ -164,6 +189,26 @@ func (k *Kubelet) Start(ctx context.Context) error {
 		args["--cloud-provider"] = "external"
 	}
 
+	if len(k.ConfigDropIns) > 0 {
+		args["--config-dir"] = k.configDropInDir
+	}
+
+	if err := installImageCredentialProviders(ctx, k.credentialProviderBinDir, k.CredentialProviders); err != nil {
+		return err
+	}
+	if len(k.CredentialProviders) > 0 {
+		if err := writeCredentialProviderConfig(k.credentialProviderConfigPath, k.CredentialProviders); err != nil {
+			return fmt.Errorf("failed to write image credential provider config: %w", err)
+		}
+		args["--image-credential-provider-config"] = k.credentialProviderConfigPath
+		args["--image-credential-provider-bin-dir"] = k.credentialProviderBinDir
+	}
+
+	seccompProfileDir := filepath.Join(k.K0sVars.KubeletRootDir, "seccomp", v1beta1.SeccompProfileDir)
+	if err := writeSeccompProfiles(seccompProfileDir, k.SeccompProfiles); err != nil {
+		return fmt.Errorf("failed to write seccomp profiles: %w", err)
+	}
+
 	// Handle the extra args as last so they can be used to override some k0s "hardcodings"
 	args.Merge(k.ExtraArgs)
 
@@ -178,6 +223,7 @@ func (k *Kubelet) Start(ctx context.Context) error {
 		DataDir: k.K0sVars.DataDir,
 		Args:    args.ToArgs(),
 	}
+	k.supervisor.ApplyRestartPolicy(k.RestartPolicy)
 
 	if err := k.writeKubeletConfig(); err != nil {
 		return err
@@ -214,12 +260,26 @@ func (k *Kubelet) writeKubeletConfig() error {
 
 	config := k.Configuration.DeepCopy()
 	config.Authentication.X509.ClientCAFile = caPath
+	if k.Rootless != nil {
+		applyRootlessConfig(config, k.Rootless)
+	}
 	if config.ResolverConfig == nil {
 		config.ResolverConfig = determineKubeletResolvConfPath()
 	}
 	config.StaticPodURL = staticPodURL
+	if k.StaticPodPath != "" {
+		config.StaticPodPath = k.StaticPodPath
+	}
 	config.ContainerRuntimeEndpoint = containerRuntimeEndpoint.String()
 
+	if diskSize, err := filesystemSize(k.K0sVars.KubeletRootDir); err != nil {
+		if !errors.Is(err, errors.ErrUnsupported) {
+			logrus.WithError(err).Warn("Failed to determine the disk size, using the kubelet's garbage collection defaults")
+		}
+	} else if applyGarbageCollectionDefaults(config, diskSize) {
+		logrus.Infof("Using garbage collection defaults for a disk size of %s", humanize.IBytes(diskSize))
+	}
+
 	if len(k.Taints) > 0 {
 		var taints []corev1.Taint
 		for _, taint := range k.Taints {
@@ -242,6 +302,60 @@ func (k *Kubelet) writeKubeletConfig() error {
 		return fmt.Errorf("failed to write kubelet config: %w", err)
 	}
 
+	if err := writeKubeletConfigDropIns(k.configDropInDir, k.ConfigDropIns); err != nil {
+		return fmt.Errorf("failed to write kubelet config drop-ins: %w", err)
+	}
+
+	return nil
+}
+
+// applyRootlessConfig adapts the kubelet configuration to the user namespace
+// of a rootless worker. The kubelet manages the pod cgroups below the cgroup
+// that has been delegated to the user.
+func applyRootlessConfig(config *kubeletv1beta1.KubeletConfiguration, env *rootless.Environment) {
+	if config.FeatureGates == nil {
+		config.FeatureGates = make(map[string]bool, 1)
+	}
+	config.FeatureGates["KubeletInUserNamespace"] = true
+	config.CgroupDriver = "cgroupfs"
+	config.CgroupRoot = env.CgroupRoot
+	if env.ResolvConf != "" && config.ResolverConfig == nil {
+		config.ResolverConfig = &env.ResolvConf
+	}
+}
+
+// writeKubeletConfigDropIns replaces the contents of the drop-in directory with
+// the given drop-ins. The kubelet merges the drop-ins in the lexical order of
+// their file names, hence they're prefixed with their index.
+func writeKubeletConfigDropIns(dropInDir string, dropIns []v1beta1.KubeletConfigDropIn) error {
+	if err := os.RemoveAll(dropInDir); err != nil {
+		return err
+	}
+	if len(dropIns) == 0 {
+		return nil
+	}
+	if err := dir.Init(dropInDir, constant.RunDirMode); err != nil {
+		return err
+	}
+
+	for i, dropIn := range dropIns {
+		var config map[string]any
+		if err := json.Unmarshal(dropIn.Config.Raw, &config); err != nil {
+			return fmt.Errorf("%s: %w", dropIn.Name, err)
+		}
+		config["apiVersion"] = kubeletv1beta1.SchemeGroupVersion.String()
+		config["kind"] = "KubeletConfiguration"
+
+		data, err := yaml.Marshal(config)
+		if err != nil {
+			return fmt.Errorf("%s: %w", dropIn.Name, err)
+		}
+		fileName := fmt.Sprintf("%02d-%s.conf", i, dropIn.Name)
+		if err := file.WriteContentAtomically(filepath.Join(dropInDir, fileName), data, 0644); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -8,7 +8,12 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/rootless"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeletv1beta1 "k8s.io/kubelet/config/v1beta1"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -118,3 +123,48 @@ func TestHasSystemdResolvedNameserver(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteKubeletConfigDropIns(t *testing.T) {
+	dropInDir := filepath.Join(t.TempDir(), "config.d")
+	require.NoError(t, os.MkdirAll(dropInDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dropInDir, "00-stale.conf"), nil, 0644))
+
+	require.NoError(t, writeKubeletConfigDropIns(dropInDir, []v1beta1.KubeletConfigDropIn{
+		{Name: "eviction", Config: &runtime.RawExtension{Raw: []byte(`{"evictionHard":{"memory.available":"500Mi"}}`)}},
+		{Name: "image-gc", Config: &runtime.RawExtension{Raw: []byte(`{"imageGCHighThresholdPercent":90}`)}},
+	}))
+
+	entries, err := os.ReadDir(dropInDir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{"00-eviction.conf", "01-image-gc.conf"}, names)
+
+	data, err := os.ReadFile(filepath.Join(dropInDir, "01-image-gc.conf"))
+	require.NoError(t, err)
+	assert.Equal(t, "apiVersion: kubelet.config.k8s.io/v1beta1\nimageGCHighThresholdPercent: 90\nkind: KubeletConfiguration\n", string(data))
+
+	require.NoError(t, writeKubeletConfigDropIns(dropInDir, nil))
+	assert.NoDirExists(t, dropInDir)
+}
+
+func TestApplyRootlessConfig(t *testing.T) {
+	resolvConf := "/etc/custom-resolv.conf"
+	config := kubeletv1beta1.KubeletConfiguration{
+		CgroupDriver:   "systemd",
+		ResolverConfig: &resolvConf,
+		FeatureGates:   map[string]bool{"Foo": true},
+	}
+
+	applyRootlessConfig(&config, &rootless.Environment{
+		CgroupRoot: "/user.slice/user-1000.slice",
+		ResolvConf: "/home/k0s/.local/share/k0s/run/resolv.conf",
+	})
+
+	assert.Equal(t, map[string]bool{"Foo": true, "KubeletInUserNamespace": true}, config.FeatureGates)
+	assert.Equal(t, "cgroupfs", config.CgroupDriver)
+	assert.Equal(t, "/user.slice/user-1000.slice", config.CgroupRoot)
+	assert.Equal(t, &resolvConf, config.ResolverConfig, "an explicit resolv.conf should be kept")
+}
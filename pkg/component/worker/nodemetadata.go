@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/node"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/sirupsen/logrus"
+)
+
+var _ manager.Component = (*NodeMetadata)(nil)
+
+// NodeMetadata declares the labels and taints of the node in an annotation of
+// its Node object, from where the controllers reconcile them. The kubelet
+// itself only sets the labels when registering the node, and isn't allowed to
+// change its taints at all.
+type NodeMetadata struct {
+	NodeName   apitypes.NodeName
+	Kubeconfig string
+	// Whether to declare the labels and taints. If not, any previous
+	// declaration is removed from the node.
+	Reconcile     bool
+	Labels        map[string]string
+	Taints        []string
+	ManagedPrefix string
+
+	declared *node.DeclaredMetadata
+	stop     func()
+}
+
+func (n *NodeMetadata) Init(context.Context) error {
+	if !n.Reconcile {
+		return nil
+	}
+
+	declared := node.DeclaredMetadata{ManagedPrefix: n.ManagedPrefix, Labels: n.Labels}
+	for _, spec := range n.Taints {
+		taint, err := parseTaint(spec)
+		if err != nil {
+			return err
+		}
+		declared.Taints = append(declared.Taints, taint)
+	}
+	n.declared = &declared
+	return nil
+}
+
+// Start updates the declaration in the background, as the Node object is only
+// created after the kubelet has registered the node.
+func (n *NodeMetadata) Start(ctx context.Context) error {
+	client, err := kubernetes.NewClientFromFile(n.Kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	var annotation *string
+	if n.declared != nil {
+		data, err := json.Marshal(n.declared)
+		if err != nil {
+			return err
+		}
+		annotation = ptr.To(string(data))
+	}
+
+	log := logrus.WithField("component", "node-metadata")
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = wait.PollUntilContextCancel(ctx, 10*time.Second, true, func(ctx context.Context) (bool, error) {
+			if err := n.updateDeclaration(ctx, client.CoreV1().Nodes(), annotation); err != nil {
+				log.WithError(err).Debug("Failed to update the declared labels and taints, retrying")
+				return false, nil
+			}
+			return true, nil
+		})
+	}()
+	n.stop = func() { cancel(); <-done }
+
+	return nil
+}
+
+func (n *NodeMetadata) updateDeclaration(ctx context.Context, nodes corev1client.NodeInterface, annotation *string) error {
+	current, err := nodes.Get(ctx, string(n.NodeName), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	value, found := current.Annotations[node.DeclaredMetadataAnnotation]
+	if annotation == nil && !found || annotation != nil && found && value == *annotation {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]*string{node.DeclaredMetadataAnnotation: annotation},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := nodes.Patch(ctx, string(n.NodeName), apitypes.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to patch node: %w", err)
+	}
+	return nil
+}
+
+func (n *NodeMetadata) Stop() error {
+	if n.stop != nil {
+		n.stop()
+	}
+	return nil
+}
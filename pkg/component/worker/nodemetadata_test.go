@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/node"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeMetadata_UpdateDeclaration(t *testing.T) {
+	client := fake.NewClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker"}})
+	nodes := client.CoreV1().Nodes()
+
+	underTest := NodeMetadata{
+		NodeName:      "worker",
+		Reconcile:     true,
+		Labels:        map[string]string{"example.com/tier": "gold"},
+		Taints:        []string{"example.com/dedicated=db:NoSchedule"},
+		ManagedPrefix: "example.com/",
+	}
+	require.NoError(t, underTest.Init(t.Context()))
+
+	annotation := `{"managedPrefix":"example.com/","labels":{"example.com/tier":"gold"},"taints":[{"key":"example.com/dedicated","value":"db","effect":"NoSchedule"}]}`
+	declared, err := json.Marshal(underTest.declared)
+	require.NoError(t, err)
+	assert.JSONEq(t, annotation, string(declared))
+
+	require.NoError(t, underTest.updateDeclaration(t.Context(), nodes, &annotation))
+	worker, err := nodes.Get(t.Context(), "worker", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, annotation, worker.Annotations[node.DeclaredMetadataAnnotation])
+
+	require.NoError(t, underTest.updateDeclaration(t.Context(), nodes, nil))
+	worker, err = nodes.Get(t.Context(), "worker", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, worker.Annotations, node.DeclaredMetadataAnnotation)
+
+	underTest.NodeName = "missing"
+	assert.Error(t, underTest.updateDeclaration(t.Context(), nodes, ptr.To(annotation)))
+}
+
+func TestNodeMetadata_InvalidTaint(t *testing.T) {
+	underTest := NodeMetadata{Reconcile: true, Taints: []string{"foo:Bar"}}
+	assert.Error(t, underTest.Init(t.Context()))
+}
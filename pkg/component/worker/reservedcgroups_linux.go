@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/k0sproject/k0s/internal/pkg/dir"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	kubeletv1beta1 "k8s.io/kubelet/config/v1beta1"
+
+	systemddbus "github.com/coreos/go-systemd/v22/dbus"
+	"github.com/godbus/dbus/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// The cgroup controllers that the kubelet expects to be enabled in the
+// reserved cgroups, as far as they're available on the host.
+var reservedCgroupControllers = []string{"cpu", "cpuset", "memory", "hugetlb", "pids"}
+
+// EnsureReservedCgroups creates the cgroups in which the kubelet enforces
+// kube-reserved and system-reserved, and enables the required controllers for
+// them. The kubelet refuses to start if they don't exist. On systemd hosts, the
+// cgroup tree is owned by systemd, so the reserved cgroups need to be slices,
+// which are started via systemd instead of being created directly.
+func EnsureReservedCgroups(ctx context.Context, config *kubeletv1beta1.KubeletConfiguration) error {
+	var startSlice func(context.Context, string) error
+	if dir.IsDirectory("/run/systemd/system") {
+		startSlice = startSystemdSlice
+	}
+	return ensureReservedCgroups(ctx, "/sys/fs/cgroup", startSlice, config)
+}
+
+func ensureReservedCgroups(ctx context.Context, cgroupRoot string, startSlice func(context.Context, string) error, config *kubeletv1beta1.KubeletConfiguration) error {
+	var cgroups []string
+	for _, enforced := range config.EnforceNodeAllocatable {
+		switch enforced {
+		case v1beta1.EnforceNodeAllocatableKubeReserved:
+			cgroups = append(cgroups, config.KubeReservedCgroup)
+		case v1beta1.EnforceNodeAllocatableSystemReserved:
+			cgroups = append(cgroups, config.SystemReservedCgroup)
+		}
+	}
+	if len(cgroups) == 0 {
+		return nil
+	}
+
+	// The root of the unified hierarchy lists the available controllers.
+	data, err := os.ReadFile(filepath.Join(cgroupRoot, "cgroup.controllers"))
+	if errors.Is(err, os.ErrNotExist) {
+		return errors.New("enforcing kube-reserved or system-reserved requires cgroup v2")
+	} else if err != nil {
+		return fmt.Errorf("failed to determine the available cgroup controllers: %w", err)
+	}
+	available := strings.Fields(string(data))
+	for _, required := range []string{"cpu", "memory"} {
+		if !slices.Contains(available, required) {
+			return fmt.Errorf("the %s cgroup controller isn't available", required)
+		}
+	}
+	controllers := slices.DeleteFunc(slices.Clone(reservedCgroupControllers), func(controller string) bool {
+		return !slices.Contains(available, controller)
+	})
+
+	if startSlice != nil {
+		for _, cgroup := range cgroups {
+			if _, ok := systemdSliceName(cgroup); !ok {
+				return fmt.Errorf("systemd hosts require the cgroups of systemd slices, e.g. kube.slice, which %s isn't", cgroup)
+			}
+		}
+	}
+
+	for _, cgroup := range cgroups {
+		if startSlice != nil {
+			err = ensureSystemdSlice(ctx, cgroupRoot, cgroup, startSlice)
+		} else {
+			err = ensureCgroup(cgroupRoot, cgroup, controllers)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create cgroup %s: %w", cgroup, err)
+		}
+	}
+
+	return nil
+}
+
+// ensureSystemdSlice starts the systemd slice for the given cgroup, leaving it
+// to systemd to create the cgroup and to enable the controllers for it.
+func ensureSystemdSlice(ctx context.Context, cgroupRoot, cgroup string, startSlice func(context.Context, string) error) error {
+	name, _ := systemdSliceName(cgroup)
+	if err := startSlice(ctx, name); err != nil {
+		return fmt.Errorf("failed to start %s: %w", name, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(cgroupRoot, path.Clean("/"+cgroup), "cgroup.controllers"))
+	if err != nil {
+		return err
+	}
+	enabled := strings.Fields(string(data))
+	for _, required := range []string{"cpu", "memory"} {
+		if !slices.Contains(enabled, required) {
+			return fmt.Errorf("systemd didn't enable the %s cgroup controller for %s", required, name)
+		}
+	}
+	return nil
+}
+
+// systemdSliceName returns the name of the systemd slice whose cgroup is the
+// given one. Slices are nested by their names, i.e. the cgroup of
+// k0s-reserved.slice is /k0s.slice/k0s-reserved.slice.
+func systemdSliceName(cgroup string) (string, bool) {
+	var prefix string
+	for elem := range strings.SplitSeq(strings.Trim(path.Clean("/"+cgroup), "/"), "/") {
+		name, ok := strings.CutSuffix(elem, ".slice")
+		if !ok {
+			return "", false
+		}
+		if child, ok := strings.CutPrefix(name, prefix); !ok || child == "" || strings.Contains(child, "-") {
+			return "", false
+		}
+		prefix = name + "-"
+	}
+	return strings.TrimSuffix(prefix, "-") + ".slice", true
+}
+
+// startSystemdSlice starts the given slice as a transient unit, with
+// accounting enabled for the controllers that the kubelet requires. If the
+// slice exists already, accounting is enabled for it at runtime.
+func startSystemdSlice(ctx context.Context, name string) error {
+	conn, err := systemddbus.NewSystemConnectionContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	props := []systemddbus.Property{
+		{Name: "CPUAccounting", Value: dbus.MakeVariant(true)},
+		{Name: "MemoryAccounting", Value: dbus.MakeVariant(true)},
+		{Name: "TasksAccounting", Value: dbus.MakeVariant(true)},
+	}
+
+	result := make(chan string, 1)
+	_, err = conn.StartTransientUnitContext(ctx, name, "replace", append(props, systemddbus.PropDescription("k0s reserved resources")), result)
+	if dbusErr := (dbus.Error{}); errors.As(err, &dbusErr) && dbusErr.Name == "org.freedesktop.systemd1.UnitExists" {
+		return conn.SetUnitPropertiesContext(ctx, name, true, props...)
+	} else if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case result := <-result:
+		if result != "done" {
+			return fmt.Errorf("job %s", result)
+		}
+		logrus.Info("Started ", name)
+		return nil
+	}
+}
+
+// ensureCgroup creates the given cgroup, enabling the given controllers in all
+// of its ancestors, so that they're available in the cgroup itself.
+func ensureCgroup(cgroupRoot, cgroup string, controllers []string) error {
+	dir := cgroupRoot
+	for elem := range strings.SplitSeq(strings.Trim(path.Clean("/"+cgroup), "/"), "/") {
+		if err := enableCgroupControllers(dir, controllers); err != nil {
+			return err
+		}
+
+		dir = filepath.Join(dir, elem)
+		if err := os.Mkdir(dir, 0755); err == nil {
+			logrus.Info("Created cgroup ", dir)
+		} else if !errors.Is(err, os.ErrExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// enableCgroupControllers enables the given controllers for the children of
+// the cgroup in the given directory, unless they're enabled already. Enabling
+// them is only possible as long as there are no processes in the cgroup.
+func enableCgroupControllers(dir string, controllers []string) error {
+	subtreeControlPath := filepath.Join(dir, "cgroup.subtree_control")
+	data, err := os.ReadFile(subtreeControlPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	enabled := strings.Fields(string(data))
+	var missing []string
+	for _, controller := range controllers {
+		if !slices.Contains(enabled, controller) {
+			missing = append(missing, "+"+controller)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if err := os.WriteFile(subtreeControlPath, []byte(strings.Join(missing, " ")), 0644); err != nil {
+		return fmt.Errorf("failed to enable cgroup controllers %s: %w", strings.Join(missing, " "), err)
+	}
+	return nil
+}
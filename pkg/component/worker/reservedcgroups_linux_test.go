@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kubeletv1beta1 "k8s.io/kubelet/config/v1beta1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureReservedCgroups(t *testing.T) {
+	config := &kubeletv1beta1.KubeletConfiguration{
+		EnforceNodeAllocatable: []string{"pods", "kube-reserved", "system-reserved"},
+		KubeReservedCgroup:     "/system.slice",
+		SystemReservedCgroup:   "/k0s.slice/system-reserved",
+	}
+
+	t.Run("pods_only", func(t *testing.T) {
+		cgroupRoot := t.TempDir()
+		assert.NoError(t, ensureReservedCgroups(t.Context(), cgroupRoot, nil, &kubeletv1beta1.KubeletConfiguration{
+			EnforceNodeAllocatable: []string{"pods"},
+		}))
+		entries, err := os.ReadDir(cgroupRoot)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("cgroup_v1", func(t *testing.T) {
+		err := ensureReservedCgroups(t.Context(), t.TempDir(), nil, config)
+		assert.ErrorContains(t, err, "enforcing kube-reserved or system-reserved requires cgroup v2")
+	})
+
+	t.Run("missing_controller", func(t *testing.T) {
+		cgroupRoot := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "cgroup.controllers"), []byte("cpu pids\n"), 0644))
+		err := ensureReservedCgroups(t.Context(), cgroupRoot, nil, config)
+		assert.ErrorContains(t, err, "the memory cgroup controller isn't available")
+	})
+
+	t.Run("creates_cgroups", func(t *testing.T) {
+		cgroupRoot := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "cgroup.controllers"), []byte("cpuset cpu io memory pids\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "cgroup.subtree_control"), []byte("cpuset cpu io memory pids\n"), 0644))
+
+		require.NoError(t, ensureReservedCgroups(t.Context(), cgroupRoot, nil, config))
+
+		assert.DirExists(t, filepath.Join(cgroupRoot, "system.slice"))
+		assert.DirExists(t, filepath.Join(cgroupRoot, "k0s.slice", "system-reserved"))
+
+		data, err := os.ReadFile(filepath.Join(cgroupRoot, "cgroup.subtree_control"))
+		require.NoError(t, err)
+		assert.Equal(t, "cpuset cpu io memory pids\n", string(data), "enabled controllers shouldn't be enabled again")
+		data, err = os.ReadFile(filepath.Join(cgroupRoot, "k0s.slice", "cgroup.subtree_control"))
+		require.NoError(t, err)
+		assert.Equal(t, "+cpu +cpuset +memory +pids", string(data))
+		assert.NoFileExists(t, filepath.Join(cgroupRoot, "system.slice", "cgroup.subtree_control"))
+	})
+
+	t.Run("enables_missing_controllers", func(t *testing.T) {
+		cgroupRoot := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "cgroup.controllers"), []byte("cpuset cpu io memory pids\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "cgroup.subtree_control"), []byte("cpu memory\n"), 0644))
+
+		require.NoError(t, ensureReservedCgroups(t.Context(), cgroupRoot, nil, &kubeletv1beta1.KubeletConfiguration{
+			EnforceNodeAllocatable: []string{"kube-reserved"},
+			KubeReservedCgroup:     "/system.slice",
+		}))
+
+		data, err := os.ReadFile(filepath.Join(cgroupRoot, "cgroup.subtree_control"))
+		require.NoError(t, err)
+		assert.Equal(t, "+cpuset +pids", string(data))
+	})
+
+	t.Run("systemd", func(t *testing.T) {
+		cgroupRoot := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "cgroup.controllers"), []byte("cpuset cpu io memory pids\n"), 0644))
+
+		var started []string
+		startSlice := func(_ context.Context, name string) error {
+			started = append(started, name)
+			dir := filepath.Join(cgroupRoot, "kube.slice")
+			if name == "k0s-system.slice" {
+				dir = filepath.Join(cgroupRoot, "k0s.slice", "k0s-system.slice")
+			}
+			require.NoError(t, os.MkdirAll(dir, 0755))
+			return os.WriteFile(filepath.Join(dir, "cgroup.controllers"), []byte("cpu memory pids\n"), 0644)
+		}
+
+		require.NoError(t, ensureReservedCgroups(t.Context(), cgroupRoot, startSlice, &kubeletv1beta1.KubeletConfiguration{
+			EnforceNodeAllocatable: []string{"kube-reserved", "system-reserved"},
+			KubeReservedCgroup:     "kube.slice",
+			SystemReservedCgroup:   "/k0s.slice/k0s-system.slice",
+		}))
+		assert.Equal(t, []string{"kube.slice", "k0s-system.slice"}, started)
+		assert.NoFileExists(t, filepath.Join(cgroupRoot, "cgroup.subtree_control"), "controllers should be left to systemd")
+	})
+
+	t.Run("systemd_no_slice", func(t *testing.T) {
+		cgroupRoot := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "cgroup.controllers"), []byte("cpuset cpu io memory pids\n"), 0644))
+		startSlice := func(context.Context, string) error { return errors.New("unexpected") }
+
+		err := ensureReservedCgroups(t.Context(), cgroupRoot, startSlice, config)
+		assert.ErrorContains(t, err, "systemd hosts require the cgroups of systemd slices, e.g. kube.slice, which /k0s.slice/system-reserved isn't")
+		entries, err := os.ReadDir(cgroupRoot)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "no cgroups should have been created")
+	})
+}
+
+func TestSystemdSliceName(t *testing.T) {
+	for _, test := range []struct {
+		cgroup, name string
+	}{
+		{"kube.slice", "kube.slice"},
+		{"/kube.slice", "kube.slice"},
+		{"/k0s.slice/k0s-system.slice", "k0s-system.slice"},
+		{"/a.slice/a-b.slice/a-b-c.slice", "a-b-c.slice"},
+		{"", ""},
+		{"/", ""},
+		{".slice", ""},
+		{"k0s-system.slice", ""},
+		{"/k0s.slice/system.slice", ""},
+		{"/k0s.slice/system-reserved", ""},
+		{"/system.slice/containerd.service", ""},
+	} {
+		t.Run(test.cgroup, func(t *testing.T) {
+			name, ok := systemdSliceName(test.cgroup)
+			assert.Equal(t, test.name, name)
+			assert.Equal(t, test.name != "", ok)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+//go:build !linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	kubeletv1beta1 "k8s.io/kubelet/config/v1beta1"
+)
+
+// EnsureReservedCgroups fails if kube-reserved or system-reserved are to be
+// enforced, as the reserved cgroups are only supported on Linux.
+func EnsureReservedCgroups(_ context.Context, config *kubeletv1beta1.KubeletConfiguration) error {
+	for _, enforced := range []string{v1beta1.EnforceNodeAllocatableKubeReserved, v1beta1.EnforceNodeAllocatableSystemReserved} {
+		if slices.Contains(config.EnforceNodeAllocatable, enforced) {
+			return fmt.Errorf("enforcing %s is not supported on %s", enforced, runtime.GOOS)
+		}
+	}
+	return nil
+}
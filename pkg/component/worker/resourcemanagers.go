@@ -0,0 +1,16 @@
+//go:build !linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	kubeletv1beta1 "k8s.io/kubelet/config/v1beta1"
+)
+
+// VerifyResourceManagers is a no-op, as the host's CPU and NUMA topology is
+// only inspected on Linux.
+func VerifyResourceManagers(*kubeletv1beta1.KubeletConfiguration) error {
+	return nil
+}
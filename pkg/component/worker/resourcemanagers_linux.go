@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	kubeletv1beta1 "k8s.io/kubelet/config/v1beta1"
+	"k8s.io/utils/cpuset"
+)
+
+// The number of NUMA nodes up to which the kubelet's topology manager works,
+// unless the max-allowable-numa-nodes policy option says otherwise.
+const defaultMaxAllowableNUMANodes = 8
+
+// VerifyResourceManagers checks the kubelet's CPU, memory and topology manager
+// settings against the host's CPU and NUMA topology.
+func VerifyResourceManagers(config *kubeletv1beta1.KubeletConfiguration) error {
+	return verifyResourceManagers("/sys", config)
+}
+
+func verifyResourceManagers(sysfsRoot string, config *kubeletv1beta1.KubeletConfiguration) error {
+	if config.ReservedSystemCPUs != "" {
+		reserved, err := cpuset.Parse(config.ReservedSystemCPUs)
+		if err != nil {
+			return fmt.Errorf("invalid reserved system CPUs: %w", err)
+		}
+		online, err := readCPUList(filepath.Join(sysfsRoot, "devices", "system", "cpu", "online"))
+		if err != nil {
+			return fmt.Errorf("failed to determine the online CPUs: %w", err)
+		}
+		if !reserved.IsSubsetOf(online) {
+			return fmt.Errorf("reserved system CPUs %s aren't online, the online CPUs are %s", reserved.Difference(online), online)
+		}
+		if reserved.Equals(online) {
+			return fmt.Errorf("reserved system CPUs %s leave no CPUs for the workloads", reserved)
+		}
+	}
+
+	topologyManager := config.TopologyManagerPolicy != "" && config.TopologyManagerPolicy != v1beta1.TopologyManagerPolicyNone
+	if !topologyManager && config.MemoryManagerPolicy != v1beta1.MemoryManagerPolicyStatic {
+		return nil
+	}
+
+	nodes, err := readCPUList(filepath.Join(sysfsRoot, "devices", "system", "node", "online"))
+	if errors.Is(err, os.ErrNotExist) {
+		return errors.New("the host doesn't expose its NUMA topology")
+	} else if err != nil {
+		return fmt.Errorf("failed to determine the NUMA nodes: %w", err)
+	}
+
+	if topologyManager {
+		maxNodes := defaultMaxAllowableNUMANodes
+		if option, ok := config.TopologyManagerPolicyOptions["max-allowable-numa-nodes"]; ok {
+			if maxNodes, err = strconv.Atoi(option); err != nil {
+				return fmt.Errorf("invalid max-allowable-numa-nodes topology manager policy option: %w", err)
+			}
+		}
+		if nodes.Size() > maxNodes {
+			return fmt.Errorf("the host has %d NUMA nodes, but the topology manager allows at most %d", nodes.Size(), maxNodes)
+		}
+	}
+
+	return nil
+}
+
+// readCPUList reads a file in the kernel's CPU list format, e.g. 0-3,6.
+func readCPUList(path string) (cpuset.CPUSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cpuset.CPUSet{}, err
+	}
+	return cpuset.Parse(strings.TrimSpace(string(data)))
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	kubeletv1beta1 "k8s.io/kubelet/config/v1beta1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyResourceManagers(t *testing.T) {
+	sysfsRoot := t.TempDir()
+	cpuDir := filepath.Join(sysfsRoot, "devices", "system", "cpu")
+	nodeDir := filepath.Join(sysfsRoot, "devices", "system", "node")
+	require.NoError(t, os.MkdirAll(cpuDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(cpuDir, "online"), []byte("0-3\n"), 0644))
+
+	t.Run("defaults", func(t *testing.T) {
+		assert.NoError(t, verifyResourceManagers(sysfsRoot, &kubeletv1beta1.KubeletConfiguration{}))
+	})
+
+	t.Run("reserved_system_cpus", func(t *testing.T) {
+		assert.NoError(t, verifyResourceManagers(sysfsRoot, &kubeletv1beta1.KubeletConfiguration{
+			CPUManagerPolicy:   "static",
+			ReservedSystemCPUs: "0,1",
+		}))
+
+		err := verifyResourceManagers(sysfsRoot, &kubeletv1beta1.KubeletConfiguration{ReservedSystemCPUs: "2-5"})
+		assert.ErrorContains(t, err, "reserved system CPUs 4-5 aren't online, the online CPUs are 0-3")
+
+		err = verifyResourceManagers(sysfsRoot, &kubeletv1beta1.KubeletConfiguration{ReservedSystemCPUs: "0-3"})
+		assert.ErrorContains(t, err, "reserved system CPUs 0-3 leave no CPUs for the workloads")
+	})
+
+	t.Run("numa_topology", func(t *testing.T) {
+		config := &kubeletv1beta1.KubeletConfiguration{TopologyManagerPolicy: "single-numa-node"}
+		assert.ErrorContains(t, verifyResourceManagers(sysfsRoot, config), "the host doesn't expose its NUMA topology")
+
+		require.NoError(t, os.MkdirAll(nodeDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(nodeDir, "online"), []byte("0-9\n"), 0644))
+		assert.ErrorContains(t, verifyResourceManagers(sysfsRoot, config), "the host has 10 NUMA nodes, but the topology manager allows at most 8")
+
+		config.TopologyManagerPolicyOptions = map[string]string{"max-allowable-numa-nodes": "16"}
+		assert.NoError(t, verifyResourceManagers(sysfsRoot, config))
+
+		assert.NoError(t, verifyResourceManagers(sysfsRoot, &kubeletv1beta1.KubeletConfiguration{MemoryManagerPolicy: "Static"}))
+	})
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/k0sproject/k0s/internal/pkg/dir"
+	"github.com/k0sproject/k0s/internal/pkg/file"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/constant"
+)
+
+// writeSeccompProfiles writes the given seccomp profiles into profileDir, and
+// removes all other files from it, so that all workers of a profile have the
+// same set of profiles.
+func writeSeccompProfiles(profileDir string, profiles []v1beta1.SeccompProfile) error {
+	if len(profiles) == 0 {
+		return os.RemoveAll(profileDir)
+	}
+	if err := dir.Init(profileDir, constant.DataDirMode); err != nil {
+		return err
+	}
+
+	fileNames := make([]string, len(profiles))
+	for i, profile := range profiles {
+		// Pods reference the profiles by their file names.
+		fileNames[i] = profile.Name + ".json"
+		if err := file.WriteContentAtomically(filepath.Join(profileDir, fileNames[i]), profile.Profile.Raw, 0644); err != nil {
+			return err
+		}
+	}
+
+	entries, err := os.ReadDir(profileDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !slices.Contains(fileNames, entry.Name()) {
+			if err := os.RemoveAll(filepath.Join(profileDir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSeccompProfiles(t *testing.T) {
+	profileDir := filepath.Join(t.TempDir(), "seccomp", "k0s")
+	profile := func(name, defaultAction string) v1beta1.SeccompProfile {
+		return v1beta1.SeccompProfile{
+			Name:    name,
+			Profile: &runtime.RawExtension{Raw: []byte(`{"defaultAction":"` + defaultAction + `"}`)},
+		}
+	}
+
+	require.NoError(t, writeSeccompProfiles(profileDir, []v1beta1.SeccompProfile{
+		profile("audit", "SCMP_ACT_LOG"),
+		profile("deny", "SCMP_ACT_ERRNO"),
+	}))
+	data, err := os.ReadFile(filepath.Join(profileDir, "audit.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"defaultAction":"SCMP_ACT_LOG"}`, string(data))
+	assert.FileExists(t, filepath.Join(profileDir, "deny.json"))
+
+	// Profiles that aren't part of the worker profile anymore are removed.
+	require.NoError(t, os.WriteFile(filepath.Join(profileDir, "stray.json"), nil, 0644))
+	require.NoError(t, writeSeccompProfiles(profileDir, []v1beta1.SeccompProfile{profile("audit", "SCMP_ACT_ALLOW")}))
+	entries, err := os.ReadDir(profileDir)
+	require.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "audit.json", entries[0].Name())
+	}
+	data, err = os.ReadFile(filepath.Join(profileDir, "audit.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"defaultAction":"SCMP_ACT_ALLOW"}`, string(data))
+
+	require.NoError(t, writeSeccompProfiles(profileDir, nil))
+	assert.NoDirExists(t, profileDir)
+}
@@ -0,0 +1,22 @@
+//go:build !linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+)
+
+// VerifySwap fails for the LimitedSwap behavior, as it's only supported on
+// Linux.
+func VerifySwap(behavior string) error {
+	if behavior == v1beta1.SwapBehaviorLimitedSwap {
+		return fmt.Errorf("%s is not supported on %s", behavior, runtime.GOOS)
+	}
+	return nil
+}
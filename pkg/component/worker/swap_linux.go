@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// VerifySwap checks that the host supports the given swap behavior of the
+// workloads. The kubelet limits the swap usage of containers via cgroup v2's
+// memory controller, so LimitedSwap doesn't work without it.
+func VerifySwap(behavior string) error {
+	if behavior != v1beta1.SwapBehaviorLimitedSwap {
+		return nil
+	}
+
+	const cgroupRoot = "/sys/fs/cgroup"
+	var st unix.Statfs_t
+	if err := unix.Statfs(cgroupRoot, &st); err != nil {
+		return fmt.Errorf("failed to detect the cgroup version: %w", err)
+	}
+	if st.Type != unix.CGROUP2_SUPER_MAGIC {
+		return fmt.Errorf("%s requires cgroup v2", behavior)
+	}
+
+	controllers, err := os.ReadFile(cgroupRoot + "/cgroup.controllers")
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(strings.Fields(string(controllers)), "memory") {
+		return errors.New("the memory cgroup controller isn't available")
+	}
+
+	swaps, err := os.ReadFile("/proc/swaps")
+	if err != nil {
+		return err
+	}
+	// The first line is a header.
+	if lines := strings.Split(strings.TrimSpace(string(swaps)), "\n"); len(lines) < 2 {
+		logrus.Warn("No swap space is enabled on the host, the workloads won't be able to use swap")
+	}
+
+	return nil
+}
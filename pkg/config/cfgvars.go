@@ -16,6 +16,7 @@ import (
 
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/constant"
+	"github.com/k0sproject/k0s/pkg/rootless"
 )
 
 // CfgVars is a struct that holds all the config variables required for K0s
@@ -33,14 +34,18 @@ type CfgVars struct {
 	KineSocketPath             string              // The unix socket path for kine
 	KonnectivitySocketDir      string              // location of konnectivity's socket path
 	KubeletAuthConfigPath      string              // KubeletAuthConfigPath defines the default kubelet auth config path
+	NodeNamePath               string              // location of the node name that's bound via a node name template
 	ManifestsDir               string              // location for all stack manifests
 	RunDir                     string              // location of supervised pid files and sockets
 	KonnectivityKubeConfigPath string              // location for konnectivity kubeconfig
 	OCIBundleDir               string              // location for OCI bundles
+	StaticPodsDir              string              // location for node-local static pod manifests
 	DefaultStorageType         v1beta1.StorageType // Default backend storage
 	RuntimeConfigPath          string              // A static copy of the config loaded at startup
 	StatusSocketPath           string              // The unix socket path for k0s status API
 	StartupConfigPath          string              // The path to the config file used at startup
+	StrictConfig               bool                // Reject unknown fields in the config file
+	ExpandConfig               bool                // Substitute environment variable and file references in the config file
 
 	// Helm config
 	HelmHome             string
@@ -78,6 +83,14 @@ func WithCommand(cmd command) CfgVarOption {
 			c.StartupConfigPath = f
 		}
 
+		if f, err := flags.GetBool("strict-config"); err == nil {
+			c.StrictConfig = f
+		}
+
+		if f, err := flags.GetBool("expand-config"); err == nil {
+			c.ExpandConfig = f
+		}
+
 		if f, err := flags.GetString("status-socket"); err == nil && f != "" {
 			c.StatusSocketPath = f
 		}
@@ -131,8 +144,11 @@ func NewCfgVars(cobraCmd command, dirs ...string) (*CfgVars, error) {
 		return nil, err
 	}
 
+	// Rootless workers are root inside their user namespace, but they can't
+	// write to /run, so they use the same run directory as other non-root
+	// invocations of k0s.
 	var runDir string
-	if os.Geteuid() == 0 {
+	if os.Geteuid() == 0 && !rootless.InNamespace() {
 		runDir = "/run/k0s"
 	} else {
 		runDir = filepath.Join(dataDir, "run")
@@ -151,6 +167,7 @@ func NewCfgVars(cobraCmd command, dirs ...string) (*CfgVars, error) {
 		AdminKubeConfigPath:        filepath.Join(certDir, "admin.conf"),
 		BinDir:                     filepath.Join(dataDir, "bin"),
 		OCIBundleDir:               filepath.Join(dataDir, "images"),
+		StaticPodsDir:              filepath.Join(dataDir, "static-pods"),
 		CertRootDir:                certDir,
 		DataDir:                    dataDir,
 		KubeletRootDir:             kubeletRootDir,
@@ -159,6 +176,7 @@ func NewCfgVars(cobraCmd command, dirs ...string) (*CfgVars, error) {
 		KineSocketPath:             filepath.Join(runDir, constant.KineSocket),
 		KonnectivitySocketDir:      filepath.Join(runDir, "konnectivity-server"),
 		KubeletAuthConfigPath:      filepath.Join(dataDir, "kubelet.conf"),
+		NodeNamePath:               filepath.Join(dataDir, "node-name"),
 		ManifestsDir:               filepath.Join(dataDir, "manifests"),
 		RunDir:                     runDir,
 		KonnectivityKubeConfigPath: filepath.Join(certDir, "konnectivity.conf"),
@@ -209,13 +227,19 @@ func (c *CfgVars) NodeConfig() (*v1beta1.ClusterConfig, error) {
 		if err != nil {
 			return nil, err
 		}
+		if bytes, err = c.expandReferences(bytes); err != nil {
+			return nil, err
+		}
+		if err := c.checkUnknownFields(bytes); err != nil {
+			return nil, err
+		}
 
 		nodeConfig, err = nodeConfig.MergedWithYAML(bytes)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		cfgContent, err := os.ReadFile(c.StartupConfigPath)
+		cfgContent, err := ReadConfig(c.StartupConfigPath)
 		if err != nil {
 			if c.StartupConfigPath == defaultConfigPath && errors.Is(err, os.ErrNotExist) {
 				// The default configuration file doesn't exist; continue with the defaults.
@@ -224,6 +248,12 @@ func (c *CfgVars) NodeConfig() (*v1beta1.ClusterConfig, error) {
 
 			return nil, err
 		}
+		if cfgContent, err = c.expandReferences(cfgContent); err != nil {
+			return nil, err
+		}
+		if err := c.checkUnknownFields(cfgContent); err != nil {
+			return nil, err
+		}
 
 		return nodeConfig.MergedWithYAML(cfgContent)
 	}
@@ -234,3 +264,24 @@ func (c *CfgVars) NodeConfig() (*v1beta1.ClusterConfig, error) {
 
 	return nodeConfig, nil
 }
+
+func (c *CfgVars) expandReferences(data []byte) ([]byte, error) {
+	if !c.ExpandConfig {
+		return data, nil
+	}
+	expanded, err := ExpandReferences(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand config references: %w", err)
+	}
+	return expanded, nil
+}
+
+func (c *CfgVars) checkUnknownFields(data []byte) error {
+	if !c.StrictConfig {
+		return nil
+	}
+	if err := CheckUnknownFields(data); err != nil {
+		return fmt.Errorf("strict config validation failed: %w", err)
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
@@ -214,3 +215,44 @@ func TestNodeConfig_Stdin(t *testing.T) {
 	assert.ErrorContains(t, err, "stdin already grabbed")
 	assert.Nil(t, nodeConfig)
 }
+
+func TestNodeConfig_StdinJSON(t *testing.T) {
+	// JSON may be indented with tabs, which aren't allowed in YAML.
+	const config = "{\n\t\"apiVersion\": \"k0s.k0sproject.io/v1beta1\",\n\t\"kind\": \"ClusterConfig\",\n\t\"spec\": {\n\t\t\"network\": {\"provider\": \"calico\"}\n\t}\n}\n"
+
+	fakeCmd := &FakeCommand{
+		stdin:   strings.NewReader(config),
+		flagSet: &FakeFlagSet{values: map[string]any{"config": "-"}},
+	}
+
+	underTest, err := NewCfgVars(fakeCmd)
+	require.NoError(t, err)
+
+	nodeConfig, err := underTest.NodeConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "calico", nodeConfig.Spec.Network.Provider)
+}
+
+func TestNodeConfig_StrictConfig(t *testing.T) {
+	const config = `spec: {api: {extraArgss: {foo: bar}}}`
+
+	for _, strict := range []bool{false, true} {
+		fakeCmd := &FakeCommand{
+			stdin:   bytes.NewReader([]byte(config)),
+			flagSet: &FakeFlagSet{values: map[string]any{"config": "-", "strict-config": strict}},
+		}
+
+		underTest, err := NewCfgVars(fakeCmd)
+		require.NoError(t, err)
+		assert.Equal(t, strict, underTest.StrictConfig)
+
+		nodeConfig, err := underTest.NodeConfig()
+		if strict {
+			assert.ErrorContains(t, err, "strict config validation failed: unknown fields: spec.api.extraArgss")
+			assert.Nil(t, nodeConfig)
+		} else {
+			assert.NoError(t, err)
+			assert.NotNil(t, nodeConfig)
+		}
+	}
+}
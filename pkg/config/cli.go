@@ -12,6 +12,7 @@ import (
 	"github.com/k0sproject/k0s/pkg/component/manager"
 	"github.com/k0sproject/k0s/pkg/constant"
 	"github.com/k0sproject/k0s/pkg/k0scloudprovider"
+	"github.com/k0sproject/k0s/pkg/rootless"
 
 	cliflag "k8s.io/component-base/cli/flag"
 
@@ -54,22 +55,44 @@ type ControllerOptions struct {
 	EnableDynamicConfig             bool
 	EnableMetricsScraper            bool
 	KubeControllerManagerExtraArgs  string
+	VerifyKubeletServingCSRs        bool
+	WorkerJoinTokenRotation         time.Duration
+	JoinTokenSocketGroup            string
+
+	AutopilotControlNodeStaleTimeout time.Duration
+	AutopilotControlNodeRemovalAge   time.Duration
 
 	enableWorker, singleNode bool
 }
 
 // Shared worker cli flags
 type WorkerOptions struct {
-	CloudProvider    bool
-	LogLevels        LogLevels
-	CriSocket        string
-	KubeletExtraArgs string
-	Labels           map[string]string
-	Taints           []string
-	TokenFile        string
-	TokenArg         string
-	WorkerProfile    string
-	IPTablesMode     string
+	CloudProvider            bool
+	LogLevels                LogLevels
+	CriSocket                string
+	KubeletExtraArgs         string
+	NodeNameTemplate         string
+	Labels                   map[string]string
+	Taints                   []string
+	ReconcileLabelsAndTaints bool
+	ReconcilePrefix          string
+	LabelsFromCloudMetadata  string
+	CloudMetadataSpotTaint   string
+	TokenFile                string
+	TokenArg                 string
+	CloudIdentityJoin        string
+	JoinCode                 string
+	JoinRequest              bool
+	JoinAPIURL               string
+	JoinAPICAFile            string
+	JoinAPIFingerprint       string
+	WorkerProfile            string
+	IPTablesMode             string
+	LogFormat                string
+	ComponentLogFiles        bool
+	ComponentLogForward      string
+	// The namespaces of a rootless worker, nil if it's not rootless.
+	Rootless *rootless.Environment
 }
 
 func (m ControllerMode) WorkloadsEnabled() bool {
@@ -106,6 +129,10 @@ func (o *ControllerOptions) Normalize() error {
 	}
 	o.DisableComponents = disabledComponents
 
+	if o.WorkerJoinTokenRotation < 0 {
+		return fmt.Errorf("invalid worker join token rotation %s: must not be negative", o.WorkerJoinTokenRotation)
+	}
+
 	return nil
 }
 
@@ -241,10 +268,24 @@ func GetWorkerFlags() *pflag.FlagSet {
 	flagset.StringVar(&workerOpts.WorkerProfile, "profile", "default", "worker profile to use on the node")
 	flagset.BoolVar(&workerOpts.CloudProvider, "enable-cloud-provider", false, "Whether or not to enable cloud provider support in kubelet")
 	flagset.StringVar(&workerOpts.TokenFile, "token-file", "", "Path to the file containing join-token.")
+	flagset.StringVar(&workerOpts.CloudIdentityJoin, "cloud-identity-join", "", "cloud provider whose signed instance identity is used to join the cluster instead of a join token (valid values: aws, gcp)")
+	flagset.StringVar(&workerOpts.JoinCode, "join-code", "", "join code created via 'k0s token create --format=short' that's exchanged for a join token via the k0s join API")
+	flagset.BoolVar(&workerOpts.JoinRequest, "join-request", false, "join by submitting a certificate signing request via the k0s join API and waiting for its approval, instead of using a join token")
+	flagset.StringVar(&workerOpts.JoinAPIURL, "join-api-url", "", "URL of the k0s join API that's used with --cloud-identity-join, --join-code or --join-request, e.g. https://controller:9443")
+	flagset.StringVar(&workerOpts.JoinAPICAFile, "join-api-ca-file", "", "path to the cluster's CA certificate that's used to verify the k0s join API with --cloud-identity-join, --join-code or --join-request")
+	flagset.StringVar(&workerOpts.JoinAPIFingerprint, "join-api-fingerprint", "", "fingerprint of the cluster's CA certificate that's used to verify the k0s join API with --join-code or --join-request, instead of --join-api-ca-file")
 	flagset.VarP((*logLevelsFlag)(&workerOpts.LogLevels), "logging", "l", "Logging Levels for the different components")
+	flagset.StringVar(&workerOpts.LogFormat, "log-format", "text", "format of the k0s log (valid values: text, json)")
+	flagset.BoolVar(&workerOpts.ComponentLogFiles, "component-log-files", false, "write the output of each component to rotated files in <rundir>/logs")
+	flagset.StringVar(&workerOpts.ComponentLogForward, "component-log-forward", "", "forward the output of the components to syslog, syslog+udp://host:port, syslog+tcp://host:port, or a ring of rotated files given as file:<path>")
 	flagset.Var((*cliflag.ConfigurationMap)(&workerOpts.Labels), "labels", "Node labels, list of key=value pairs")
 	flagset.StringSliceVarP(&workerOpts.Taints, "taints", "", []string{}, "Node taints, list of key=value:effect strings")
+	flagset.BoolVar(&workerOpts.ReconcileLabelsAndTaints, "reconcile-labels-and-taints", false, "reconcile the node's labels and taints with --labels and --taints on each start")
+	flagset.StringVar(&workerOpts.ReconcilePrefix, "reconcile-prefix", "", "key prefix of the labels and taints that are removed from the node if they're not given in --labels and --taints")
+	flagset.StringVar(&workerOpts.LabelsFromCloudMetadata, "labels-from-cloud-metadata", "", "cloud provider whose instance metadata is used to label the node with its region, zone, instance type and whether it's a spot instance (valid values: auto, aws, gcp, azure, openstack)")
+	flagset.StringVar(&workerOpts.CloudMetadataSpotTaint, "cloud-metadata-spot-taint", "", "effect of the taint that's added to spot instances detected via --labels-from-cloud-metadata (valid values: NoSchedule, PreferNoSchedule, NoExecute)")
 	flagset.StringVar(&workerOpts.KubeletExtraArgs, "kubelet-extra-args", "", "extra args for kubelet")
+	flagset.StringVar(&workerOpts.NodeNameTemplate, "node-name-template", "", "template for the node name that's bound at the node's first start, e.g. pool-{{.RandomID}} (fields: Hostname, MachineID, RandomID)")
 	flagset.StringVar(&workerOpts.IPTablesMode, "iptables-mode", "", "iptables mode (valid values: nft, legacy, auto). default: auto")
 	flagset.AddFlagSet(GetCriSocketFlag())
 
@@ -284,7 +325,14 @@ func GetControllerFlags(controllerOpts *ControllerOptions) *pflag.FlagSet {
 	flagset.BoolVar(&controllerOpts.EnableDynamicConfig, "enable-dynamic-config", false, "enable cluster-wide dynamic config based on custom resource")
 	flagset.BoolVar(&controllerOpts.EnableMetricsScraper, "enable-metrics-scraper", false, "enable scraping metrics from the controller components (kube-scheduler, kube-controller-manager)")
 	flagset.StringVar(&controllerOpts.KubeControllerManagerExtraArgs, "kube-controller-manager-extra-args", "", "extra args for kube-controller-manager")
+	flagset.BoolVar(&controllerOpts.VerifyKubeletServingCSRs, "verify-kubelet-serving-csrs", false, "verify the node identity and the SANs of kubelet serving CSRs against the Node objects before approving them")
+	flagset.DurationVar(&controllerOpts.WorkerJoinTokenRotation, "worker-join-token-rotation", 0, "the validity of the worker join tokens that are rotated automatically and published in the kube-system/k0s-worker-join-token secret (0 disables the rotation)")
+	flagset.StringVar(&controllerOpts.JoinTokenSocketGroup, "join-token-socket-group", "", "group whose members may create, list and invalidate join tokens via the status socket, in addition to root")
 	flagset.BoolVar(&controllerOpts.InitOnly, "init-only", false, "only initialize controller and exit")
+	flagset.Bool("strict-config", false, "reject unknown fields in the config file")
+	flagset.Bool("expand-config", false, "substitute ${ENV_VAR} and ${file:/path} references in the config file")
+	flagset.DurationVar(&controllerOpts.AutopilotControlNodeStaleTimeout, "autopilot-controlnode-stale-timeout", 5*time.Minute, "the time after which autopilot marks controllers as stale that didn't renew their lease (0 disables the staleness detection)")
+	flagset.DurationVar(&controllerOpts.AutopilotControlNodeRemovalAge, "autopilot-controlnode-removal-age", 0, "the time after which autopilot removes the ControlNodes of stale controllers (0 keeps them)")
 	return flagset
 }
 
@@ -293,7 +341,7 @@ func GetControllerFlags(controllerOpts *ControllerOptions) *pflag.FlagSet {
 // it in multiple places
 func FileInputFlag() *pflag.FlagSet {
 	flagset := &pflag.FlagSet{}
-	descString := fmt.Sprintf("config file, use '-' to read the config from stdin (default %q)", constant.K0sConfigPathDefault)
+	descString := fmt.Sprintf("config file or directory of config files to be merged, use '-' to read the config from stdin (default %q)", constant.K0sConfigPathDefault)
 	flagset.StringVarP(&CfgFile, "config", "c", "", descString)
 
 	return flagset
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+)
+
+// Feature describes a k0s feature that can be enabled or disabled via
+// spec.k0sFeatureGates. These are distinct from the Kubernetes feature gates
+// in spec.featureGates, which are passed on to the Kubernetes components.
+type Feature struct {
+	// Name is the name of the feature gate.
+	Name string
+	// Stage is the maturity of the feature.
+	Stage v1beta1.K0sFeatureStage
+	// Default indicates whether the feature is enabled if there's no feature
+	// gate for it. GA features are always enabled.
+	Default bool
+	// Description briefly describes the feature.
+	Description string
+}
+
+// featureRegistry holds all features known to k0s, by name.
+type featureRegistry map[string]Feature
+
+// features is the registry of all k0s features. Features are registered via
+// [RegisterFeature].
+var features = make(featureRegistry)
+
+// RootlessWorkerFeature gates running workers as unprivileged users in user
+// namespaces, see k0s worker --rootless.
+var RootlessWorkerFeature = RegisterFeature(Feature{
+	Name:        "RootlessWorker",
+	Stage:       v1beta1.K0sFeatureAlpha,
+	Description: "Run workers as unprivileged users in user namespaces",
+})
+
+// RegisterFeature adds a feature to the registry and returns it. Panics if
+// there's already a feature with the same name.
+func RegisterFeature(feature Feature) Feature {
+	features.register(feature)
+	return feature
+}
+
+// Features returns all registered features, sorted by name.
+func Features() []Feature {
+	return features.list()
+}
+
+// FeatureEnabled returns whether the registered feature with the given name is
+// enabled by the given feature gates. Panics if there's no such feature.
+func FeatureEnabled(gates v1beta1.K0sFeatureGates, name string) bool {
+	return features.enabled(gates, name)
+}
+
+// ValidateK0sFeatureGates checks that all feature gates refer to registered
+// features, and that generally available features aren't disabled.
+func ValidateK0sFeatureGates(gates v1beta1.K0sFeatureGates) []error {
+	return features.validate(gates)
+}
+
+// K0sFeatureGateStatuses reports whether each of the registered features is
+// enabled by the given feature gates.
+func K0sFeatureGateStatuses(gates v1beta1.K0sFeatureGates) []v1beta1.K0sFeatureGateStatus {
+	return features.statuses(gates)
+}
+
+func (r featureRegistry) register(feature Feature) {
+	if _, exists := r[feature.Name]; exists {
+		panic(fmt.Sprintf("feature %q registered twice", feature.Name))
+	}
+	r[feature.Name] = feature
+}
+
+func (r featureRegistry) list() []Feature {
+	return slices.SortedFunc(maps.Values(r), func(a, b Feature) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
+
+func (r featureRegistry) enabled(gates v1beta1.K0sFeatureGates, name string) bool {
+	feature, ok := r[name]
+	if !ok {
+		panic(fmt.Sprintf("unknown feature %q", name))
+	}
+	if feature.Stage == v1beta1.K0sFeatureGA {
+		return true
+	}
+	if enabled, found := gates.Lookup(name); found {
+		return enabled
+	}
+	return feature.Default
+}
+
+func (r featureRegistry) validate(gates v1beta1.K0sFeatureGates) []error {
+	var errs []error
+	for _, gate := range gates {
+		feature, ok := r[gate.Name]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("k0sFeatureGates: unknown feature %q", gate.Name))
+		case feature.Stage == v1beta1.K0sFeatureGA && !gate.Enabled:
+			errs = append(errs, fmt.Errorf("k0sFeatureGates: feature %q is generally available and can't be disabled", gate.Name))
+		}
+	}
+	return errs
+}
+
+func (r featureRegistry) statuses(gates v1beta1.K0sFeatureGates) []v1beta1.K0sFeatureGateStatus {
+	var statuses []v1beta1.K0sFeatureGateStatus
+	for _, feature := range r.list() {
+		statuses = append(statuses, v1beta1.K0sFeatureGateStatus{
+			Name:    feature.Name,
+			Stage:   feature.Stage,
+			Enabled: r.enabled(gates, feature.Name),
+		})
+	}
+	return statuses
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureRegistry(t *testing.T) {
+	registry := make(featureRegistry)
+	registry.register(Feature{Name: "Stable", Stage: v1beta1.K0sFeatureGA})
+	registry.register(Feature{Name: "Experimental", Stage: v1beta1.K0sFeatureAlpha})
+	registry.register(Feature{Name: "Tested", Stage: v1beta1.K0sFeatureBeta, Default: true})
+
+	assert.Panics(t, func() { registry.register(Feature{Name: "Stable"}) })
+
+	t.Run("list", func(t *testing.T) {
+		var names []string
+		for _, feature := range registry.list() {
+			names = append(names, feature.Name)
+		}
+		assert.Equal(t, []string{"Experimental", "Stable", "Tested"}, names)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		assert.False(t, registry.enabled(nil, "Experimental"))
+		assert.True(t, registry.enabled(nil, "Tested"))
+		assert.True(t, registry.enabled(nil, "Stable"))
+
+		gates := v1beta1.K0sFeatureGates{
+			{Name: "Experimental", Enabled: true},
+			{Name: "Tested", Enabled: false},
+		}
+		assert.True(t, registry.enabled(gates, "Experimental"))
+		assert.False(t, registry.enabled(gates, "Tested"))
+
+		assert.Panics(t, func() { registry.enabled(gates, "Unknown") })
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		assert.Empty(t, registry.validate(v1beta1.K0sFeatureGates{
+			{Name: "Experimental", Enabled: true},
+			{Name: "Stable", Enabled: true},
+		}))
+
+		errs := registry.validate(v1beta1.K0sFeatureGates{
+			{Name: "Unknown", Enabled: true},
+			{Name: "Stable", Enabled: false},
+		})
+		if assert.Len(t, errs, 2) {
+			assert.ErrorContains(t, errs[0], `unknown feature "Unknown"`)
+			assert.ErrorContains(t, errs[1], `feature "Stable" is generally available and can't be disabled`)
+		}
+	})
+
+	t.Run("statuses", func(t *testing.T) {
+		assert.Equal(t, []v1beta1.K0sFeatureGateStatus{
+			{Name: "Experimental", Stage: v1beta1.K0sFeatureAlpha, Enabled: true},
+			{Name: "Stable", Stage: v1beta1.K0sFeatureGA, Enabled: true},
+			{Name: "Tested", Stage: v1beta1.K0sFeatureBeta, Enabled: true},
+		}, registry.statuses(v1beta1.K0sFeatureGates{{Name: "Experimental", Enabled: true}}))
+	})
+}
+
+func TestRootlessWorkerFeature(t *testing.T) {
+	assert.Equal(t, v1beta1.K0sFeatureAlpha, RootlessWorkerFeature.Stage)
+	assert.False(t, FeatureEnabled(nil, RootlessWorkerFeature.Name))
+	assert.True(t, FeatureEnabled(v1beta1.K0sFeatureGates{{Name: "RootlessWorker", Enabled: true}}, RootlessWorkerFeature.Name))
+	assert.Empty(t, ValidateK0sFeatureGates(v1beta1.K0sFeatureGates{{Name: "RootlessWorker", Enabled: true}}))
+}
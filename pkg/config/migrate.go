@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	"sigs.k8s.io/yaml"
+)
+
+// MigrationChange describes a change that has been made to a configuration
+// while migrating it.
+type MigrationChange struct {
+	// Path is the path of the changed field.
+	Path string
+	// Message describes the change and its effect on the cluster.
+	Message string
+}
+
+func (c MigrationChange) String() string {
+	return c.Path + ": " + c.Message
+}
+
+// migration replaces a deprecated configuration field.
+type migration struct {
+	// path is the path of the parent of the deprecated field.
+	path []string
+	// migrate changes the parent of the deprecated field in place.
+	migrate func(parent map[string]any) []MigrationChange
+}
+
+// migrations lists the replacements for all deprecated fields.
+var migrations = []migration{
+	{[]string{"spec", "extensions"}, func(extensions map[string]any) []MigrationChange {
+		if _, ok := extensions["storage"]; !ok {
+			return nil
+		}
+		delete(extensions, "storage")
+		return []MigrationChange{{"spec.extensions.storage", "Removed, it is ignored since k0s 1.31. Install OpenEBS as a Helm chart extension instead, see https://docs.k0sproject.io/stable/examples/openebs/"}}
+	}},
+
+	{[]string{"spec", "network", "kuberouter"}, func(kubeRouter map[string]any) []MigrationChange {
+		hairpinMode, ok := kubeRouter["hairpinMode"]
+		if !ok {
+			return nil
+		}
+		delete(kubeRouter, "hairpinMode")
+		if _, ok := kubeRouter["hairpin"]; ok {
+			return []MigrationChange{{"spec.network.kuberouter.hairpinMode", "Removed, it was overridden by hairpin"}}
+		}
+		if hairpinMode == true {
+			kubeRouter["hairpin"] = string(v1beta1.HairpinEnabled)
+			return []MigrationChange{{"spec.network.kuberouter.hairpinMode", "Replaced by hairpin: " + string(v1beta1.HairpinEnabled)}}
+		}
+		return []MigrationChange{{"spec.network.kuberouter.hairpinMode", "Removed, hairpin defaults to " + string(v1beta1.HairpinEnabled)}}
+	}},
+
+	{[]string{"spec", "network", "kuberouter"}, func(kubeRouter map[string]any) []MigrationChange {
+		var changes []MigrationChange
+		for _, replacement := range []struct{ field, arg string }{
+			{"peerRouterASNs", "peer-router-asns"},
+			{"peerRouterIPs", "peer-router-ips"},
+		} {
+			field, arg := replacement.field, replacement.arg
+			value, ok := kubeRouter[field]
+			if !ok {
+				continue
+			}
+			delete(kubeRouter, field)
+			path := "spec.network.kuberouter." + field
+
+			if value == nil || value == "" {
+				changes = append(changes, MigrationChange{path, "Removed, it was empty"})
+				continue
+			}
+
+			extraArgs, _ := kubeRouter["extraArgs"].(map[string]any)
+			if extraArgs == nil {
+				extraArgs = make(map[string]any)
+				kubeRouter["extraArgs"] = extraArgs
+			}
+			if _, ok := extraArgs[arg]; ok {
+				changes = append(changes, MigrationChange{path, "Removed, it was overridden by extraArgs." + arg})
+				continue
+			}
+			extraArgs[arg] = fmt.Sprint(value)
+			changes = append(changes, MigrationChange{path, "Replaced by extraArgs." + arg})
+		}
+		return changes
+	}},
+
+	{[]string{"spec", "network", "calico"}, func(calico map[string]any) []MigrationChange {
+		if calico["mode"] != string(v1beta1.CalicoModeIPIP) {
+			return nil
+		}
+		calico["mode"] = string(v1beta1.CalicoModeBIRD)
+		return []MigrationChange{{"spec.network.calico.mode", "Replaced " + string(v1beta1.CalicoModeIPIP) + " by its new name " + string(v1beta1.CalicoModeBIRD) + ", the behavior is unchanged"}}
+	}},
+}
+
+// MigrateConfig replaces the deprecated fields in the given ClusterConfig YAML
+// with their current counterparts. Returns the migrated configuration along
+// with the changes that have been made. Comments and the order of fields
+// aren't preserved.
+func MigrateConfig(data []byte) ([]byte, []MigrationChange, error) {
+	var obj map[string]any
+	if err := yaml.Unmarshal(data, &obj); err != nil {
+		return nil, nil, err
+	}
+
+	var changes []MigrationChange
+	for _, migration := range migrations {
+		if parent := nestedMap(obj, migration.path); parent != nil {
+			changes = append(changes, migration.migrate(parent)...)
+		}
+	}
+
+	if len(changes) == 0 {
+		return data, nil, nil
+	}
+
+	migrated, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	return migrated, changes, nil
+}
+
+// nestedMap returns the map at the given path, or nil if there's none.
+func nestedMap(obj map[string]any, path []string) map[string]any {
+	for _, key := range path {
+		var ok bool
+		if obj, ok = obj[key].(map[string]any); !ok {
+			return nil
+		}
+	}
+	return obj
+}
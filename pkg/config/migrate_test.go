@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestMigrateConfig(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		input    string
+		expected string
+		changes  []string
+	}{
+		{
+			"nothing_to_migrate",
+			"spec:\n  network:\n    provider: calico\n",
+			"spec:\n  network:\n    provider: calico\n",
+			nil,
+		},
+		{
+			"extensions_storage",
+			"spec:\n  extensions:\n    storage:\n      type: openebs_local_storage\n",
+			"spec:\n  extensions: {}\n",
+			[]string{"spec.extensions.storage"},
+		},
+		{
+			"hairpin_mode",
+			"spec:\n  network:\n    kuberouter:\n      hairpinMode: true\n",
+			"spec:\n  network:\n    kuberouter:\n      hairpin: Enabled\n",
+			[]string{"spec.network.kuberouter.hairpinMode"},
+		},
+		{
+			"hairpin_mode_overridden",
+			"spec:\n  network:\n    kuberouter:\n      hairpinMode: true\n      hairpin: Disabled\n",
+			"spec:\n  network:\n    kuberouter:\n      hairpin: Disabled\n",
+			[]string{"spec.network.kuberouter.hairpinMode"},
+		},
+		{
+			"peer_routers",
+			"spec:\n  network:\n    kuberouter:\n      peerRouterASNs: 65000,65001\n      peerRouterIPs: 10.0.0.1,10.0.0.2\n      extraArgs:\n        peer-router-ips: 10.0.0.3\n",
+			"spec:\n  network:\n    kuberouter:\n      extraArgs:\n        peer-router-asns: 65000,65001\n        peer-router-ips: 10.0.0.3\n",
+			[]string{"spec.network.kuberouter.peerRouterASNs", "spec.network.kuberouter.peerRouterIPs"},
+		},
+		{
+			"calico_ipip",
+			"spec:\n  network:\n    calico:\n      mode: ipip\n",
+			"spec:\n  network:\n    calico:\n      mode: bird\n",
+			[]string{"spec.network.calico.mode"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			migrated, changes, err := MigrateConfig([]byte(test.input))
+			require.NoError(t, err)
+
+			var expected, actual any
+			require.NoError(t, yaml.Unmarshal([]byte(test.expected), &expected))
+			require.NoError(t, yaml.Unmarshal(migrated, &actual))
+			assert.Equal(t, expected, actual)
+
+			var paths []string
+			for _, change := range changes {
+				assert.NotEmpty(t, change.Message)
+				paths = append(paths, change.Path)
+			}
+			assert.Equal(t, test.changes, paths)
+		})
+	}
+}
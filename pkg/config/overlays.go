@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ReadConfig reads the configuration at the given path. If the path is a
+// directory, all of its YAML and JSON files (*.yaml, *.yml and *.json, except
+// hidden ones) are merged in the lexical order of their names. This allows to split the
+// configuration into a base and several overrides, e.g. 00-base.yaml,
+// 10-site.yaml and 20-node.yaml.
+//
+// The files are merged with the semantics of a JSON merge patch (RFC 7386):
+// Mappings are merged recursively, lists and scalar values replace the
+// previous ones as a whole, and null values remove the previous ones.
+func ReadConfig(path string) ([]byte, error) {
+	if info, err := os.Stat(path); err != nil {
+		return nil, err
+	} else if !info.IsDir() {
+		return os.ReadFile(path)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := filepath.Ext(name)
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !slices.Contains([]string{".yaml", ".yml", ".json"}, ext) {
+			continue
+		}
+		files = append(files, name)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no configuration files in %s", path)
+	}
+	slices.Sort(files)
+
+	var merged map[string]any
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(path, name))
+		if err != nil {
+			return nil, err
+		}
+		var overlay map[string]any
+		if err := yaml.Unmarshal(data, &overlay); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		merged = mergeConfig(merged, overlay)
+	}
+
+	return yaml.Marshal(merged)
+}
+
+// mergeConfig merges overlay into base, which is modified in place.
+func mergeConfig(base, overlay map[string]any) map[string]any {
+	if base == nil {
+		base = make(map[string]any, len(overlay))
+	}
+	for key, value := range overlay {
+		if value == nil {
+			delete(base, key)
+			continue
+		}
+		overlayMap, isMap := value.(map[string]any)
+		if baseMap, baseIsMap := base[key].(map[string]any); isMap && baseIsMap {
+			base[key] = mergeConfig(baseMap, overlayMap)
+		} else if isMap {
+			// Remove nulls from nested mappings, too.
+			base[key] = mergeConfig(nil, overlayMap)
+		} else {
+			base[key] = value
+		}
+	}
+	return base
+}
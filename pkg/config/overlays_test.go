@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestReadConfig(t *testing.T) {
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "k0s.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("spec: {}\n"), 0644))
+
+		data, err := ReadConfig(path)
+		require.NoError(t, err)
+		assert.Equal(t, "spec: {}\n", string(data))
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		dir := t.TempDir()
+		for name, content := range map[string]string{
+			"00-base.yaml": `
+apiVersion: k0s.k0sproject.io/v1beta1
+kind: ClusterConfig
+spec:
+  api:
+    sans: [a, b]
+    extraArgs:
+      foo: bar
+      baz: qux
+  network:
+    provider: calico
+`,
+			"10-site.yml": `
+spec:
+  api:
+    sans: [c]
+    extraArgs:
+      baz: null
+  network:
+    provider: kuberouter
+`,
+			"20-node.yaml": `
+spec:
+  api:
+    address: 10.0.0.1
+`,
+			"30-generated.json": `{"spec": {"network": {"podCIDR": "10.245.0.0/16"}}}`,
+			".hidden.yaml":      "spec: null",
+			"README.md":         "spec: null",
+		} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+		}
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "ignored.yaml"), 0755))
+
+		data, err := ReadConfig(dir)
+		require.NoError(t, err)
+
+		var merged map[string]any
+		require.NoError(t, yaml.Unmarshal(data, &merged))
+		assert.Equal(t, map[string]any{
+			"apiVersion": "k0s.k0sproject.io/v1beta1",
+			"kind":       "ClusterConfig",
+			"spec": map[string]any{
+				"api": map[string]any{
+					"address":   "10.0.0.1",
+					"sans":      []any{"c"},
+					"extraArgs": map[string]any{"foo": "bar"},
+				},
+				"network": map[string]any{
+					"provider": "kuberouter",
+					"podCIDR":  "10.245.0.0/16",
+				},
+			},
+		}, merged)
+	})
+
+	t.Run("empty_directory", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := ReadConfig(dir)
+		assert.ErrorContains(t, err, "no configuration files in "+dir)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		_, err := ReadConfig(filepath.Join(t.TempDir(), "k0s.yaml"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/static"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	"sigs.k8s.io/yaml"
+)
+
+// clusterConfigSchema loads the structural schema of the ClusterConfig CRD.
+var clusterConfigSchema = sync.OnceValues(func() (*structuralschema.Structural, error) {
+	data, err := fs.ReadFile(static.CRDs, "k0s/k0s.k0sproject.io_clusterconfigs.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := yaml.Unmarshal(data, &crd); err != nil {
+		return nil, fmt.Errorf("failed to parse ClusterConfig CRD: %w", err)
+	}
+
+	for _, version := range crd.Spec.Versions {
+		if version.Name != v1beta1.GroupVersion.Version || version.Schema == nil {
+			continue
+		}
+
+		var props apiextensions.JSONSchemaProps
+		if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(version.Schema.OpenAPIV3Schema, &props, nil); err != nil {
+			return nil, err
+		}
+		return structuralschema.NewStructural(&props)
+	}
+
+	return nil, fmt.Errorf("ClusterConfig CRD has no schema for version %s", v1beta1.GroupVersion.Version)
+})
+
+// CheckUnknownFields returns an error if the given ClusterConfig YAML contains
+// fields that aren't part of the ClusterConfig schema, such as misspelled
+// ones. The regular parsing silently ignores some of them.
+func CheckUnknownFields(data []byte) error {
+	schema, err := clusterConfigSchema()
+	if err != nil {
+		return fmt.Errorf("failed to load ClusterConfig schema: %w", err)
+	}
+
+	var obj map[string]any
+	if err := yaml.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	unknown := pruning.PruneWithOptions(obj, schema, true, structuralschema.UnknownFieldPathOptions{
+		TrackUnknownFieldPaths: true,
+	})
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown fields: %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// referencePattern matches escaped dollar signs and references.
+	referencePattern = regexp.MustCompile(`\$\$|\$\{([^}]*)\}`)
+	envVarPattern    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// ExpandReferences replaces the references in all string values of the given
+// YAML configuration. ${NAME} is replaced by the value of the environment
+// variable NAME, and ${file:/path} by the contents of the file at /path,
+// without trailing line breaks. $$ is replaced by a literal dollar sign.
+// Referencing unset environment variables or missing files is an error.
+//
+// The values are substituted after parsing the YAML, so that the referenced
+// values don't need to be quoted.
+func ExpandReferences(data []byte) ([]byte, error) {
+	return expandReferences(data, os.LookupEnv, os.ReadFile)
+}
+
+func expandReferences(data []byte, lookupEnv func(string) (string, bool), readFile func(string) ([]byte, error)) ([]byte, error) {
+	var obj any
+	if err := yaml.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	expand := func(value string) (string, error) {
+		var err error
+		expanded := referencePattern.ReplaceAllStringFunc(value, func(match string) string {
+			if err != nil {
+				return ""
+			}
+			if match == "$$" {
+				return "$"
+			}
+
+			ref := match[2 : len(match)-1]
+			if path, isFile := strings.CutPrefix(ref, "file:"); isFile {
+				var content []byte
+				if content, err = readFile(path); err != nil {
+					err = fmt.Errorf("failed to read referenced file: %w", err)
+					return ""
+				}
+				return strings.TrimRight(string(content), "\r\n")
+			}
+
+			if !envVarPattern.MatchString(ref) {
+				err = fmt.Errorf("invalid reference %q", match)
+				return ""
+			}
+			value, ok := lookupEnv(ref)
+			if !ok {
+				err = fmt.Errorf("environment variable %s is not set", ref)
+				return ""
+			}
+			return value
+		})
+		return expanded, err
+	}
+
+	obj, err := expandValues(obj, "", expand)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(obj)
+}
+
+// expandValues applies expand to all string values in obj.
+func expandValues(obj any, path string, expand func(string) (string, error)) (any, error) {
+	switch obj := obj.(type) {
+	case string:
+		expanded, err := expand(obj)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", strings.TrimPrefix(path, "."), err)
+		}
+		return expanded, nil
+
+	case map[string]any:
+		for key, value := range obj {
+			expanded, err := expandValues(value, path+"."+key, expand)
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = expanded
+		}
+
+	case []any:
+		for i, value := range obj {
+			expanded, err := expandValues(value, fmt.Sprintf("%s[%d]", path, i), expand)
+			if err != nil {
+				return nil, err
+			}
+			obj[i] = expanded
+		}
+	}
+
+	return obj, nil
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestExpandReferences(t *testing.T) {
+	env := map[string]string{"DSN": "mysql://user:p@ss@tcp(db:3306)/k0s", "EMPTY": ""}
+	files := map[string]string{"/etc/k0s/secret": "s3cr3t\n"}
+
+	lookupEnv := func(name string) (string, bool) { value, ok := env[name]; return value, ok }
+	readFile := func(path string) ([]byte, error) {
+		if content, ok := files[path]; ok {
+			return []byte(content), nil
+		}
+		return nil, &fs.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	}
+
+	expand := func(t *testing.T, config string) (map[string]any, error) {
+		data, err := expandReferences([]byte(config), lookupEnv, readFile)
+		if err != nil {
+			return nil, err
+		}
+		var expanded map[string]any
+		require.NoError(t, yaml.Unmarshal(data, &expanded))
+		return expanded, nil
+	}
+
+	t.Run("substitutes", func(t *testing.T) {
+		expanded, err := expand(t, `
+spec:
+  storage:
+    kine:
+      dataSource: ${DSN}
+  api:
+    sans: ["${file:/etc/k0s/secret}", "a${EMPTY}b", "$${DSN}", "$HOME"]
+    port: 6443
+`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"spec": map[string]any{
+				"storage": map[string]any{"kine": map[string]any{"dataSource": "mysql://user:p@ss@tcp(db:3306)/k0s"}},
+				"api": map[string]any{
+					"sans": []any{"s3cr3t", "ab", "${DSN}", "$HOME"},
+					"port": float64(6443),
+				},
+			},
+		}, expanded)
+	})
+
+	t.Run("unset_env_var", func(t *testing.T) {
+		_, err := expand(t, "spec:\n  storage:\n    kine:\n      dataSource: ${UNSET}\n")
+		assert.ErrorContains(t, err, "spec.storage.kine.dataSource: environment variable UNSET is not set")
+	})
+
+	t.Run("missing_file", func(t *testing.T) {
+		_, err := expand(t, "spec:\n  api:\n    sans: [\"${file:/nope}\"]\n")
+		assert.ErrorContains(t, err, "spec.api.sans[0]: failed to read referenced file: ")
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("invalid_reference", func(t *testing.T) {
+		_, err := expand(t, "spec:\n  api:\n    address: ${not valid}\n")
+		assert.ErrorContains(t, err, `spec.api.address: invalid reference "${not valid}"`)
+	})
+}
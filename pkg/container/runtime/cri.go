@@ -5,6 +5,7 @@ package runtime
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 
@@ -36,6 +37,20 @@ func (cri *CRIRuntime) Ping(ctx context.Context) error {
 	return err
 }
 
+func (cri *CRIRuntime) RuntimeName(ctx context.Context) (string, error) {
+	client, conn, err := cri.newRuntimeClient()
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	r, err := client.Version(ctx, &pb.VersionRequest{})
+	if err != nil {
+		return "", err
+	}
+	return r.GetRuntimeName(), nil
+}
+
 func (cri *CRIRuntime) ListContainers(ctx context.Context) ([]string, error) {
 	client, conn, err := cri.newRuntimeClient()
 	if err != nil {
@@ -93,6 +108,58 @@ func (cri *CRIRuntime) StopContainer(ctx context.Context, id string) error {
 	return nil
 }
 
+func (cri *CRIRuntime) PodNetworkNamespaces(ctx context.Context) ([]string, error) {
+	client, conn, err := cri.newRuntimeClient()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	r, err := client.ListPodSandbox(ctx, &pb.ListPodSandboxRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, p := range r.GetItems() {
+		status, err := client.PodSandboxStatus(ctx, &pb.PodSandboxStatusRequest{PodSandboxId: p.Id, Verbose: true})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get status of pod sandbox %s: %w", p.Id, err)
+		}
+		if path := sandboxNetworkNamespace(status.GetInfo()); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// sandboxNetworkNamespace returns the path of the network namespace from the
+// verbose info of a pod sandbox status. Containerd exposes the OCI runtime
+// spec of the sandbox, which contains the network namespace. Pods in the host
+// network don't have a path.
+func sandboxNetworkNamespace(info map[string]string) string {
+	var sandboxInfo struct {
+		RuntimeSpec struct {
+			Linux struct {
+				Namespaces []struct {
+					Type string `json:"type"`
+					Path string `json:"path"`
+				} `json:"namespaces"`
+			} `json:"linux"`
+		} `json:"runtimeSpec"`
+	}
+	if err := json.Unmarshal([]byte(info["info"]), &sandboxInfo); err != nil {
+		return ""
+	}
+
+	for _, ns := range sandboxInfo.RuntimeSpec.Linux.Namespaces {
+		if ns.Type == "network" {
+			return ns.Path
+		}
+	}
+	return ""
+}
+
 func (cri *CRIRuntime) newRuntimeClient() (pb.RuntimeServiceClient, io.Closer, error) {
 	conn, err := grpc.NewClient(cri.target, cri.dialOptions...)
 	if err != nil {
@@ -10,9 +10,14 @@ import (
 
 type ContainerRuntime interface {
 	Ping(ctx context.Context) error
+	// RuntimeName returns the name of the container runtime, e.g. containerd.
+	RuntimeName(ctx context.Context) (string, error)
 	ListContainers(ctx context.Context) ([]string, error)
 	RemoveContainer(ctx context.Context, id string) error
 	StopContainer(ctx context.Context, id string) error
+	// PodNetworkNamespaces returns the paths of the network namespaces of all
+	// pod sandboxes, as far as the container runtime exposes them.
+	PodNetworkNamespaces(ctx context.Context) ([]string, error)
 }
 
 func NewContainerRuntime(runtimeEndpoint *url.URL) ContainerRuntime {
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package encryptionconfig rotates the keys of the encryption at rest
+// configuration of the Kubernetes API server.
+package encryptionconfig
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	apiserverv1 "k8s.io/apiserver/pkg/apis/apiserver/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// keySize is the size of the generated keys in bytes. It's valid for all of
+// the AES-CBC, AES-GCM and secretbox providers.
+const keySize = 32
+
+// Unmarshal parses an EncryptionConfiguration.
+func Unmarshal(data []byte) (*apiserverv1.EncryptionConfiguration, error) {
+	var config apiserverv1.EncryptionConfiguration
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, err
+	}
+	if config.Kind != "EncryptionConfiguration" {
+		return nil, fmt.Errorf("unexpected kind %q", config.Kind)
+	}
+	return &config, nil
+}
+
+// Marshal serializes an EncryptionConfiguration.
+func Marshal(config *apiserverv1.EncryptionConfiguration) ([]byte, error) {
+	return yaml.Marshal(config)
+}
+
+// AddKey generates a new key with the given name and appends it to the keys of
+// each provider that's used to encrypt resources, i.e. the first provider of
+// each resource configuration, if it's an AES-CBC, AES-GCM or secretbox
+// provider. As the API server encrypts with the first key only, the new key is
+// used for decryption only, until it's promoted.
+func AddKey(config *apiserverv1.EncryptionConfiguration, name string) error {
+	return addKey(config, name, rand.Reader)
+}
+
+func addKey(config *apiserverv1.EncryptionConfiguration, name string, random io.Reader) error {
+	keyLists := encryptingKeyLists(config)
+	if len(keyLists) == 0 {
+		return errors.New("no resources are encrypted with an AES-CBC, AES-GCM or secretbox provider")
+	}
+
+	secret := make([]byte, keySize)
+	if _, err := io.ReadFull(random, secret); err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	for _, keys := range keyLists {
+		if slices.ContainsFunc(*keys, func(key apiserverv1.Key) bool { return key.Name == name }) {
+			return fmt.Errorf("key %q already exists", name)
+		}
+		*keys = append(*keys, apiserverv1.Key{
+			Name:   name,
+			Secret: base64.StdEncoding.EncodeToString(secret),
+		})
+	}
+	return nil
+}
+
+// PromoteLatestKeys moves the last key of each provider that's used to encrypt
+// resources to the front, so that the API server encrypts with it. Returns the
+// names of the promoted keys.
+func PromoteLatestKeys(config *apiserverv1.EncryptionConfiguration) []string {
+	var promoted []string
+	for _, keys := range encryptingKeyLists(config) {
+		last := len(*keys) - 1
+		if last < 1 {
+			continue
+		}
+		latest := (*keys)[last]
+		*keys = slices.Insert((*keys)[:last], 0, latest)
+		promoted = append(promoted, latest.Name)
+	}
+	return promoted
+}
+
+// PruneKeys removes all but the first key of each provider that's used to
+// encrypt resources. Returns the names of the removed keys.
+func PruneKeys(config *apiserverv1.EncryptionConfiguration) []string {
+	var removed []string
+	for _, keys := range encryptingKeyLists(config) {
+		if len(*keys) < 2 {
+			continue
+		}
+		for _, key := range (*keys)[1:] {
+			removed = append(removed, key.Name)
+		}
+		*keys = (*keys)[:1]
+	}
+	return removed
+}
+
+// EncryptedResources returns the resources that are encrypted by a provider
+// whose keys are rotated.
+func EncryptedResources(config *apiserverv1.EncryptionConfiguration) []string {
+	var resources []string
+	for _, resource := range config.Resources {
+		if len(resource.Providers) > 0 && providerKeys(&resource.Providers[0]) != nil {
+			resources = append(resources, resource.Resources...)
+		}
+	}
+	return resources
+}
+
+func encryptingKeyLists(config *apiserverv1.EncryptionConfiguration) []*[]apiserverv1.Key {
+	var keyLists []*[]apiserverv1.Key
+	for i := range config.Resources {
+		if providers := config.Resources[i].Providers; len(providers) > 0 {
+			if keys := providerKeys(&providers[0]); keys != nil {
+				keyLists = append(keyLists, keys)
+			}
+		}
+	}
+	return keyLists
+}
+
+func providerKeys(provider *apiserverv1.ProviderConfiguration) *[]apiserverv1.Key {
+	switch {
+	case provider.AESCBC != nil:
+		return &provider.AESCBC.Keys
+	case provider.AESGCM != nil:
+		return &provider.AESGCM.Keys
+	case provider.Secretbox != nil:
+		return &provider.Secretbox.Keys
+	default:
+		return nil
+	}
+}
+
+// reloadTimestampMetric is the API server metric that records when it last
+// reloaded the encryption configuration, labeled by the reload's status.
+const reloadTimestampMetric = "apiserver_encryption_config_controller_automatic_reload_last_timestamp_seconds"
+
+// ReloadTimestamps extracts the times of the last successful and failed
+// reloads of the encryption configuration from the API server's metrics, in
+// seconds since the epoch. They're zero if there was no such reload.
+func ReloadTimestamps(metrics []byte) (success, failure float64, _ error) {
+	lines := bufio.NewScanner(bytes.NewReader(metrics))
+	for lines.Scan() {
+		line := lines.Text()
+		if !strings.HasPrefix(line, reloadTimestampMetric+"{") {
+			continue
+		}
+		labels, value, ok := strings.Cut(strings.TrimPrefix(line, reloadTimestampMetric), " ")
+		if !ok {
+			continue
+		}
+		timestamp, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid value for %s: %w", reloadTimestampMetric, err)
+		}
+		switch {
+		case strings.Contains(labels, `status="success"`):
+			success = max(success, timestamp)
+		case strings.Contains(labels, `status="failure"`):
+			failure = max(failure, timestamp)
+		}
+	}
+	return success, failure, lines.Err()
+}
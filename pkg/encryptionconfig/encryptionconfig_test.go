@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package encryptionconfig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiserverv1 "k8s.io/apiserver/pkg/apis/apiserver/v1"
+)
+
+const testConfig = `
+apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+  - resources: [secrets, configmaps]
+    providers:
+      - secretbox:
+          keys:
+            - name: key1
+              secret: c2VjcmV0IGlzIHNlY3VyZSwgb3IgaXMgaXQ/Cg==
+      - identity: {}
+  - resources: [events]
+    providers:
+      - identity: {}
+`
+
+func TestKeyRotation(t *testing.T) {
+	config, err := Unmarshal([]byte(testConfig))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"secrets", "configmaps"}, EncryptedResources(config))
+
+	assert.Empty(t, PromoteLatestKeys(config), "single key")
+	assert.Empty(t, PruneKeys(config), "single key")
+
+	random := bytes.NewReader(bytes.Repeat([]byte{'k'}, keySize))
+	require.NoError(t, addKey(config, "key2", random))
+	assert.Equal(t, []apiserverv1.Key{
+		{Name: "key1", Secret: "c2VjcmV0IGlzIHNlY3VyZSwgb3IgaXMgaXQ/Cg=="},
+		{Name: "key2", Secret: "a2tra2tra2tra2tra2tra2tra2tra2tra2tra2tra2s="},
+	}, config.Resources[0].Providers[0].Secretbox.Keys)
+	assert.ErrorContains(t, AddKey(config, "key2"), `key "key2" already exists`)
+
+	assert.Equal(t, []string{"key2"}, PromoteLatestKeys(config))
+	assert.Equal(t, []string{"key2", "key1"}, keyNames(config))
+
+	assert.Equal(t, []string{"key1"}, PruneKeys(config))
+	assert.Equal(t, []string{"key2"}, keyNames(config))
+
+	data, err := Marshal(config)
+	require.NoError(t, err)
+	roundTripped, err := Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, config, roundTripped)
+}
+
+func TestAddKey_NoEncryptingProvider(t *testing.T) {
+	config := &apiserverv1.EncryptionConfiguration{
+		Resources: []apiserverv1.ResourceConfiguration{{
+			Resources: []string{"secrets"},
+			Providers: []apiserverv1.ProviderConfiguration{{Identity: &apiserverv1.IdentityConfiguration{}}},
+		}},
+	}
+	assert.ErrorContains(t, AddKey(config, "key"), "no resources are encrypted")
+	assert.Empty(t, EncryptedResources(config))
+}
+
+func TestReloadTimestamps(t *testing.T) {
+	metrics := []byte(`# HELP apiserver_encryption_config_controller_automatic_reload_last_timestamp_seconds [ALPHA] Timestamp of the last successful or failed automatic reload of encryption configuration split by apiserver identity.
+# TYPE apiserver_encryption_config_controller_automatic_reload_last_timestamp_seconds gauge
+apiserver_encryption_config_controller_automatic_reload_last_timestamp_seconds{apiserver_id_hash="sha256:abc",status="failure"} 1.7e+09
+apiserver_encryption_config_controller_automatic_reload_last_timestamp_seconds{apiserver_id_hash="sha256:abc",status="success"} 1.75e+09
+apiserver_request_total{code="200"} 42
+`)
+	success, failure, err := ReloadTimestamps(metrics)
+	require.NoError(t, err)
+	assert.Equal(t, 1.75e+09, success)
+	assert.Equal(t, 1.7e+09, failure)
+
+	success, failure, err = ReloadTimestamps(nil)
+	require.NoError(t, err)
+	assert.Zero(t, success)
+	assert.Zero(t, failure)
+}
+
+func keyNames(config *apiserverv1.EncryptionConfiguration) (names []string) {
+	for _, key := range config.Resources[0].Providers[0].Secretbox.Keys {
+		names = append(names, key.Name)
+	}
+	return names
+}
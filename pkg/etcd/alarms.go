@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/sirupsen/logrus"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// NoSpaceAlarm is the alarm that etcd raises once the size of a member's
+// database exceeds the backend quota. The cluster rejects all writes as long
+// as it's raised.
+var NoSpaceAlarm = etcdserverpb.AlarmType_NOSPACE.String()
+
+// MemberAlarms describes the alarms raised for the local etcd member.
+type MemberAlarms struct {
+	MemberID uint64
+	Name     string
+	Alarms   []string
+}
+
+// LocalAlarms returns the alarms that are raised for the local member.
+func (c *Client) LocalAlarms(ctx context.Context) (*MemberAlarms, error) {
+	status, err := c.client.Status(ctx, c.Config.Endpoints[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get etcd member status: %w", err)
+	}
+	members, err := c.client.MemberList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list etcd members: %w", err)
+	}
+	alarms, err := c.client.AlarmList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list etcd alarms: %w", err)
+	}
+
+	result := MemberAlarms{MemberID: status.Header.MemberId}
+	for _, member := range members.Members {
+		if member.ID == result.MemberID {
+			result.Name = member.Name
+			break
+		}
+	}
+	for _, alarm := range alarms.Alarms {
+		if alarm.MemberID == result.MemberID {
+			result.Alarms = append(result.Alarms, alarm.Alarm.String())
+		}
+	}
+
+	return &result, nil
+}
+
+// RecoverFromNoSpace recovers the local member from a NOSPACE alarm. The key
+// space history is compacted up to the current revision, the member is
+// defragmented to give the freed space back, and the alarm is disarmed. etcd
+// raises the alarm again on the next write if the database still exceeds the
+// quota afterwards.
+//
+// The defragmentation lock can't be acquired while the cluster rejects writes,
+// hence the member is defragmented without coordinating with the others.
+func (c *Client) RecoverFromNoSpace(ctx context.Context, log logrus.FieldLogger) error {
+	endpoint := c.Config.Endpoints[0]
+	status, err := c.client.Status(ctx, endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to get etcd member status: %w", err)
+	}
+	memberID := status.Header.MemberId
+
+	// Compaction applies to the whole cluster. Other members might have
+	// compacted concurrently.
+	log.Infof("Compacting etcd key space history up to revision %d", status.Header.Revision)
+	if _, err := c.client.Compact(ctx, status.Header.Revision, clientv3.WithCompactPhysical()); err != nil && !errors.Is(err, rpctypes.ErrCompacted) {
+		return fmt.Errorf("failed to compact etcd key space history: %w", err)
+	}
+
+	log.Infof("Defragmenting etcd member %x, database size is %s", memberID, humanize.IBytes(uint64(status.DbSize)))
+	start := time.Now()
+	if _, err := c.client.Defragment(ctx, endpoint); err != nil {
+		return fmt.Errorf("failed to defragment etcd member: %w", err)
+	}
+	if status, err = c.client.Status(ctx, endpoint); err != nil {
+		return fmt.Errorf("failed to get etcd member status: %w", err)
+	}
+	log.Infof("Defragmented etcd member %x in %s, database size is %s", memberID, time.Since(start).Round(time.Millisecond), humanize.IBytes(uint64(status.DbSize)))
+
+	if _, err := c.client.AlarmDisarm(ctx, &clientv3.AlarmMember{
+		MemberID: memberID,
+		Alarm:    etcdserverpb.AlarmType_NOSPACE,
+	}); err != nil {
+		return fmt.Errorf("failed to disarm etcd alarm: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	// certRotationRequestKey holds the time at which the rotation of the
+	// certificates of all members has been requested most recently.
+	certRotationRequestKey = "/k0s/etcd-cert-rotation/request"
+
+	// certRotationMembersPrefix is the prefix of the keys that hold the time at
+	// which the certificates of a member have been rotated most recently,
+	// followed by the hex encoded member ID.
+	certRotationMembersPrefix = "/k0s/etcd-cert-rotation/members/"
+
+	// certRotationLockKey is the key of the lock that ensures that only one
+	// member of the cluster is restarted at a time.
+	certRotationLockKey = "/k0s/etcd-cert-rotation-lock"
+
+	// certRotationLockTTL is the TTL of the lease of the lock, in seconds. It
+	// needs to outlive the restart of a member, during which the lease can't be
+	// kept alive through the member being restarted.
+	certRotationLockTTL = 600
+)
+
+// CertificateRotator rotates the certificates of the local etcd member. A
+// member being restarted to pick up its new certificates doesn't respond to
+// any requests, hence the members of the cluster rotate their certificates
+// one at a time, and only if the remaining members keep the cluster's quorum.
+type CertificateRotator struct {
+	Client *Client
+	// PeerTLS is used to probe the other members of the cluster.
+	PeerTLS *tls.Config
+	// Rotate reissues the certificates and restarts the local member, waiting
+	// until it's healthy again.
+	Rotate func(context.Context) error
+	Log    logrus.FieldLogger
+}
+
+// RotationRequested returns true if the rotation of the certificates of all
+// members has been requested after the certificates of the local member have
+// been rotated the last time.
+func (r *CertificateRotator) RotationRequested(ctx context.Context) (bool, error) {
+	memberID, err := r.Client.localMemberID(ctx)
+	if err != nil {
+		return false, err
+	}
+	requestedAt, err := r.Client.readTime(ctx, certRotationRequestKey)
+	if err != nil || requestedAt.IsZero() {
+		return false, err
+	}
+	rotatedAt, err := r.Client.readTime(ctx, certRotationMembersPrefix+formatID(memberID))
+	if err != nil {
+		return false, err
+	}
+	return rotatedAt.Before(requestedAt), nil
+}
+
+// RotateCertificates rotates the certificates of the local member. Waits until
+// no other member is rotating its certificates.
+func (r *CertificateRotator) RotateCertificates(ctx context.Context) error {
+	r.Log.Debug("Acquiring etcd certificate rotation lock")
+	unlock, err := r.Client.Lock(ctx, certRotationLockKey, certRotationLockTTL)
+	if err != nil {
+		return fmt.Errorf("failed to acquire etcd certificate rotation lock: %w", err)
+	}
+	defer func() {
+		if err := unlock(); err != nil {
+			r.Log.WithError(err).Warn("Failed to release etcd certificate rotation lock")
+		}
+	}()
+
+	status, err := r.Client.client.Status(ctx, r.Client.Config.Endpoints[0])
+	if err != nil {
+		return fmt.Errorf("failed to get etcd member status: %w", err)
+	}
+	memberID := status.Header.MemberId
+	members, err := r.Client.client.MemberList(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list etcd members: %w", err)
+	}
+	transferee, err := ProbeQuorum(ctx, r.PeerTLS, memberID, members.Members, r.Log)
+	if err != nil {
+		return fmt.Errorf("refusing to restart etcd member: %w", err)
+	}
+
+	// Restarting the leader would stall the whole cluster until a new leader
+	// has been elected. Hand over the leadership beforehand.
+	if status.Leader == memberID && transferee != 0 {
+		r.Log.Infof("Moving etcd leadership to member %x", transferee)
+		if _, err := r.Client.client.MoveLeader(ctx, transferee); err != nil {
+			return fmt.Errorf("failed to move etcd leadership: %w", err)
+		}
+	}
+
+	r.Log.Infof("Rotating the certificates of etcd member %x", memberID)
+	rotatedAt := time.Now()
+	if err := r.Rotate(ctx); err != nil {
+		return err
+	}
+
+	if _, err := r.Client.client.Put(ctx, certRotationMembersPrefix+formatID(memberID), rotatedAt.UTC().Format(time.RFC3339Nano)); err != nil {
+		return fmt.Errorf("failed to record certificate rotation: %w", err)
+	}
+	r.Log.Infof("Rotated the certificates of etcd member %x", memberID)
+	return nil
+}
+
+// RequestCertificateRotation requests the rotation of the certificates of all
+// members. Members whose certificates have been rotated before the given time
+// will rotate them again.
+func (c *Client) RequestCertificateRotation(ctx context.Context, at time.Time) error {
+	if _, err := c.client.Put(ctx, certRotationRequestKey, at.UTC().Format(time.RFC3339Nano)); err != nil {
+		return fmt.Errorf("failed to request certificate rotation: %w", err)
+	}
+	return nil
+}
+
+// PendingCertificateRotations returns the names of the members that didn't
+// rotate their certificates since the given time.
+func (c *Client) PendingCertificateRotations(ctx context.Context, since time.Time) ([]string, error) {
+	members, err := c.client.MemberList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list etcd members: %w", err)
+	}
+	resp, err := c.client.Get(ctx, certRotationMembersPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certificate rotations: %w", err)
+	}
+
+	rotations := make(map[string]string, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		rotations[strings.TrimPrefix(string(kv.Key), certRotationMembersPrefix)] = string(kv.Value)
+	}
+
+	var pending []string
+	for _, member := range members.Members {
+		rotatedAt, err := time.Parse(time.RFC3339Nano, rotations[formatID(member.ID)])
+		if err != nil || rotatedAt.Before(since) {
+			pending = append(pending, member.Name)
+		}
+	}
+	return pending, nil
+}
+
+func (c *Client) localMemberID(ctx context.Context) (uint64, error) {
+	status, err := c.client.Status(ctx, c.Config.Endpoints[0])
+	if err != nil {
+		return 0, fmt.Errorf("failed to get etcd member status: %w", err)
+	}
+	return status.Header.MemberId, nil
+}
+
+// readTime reads a timestamp from the given key. Returns the zero time if the
+// key doesn't exist.
+func (c *Client) readTime(ctx context.Context, key string) (time.Time, error) {
+	resp, err := c.client.Get(ctx, key)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	if len(resp.Kvs) == 0 {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, string(resp.Kvs[0].Value))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time in %s: %w", key, err)
+	}
+	return t, nil
+}
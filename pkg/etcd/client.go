@@ -19,35 +19,39 @@ import (
 
 // Client is our internal helper to access some of the etcd APIs
 type Client struct {
-	Config  *clientv3.Config
-	client  *clientv3.Client
-	tlsInfo transport.TLSInfo
+	Config *clientv3.Config
+	client *clientv3.Client
 }
 
 // NewClient creates new Client
 func NewClient(certDir, etcdCertDir string, etcdConf *v1beta1.EtcdConfig) (*Client, error) {
-	client := &Client{}
+	cfg, err := clientConfig(certDir, etcdCertDir, etcdConf)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientWithConfig(*cfg)
+}
 
+func clientConfig(certDir, etcdCertDir string, etcdConf *v1beta1.EtcdConfig) (*clientv3.Config, error) {
 	var tlsConfig *tls.Config
 	if etcdConf.IsTLSEnabled() {
-		client.tlsInfo = transport.TLSInfo{
+		tlsInfo := transport.TLSInfo{
 			CertFile:      etcdConf.GetCertFilePath(certDir),
 			KeyFile:       etcdConf.GetKeyFilePath(certDir),
 			TrustedCAFile: etcdConf.GetCaFilePath(etcdCertDir),
 		}
 
 		var err error
-		tlsConfig, err = client.tlsInfo.ClientConfig()
+		tlsConfig, err = tlsInfo.ClientConfig()
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	cfg := clientv3.Config{
+	return &clientv3.Config{
 		Endpoints: etcdConf.GetEndpoints(),
 		TLS:       tlsConfig,
-	}
-	return NewClientWithConfig(cfg)
+	}, nil
 }
 
 func NewClientWithConfig(cfg clientv3.Config) (*Client, error) {
@@ -78,8 +82,24 @@ func (c *Client) ListMembers(ctx context.Context) (map[string]string, error) {
 
 // AddMember add new member to etcd cluster
 func (c *Client) AddMember(ctx context.Context, name, peerAddress string) ([]string, error) {
+	return c.addMember(ctx, name, peerAddress, false)
+}
 
-	addResp, err := c.client.MemberAdd(ctx, []string{peerAddress})
+// AddLearner adds a new non-voting member to the etcd cluster. The learner
+// doesn't count towards the quorum until it has been promoted, which is only
+// possible once it has caught up with the leader.
+func (c *Client) AddLearner(ctx context.Context, name, peerAddress string) ([]string, error) {
+	return c.addMember(ctx, name, peerAddress, true)
+}
+
+func (c *Client) addMember(ctx context.Context, name, peerAddress string, learner bool) ([]string, error) {
+	var addResp *clientv3.MemberAddResponse
+	var err error
+	if learner {
+		addResp, err = c.client.MemberAddAsLearner(ctx, []string{peerAddress})
+	} else {
+		addResp, err = c.client.MemberAdd(ctx, []string{peerAddress})
+	}
 	if err != nil {
 		// TODO we should try to detect possible double add for a peer
 		// Not sure though if we can return correct initial-cluster as the order
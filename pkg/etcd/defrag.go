@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defragLockKey is the key of the lock that ensures that only one member
+	// of the cluster is defragmented at a time.
+	defragLockKey = "/k0s/etcd-defrag-lock"
+
+	// defragLockTTL is the TTL of the lease of the lock, in seconds. It needs
+	// to outlive the defragmentation of a member, during which the lease can't
+	// be kept alive reliably through the member being defragmented.
+	defragLockTTL = 600
+)
+
+// Defragmenter defragments the local etcd member, if the size of its database
+// exceeds a threshold. A member being defragmented doesn't respond to any
+// requests, hence the members of the cluster are defragmented one at a time,
+// and only if the remaining members keep the cluster's quorum.
+type Defragmenter struct {
+	Client *Client
+	// PeerTLS is used to probe the other members of the cluster.
+	PeerTLS *tls.Config
+	// MinDBSize is the size of the database in bytes above which the member
+	// is defragmented.
+	MinDBSize int64
+	// Force skips the quorum check.
+	Force bool
+	Log   logrus.FieldLogger
+}
+
+// DefragmentResult describes the outcome of a defragmentation.
+type DefragmentResult struct {
+	MemberID uint64
+	// Skipped is true if the database didn't exceed the size threshold.
+	Skipped      bool
+	DBSizeBefore int64
+	DBSizeAfter  int64
+}
+
+// Defragment defragments the local etcd member, if the size of its database
+// exceeds the threshold. Waits until no other member is being defragmented.
+func (d *Defragmenter) Defragment(ctx context.Context) (*DefragmentResult, error) {
+	endpoint := d.Client.Config.Endpoints[0]
+	status, err := d.Client.client.Status(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get etcd member status: %w", err)
+	}
+
+	result := DefragmentResult{MemberID: status.Header.MemberId, DBSizeBefore: status.DbSize}
+	if status.DbSize <= d.MinDBSize {
+		d.Log.Debugf("Not defragmenting etcd member, database size of %s doesn't exceed %s", humanize.IBytes(uint64(status.DbSize)), humanize.IBytes(uint64(d.MinDBSize)))
+		result.Skipped = true
+		return &result, nil
+	}
+
+	d.Log.Debug("Acquiring etcd defragmentation lock")
+	unlock, err := d.Client.Lock(ctx, defragLockKey, defragLockTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire etcd defragmentation lock: %w", err)
+	}
+	defer func() {
+		if err := unlock(); err != nil {
+			d.Log.WithError(err).Warn("Failed to release etcd defragmentation lock")
+		}
+	}()
+
+	members, err := d.Client.client.MemberList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list etcd members: %w", err)
+	}
+	transferee, err := ProbeQuorum(ctx, d.PeerTLS, result.MemberID, members.Members, d.Log)
+	if err != nil {
+		if !d.Force {
+			return nil, fmt.Errorf("refusing to defragment: %w", err)
+		}
+		d.Log.WithError(err).Warn("Defragmenting anyway")
+	}
+
+	// Defragmenting the leader would stall the whole cluster until a new
+	// leader has been elected. Hand over the leadership beforehand.
+	if status.Leader == result.MemberID && transferee != 0 {
+		d.Log.Infof("Moving etcd leadership to member %x", transferee)
+		if _, err := d.Client.client.MoveLeader(ctx, transferee); err != nil {
+			return nil, fmt.Errorf("failed to move etcd leadership: %w", err)
+		}
+	}
+
+	d.Log.Infof("Defragmenting etcd member %x, database size is %s", result.MemberID, humanize.IBytes(uint64(status.DbSize)))
+	start := time.Now()
+	if _, err := d.Client.client.Defragment(ctx, endpoint); err != nil {
+		return nil, fmt.Errorf("failed to defragment etcd member: %w", err)
+	}
+
+	if status, err = d.Client.client.Status(ctx, endpoint); err != nil {
+		return nil, fmt.Errorf("failed to get etcd member status: %w", err)
+	}
+	result.DBSizeAfter = status.DbSize
+	d.Log.Infof("Defragmented etcd member %x in %s, database size is %s", result.MemberID, time.Since(start).Round(time.Millisecond), humanize.IBytes(uint64(status.DbSize)))
+
+	return &result, nil
+}
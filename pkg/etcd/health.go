@@ -5,6 +5,7 @@ package etcd
 
 import (
 	"context"
+	"time"
 
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 
@@ -21,3 +22,42 @@ func CheckEtcdReady(ctx context.Context, certDir string, etcdCertDir string, etc
 
 	return c.Health(ctx)
 }
+
+// endpointHealthTimeout limits the time spent on checking a single endpoint,
+// so that an unreachable endpoint doesn't delay the checks of the others.
+const endpointHealthTimeout = 5 * time.Second
+
+// EndpointHealth is the health of a single etcd endpoint.
+type EndpointHealth struct {
+	Endpoint string
+	Error    error
+}
+
+// CheckEndpointsHealth checks the health of each configured etcd endpoint
+// individually. Unlike [CheckEtcdReady], which succeeds as soon as any
+// endpoint responds, this reveals the endpoints that are unhealthy.
+func CheckEndpointsHealth(ctx context.Context, certDir string, etcdCertDir string, etcdConf *v1beta1.EtcdConfig) ([]EndpointHealth, error) {
+	cfg, err := clientConfig(certDir, etcdCertDir, etcdConf)
+	if err != nil {
+		return nil, err
+	}
+
+	health := make([]EndpointHealth, len(cfg.Endpoints))
+	for i, endpoint := range cfg.Endpoints {
+		health[i].Endpoint = endpoint
+
+		endpointCfg := *cfg
+		endpointCfg.Endpoints = []string{endpoint}
+		c, err := NewClientWithConfig(endpointCfg)
+		if err != nil {
+			health[i].Error = err
+			continue
+		}
+		endpointCtx, cancel := context.WithTimeout(ctx, endpointHealthTimeout)
+		health[i].Error = c.Health(endpointCtx)
+		cancel()
+		c.Close()
+	}
+
+	return health, nil
+}
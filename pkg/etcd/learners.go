@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+// PromoteLearners promotes all learners of the etcd cluster that have caught
+// up with the leader to voting members. Learners that are still catching up
+// are left alone, so that this can be called repeatedly.
+func (c *Client) PromoteLearners(ctx context.Context, log logrus.FieldLogger) error {
+	members, err := c.client.MemberList(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list etcd members: %w", err)
+	}
+
+	var errs []error
+	for _, member := range members.Members {
+		// Learners without a name haven't been started yet.
+		if !member.IsLearner || member.Name == "" {
+			continue
+		}
+
+		_, err := c.client.MemberPromote(ctx, member.ID)
+		switch {
+		case err == nil:
+			log.Infof("Promoted etcd learner %s (%x) to a voting member", member.Name, member.ID)
+		case errors.Is(err, rpctypes.ErrMemberLearnerNotReady):
+			log.Debugf("etcd learner %s (%x) hasn't caught up with the leader yet", member.Name, member.ID)
+		case errors.Is(err, rpctypes.ErrMemberNotLearner):
+			// Promoted concurrently by another controller.
+		default:
+			errs = append(errs, fmt.Errorf("failed to promote etcd learner %s (%x): %w", member.Name, member.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// IsLearner returns true if the local member is a learner.
+func (c *Client) IsLearner(ctx context.Context) (bool, error) {
+	status, err := c.client.Status(ctx, c.Config.Endpoints[0])
+	if err != nil {
+		return false, fmt.Errorf("failed to get etcd member status: %w", err)
+	}
+	return status.IsLearner, nil
+}
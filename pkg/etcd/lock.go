@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+// Lock acquires the cluster-wide lock with the given key, waiting until it's
+// released by its current holder. The lock is held until the returned unlock
+// function is called, or until the lease with the given TTL in seconds expires.
+func (c *Client) Lock(ctx context.Context, key string, ttl int) (unlock func() error, _ error) {
+	session, err := concurrency.NewSession(c.client, concurrency.WithTTL(ttl), concurrency.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	mutex := concurrency.NewMutex(session, key)
+	if err := mutex.Lock(ctx); err != nil {
+		return nil, errors.Join(err, session.Close())
+	}
+
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return errors.Join(mutex.Unlock(ctx), session.Close())
+	}, nil
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/version"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+)
+
+// peerProbeTimeout is the time after which a peer that didn't respond is
+// considered to be unreachable.
+const peerProbeTimeout = 5 * time.Second
+
+// PeerTLSConfig returns the TLS configuration that etcd members use to
+// connect to their peers.
+func PeerTLSConfig(etcdCertDir string) (*tls.Config, error) {
+	tlsInfo := transport.TLSInfo{
+		CertFile:      filepath.Join(etcdCertDir, "peer.crt"),
+		KeyFile:       filepath.Join(etcdCertDir, "peer.key"),
+		TrustedCAFile: filepath.Join(etcdCertDir, "ca.crt"),
+	}
+	return tlsInfo.ClientConfig()
+}
+
+// ProbeQuorum ensures that the cluster keeps its quorum while the member with
+// the given ID is unavailable, by probing the peer endpoints of all other
+// voting members. Returns the ID of a reachable voting member to which the
+// leadership may be moved, if any, even if the quorum would be lost.
+func ProbeQuorum(ctx context.Context, tlsConfig *tls.Config, memberID uint64, members []*etcdserverpb.Member, log logrus.FieldLogger) (transferee uint64, _ error) {
+	var voting, reachable int
+	for _, member := range members {
+		if member.IsLearner {
+			continue
+		}
+		voting++
+		if member.ID == memberID {
+			reachable++
+			continue
+		}
+		if len(member.PeerURLs) == 0 {
+			continue
+		}
+		if err := ProbePeer(ctx, tlsConfig, member.PeerURLs[0]); err != nil {
+			log.WithError(err).Warnf("etcd member %s (%x) is unreachable", member.Name, member.ID)
+			continue
+		}
+		reachable++
+		if transferee == 0 {
+			transferee = member.ID
+		}
+	}
+
+	if reachable-1 < voting/2+1 && voting > 1 {
+		return transferee, fmt.Errorf("the etcd cluster would lose its quorum, %d out of %d members are reachable", reachable, voting)
+	}
+
+	return transferee, nil
+}
+
+// ProbePeer checks whether the etcd member with the given peer URL is
+// reachable, by requesting its version from the peer endpoint. Members only
+// serve their client API on the loopback interface, so this is the only way
+// for a controller to tell if the other members are up.
+func ProbePeer(ctx context.Context, tlsConfig *tls.Config, peerURL string) error {
+	_, err := PeerVersion(ctx, tlsConfig, peerURL)
+	return err
+}
+
+// PeerVersion returns the etcd server version of the member with the given
+// peer URL, as reported by its peer endpoint.
+func PeerVersion(ctx context.Context, tlsConfig *tls.Config, peerURL string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, peerProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, peerURL+"/version", nil)
+	if err != nil {
+		return "", err
+	}
+	client := http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	defer client.CloseIdleConnections()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return "", fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+	}
+
+	var versions version.Versions
+	if err := json.NewDecoder(resp.Body).Decode(&versions); err != nil {
+		return "", fmt.Errorf("failed to decode version: %w", err)
+	}
+	return versions.Server, nil
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/pkg/file"
+
+	bolt "go.etcd.io/bbolt"
+	"go.etcd.io/etcd/client/v3/snapshot"
+	"go.uber.org/zap"
+)
+
+// DataDirDB returns the path to the backend database in the given etcd data
+// directory.
+func DataDirDB(dataDir string) string {
+	return filepath.Join(dataDir, "member", "snap", "db")
+}
+
+// SaveDataDirSnapshot saves a snapshot of the backend database in the given
+// data directory of a stopped etcd member to path. The snapshot has the same
+// format as the ones taken by a running etcd member, i.e. it can be restored
+// with etcdutl.
+func SaveDataDirSnapshot(dataDir, path string) error {
+	// A read-only open fails if etcd is still running, since etcd holds an
+	// exclusive lock on the database.
+	db, err := bolt.Open(DataDirDB(dataDir), 0400, &bolt.Options{ReadOnly: true, Timeout: 10 * time.Second})
+	if err != nil {
+		return fmt.Errorf("failed to open etcd database: %w", err)
+	}
+	defer db.Close()
+
+	return file.WriteAtomically(path, 0600, func(w io.Writer) error {
+		return db.View(func(tx *bolt.Tx) error {
+			// Snapshots are followed by the SHA256 hash of the database, which
+			// is verified when restoring them.
+			hash := sha256.New()
+			if _, err := tx.WriteTo(io.MultiWriter(w, hash)); err != nil {
+				return err
+			}
+			_, err := w.Write(hash.Sum(nil))
+			return err
+		})
+	})
+}
+
+// SaveSnapshot saves a snapshot of the backend database of the etcd member to
+// path. Returns the etcd version of the member.
+func (c *Client) SaveSnapshot(ctx context.Context, path string) (string, error) {
+	version, err := snapshot.SaveWithVersion(ctx, zap.NewNop(), *c.Config, path)
+	if err != nil {
+		return "", fmt.Errorf("failed to save etcd snapshot: %w", err)
+	}
+	return version, nil
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strconv"
+)
+
+// ClusterStatus describes the state of an etcd cluster, as seen by the local
+// etcd member.
+type ClusterStatus struct {
+	ClusterID string         `json:"clusterID"`
+	LeaderID  string         `json:"leaderID,omitempty"`
+	RaftTerm  uint64         `json:"raftTerm"`
+	Members   []MemberStatus `json:"members"`
+}
+
+// MemberStatus describes the state of a single etcd member. Members only serve
+// their client API on the loopback interface, hence the database size and raft
+// index are only known for the local member. Remote members are probed via
+// their peer endpoint.
+type MemberStatus struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	PeerURL   string   `json:"peerURL,omitempty"`
+	Local     bool     `json:"local"`
+	Learner   bool     `json:"learner"`
+	Leader    bool     `json:"leader"`
+	Healthy   bool     `json:"healthy"`
+	Error     string   `json:"error,omitempty"`
+	Version   string   `json:"version,omitempty"`
+	DBSize    int64    `json:"dbSize,omitempty"`
+	DBInUse   int64    `json:"dbSizeInUse,omitempty"`
+	RaftIndex uint64   `json:"raftIndex,omitempty"`
+	Alarms    []string `json:"alarms,omitempty"`
+}
+
+// IsHealthy returns true if all members are healthy and no alarms are raised.
+func (s *ClusterStatus) IsHealthy() bool {
+	for _, member := range s.Members {
+		if !member.Healthy || len(member.Alarms) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Status queries the state of the etcd cluster. The local member is queried
+// via the client API, all other members are probed via their peer endpoint
+// using the given TLS configuration.
+func (c *Client) Status(ctx context.Context, peerTLS *tls.Config) (*ClusterStatus, error) {
+	endpoint := c.Config.Endpoints[0]
+	status, err := c.client.Status(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get etcd member status: %w", err)
+	}
+	members, err := c.client.MemberList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list etcd members: %w", err)
+	}
+	alarms, err := c.client.AlarmList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list etcd alarms: %w", err)
+	}
+
+	result := ClusterStatus{
+		ClusterID: formatID(status.Header.ClusterId),
+		RaftTerm:  status.RaftTerm,
+	}
+	if status.Leader != 0 {
+		result.LeaderID = formatID(status.Leader)
+	}
+
+	for _, member := range members.Members {
+		memberStatus := MemberStatus{
+			ID:      formatID(member.ID),
+			Name:    member.Name,
+			Learner: member.IsLearner,
+			Leader:  member.ID == status.Leader,
+		}
+		if len(member.PeerURLs) > 0 {
+			memberStatus.PeerURL = member.PeerURLs[0]
+		}
+		for _, alarm := range alarms.Alarms {
+			if alarm.MemberID == member.ID {
+				memberStatus.Alarms = append(memberStatus.Alarms, alarm.Alarm.String())
+			}
+		}
+
+		switch {
+		case member.ID == status.Header.MemberId:
+			memberStatus.Local = true
+			memberStatus.Version = status.Version
+			memberStatus.DBSize = status.DbSize
+			memberStatus.DBInUse = status.DbSizeInUse
+			memberStatus.RaftIndex = status.RaftIndex
+			if err := c.Health(ctx); err != nil {
+				memberStatus.Error = err.Error()
+			} else if len(status.Errors) > 0 {
+				memberStatus.Error = status.Errors[0]
+			} else {
+				memberStatus.Healthy = true
+			}
+
+		case memberStatus.PeerURL == "":
+			memberStatus.Error = "member has no peer URL"
+
+		default:
+			if version, err := PeerVersion(ctx, peerTLS, memberStatus.PeerURL); err != nil {
+				memberStatus.Error = err.Error()
+			} else {
+				memberStatus.Version = version
+				memberStatus.Healthy = true
+			}
+		}
+
+		result.Members = append(result.Members, memberStatus)
+	}
+
+	return &result, nil
+}
+
+func formatID(id uint64) string {
+	return strconv.FormatUint(id, 16)
+}
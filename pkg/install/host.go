@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+// HostIssue is an issue of the host that would only surface once k0s is
+// running, and that can be fixed automatically at install time.
+type HostIssue struct {
+	// Description of the issue.
+	Description string
+	// Remediation describes how the issue is fixed.
+	Remediation string
+
+	fix func() error
+}
+
+// Fix applies the remediation of this issue.
+func (i *HostIssue) Fix() error {
+	return i.fix()
+}
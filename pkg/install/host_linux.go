@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/k0sproject/k0s/internal/pkg/dir"
+	"github.com/k0sproject/k0s/internal/pkg/file"
+)
+
+// The kernel modules that workers need, and whether they're file systems.
+var workerKernelModules = []struct {
+	name         string
+	isFilesystem bool
+}{
+	{"overlay", true},
+	{"br_netfilter", false},
+}
+
+// The sysctls that workers need. The bridge sysctls are only available once
+// br_netfilter has been loaded.
+var workerSysctls = []string{
+	"net.ipv4.ip_forward",
+	"net.bridge.bridge-nf-call-iptables",
+	"net.bridge.bridge-nf-call-ip6tables",
+}
+
+// CheckHost looks for host issues that can be fixed automatically. Only
+// workers are subject to those.
+func CheckHost(workerEnabled bool) []HostIssue {
+	if !workerEnabled {
+		return nil
+	}
+	return (&hostChecker{
+		procDir: "/proc",
+		sysDir:  "/sys",
+		etcDir:  "/etc",
+		run: func(name string, args ...string) error {
+			if out, err := exec.Command(name, args...).CombinedOutput(); err != nil {
+				return fmt.Errorf("%s: %w: %s", name, err, bytes.TrimSpace(out))
+			}
+			return nil
+		},
+	}).check()
+}
+
+type hostChecker struct {
+	procDir, sysDir, etcDir string
+	run                     func(name string, args ...string) error
+}
+
+func (h *hostChecker) check() []HostIssue {
+	var issues []HostIssue
+
+	for _, module := range workerKernelModules {
+		if h.isModuleLoaded(module.name, module.isFilesystem) {
+			continue
+		}
+		modulesLoadPath := filepath.Join(h.etcDir, "modules-load.d", "k0s.conf")
+		issues = append(issues, HostIssue{
+			Description: fmt.Sprintf("Kernel module %s isn't loaded", module.name),
+			Remediation: fmt.Sprintf("Load %s and add it to %s", module.name, modulesLoadPath),
+			fix: func() error {
+				if err := h.run("modprobe", module.name); err != nil {
+					return err
+				}
+				return addLines(modulesLoadPath, module.name)
+			},
+		})
+	}
+
+	sysctlPath := filepath.Join(h.etcDir, "sysctl.d", "99-k0s.conf")
+	for _, name := range workerSysctls {
+		procPath := filepath.Join(h.procDir, "sys", strings.ReplaceAll(name, ".", "/"))
+		if value, err := os.ReadFile(procPath); err == nil && strings.TrimSpace(string(value)) == "1" {
+			continue
+		}
+		issues = append(issues, HostIssue{
+			Description: fmt.Sprintf("Sysctl %s isn't enabled", name),
+			Remediation: fmt.Sprintf("Enable %s and add it to %s", name, sysctlPath),
+			fix: func() error {
+				if err := os.WriteFile(procPath, []byte("1"), 0644); err != nil {
+					return err
+				}
+				return addLines(sysctlPath, name+" = 1")
+			},
+		})
+	}
+
+	if swaps, err := os.ReadFile(filepath.Join(h.procDir, "swaps")); err == nil {
+		// The first line is a header.
+		if lines := strings.Split(strings.TrimSpace(string(swaps)), "\n"); len(lines) > 1 {
+			fstabPath := filepath.Join(h.etcDir, "fstab")
+			issues = append(issues, HostIssue{
+				Description: "Swap is enabled",
+				Remediation: "Disable swap and comment out the swap entries in " + fstabPath,
+				fix: func() error {
+					if err := h.run("swapoff", "-a"); err != nil {
+						return err
+					}
+					return commentOutSwap(fstabPath)
+				},
+			})
+		}
+	}
+
+	return issues
+}
+
+func (h *hostChecker) isModuleLoaded(name string, isFilesystem bool) bool {
+	if dir.IsDirectory(filepath.Join(h.sysDir, "module", name)) {
+		return true
+	}
+	if !isFilesystem {
+		return false
+	}
+	// Built-in file systems don't necessarily show up in /sys/module.
+	filesystems, err := os.ReadFile(filepath.Join(h.procDir, "filesystems"))
+	if err != nil {
+		return false
+	}
+	for line := range strings.SplitSeq(string(filesystems), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 && fields[len(fields)-1] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addLines adds the given line to the given file, unless it's already there.
+func addLines(path, line string) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	if slices.Contains(lines, line) {
+		return nil
+	}
+	if err := dir.Init(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+		content = append(content, '\n')
+	}
+	return file.WriteContentAtomically(path, append(content, line+"\n"...), 0644)
+}
+
+// commentOutSwap comments out the swap entries in the given fstab.
+func commentOutSwap(path string) error {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	var changed bool
+	lines := bufio.NewScanner(bytes.NewReader(content))
+	for lines.Scan() {
+		line := lines.Text()
+		if fields := strings.Fields(line); len(fields) > 2 && !strings.HasPrefix(fields[0], "#") && fields[2] == "swap" {
+			line, changed = "# Disabled by k0s: "+line, true
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	if err := lines.Err(); err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return file.WriteContentAtomically(path, buf.Bytes(), stat.Mode().Perm())
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostChecker(t *testing.T) {
+	procDir, sysDir, etcDir := t.TempDir(), t.TempDir(), t.TempDir()
+
+	writeFile := func(t *testing.T, path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	// overlay is built-in, br_netfilter is missing
+	writeFile(t, filepath.Join(procDir, "filesystems"), "nodev\tsysfs\nnodev\toverlay\n")
+	// ip_forward is enabled, the bridge sysctls are missing
+	writeFile(t, filepath.Join(procDir, "sys", "net", "ipv4", "ip_forward"), "1\n")
+	writeFile(t, filepath.Join(procDir, "sys", "net", "bridge", "bridge-nf-call-iptables"), "0\n")
+	writeFile(t, filepath.Join(procDir, "swaps"), strings.Join([]string{
+		"Filename\tType\tSize\tUsed\tPriority",
+		"/dev/sda2\tpartition\t1048572\t0\t-2",
+	}, "\n"))
+	writeFile(t, filepath.Join(etcDir, "fstab"), strings.Join([]string{
+		"/dev/sda1 / ext4 defaults 0 1",
+		"/dev/sda2 none swap sw 0 0",
+		"# /dev/sda3 none swap sw 0 0",
+		"",
+	}, "\n"))
+	writeFile(t, filepath.Join(etcDir, "modules-load.d", "k0s.conf"), "overlay")
+
+	var ran []string
+	underTest := hostChecker{procDir, sysDir, etcDir, func(name string, args ...string) error {
+		ran = append(ran, strings.Join(append([]string{name}, args...), " "))
+		return nil
+	}}
+
+	issues := underTest.check()
+	var descriptions []string
+	for _, issue := range issues {
+		descriptions = append(descriptions, issue.Description)
+	}
+	assert.Equal(t, []string{
+		"Kernel module br_netfilter isn't loaded",
+		"Sysctl net.bridge.bridge-nf-call-iptables isn't enabled",
+		"Sysctl net.bridge.bridge-nf-call-ip6tables isn't enabled",
+		"Swap is enabled",
+	}, descriptions)
+
+	// The proc directory isn't writable for real, so create the missing sysctl.
+	writeFile(t, filepath.Join(procDir, "sys", "net", "bridge", "bridge-nf-call-ip6tables"), "0\n")
+	for _, issue := range issues {
+		assert.NoError(t, issue.Fix(), issue.Description)
+	}
+	// Fixing twice doesn't change anything
+	for _, issue := range issues {
+		assert.NoError(t, issue.Fix(), issue.Description)
+	}
+
+	assert.Equal(t, []string{
+		"modprobe br_netfilter", "swapoff -a",
+		"modprobe br_netfilter", "swapoff -a",
+	}, ran)
+
+	readFile := func(t *testing.T, path string) string {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(content)
+	}
+	assert.Equal(t, "overlay\nbr_netfilter\n", readFile(t, filepath.Join(etcDir, "modules-load.d", "k0s.conf")))
+	assert.Equal(t,
+		"net.bridge.bridge-nf-call-iptables = 1\nnet.bridge.bridge-nf-call-ip6tables = 1\n",
+		readFile(t, filepath.Join(etcDir, "sysctl.d", "99-k0s.conf")),
+	)
+	assert.Equal(t, "1", readFile(t, filepath.Join(procDir, "sys", "net", "bridge", "bridge-nf-call-iptables")))
+	assert.Equal(t, strings.Join([]string{
+		"/dev/sda1 / ext4 defaults 0 1",
+		"# Disabled by k0s: /dev/sda2 none swap sw 0 0",
+		"# /dev/sda3 none swap sw 0 0",
+		"",
+	}, "\n"), readFile(t, filepath.Join(etcDir, "fstab")))
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+// CheckHost looks for host issues that can be fixed automatically. There are
+// none on this platform.
+func CheckHost(bool) []HostIssue {
+	return nil
+}
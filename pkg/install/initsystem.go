@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kardianos/service"
+)
+
+// AutoInitSystem selects the init system automatically.
+const AutoInitSystem = "auto"
+
+// InitSystems returns the names of the init systems that are supported on
+// this platform, in addition to [AutoInitSystem].
+func InitSystems() []string {
+	names := make([]string, 0, len(initSystems))
+	for name := range initSystems {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// SelectInitSystem selects the init system that manages the k0s service. The
+// init system is detected if the name is empty or [AutoInitSystem].
+func SelectInitSystem(name string) error {
+	if name == "" || name == AutoInitSystem {
+		return nil
+	}
+
+	platform, ok := initSystems[name]
+	if !ok {
+		supported := append([]string{AutoInitSystem}, InitSystems()...)
+		return fmt.Errorf("unsupported init system %q (supported: %s)", name, strings.Join(supported, ", "))
+	}
+	for _, system := range service.AvailableSystems() {
+		if system.String() == platform {
+			service.ChooseSystem(selectedSystem{system})
+			return nil
+		}
+	}
+
+	return fmt.Errorf("init system %q is not available", name)
+}
+
+// selectedSystem is an init system that has been selected explicitly, and
+// hence isn't subject to detection.
+type selectedSystem struct{ service.System }
+
+func (selectedSystem) Detect() bool { return true }
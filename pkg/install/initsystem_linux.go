@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/kardianos/service"
+)
+
+var initSystems = map[string]string{
+	"systemd": "linux-systemd",
+	"openrc":  "linux-openrc",
+	"upstart": "linux-upstart",
+	"sysv":    "unix-systemv",
+	"runit":   runit.platform,
+	"s6":      s6.platform,
+}
+
+// The init systems that are recognized by the name of the process with PID 1.
+var initProcesses = map[string]string{
+	"systemd":     "linux-systemd",
+	"openrc-init": "linux-openrc",
+	"runit":       runit.platform,
+	"runit-init":  runit.platform,
+	"s6-svscan":   s6.platform,
+}
+
+func init() {
+	service.ChooseSystem(detectionOrder(service.AvailableSystems(), pid1Name())...)
+}
+
+// detectionOrder returns the order in which the init systems are detected.
+// The supervision suites are detected before System V, which is always
+// detected as a fallback. If the init system is known by the name of the
+// process with PID 1, it's selected without further detection.
+func detectionOrder(systems []service.System, pid1 string) []service.System {
+	systems = slices.Clone(systems)
+	idx := slices.IndexFunc(systems, func(s service.System) bool { return s.String() == "unix-systemv" })
+	if idx < 0 {
+		idx = len(systems)
+	}
+	systems = slices.Insert(systems, idx, []service.System{supervisionSystem{runit}, supervisionSystem{s6}}...)
+
+	if platform, ok := initProcesses[pid1]; ok {
+		idx := slices.IndexFunc(systems, func(s service.System) bool { return s.String() == platform })
+		if idx >= 0 {
+			system := systems[idx]
+			systems = slices.Delete(systems, idx, idx+1)
+			systems = slices.Insert(systems, 0, service.System(selectedSystem{system}))
+		}
+	}
+
+	return systems
+}
+
+func pid1Name() string {
+	comm, err := os.ReadFile("/proc/1/comm")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(comm))
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+import (
+	"testing"
+
+	"github.com/kardianos/service"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSystem string
+
+func (s fakeSystem) String() string  { return string(s) }
+func (fakeSystem) Detect() bool      { return false }
+func (fakeSystem) Interactive() bool { return false }
+func (fakeSystem) New(service.Interface, *service.Config) (service.Service, error) {
+	return nil, nil
+}
+
+func TestDetectionOrder(t *testing.T) {
+	systems := []service.System{
+		fakeSystem("linux-systemd"),
+		fakeSystem("linux-openrc"),
+		fakeSystem("unix-systemv"),
+	}
+
+	names := func(systems []service.System) (names []string) {
+		for _, s := range systems {
+			names = append(names, s.String())
+		}
+		return names
+	}
+
+	t.Run("unknown_pid1", func(t *testing.T) {
+		order := detectionOrder(systems, "init")
+		assert.Equal(t, []string{"linux-systemd", "linux-openrc", "linux-runit", "linux-s6", "unix-systemv"}, names(order))
+		assert.False(t, order[0].Detect())
+	})
+
+	t.Run("runit_pid1", func(t *testing.T) {
+		order := detectionOrder(systems, "runit")
+		assert.Equal(t, []string{"linux-runit", "linux-systemd", "linux-openrc", "linux-s6", "unix-systemv"}, names(order))
+		assert.True(t, order[0].Detect(), "the system of PID 1 should always be detected")
+	})
+
+	t.Run("systemd_pid1", func(t *testing.T) {
+		order := detectionOrder(systems, "systemd")
+		assert.Equal(t, []string{"linux-systemd", "linux-openrc", "linux-runit", "linux-s6", "unix-systemv"}, names(order))
+		assert.True(t, order[0].Detect(), "the system of PID 1 should always be detected")
+	})
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, `'/usr/local/bin/k0s'`, shellQuote("/usr/local/bin/k0s"))
+	assert.Equal(t, `'--labels=a='\''b'\'''`, shellQuote("--labels=a='b'"))
+}
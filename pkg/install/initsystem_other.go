@@ -0,0 +1,9 @@
+//go:build !linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+// There's only the platform's service manager.
+var initSystems = map[string]string{}
@@ -18,6 +18,8 @@ StartLimitBurst=10
 ExecStart={{.Path|cmdEscape}}{{range .Arguments}} {{.|cmdEscape}}{{end}}
 {{- if .Option.Environment}}{{range .Option.Environment}}
 Environment="{{.}}"{{end}}{{- end}}
+{{- if .Option.EnvironmentFile}}{{range .Option.EnvironmentFile}}
+EnvironmentFile={{.}}{{end}}{{- end}}
 
 RestartSec=10
 Delegate=yes
@@ -25,6 +27,12 @@ KillMode=process
 LimitCORE=infinity
 TasksMax=infinity
 TimeoutStartSec=0
+{{- if .Option.CPUQuota}}
+CPUQuota={{.Option.CPUQuota}}{{- end}}
+{{- if .Option.MemoryMax}}
+MemoryMax={{.Option.MemoryMax}}{{- end}}
+{{- if .Option.WatchdogSec}}
+WatchdogSec={{.Option.WatchdogSec}}{{- end}}
 
 {{- if .ChRoot}}RootDirectory={{.ChRoot|cmd}}{{- end}}
 
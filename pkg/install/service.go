@@ -5,6 +5,7 @@ package install
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/kardianos/service"
 	"github.com/sirupsen/logrus"
@@ -52,7 +53,7 @@ func InstalledService() (service.Service, error) {
 }
 
 // InstallService installs the k0s service, per the given arguments, and the detected platform
-func InstallService(args []string, envVars []string, force bool) error {
+func InstallService(args []string, envVars []string, force bool, unitOpts *UnitOptions) error {
 	var svcConfig *service.Config
 
 	prg := &Program{}
@@ -69,9 +70,18 @@ func InstallService(args []string, envVars []string, force bool) error {
 	}
 
 	configureServicePlatform(s, svcConfig)
+	if err := applyUnitOptions(s, svcConfig, unitOpts); err != nil {
+		return err
+	}
 
 	if len(envVars) > 0 {
 		svcConfig.Option["Environment"] = envVars
+		// The Windows service manager takes environment variables as a map.
+		svcConfig.EnvVars = make(map[string]string, len(envVars))
+		for _, envVar := range envVars {
+			name, value, _ := strings.Cut(envVar, "=")
+			svcConfig.EnvVars[name] = value
+		}
 	}
 
 	svcConfig.Arguments = args
@@ -88,6 +98,42 @@ func InstallService(args []string, envVars []string, force bool) error {
 	return s.Install()
 }
 
+// IsServiceInstalled checks if the k0s service for the given role has been
+// installed on the host.
+func IsServiceInstalled(role string) (bool, error) {
+	s, err := service.New(&Program{}, GetServiceConfig(role))
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := s.Status(); err != nil {
+		if errors.Is(err, service.ErrNotInstalled) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// StopService stops the k0s service for the given role, if it's running.
+func StopService(role string) error {
+	s, err := service.New(&Program{}, GetServiceConfig(role))
+	if err != nil {
+		return err
+	}
+
+	status, err := s.Status()
+	if err != nil {
+		return err
+	}
+	if status != service.StatusRunning {
+		return nil
+	}
+
+	return s.Stop()
+}
+
 func UninstallService(role string) error {
 	prg := &Program{}
 
@@ -3,7 +3,15 @@
 
 package install
 
-import "github.com/kardianos/service"
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/kardianos/service"
+)
 
 func configureServicePlatform(s service.Service, svcConfig *service.Config) {
 	switch s.Platform() {
@@ -20,6 +28,9 @@ func configureServicePlatform(s service.Service, svcConfig *service.Config) {
 		svcConfig.Option = map[string]any{
 			"SysVScript": sysvScript,
 		}
+	case runit.platform, s6.platform:
+		// The run script is generated by k0s itself.
+		svcConfig.Option = map[string]any{}
 	case "linux-systemd":
 		svcConfig.Dependencies = []string{"After=network-online.target", "Wants=network-online.target"}
 		svcConfig.Option = map[string]any{
@@ -28,3 +39,67 @@ func configureServicePlatform(s service.Service, svcConfig *service.Config) {
 		}
 	}
 }
+
+// ServiceLeftovers returns the files that the init scripts of the k0s service
+// for the given role leave behind when it's uninstalled, i.e. its log and PID
+// files.
+func ServiceLeftovers(role string) ([]string, error) {
+	name := GetServiceConfig(role).Name
+
+	var leftovers []string
+	for _, path := range []string{
+		"/var/log/" + name + ".log",
+		"/var/log/" + name + ".out",
+		"/var/log/" + name + ".err",
+		"/var/run/" + name + ".pid",
+	} {
+		if _, err := os.Lstat(path); err == nil {
+			leftovers = append(leftovers, path)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return leftovers, err
+		}
+	}
+
+	return leftovers, nil
+}
+
+// ServiceFiles returns the files of the k0s service for the given role that
+// are installed on the host. In contrast to uninstalling the service, which
+// only considers the detected init system, this includes the files of all the
+// supported init systems, along with the links that enable the service. Some
+// of them may be directories.
+func ServiceFiles(role string) ([]string, error) {
+	name := GetServiceConfig(role).Name
+
+	candidates := []string{
+		"/etc/systemd/system/" + name + ".service",
+		"/etc/systemd/system/multi-user.target.wants/" + name + ".service",
+		"/etc/init.d/" + name,
+		"/etc/runlevels/default/" + name,
+		"/etc/init/" + name + ".conf",
+		filepath.Join(runit.serviceDir, name),
+		filepath.Join(s6.serviceDir, name),
+	}
+	for _, suite := range []*supervisionSuite{runit, s6} {
+		for _, scanDir := range suite.scanDirs {
+			candidates = append(candidates, filepath.Join(scanDir, name))
+		}
+	}
+	for _, level := range []int{2, 3, 4, 5} {
+		candidates = append(candidates, fmt.Sprintf("/etc/rc%d.d/S50%s", level, name))
+	}
+	for _, level := range []int{0, 1, 6} {
+		candidates = append(candidates, fmt.Sprintf("/etc/rc%d.d/K02%s", level, name))
+	}
+
+	var files []string
+	for _, path := range candidates {
+		if _, err := os.Lstat(path); err == nil {
+			files = append(files, path)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return files, err
+		}
+	}
+
+	return files, nil
+}
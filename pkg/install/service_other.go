@@ -1,4 +1,4 @@
-//go:build !linux
+//go:build !linux && !windows
 
 // SPDX-FileCopyrightText: 2025 k0s authors
 // SPDX-License-Identifier: Apache-2.0
@@ -10,3 +10,10 @@ import "github.com/kardianos/service"
 func configureServicePlatform(s service.Service, svcConfig *service.Config) {
 	// no-op
 }
+
+// ServiceFiles returns the files of the k0s service for the given role that
+// are installed on the host. There are none besides the service itself on
+// this platform.
+func ServiceFiles(string) ([]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+import (
+	"github.com/kardianos/service"
+)
+
+func configureServicePlatform(s service.Service, svcConfig *service.Config) {
+	if s.Platform() != "windows-service" {
+		return
+	}
+
+	// Start automatically, but only after the services that are started
+	// during boot, so that the network is up. Restart k0s if it terminates
+	// unexpectedly, analogous to the systemd unit.
+	svcConfig.Option = map[string]any{
+		service.StartType:              service.ServiceStartAutomatic,
+		"DelayedAutoStart":             true,
+		service.OnFailure:              service.OnFailureRestart,
+		service.OnFailureDelayDuration: "10s",
+		service.OnFailureResetPeriod:   24 * 60 * 60,
+	}
+}
+
+// ServiceFiles returns the files of the k0s service for the given role that
+// are installed on the host. There are none besides the service itself on
+// this platform.
+func ServiceFiles(string) ([]string, error) {
+	return nil, nil
+}
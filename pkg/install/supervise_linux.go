@@ -0,0 +1,306 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/kardianos/service"
+)
+
+// supervisionSuite describes one of the daemontools-style service supervision
+// suites, i.e. runit and s6. Services are defined in a directory that contains
+// a run script and is linked into the directory that's scanned by the
+// supervisor.
+type supervisionSuite struct {
+	platform string
+	// The directory in which the service directories are created.
+	serviceDir string
+	// Candidates for the directory that's scanned by the supervisor. The
+	// first one that exists is used.
+	scanDirs []string
+	// The binary whose presence indicates that the suite is installed.
+	binary string
+	// The logger that's run as the log service, taking the log directory.
+	logScript string
+
+	start, stop, restart func(dir string) []string
+	isUp                 func(dir string) (bool, error)
+	// Optionally tells the supervisor to rescan its scan directory.
+	rescan func(scanDir string) []string
+}
+
+var runit = &supervisionSuite{
+	platform:   "linux-runit",
+	serviceDir: "/etc/sv",
+	scanDirs:   []string{"/var/service", "/etc/service", "/run/runit/service", "/etc/runit/runsvdir/default"},
+	binary:     "sv",
+	logScript:  "exec svlogd -tt {{.LogDir|shellQuote}}",
+	start:      func(dir string) []string { return []string{"sv", "up", dir} },
+	stop:       func(dir string) []string { return []string{"sv", "down", dir} },
+	restart:    func(dir string) []string { return []string{"sv", "restart", dir} },
+	isUp: func(dir string) (bool, error) {
+		out, err := exec.Command("sv", "status", dir).Output()
+		if err != nil {
+			return false, err
+		}
+		return strings.HasPrefix(string(out), "run:"), nil
+	},
+}
+
+var s6 = &supervisionSuite{
+	platform:   "linux-s6",
+	serviceDir: "/etc/s6/sv",
+	scanDirs:   []string{"/run/service", "/service", "/etc/service"},
+	binary:     "s6-svc",
+	logScript:  "exec s6-log -b T {{.LogDir|shellQuote}}",
+	start:      func(dir string) []string { return []string{"s6-svc", "-u", dir} },
+	stop:       func(dir string) []string { return []string{"s6-svc", "-d", dir} },
+	restart:    func(dir string) []string { return []string{"s6-svc", "-r", dir} },
+	isUp: func(dir string) (bool, error) {
+		out, err := exec.Command("s6-svstat", "-o", "up", dir).Output()
+		if err != nil {
+			return false, err
+		}
+		return strings.TrimSpace(string(out)) == "true", nil
+	},
+	rescan: func(scanDir string) []string { return []string{"s6-svscanctl", "-an", scanDir} },
+}
+
+func (s *supervisionSuite) scanDir() string {
+	for _, dir := range s.scanDirs {
+		if stat, err := os.Stat(dir); err == nil && stat.IsDir() {
+			return dir
+		}
+	}
+	return ""
+}
+
+// supervisionSystem makes a supervision suite available as init system.
+type supervisionSystem struct{ *supervisionSuite }
+
+func (s supervisionSystem) String() string { return s.platform }
+
+func (s supervisionSystem) Detect() bool {
+	_, err := exec.LookPath(s.binary)
+	return err == nil && s.scanDir() != ""
+}
+
+func (s supervisionSystem) Interactive() bool {
+	return os.Getppid() != 1
+}
+
+func (s supervisionSystem) New(_ service.Interface, c *service.Config) (service.Service, error) {
+	return &supervisedService{s.supervisionSuite, c}, nil
+}
+
+// supervisedService is a service that's managed by a supervision suite.
+type supervisedService struct {
+	suite *supervisionSuite
+	*service.Config
+}
+
+const superviseRunScript = `#!/bin/sh
+exec 2>&1
+{{- range .Option.Environment}}
+export {{.|shellQuote}}
+{{- end}}
+exec {{.Path|shellQuote}}{{range .Arguments}} {{.|shellQuote}}{{end}}
+`
+
+const superviseLogScript = `#!/bin/sh
+mkdir -p {{.LogDir|shellQuote}}
+{{.LogCommand}}
+`
+
+func (s *supervisedService) dir() string {
+	return filepath.Join(s.suite.serviceDir, s.Name)
+}
+
+func (s *supervisedService) Install() error {
+	dir := s.dir()
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("Init already exists: %s", dir)
+	}
+	scanDir := s.suite.scanDir()
+	if scanDir == "" {
+		return fmt.Errorf("none of the supervisor's scan directories exist: %s", strings.Join(s.suite.scanDirs, ", "))
+	}
+
+	path := s.Executable
+	if path == "" {
+		var err error
+		if path, err = os.Executable(); err != nil {
+			return err
+		}
+	}
+
+	logDir := filepath.Join("/var/log", s.Name)
+	logCommand, err := renderScript(s.suite.logScript, map[string]any{"LogDir": logDir})
+	if err != nil {
+		return err
+	}
+	runScript, err := renderScript(superviseRunScript, struct {
+		*service.Config
+		Path string
+	}{s.Config, path})
+	if err != nil {
+		return err
+	}
+	logScript, err := renderScript(superviseLogScript, map[string]any{"LogDir": logDir, "LogCommand": strings.TrimSpace(logCommand)})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "log"), 0755); err != nil {
+		return err
+	}
+	for file, content := range map[string]string{"run": runScript, "log/run": logScript} {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0755); err != nil {
+			return err
+		}
+	}
+	// Don't start the service before it's started explicitly. The down file
+	// is removed when starting it, so that it comes up after reboots.
+	if err := os.WriteFile(filepath.Join(dir, "down"), nil, 0644); err != nil {
+		return err
+	}
+
+	if err := os.Symlink(dir, filepath.Join(scanDir, s.Name)); err != nil {
+		return err
+	}
+	if s.suite.rescan != nil {
+		_ = runCommand(s.suite.rescan(scanDir))
+	}
+	return nil
+}
+
+func (s *supervisedService) Uninstall() error {
+	dir := s.dir()
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return service.ErrNotInstalled
+	}
+
+	_ = runCommand(s.suite.stop(dir))
+	for _, scanDir := range s.suite.scanDirs {
+		link := filepath.Join(scanDir, s.Name)
+		if target, err := os.Readlink(link); err == nil && target == dir {
+			if err := os.Remove(link); err != nil {
+				return err
+			}
+			if s.suite.rescan != nil {
+				_ = runCommand(s.suite.rescan(scanDir))
+			}
+		}
+	}
+	return os.RemoveAll(dir)
+}
+
+func (s *supervisedService) Start() error {
+	dir := s.dir()
+	if err := os.Remove(filepath.Join(dir, "down")); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	// Wait for the supervisor to pick up the service.
+	for deadline := time.Now().Add(15 * time.Second); ; {
+		if _, err := os.Stat(filepath.Join(dir, "supervise")); err == nil {
+			break
+		} else if time.Now().After(deadline) {
+			return fmt.Errorf("service %s isn't supervised, is the supervisor running?", s.Name)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	return runCommand(s.suite.start(dir))
+}
+
+func (s *supervisedService) Stop() error {
+	return runCommand(s.suite.stop(s.dir()))
+}
+
+func (s *supervisedService) Restart() error {
+	return runCommand(s.suite.restart(s.dir()))
+}
+
+func (s *supervisedService) Status() (service.Status, error) {
+	dir := s.dir()
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return service.StatusUnknown, service.ErrNotInstalled
+	} else if err != nil {
+		return service.StatusUnknown, err
+	}
+	if _, err := os.Stat(filepath.Join(dir, "supervise")); errors.Is(err, fs.ErrNotExist) {
+		return service.StatusStopped, nil
+	}
+
+	up, err := s.suite.isUp(dir)
+	if err != nil {
+		return service.StatusUnknown, err
+	}
+	if up {
+		return service.StatusRunning, nil
+	}
+	return service.StatusStopped, nil
+}
+
+func (s *supervisedService) Run() error {
+	return errors.New("running under a supervision suite is not supported")
+}
+
+func (s *supervisedService) Logger(chan<- error) (service.Logger, error) {
+	return service.ConsoleLogger, nil
+}
+
+func (s *supervisedService) SystemLogger(chan<- error) (service.Logger, error) {
+	return service.ConsoleLogger, nil
+}
+
+func (s *supervisedService) String() string {
+	if s.DisplayName != "" {
+		return s.DisplayName
+	}
+	return s.Name
+}
+
+func (s *supervisedService) Platform() string {
+	return s.suite.platform
+}
+
+func renderScript(script string, data any) (string, error) {
+	tmpl, err := template.New("").Funcs(template.FuncMap{"shellQuote": shellQuote}).Parse(script)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// shellQuote quotes the given string for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func runCommand(args []string) error {
+	out, err := exec.Command(args[0], args[1:]...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s: %w: %s", args[0], err, msg)
+		}
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	return nil
+}
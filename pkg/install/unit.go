@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kardianos/service"
+)
+
+// UnitOptions customize the systemd unit of the k0s service. They're rendered
+// into the generated unit, so that they survive reinstalls via --force.
+type UnitOptions struct {
+	// Files from which systemd reads environment variables for k0s. Files
+	// prefixed with "-" are ignored if they don't exist.
+	EnvironmentFiles []string
+	// Units after which k0s is started.
+	After []string
+	// Units that are started along with k0s.
+	Wants []string
+	// The CPU quota of the k0s service, as a percentage, e.g. 200%.
+	CPUQuota string
+	// The memory limit of the k0s service, e.g. 4G.
+	MemoryMax string
+	// If k0s doesn't notify systemd within this time, it's restarted.
+	WatchdogTimeout time.Duration
+}
+
+var (
+	cpuQuotaPattern  = regexp.MustCompile(`^[1-9][0-9]*%$`)
+	memoryMaxPattern = regexp.MustCompile(`^([1-9][0-9]*[KMGT]?|[1-9][0-9]?%|100%|infinity)$`)
+	unitNamePattern  = regexp.MustCompile(`^[A-Za-z0-9:_.@\\-]+$`)
+)
+
+// IsZero returns true if no options have been set.
+func (o *UnitOptions) IsZero() bool {
+	return o == nil || (len(o.EnvironmentFiles) == 0 && len(o.After) == 0 && len(o.Wants) == 0 &&
+		o.CPUQuota == "" && o.MemoryMax == "" && o.WatchdogTimeout == 0)
+}
+
+// Validate checks if the options can be rendered into a systemd unit.
+func (o *UnitOptions) Validate() error {
+	var errs []error
+
+	for _, file := range o.EnvironmentFiles {
+		if !filepath.IsAbs(strings.TrimPrefix(file, "-")) {
+			errs = append(errs, fmt.Errorf("environment file %q is not an absolute path", file))
+		}
+	}
+	for _, unit := range slices.Concat(o.After, o.Wants) {
+		if !unitNamePattern.MatchString(unit) {
+			errs = append(errs, fmt.Errorf("invalid unit name %q", unit))
+		}
+	}
+	if o.CPUQuota != "" && !cpuQuotaPattern.MatchString(o.CPUQuota) {
+		errs = append(errs, fmt.Errorf("invalid CPU quota %q, needs to be a percentage", o.CPUQuota))
+	}
+	if o.MemoryMax != "" && !memoryMaxPattern.MatchString(o.MemoryMax) {
+		errs = append(errs, fmt.Errorf("invalid memory limit %q", o.MemoryMax))
+	}
+	if o.WatchdogTimeout != 0 && o.WatchdogTimeout < time.Second {
+		errs = append(errs, errors.New("watchdog timeout needs to be at least one second"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func applyUnitOptions(s service.Service, svcConfig *service.Config, opts *UnitOptions) error {
+	if opts.IsZero() {
+		return nil
+	}
+	if platform := s.Platform(); platform != "linux-systemd" {
+		return fmt.Errorf("unit options are only supported with systemd, not %s", platform)
+	}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	for _, unit := range opts.After {
+		svcConfig.Dependencies = append(svcConfig.Dependencies, "After="+unit)
+	}
+	for _, unit := range opts.Wants {
+		svcConfig.Dependencies = append(svcConfig.Dependencies, "Wants="+unit)
+	}
+	if len(opts.EnvironmentFiles) > 0 {
+		svcConfig.Option["EnvironmentFile"] = opts.EnvironmentFiles
+	}
+	if opts.CPUQuota != "" {
+		svcConfig.Option["CPUQuota"] = opts.CPUQuota
+	}
+	if opts.MemoryMax != "" {
+		svcConfig.Option["MemoryMax"] = opts.MemoryMax
+	}
+	if opts.WatchdogTimeout != 0 {
+		svcConfig.Option["WatchdogSec"] = strconv.FormatInt(int64(opts.WatchdogTimeout/time.Second), 10)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitOptions_Validate(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		opts UnitOptions
+		err  string
+	}{
+		{"valid", UnitOptions{
+			EnvironmentFiles: []string{"/etc/k0s/env", "-/etc/default/k0s"},
+			After:            []string{"network-online.target", "containerd.service"},
+			Wants:            []string{"getty@tty1.service"},
+			CPUQuota:         "200%",
+			MemoryMax:        "4G",
+			WatchdogTimeout:  time.Minute,
+		}, ""},
+		{"relative_env_file", UnitOptions{EnvironmentFiles: []string{"-env"}}, `environment file "-env" is not an absolute path`},
+		{"unit_name", UnitOptions{After: []string{"foo bar.service"}}, `invalid unit name "foo bar.service"`},
+		{"cpu_quota", UnitOptions{CPUQuota: "2"}, `invalid CPU quota "2"`},
+		{"memory_max", UnitOptions{MemoryMax: "4 GiB"}, `invalid memory limit "4 GiB"`},
+		{"watchdog", UnitOptions{WatchdogTimeout: time.Millisecond}, "watchdog timeout needs to be at least one second"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.opts.Validate()
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package install
 import (
 	"errors"
 	"os/exec"
+	"os/user"
 	"slices"
 
 	"github.com/sirupsen/logrus"
@@ -44,22 +45,67 @@ func EnsureControllerUsers(systemUsers *v1beta1.SystemUser, homeDir string) erro
 
 // Deletes existing controller users.
 func DeleteControllerUsers(systemUsers *v1beta1.SystemUser) error {
+	userNames, err := ExistingControllerUsers(systemUsers)
+	errs := []error{err}
+	for _, userName := range userNames {
+		logrus.Debugf("Deleting user %q", userName)
+
+		if err := deleteUser(userName); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Returns the names of the controller users that exist on the host.
+func ExistingControllerUsers(systemUsers *v1beta1.SystemUser) ([]string, error) {
+	var userNames []string
 	var errs []error
 	for _, userName := range getControllerUserNames(systemUsers) {
 		if _, err := users.LookupUID(userName); err == nil {
-			logrus.Debugf("Deleting user %q", userName)
-
-			if err := deleteUser(userName); err != nil {
-				errs = append(errs, err)
-			}
+			userNames = append(userNames, userName)
 		} else if !errors.Is(err, users.ErrNotExist) {
 			errs = append(errs, err)
 		}
 	}
 
+	return userNames, errors.Join(errs...)
+}
+
+// Deletes existing groups that are named after the controller users. Those
+// are usually created along with the users, but may be left behind when the
+// users are deleted.
+func DeleteControllerGroups(systemUsers *v1beta1.SystemUser) error {
+	groupNames, err := ExistingControllerGroups(systemUsers)
+	errs := []error{err}
+	for _, groupName := range groupNames {
+		logrus.Debugf("Deleting group %q", groupName)
+
+		if err := deleteGroup(groupName); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	return errors.Join(errs...)
 }
 
+// Returns the names of the groups named after the controller users that exist
+// on the host.
+func ExistingControllerGroups(systemUsers *v1beta1.SystemUser) ([]string, error) {
+	var groupNames []string
+	var errs []error
+	for _, groupName := range getControllerUserNames(systemUsers) {
+		if _, err := user.LookupGroup(groupName); err == nil {
+			groupNames = append(groupNames, groupName)
+		} else if !errors.Is(err, user.UnknownGroupError(groupName)) {
+			errs = append(errs, err)
+		}
+	}
+
+	return groupNames, errors.Join(errs...)
+}
+
 // nologinShell returns the path to /sbin/nologin, /bin/false or equivalent or an error if neither is available
 func nologinShell() (string, error) {
 	for _, p := range []string{"nologin", "false"} {
@@ -88,6 +134,15 @@ func deleteUser(userName string) error {
 	return err
 }
 
+// deleteGroup deletes system groups with either `groupdel` or `delgroup` command
+func deleteGroup(groupName string) error {
+	_, err := exec.Command("groupdel", groupName).Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		_, err = exec.Command("delgroup", groupName).Output()
+	}
+	return err
+}
+
 // Returns the controller user names.
 func getControllerUserNames(users *v1beta1.SystemUser) []string {
 	userNames := []string{
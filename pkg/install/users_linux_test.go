@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCommands replaces PATH with a directory containing scripts with the
+// given names. Each script records its name and arguments in the returned
+// log file.
+func fakeCommands(t *testing.T, names ...string) (logFile string) {
+	dir := t.TempDir()
+	logFile = filepath.Join(dir, "calls.log")
+	for _, name := range names {
+		script := "#!/bin/sh\necho " + name + ` "$@" >>` + logFile + "\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(script), 0755))
+	}
+	t.Setenv("PATH", dir)
+	return logFile
+}
+
+func readCalls(t *testing.T, logFile string) []string {
+	data, err := os.ReadFile(logFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestDeleteUser(t *testing.T) {
+	t.Run("userdel", func(t *testing.T) {
+		logFile := fakeCommands(t, "userdel", "deluser")
+		require.NoError(t, deleteUser("k0s-test"))
+		assert.Equal(t, []string{"userdel k0s-test"}, readCalls(t, logFile))
+	})
+
+	t.Run("deluser", func(t *testing.T) {
+		logFile := fakeCommands(t, "deluser")
+		require.NoError(t, deleteUser("k0s-test"))
+		assert.Equal(t, []string{"deluser k0s-test"}, readCalls(t, logFile))
+	})
+
+	t.Run("none", func(t *testing.T) {
+		fakeCommands(t)
+		assert.Error(t, deleteUser("k0s-test"))
+	})
+}
+
+func TestDeleteGroup(t *testing.T) {
+	t.Run("groupdel", func(t *testing.T) {
+		logFile := fakeCommands(t, "groupdel", "delgroup")
+		require.NoError(t, deleteGroup("k0s-test"))
+		assert.Equal(t, []string{"groupdel k0s-test"}, readCalls(t, logFile))
+	})
+
+	t.Run("delgroup", func(t *testing.T) {
+		logFile := fakeCommands(t, "delgroup")
+		require.NoError(t, deleteGroup("k0s-test"))
+		assert.Equal(t, []string{"delgroup k0s-test"}, readCalls(t, logFile))
+	})
+
+	t.Run("none", func(t *testing.T) {
+		fakeCommands(t)
+		assert.Error(t, deleteGroup("k0s-test"))
+	})
+}
+
+func TestDeleteControllerGroups(t *testing.T) {
+	if _, err := user.LookupGroup("root"); err != nil {
+		t.Skip("No root group: ", err)
+	}
+
+	systemUsers := &v1beta1.SystemUser{
+		Etcd:          "root",
+		Kine:          "k0s-nonexistent-group",
+		Konnectivity:  "root",
+		KubeAPIServer: "k0s-nonexistent-group",
+		KubeScheduler: "k0s-nonexistent-group",
+	}
+
+	groupNames, err := ExistingControllerGroups(systemUsers)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"root"}, groupNames)
+
+	logFile := fakeCommands(t, "groupdel")
+	require.NoError(t, DeleteControllerGroups(systemUsers))
+	assert.Equal(t, []string{"groupdel root"}, readCalls(t, logFile))
+}
+
+func TestDeleteControllerUsers(t *testing.T) {
+	if _, err := user.Lookup("root"); err != nil {
+		t.Skip("No root user: ", err)
+	}
+
+	systemUsers := &v1beta1.SystemUser{
+		Etcd:          "root",
+		Kine:          "root",
+		Konnectivity:  "root",
+		KubeAPIServer: "root",
+		KubeScheduler: "root",
+	}
+
+	userNames, err := ExistingControllerUsers(systemUsers)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"root"}, userNames)
+
+	logFile := fakeCommands(t, "userdel")
+	require.NoError(t, DeleteControllerUsers(systemUsers))
+	assert.Equal(t, []string{"userdel root"}, readCalls(t, logFile))
+}
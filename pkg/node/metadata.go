@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package node
+
+import (
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kubeletapis "k8s.io/kubelet/pkg/apis"
+)
+
+// DeclaredMetadataAnnotation is the annotation in which a worker declares the
+// labels and taints that are to be reconciled on its Node object.
+const DeclaredMetadataAnnotation = "node.k0sproject.io/declared-metadata"
+
+// DeclaredMetadata is a set of labels and taints that a worker declares for
+// its node. Labels that kubelets aren't allowed to set on their own, and
+// taints in the kubernetes.io and k8s.io namespaces are never touched, so that
+// the declaration can't be used to bypass the NodeRestriction admission
+// plugin.
+type DeclaredMetadata struct {
+	// Labels and taints whose keys start with this prefix are owned by the
+	// declaration, i.e. they're removed from the node if they're not declared.
+	// All others are only added or updated.
+	ManagedPrefix string            `json:"managedPrefix,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Taints        []corev1.Taint    `json:"taints,omitempty"`
+}
+
+// Reconcile updates the labels and taints of the given node so that they
+// match the declaration. Returns whether the node has been changed.
+func (d *DeclaredMetadata) Reconcile(node *corev1.Node) bool {
+	var changed bool
+
+	for key := range node.Labels {
+		if _, declared := d.Labels[key]; !declared && d.manages(key) && isReconcilableLabel(key) {
+			delete(node.Labels, key)
+			changed = true
+		}
+	}
+	for key, value := range d.Labels {
+		if !isReconcilableLabel(key) {
+			continue
+		}
+		if current, ok := node.Labels[key]; !ok || current != value {
+			if node.Labels == nil {
+				node.Labels = make(map[string]string, len(d.Labels))
+			}
+			node.Labels[key] = value
+			changed = true
+		}
+	}
+
+	taints := slices.DeleteFunc(slices.Clone(node.Spec.Taints), func(taint corev1.Taint) bool {
+		return d.manages(taint.Key) && isReconcilableTaint(taint.Key) && !slices.ContainsFunc(d.Taints, func(declared corev1.Taint) bool {
+			return declared.MatchTaint(&taint)
+		})
+	})
+	for _, declared := range d.Taints {
+		if !isReconcilableTaint(declared.Key) {
+			continue
+		}
+		i := slices.IndexFunc(taints, func(taint corev1.Taint) bool { return declared.MatchTaint(&taint) })
+		switch {
+		case i < 0:
+			taints = append(taints, declared)
+		case taints[i].Value != declared.Value:
+			taints[i].Value = declared.Value
+		}
+	}
+	if !slices.EqualFunc(taints, node.Spec.Taints, func(l, r corev1.Taint) bool {
+		return l.MatchTaint(&r) && l.Value == r.Value
+	}) {
+		node.Spec.Taints = taints
+		changed = true
+	}
+
+	return changed
+}
+
+// manages returns whether the given label or taint key is owned by the
+// declaration.
+func (d *DeclaredMetadata) manages(key string) bool {
+	return d.ManagedPrefix != "" && strings.HasPrefix(key, d.ManagedPrefix)
+}
+
+func isReconcilableLabel(key string) bool {
+	return !isKubernetesKey(key) || kubeletapis.IsKubeletLabel(key)
+}
+
+func isReconcilableTaint(key string) bool {
+	return !isKubernetesKey(key)
+}
+
+// isKubernetesKey returns whether the given key belongs to the kubernetes.io
+// or k8s.io namespaces.
+func isKubernetesKey(key string) bool {
+	namespace, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+	for _, reserved := range []string{"kubernetes.io", "k8s.io"} {
+		if namespace == reserved || strings.HasSuffix(namespace, "."+reserved) {
+			return true
+		}
+	}
+	return false
+}
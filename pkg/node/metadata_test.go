@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package node
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeclaredMetadata_Reconcile(t *testing.T) {
+	declared := DeclaredMetadata{
+		ManagedPrefix: "example.com/",
+		Labels: map[string]string{
+			"example.com/tier":                    "gold",
+			"zone":                                "a",
+			"node-restriction.kubernetes.io/pool": "db",
+			"topology.kubernetes.io/zone":         "a",
+		},
+		Taints: []corev1.Taint{
+			{Key: "example.com/dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule},
+			{Key: "node.kubernetes.io/unschedulable", Effect: corev1.TaintEffectNoSchedule},
+		},
+	}
+
+	node := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+			"example.com/tier":     "silver",
+			"example.com/obsolete": "true",
+			"external":             "true",
+			"kubernetes.io/os":     "linux",
+		}},
+		Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+			{Key: "example.com/dedicated", Value: "web", Effect: corev1.TaintEffectNoSchedule},
+			{Key: "example.com/obsolete", Effect: corev1.TaintEffectNoExecute},
+			{Key: "node.kubernetes.io/not-ready", Effect: corev1.TaintEffectNoSchedule},
+		}},
+	}
+
+	assert.True(t, declared.Reconcile(&node))
+	assert.Equal(t, map[string]string{
+		"example.com/tier":            "gold",
+		"external":                    "true",
+		"kubernetes.io/os":            "linux",
+		"zone":                        "a",
+		"topology.kubernetes.io/zone": "a",
+	}, node.Labels)
+	assert.Equal(t, []corev1.Taint{
+		{Key: "example.com/dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "node.kubernetes.io/not-ready", Effect: corev1.TaintEffectNoSchedule},
+	}, node.Spec.Taints)
+
+	assert.False(t, declared.Reconcile(&node), "second reconciliation")
+
+	t.Run("kubernetes_prefix", func(t *testing.T) {
+		declared := DeclaredMetadata{ManagedPrefix: "node-role.kubernetes.io/"}
+		node := corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"node-role.kubernetes.io/control-plane": "true"}},
+			Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+				{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule},
+			}},
+		}
+		assert.False(t, declared.Reconcile(&node))
+	})
+
+	t.Run("without_prefix", func(t *testing.T) {
+		declared := DeclaredMetadata{Labels: map[string]string{"zone": "b"}}
+		node := corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"example.com/tier": "gold"}}}
+		assert.True(t, declared.Reconcile(&node))
+		assert.Equal(t, map[string]string{"example.com/tier": "gold", "zone": "b"}, node.Labels)
+	})
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/k0sproject/k0s/internal/pkg/file"
+
+	apitypes "k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// BindNodeName returns the node name that's stored in the given file. If there
+// is no such file, the node name is rendered from the given template and stored
+// in the file. This binds the node's identity at its first start, and keeps it
+// stable afterwards, even if the template would render differently.
+func BindNodeName(ctx context.Context, path, nameTemplate string) (apitypes.NodeName, error) {
+	if data, err := os.ReadFile(path); err == nil {
+		nodeName := strings.TrimSpace(string(data))
+		if errs := validation.IsDNS1123Subdomain(nodeName); len(errs) > 0 {
+			return "", fmt.Errorf("invalid node name %q in %s: %s", nodeName, path, strings.Join(errs, ", "))
+		}
+		return apitypes.NodeName(nodeName), nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	nodeName, err := renderNodeNameTemplate(nameTemplate, &nodeNameTemplateData{ctx})
+	if err != nil {
+		return "", err
+	}
+	if err := file.WriteContentAtomically(path, []byte(nodeName+"\n"), 0644); err != nil {
+		return "", fmt.Errorf("failed to store node name: %w", err)
+	}
+	return nodeName, nil
+}
+
+func renderNodeNameTemplate(nameTemplate string, data any) (apitypes.NodeName, error) {
+	tmpl, err := template.New("node-name").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid node name template: %w", err)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("failed to render node name template: %w", err)
+	}
+
+	nodeName := strings.ToLower(strings.TrimSpace(rendered.String()))
+	if errs := validation.IsDNS1123Subdomain(nodeName); len(errs) > 0 {
+		return "", fmt.Errorf("node name template rendered an invalid node name %q: %s", nodeName, strings.Join(errs, ", "))
+	}
+	return apitypes.NodeName(nodeName), nil
+}
+
+// The data that's available to node name templates.
+type nodeNameTemplateData struct {
+	ctx context.Context
+}
+
+// Hostname returns the node name that would be used without a template.
+func (d *nodeNameTemplateData) Hostname() (string, error) {
+	nodeName, err := getNodeName(d.ctx, "")
+	return string(nodeName), err
+}
+
+// MachineID returns the machine ID of the host, as found in /etc/machine-id.
+func (*nodeNameTemplateData) MachineID() (string, error) {
+	data, err := os.ReadFile("/etc/machine-id")
+	if err != nil {
+		return "", fmt.Errorf("failed to read machine ID: %w", err)
+	}
+	machineID := strings.TrimSpace(string(data))
+	if machineID == "" || machineID == "uninitialized" {
+		return "", errors.New("machine ID isn't initialized")
+	}
+	return machineID, nil
+}
+
+// RandomID returns a random string of eight lowercase alphanumeric characters.
+func (*nodeNameTemplateData) RandomID() string {
+	return utilrand.String(8)
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package node
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apitypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNodeNameTemplateData struct{}
+
+func (fakeNodeNameTemplateData) Hostname() (string, error)  { return "Host", nil }
+func (fakeNodeNameTemplateData) MachineID() (string, error) { return "", errors.New("no machine ID") }
+func (fakeNodeNameTemplateData) RandomID() string           { return "x7k2m9qz" }
+
+func TestRenderNodeNameTemplate(t *testing.T) {
+	for _, tc := range []struct {
+		name, template string
+		expected       apitypes.NodeName
+		err            string
+	}{
+		{"hostname", "{{.Hostname}}-gpu", "host-gpu", ""},
+		{"random", "pool-a-{{.RandomID}}", "pool-a-x7k2m9qz", ""},
+		{"failing_field", "{{.MachineID}}", "", "no machine ID"},
+		{"unknown_field", "{{.InstanceID}}", "", "can't evaluate field InstanceID"},
+		{"syntax_error", "{{.Hostname", "", "invalid node name template"},
+		{"invalid_name", "pool_{{.RandomID}}", "", `node name template rendered an invalid node name "pool_x7k2m9qz"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nodeName, err := renderNodeNameTemplate(tc.template, fakeNodeNameTemplateData{})
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, nodeName)
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestBindNodeName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "node-name")
+
+	nodeName, err := BindNodeName(t.Context(), path, "pool-{{.RandomID}}")
+	require.NoError(t, err)
+	assert.Regexp(t, `^pool-[a-z0-9]{8}$`, nodeName)
+
+	// The node name is bound on the first start and doesn't change anymore.
+	boundNodeName, err := BindNodeName(t.Context(), path, "other-{{.RandomID}}")
+	require.NoError(t, err)
+	assert.Equal(t, nodeName, boundNodeName)
+
+	require.NoError(t, os.WriteFile(path, []byte("Not_Valid\n"), 0644))
+	_, err = BindNodeName(t.Context(), path, "pool-{{.RandomID}}")
+	assert.ErrorContains(t, err, `invalid node name "Not_Valid"`)
+}
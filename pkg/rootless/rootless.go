@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rootless runs the k0s worker as an unprivileged user. The k0s
+// process re-executes itself in new user, mount and network namespaces, in
+// which it's root. The network namespace is connected to the host's network
+// via pasta or slirp4netns.
+package rootless
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// The network drivers that connect the worker's network namespace to the
+// host's network.
+const (
+	NetworkAuto        = "auto"
+	NetworkPasta       = "pasta"
+	NetworkSlirp4netns = "slirp4netns"
+)
+
+// The environment variable that marks the re-executed k0s process inside the
+// namespaces. It holds the network driver that's been used.
+const networkEnvVar = "_K0S_ROOTLESS_NETWORK"
+
+// Environment describes the namespaces in which the rootless worker runs.
+type Environment struct {
+	// The network driver that connects the network namespace to the host.
+	Network string
+	// The IP address of the node, if it differs from the host's.
+	NodeIP string
+	// The resolv.conf to be used by the worker, if it differs from the host's.
+	ResolvConf string
+	// The delegated cgroup in which the kubelet places the pods.
+	CgroupRoot string
+}
+
+// InNamespace checks if the current process is a rootless worker that's
+// running inside its namespaces.
+func InNamespace() bool {
+	return os.Getenv(networkEnvVar) != ""
+}
+
+// DefaultDataDir returns the data directory of rootless workers. It's located
+// in the user's XDG data directory.
+func DefaultDataDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "k0s"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.New("failed to determine the default data directory, use --data-dir to specify it")
+	}
+	return filepath.Join(home, ".local", "share", "k0s"), nil
+}
@@ -0,0 +1,324 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rootless
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/k0sproject/k0s/internal/pkg/dir"
+	"github.com/k0sproject/k0s/pkg/constant"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// The addresses of the network namespace that are configured by slirp4netns.
+const (
+	slirp4netnsNodeIP     = "10.0.2.100"
+	slirp4netnsNameserver = "10.0.2.3"
+)
+
+// The file descriptor in the re-executed process on which the parent process
+// signals that the namespaces are ready.
+const readyFD = 3
+
+// Run re-executes k0s with the given arguments in new user, mount and network
+// namespaces, connects the network namespace to the host using the given
+// network driver, and waits for the re-executed process to exit. Cancelling
+// the context terminates the re-executed process.
+func Run(ctx context.Context, network string, args []string) error {
+	network, err := resolveNetwork(network)
+	if err != nil {
+		return err
+	}
+
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer readyW.Close()
+
+	cmd := exec.CommandContext(ctx, "/proc/self/exe", args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = append(os.Environ(), networkEnvVar+"="+network)
+	cmd.ExtraFiles = []*os.File{readyR}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWNET,
+		Pdeathsig:  syscall.SIGTERM,
+	}
+	cmd.Cancel = func() error { return cmd.Process.Signal(syscall.SIGTERM) }
+
+	err = cmd.Start()
+	readyR.Close()
+	if err != nil {
+		return fmt.Errorf("failed to re-execute k0s in a user namespace: %w", err)
+	}
+
+	stopNetwork, err := setUpNamespaces(cmd.Process.Pid, network)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return err
+	}
+	defer stopNetwork()
+
+	// Signal the re-executed process that its namespaces are ready.
+	if _, err := readyW.Write([]byte{1}); err != nil {
+		return err
+	}
+	readyW.Close()
+
+	return cmd.Wait()
+}
+
+// Setup prepares the namespaces from within the re-executed k0s process. It
+// waits until the parent process has set up the ID mappings and the network,
+// and then sets up the mounts and the cgroups.
+func Setup(runDir string) (*Environment, error) {
+	ready := os.NewFile(readyFD, "rootless-ready")
+	var b [1]byte
+	_, err := io.ReadFull(ready, b[:])
+	ready.Close()
+	if err != nil {
+		return nil, errors.New("the parent process failed to set up the namespaces")
+	}
+
+	// Don't propagate any mounts back into the host's mount namespace.
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_SLAVE, ""); err != nil {
+		return nil, fmt.Errorf("failed to change the mount propagation: %w", err)
+	}
+
+	env := &Environment{Network: os.Getenv(networkEnvVar)}
+
+	if env.Network == NetworkSlirp4netns {
+		// The host's loopback interface isn't reachable from within the
+		// network namespace, hence use the DNS forwarder of slirp4netns.
+		if err := dir.Init(runDir, constant.RunDirMode); err != nil {
+			return nil, err
+		}
+		env.NodeIP = slirp4netnsNodeIP
+		env.ResolvConf = filepath.Join(runDir, "resolv.conf")
+		if err := os.WriteFile(env.ResolvConf, []byte("nameserver "+slirp4netnsNameserver+"\n"), 0644); err != nil {
+			return nil, err
+		}
+		if err := unix.Mount(env.ResolvConf, "/etc/resolv.conf", "", unix.MS_BIND, ""); err != nil {
+			return nil, fmt.Errorf("failed to mount %s: %w", env.ResolvConf, err)
+		}
+	}
+
+	if env.CgroupRoot, err = delegateCgroup("/proc/self/cgroup", "/sys/fs/cgroup"); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+func resolveNetwork(network string) (string, error) {
+	switch network {
+	case NetworkPasta, NetworkSlirp4netns:
+		if _, err := exec.LookPath(network); err != nil {
+			return "", fmt.Errorf("network driver %s not found: %w", network, err)
+		}
+		return network, nil
+	case NetworkAuto, "":
+		for _, network := range []string{NetworkPasta, NetworkSlirp4netns} {
+			if _, err := exec.LookPath(network); err == nil {
+				return network, nil
+			}
+		}
+		return "", errors.New("neither pasta nor slirp4netns found, one of them is required to run rootless")
+	default:
+		return "", fmt.Errorf("unsupported network driver %q", network)
+	}
+}
+
+// setUpNamespaces writes the ID mappings of the user namespace of the process
+// with the given PID, and connects its network namespace to the host. Returns
+// a function that stops the network driver.
+func setUpNamespaces(pid int, network string) (func(), error) {
+	u, err := user.Current()
+	if err != nil {
+		return nil, err
+	}
+	if err := writeIDMappings(pid, u); err != nil {
+		return nil, fmt.Errorf("failed to set up the ID mappings: %w", err)
+	}
+
+	switch network {
+	case NetworkPasta:
+		// Pasta forks into the background as soon as the network is set up,
+		// and terminates along with the network namespace.
+		if out, err := exec.Command("pasta", "--config-net", "--quiet", strconv.Itoa(pid)).CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("pasta failed: %w: %s", err, strings.TrimSpace(string(out)))
+		}
+		return func() {}, nil
+
+	default:
+		return startSlirp4netns(pid)
+	}
+}
+
+func startSlirp4netns(pid int) (func(), error) {
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer readyR.Close()
+
+	cmd := exec.Command("slirp4netns",
+		"--configure", "--mtu=65520", "--disable-host-loopback",
+		"--ready-fd=3", strconv.Itoa(pid), "tap0",
+	)
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = []*os.File{readyW}
+	cmd.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+	err = cmd.Start()
+	readyW.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start slirp4netns: %w", err)
+	}
+
+	// Slirp4netns writes to the ready file descriptor once the network has
+	// been configured. It's closed without writing if slirp4netns fails.
+	var b [1]byte
+	if _, err := io.ReadFull(readyR, b[:]); err != nil {
+		_ = cmd.Process.Kill()
+		return nil, fmt.Errorf("slirp4netns failed: %w", cmd.Wait())
+	}
+
+	return func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}, nil
+}
+
+// writeIDMappings maps the given user to root in the user namespace of the
+// process with the given PID, along with the user's subordinate IDs. If there
+// are none, only the user itself is mapped.
+func writeIDMappings(pid int, u *user.User) error {
+	uid, gid := os.Getuid(), os.Getgid()
+	subUIDs, err := subIDRange("/etc/subuid", u.Username, uid)
+	if err != nil {
+		return err
+	}
+	subGIDs, err := subIDRange("/etc/subgid", u.Username, uid)
+	if err != nil {
+		return err
+	}
+
+	if subUIDs == nil || subGIDs == nil {
+		logrus.Warnf("No subordinate IDs found for user %s in /etc/subuid and /etc/subgid, containers will only be able to use a single user and group", u.Username)
+		procDir := filepath.Join("/proc", strconv.Itoa(pid))
+		if err := os.WriteFile(filepath.Join(procDir, "uid_map"), fmt.Appendf(nil, "0 %d 1\n", uid), 0); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(procDir, "setgroups"), []byte("deny"), 0); err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(procDir, "gid_map"), fmt.Appendf(nil, "0 %d 1\n", gid), 0)
+	}
+
+	for _, mapping := range []struct {
+		cmd    string
+		id     int
+		subIDs *idRange
+	}{
+		{"newuidmap", uid, subUIDs},
+		{"newgidmap", gid, subGIDs},
+	} {
+		out, err := exec.Command(mapping.cmd, strconv.Itoa(pid),
+			"0", strconv.Itoa(mapping.id), "1",
+			"1", strconv.Itoa(mapping.subIDs.start), strconv.Itoa(mapping.subIDs.count),
+		).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("%s failed: %w: %s", mapping.cmd, err, strings.TrimSpace(string(out)))
+		}
+	}
+
+	return nil
+}
+
+// A range of subordinate IDs.
+type idRange struct {
+	start, count int
+}
+
+// subIDRange returns the first range of subordinate IDs of the given user in
+// the given file, or nil if there's none.
+func subIDRange(path, name string, id int) (*idRange, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	owners := []string{name, strconv.Itoa(id)}
+	lines := bufio.NewScanner(f)
+	for lines.Scan() {
+		fields := strings.Split(strings.TrimSpace(lines.Text()), ":")
+		if len(fields) != 3 || (fields[0] != owners[0] && fields[0] != owners[1]) {
+			continue
+		}
+		start, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		count, err := strconv.Atoi(fields[2])
+		if err != nil || count < 1 {
+			continue
+		}
+		return &idRange{start, count}, nil
+	}
+
+	return nil, lines.Err()
+}
+
+// delegateCgroup moves the current process into a leaf of its cgroup, and
+// enables the available controllers for the cgroup's children, so that the
+// kubelet can create the pod cgroups next to it. Returns the cgroup's path.
+// This requires cgroup v2 and the cgroup to be delegated to the user.
+func delegateCgroup(procCgroupPath, cgroupRoot string) (string, error) {
+	data, err := os.ReadFile(procCgroupPath)
+	if err != nil {
+		return "", err
+	}
+	path, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "0::")
+	if !ok || strings.Contains(path, "\n") {
+		return "", errors.New("running rootless requires cgroup v2")
+	}
+
+	cgroupDir := filepath.Join(cgroupRoot, path)
+	leafDir := filepath.Join(cgroupDir, "k0s")
+	if err := os.Mkdir(leafDir, 0755); err != nil && !errors.Is(err, os.ErrExist) {
+		return "", fmt.Errorf("cgroup %s isn't delegated to the user: %w", path, err)
+	}
+	if err := os.WriteFile(filepath.Join(leafDir, "cgroup.procs"), []byte("0"), 0); err != nil {
+		return "", fmt.Errorf("failed to move k0s into cgroup %s: %w", filepath.Join(path, "k0s"), err)
+	}
+
+	controllers, err := os.ReadFile(filepath.Join(cgroupDir, "cgroup.controllers"))
+	if err != nil {
+		return "", err
+	}
+	for controller := range strings.FieldsSeq(string(controllers)) {
+		if err := os.WriteFile(filepath.Join(cgroupDir, "cgroup.subtree_control"), []byte("+"+controller), 0); err != nil {
+			logrus.WithError(err).Warnf("Failed to enable the %s cgroup controller", controller)
+		}
+	}
+
+	return path, nil
+}
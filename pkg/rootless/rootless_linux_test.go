@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rootless
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubIDRange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "subuid")
+	require.NoError(t, os.WriteFile(path, []byte(`root:100000:65536
+malformed
+k0s:invalid:65536
+k0s:200000:65536
+1001:300000:65536
+`), 0644))
+
+	for _, test := range []struct {
+		name     string
+		user     string
+		id       int
+		expected *idRange
+	}{
+		{"by_name", "k0s", 1000, &idRange{200000, 65536}},
+		{"by_id", "other", 1001, &idRange{300000, 65536}},
+		{"none", "other", 1002, nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			subIDs, err := subIDRange(path, test.user, test.id)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, subIDs)
+		})
+	}
+
+	t.Run("no_file", func(t *testing.T) {
+		subIDs, err := subIDRange(filepath.Join(t.TempDir(), "subuid"), "k0s", 1000)
+		assert.NoError(t, err)
+		assert.Nil(t, subIDs)
+	})
+}
+
+func TestDelegateCgroup(t *testing.T) {
+	procCgroup := filepath.Join(t.TempDir(), "cgroup")
+	cgroupRoot := t.TempDir()
+	cgroupDir := filepath.Join(cgroupRoot, "user.slice", "user-1000.slice")
+	require.NoError(t, os.MkdirAll(cgroupDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(cgroupDir, "cgroup.controllers"), []byte("cpu memory pids\n"), 0644))
+
+	t.Run("cgroup_v1", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(procCgroup, []byte("12:pids:/user.slice\n0::/user.slice\n"), 0644))
+		_, err := delegateCgroup(procCgroup, cgroupRoot)
+		assert.ErrorContains(t, err, "requires cgroup v2")
+	})
+
+	t.Run("cgroup_v2", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(procCgroup, []byte("0::/user.slice/user-1000.slice\n"), 0644))
+		path, err := delegateCgroup(procCgroup, cgroupRoot)
+		require.NoError(t, err)
+		assert.Equal(t, "/user.slice/user-1000.slice", path)
+		assert.FileExists(t, filepath.Join(cgroupDir, "k0s", "cgroup.procs"))
+		subtreeControl, err := os.ReadFile(filepath.Join(cgroupDir, "cgroup.subtree_control"))
+		require.NoError(t, err)
+		assert.Equal(t, "+pids", string(subtreeControl), "the controllers should be enabled one by one")
+	})
+}
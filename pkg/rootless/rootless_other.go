@@ -0,0 +1,21 @@
+//go:build !linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rootless
+
+import (
+	"context"
+	"errors"
+)
+
+var errUnsupported = errors.New("running rootless is only supported on Linux")
+
+// Run re-executes k0s with the given arguments in new user, mount and network
+// namespaces, connects the network namespace to the host using the given
+// network driver, and waits for the re-executed process to exit.
+func Run(context.Context, string, []string) error { return errUnsupported }
+
+// Setup prepares the namespaces from within the re-executed k0s process.
+func Setup(string) (*Environment, error) { return nil, errUnsupported }
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package selinux manages the SELinux policy module of k0s. The module labels
+// the container runtime binaries and state in the k0s data directory, so that
+// they're treated by the container-selinux policy in the same way as the ones
+// of a distribution-provided container runtime.
+package selinux
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ModuleName is the name of the k0s policy module.
+const ModuleName = "k0s"
+
+// A fileContext of the k0s policy module.
+type fileContext struct {
+	// Regular expression for the paths, relative to the data directory.
+	pattern string
+	// The CIL file type, i.e. file, dir or any.
+	fileType string
+	// The SELinux type of the matching files.
+	seType string
+	// The paths whose labels are verified, relative to the data directory.
+	verify []string
+}
+
+var fileContexts = []fileContext{
+	{`bin/containerd.*`, "file", "container_runtime_exec_t", []string{"bin/containerd", "bin/containerd-shim-runc-v2"}},
+	{`bin/runc`, "file", "container_runtime_exec_t", []string{"bin/runc"}},
+	{`containerd(/.*)?`, "any", "container_var_lib_t", []string{"containerd"}},
+	{`containerd/io\.containerd\.snapshotter\..*/snapshots(/.*)?`, "any", "container_ro_file_t", nil},
+}
+
+// PolicyModule returns the k0s policy module for the given data directory, in
+// the Common Intermediate Language (CIL) that's understood by semodule.
+func PolicyModule(dataDir string) ([]byte, error) {
+	if !filepath.IsAbs(dataDir) || strings.ContainsAny(dataDir, "\"\n") {
+		return nil, fmt.Errorf("unsupported data directory for SELinux policy module: %q", dataDir)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("; SELinux policy module for k0s, generated by k0s selinux install\n")
+	prefix := regexp.QuoteMeta(filepath.Clean(dataDir))
+	for _, fc := range fileContexts {
+		fmt.Fprintf(&buf, "(filecon \"%s/%s\" %s (system_u object_r %s ((s0) (s0))))\n", prefix, fc.pattern, fc.fileType, fc.seType)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// LabelMismatch describes a file in the data directory whose SELinux label
+// doesn't match the k0s policy module.
+type LabelMismatch struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+func (m *LabelMismatch) String() string {
+	return fmt.Sprintf("%s has SELinux type %s instead of %s", m.Path, m.Actual, m.Expected)
+}
+
+// typeOf returns the type of the given SELinux security context.
+func typeOf(context string) string {
+	parts := strings.SplitN(strings.TrimRight(context, "\x00"), ":", 4)
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[2]
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package selinux
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+const selinuxFS = "/sys/fs/selinux"
+
+// Enabled returns true if SELinux is enabled on this host.
+func Enabled() bool {
+	var stat unix.Statfs_t
+	return unix.Statfs(selinuxFS, &stat) == nil && stat.Type == unix.SELINUX_MAGIC
+}
+
+// Enforcing returns true if SELinux is enabled in enforcing mode.
+func Enforcing() bool {
+	enforce, err := os.ReadFile(filepath.Join(selinuxFS, "enforce"))
+	return err == nil && strings.TrimSpace(string(enforce)) == "1"
+}
+
+// InstallModule installs or upgrades the k0s policy module for the given data
+// directory, and relabels the files in the data directory accordingly.
+func InstallModule(ctx context.Context, dataDir string) error {
+	module, err := PolicyModule(dataDir)
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "k0s-selinux-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	modulePath := filepath.Join(tmpDir, ModuleName+".cil")
+	if err := os.WriteFile(modulePath, module, 0600); err != nil {
+		return err
+	}
+
+	if err := run(ctx, "semodule", "-i", modulePath); err != nil {
+		return fmt.Errorf("failed to install SELinux policy module (is container-selinux installed?): %w", err)
+	}
+
+	return RestoreLabels(ctx, dataDir)
+}
+
+// RestoreLabels relabels the files in the given data directory that are
+// covered by the k0s policy module.
+func RestoreLabels(ctx context.Context, dataDir string) error {
+	var paths []string
+	for _, dir := range []string{"bin", "containerd"} {
+		path := filepath.Join(dataDir, dir)
+		if _, err := os.Lstat(path); err == nil {
+			paths = append(paths, path)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	if err := run(ctx, "restorecon", append([]string{"-R"}, paths...)...); err != nil {
+		return fmt.Errorf("failed to restore SELinux labels: %w", err)
+	}
+	return nil
+}
+
+// VerifyLabels checks the SELinux labels of the files in the given data
+// directory against the k0s policy module. Files that don't exist are skipped.
+func VerifyLabels(dataDir string) ([]LabelMismatch, error) {
+	var mismatches []LabelMismatch
+	for _, fc := range fileContexts {
+		for _, path := range fc.verify {
+			path = filepath.Join(dataDir, path)
+			label, err := getLabel(path)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to get SELinux label of %s: %w", path, err)
+			}
+			if actual := typeOf(label); actual != fc.seType {
+				mismatches = append(mismatches, LabelMismatch{path, fc.seType, actual})
+			}
+		}
+	}
+	return mismatches, nil
+}
+
+// CheckLabels verifies the SELinux labels in the given data directory, if
+// SELinux is enabled. Wrong labels are restored, if possible. Returns an error
+// that describes the labels that are still wrong.
+func CheckLabels(ctx context.Context, dataDir string) error {
+	if !Enabled() {
+		return nil
+	}
+
+	mismatches, err := VerifyLabels(dataDir)
+	if err != nil || len(mismatches) == 0 {
+		return err
+	}
+
+	if err := RestoreLabels(ctx, dataDir); err != nil {
+		return err
+	}
+	if mismatches, err = VerifyLabels(dataDir); err != nil || len(mismatches) == 0 {
+		return err
+	}
+
+	var errs []error
+	for _, mismatch := range mismatches {
+		errs = append(errs, errors.New(mismatch.String()))
+	}
+	return fmt.Errorf("SELinux labels don't match the k0s policy module, which might not be installed: %w", errors.Join(errs...))
+}
+
+func getLabel(path string) (string, error) {
+	buf := make([]byte, 256)
+	for {
+		n, err := unix.Lgetxattr(path, "security.selinux", buf)
+		if errors.Is(err, unix.ERANGE) {
+			buf = make([]byte, len(buf)*2)
+			continue
+		}
+		if err != nil {
+			return "", &fs.PathError{Op: "getxattr", Path: path, Err: err}
+		}
+		return string(buf[:n]), nil
+	}
+}
+
+func run(ctx context.Context, name string, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+//go:build !linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package selinux
+
+import (
+	"context"
+	"errors"
+)
+
+var errUnsupported = errors.New("SELinux is only supported on Linux")
+
+// Enabled returns true if SELinux is enabled on this host.
+func Enabled() bool { return false }
+
+// Enforcing returns true if SELinux is enabled in enforcing mode.
+func Enforcing() bool { return false }
+
+// InstallModule installs or upgrades the k0s policy module for the given data
+// directory, and relabels the files in the data directory accordingly.
+func InstallModule(context.Context, string) error { return errUnsupported }
+
+// RestoreLabels relabels the files in the given data directory that are
+// covered by the k0s policy module.
+func RestoreLabels(context.Context, string) error { return errUnsupported }
+
+// VerifyLabels checks the SELinux labels of the files in the given data
+// directory against the k0s policy module.
+func VerifyLabels(string) ([]LabelMismatch, error) { return nil, errUnsupported }
+
+// CheckLabels verifies the SELinux labels in the given data directory, if
+// SELinux is enabled.
+func CheckLabels(context.Context, string) error { return nil }
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package selinux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyModule(t *testing.T) {
+	module, err := PolicyModule("/var/lib/k0s.d/")
+	require.NoError(t, err)
+	assert.Equal(t, `; SELinux policy module for k0s, generated by k0s selinux install
+(filecon "/var/lib/k0s\.d/bin/containerd.*" file (system_u object_r container_runtime_exec_t ((s0) (s0))))
+(filecon "/var/lib/k0s\.d/bin/runc" file (system_u object_r container_runtime_exec_t ((s0) (s0))))
+(filecon "/var/lib/k0s\.d/containerd(/.*)?" any (system_u object_r container_var_lib_t ((s0) (s0))))
+(filecon "/var/lib/k0s\.d/containerd/io\.containerd\.snapshotter\..*/snapshots(/.*)?" any (system_u object_r container_ro_file_t ((s0) (s0))))
+`, string(module))
+
+	_, err = PolicyModule("var/lib/k0s")
+	assert.ErrorContains(t, err, "unsupported data directory")
+	_, err = PolicyModule(`/var/lib/"k0s"`)
+	assert.ErrorContains(t, err, "unsupported data directory")
+}
+
+func TestTypeOf(t *testing.T) {
+	assert.Equal(t, "container_runtime_exec_t", typeOf("system_u:object_r:container_runtime_exec_t:s0\x00"))
+	assert.Equal(t, "bin_t", typeOf("unconfined_u:object_r:bin_t:s0:c0.c1023"))
+	assert.Empty(t, typeOf("garbage"))
+}
@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/rootless"
+)
+
+// The period of the CPU limits, in microseconds. This is the kernel's default
+// for cpu.max.
+const cpuPeriod = 100000
+
+// The cgroup in which the supervised processes get cgroups of their own. This
+// is the cgroup in which k0s has been started. It's set up when the first
+// process with resource limits is started.
+var cgroupParent = func() (string, error) {
+	if os.Geteuid() != 0 || rootless.InNamespace() {
+		return "", fmt.Errorf("%w: not running as root", errors.ErrUnsupported)
+	}
+	return hostCgroups.parent()
+}
+
+var hostCgroups = cgroupHierarchy{root: "/sys/fs/cgroup", procDir: "/proc"}
+
+type cgroupHierarchy struct {
+	root, procDir string
+
+	mu   sync.Mutex
+	path string
+}
+
+// Returns the cgroup in which the supervised processes get cgroups of their
+// own, setting it up if it hasn't been set up successfully yet.
+func (h *cgroupHierarchy) parent() (string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.path != "" {
+		return h.path, nil
+	}
+
+	path, err := setupCgroupParent(h.root, h.procDir)
+	if err != nil {
+		return "", err
+	}
+	h.path = path
+	return path, nil
+}
+
+// Prepares the cgroup in which k0s has been started, so that the supervised
+// processes can get cgroups of their own in it. The cgroup v2 hierarchy doesn't
+// allow processes in cgroups that delegate controllers to their children, so
+// k0s and the processes that it has started so far move to a child cgroup
+// named k0s. Returns the path of the cgroup.
+func setupCgroupParent(root, procDir string) (string, error) {
+	own, err := (&usageReader{procDir: procDir}).cgroupOf("self")
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrUnsupported, err)
+	}
+
+	parent := filepath.Join(root, filepath.FromSlash(own))
+	controllers, err := os.ReadFile(filepath.Join(parent, "cgroup.controllers"))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrUnsupported, err)
+	}
+
+	// The root cgroup is exempt, and it would be fatal to move all the
+	// processes of the host.
+	if own != "/" {
+		if err := moveProcesses(parent, filepath.Join(parent, "k0s")); err != nil {
+			return "", err
+		}
+	}
+
+	// Delegate the controllers that are needed to enforce resource limits, as
+	// far as they're available.
+	var enable []string
+	for controller := range strings.FieldsSeq(string(controllers)) {
+		if controller == "cpu" || controller == "memory" {
+			enable = append(enable, "+"+controller)
+		}
+	}
+	if len(enable) > 0 {
+		if err := os.WriteFile(filepath.Join(parent, "cgroup.subtree_control"), []byte(strings.Join(enable, " ")), 0644); err != nil {
+			return "", fmt.Errorf("failed to enable the %s controllers in %s: %w", strings.Join(enable, " "), parent, err)
+		}
+	}
+
+	return parent, nil
+}
+
+// Moves all processes of the cgroup at the given path into the child cgroup at
+// the given path. Processes that are being forked while moving may still end
+// up in the cgroup, hence the moving is repeated until no new processes
+// appear.
+func moveProcesses(cgroup, child string) error {
+	if err := os.Mkdir(child, 0755); err != nil && !errors.Is(err, os.ErrExist) {
+		return err
+	}
+	procs, err := os.OpenFile(filepath.Join(child, "cgroup.procs"), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer procs.Close()
+
+	moved := make(map[string]bool)
+	for {
+		data, err := os.ReadFile(filepath.Join(cgroup, "cgroup.procs"))
+		if err != nil {
+			return err
+		}
+
+		var movedAny bool
+		for pid := range strings.FieldsSeq(string(data)) {
+			if moved[pid] {
+				continue
+			}
+			// The kernel accepts only a single PID per write.
+			if _, err := procs.WriteString(pid + "\n"); err != nil && !errors.Is(err, syscall.ESRCH) {
+				return fmt.Errorf("failed to move process %s into %s: %w", pid, child, err)
+			}
+			moved[pid], movedAny = true, true
+		}
+		if !movedAny {
+			return nil
+		}
+	}
+}
+
+// Creates the cgroup of the supervised process and applies its resource
+// limits. Returns an empty path if the process has no resource limits, in
+// which case it stays in the cgroup of k0s.
+func (s *Supervisor) prepareCgroup() (string, error) {
+	if s.Resources == nil {
+		return "", nil
+	}
+	path, err := cgroupParent()
+	if err == nil {
+		path, err = prepareCgroup(path, s.Name, s.Resources)
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot enforce resource limits: %w", err)
+	}
+	return path, nil
+}
+
+func prepareCgroup(parent, name string, resources *v1beta1.ProcessResources) (string, error) {
+	path := filepath.Join(parent, name)
+	if err := os.Mkdir(path, 0755); err != nil && !errors.Is(err, os.ErrExist) {
+		return "", err
+	}
+
+	// Always write the limits, so that the ones of previous runs are lifted.
+	limits := []struct {
+		file, value string
+		limited     bool
+	}{
+		{"cpu.max", fmt.Sprintf("max %d", cpuPeriod), false},
+		{"memory.max", "max", false},
+	}
+	if resources != nil && resources.CPU != nil {
+		limits[0].value = fmt.Sprintf("%d %d", resources.CPU.MilliValue()*cpuPeriod/1000, cpuPeriod)
+		limits[0].limited = true
+	}
+	if resources != nil && resources.Memory != nil {
+		limits[1].value = strconv.FormatInt(resources.Memory.Value(), 10)
+		limits[1].limited = true
+	}
+	for _, limit := range limits {
+		err := os.WriteFile(filepath.Join(path, limit.file), []byte(limit.value), 0644)
+		// The file doesn't exist if the controller isn't available, which is
+		// fine as long as there's no limit to be enforced.
+		if err != nil && (limit.limited || !errors.Is(err, os.ErrNotExist)) {
+			return "", fmt.Errorf("failed to set %s of %s: %w", limit.file, path, err)
+		}
+	}
+
+	return path, nil
+}
+
+// Lets the process be started directly in the cgroup at the given path, so
+// that it can't fork any children outside of it. Returns a function that
+// releases the cgroup's file descriptor after the process has been started.
+func startInCgroup(attr *syscall.SysProcAttr, path string) (func(), error) {
+	dir, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	attr.UseCgroupFD, attr.CgroupFD = true, int(dir.Fd())
+	return func() { _ = dir.Close() }, nil
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Sets up a fake cgroup hierarchy in which k0s runs in the given cgroup, along
+// with the given processes.
+func fakeCgroupHierarchy(t *testing.T, cgroup string, pids ...string) *cgroupHierarchy {
+	root, procDir := t.TempDir(), t.TempDir()
+	parent := filepath.Join(root, filepath.FromSlash(cgroup))
+	require.NoError(t, os.MkdirAll(parent, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(parent, "cgroup.controllers"), []byte("cpuset cpu io memory pids\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(parent, "cgroup.procs"), []byte(strings.Join(pids, "\n")+"\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(procDir, "self"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(procDir, "self", "cgroup"), []byte("0::"+cgroup+"\n"), 0644))
+	return &cgroupHierarchy{root: root, procDir: procDir}
+}
+
+func TestSetupCgroupParent(t *testing.T) {
+	readFile := func(t *testing.T, path string) string {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	t.Run("service", func(t *testing.T) {
+		// k0s and the processes it has started so far.
+		h := fakeCgroupHierarchy(t, "/system.slice/k0scontroller.service", "42", "43")
+		parent := filepath.Join(h.root, "system.slice", "k0scontroller.service")
+
+		path, err := setupCgroupParent(h.root, h.procDir)
+		require.NoError(t, err)
+		assert.Equal(t, parent, path)
+		assert.Equal(t, "42\n43\n", readFile(t, filepath.Join(parent, "k0s", "cgroup.procs")))
+		assert.Equal(t, "+cpu +memory", readFile(t, filepath.Join(parent, "cgroup.subtree_control")))
+	})
+
+	t.Run("root", func(t *testing.T) {
+		h := fakeCgroupHierarchy(t, "/", "1", "42")
+
+		path, err := setupCgroupParent(h.root, h.procDir)
+		require.NoError(t, err)
+		assert.Equal(t, h.root, path)
+		assert.NoDirExists(t, filepath.Join(h.root, "k0s"), "processes in the root cgroup must not be moved")
+		assert.Equal(t, "+cpu +memory", readFile(t, filepath.Join(h.root, "cgroup.subtree_control")))
+	})
+
+	t.Run("subtree_control_fails", func(t *testing.T) {
+		h := fakeCgroupHierarchy(t, "/system.slice/k0scontroller.service", "42")
+		parent := filepath.Join(h.root, "system.slice", "k0scontroller.service")
+		require.NoError(t, os.Mkdir(filepath.Join(parent, "cgroup.subtree_control"), 0755))
+
+		_, err := setupCgroupParent(h.root, h.procDir)
+		assert.ErrorContains(t, err, "failed to enable the +cpu +memory controllers")
+
+		// The setup is retried after failures.
+		_, err = h.parent()
+		assert.Error(t, err)
+		require.NoError(t, os.Remove(filepath.Join(parent, "cgroup.subtree_control")))
+		path, err := h.parent()
+		require.NoError(t, err)
+		assert.Equal(t, parent, path)
+	})
+}
+
+func TestSupervisor_PrepareCgroup_OnlyOneLimited(t *testing.T) {
+	// etcd has been started already and runs alongside k0s (PID 42).
+	h := fakeCgroupHierarchy(t, "/system.slice/k0scontroller.service", "42", "43")
+	parent := filepath.Join(h.root, "system.slice", "k0scontroller.service")
+	origCgroupParent := cgroupParent
+	t.Cleanup(func() { cgroupParent = origCgroupParent })
+	cgroupParent = h.parent
+
+	// Processes without limits stay in the cgroup of k0s, which is left alone.
+	path, err := (&Supervisor{Name: "etcd"}).prepareCgroup()
+	require.NoError(t, err)
+	assert.Empty(t, path)
+	assert.NoDirExists(t, filepath.Join(parent, "k0s"))
+
+	memory := resource.MustParse("1Gi")
+	path, err = (&Supervisor{Name: "kube-apiserver", Resources: &v1beta1.ProcessResources{Memory: &memory}}).prepareCgroup()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(parent, "kube-apiserver"), path)
+
+	// Both k0s and etcd have been moved out of the way of the controllers.
+	procs, err := os.ReadFile(filepath.Join(parent, "k0s", "cgroup.procs"))
+	require.NoError(t, err)
+	assert.Equal(t, "42\n43\n", string(procs))
+	limit, err := os.ReadFile(filepath.Join(path, "memory.max"))
+	require.NoError(t, err)
+	assert.Equal(t, "1073741824", string(limit))
+}
+
+func TestPrepareCgroup(t *testing.T) {
+	cpu, memory := resource.MustParse("250m"), resource.MustParse("512Mi")
+	limits := &v1beta1.ProcessResources{CPU: &cpu, Memory: &memory}
+
+	readFile := func(t *testing.T, path string) string {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	t.Run("limits", func(t *testing.T) {
+		parent := t.TempDir()
+		path, err := prepareCgroup(parent, "etcd", limits)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(parent, "etcd"), path)
+		assert.Equal(t, "25000 100000", readFile(t, filepath.Join(path, "cpu.max")))
+		assert.Equal(t, "536870912", readFile(t, filepath.Join(path, "memory.max")))
+
+		// The limits are lifted when preparing the existing cgroup again.
+		_, err = prepareCgroup(parent, "etcd", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "max 100000", readFile(t, filepath.Join(path, "cpu.max")))
+		assert.Equal(t, "max", readFile(t, filepath.Join(path, "memory.max")))
+	})
+
+	t.Run("no_parent", func(t *testing.T) {
+		_, err := prepareCgroup(filepath.Join(t.TempDir(), "missing"), "etcd", nil)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestStartInCgroup(t *testing.T) {
+	var attr syscall.SysProcAttr
+	release, err := startInCgroup(&attr, t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(release)
+	assert.True(t, attr.UseCgroupFD)
+	assert.Positive(t, attr.CgroupFD)
+
+	_, err = startInCgroup(&syscall.SysProcAttr{}, filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,23 @@
+//go:build !linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"errors"
+	"syscall"
+)
+
+// Supervised processes get cgroups of their own only on Linux.
+func (s *Supervisor) prepareCgroup() (string, error) {
+	if s.Resources != nil {
+		return "", errors.New("resource limits are only supported on Linux")
+	}
+	return "", nil
+}
+
+func startInCgroup(*syscall.SysProcAttr, string) (func(), error) {
+	return nil, errors.ErrUnsupported
+}
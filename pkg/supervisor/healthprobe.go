@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"slices"
+	"sync"
+	"time"
+)
+
+// HealthProbe checks the health of a supervised process while it's running.
+// The process is restarted once the probe failed a number of times in a row.
+// Exactly one of HTTPGet, TCPSocket and Exec has to be set.
+type HealthProbe struct {
+	// The URL to which HTTP GET requests are sent. The probe succeeds if the
+	// response status is 2xx. Certificates of HTTPS endpoints aren't verified.
+	HTTPGet string
+	// The address to which TCP connections are made. The probe succeeds if the
+	// connection can be established.
+	TCPSocket string
+	// The command to be executed. The probe succeeds if it exits with zero.
+	Exec []string
+
+	// The time to wait after the process has been started before probing it.
+	// Defaults to 30 seconds.
+	InitialDelay time.Duration
+	// The time between probes. Defaults to 10 seconds.
+	Period time.Duration
+	// The time after which a probe fails. Defaults to 3 seconds.
+	Timeout time.Duration
+	// The number of failed probes in a row after which the process is
+	// restarted. Defaults to 3.
+	FailureThreshold int
+}
+
+// UnhealthyHandler is called when a supervisor restarts its process because it
+// failed its health probe.
+type UnhealthyHandler func(component string, err error)
+
+var unhealthyHandlers struct {
+	mu       sync.Mutex
+	handlers []*UnhealthyHandler
+}
+
+// OnUnhealthy registers a handler that's called whenever a supervisor restarts
+// its process because it failed its health probe. Returns a function that
+// unregisters the handler.
+func OnUnhealthy(handler UnhealthyHandler) (unregister func()) {
+	unhealthyHandlers.mu.Lock()
+	defer unhealthyHandlers.mu.Unlock()
+	h := &handler
+	unhealthyHandlers.handlers = append(unhealthyHandlers.handlers, h)
+	return func() {
+		unhealthyHandlers.mu.Lock()
+		defer unhealthyHandlers.mu.Unlock()
+		unhealthyHandlers.handlers = slices.DeleteFunc(unhealthyHandlers.handlers, func(candidate *UnhealthyHandler) bool {
+			return candidate == h
+		})
+	}
+}
+
+func notifyUnhealthy(component string, err error) {
+	unhealthyHandlers.mu.Lock()
+	handlers := slices.Clone(unhealthyHandlers.handlers)
+	unhealthyHandlers.mu.Unlock()
+	for _, handler := range handlers {
+		(*handler)(component, err)
+	}
+}
+
+func (p *HealthProbe) withDefaults() *HealthProbe {
+	probe := *p
+	if probe.InitialDelay == 0 {
+		probe.InitialDelay = 30 * time.Second
+	}
+	if probe.Period == 0 {
+		probe.Period = 10 * time.Second
+	}
+	if probe.Timeout == 0 {
+		probe.Timeout = 3 * time.Second
+	}
+	if probe.FailureThreshold == 0 {
+		probe.FailureThreshold = 3
+	}
+	return &probe
+}
+
+// Performs a single probe.
+func (p *HealthProbe) probe(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	switch {
+	case p.HTTPGet != "":
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.HTTPGet, nil)
+		if err != nil {
+			return err
+		}
+		client := http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+				DisableKeepAlives: true,
+			},
+			CheckRedirect: func(req *http.Request, _ []*http.Request) error {
+				return fmt.Errorf("no redirects allowed: %s", req.URL)
+			},
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("unexpected HTTP response status: %s", resp.Status)
+		}
+		return nil
+
+	case p.TCPSocket != "":
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", p.TCPSocket)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+
+	case len(p.Exec) > 0:
+		out, err := exec.CommandContext(ctx, p.Exec[0], p.Exec[1:]...).CombinedOutput()
+		if err != nil {
+			if out = bytes.TrimSpace(out); len(out) > 0 {
+				return fmt.Errorf("%w: %s", err, out)
+			}
+			return err
+		}
+		return nil
+
+	default:
+		return errors.New("neither HTTPGet, TCPSocket nor Exec given")
+	}
+}
+
+// Probes the health of the given process until the context is done. Terminates
+// the process once it's unhealthy, so that the supervisor restarts it.
+func (s *Supervisor) probeHealth(ctx context.Context, process *os.Process) {
+	probe := s.HealthProbe.withDefaults()
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(probe.InitialDelay):
+	}
+
+	ticker := time.NewTicker(probe.Period)
+	defer ticker.Stop()
+
+	for failures := 0; ; {
+		err := probe.probe(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			failures = 0
+		} else if failures++; failures < probe.FailureThreshold {
+			s.log.WithError(err).Warnf("Health probe failed (%d/%d)", failures, probe.FailureThreshold)
+		} else {
+			s.log.WithError(err).Errorf("Health probe failed %d times in a row, restarting", failures)
+			unhealthyRestartsTotal.WithLabelValues(s.Name).Inc()
+			notifyUnhealthy(s.Name, err)
+			s.terminate(ctx, process)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Terminates the given process gracefully, and kills it if it's still running
+// after s.TimeoutStop. The context is done once the process exited.
+func (s *Supervisor) terminate(ctx context.Context, process *os.Process) {
+	if err := requestGracefulShutdown(process); err != nil {
+		s.log.WithError(err).Warn("Failed to request graceful shutdown")
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(s.TimeoutStop):
+		if err := process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			s.log.WithError(err).Warn("Failed to kill process")
+		}
+	}
+}
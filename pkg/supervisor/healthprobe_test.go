@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthProbe_Probe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(server.Close)
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(tlsServer.Close)
+
+	ok := selectCmd(t, cmd{"true", nil}, cmd{"powershell", []string{"-noprofile", "-noninteractive", "-command", "exit 0"}})
+	fail := selectCmd(t, cmd{"false", nil}, cmd{"powershell", []string{"-noprofile", "-noninteractive", "-command", "exit 1"}})
+
+	tests := []struct {
+		name    string
+		probe   HealthProbe
+		healthy bool
+	}{
+		{"http_ok", HealthProbe{HTTPGet: server.URL + "/healthz"}, true},
+		{"http_error", HealthProbe{HTTPGet: server.URL + "/livez"}, false},
+		{"https_self_signed", HealthProbe{HTTPGet: tlsServer.URL}, true},
+		{"tcp_ok", HealthProbe{TCPSocket: server.Listener.Addr().String()}, true},
+		{"tcp_closed", HealthProbe{TCPSocket: closedAddress(t)}, false},
+		{"exec_ok", HealthProbe{Exec: append([]string{ok.binPath}, ok.binArgs...)}, true},
+		{"exec_failed", HealthProbe{Exec: append([]string{fail.binPath}, fail.binArgs...)}, false},
+		{"no_handler", HealthProbe{}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.probe.withDefaults().probe(t.Context())
+			if test.healthy {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestHealthProbe_Restart(t *testing.T) {
+	sleep := selectCmd(t,
+		cmd{"sleep", []string{"60"}},
+		cmd{"powershell", []string{"-noprofile", "-noninteractive", "-command", "Start-Sleep -Seconds 60"}},
+	)
+
+	unhealthy := make(chan string, 10)
+	t.Cleanup(OnUnhealthy(func(component string, err error) {
+		assert.Error(t, err)
+		select {
+		case unhealthy <- component:
+		default:
+		}
+	}))
+
+	s := Supervisor{
+		Name:           "supervisor-test-health-probe",
+		BinPath:        sleep.binPath,
+		Args:           sleep.binArgs,
+		RunDir:         t.TempDir(),
+		TimeoutStop:    10 * time.Second,
+		TimeoutRespawn: 1 * time.Millisecond,
+		HealthProbe: &HealthProbe{
+			TCPSocket:        closedAddress(t),
+			InitialDelay:     1 * time.Millisecond,
+			Period:           10 * time.Millisecond,
+			FailureThreshold: 2,
+		},
+	}
+	require.NoError(t, s.Supervise())
+	t.Cleanup(s.Stop)
+	pid := s.GetProcess().Pid
+
+	select {
+	case component := <-unhealthy:
+		assert.Equal(t, s.Name, component)
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "Process hasn't been reported as unhealthy")
+	}
+
+	assert.Eventually(t, func() bool {
+		process := s.GetProcess()
+		return process != nil && process.Pid != pid
+	}, 10*time.Second, 10*time.Millisecond, "Process hasn't been restarted")
+}
+
+// Returns an address on which nothing is listening.
+func closedAddress(t *testing.T) string {
+	listener, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	return addr
+}
@@ -0,0 +1,33 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"fmt"
+	"io"
+	"log/syslog"
+	"net/url"
+	"strings"
+)
+
+const syslogPriority = syslog.LOG_INFO | syslog.LOG_DAEMON
+
+// Connects to the given syslog daemon.
+func dialSyslog(target string) (io.WriteCloser, error) {
+	if target == "syslog" {
+		return syslog.New(syslogPriority, "k0s")
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log forwarding target: %w", err)
+	}
+	network, ok := strings.CutPrefix(u.Scheme, "syslog+")
+	if !ok || (network != "udp" && network != "tcp") || u.Host == "" {
+		return nil, fmt.Errorf("unsupported log forwarding target %q (valid values: syslog, syslog+udp://host:port, syslog+tcp://host:port, file:<path>)", target)
+	}
+	return syslog.Dial(network, u.Host, syslogPriority, "k0s")
+}
@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"fmt"
+	"io"
+)
+
+// Syslog isn't available on Windows.
+func dialSyslog(target string) (io.WriteCloser, error) {
+	return nil, fmt.Errorf("unsupported log forwarding target %q (valid values: file:<path>)", target)
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	internallog "github.com/k0sproject/k0s/internal/pkg/log"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// The rotation of log files: They're rotated once they exceed the maximum size
+// in megabytes, keeping the given number of rotated files.
+const (
+	logFileMaxSize    = 10
+	logFileMaxBackups = 3
+)
+
+// LogOptions configures where the output of the supervised processes goes, in
+// addition to the k0s log.
+type LogOptions struct {
+	// Write the output of each process to rotated files in the logs directory
+	// below the run directory, named after the process.
+	Files bool
+
+	// Forward the output of all processes to the given target: "syslog" for
+	// the local syslog daemon, "syslog+udp://host:port" or
+	// "syslog+tcp://host:port" for a remote one, or "file:<path>" for a ring of
+	// rotated files.
+	Forward string
+}
+
+var logOptions struct {
+	mu      sync.Mutex
+	files   bool
+	forward io.WriteCloser
+}
+
+// ConfigureLogs configures where the output of the supervised processes goes.
+// It applies to the processes that are started afterwards.
+func ConfigureLogs(opts LogOptions) error {
+	forward, err := openLogForward(opts.Forward)
+	if err != nil {
+		return err
+	}
+
+	logOptions.mu.Lock()
+	defer logOptions.mu.Unlock()
+	if logOptions.forward != nil {
+		_ = logOptions.forward.Close()
+	}
+	logOptions.files, logOptions.forward = opts.Files, forward
+	return nil
+}
+
+func openLogForward(target string) (io.WriteCloser, error) {
+	switch {
+	case target == "":
+		return nil, nil
+	case strings.HasPrefix(target, "file:"):
+		path := strings.TrimPrefix(target, "file:")
+		if path == "" {
+			return nil, errors.New("no path given to forward logs to")
+		}
+		return newLogFile(path), nil
+	default:
+		return dialSyslog(target)
+	}
+}
+
+func newLogFile(path string) *lumberjack.Logger {
+	return &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    logFileMaxSize,
+		MaxBackups: logFileMaxBackups,
+	}
+}
+
+// Returns the logger for the output of the supervised process, and a function
+// that releases it. The output goes to the k0s log, and to the files and the
+// forwarding target, if configured.
+func (s *Supervisor) outputLogger() (logrus.FieldLogger, func()) {
+	logOptions.mu.Lock()
+	files, forward := logOptions.files, logOptions.forward
+	logOptions.mu.Unlock()
+
+	if !files && forward == nil {
+		return s.log, func() {}
+	}
+
+	// Capture the output in a logger of its own, so that it's captured
+	// regardless of the level of the k0s log.
+	log := logrus.New()
+	log.SetFormatter(internallog.NewFileFormatter())
+	log.SetOutput(io.Discard)
+	log.AddHook(relayHook{})
+	if forward != nil {
+		log.AddHook(&writerHook{forward})
+	}
+
+	release := func() {}
+	if files {
+		file := newLogFile(filepath.Join(s.RunDir, "logs", s.Name+".log"))
+		log.SetOutput(file)
+		release = func() {
+			if err := file.Close(); err != nil {
+				s.log.WithError(err).Debug("Failed to close log file")
+			}
+		}
+	}
+
+	return log.WithField("component", s.Name), release
+}
+
+// Relays log entries to the k0s log.
+type relayHook struct{}
+
+// Levels implements [logrus.Hook].
+func (relayHook) Levels() []logrus.Level { return logrus.AllLevels }
+
+// Fire implements [logrus.Hook].
+func (relayHook) Fire(entry *logrus.Entry) error {
+	logrus.WithFields(entry.Data).WithTime(entry.Time).Log(entry.Level, entry.Message)
+	return nil
+}
+
+// Writes formatted log entries to a writer.
+type writerHook struct{ w io.Writer }
+
+// Levels implements [logrus.Hook].
+func (h *writerHook) Levels() []logrus.Level { return logrus.AllLevels }
+
+// Fire implements [logrus.Hook].
+func (h *writerHook) Fire(entry *logrus.Entry) error {
+	line, err := entry.Logger.Formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	_, err = h.w.Write(line)
+	return err
+}
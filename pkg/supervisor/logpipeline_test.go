@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputLogger(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, ConfigureLogs(LogOptions{})) })
+
+	t.Run("disabled", func(t *testing.T) {
+		require.NoError(t, ConfigureLogs(LogOptions{}))
+		s := Supervisor{Name: "test", RunDir: t.TempDir(), log: logrus.WithField("component", "test")}
+		log, release := s.outputLogger()
+		release()
+		assert.Same(t, s.log, log)
+	})
+
+	t.Run("files_and_forward", func(t *testing.T) {
+		forwardPath := filepath.Join(t.TempDir(), "forward", "components.log")
+		require.NoError(t, ConfigureLogs(LogOptions{Files: true, Forward: "file:" + forwardPath}))
+
+		formatter := logrus.StandardLogger().Formatter
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+		t.Cleanup(func() { logrus.SetFormatter(formatter) })
+
+		s := Supervisor{Name: "test", RunDir: t.TempDir(), log: logrus.WithField("component", "test")}
+		log, release := s.outputLogger()
+		log.WithField("stream", "stdout").Info("hello")
+		release()
+		require.NoError(t, ConfigureLogs(LogOptions{})) // closes the forwarding target
+
+		for _, path := range []string{filepath.Join(s.RunDir, "logs", "test.log"), forwardPath} {
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(content, &entry), "in %s", path)
+			assert.Equal(t, "hello", entry["msg"])
+			assert.Equal(t, "test", entry["component"])
+			assert.Equal(t, "stdout", entry["stream"])
+		}
+	})
+
+	for _, target := range []string{"file:", "syslog+http://example.com", "syslog+udp://"} {
+		t.Run("invalid_"+strings.Split(target, ":")[0], func(t *testing.T) {
+			assert.Error(t, ConfigureLogs(LogOptions{Forward: target}))
+		})
+	}
+}
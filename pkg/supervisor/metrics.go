@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsSubsystem = "supervisor"
+
+var (
+	restartsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "k0s",
+		Subsystem: metricsSubsystem,
+		Name:      "restarts_total",
+		Help:      "The number of times the supervised process has been restarted.",
+	}, []string{"component"})
+
+	lastExitCode = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "k0s",
+		Subsystem: metricsSubsystem,
+		Name:      "last_exit_code",
+		Help:      "The exit code of the supervised process when it exited the last time, -1 if it has been terminated by a signal.",
+	}, []string{"component"})
+
+	unhealthyRestartsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "k0s",
+		Subsystem: metricsSubsystem,
+		Name:      "unhealthy_restarts_total",
+		Help:      "The number of times the supervised process has been restarted because it failed its health probe.",
+	}, []string{"component"})
+
+	givenUpGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "k0s",
+		Subsystem: metricsSubsystem,
+		Name:      "given_up",
+		Help:      "Whether the supervisor gave up restarting the process.",
+	}, []string{"component"})
+)
+
+// The supervisors whose processes are reported by the usage collector, by name.
+var supervised sync.Map
+
+func init() {
+	crmetrics.Registry.MustRegister(restartsTotal, lastExitCode, unhealthyRestartsTotal, givenUpGauge, usageCollector{})
+}
+
+var (
+	uptimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("k0s", metricsSubsystem, "process_uptime_seconds"),
+		"The time since the supervised process has been started.",
+		[]string{"component"}, nil,
+	)
+	memoryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("k0s", metricsSubsystem, "process_memory_bytes"),
+		"The memory usage of the supervised process.",
+		[]string{"component"}, nil,
+	)
+	cpuDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("k0s", metricsSubsystem, "process_cpu_seconds_total"),
+		"The CPU time consumed by the supervised process.",
+		[]string{"component"}, nil,
+	)
+)
+
+// The resource usage of a process.
+type usage struct {
+	memoryBytes uint64
+	cpuSeconds  float64
+}
+
+// Collects the uptime and the resource usage of the supervised processes at
+// scrape time.
+type usageCollector struct{}
+
+// Describe implements [prometheus.Collector].
+func (usageCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- uptimeDesc
+	descs <- memoryDesc
+	descs <- cpuDesc
+}
+
+// Collect implements [prometheus.Collector].
+func (usageCollector) Collect(metrics chan<- prometheus.Metric) {
+	supervised.Range(func(name, s any) bool {
+		pid, startTime := s.(*Supervisor).runningProcess()
+		if pid == 0 {
+			return true
+		}
+
+		metrics <- prometheus.MustNewConstMetric(uptimeDesc, prometheus.GaugeValue, time.Since(startTime).Seconds(), name.(string))
+
+		usage, err := processUsage(pid)
+		if err != nil {
+			if !errors.Is(err, errors.ErrUnsupported) {
+				logrus.WithField("component", name).WithError(err).Debug("Failed to read the resource usage")
+			}
+			return true
+		}
+		metrics <- prometheus.MustNewConstMetric(memoryDesc, prometheus.GaugeValue, float64(usage.memoryBytes), name.(string))
+		metrics <- prometheus.MustNewConstMetric(cpuDesc, prometheus.CounterValue, usage.cpuSeconds, name.(string))
+		return true
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/k0sproject/k0s/internal/pkg/dir"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/constant"
 )
 
@@ -38,12 +39,28 @@ type Supervisor struct {
 	GID            int
 	TimeoutStop    time.Duration
 	TimeoutRespawn time.Duration
+	// The upper bound of the respawn timeout, which doubles each time the
+	// process exits before having run for that long. Defaults to
+	// TimeoutRespawn, i.e. a fixed timeout.
+	MaxTimeoutRespawn time.Duration
+	// Give up restarting the process once it has been restarted more than
+	// MaxRestarts times within RestartWindow. Zero restarts it forever.
+	MaxRestarts   int
+	RestartWindow time.Duration
+	// The resource limits of the process. The process is placed in a cgroup
+	// of its own whenever possible, but the limits can only be enforced if
+	// the cgroup v2 controllers are available.
+	Resources *v1beta1.ProcessResources
+	// The probe that checks the health of the process while it's running. The
+	// process is restarted if it's unhealthy.
+	HealthProbe *HealthProbe
 	// For those components having env prefix convention such as ETCD_xxx, we should keep the prefix.
 	KeepEnvPrefix bool
 	// A function to clean some leftovers before starting or restarting the supervised process
 	CleanBeforeFn func() error
 
 	cmd            *exec.Cmd
+	startTime      time.Time // zero if the process isn't running
 	done           chan bool
 	log            logrus.FieldLogger
 	mutex          sync.Mutex
@@ -53,6 +70,38 @@ type Supervisor struct {
 
 const k0sManaged = "_K0S_MANAGED=yes"
 
+// The reasons why supervisors gave up restarting their processes, by name.
+var givenUp sync.Map
+
+// GivenUp returns the reasons why supervisors gave up restarting their
+// processes, by process name. The node is degraded if there are any.
+func GivenUp() map[string]string {
+	reasons := make(map[string]string)
+	givenUp.Range(func(name, reason any) bool {
+		reasons[name.(string)] = reason.(string)
+		return true
+	})
+	return reasons
+}
+
+// ApplyRestartPolicy configures the respawn behavior according to the given
+// policy. Unset fields of the policy leave the supervisor's settings as is.
+func (s *Supervisor) ApplyRestartPolicy(policy *v1beta1.RestartPolicy) {
+	if policy == nil {
+		return
+	}
+	if policy.Backoff != nil {
+		s.TimeoutRespawn = policy.Backoff.Duration
+	}
+	if policy.MaxBackoff != nil {
+		s.MaxTimeoutRespawn = policy.MaxBackoff.Duration
+	}
+	s.MaxRestarts = int(policy.MaxRestarts)
+	if policy.Window != nil {
+		s.RestartWindow = policy.Window.Duration
+	}
+}
+
 // processWaitQuit waits for a process to exit or a shut down signal
 // returns true if shutdown is requested
 func (s *Supervisor) processWaitQuit(ctx context.Context) bool {
@@ -105,6 +154,15 @@ func (s *Supervisor) Supervise() error {
 	if s.TimeoutRespawn == 0 {
 		s.TimeoutRespawn = 5 * time.Second
 	}
+	if s.MaxTimeoutRespawn < s.TimeoutRespawn {
+		s.MaxTimeoutRespawn = s.TimeoutRespawn
+	}
+	if s.RestartWindow == 0 {
+		s.RestartWindow = 10 * time.Minute
+	}
+	givenUp.Delete(s.Name)
+	givenUpGauge.WithLabelValues(s.Name).Set(0)
+	supervised.Store(s.Name, s)
 
 	if err := s.maybeKillPidFile(); err != nil {
 		if !errors.Is(err, errors.ErrUnsupported) {
@@ -118,15 +176,30 @@ func (s *Supervisor) Supervise() error {
 	ctx, s.cancel = context.WithCancel(context.Background())
 	started := make(chan error)
 	s.done = make(chan bool)
+	outputLog, releaseOutputLog := s.outputLogger()
 
 	go func() {
 		defer func() {
+			releaseOutputLog()
 			close(s.done)
 		}()
 
+		var cgroup string
+		defer func() {
+			// Remove the cgroup if the process has left it.
+			if cgroup != "" {
+				if err := os.Remove(cgroup); err != nil && !errors.Is(err, os.ErrNotExist) {
+					s.log.WithError(err).Debug("Failed to remove cgroup")
+				}
+			}
+		}()
+
 		s.log.Info("Starting to supervise")
 		restarts := 0
+		timeoutRespawn := s.TimeoutRespawn
+		var restartTimes []time.Time
 		for {
+			startTime := time.Now()
 			s.mutex.Lock()
 
 			var err error
@@ -135,7 +208,7 @@ func (s *Supervisor) Supervise() error {
 			}
 			if err != nil {
 				s.log.Warnf("Failed to clean before running the process %s: %s", s.BinPath, err)
-			} else {
+			} else if cgroup, err = s.prepareCgroup(); err == nil {
 				s.cmd = exec.Command(s.BinPath, s.Args...)
 				s.cmd.Dir = s.DataDir
 				s.cmd.Env = getEnv(s.DataDir, s.Name, s.KeepEnvPrefix)
@@ -146,18 +219,25 @@ func (s *Supervisor) Supervise() error {
 				// detach from the process group so children don't
 				// get signals sent directly to parent.
 				s.cmd.SysProcAttr = DetachAttr(s.UID, s.GID)
+				releaseCgroup := func() {}
+				if cgroup != "" {
+					releaseCgroup, err = startInCgroup(s.cmd.SysProcAttr, cgroup)
+				}
 
 				const maxLogChunkLen = 16 * 1024
 				s.cmd.Stdout = &logWriter{
-					log: s.log.WithField("stream", "stdout"),
+					log: outputLog.WithField("stream", "stdout"),
 					buf: make([]byte, maxLogChunkLen),
 				}
 				s.cmd.Stderr = &logWriter{
-					log: s.log.WithField("stream", "stderr"),
+					log: outputLog.WithField("stream", "stderr"),
 					buf: make([]byte, maxLogChunkLen),
 				}
 
-				err = s.cmd.Start()
+				if err == nil {
+					err = s.cmd.Start()
+					releaseCgroup()
+				}
 			}
 			s.mutex.Unlock()
 			if err != nil {
@@ -176,23 +256,54 @@ func (s *Supervisor) Supervise() error {
 					started <- nil
 				} else {
 					s.log.Infof("Restarted (%d)", restarts)
+					restartsTotal.WithLabelValues(s.Name).Inc()
 				}
 				restarts++
-				if s.processWaitQuit(ctx) {
+				s.mutex.Lock()
+				s.startTime = time.Now()
+				s.mutex.Unlock()
+				stopProbe := s.startHealthProbe(ctx)
+				quit := s.processWaitQuit(ctx)
+				stopProbe()
+				s.mutex.Lock()
+				s.startTime = time.Time{}
+				s.mutex.Unlock()
+				if quit {
 					return
 				}
+				if state := s.cmd.ProcessState; state != nil {
+					lastExitCode.WithLabelValues(s.Name).Set(float64(state.ExitCode()))
+				}
 			}
 
-			// TODO Maybe some backoff thingy would be nice
-			s.log.Infof("respawning in %s", s.TimeoutRespawn.String())
+			now := time.Now()
+			if s.MaxRestarts > 0 {
+				restartTimes = slices.DeleteFunc(append(restartTimes, now), func(t time.Time) bool {
+					return now.Sub(t) > s.RestartWindow
+				})
+				if len(restartTimes) > s.MaxRestarts {
+					reason := fmt.Sprintf("restarted more than %d times within %s", s.MaxRestarts, s.RestartWindow)
+					s.log.Errorf("Giving up, the process has been %s", reason)
+					givenUp.Store(s.Name, reason)
+					givenUpGauge.WithLabelValues(s.Name).Set(1)
+					return
+				}
+			}
+
+			// Back off exponentially while the process keeps exiting quickly.
+			if now.Sub(startTime) >= s.MaxTimeoutRespawn {
+				timeoutRespawn = s.TimeoutRespawn
+			}
+			s.log.Infof("respawning in %s", timeoutRespawn.String())
 
 			select {
 			case <-ctx.Done():
 				s.log.Debug("respawn canceled")
 				return
-			case <-time.After(s.TimeoutRespawn):
+			case <-time.After(timeoutRespawn):
 				s.log.Debug("respawning")
 			}
+			timeoutRespawn = min(2*timeoutRespawn, s.MaxTimeoutRespawn)
 		}
 	}()
 	return <-started
@@ -214,6 +325,7 @@ func (s *Supervisor) Stop() {
 	if s.done != nil {
 		<-s.done
 	}
+	supervised.CompareAndDelete(s.Name, s)
 }
 
 // maybeKillPidFile checks kills the process in the pidFile if it's has
@@ -367,6 +479,33 @@ func getEnv(dataDir, component string, keepEnvPrefix bool) []string {
 	return env[:i]
 }
 
+// Starts probing the health of the just started process, if there's a probe.
+// Returns a function that stops probing.
+func (s *Supervisor) startHealthProbe(ctx context.Context) func() {
+	if s.HealthProbe == nil {
+		return func() {}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	process := s.cmd.Process
+	go func() {
+		defer close(done)
+		s.probeHealth(ctx, process)
+	}()
+	return func() { cancel(); <-done }
+}
+
+// Returns the PID and the start time of the supervised process, if it's
+// running. The PID is zero otherwise.
+func (s *Supervisor) runningProcess() (int, time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.startTime.IsZero() {
+		return 0, time.Time{}
+	}
+	return s.cmd.Process.Pid, s.startTime
+}
+
 // GetProcess returns the last started process
 func (s *Supervisor) GetProcess() *os.Process {
 	s.mutex.Lock()
@@ -19,8 +19,11 @@ import (
 	"time"
 
 	"github.com/k0sproject/k0s/internal/testutil/pingpong"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type SupervisorTest struct {
@@ -192,6 +195,62 @@ func TestStopWhileRespawn(t *testing.T) {
 	s.Stop()
 }
 
+func TestGiveUp(t *testing.T) {
+	fail := selectCmd(t,
+		cmd{"false", []string{}},
+		cmd{"sh", []string{"-c", "exit 1"}},
+		cmd{"powershell", []string{"-noprofile", "-noninteractive", "-command", "exit 1"}},
+	)
+
+	s := Supervisor{
+		Name:              "supervisor-test-give-up",
+		BinPath:           fail.binPath,
+		Args:              fail.binArgs,
+		RunDir:            t.TempDir(),
+		TimeoutStop:       1 * time.Minute,
+		TimeoutRespawn:    1 * time.Millisecond,
+		MaxTimeoutRespawn: 10 * time.Millisecond,
+	}
+	s.ApplyRestartPolicy(&v1beta1.RestartPolicy{
+		MaxRestarts: 2,
+		Window:      &metav1.Duration{Duration: time.Hour},
+	})
+	assert.Equal(t, time.Millisecond, s.TimeoutRespawn, "Unset fields should be kept")
+
+	require.NoError(t, s.Supervise())
+	t.Cleanup(s.Stop)
+
+	select {
+	case <-s.done:
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "Supervisor didn't give up")
+	}
+
+	assert.Equal(t, "restarted more than 2 times within 1h0m0s", GivenUp()[s.Name])
+}
+
+func TestUsageCollector(t *testing.T) {
+	sleep := selectCmd(t,
+		cmd{"sleep", []string{"60"}},
+		cmd{"powershell", []string{"-noprofile", "-noninteractive", "-command", "Start-Sleep -Seconds 60"}},
+	)
+
+	s := Supervisor{
+		Name:    "supervisor-test-usage",
+		BinPath: sleep.binPath,
+		Args:    sleep.binArgs,
+		RunDir:  t.TempDir(),
+	}
+	require.NoError(t, s.Supervise())
+
+	assert.Eventually(t, func() bool {
+		return testutil.CollectAndCount(usageCollector{}, "k0s_supervisor_process_uptime_seconds") == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	s.Stop()
+	assert.Zero(t, testutil.CollectAndCount(usageCollector{}))
+}
+
 func TestMultiThread(t *testing.T) {
 	sleep := selectCmd(t,
 		cmd{"sleep", []string{"60"}},
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The clock ticks per second in which the kernel reports CPU times in procfs.
+// This is USER_HZ, which is 100 on all architectures that k0s supports.
+const userHZ = 100
+
+func processUsage(pid int) (*usage, error) {
+	return (&usageReader{"/proc", "/sys/fs/cgroup"}).read(pid)
+}
+
+type usageReader struct {
+	procDir, cgroupDir string
+}
+
+// Reads the resource usage of the given process. It's read from the process's
+// cgroup if the process has one of its own, so that it includes the usage of
+// its children. Otherwise, the process shares its cgroup with k0s, and the
+// usage of the process itself is read.
+func (r *usageReader) read(pid int) (*usage, error) {
+	if cgroup, err := r.cgroupOf(strconv.Itoa(pid)); err == nil {
+		if own, err := r.cgroupOf("self"); err == nil && own != cgroup {
+			if usage, err := r.cgroupUsage(cgroup); err == nil {
+				return usage, nil
+			}
+		}
+	}
+
+	return r.procUsage(pid)
+}
+
+// Returns the unified cgroup hierarchy path of the given process.
+func (r *usageReader) cgroupOf(pid string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(r.procDir, pid, "cgroup"))
+	if err != nil {
+		return "", err
+	}
+	for line := range strings.SplitSeq(string(data), "\n") {
+		if path, ok := strings.CutPrefix(line, "0::"); ok {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("not in a cgroup v2 hierarchy")
+}
+
+func (r *usageReader) cgroupUsage(cgroup string) (*usage, error) {
+	dir := filepath.Join(r.cgroupDir, filepath.FromSlash(cgroup))
+
+	memory, err := os.ReadFile(filepath.Join(dir, "memory.current"))
+	if err != nil {
+		return nil, err
+	}
+	memoryBytes, err := strconv.ParseUint(strings.TrimSpace(string(memory)), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse memory.current: %w", err)
+	}
+
+	cpuStat, err := os.ReadFile(filepath.Join(dir, "cpu.stat"))
+	if err != nil {
+		return nil, err
+	}
+	lines := bufio.NewScanner(bytes.NewReader(cpuStat))
+	for lines.Scan() {
+		if value, ok := strings.CutPrefix(lines.Text(), "usage_usec "); ok {
+			usec, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse cpu.stat: %w", err)
+			}
+			return &usage{memoryBytes, float64(usec) / 1e6}, nil
+		}
+	}
+	return nil, fmt.Errorf("no CPU usage in cpu.stat")
+}
+
+func (r *usageReader) procUsage(pid int) (*usage, error) {
+	procDir := filepath.Join(r.procDir, strconv.Itoa(pid))
+
+	// The second field is the resident set size in pages.
+	statm, err := os.ReadFile(filepath.Join(procDir, "statm"))
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("unexpected statm: %q", statm)
+	}
+	residentPages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse statm: %w", err)
+	}
+
+	// The command may contain spaces, so start after its closing parenthesis.
+	// The user and system times are the 14th and 15th field, respectively.
+	stat, err := os.ReadFile(filepath.Join(procDir, "stat"))
+	if err != nil {
+		return nil, err
+	}
+	idx := bytes.LastIndexByte(stat, ')')
+	if idx < 0 {
+		return nil, fmt.Errorf("unexpected stat: %q", stat)
+	}
+	fields = strings.Fields(string(stat[idx+1:]))
+	if len(fields) < 13 {
+		return nil, fmt.Errorf("unexpected stat: %q", stat)
+	}
+	var ticks uint64
+	for _, field := range fields[11:13] {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse stat: %w", err)
+		}
+		ticks += value
+	}
+
+	return &usage{
+		memoryBytes: residentPages * uint64(os.Getpagesize()),
+		cpuSeconds:  float64(ticks) / userHZ,
+	}, nil
+}
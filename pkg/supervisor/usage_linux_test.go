@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageReader(t *testing.T) {
+	writeFile := func(t *testing.T, path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	setup := func(t *testing.T, cgroup string) *usageReader {
+		r := &usageReader{t.TempDir(), t.TempDir()}
+		writeFile(t, filepath.Join(r.procDir, "self", "cgroup"), "0::/system.slice/k0scontroller.service\n")
+		writeFile(t, filepath.Join(r.procDir, "42", "cgroup"), "0::"+cgroup+"\n")
+		writeFile(t, filepath.Join(r.procDir, "42", "statm"), "1000 250 100 10 0 200 0\n")
+		writeFile(t, filepath.Join(r.procDir, "42", "stat"), "42 (kube apiserver) S 1 42 42 0 -1 4194560 100 0 0 0 150 50 0 0 20 0 10 0 1000 1000 250\n")
+		cgroupDir := filepath.Join(r.cgroupDir, "system.slice", "k0s-etcd.scope")
+		writeFile(t, filepath.Join(cgroupDir, "memory.current"), "1048576\n")
+		writeFile(t, filepath.Join(cgroupDir, "cpu.stat"), "usage_usec 2500000\nuser_usec 2000000\nsystem_usec 500000\n")
+		return r
+	}
+
+	t.Run("shared_cgroup", func(t *testing.T) {
+		r := setup(t, "/system.slice/k0scontroller.service")
+		usage, err := r.read(42)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(250*os.Getpagesize()), usage.memoryBytes)
+		assert.InDelta(t, 2.0, usage.cpuSeconds, 1e-9)
+	})
+
+	t.Run("own_cgroup", func(t *testing.T) {
+		r := setup(t, "/system.slice/k0s-etcd.scope")
+		usage, err := r.read(42)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1048576), usage.memoryBytes)
+		assert.InDelta(t, 2.5, usage.cpuSeconds, 1e-9)
+	})
+
+	t.Run("no_process", func(t *testing.T) {
+		r := setup(t, "/")
+		_, err := r.read(43)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import "errors"
+
+func processUsage(int) (*usage, error) {
+	return nil, errors.ErrUnsupported
+}
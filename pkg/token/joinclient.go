@@ -89,3 +89,126 @@ func (j *JoinClient) JoinEtcd(ctx context.Context, etcdRequest v1beta1.EtcdReque
 
 	return etcdResponse, err
 }
+
+// JoinWithCloudIdentity calls the cloud identity join API at the given URL and
+// returns the worker join token that it issues for the given identity.
+func JoinWithCloudIdentity(ctx context.Context, joinURL string, caCert []byte, joinRequest *v1beta1.CloudIdentityJoinRequest) (string, error) {
+	restClient, err := joinAPIClientFor(&rest.Config{
+		Host:            joinURL,
+		TLSClientConfig: rest.TLSClientConfig{CAData: caCert},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(joinRequest); err != nil {
+		return "", err
+	}
+
+	b, err := restClient.Post().AbsPath("v1beta1", "join", "cloud-identity").Body(buf).Do(ctx).Raw()
+	if err != nil {
+		return "", err
+	}
+
+	var joinResponse v1beta1.CloudIdentityJoinResponse
+	if err := json.Unmarshal(b, &joinResponse); err != nil {
+		return "", err
+	}
+	return joinResponse.Token, nil
+}
+
+// FetchCACertificate fetches the cluster's CA certificate from the join API at
+// the given URL. The join API isn't verified, as this is the certificate that
+// it would be verified with. Callers need to verify the certificate, e.g. via
+// its fingerprint, before trusting it.
+func FetchCACertificate(ctx context.Context, joinURL string) ([]byte, error) {
+	restClient, err := joinAPIClientFor(&rest.Config{
+		Host:            joinURL,
+		TLSClientConfig: rest.TLSClientConfig{Insecure: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restClient.Get().AbsPath("v1beta1", "join", "ca-certificate").Do(ctx).Raw()
+}
+
+// JoinWithCode calls the join code API at the given URL and returns the worker
+// join token that belongs to the given join code.
+func JoinWithCode(ctx context.Context, joinURL string, caCert []byte, joinCode string) (string, error) {
+	restClient, err := joinAPIClientFor(&rest.Config{
+		Host:            joinURL,
+		BearerToken:     joinCode,
+		TLSClientConfig: rest.TLSClientConfig{CAData: caCert},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	b, err := restClient.Get().AbsPath("v1beta1", "join", "code").Do(ctx).Raw()
+	if err != nil {
+		return "", err
+	}
+
+	var joinResponse v1beta1.JoinCodeResponse
+	if err := json.Unmarshal(b, &joinResponse); err != nil {
+		return "", err
+	}
+	return joinResponse.Token, nil
+}
+
+// SubmitJoinRequest submits the given join request to the join API at the
+// given URL and returns its ID.
+func SubmitJoinRequest(ctx context.Context, joinURL string, caCert []byte, joinRequest *v1beta1.JoinRequest) (string, error) {
+	restClient, err := joinAPIClientFor(&rest.Config{
+		Host:            joinURL,
+		TLSClientConfig: rest.TLSClientConfig{CAData: caCert},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(joinRequest); err != nil {
+		return "", err
+	}
+
+	b, err := restClient.Post().AbsPath("v1beta1", "join", "requests").Body(buf).Do(ctx).Raw()
+	if err != nil {
+		return "", err
+	}
+
+	var joinResponse v1beta1.JoinRequestResponse
+	if err := json.Unmarshal(b, &joinResponse); err != nil {
+		return "", err
+	}
+	return joinResponse.ID, nil
+}
+
+// GetJoinRequestStatus gets the status of the join request with the given ID
+// from the join API at the given URL.
+func GetJoinRequestStatus(ctx context.Context, joinURL string, caCert []byte, id string) (*v1beta1.JoinRequestStatus, error) {
+	restClient, err := joinAPIClientFor(&rest.Config{
+		Host:            joinURL,
+		TLSClientConfig: rest.TLSClientConfig{CAData: caCert},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := restClient.Get().AbsPath("v1beta1", "join", "requests", id).Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	var status v1beta1.JoinRequestStatus
+	if err := json.Unmarshal(b, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func joinAPIClientFor(restConfig *rest.Config) (*rest.RESTClient, error) {
+	return rest.UnversionedRESTClientFor(dynamic.ConfigFor(restConfig))
+}
@@ -13,6 +13,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/k0sproject/k0s/internal/testutil"
@@ -89,6 +90,41 @@ func TestJoinClient_JoinEtcd(t *testing.T) {
 	assert.Zero(t, response)
 }
 
+func TestJoinWithCode(t *testing.T) {
+	t.Parallel()
+
+	var caCert []byte
+	joinURL, certData := startFakeJoinServer(t, func(res http.ResponseWriter, req *http.Request) {
+		switch req.RequestURI {
+		case "/v1beta1/join/ca-certificate":
+			assert.Empty(t, req.Header["Authorization"])
+			_, err := res.Write(caCert)
+			assert.NoError(t, err)
+		case "/v1beta1/join/code":
+			assert.Equal(t, []string{"Bearer the-id.the-secret"}, req.Header["Authorization"])
+			_, err := res.Write([]byte(`{"token":"the-join-token"}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request", "%s", req.RequestURI)
+		}
+	})
+	caCert = certData
+
+	fetched, err := token.FetchCACertificate(t.Context(), joinURL.String())
+	require.NoError(t, err)
+	assert.Equal(t, certData, fetched)
+
+	fingerprint, err := token.CAFingerprint(certData)
+	require.NoError(t, err)
+	assert.Regexp(t, "^sha256:[0-9a-f]{64}$", fingerprint)
+	assert.NoError(t, token.VerifyCAFingerprint(fetched, fingerprint))
+	assert.ErrorContains(t, token.VerifyCAFingerprint(fetched, "sha256:"+strings.Repeat("0", 64)), "doesn't match the fingerprint")
+
+	joinToken, err := token.JoinWithCode(t.Context(), joinURL.String(), fetched, "the-id.the-secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "the-join-token", joinToken)
+}
+
 func TestJoinClient_Cancellation(t *testing.T) {
 	t.Parallel()
 
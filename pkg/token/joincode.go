@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/config"
+
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pubkeypin"
+)
+
+// JoinCode is the compact form of a worker join token. Instead of the whole
+// kubeconfig, it consists only of the bootstrap token, which is exchanged for
+// the full join token via the k0s join API. The fingerprint of the cluster's
+// CA is used to verify the join API before the code is sent to it.
+type JoinCode struct {
+	Code        string
+	Fingerprint string
+	JoinAPIURL  string
+}
+
+// CreateJoinCode creates a new worker bootstrap token and returns it in the
+// form of a join code.
+func CreateJoinCode(ctx context.Context, api *v1beta1.APISpec, k0sVars *config.CfgVars, expiry time.Duration, oneTime bool) (*JoinCode, error) {
+	caCert, err := loadCACert(k0sVars)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, err := CAFingerprint(caCert)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := loadToken(ctx, k0sVars, RoleWorker, expiry, oneTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JoinCode{
+		Code:        token.String(),
+		Fingerprint: fingerprint,
+		JoinAPIURL:  api.K0sControlPlaneAPIAddress(),
+	}, nil
+}
+
+// CAFingerprint returns the fingerprint of the given PEM encoded CA
+// certificate. It's the SHA-256 hash of the certificate's public key, in the
+// same format that kubeadm uses for its CA certificate hashes.
+func CAFingerprint(caCert []byte) (string, error) {
+	certs, err := certutil.ParseCertsPEM(caCert)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+	return pubkeypin.Hash(certs[0]), nil
+}
+
+// VerifyCAFingerprint checks that the given PEM encoded CA certificate has the
+// given fingerprint.
+func VerifyCAFingerprint(caCert []byte, fingerprint string) error {
+	pins := pubkeypin.NewSet()
+	if err := pins.Allow(fingerprint); err != nil {
+		return err
+	}
+	certs, err := certutil.ParseCertsPEM(caCert)
+	if err != nil {
+		return fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+	if err := pins.CheckAny(certs[:1]); err != nil {
+		return errors.New("the CA certificate doesn't match the fingerprint")
+	}
+	return nil
+}
@@ -0,0 +1,326 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+// JoinRequestSecretType is the type of the secrets in the kube-system
+// namespace that hold the join requests of nodes.
+const JoinRequestSecretType corev1.SecretType = "k0s.k0sproject.io/join-request"
+
+// JoinRequestStateLabel holds the state of a join request. Join requests are
+// approved or denied by setting it to [JoinRequestApproved] or
+// [JoinRequestDenied], respectively.
+const JoinRequestStateLabel = "k0s.k0sproject.io/join-request-state"
+
+// JoinRequestDecidedByAnnotation records who approved or denied a join request.
+const JoinRequestDecidedByAnnotation = "k0s.k0sproject.io/join-request-decided-by"
+
+// The states of join requests.
+const (
+	JoinRequestPending  = "Pending"
+	JoinRequestApproved = "Approved"
+	JoinRequestDenied   = "Denied"
+)
+
+// JoinRequestTTL is the time in which join requests need to be approved, and
+// the validity of the client certificates that are issued for them. Nodes only
+// use them to obtain their regular client certificate.
+const JoinRequestTTL = time.Hour
+
+// ErrTooManyPendingJoinRequests is returned when a join request is submitted
+// while the maximum number of join requests is already pending.
+var ErrTooManyPendingJoinRequests = errors.New("too many pending join requests")
+
+const joinRequestSecretPrefix = "k0s-join-request-"
+
+// JoinRequest is a request of a node to join the cluster.
+type JoinRequest struct {
+	ID          string
+	NodeName    string
+	RequestedBy string
+	Created     string
+	State       string
+	DecidedBy   string
+}
+
+func (r JoinRequest) ToArray() []string {
+	return []string{r.ID, r.NodeName, r.RequestedBy, r.Created, r.State, r.DecidedBy}
+}
+
+// SubmitJoinRequest stores a join request of the given node with the given
+// certificate signing request, and returns its ID. The request is rejected if
+// the maximum number of join requests is already pending, either in total or
+// from the requesting source.
+func (m *Manager) SubmitJoinRequest(ctx context.Context, nodeName string, csr []byte, requestedBy string, maxPending, maxPendingPerSource int) (string, error) {
+	secrets := m.client.CoreV1().Secrets(metav1.NamespaceSystem)
+
+	requests, err := m.listJoinRequestSecrets(ctx)
+	if err != nil {
+		return "", err
+	}
+	var pending, pendingFromSource int
+	for i := range requests {
+		if isPendingJoinRequest(&requests[i], time.Now()) {
+			pending++
+			if string(requests[i].Data["requested-by"]) == requestedBy {
+				pendingFromSource++
+			}
+		} else if isExpiredJoinRequest(&requests[i], time.Now()) {
+			if err := secrets.Delete(ctx, requests[i].Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return "", err
+			}
+		}
+	}
+	if pending >= maxPending {
+		return "", ErrTooManyPendingJoinRequests
+	}
+	if pendingFromSource >= maxPendingPerSource {
+		return "", fmt.Errorf("%w from %s", ErrTooManyPendingJoinRequests, requestedBy)
+	}
+
+	id, err := newJoinRequestID()
+	if err != nil {
+		return "", err
+	}
+	_, err = secrets.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   joinRequestSecretPrefix + id,
+			Labels: map[string]string{JoinRequestStateLabel: JoinRequestPending},
+		},
+		Type: JoinRequestSecretType,
+		Data: map[string][]byte{
+			"csr":          csr,
+			"node-name":    []byte(nodeName),
+			"requested-by": []byte(requestedBy),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Join request IDs are all that's needed to poll for the node's certificate,
+// so they need to be unguessable.
+func newJoinRequestID() (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id[:]), nil
+}
+
+// ListJoinRequests returns all the join requests that haven't expired.
+func (m *Manager) ListJoinRequests(ctx context.Context) (requests []JoinRequest, _ error) {
+	secrets, err := m.listJoinRequestSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range secrets {
+		secret := &secrets[i]
+		if isExpiredJoinRequest(secret, now) {
+			continue
+		}
+		requests = append(requests, JoinRequest{
+			ID:          strings.TrimPrefix(secret.Name, joinRequestSecretPrefix),
+			NodeName:    string(secret.Data["node-name"]),
+			RequestedBy: string(secret.Data["requested-by"]),
+			Created:     secret.CreationTimestamp.UTC().Format(time.RFC3339),
+			State:       secret.Labels[JoinRequestStateLabel],
+			DecidedBy:   secret.Annotations[JoinRequestDecidedByAnnotation],
+		})
+	}
+
+	return requests, nil
+}
+
+// ApproveJoinRequest approves the pending join request with the given ID.
+func (m *Manager) ApproveJoinRequest(ctx context.Context, id string) error {
+	return m.decideJoinRequest(ctx, id, JoinRequestApproved)
+}
+
+// DenyJoinRequest denies the pending join request with the given ID.
+func (m *Manager) DenyJoinRequest(ctx context.Context, id string) error {
+	return m.decideJoinRequest(ctx, id, JoinRequestDenied)
+}
+
+func (m *Manager) decideJoinRequest(ctx context.Context, id, state string) error {
+	secrets := m.client.CoreV1().Secrets(metav1.NamespaceSystem)
+	secret, err := secrets.Get(ctx, joinRequestSecretPrefix+id, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) || (err == nil && (secret.Type != JoinRequestSecretType || isExpiredJoinRequest(secret, time.Now()))) {
+		return fmt.Errorf("join request %s not found", id)
+	} else if err != nil {
+		return err
+	}
+	if current := secret.Labels[JoinRequestStateLabel]; current != JoinRequestPending {
+		return fmt.Errorf("join request %s has already been decided: %s", id, current)
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels":          map[string]string{JoinRequestStateLabel: state},
+			"annotations":     map[string]string{JoinRequestDecidedByAnnotation: currentUser()},
+			"resourceVersion": secret.ResourceVersion,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = secrets.Patch(ctx, secret.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// GetJoinRequestSecret returns the secret of the join request with the given
+// ID. Returns a not found error for expired join requests.
+func (m *Manager) GetJoinRequestSecret(ctx context.Context, id string) (*corev1.Secret, error) {
+	secret, err := m.client.CoreV1().Secrets(metav1.NamespaceSystem).Get(ctx, joinRequestSecretPrefix+id, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if secret.Type != JoinRequestSecretType || isExpiredJoinRequest(secret, time.Now()) {
+		return nil, apierrors.NewNotFound(corev1.Resource("secrets"), secret.Name)
+	}
+	return secret, nil
+}
+
+// IssueJoinRequestCertificate signs the client certificate of the given
+// approved join request with the given CA, and stores it in the join request,
+// so that the same certificate is returned for repeated requests.
+func (m *Manager) IssueJoinRequestCertificate(ctx context.Context, secret *corev1.Secret, caCert, caKey []byte) ([]byte, error) {
+	if cert := secret.Data["certificate"]; len(cert) > 0 {
+		return cert, nil
+	}
+	if state := secret.Labels[JoinRequestStateLabel]; state != JoinRequestApproved {
+		return nil, fmt.Errorf("join request is %s", state)
+	}
+
+	csr, err := ParseNodeCSR(secret.Data["csr"], string(secret.Data["node-name"]))
+	if err != nil {
+		return nil, err
+	}
+	cert, err := signNodeClientCertificate(csr, caCert, caKey, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	secret = secret.DeepCopy()
+	secret.Data["certificate"] = cert
+	if _, err := m.client.CoreV1().Secrets(metav1.NamespaceSystem).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+func (m *Manager) listJoinRequestSecrets(ctx context.Context) ([]corev1.Secret, error) {
+	secrets, err := m.client.CoreV1().Secrets(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(JoinRequestSecretType)).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return secrets.Items, nil
+}
+
+func isPendingJoinRequest(secret *corev1.Secret, now time.Time) bool {
+	return secret.Labels[JoinRequestStateLabel] == JoinRequestPending && !isExpiredJoinRequest(secret, now)
+}
+
+// Join requests expire if they aren't approved within their TTL. Approved
+// requests expire along with their certificate.
+func isExpiredJoinRequest(secret *corev1.Secret, now time.Time) bool {
+	ttl := JoinRequestTTL
+	if secret.Labels[JoinRequestStateLabel] == JoinRequestApproved {
+		ttl *= 2
+	}
+	return now.Sub(secret.CreationTimestamp.Time) > ttl
+}
+
+// ParseNodeCSR parses the given PEM encoded certificate signing request and
+// checks that it requests a client certificate for the given node.
+func ParseNodeCSR(csrPEM []byte, nodeName string) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode(csrPEM)
+	if block == nil || block.Type != certutil.CertificateRequestBlockType {
+		return nil, errors.New("no PEM encoded certificate signing request found")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case nodeName == "":
+		return nil, errors.New("node name is empty")
+	case csr.Subject.CommonName != "system:node:"+nodeName:
+		return nil, fmt.Errorf("common name needs to be system:node:%s", nodeName)
+	case !slices.Equal(csr.Subject.Organization, []string{"system:nodes"}):
+		return nil, errors.New("organization needs to be system:nodes")
+	case len(csr.DNSNames) > 0 || len(csr.IPAddresses) > 0 || len(csr.EmailAddresses) > 0 || len(csr.URIs) > 0:
+		return nil, errors.New("subject alternative names aren't allowed")
+	}
+
+	return csr, nil
+}
+
+func signNodeClientCertificate(csr *x509.CertificateRequest, caCertPEM, caKeyPEM []byte, now time.Time) ([]byte, error) {
+	caCerts, err := certutil.ParseCertsPEM(caCertPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+	caKey, err := keyutil.ParsePrivateKeyPEM(caKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA key: %w", err)
+	}
+	signer, ok := caKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("CA key can't be used for signing")
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: csr.Subject.CommonName, Organization: csr.Subject.Organization},
+		NotBefore:    now.Add(-5 * time.Minute),
+		NotAfter:     now.Add(JoinRequestTTL),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, caCerts[0], csr.PublicKey, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: certutil.CertificateBlockType, Bytes: der}), nil
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+func TestJoinRequests(t *testing.T) {
+	client := fake.NewClientset()
+	// The fake client doesn't set creation timestamps, which are required to
+	// expire join requests.
+	client.PrependReactor("create", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		secret := action.(k8stesting.CreateAction).GetObject().(*corev1.Secret)
+		secret.CreationTimestamp = metav1.Now()
+		return false, nil, nil
+	})
+	manager, err := NewManagerForClient(client)
+	require.NoError(t, err)
+
+	caCert, caKey := generateTestCA(t)
+	csr := generateNodeCSR(t, "system:node:worker-0", []string{"system:nodes"})
+
+	id, err := manager.SubmitJoinRequest(t.Context(), "worker-0", csr, "192.0.2.1", 2, 1)
+	require.NoError(t, err)
+	assert.Len(t, id, 32)
+
+	t.Run("max_pending_per_source", func(t *testing.T) {
+		_, err := manager.SubmitJoinRequest(t.Context(), "worker-0", csr, "192.0.2.1", 2, 1)
+		assert.ErrorIs(t, err, ErrTooManyPendingJoinRequests)
+		assert.ErrorContains(t, err, "from 192.0.2.1")
+	})
+
+	otherID, err := manager.SubmitJoinRequest(t.Context(), "worker-0", csr, "192.0.2.2", 2, 1)
+	require.NoError(t, err)
+
+	t.Run("max_pending", func(t *testing.T) {
+		_, err := manager.SubmitJoinRequest(t.Context(), "worker-0", csr, "192.0.2.3", 2, 1)
+		assert.ErrorIs(t, err, ErrTooManyPendingJoinRequests)
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		secret, err := manager.GetJoinRequestSecret(t.Context(), id)
+		require.NoError(t, err)
+		_, err = manager.IssueJoinRequestCertificate(t.Context(), secret, caCert, caKey)
+		assert.ErrorContains(t, err, "join request is Pending")
+	})
+
+	t.Run("approve", func(t *testing.T) {
+		require.NoError(t, manager.ApproveJoinRequest(t.Context(), id))
+		assert.ErrorContains(t, manager.DenyJoinRequest(t.Context(), id), "already been decided")
+
+		secret, err := manager.GetJoinRequestSecret(t.Context(), id)
+		require.NoError(t, err)
+		certPEM, err := manager.IssueJoinRequestCertificate(t.Context(), secret, caCert, caKey)
+		require.NoError(t, err)
+
+		certs, err := certutil.ParseCertsPEM(certPEM)
+		require.NoError(t, err)
+		assert.Equal(t, "system:node:worker-0", certs[0].Subject.CommonName)
+		assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, certs[0].ExtKeyUsage)
+
+		// The certificate is stored, so it's returned again for repeated requests.
+		secret, err = manager.GetJoinRequestSecret(t.Context(), id)
+		require.NoError(t, err)
+		again, err := manager.IssueJoinRequestCertificate(t.Context(), secret, caCert, caKey)
+		require.NoError(t, err)
+		assert.Equal(t, certPEM, again)
+	})
+
+	t.Run("deny", func(t *testing.T) {
+		require.NoError(t, manager.DenyJoinRequest(t.Context(), otherID))
+		secret, err := manager.GetJoinRequestSecret(t.Context(), otherID)
+		require.NoError(t, err)
+		_, err = manager.IssueJoinRequestCertificate(t.Context(), secret, caCert, caKey)
+		assert.ErrorContains(t, err, "join request is Denied")
+	})
+
+	t.Run("list", func(t *testing.T) {
+		requests, err := manager.ListJoinRequests(t.Context())
+		require.NoError(t, err)
+		states := map[string]string{}
+		for _, r := range requests {
+			states[r.ID] = r.State
+			assert.Equal(t, "worker-0", r.NodeName)
+			assert.Equal(t, currentUser(), r.DecidedBy)
+		}
+		assert.Equal(t, map[string]string{id: JoinRequestApproved, otherID: JoinRequestDenied}, states)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		assert.ErrorContains(t, manager.ApproveJoinRequest(t.Context(), "nope00"), "not found")
+	})
+}
+
+func TestParseNodeCSR(t *testing.T) {
+	_, err := ParseNodeCSR(generateNodeCSR(t, "system:node:worker-0", []string{"system:nodes"}), "worker-0")
+	assert.NoError(t, err)
+
+	_, err = ParseNodeCSR(generateNodeCSR(t, "system:node:worker-1", []string{"system:nodes"}), "worker-0")
+	assert.ErrorContains(t, err, "common name")
+
+	_, err = ParseNodeCSR(generateNodeCSR(t, "system:node:worker-0", []string{"system:masters"}), "worker-0")
+	assert.ErrorContains(t, err, "organization")
+
+	_, err = ParseNodeCSR([]byte("garbage"), "worker-0")
+	assert.ErrorContains(t, err, "no PEM encoded certificate signing request found")
+}
+
+func generateTestCA(t *testing.T) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	cert, err := certutil.NewSelfSignedCACert(certutil.Config{CommonName: "kubernetes-ca"}, key)
+	require.NoError(t, err)
+	keyPEM, err = keyutil.MarshalPrivateKeyToPEM(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: certutil.CertificateBlockType, Bytes: cert.Raw}), keyPEM
+}
+
+func generateNodeCSR(t *testing.T, commonName string, organization []string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: commonName, Organization: organization},
+	}, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: certutil.CertificateRequestBlockType, Bytes: der})
+}
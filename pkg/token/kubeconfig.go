@@ -30,7 +30,17 @@ const (
 )
 
 // CreateKubeletBootstrapToken creates a new k0s bootstrap token.
-func CreateKubeletBootstrapToken(ctx context.Context, api *v1beta1.APISpec, k0sVars *config.CfgVars, role string, expiry time.Duration) (string, error) {
+func CreateKubeletBootstrapToken(ctx context.Context, api *v1beta1.APISpec, k0sVars *config.CfgVars, role string, expiry time.Duration, oneTime bool) (string, error) {
+	manager, err := NewManager(k0sVars.AdminKubeConfigPath)
+	if err != nil {
+		return "", err
+	}
+	return manager.CreateJoinToken(ctx, api, k0sVars, role, expiry, oneTime, currentUser())
+}
+
+// CreateJoinToken creates a new bootstrap token on behalf of the given creator
+// and returns it in the form of a join token.
+func (m *Manager) CreateJoinToken(ctx context.Context, api *v1beta1.APISpec, k0sVars *config.CfgVars, role string, expiry time.Duration, oneTime bool, createdBy string) (string, error) {
 	userName, joinURL, err := loadUserAndJoinURL(api, role)
 	if err != nil {
 		return "", err
@@ -41,7 +51,7 @@ func CreateKubeletBootstrapToken(ctx context.Context, api *v1beta1.APISpec, k0sV
 		return "", err
 	}
 
-	token, err := loadToken(ctx, k0sVars, role, expiry)
+	token, err := m.CreateFor(ctx, expiry, role, oneTime, createdBy)
 	if err != nil {
 		return "", err
 	}
@@ -94,10 +104,10 @@ func loadCACert(k0sVars *config.CfgVars) ([]byte, error) {
 	return caCert, nil
 }
 
-func loadToken(ctx context.Context, k0sVars *config.CfgVars, role string, expiry time.Duration) (*bootstraptokenv1.BootstrapTokenString, error) {
+func loadToken(ctx context.Context, k0sVars *config.CfgVars, role string, expiry time.Duration, oneTime bool) (*bootstraptokenv1.BootstrapTokenString, error) {
 	manager, err := NewManager(k0sVars.AdminKubeConfigPath)
 	if err != nil {
 		return nil, err
 	}
-	return manager.Create(ctx, expiry, role)
+	return manager.Create(ctx, expiry, role, oneTime)
 }
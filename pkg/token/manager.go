@@ -6,8 +6,12 @@ package token
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"os/user"
 	"slices"
+	"strings"
 	"time"
 
 	k8sutil "github.com/k0sproject/k0s/pkg/kubernetes"
@@ -16,21 +20,66 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
 	tokenutil "k8s.io/cluster-bootstrap/token/util"
 	bootstraptokenv1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/bootstraptoken/v1"
 
 	"github.com/sirupsen/logrus"
 )
 
+// OneTimeLabel marks the bootstrap token secrets of one-time join tokens,
+// which are invalidated after the first successful join.
+const OneTimeLabel = "k0s.k0sproject.io/one-time-join-token"
+
+// RotatedWorkerTokenSecretName is the name of the secret in the kube-system
+// namespace in which the current automatically rotated worker join token is
+// published.
+const RotatedWorkerTokenSecretName = "k0s-worker-join-token"
+
+// The extra groups that join tokens authenticate as, in addition to the
+// system:bootstrappers group, so that the identities of worker and controller
+// tokens can be told apart.
+const (
+	WorkerTokenGroup     = "system:bootstrappers:k0s-worker"
+	ControllerTokenGroup = "system:bootstrappers:k0s-controller"
+)
+
+// Annotations on the bootstrap token secrets of join tokens that keep track of
+// who created them, and when and by whom they have been used last.
+const (
+	CreatedByAnnotation  = "k0s.k0sproject.io/join-token-created-by"
+	LastUsedAnnotation   = "k0s.k0sproject.io/join-token-last-used"
+	LastUsedByAnnotation = "k0s.k0sproject.io/join-token-last-used-by"
+)
+
+// WorkerGroupMigratedAnnotation marks the bootstrap token secrets of worker
+// tokens created by earlier k0s versions to which the [WorkerTokenGroup] has
+// been added, so that they're migrated only once.
+const WorkerGroupMigratedAnnotation = "k0s.k0sproject.io/join-token-worker-group-migrated"
+
+// The descriptions of the bootstrap tokens that k0s creates. Earlier k0s
+// versions didn't annotate the tokens they created, so this is how they're
+// recognized.
+const (
+	workerTokenDescription     = "Worker bootstrap token generated by k0s"
+	controllerTokenDescription = "Controller bootstrap token generated by k0s"
+)
+
 type Token struct {
-	ID     string
-	Role   string
-	Expiry string
+	ID         string
+	Role       string
+	Created    string
+	CreatedBy  string
+	Expiry     string
+	LastUsed   string
+	LastUsedBy string
 }
 
 func (t Token) ToArray() []string {
-	return []string{t.ID, t.Role, t.Expiry}
+	return []string{t.ID, t.Role, t.Created, t.CreatedBy, t.Expiry, t.LastUsed, t.LastUsedBy}
 }
 
 // NewManager creates a new token manager using given kubeconfig
@@ -72,12 +121,14 @@ func RandomBootstrapSecret(role string, ttl time.Duration) (*corev1.Secret, *boo
 
 	switch role {
 	case RoleWorker:
-		token.Description = "Worker bootstrap token generated by k0s"
+		token.Description = workerTokenDescription
 		token.Usages = append(token.Usages, "authentication")
+		token.Groups = append(token.Groups, WorkerTokenGroup)
 	case RoleController:
-		token.Description = "Controller bootstrap token generated by k0s"
+		token.Description = controllerTokenDescription
 		token.Usages = append(token.Usages, "controller-join")
 		legacyUsages = append(legacyUsages, "controller-join")
+		token.Groups = append(token.Groups, ControllerTokenGroup)
 	default:
 		return nil, nil, fmt.Errorf("unsupported role %q", role)
 	}
@@ -91,12 +142,23 @@ func RandomBootstrapSecret(role string, ttl time.Duration) (*corev1.Secret, *boo
 	return secret, token.Token, nil
 }
 
-// Create creates a new bootstrap token
-func (m *Manager) Create(ctx context.Context, valid time.Duration, role string) (*bootstraptokenv1.BootstrapTokenString, error) {
+// Create creates a new bootstrap token. One-time tokens are invalidated after
+// the first successful join.
+func (m *Manager) Create(ctx context.Context, valid time.Duration, role string, oneTime bool) (*bootstraptokenv1.BootstrapTokenString, error) {
+	return m.CreateFor(ctx, valid, role, oneTime, currentUser())
+}
+
+// CreateFor creates a new bootstrap token, just like [Manager.Create], on
+// behalf of the given creator.
+func (m *Manager) CreateFor(ctx context.Context, valid time.Duration, role string, oneTime bool, createdBy string) (*bootstraptokenv1.BootstrapTokenString, error) {
 	secret, token, err := RandomBootstrapSecret(role, valid)
 	if err != nil {
 		return nil, err
 	}
+	if oneTime {
+		secret.Labels = map[string]string{OneTimeLabel: "true"}
+	}
+	secret.Annotations = map[string]string{CreatedByAnnotation: createdBy}
 
 	_, err = m.client.CoreV1().Secrets(metav1.NamespaceSystem).Create(ctx, secret, metav1.CreateOptions{})
 	if err != nil {
@@ -121,15 +183,17 @@ func (m *Manager) List(ctx context.Context) (tokens []Token, _ error) {
 			continue // ignore invalid tokens
 		}
 
-		token := Token{ID: parsed.Token.ID}
+		token := Token{
+			ID:         parsed.Token.ID,
+			Role:       RoleOf(&secret),
+			Created:    secret.CreationTimestamp.UTC().Format(time.RFC3339),
+			CreatedBy:  secret.Annotations[CreatedByAnnotation],
+			LastUsed:   secret.Annotations[LastUsedAnnotation],
+			LastUsedBy: secret.Annotations[LastUsedByAnnotation],
+		}
 
-		if slices.Contains(parsed.Usages, "controller-join") {
-			token.Role = "controller"
-		} else if bytes.Equal(secret.Data["usage-controller-join"], []byte("true")) {
-			// Legacy form of token usage
-			token.Role = "controller"
-		} else if slices.Contains(parsed.Usages, "authentication") {
-			token.Role = "worker"
+		if token.Role == "" {
+			token.Role = "unknown"
 		}
 
 		if parsed.Expires != nil {
@@ -142,6 +206,148 @@ func (m *Manager) List(ctx context.Context) (tokens []Token, _ error) {
 	return tokens, nil
 }
 
+// RoleOf returns the role of the join token in the given bootstrap token
+// secret. Worker tokens may only be used to authenticate against the
+// Kubernetes API, controller tokens only to join via the k0s join API. Tokens
+// that would be usable for both roles, or for neither of them, have no role,
+// and an empty string is returned for them.
+func RoleOf(secret *corev1.Secret) string {
+	isSet := func(key string) bool {
+		return bytes.Equal(secret.Data[key], []byte("true"))
+	}
+
+	worker := isSet(bootstrapapi.BootstrapTokenUsageAuthentication)
+	controller := isSet(bootstrapapi.BootstrapTokenUsagePrefix+"controller-join") ||
+		isSet("usage-controller-join") // legacy form of token usage
+	groups := strings.Split(string(secret.Data[bootstrapapi.BootstrapTokenExtraGroupsKey]), ",")
+
+	switch {
+	case worker && !controller && !slices.Contains(groups, ControllerTokenGroup):
+		return RoleWorker
+	case controller && !worker && !slices.Contains(groups, WorkerTokenGroup):
+		return RoleController
+	default:
+		return ""
+	}
+}
+
+// IsOneTime returns whether the given bootstrap token secret belongs to a
+// one-time join token.
+func IsOneTime(secret *corev1.Secret) bool {
+	return secret.Labels[OneTimeLabel] == "true"
+}
+
+// ConsumeOneTime atomically consumes the one-time token of the given bootstrap
+// token secret by deleting it, unless the secret has been changed or deleted
+// since it has been read. Returns whether the token has been consumed.
+func ConsumeOneTime(ctx context.Context, secrets clientcorev1.SecretInterface, secret *corev1.Secret) (bool, error) {
+	err := secrets.Delete(ctx, secret.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &secret.UID, ResourceVersion: &secret.ResourceVersion},
+	})
+	switch {
+	case err == nil:
+		return true, nil
+	case apierrors.IsNotFound(err), apierrors.IsConflict(err):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// RestoreOneTime recreates the given bootstrap token secret of a consumed
+// one-time token, so that the token may be used again, e.g. after a failed
+// join.
+func RestoreOneTime(ctx context.Context, secrets clientcorev1.SecretInterface, secret *corev1.Secret) error {
+	restored := secret.DeepCopy()
+	restored.ObjectMeta = metav1.ObjectMeta{
+		Name:        secret.Name,
+		Namespace:   secret.Namespace,
+		Labels:      secret.Labels,
+		Annotations: secret.Annotations,
+	}
+	_, err := secrets.Create(ctx, restored, metav1.CreateOptions{})
+	return err
+}
+
+// RecordUsage records on the bootstrap token secret with the given name that
+// the token has been used by the given node at the given time.
+func RecordUsage(ctx context.Context, secrets clientcorev1.SecretInterface, secretName, usedBy string, usedAt time.Time) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{
+				LastUsedAnnotation:   usedAt.UTC().Format(time.RFC3339),
+				LastUsedByAnnotation: usedBy,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = secrets.Patch(ctx, secretName, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// MigrateWorkerToken adds the [WorkerTokenGroup] to the given bootstrap token
+// secret of a worker token that has been created by an earlier k0s version,
+// which wouldn't be authorized to join otherwise. Tokens that k0s didn't create
+// are left alone, and each token is migrated only once. Returns whether the
+// token has been migrated.
+func MigrateWorkerToken(ctx context.Context, secrets clientcorev1.SecretInterface, secret *corev1.Secret) (bool, error) {
+	_, annotated := secret.Annotations[CreatedByAnnotation]
+	createdByK0s := annotated || string(secret.Data[bootstrapapi.BootstrapTokenDescriptionKey]) == workerTokenDescription
+	if !createdByK0s || RoleOf(secret) != RoleWorker {
+		return false, nil
+	}
+	if _, migrated := secret.Annotations[WorkerGroupMigratedAnnotation]; migrated {
+		return false, nil
+	}
+
+	var groups []string
+	if extraGroups := string(secret.Data[bootstrapapi.BootstrapTokenExtraGroupsKey]); extraGroups != "" {
+		groups = strings.Split(extraGroups, ",")
+	}
+	if slices.Contains(groups, WorkerTokenGroup) {
+		return false, nil
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"resourceVersion": secret.ResourceVersion,
+			"annotations":     map[string]string{WorkerGroupMigratedAnnotation: "true"},
+		},
+		"data": map[string][]byte{
+			bootstrapapi.BootstrapTokenExtraGroupsKey: []byte(strings.Join(append(groups, WorkerTokenGroup), ",")),
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	_, err = secrets.Patch(ctx, secret.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	switch {
+	case err == nil:
+		return true, nil
+	case apierrors.IsNotFound(err), apierrors.IsConflict(err):
+		// The token has been deleted or changed in the meantime.
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// Returns the user that's running this process, in the form of user@host.
+func currentUser() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		name += "@" + hostname
+	}
+	return name
+}
+
 func (m *Manager) Remove(ctx context.Context, tokenID string) error {
 	err := m.client.CoreV1().Secrets(metav1.NamespaceSystem).Delete(ctx, tokenutil.BootstrapTokenSecretName(tokenID), metav1.DeleteOptions{})
 	if apierrors.IsNotFound(err) {
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleOf(t *testing.T) {
+	for _, role := range []string{RoleWorker, RoleController} {
+		t.Run(role, func(t *testing.T) {
+			secret, _, err := RandomBootstrapSecret(role, time.Hour)
+			require.NoError(t, err)
+			assert.Equal(t, role, RoleOf(secret))
+		})
+	}
+
+	t.Run("legacy_controller", func(t *testing.T) {
+		secret, _, err := RandomBootstrapSecret(RoleController, time.Hour)
+		require.NoError(t, err)
+		delete(secret.Data, "usage-bootstrap-controller-join")
+		delete(secret.Data, "auth-extra-groups")
+		assert.Equal(t, RoleController, RoleOf(secret))
+	})
+
+	t.Run("both_usages", func(t *testing.T) {
+		secret, _, err := RandomBootstrapSecret(RoleController, time.Hour)
+		require.NoError(t, err)
+		secret.Data["usage-bootstrap-authentication"] = []byte("true")
+		assert.Empty(t, RoleOf(secret), "a controller token must not be usable by workers")
+	})
+
+	t.Run("foreign_group", func(t *testing.T) {
+		secret, _, err := RandomBootstrapSecret(RoleWorker, time.Hour)
+		require.NoError(t, err)
+		secret.Data["auth-extra-groups"] = []byte(ControllerTokenGroup)
+		assert.Empty(t, RoleOf(secret))
+	})
+
+	t.Run("no_usages", func(t *testing.T) {
+		secret, _, err := RandomBootstrapSecret(RoleWorker, time.Hour)
+		require.NoError(t, err)
+		delete(secret.Data, "usage-bootstrap-authentication")
+		assert.Empty(t, RoleOf(secret))
+	})
+}
+
+func TestConsumeOneTime(t *testing.T) {
+	secrets := fake.NewClientset().CoreV1().Secrets(metav1.NamespaceSystem)
+	secret, _, err := RandomBootstrapSecret(RoleController, time.Hour)
+	require.NoError(t, err)
+	secret.Labels = map[string]string{OneTimeLabel: "true"}
+	secret, err = secrets.Create(t.Context(), secret, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	consumed, err := ConsumeOneTime(t.Context(), secrets, secret)
+	require.NoError(t, err)
+	assert.True(t, consumed)
+
+	consumed, err = ConsumeOneTime(t.Context(), secrets, secret)
+	require.NoError(t, err)
+	assert.False(t, consumed, "the token must not be consumed twice")
+
+	require.NoError(t, RestoreOneTime(t.Context(), secrets, secret))
+	restored, err := secrets.Get(t.Context(), secret.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, IsOneTime(restored))
+	assert.Equal(t, secret.Data, restored.Data)
+}